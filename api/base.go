@@ -1,66 +1,127 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"math/big"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strings"
-	"time"
 
+	"github.com/chinmay1088/odyssey/config"
 	"github.com/shopspring/decimal"
 )
 
 // Client handles API calls to external services
 type Client struct {
-	httpClient *http.Client
-	network    string
+	httpClient  *http.Client
+	network     string
+	net         *config.Network     // nodes and explorers of the active network
+	ethereumRPC string              // node of another EVM chain, see ForEVMChain
+	endpoints   map[string][]string // configured nodes tried for a built-in node
+	timeouts    *config.Config      // request timeouts, see requestTimeout
 }
 
 // NewClient creates a new API client
 func NewClient() *Client {
 	// Determine the current network
-	network := NetworkMainnet // Default to mainnet
-
-	homeDir, err := os.UserHomeDir()
-	if err == nil {
-		networkPath := filepath.Join(homeDir, ".odyssey", "network.txt")
-
-		// Read network file if it exists
-		if _, err := os.Stat(networkPath); err == nil {
-			data, err := os.ReadFile(networkPath)
-			if err == nil {
-				network = strings.TrimSpace(string(data))
-				// Validate network
-				if network != NetworkMainnet && network != NetworkTestnet {
-					network = NetworkMainnet // Default to mainnet if invalid
-				}
+	net := config.ActiveNetwork()
+
+	// Requests time out per chain and operation, see do
+	httpClient := &http.Client{}
+
+	// In offline mode every request fails before it leaves the machine
+	if offline {
+		httpClient.Transport = offlineTransport{}
+	} else {
+		attempts, backoff := config.DefaultRetryAttempts, config.DefaultRetryBackoff
+		if cfg, err := config.Load(); err == nil {
+			attempts, backoff = cfg.RetryAttempts(), cfg.RetryBackoff()
+		}
+		httpClient.Transport = retryTransport{
+			next:     rateLimitTransport{next: logTransport{next: http.DefaultTransport}},
+			attempts: attempts,
+			backoff:  backoff,
+		}
+
+		// Repeated commands reuse fresh balance, history and price responses
+		if cacheEnabled {
+			if dir, err := cacheDir(); err == nil {
+				httpClient.Transport = cacheTransport{next: httpClient.Transport, dir: dir}
 			}
 		}
 	}
 
+	var timeouts *config.Config
+	if cfg, err := config.Load(); err == nil {
+		timeouts = cfg
+	}
+
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		network: network,
+		httpClient: httpClient,
+		network:    net.Keys,
+		net:        net,
+		endpoints:  loadRPCEndpoints(),
+		timeouts:   timeouts,
 	}
 }
 
+// offline disables all network access of clients created afterwards
+var offline bool
+
+// ErrOffline is returned for every request made in offline mode
+var ErrOffline = errors.New("network access is disabled in offline mode")
+
+// SetOffline enables or disables offline mode
+func SetOffline(enabled bool) {
+	offline = enabled
+}
+
+// IsOffline returns true if network access is disabled
+func IsOffline() bool {
+	return offline
+}
+
+// offlineTransport rejects every request
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("%w: refusing request to %s", ErrOffline, req.URL.Host)
+}
+
 // IsTestnet returns true if the client is using testnet
 func (c *Client) IsTestnet() bool {
 	return c.network == NetworkTestnet
 }
 
+// Network returns the network the client was created for
+func (c *Client) Network() *config.Network {
+	return c.net
+}
+
+// EthereumChainID returns the chain ID of the Ethereum node of the network,
+// 0 when the network leaves it out and the node can't be asked
+func (c *Client) EthereumChainID() int64 {
+	id, _ := c.net.ResolveEthereumChainID()
+	return id
+}
+
+// ErrNoFiat is returned for price lookups on networks without prices
+var ErrNoFiat = errors.New("prices are disabled on this network")
+
 // GetPrice fetches current price for a cryptocurrency
-func (c *Client) GetPrice(symbol string) (*PriceData, error) {
+func (c *Client) GetPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	if c.net.NoFiat {
+		return nil, ErrNoFiat
+	}
+
 	// Use CoinGecko API
 	url := fmt.Sprintf("https://api.coingecko.com/api/v3/simple/price?ids=%s&vs_currencies=usd", symbol)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch price: %w", err)
 	}
@@ -89,31 +150,93 @@ func (c *Client) GetPrice(symbol string) (*PriceData, error) {
 	return nil, fmt.Errorf("price not found for symbol: %s", symbol)
 }
 
+// GetPrices fetches current prices and 24h changes for several cryptocurrencies in one call
+func (c *Client) GetPrices(ctx context.Context, symbols []string) (map[string]*PriceData, error) {
+	if c.net.NoFiat {
+		return nil, ErrNoFiat
+	}
+
+	url := fmt.Sprintf("https://api.coingecko.com/api/v3/simple/price?ids=%s&vs_currencies=usd&include_24hr_change=true", strings.Join(symbols, ","))
+
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch prices: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result map[string]map[string]float64
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	prices := make(map[string]*PriceData, len(result))
+	for symbol, priceData := range result {
+		usdPrice, exists := priceData["usd"]
+		if !exists {
+			continue
+		}
+		prices[symbol] = &PriceData{
+			Symbol:    symbol,
+			Price:     decimal.NewFromFloat(usdPrice),
+			USD:       decimal.NewFromFloat(usdPrice),
+			Change24h: decimal.NewFromFloat(priceData["usd_24h_change"]),
+		}
+	}
+
+	return prices, nil
+}
+
 
 
 // Helper to convert Wei to Ether
-func weiToEth(wei *big.Int) float64 {
+func weiToEth(wei *big.Int) decimal.Decimal {
 	if wei == nil {
-		return 0
+		return decimal.Zero
 	}
 
 	// Convert wei to ether (1 ETH = 10^18 Wei)
-	ether := new(big.Float).SetInt(wei)
-	ether.Quo(ether, big.NewFloat(1e18))
-
-	// Convert to float64 for display
-	result, _ := ether.Float64()
-	return result
+	return decimal.NewFromBigInt(wei, -18)
 }
 
-// postJSON sends a POST request with JSON payload
-func (c *Client) postJSON(url string, payload interface{}) ([]byte, error) {
+// postJSON sends a POST request with JSON payload. Requests to a node with
+// other nodes configured for its chain fail over to them.
+func (c *Client) postJSON(ctx context.Context, url string, payload interface{}) ([]byte, error) {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	resp, err := c.httpClient.Post(url, "application/json", strings.NewReader(string(jsonData)))
+	endpoints := c.rpcEndpoints(url)
+	if len(endpoints) == 1 {
+		return c.post(ctx, url, jsonData)
+	}
+
+	for _, endpoint := range endpoints {
+		body, postErr := c.post(ctx, endpoint, jsonData)
+		if postErr == nil {
+			rpcSucceeded(endpoint)
+			return body, nil
+		}
+		// Answers of a working node, e.g. a rejected transaction, are final.
+		// A cancelled request says nothing about the node.
+		if !IsTransientError(postErr) || ctx.Err() != nil {
+			return nil, postErr
+		}
+		slog.Info("RPC node failed, trying the next one", "node", endpoint, "error", postErr)
+		rpcFailed(endpoint)
+		err = postErr
+	}
+	return nil, err
+}
+
+// post sends JSON data to one endpoint
+func (c *Client) post(ctx context.Context, url string, jsonData []byte) ([]byte, error) {
+	resp, err := c.postBody(ctx, url, "application/json", strings.NewReader(string(jsonData)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -124,9 +247,32 @@ func (c *Client) postJSON(url string, payload interface{}) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if err := rpcRateLimitError(url, body); err != nil {
+		return nil, err
 	}
 
 	return body, nil
 }
+
+// get sends a GET request that is abandoned once ctx is done
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// postBody sends a POST request that is abandoned once ctx is done
+func (c *Client) postBody(ctx context.Context, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return c.do(req)
+}
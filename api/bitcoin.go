@@ -1,71 +1,145 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/shopspring/decimal"
 )
 
-// GetBitcoinRPC returns the Bitcoin RPC URL (mainnet only)
+// GetBitcoinRPC returns the Bitcoin RPC URL of mainnet
 func (c *Client) GetBitcoinRPC() string {
 	return MainnetBitcoinRPC
 }
 
-// GetBitcoinBalance fetches Bitcoin balance
-func (c *Client) GetBitcoinBalance(address string) (float64, error) {
-	// Bitcoin only supported in mainnet
-	if c.IsTestnet() {
-		return 0, fmt.Errorf("bitcoin is not supported in testnet mode")
+// GetBitcoinBalance fetches the Bitcoin balance of an address in satoshis
+func (c *Client) GetBitcoinBalance(ctx context.Context, address string) (int64, error) {
+	balances, err := c.GetBitcoinBalances(ctx, []string{address})
+	if err != nil {
+		return 0, err
+	}
+	return balances[address], nil
+}
+
+// GetBitcoinBalances fetches the balances of several Bitcoin addresses in one
+// request, in satoshis keyed by address
+func (c *Client) GetBitcoinBalances(ctx context.Context, addresses []string) (map[string]int64, error) {
+	if !c.net.HasBitcoin() {
+		return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+
+	if node, err := configuredBitcoinNode(); err != nil || node != nil {
+		if err != nil {
+			return nil, err
+		}
+		balances, err := c.bitcoinNodeBalances(ctx, node, addresses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch balances: %w", err)
+		}
+		return balances, nil
+	}
+
+	provider, err := c.BalanceProvider("bitcoin")
+	if err != nil {
+		return nil, err
+	}
+	satoshis, err := providerBalances(ctx, provider, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]int64, len(addresses))
+	for address, balance := range satoshis {
+		balances[address] = balance.Int64()
+	}
+	return balances, nil
+}
+
+// GetBitcoinTxCounts fetches the number of transactions of several Bitcoin
+// addresses in one request, keyed by address. A node only knows unspent
+// outputs, addresses spent empty count as unused.
+func (c *Client) GetBitcoinTxCounts(ctx context.Context, addresses []string) (map[string]int, error) {
+	if !c.net.HasBitcoin() {
+		return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+
+	if node, err := configuredBitcoinNode(); err != nil || node != nil {
+		if err != nil {
+			return nil, err
+		}
+		scans, err := c.scanBitcoinAddresses(ctx, node, addresses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch transaction counts: %w", err)
+		}
+		counts := make(map[string]int, len(addresses))
+		for _, address := range addresses {
+			counts[address] = len(scans[address])
+		}
+		return counts, nil
 	}
 
-	// Use blockchain.info API
-	url := fmt.Sprintf("%s/balance?active=%s", c.GetBitcoinRPC(), address)
+	url := fmt.Sprintf("%s/balance?active=%s", c.GetBitcoinRPC(), strings.Join(addresses, "|"))
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch balance: %w", err)
+		return nil, fmt.Errorf("failed to fetch transaction counts: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Blockchain.info returns address as key in JSON object
 	var result map[string]struct {
-		FinalBalance int64 `json:"final_balance"`
+		TxCount int `json:"n_tx"`
 	}
-
 	if err := json.Unmarshal(body, &result); err != nil {
-		return 0, fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	// Extract address data
-	addrData, exists := result[address]
-	if !exists {
-		return 0, fmt.Errorf("address data not found in response")
+	counts := make(map[string]int, len(addresses))
+	for _, address := range addresses {
+		addrData, exists := result[address]
+		if !exists {
+			return nil, fmt.Errorf("address data not found in response")
+		}
+		counts[address] = addrData.TxCount
 	}
 
-	// Convert balance from satoshis to BTC
-	return float64(addrData.FinalBalance) / 100000000.0, nil
+	return counts, nil
 }
 
 // GetBitcoinUTXOs fetches Bitcoin UTXOs
-func (c *Client) GetBitcoinUTXOs(address string) ([]BitcoinUTXO, error) {
-	// Bitcoin only supported in mainnet
-	if c.IsTestnet() {
+func (c *Client) GetBitcoinUTXOs(ctx context.Context, address string) ([]BitcoinUTXO, error) {
+	if !c.net.HasBitcoin() {
 		return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
 	}
 
+	if node, err := configuredBitcoinNode(); err != nil || node != nil {
+		if err != nil {
+			return nil, err
+		}
+		scans, err := c.scanBitcoinAddresses(ctx, node, []string{address})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch UTXOs: %w", err)
+		}
+		return scans[address], nil
+	}
+
 	// Use Blockchair API
 	url := fmt.Sprintf("https://api.blockchair.com/bitcoin/outputs?q=recipient(%s),is_spent(false)", address)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch UTXOs: %w", err)
 	}
@@ -94,7 +168,7 @@ func (c *Client) GetBitcoinUTXOs(address string) ([]BitcoinUTXO, error) {
 	// Convert to our UTXO format
 	var utxos []BitcoinUTXO
 	for _, item := range result.Data.Items {
-		value, err := strconv.ParseFloat(item.Value, 64)
+		value, err := strconv.ParseInt(item.Value, 10, 64)
 		if err != nil {
 			continue // Skip this UTXO if value can't be parsed
 		}
@@ -102,7 +176,7 @@ func (c *Client) GetBitcoinUTXOs(address string) ([]BitcoinUTXO, error) {
 		utxo := BitcoinUTXO{
 			TxID:   item.TransactionHash,
 			Vout:   item.Index,
-			Value:  value / 100000000.0, // Convert from satoshis to BTC
+			Value:  value,
 			Script: item.ScriptHex,
 		}
 		utxos = append(utxos, utxo)
@@ -112,44 +186,263 @@ func (c *Client) GetBitcoinUTXOs(address string) ([]BitcoinUTXO, error) {
 }
 
 // SendBitcoinTransaction sends a Bitcoin transaction
-func (c *Client) SendBitcoinTransaction(signedTx string) (string, error) {
-	// Bitcoin only supported in mainnet
-	if c.IsTestnet() {
+func (c *Client) SendBitcoinTransaction(ctx context.Context, signedTx string) (string, error) {
+	if !c.net.HasBitcoin() {
 		return "", fmt.Errorf("bitcoin is not supported in testnet mode")
 	}
 
-	// Use mempool.space API
-	url := "https://mempool.space/api/tx"
+	if node, err := configuredBitcoinNode(); err != nil || node != nil {
+		if err != nil {
+			return "", err
+		}
+		var txid string
+		if err := c.bitcoindCall(ctx, node, "sendrawtransaction", []interface{}{signedTx}, &txid); err != nil {
+			return "", fmt.Errorf("transaction failed: %w", err)
+		}
+		forgetBitcoinScans()
+		return txid, nil
+	}
+
+	provider, err := c.BroadcastProvider("bitcoin")
+	if err != nil {
+		return "", err
+	}
+	return provider.Broadcast(ctx, signedTx)
+}
+
+// BitcoinTransactionKnown returns true if a transaction is in the mempool or
+// already confirmed
+func (c *Client) BitcoinTransactionKnown(ctx context.Context, txid string) (bool, error) {
+	if c.net.IsRegtest() {
+		confirmations, err := c.regtestConfirmations(ctx, txid)
+		return confirmations >= 0, err
+	}
+
+	resp, err := c.get(ctx, fmt.Sprintf("https://mempool.space/api/tx/%s/status", txid))
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch transaction status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		return true, nil
+	case 404:
+		return false, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return false, &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+}
 
-	resp, err := c.httpClient.Post(url, "text/plain", strings.NewReader(signedTx))
+// BitcoinTransactionConfirmed returns true if a transaction is in a block
+func (c *Client) BitcoinTransactionConfirmed(ctx context.Context, txid string) (bool, error) {
+	if c.net.IsRegtest() {
+		confirmations, err := c.regtestConfirmations(ctx, txid)
+		return confirmations > 0, err
+	}
+
+	resp, err := c.get(ctx, fmt.Sprintf("https://mempool.space/api/tx/%s/status", txid))
 	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
+		return false, fmt.Errorf("failed to fetch transaction status: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case 200:
+	case 404:
+		return false, nil
+	default:
+		return false, &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("transaction failed: %s", string(body))
+	var status struct {
+		Confirmed bool `json:"confirmed"`
 	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return false, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return status.Confirmed, nil
+}
 
-	return string(body), nil
+// regtestConfirmations asks the regtest node for the confirmations of a
+// transaction, -1 if it doesn't know it. Confirmed transactions are only
+// found by nodes started with -txindex.
+func (c *Client) regtestConfirmations(ctx context.Context, txid string) (int, error) {
+	node, err := configuredBitcoinNode()
+	if err != nil {
+		return 0, err
+	}
+
+	var tx struct {
+		Confirmations int `json:"confirmations"`
+	}
+	if err := c.bitcoindCall(ctx, node, "getrawtransaction", []interface{}{txid, true}, &tx); err != nil {
+		// RPC_INVALID_ADDRESS_OR_KEY: no such mempool or blockchain transaction
+		var nodeErr *BitcoinNodeError
+		if errors.As(err, &nodeErr) && nodeErr.Code == -5 {
+			return -1, nil
+		}
+		return 0, fmt.Errorf("failed to fetch transaction status: %w", err)
+	}
+	return tx.Confirmations, nil
 }
 
-// GetBitcoinTransactions fetches transaction history for a Bitcoin address
-func (c *Client) GetBitcoinTransactions(address string) ([]Transaction, error) {
-	// Bitcoin only supported in mainnet
-	if c.IsTestnet() {
+// GetBitcoinTransactions fetches a page of transaction history for a Bitcoin
+// address from the configured provider
+func (c *Client) GetBitcoinTransactions(ctx context.Context, address string, limit int, cursor string) (*TransactionPage, error) {
+	ctx = withOperation(ctx, config.OperationHistory)
+
+	if !c.net.HasBitcoin() {
 		return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
 	}
+	if c.net.IsRegtest() {
+		return nil, fmt.Errorf("bitcoin transaction history comes from explorers, which don't index regtest")
+	}
+
+	provider, err := c.HistoryProvider("bitcoin")
+	if err != nil {
+		return nil, err
+	}
+	return provider.History(ctx, address, limit, cursor)
+}
+
+// GetBitcoinFeeEstimate returns the estimated fee rate for Bitcoin in satoshis/byte
+func (c *Client) GetBitcoinFeeEstimate(ctx context.Context) (int64, error) {
+	if !c.net.HasBitcoin() {
+		return 0, fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+
+	if node, err := configuredBitcoinNode(); err != nil || node != nil {
+		if err != nil {
+			return 0, err
+		}
+		// Within about half an hour, like the half hour rate below
+		return c.bitcoinNodeFeeRate(ctx, node, 3)
+	}
+
+	// Try mempool.space API first
+	url := "https://mempool.space/api/v1/fees/recommended"
+	resp, err := c.get(ctx, url)
+	if err == nil && resp.StatusCode == http.StatusOK {
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err == nil {
+			var feeResponse struct {
+				FastestFee  int64 `json:"fastestFee"`
+				HalfHourFee int64 `json:"halfHourFee"`
+				HourFee     int64 `json:"hourFee"`
+				EconomyFee  int64 `json:"economyFee"`
+				MinimumFee  int64 `json:"minimumFee"`
+			}
+
+			if err := json.Unmarshal(body, &feeResponse); err == nil && feeResponse.HalfHourFee > 0 {
+				// Use the half hour fee rate (average priority)
+				return feeResponse.HalfHourFee, nil
+			}
+		}
+	}
+
+	// Fallback to blockchain.info
+	url = "https://api.blockchain.info/mempool/fees"
+	resp, err = c.get(ctx, url)
+	if err != nil {
+		return 10, nil // Default to 10 sat/byte if API fails
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 10, nil // Default to 10 sat/byte if reading fails
+	}
+
+	var feeResponse struct {
+		Regular  int64 `json:"regular"`
+		Priority int64 `json:"priority"`
+	}
+
+	if err := json.Unmarshal(body, &feeResponse); err != nil {
+		return 10, nil // Default to 10 sat/byte if parsing fails
+	}
+
+	if feeResponse.Regular > 0 {
+		return feeResponse.Regular, nil
+	}
+
+	// Default if both APIs fail or return 0
+	return 10, nil
+}
+
+// blockchainInfoProvider serves Bitcoin balances and history from blockchain.info
+type blockchainInfoProvider struct {
+	c *Client
+}
+
+// Balance returns the balance of an address in satoshis
+func (p blockchainInfoProvider) Balance(ctx context.Context, address string) (*big.Int, error) {
+	balances, err := p.Balances(ctx, []string{address})
+	if err != nil {
+		return nil, err
+	}
+	return balances[address], nil
+}
+
+// Balances returns the balances of several addresses in one request
+func (p blockchainInfoProvider) Balances(ctx context.Context, addresses []string) (map[string]*big.Int, error) {
+	url := fmt.Sprintf("%s/balance?active=%s", p.c.GetBitcoinRPC(), strings.Join(addresses, "|"))
+
+	resp, err := p.c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balances: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result map[string]struct {
+		FinalBalance int64 `json:"final_balance"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	balances := make(map[string]*big.Int, len(addresses))
+	for _, address := range addresses {
+		addrData, exists := result[address]
+		if !exists {
+			return nil, fmt.Errorf("address data not found in response")
+		}
+		balances[address] = big.NewInt(addrData.FinalBalance)
+	}
+
+	return balances, nil
+}
+
+// History returns a page of the transactions of an address. The cursor is the
+// number of transactions to skip; an empty cursor starts at the newest.
+func (p blockchainInfoProvider) History(ctx context.Context, address string, limit int, cursor string) (*TransactionPage, error) {
+	offset := 0
+	if cursor != "" {
+		var err error
+		offset, err = strconv.Atoi(cursor)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid Bitcoin cursor %q: must be a transaction offset", cursor)
+		}
+	}
 
 	// Use Blockchain.info API
-	url := fmt.Sprintf("https://blockchain.info/rawaddr/%s?limit=50", address)
+	url := fmt.Sprintf("https://blockchain.info/rawaddr/%s?limit=%d&offset=%d", address, limit, offset)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := p.c.get(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
 	}
@@ -186,7 +479,11 @@ func (c *Client) GetBitcoinTransactions(address string) ([]Transaction, error) {
 	}
 
 	// Convert to generic transaction format
-	transactions := make([]Transaction, 0, len(result.Transactions))
+	page := &TransactionPage{Transactions: make([]Transaction, 0, len(result.Transactions))}
+	if next := offset + len(result.Transactions); len(result.Transactions) > 0 && next < result.TxCount {
+		page.NextCursor = strconv.Itoa(next)
+	}
+
 	for _, tx := range result.Transactions {
 		// Determine if transaction is incoming or outgoing
 		var from, to string
@@ -226,78 +523,20 @@ func (c *Client) GetBitcoinTransactions(address string) ([]Transaction, error) {
 		}
 
 		// Convert satoshis to BTC
-		btcAmount := float64(amount) / 100000000.0
-		btcFee := float64(tx.Fee) / 100000000.0
+		btcAmount := decimal.New(amount, -8)
+		btcFee := decimal.New(tx.Fee, -8)
 
-		transactions = append(transactions, Transaction{
+		page.Transactions = append(page.Transactions, Transaction{
 			Hash:        tx.Hash,
 			From:        from,
 			To:          to,
-			Amount:      fmt.Sprintf("%.8f BTC", btcAmount),
-			Fee:         fmt.Sprintf("%.8f BTC", btcFee),
+			Amount:      btcAmount.StringFixed(8) + " BTC",
+			Fee:         btcFee.StringFixed(8) + " BTC",
 			BlockNumber: tx.BlockHeight,
 			Timestamp:   time.Unix(tx.Time, 0),
 			IsIncoming:  isIncoming,
 		})
 	}
 
-	return transactions, nil
-}
-
-// GetBitcoinFeeEstimate returns the estimated fee rate for Bitcoin in satoshis/byte
-func (c *Client) GetBitcoinFeeEstimate() (int64, error) {
-	if c.IsTestnet() {
-		return 0, fmt.Errorf("bitcoin is not supported in testnet mode")
-	}
-
-	// Try mempool.space API first
-	url := "https://mempool.space/api/v1/fees/recommended"
-	resp, err := c.httpClient.Get(url)
-	if err == nil && resp.StatusCode == http.StatusOK {
-		defer resp.Body.Close()
-		body, err := io.ReadAll(resp.Body)
-		if err == nil {
-			var feeResponse struct {
-				FastestFee  int64 `json:"fastestFee"`
-				HalfHourFee int64 `json:"halfHourFee"`
-				HourFee     int64 `json:"hourFee"`
-				EconomyFee  int64 `json:"economyFee"`
-				MinimumFee  int64 `json:"minimumFee"`
-			}
-
-			if err := json.Unmarshal(body, &feeResponse); err == nil && feeResponse.HalfHourFee > 0 {
-				// Use the half hour fee rate (average priority)
-				return feeResponse.HalfHourFee, nil
-			}
-		}
-	}
-
-	// Fallback to blockchain.info
-	url = "https://api.blockchain.info/mempool/fees"
-	resp, err = c.httpClient.Get(url)
-	if err != nil {
-		return 10, nil // Default to 10 sat/byte if API fails
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 10, nil // Default to 10 sat/byte if reading fails
-	}
-
-	var feeResponse struct {
-		Regular  int64 `json:"regular"`
-		Priority int64 `json:"priority"`
-	}
-
-	if err := json.Unmarshal(body, &feeResponse); err != nil {
-		return 10, nil // Default to 10 sat/byte if parsing fails
-	}
-
-	if feeResponse.Regular > 0 {
-		return feeResponse.Regular, nil
-	}
-
-	// Default if both APIs fail or return 0
-	return 10, nil
+	return page, nil
 }
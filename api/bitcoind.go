@@ -0,0 +1,331 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// scantxoutset walks the whole UTXO set, which takes minutes on mainnet
+	bitcoinNodeTimeout = 5 * time.Minute
+
+	// Scan results are reused within a command, e.g. the balance check and
+	// the UTXO lookup of a payment
+	bitcoinScanTTL = time.Minute
+)
+
+// bitcoinNode is the user's own Bitcoin Core node
+type bitcoinNode struct {
+	url      string
+	user     string
+	password string
+}
+
+// BitcoinNodeError is an error returned by the Bitcoin Core RPC
+type BitcoinNodeError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *BitcoinNodeError) Error() string {
+	return fmt.Sprintf("bitcoin node error %d: %s", e.Code, e.Message)
+}
+
+// configuredBitcoinNode returns the Bitcoin Core node of the network or the
+// one set in the config, if any
+func configuredBitcoinNode() (*bitcoinNode, error) {
+	if network := config.ActiveNetwork(); network.BitcoinRPC != "" {
+		return networkBitcoinNode(network)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil
+	}
+	url := cfg.Get(config.KeyBitcoinRPCURL)
+	if url == "" {
+		return nil, nil
+	}
+
+	node := &bitcoinNode{
+		url:      url,
+		user:     cfg.Get(config.KeyBitcoinRPCUser),
+		password: cfg.Get(config.KeyBitcoinRPCPassword),
+	}
+	if node.user == "" {
+		if cookie := cfg.Get(config.KeyBitcoinRPCCookie); cookie != "" {
+			// The cookie holds user:password and changes on every node restart
+			if strings.HasPrefix(cookie, "~/") {
+				if homeDir, err := os.UserHomeDir(); err == nil {
+					cookie = filepath.Join(homeDir, cookie[2:])
+				}
+			}
+			data, err := os.ReadFile(cookie)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read Bitcoin node cookie: %w", err)
+			}
+			user, password, ok := strings.Cut(strings.TrimSpace(string(data)), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid Bitcoin node cookie file %s", cookie)
+			}
+			node.user, node.password = user, password
+		}
+	}
+	return node, nil
+}
+
+// networkBitcoinNode returns the Bitcoin Core node of a network. The
+// credentials are part of its URL, else a regtest node's cookie is read from
+// the default data directory.
+func networkBitcoinNode(network *config.Network) (*bitcoinNode, error) {
+	parsed, err := url.Parse(network.BitcoinRPC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Bitcoin node URL: %w", err)
+	}
+
+	node := &bitcoinNode{}
+	if parsed.User != nil {
+		node.user = parsed.User.Username()
+		node.password, _ = parsed.User.Password()
+		parsed.User = nil
+	} else if network.IsRegtest() {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			// Nodes started with rpcuser and rpcpassword have no cookie
+			if data, err := os.ReadFile(filepath.Join(homeDir, ".bitcoin", "regtest", ".cookie")); err == nil {
+				node.user, node.password, _ = strings.Cut(strings.TrimSpace(string(data)), ":")
+			}
+		}
+	}
+	node.url = parsed.String()
+	return node, nil
+}
+
+// bitcoindCall calls a Bitcoin Core RPC method and decodes its result
+func (c *Client) bitcoindCall(ctx context.Context, node *bitcoinNode, method string, params []interface{}, result interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      "odyssey",
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bitcoinNodeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, node.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if node.user != "" {
+		req.SetBasicAuth(node.user, node.password)
+	}
+
+	// Scans outlast the request timeouts, the context bounds them instead
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Bitcoin node: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("bitcoin node rejected the credentials, check bitcoin.rpc_user and bitcoin.rpc_password or bitcoin.rpc_cookie")
+	}
+
+	// Older nodes answer errors with a 500 and the error in the body
+	var response struct {
+		Result json.RawMessage   `json:"result"`
+		Error  *BitcoinNodeError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+		}
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to parse %s result: %w", method, err)
+	}
+	return nil
+}
+
+// bitcoinScan is the unspent outputs of one address found by a scan
+type bitcoinScan struct {
+	utxos   []BitcoinUTXO
+	scanned time.Time
+}
+
+var (
+	bitcoinScansMu sync.Mutex
+	bitcoinScans   = make(map[string]bitcoinScan)
+)
+
+// scanBitcoinAddresses returns the unspent outputs of addresses from the UTXO
+// set of the node, keyed by address
+func (c *Client) scanBitcoinAddresses(ctx context.Context, node *bitcoinNode, addresses []string) (map[string][]BitcoinUTXO, error) {
+	found := make(map[string][]BitcoinUTXO, len(addresses))
+	var missing []string
+
+	bitcoinScansMu.Lock()
+	for _, address := range addresses {
+		if scan, ok := bitcoinScans[address]; ok && time.Since(scan.scanned) < bitcoinScanTTL {
+			found[address] = scan.utxos
+		} else {
+			missing = append(missing, address)
+		}
+	}
+	bitcoinScansMu.Unlock()
+	if len(missing) == 0 {
+		return found, nil
+	}
+
+	descriptors := make([]string, len(missing))
+	for i, address := range missing {
+		descriptors[i] = "addr(" + address + ")"
+	}
+
+	var result struct {
+		Success  bool `json:"success"`
+		Unspents []struct {
+			TxID         string          `json:"txid"`
+			Vout         uint32          `json:"vout"`
+			ScriptPubKey string          `json:"scriptPubKey"`
+			Desc         string          `json:"desc"`
+			Amount       decimal.Decimal `json:"amount"` // BTC, decoded exactly
+		} `json:"unspents"`
+	}
+	if err := c.bitcoindCall(ctx, node, "scantxoutset", []interface{}{"start", descriptors}, &result); err != nil {
+		return nil, fmt.Errorf("failed to scan UTXO set: %w", err)
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("failed to scan UTXO set: scan did not complete")
+	}
+
+	for _, address := range missing {
+		found[address] = []BitcoinUTXO{}
+	}
+	for _, unspent := range result.Unspents {
+		// desc is addr(<address>)#<checksum>
+		address := strings.TrimPrefix(unspent.Desc, "addr(")
+		if end := strings.Index(address, ")"); end >= 0 {
+			address = address[:end]
+		}
+		if _, ok := found[address]; !ok {
+			continue
+		}
+		found[address] = append(found[address], BitcoinUTXO{
+			TxID:   unspent.TxID,
+			Vout:   unspent.Vout,
+			Value:  unspent.Amount.Shift(8).IntPart(),
+			Script: unspent.ScriptPubKey,
+		})
+	}
+
+	bitcoinScansMu.Lock()
+	now := time.Now()
+	for _, address := range missing {
+		bitcoinScans[address] = bitcoinScan{utxos: found[address], scanned: now}
+	}
+	bitcoinScansMu.Unlock()
+
+	return found, nil
+}
+
+// forgetBitcoinScans drops the scan results, the outputs a transaction spent
+// are gone once it is broadcast
+func forgetBitcoinScans() {
+	bitcoinScansMu.Lock()
+	defer bitcoinScansMu.Unlock()
+	bitcoinScans = make(map[string]bitcoinScan)
+}
+
+// bitcoinNodeBalances sums the unspent outputs of addresses in satoshis
+func (c *Client) bitcoinNodeBalances(ctx context.Context, node *bitcoinNode, addresses []string) (map[string]int64, error) {
+	scans, err := c.scanBitcoinAddresses(ctx, node, addresses)
+	if err != nil {
+		return nil, err
+	}
+	balances := make(map[string]int64, len(addresses))
+	for _, address := range addresses {
+		sats := int64(0)
+		for _, utxo := range scans[address] {
+			sats += utxo.Value
+		}
+		balances[address] = sats
+	}
+	return balances, nil
+}
+
+// bitcoinNodeFeeRate estimates the fee rate in sat/vB for confirmation within
+// target blocks with estimatesmartfee
+func (c *Client) bitcoinNodeFeeRate(ctx context.Context, node *bitcoinNode, target int) (int64, error) {
+	var result struct {
+		FeeRate float64  `json:"feerate"` // BTC/kvB
+		Errors  []string `json:"errors"`
+	}
+	if err := c.bitcoindCall(ctx, node, "estimatesmartfee", []interface{}{target}, &result); err != nil {
+		return 0, fmt.Errorf("failed to estimate fee: %w", err)
+	}
+	if result.FeeRate <= 0 {
+		// Regtest mines only on request, never enough blocks to estimate
+		if c.net.IsRegtest() {
+			return c.bitcoinNodeMinimumFeeRate(ctx, node)
+		}
+		// A node that just started hasn't seen enough blocks to estimate
+		if len(result.Errors) > 0 {
+			return 0, fmt.Errorf("failed to estimate fee: %s", strings.Join(result.Errors, ", "))
+		}
+		return 0, fmt.Errorf("failed to estimate fee: no estimate available")
+	}
+	return bitcoinFeeRateToSatPerVByte(result.FeeRate), nil
+}
+
+// bitcoinNodeMinimumFeeRate returns the lowest fee rate the node's mempool
+// accepts in sat/vB
+func (c *Client) bitcoinNodeMinimumFeeRate(ctx context.Context, node *bitcoinNode) (int64, error) {
+	var result struct {
+		MempoolMinFee float64 `json:"mempoolminfee"` // BTC/kvB
+	}
+	if err := c.bitcoindCall(ctx, node, "getmempoolinfo", nil, &result); err != nil {
+		return 0, fmt.Errorf("failed to get mempool info: %w", err)
+	}
+	return bitcoinFeeRateToSatPerVByte(result.MempoolMinFee), nil
+}
+
+// bitcoinFeeRateToSatPerVByte converts BTC/kvB to whole sat/vB, rounded up
+func bitcoinFeeRateToSatPerVByte(rate float64) int64 {
+	satPerVByte := int64(math.Ceil(rate * 1e8 / 1000))
+	if satPerVByte < 1 {
+		satPerVByte = 1
+	}
+	return satPerVByte
+}
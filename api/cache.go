@@ -0,0 +1,263 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How long cached responses stay fresh, per kind of data
+const (
+	priceCacheTTL   = 60 * time.Second
+	balanceCacheTTL = 20 * time.Second
+	historyCacheTTL = 30 * time.Second
+)
+
+// Read-only JSON-RPC methods that are cached, with their TTL. Nonces, fees,
+// blockhashes, receipts and anything that submits are always fetched.
+var cachedRPCMethods = map[string]time.Duration{
+	"eth_getBalance":          balanceCacheTTL,
+	"eth_call":                balanceCacheTTL, // token balances
+	"getBalance":              balanceCacheTTL,
+	"getTokenAccountsByOwner": balanceCacheTTL,
+	"eth_blockNumber":         historyCacheTTL,
+	"eth_getBlockByNumber":    historyCacheTTL,
+	"eth_getLogs":             historyCacheTTL,
+	"getSignaturesForAddress": historyCacheTTL,
+	"getTransaction":          historyCacheTTL,
+}
+
+// cacheEnabled turns on the response cache for clients created afterwards
+var cacheEnabled bool
+
+// SetCache enables or disables the response cache. Only commands that
+// display data should enable it, never ones that act on balances.
+func SetCache(enabled bool) {
+	cacheEnabled = enabled
+}
+
+// cacheEntry is a cached response as stored on disk
+type cacheEntry struct {
+	Expires     time.Time `json:"expires"`
+	ContentType string    `json:"content_type,omitempty"`
+	Body        []byte    `json:"body"`
+}
+
+// cacheTransport answers read-only requests from ~/.odyssey/cache while
+// they are fresh and stores successful responses there
+type cacheTransport struct {
+	next http.RoundTripper
+	dir  string
+}
+
+// pruneCache removes expired entries once per process
+var pruneCache sync.Once
+
+func (t cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, ttl := t.cacheKey(req)
+	if ttl == 0 {
+		return t.next.RoundTrip(req)
+	}
+	path := filepath.Join(t.dir, key+".json")
+
+	if entry, ok := readCacheEntry(path); ok {
+		slog.Debug("answered from cache", "url", req.URL.String(), "expires", entry.Expires.Format(time.TimeOnly))
+		header := make(http.Header)
+		if entry.ContentType != "" {
+			header.Set("Content-Type", entry.ContentType)
+		}
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+			ContentLength: int64(len(entry.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !rpcErrorBody(body) {
+		t.store(path, cacheEntry{
+			Expires:     time.Now().Add(ttl),
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        body,
+		})
+	}
+	return resp, nil
+}
+
+// cacheKey returns the cache key of a request and its TTL, zero if the
+// request must not be cached
+func (t cacheTransport) cacheKey(req *http.Request) (string, time.Duration) {
+	var ttl time.Duration
+	var body []byte
+
+	switch req.Method {
+	case http.MethodGet:
+		switch {
+		case req.URL.Host == "api.coingecko.com":
+			ttl = priceCacheTTL
+		case strings.HasSuffix(req.URL.Host, "blockchain.info") && req.URL.Path == "/balance":
+			ttl = balanceCacheTTL
+		case strings.HasSuffix(req.URL.Host, "blockchain.info") && strings.HasPrefix(req.URL.Path, "/rawaddr/"):
+			ttl = historyCacheTTL
+		case (req.URL.Host == "mempool.space" || req.URL.Host == "blockstream.info") && strings.HasPrefix(req.URL.Path, "/api/address/"):
+			// Esplora balances at /address/<address>, history below it
+			ttl = balanceCacheTTL
+			if strings.Count(req.URL.Path, "/") > 3 {
+				ttl = historyCacheTTL
+			}
+		case req.URL.Host == "api.etherscan.io" && req.URL.Query().Get("module") == "account":
+			ttl = balanceCacheTTL
+			if req.URL.Query().Get("action") == "txlist" {
+				ttl = historyCacheTTL
+			}
+		}
+	case http.MethodPost:
+		if req.GetBody == nil {
+			return "", 0
+		}
+		reader, err := req.GetBody()
+		if err != nil {
+			return "", 0
+		}
+		body, err = io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return "", 0
+		}
+		var call struct {
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(body, &call) != nil {
+			return "", 0
+		}
+		ttl = cachedRPCMethods[call.Method]
+	}
+	if ttl == 0 {
+		return "", 0
+	}
+
+	// JSON-RPC ids differ between otherwise equal requests
+	body = stripRPCID(body)
+
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.String() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), ttl
+}
+
+// stripRPCID removes the id of a JSON-RPC request body
+func stripRPCID(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var call map[string]json.RawMessage
+	if json.Unmarshal(body, &call) != nil {
+		return body
+	}
+	delete(call, "id")
+	// Map keys are encoded sorted, so equal requests encode equally
+	normalized, err := json.Marshal(call)
+	if err != nil {
+		return body
+	}
+	return normalized
+}
+
+// rpcErrorBody returns true if a response is a JSON-RPC error, which is
+// never cached
+func rpcErrorBody(body []byte) bool {
+	var response struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(body, &response) != nil {
+		return false
+	}
+	return len(response.Error) > 0 && string(response.Error) != "null"
+}
+
+// readCacheEntry returns the entry at path if it is still fresh
+func readCacheEntry(path string) (cacheEntry, bool) {
+	var entry cacheEntry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, false
+	}
+	if json.Unmarshal(data, &entry) != nil || time.Now().After(entry.Expires) {
+		return entry, false
+	}
+	return entry, true
+}
+
+// store writes an entry, a cache that can't be written is skipped silently
+func (t cacheTransport) store(path string, entry cacheEntry) {
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return
+	}
+	pruneCache.Do(func() { t.prune() })
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(t.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// prune removes expired entries so the cache doesn't grow without bound
+func (t cacheTransport) prune() {
+	files, err := filepath.Glob(filepath.Join(t.dir, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if _, ok := readCacheEntry(file); !ok {
+			os.Remove(file)
+		}
+	}
+}
+
+// cacheDir returns the directory of the response cache
+func cacheDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".odyssey", "cache"), nil
+}
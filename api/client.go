@@ -7,11 +7,31 @@ package api
 //   types.go     - Struct definitions (transaction, priceData, etc.)
 //   base.go      - Core client functionality (client struct, newClient, helpers)
 //   ethereum.go  - Ethereum-specific functions (balance, transactions, gas, etc.)
+//   evm.go       - Registry of other EVM chains (Polygon, Arbitrum, Optimism, Base, BSC)
 //   bitcoin.go   - Bitcoin-specific functions (balance, utxos, transactions, etc.)
+//   bitcoind.go  - The user's own Bitcoin Core node (scans, fee estimates, broadcast)
+//   esplora.go   - Esplora APIs (mempool.space, Blockstream) as Bitcoin providers
+//   electrum.go  - Electrum servers as Bitcoin providers
 //   solana.go    - Solana-specific functions (balance, transactions, blockhash, etc.)
+//   tokens.go    - ERC-20 and SPL token registry and balances
+//   tokenmeta.go - Token names, symbols and decimals read from the chain
+//   swap.go      - Cross-chain swap quotes (THORChain)
+//   rpc.go       - Raw JSON-RPC passthrough
+//   safe.go      - Safe (Gnosis Safe) transaction service
+//   etherscan.go - Verified contract ABIs from Etherscan
+//   ratelimit.go - Per provider request pacing and rate limit errors
+//   retry.go     - Retries of transient failures with backoff and jitter
+//   failover.go  - Configured RPC nodes per chain, failover and cooldowns
+//   cache.go     - On-disk cache of balance, history and price responses
+//   timeout.go   - Request timeouts per chain and operation
+//   gasoracle.go - Gas price tiers agreed by the node, fee history and Etherscan
+//   screening.go - Sanctions screening of addresses (Chainalysis)
+//   trace.go     - Debug logging of requests and raw traffic
+//   health.go    - Probes of the nodes used per chain for diagnostics
+//   providers.go - Balance, history and broadcast providers chosen per chain
 //
 // Usage:
 //   client := api.NewClient()  // from base.go
-//   balance, err := client.GetEthereumBalance(address)  // from ethereum.go
-//   utxos, err := client.GetBitcoinUTXOs(address)      // from bitcoin.go
-//   txHash, err := client.SendSolanaTransaction(tx)    // from solana.go
+//   balance, err := client.GetEthereumBalance(ctx, address)  // from ethereum.go
+//   utxos, err := client.GetBitcoinUTXOs(ctx, address)      // from bitcoin.go
+//   txHash, err := client.SendSolanaTransaction(ctx, tx)    // from solana.go
@@ -18,3 +18,45 @@ const (
 	TestnetSolanaRPC   = "https://api.devnet.solana.com"
 	// bitcoin is not supported for testnet
 )
+
+// Esplora APIs, Bitcoin balances, history and broadcasts (mainnet only)
+const (
+	MempoolAPI     = "https://mempool.space/api"
+	BlockstreamAPI = "https://blockstream.info/api"
+)
+
+// Helius RPC nodes, the API key is added as a query parameter
+const (
+	HeliusMainnetRPC = "https://mainnet.helius-rpc.com"
+	HeliusDevnetRPC  = "https://devnet.helius-rpc.com"
+)
+
+// Safe transaction service, shares multisig proposals and owner signatures
+const (
+	MainnetSafeServiceAPI = "https://safe-transaction-mainnet.safe.global"
+	TestnetSafeServiceAPI = "https://safe-transaction-sepolia.safe.global"
+)
+
+// Etherscan API (v2, one endpoint for all chains selected by chain ID)
+const (
+	EtherscanAPI = "https://api.etherscan.io/v2/api"
+)
+
+// Swap provider
+const (
+	// thorchain node api used for cross-chain swap quotes (mainnet only)
+	ThorchainAPI = "https://thornode.ninerealms.com"
+
+	// DEX aggregators used for same-chain token swaps (mainnet only)
+	JupiterAPI = "https://lite-api.jup.ag/swap/v1"
+	ZeroExAPI  = "https://api.0x.org"
+
+	// Jupiter token list, names and symbols of SPL mints (mainnet only)
+	JupiterTokensAPI = "https://lite-api.jup.ag/tokens/v2"
+)
+
+// Chainalysis sanctions API, screens payment recipients when an API key is set
+const ChainalysisSanctionsAPI = "https://public.chainalysis.com/api/v1/address"
+
+// Tenderly API, used to simulate EVM transactions when an account is configured
+const TenderlyAPI = "https://api.tenderly.co/api/v1"
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+)
+
+// Native assets as addressed by the DEX aggregators
+var (
+	// 0x uses this placeholder address for ETH
+	NativeETH = Token{Symbol: "ETH", Name: "Ether", Address: "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE", Decimals: 18, CoingeckoID: "ethereum"}
+	// Jupiter wraps and unwraps SOL through the wrapped SOL mint
+	NativeSOL = Token{Symbol: "SOL", Name: "Solana", Address: "So11111111111111111111111111111111111111112", Decimals: 9, CoingeckoID: "solana"}
+)
+
+// DEXTransaction is an EVM transaction returned by an aggregator, to be signed
+// by the taker
+type DEXTransaction struct {
+	To       string
+	Data     []byte
+	Value    *big.Int
+	Gas      uint64
+	GasPrice *big.Int
+}
+
+// DEXQuote is a same-chain token swap quote from a DEX aggregator
+type DEXQuote struct {
+	Chain        string // ethereum or solana
+	Provider     string
+	Sell         Token
+	Buy          Token
+	SellAmount   decimal.Decimal
+	BuyAmount    decimal.Decimal  // expected output
+	MinBuyAmount decimal.Decimal  // output guaranteed by the slippage limit
+	PriceImpact  *decimal.Decimal // percent, nil if the provider doesn't report it
+	Route        []string         // liquidity sources used
+	SlippageBps  int
+
+	// Ethereum only, the swap transaction and the spender that needs an
+	// allowance first when selling an ERC-20 (empty if it already has one)
+	Transaction      *DEXTransaction
+	AllowanceSpender string
+
+	// Solana only, the quote as returned by Jupiter, needed to build the swap
+	jupiterQuote json.RawMessage
+}
+
+// SwapTokens returns the tokens that can be swapped on a chain, native asset first
+func (c *Client) SwapTokens(chain string) []Token {
+	switch chain {
+	case "ethereum":
+		return append([]Token{NativeETH}, c.GetEthereumTokens()...)
+	case "solana":
+		return append([]Token{NativeSOL}, c.GetSolanaTokens()...)
+	default:
+		return nil
+	}
+}
+
+// FindSwapToken looks up a swappable token of a chain by symbol
+func (c *Client) FindSwapToken(chain, symbol string) (Token, bool) {
+	for _, token := range c.SwapTokens(chain) {
+		if strings.EqualFold(token.Symbol, symbol) {
+			return token, true
+		}
+	}
+	return Token{}, false
+}
+
+// GetJupiterQuote requests a quote for swapping amount of sell into buy on Solana
+func (c *Client) GetJupiterQuote(ctx context.Context, sell, buy Token, amount decimal.Decimal, slippageBps int) (*DEXQuote, error) {
+	if c.IsTestnet() {
+		return nil, fmt.Errorf("token swaps are only supported on mainnet")
+	}
+
+	params := url.Values{}
+	params.Set("inputMint", sell.Address)
+	params.Set("outputMint", buy.Address)
+	params.Set("amount", amount.Shift(sell.Decimals).Truncate(0).String())
+	params.Set("slippageBps", fmt.Sprintf("%d", slippageBps))
+
+	resp, err := c.get(ctx, JupiterAPI+"/quote?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch swap quote: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result struct {
+		InAmount             string `json:"inAmount"`
+		OutAmount            string `json:"outAmount"`
+		OtherAmountThreshold string `json:"otherAmountThreshold"`
+		PriceImpactPct       string `json:"priceImpactPct"`
+		RoutePlan            []struct {
+			SwapInfo struct {
+				Label string `json:"label"`
+			} `json:"swapInfo"`
+		} `json:"routePlan"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if result.Error != "" {
+		return nil, fmt.Errorf("swap quote error: %s", result.Error)
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("swap quote failed: %w", &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)})
+	}
+
+	quote := &DEXQuote{
+		Chain:        "solana",
+		Provider:     "Jupiter",
+		Sell:         sell,
+		Buy:          buy,
+		SellAmount:   tokenAmountString(result.InAmount, sell.Decimals),
+		BuyAmount:    tokenAmountString(result.OutAmount, buy.Decimals),
+		MinBuyAmount: tokenAmountString(result.OtherAmountThreshold, buy.Decimals),
+		SlippageBps:  slippageBps,
+		jupiterQuote: body,
+	}
+	if impact, err := decimal.NewFromString(result.PriceImpactPct); err == nil {
+		// Jupiter reports the impact as a fraction
+		impact = impact.Shift(2)
+		quote.PriceImpact = &impact
+	}
+	for _, step := range result.RoutePlan {
+		quote.Route = appendUnique(quote.Route, step.SwapInfo.Label)
+	}
+
+	return quote, nil
+}
+
+// GetJupiterSwapTransaction builds the unsigned swap transaction of a Jupiter
+// quote for owner, base64 encoded
+func (c *Client) GetJupiterSwapTransaction(ctx context.Context, quote *DEXQuote, owner string) (string, error) {
+	if quote.jupiterQuote == nil {
+		return "", fmt.Errorf("not a Jupiter quote")
+	}
+
+	payload := map[string]interface{}{
+		"quoteResponse":             quote.jupiterQuote,
+		"userPublicKey":             owner,
+		"wrapAndUnwrapSol":          true,
+		"dynamicComputeUnitLimit":   true,
+		"prioritizationFeeLamports": "auto",
+	}
+
+	response, err := c.postJSON(ctx, JupiterAPI+"/swap", payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to build swap transaction: %w", err)
+	}
+
+	var result struct {
+		SwapTransaction string `json:"swapTransaction"`
+		Error           string `json:"error"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Error != "" {
+		return "", fmt.Errorf("failed to build swap transaction: %s", result.Error)
+	}
+	if result.SwapTransaction == "" {
+		return "", fmt.Errorf("swap response contains no transaction")
+	}
+
+	return result.SwapTransaction, nil
+}
+
+// GetZeroExQuote requests a firm quote for swapping amount of sell into buy on
+// Ethereum, executed by a transaction from taker
+func (c *Client) GetZeroExQuote(ctx context.Context, sell, buy Token, amount decimal.Decimal, taker string, slippageBps int, apiKey string) (*DEXQuote, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("a 0x API key is required for Ethereum swaps. Get one at https://dashboard.0x.org and set it with 'odyssey config set swap.0x_api_key <key>'")
+	}
+	if c.IsTestnet() {
+		return nil, fmt.Errorf("token swaps are only supported on mainnet")
+	}
+
+	params := url.Values{}
+	params.Set("chainId", "1")
+	params.Set("sellToken", sell.Address)
+	params.Set("buyToken", buy.Address)
+	params.Set("sellAmount", amount.Shift(sell.Decimals).Truncate(0).String())
+	params.Set("taker", taker)
+	params.Set("slippageBps", fmt.Sprintf("%d", slippageBps))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ZeroExAPI+"/swap/allowance-holder/quote?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("0x-api-key", apiKey)
+	req.Header.Set("0x-version", "v2")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch swap quote: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result struct {
+		LiquidityAvailable bool   `json:"liquidityAvailable"`
+		BuyAmount          string `json:"buyAmount"`
+		MinBuyAmount       string `json:"minBuyAmount"`
+		SellAmount         string `json:"sellAmount"`
+		Issues             struct {
+			Allowance *struct {
+				Actual  string `json:"actual"`
+				Spender string `json:"spender"`
+			} `json:"allowance"`
+			Balance *struct {
+				Actual   string `json:"actual"`
+				Expected string `json:"expected"`
+			} `json:"balance"`
+		} `json:"issues"`
+		Route struct {
+			Fills []struct {
+				Source string `json:"source"`
+			} `json:"fills"`
+		} `json:"route"`
+		Transaction struct {
+			To       string `json:"to"`
+			Data     string `json:"data"`
+			Gas      string `json:"gas"`
+			GasPrice string `json:"gasPrice"`
+			Value    string `json:"value"`
+		} `json:"transaction"`
+		Name    string `json:"name"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if resp.StatusCode != 200 {
+		if result.Message != "" {
+			return nil, fmt.Errorf("swap quote error: %s", strings.TrimSpace(result.Name+" "+result.Message))
+		}
+		return nil, fmt.Errorf("swap quote failed: %w", &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)})
+	}
+	if !result.LiquidityAvailable {
+		return nil, fmt.Errorf("no liquidity available to swap %s for %s", sell.Symbol, buy.Symbol)
+	}
+	if balance := result.Issues.Balance; balance != nil {
+		return nil, fmt.Errorf("%w of %s. The swap needs %s %s but your balance is only %s %s",
+			ErrInsufficientFunds, sell.Symbol, tokenAmountString(balance.Expected, sell.Decimals), sell.Symbol, tokenAmountString(balance.Actual, sell.Decimals), sell.Symbol)
+	}
+
+	data, err := hexutil.Decode(result.Transaction.Data)
+	if err != nil {
+		return nil, fmt.Errorf("swap quote contains invalid call data: %w", err)
+	}
+	value, ok := new(big.Int).SetString(result.Transaction.Value, 10)
+	if !ok {
+		return nil, fmt.Errorf("swap quote contains an invalid value")
+	}
+	gasPrice, ok := new(big.Int).SetString(result.Transaction.GasPrice, 10)
+	if !ok {
+		return nil, fmt.Errorf("swap quote contains an invalid gas price")
+	}
+	gas, ok := new(big.Int).SetString(result.Transaction.Gas, 10)
+	if !ok || !gas.IsUint64() {
+		return nil, fmt.Errorf("swap quote contains an invalid gas limit")
+	}
+
+	quote := &DEXQuote{
+		Chain:        "ethereum",
+		Provider:     "0x",
+		Sell:         sell,
+		Buy:          buy,
+		SellAmount:   tokenAmountString(result.SellAmount, sell.Decimals),
+		BuyAmount:    tokenAmountString(result.BuyAmount, buy.Decimals),
+		MinBuyAmount: tokenAmountString(result.MinBuyAmount, buy.Decimals),
+		SlippageBps:  slippageBps,
+		Transaction: &DEXTransaction{
+			To:       result.Transaction.To,
+			Data:     data,
+			Value:    value,
+			Gas:      gas.Uint64(),
+			GasPrice: gasPrice,
+		},
+	}
+	if allowance := result.Issues.Allowance; allowance != nil {
+		quote.AllowanceSpender = allowance.Spender
+	}
+	for _, fill := range result.Route.Fills {
+		quote.Route = appendUnique(quote.Route, fill.Source)
+	}
+
+	return quote, nil
+}
+
+// tokenAmountString converts a raw token amount given as a decimal string
+func tokenAmountString(raw string, decimals int32) decimal.Decimal {
+	amount, err := decimal.NewFromString(raw)
+	if err != nil {
+		return decimal.Zero
+	}
+	return amount.Shift(-decimals)
+}
+
+// appendUnique appends value unless it is empty or already present
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/chinmay1088/odyssey/config"
+)
+
+// electrumProvider serves Bitcoin balances and broadcasts from an Electrum
+// server (ElectrumX, Fulcrum or electrs). Electrum servers look up addresses
+// by the hash of their output script.
+type electrumProvider struct {
+	c      *Client
+	server *url.URL
+}
+
+func newElectrumProvider(c *Client) (interface{}, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	value := cfg.Get(config.KeyElectrumServer)
+	if value == "" {
+		return nil, fmt.Errorf("no Electrum server is set. Set one with 'odyssey config set %s ssl://electrum.example.com:50002'", config.KeyElectrumServer)
+	}
+	server, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Electrum server: %w", err)
+	}
+	return electrumProvider{c: c, server: server}, nil
+}
+
+// Balance returns the balance of an address in satoshis, including
+// unconfirmed transactions
+func (p electrumProvider) Balance(ctx context.Context, address string) (*big.Int, error) {
+	balances, err := p.Balances(ctx, []string{address})
+	if err != nil {
+		return nil, err
+	}
+	return balances[address], nil
+}
+
+// Balances returns the balances of several addresses over one connection
+func (p electrumProvider) Balances(ctx context.Context, addresses []string) (map[string]*big.Int, error) {
+	conn, err := p.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balances: %w", err)
+	}
+	defer conn.Close()
+
+	balances := make(map[string]*big.Int, len(addresses))
+	for _, address := range addresses {
+		scriptHash, err := p.scriptHash(address)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Confirmed   int64 `json:"confirmed"`
+			Unconfirmed int64 `json:"unconfirmed"`
+		}
+		if err := conn.call("blockchain.scripthash.get_balance", []interface{}{scriptHash}, &result); err != nil {
+			return nil, fmt.Errorf("failed to fetch balances: %w", err)
+		}
+		balances[address] = big.NewInt(result.Confirmed + result.Unconfirmed)
+	}
+	return balances, nil
+}
+
+// Broadcast sends a signed transaction and returns its ID
+func (p electrumProvider) Broadcast(ctx context.Context, signedTx string) (string, error) {
+	conn, err := p.dial(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to send transaction: %w", err)
+	}
+	defer conn.Close()
+
+	var txid string
+	if err := conn.call("blockchain.transaction.broadcast", []interface{}{signedTx}, &txid); err != nil {
+		return "", fmt.Errorf("transaction failed: %w", err)
+	}
+	return txid, nil
+}
+
+// scriptHash returns the Electrum script hash of an address: the SHA-256 of
+// its output script, byte reversed
+func (p electrumProvider) scriptHash(address string) (string, error) {
+	decoded, err := btcutil.DecodeAddress(address, p.c.net.BitcoinParams())
+	if err != nil {
+		return "", fmt.Errorf("invalid Bitcoin address %s: %w", address, err)
+	}
+	script, err := txscript.PayToAddrScript(decoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid Bitcoin address %s: %w", address, err)
+	}
+
+	hash := sha256.Sum256(script)
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// electrumConn is a connection to an Electrum server, requests are sent one
+// at a time as lines of JSON
+type electrumConn struct {
+	net.Conn
+	reader *bufio.Reader
+	nextID int
+}
+
+// dial connects to the server, over TLS for ssl:// servers. The connection
+// times out like a request to a Bitcoin API.
+func (p electrumProvider) dial(ctx context.Context) (*electrumConn, error) {
+	if offline {
+		return nil, fmt.Errorf("%w: refusing connection to %s", ErrOffline, p.server.Host)
+	}
+
+	timeout := timeoutOverride
+	if timeout == 0 {
+		timeout = config.DefaultNetworkTimeout
+		if p.c.timeouts != nil {
+			operation, _ := ctx.Value(operationKey{}).(string)
+			timeout = p.c.timeouts.RequestTimeout("bitcoin", operation)
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	var conn net.Conn
+	var err error
+	if p.server.Scheme == "ssl" {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: p.server.Hostname()}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", p.server.Host)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", p.server.Host)
+	}
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	slog.Debug("connected to Electrum server", "server", p.server.Host)
+	return &electrumConn{Conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+// call sends a request and decodes its result
+func (c *electrumConn) call(method string, params []interface{}, result interface{}) error {
+	c.nextID++
+	request, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      c.nextID,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	if _, err := c.Write(append(request, '\n')); err != nil {
+		return err
+	}
+
+	line, err := c.reader.ReadBytes('\n')
+	if err != nil {
+		return err
+	}
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(line, &response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Servers report errors as an object with a message or as a string
+	if len(response.Error) > 0 && string(response.Error) != "null" {
+		var rpcErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(response.Error, &rpcErr) != nil || rpcErr.Message == "" {
+			rpcErr.Message = string(response.Error)
+		}
+		return fmt.Errorf("electrum error: %s", rpcErr.Message)
+	}
+
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// The script hash of the genesis address, from the Electrum protocol docs
+const (
+	testElectrumAddress    = "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+	testElectrumScriptHash = "8b01df4e368ea28f8dc0423bcf7a4923e3a12d307c875e47a0cfbf90b5c39161"
+)
+
+// electrumServer serves the recorded response of each method over TCP, a
+// request per line, and returns its tcp:// URL
+func electrumServer(t *testing.T, responses map[string]string) *url.URL {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					var request struct {
+						Method string   `json:"method"`
+						Params []string `json:"params"`
+					}
+					if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+						t.Errorf("invalid request %q: %v", scanner.Text(), err)
+						return
+					}
+					if request.Method == "blockchain.scripthash.get_balance" && (len(request.Params) != 1 || request.Params[0] != testElectrumScriptHash) {
+						t.Errorf("balance of %v, want script hash %s", request.Params, testElectrumScriptHash)
+					}
+
+					name, ok := responses[request.Method]
+					if !ok {
+						conn.Write([]byte(`{"jsonrpc": "2.0", "error": "unknown method", "id": 1}` + "\n"))
+						continue
+					}
+					conn.Write(append(bytes.TrimSpace(fixture(t, name)), '\n'))
+				}
+			}()
+		}
+	}()
+
+	return &url.URL{Scheme: "tcp", Host: listener.Addr().String()}
+}
+
+func TestElectrumBalances(t *testing.T) {
+	server := electrumServer(t, map[string]string{"blockchain.scripthash.get_balance": "electrum_get_balance.json"})
+	p := electrumProvider{c: testClient(t, http.NotFoundHandler()), server: server}
+
+	// One connection serves every address
+	balances, err := p.Balances(context.Background(), []string{testElectrumAddress, testElectrumAddress})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance := balances[testElectrumAddress]; balance == nil || balance.Int64() != 5000001200 {
+		t.Errorf("balance = %v sats, want confirmed and unconfirmed 5000001200", balance)
+	}
+
+	if _, err := p.Balance(context.Background(), testBitcoinPeer+"x"); err == nil {
+		t.Error("invalid address accepted")
+	}
+}
+
+func TestElectrumErrors(t *testing.T) {
+	server := electrumServer(t, map[string]string{"blockchain.transaction.broadcast": "electrum_broadcast_rejected.json"})
+	p := electrumProvider{c: testClient(t, http.NotFoundHandler()), server: server}
+
+	// Servers report errors as objects with a message
+	_, err := p.Broadcast(context.Background(), "0100")
+	if err == nil || !strings.Contains(err.Error(), "bad-txns-inputs-missingorspent") {
+		t.Errorf("error = %v, want the reason of the rejection", err)
+	}
+
+	// or as strings
+	_, err = p.Balance(context.Background(), testElectrumAddress)
+	if err == nil || !strings.Contains(err.Error(), "unknown method") {
+		t.Errorf("error = %v, want the server's error", err)
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// ErrInsufficientFunds is wrapped by errors of payments the balance can't cover
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// HTTPStatusError is returned when a server answers with an unexpected status
+type HTTPStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// IsTransientError returns true if a request failed for a reason that may go
+// away on its own, such as a timeout, a dropped connection, rate limiting or a
+// server error. Rejections by the node itself are never transient.
+func IsTransientError(err error) bool {
+	// Offline mode and a cancelled command fail the same way on every retry
+	if err == nil || errors.Is(err, ErrOffline) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var limitErr *RateLimitError
+	if errors.As(err, &limitErr) {
+		return true
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == 429 || statusErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// Connection errors that never reached the server
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
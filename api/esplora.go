@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// esploraPageSize is the number of confirmed transactions an Esplora API
+// returns per page
+const esploraPageSize = 25
+
+// esploraProvider serves Bitcoin balances, history and broadcasts from an
+// Esplora API such as mempool.space or Blockstream
+type esploraProvider struct {
+	c   *Client
+	api string
+}
+
+// esploraTransaction is a transaction as listed by an Esplora API
+type esploraTransaction struct {
+	TxID   string `json:"txid"`
+	Fee    int64  `json:"fee"`
+	Status struct {
+		Confirmed   bool  `json:"confirmed"`
+		BlockHeight int64 `json:"block_height"`
+		BlockTime   int64 `json:"block_time"`
+	} `json:"status"`
+	Vin []struct {
+		Prevout struct {
+			Address string `json:"scriptpubkey_address"`
+			Value   int64  `json:"value"`
+		} `json:"prevout"`
+	} `json:"vin"`
+	Vout []struct {
+		Address string `json:"scriptpubkey_address"`
+		Value   int64  `json:"value"`
+	} `json:"vout"`
+}
+
+// Balance returns the balance of an address in satoshis, including
+// unconfirmed transactions
+func (p esploraProvider) Balance(ctx context.Context, address string) (*big.Int, error) {
+	var result struct {
+		ChainStats struct {
+			Funded int64 `json:"funded_txo_sum"`
+			Spent  int64 `json:"spent_txo_sum"`
+		} `json:"chain_stats"`
+		MempoolStats struct {
+			Funded int64 `json:"funded_txo_sum"`
+			Spent  int64 `json:"spent_txo_sum"`
+		} `json:"mempool_stats"`
+	}
+	if err := p.getJSON(ctx, "/address/"+address, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+
+	balance := result.ChainStats.Funded - result.ChainStats.Spent + result.MempoolStats.Funded - result.MempoolStats.Spent
+	return big.NewInt(balance), nil
+}
+
+// History returns a page of the transactions of an address. The cursor is the
+// last transaction of the previous page; an empty cursor starts at the newest,
+// unconfirmed ones included.
+func (p esploraProvider) History(ctx context.Context, address string, limit int, cursor string) (*TransactionPage, error) {
+	path := "/address/" + address + "/txs"
+	if cursor != "" {
+		path += "/chain/" + cursor
+	}
+
+	var result []esploraTransaction
+	if err := p.getJSON(ctx, path, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
+	}
+
+	// Full pages of confirmed transactions continue after their last one
+	confirmed := 0
+	for _, tx := range result {
+		if tx.Status.Confirmed {
+			confirmed++
+		}
+	}
+	more := confirmed == esploraPageSize
+	if len(result) > limit {
+		result = result[:limit]
+		more = true
+	}
+
+	page := &TransactionPage{Transactions: make([]Transaction, 0, len(result))}
+	if more && len(result) > 0 && result[len(result)-1].Status.Confirmed {
+		page.NextCursor = result[len(result)-1].TxID
+	}
+
+	for _, tx := range result {
+		page.Transactions = append(page.Transactions, tx.transaction(address))
+	}
+	return page, nil
+}
+
+// Broadcast sends a signed transaction and returns its ID
+func (p esploraProvider) Broadcast(ctx context.Context, signedTx string) (string, error) {
+	resp, err := p.c.postBody(ctx, p.api+"/tx", "text/plain", strings.NewReader(signedTx))
+	if err != nil {
+		return "", fmt.Errorf("failed to send transaction: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("transaction failed: %w", &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)})
+	}
+
+	return string(body), nil
+}
+
+// getJSON fetches a path of the API and decodes its JSON response
+func (p esploraProvider) getJSON(ctx context.Context, path string, result interface{}) error {
+	resp, err := p.c.get(ctx, p.api+path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != 200 {
+		return &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// transaction converts a transaction to the generic format, as seen from address
+func (tx esploraTransaction) transaction(address string) Transaction {
+	var from, to string
+	var amount int64
+	isIncoming := false
+
+	if len(tx.Vin) > 0 {
+		from = tx.Vin[0].Prevout.Address
+	}
+
+	// Sent when one of the inputs is ours, the amount is what left the wallet
+	spent := false
+	for _, in := range tx.Vin {
+		if in.Prevout.Address == address {
+			spent = true
+			break
+		}
+	}
+
+	if spent {
+		for _, out := range tx.Vout {
+			if out.Address != address {
+				to = out.Address
+				amount += out.Value
+			}
+		}
+		if to == "" {
+			to = address
+		}
+	} else {
+		isIncoming = true
+		to = address
+		for _, out := range tx.Vout {
+			if out.Address == address {
+				amount += out.Value
+			}
+		}
+	}
+
+	timestamp := time.Now()
+	if tx.Status.Confirmed {
+		timestamp = time.Unix(tx.Status.BlockTime, 0)
+	}
+
+	return Transaction{
+		Hash:        tx.TxID,
+		From:        from,
+		To:          to,
+		Amount:      fmt.Sprintf("%.8f BTC", float64(amount)/100000000.0),
+		Fee:         fmt.Sprintf("%.8f BTC", float64(tx.Fee)/100000000.0),
+		BlockNumber: tx.Status.BlockHeight,
+		Timestamp:   timestamp,
+		IsIncoming:  isIncoming,
+	}
+}
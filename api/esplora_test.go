@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	testBitcoinAddress = "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"
+	testBitcoinPeer    = "bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh"
+)
+
+// esploraHandler replays the recorded responses of mempool.space by path
+func esploraHandler(t *testing.T, responses map[string]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "mempool.space" {
+			t.Errorf("request to %s, want mempool.space", r.Host)
+		}
+		name, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(fixture(t, name))
+	})
+}
+
+func TestEsploraBalance(t *testing.T) {
+	c := testClient(t, esploraHandler(t, map[string]string{
+		"/api/address/" + testBitcoinAddress: "esplora_address.json",
+	}))
+	p := esploraProvider{c: c, api: MempoolAPI}
+
+	balance, err := p.Balance(context.Background(), testBitcoinAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Confirmed 150000 - 50000, less 20000 spent in the mempool
+	if balance.Int64() != 80000 {
+		t.Errorf("balance = %s sats, want 80000", balance)
+	}
+}
+
+func TestEsploraHistory(t *testing.T) {
+	c := testClient(t, esploraHandler(t, map[string]string{
+		"/api/address/" + testBitcoinAddress + "/txs": "esplora_txs.json",
+	}))
+	p := esploraProvider{c: c, api: MempoolAPI}
+
+	page, err := p.History(context.Background(), testBitcoinAddress, 10, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Transactions) != 2 {
+		t.Fatalf("got %d transactions, want 2", len(page.Transactions))
+	}
+	if page.NextCursor != "" {
+		t.Errorf("next cursor = %q, want none after a short page", page.NextCursor)
+	}
+
+	// Only what left the wallet counts as sent, the change stays
+	sent := page.Transactions[0]
+	if sent.IsIncoming || sent.To != testBitcoinPeer || sent.Amount != "0.00015000 BTC" || sent.Fee != "0.00001000 BTC" {
+		t.Errorf("first transaction = %+v, want 0.00015 BTC sent to %s", sent, testBitcoinPeer)
+	}
+	if sent.BlockNumber != 0 {
+		t.Errorf("unconfirmed transaction in block %d", sent.BlockNumber)
+	}
+
+	received := page.Transactions[1]
+	if !received.IsIncoming || received.From != testBitcoinPeer || received.Amount != "0.00100000 BTC" {
+		t.Errorf("second transaction = %+v, want 0.001 BTC received from %s", received, testBitcoinPeer)
+	}
+	if received.BlockNumber != 830000 || !received.Timestamp.Equal(time.Unix(1707000000, 0)) {
+		t.Errorf("second transaction = %+v, want block 830000", received)
+	}
+}
+
+func TestEsploraHistoryPages(t *testing.T) {
+	c := testClient(t, esploraHandler(t, map[string]string{
+		"/api/address/" + testBitcoinAddress + "/txs/chain/a0f1e2d3c4b5a6978877665544332211ffeeddccbbaa99887766554433221100": "esplora_txs.json",
+	}))
+	p := esploraProvider{c: c, api: MempoolAPI}
+
+	// Older pages continue after the cursor's transaction
+	page, err := p.History(context.Background(), testBitcoinAddress, 2, "a0f1e2d3c4b5a6978877665544332211ffeeddccbbaa99887766554433221100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Transactions) != 2 || page.NextCursor != "" {
+		t.Errorf("page = %+v, want both transactions and no cursor", page)
+	}
+}
+
+func TestBitcoinUTXOs(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "api.blockchair.com" || r.URL.Query().Get("q") != "recipient("+testBitcoinAddress+"),is_spent(false)" {
+			t.Errorf("request to %s, want the unspent outputs of %s", r.URL, testBitcoinAddress)
+		}
+		w.Write(fixture(t, "blockchair_outputs.json"))
+	}))
+
+	utxos, err := c.GetBitcoinUTXOs(context.Background(), testBitcoinAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utxos) != 2 {
+		t.Fatalf("got %d UTXOs, want 2", len(utxos))
+	}
+	want := []BitcoinUTXO{
+		{TxID: "a0f1e2d3c4b5a6978877665544332211ffeeddccbbaa99887766554433221100", Vout: 0, Value: 100000, Script: "0014e8df018c7e326cc253faac7e46cdc51e68542c42"},
+		{TxID: "5d1a9c1f0e7b6a4c3b2e1d0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b", Vout: 1, Value: 4000, Script: "0014e8df018c7e326cc253faac7e46cdc51e68542c42"},
+	}
+	for i := range want {
+		if utxos[i] != want[i] {
+			t.Errorf("UTXO %d = %+v, want %+v", i, utxos[i], want[i])
+		}
+	}
+}
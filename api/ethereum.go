@@ -1,24 +1,56 @@
 package api
 
 import (
+	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // GetEthereumRPC returns the appropriate Ethereum RPC URL
 func (c *Client) GetEthereumRPC() string {
-	if c.IsTestnet() {
-		return TestnetEthereumRPC
+	if c.ethereumRPC != "" {
+		return c.ethereumRPC
+	}
+	return c.net.EthereumRPC
+}
+
+// DetectEthereumChainID asks an Ethereum node for its chain ID
+func DetectEthereumChainID(rpc string) (int64, error) {
+	// A local node that isn't running shouldn't hold up the command
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var result string
+	if err := NewClient().rpcCall(ctx, rpc, "eth_chainId", []interface{}{}, &result); err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(result, "0x"), 16, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid chain ID: %s", result)
+	}
+	return id, nil
+}
+
+// GetEthereumBalance fetches Ethereum balance from the configured provider
+func (c *Client) GetEthereumBalance(ctx context.Context, address string) (*big.Int, error) {
+	provider, err := c.BalanceProvider("ethereum")
+	if err != nil {
+		return nil, err
 	}
-	return MainnetEthereumRPC
+	return provider.Balance(ctx, address)
 }
 
-// GetEthereumBalance fetches Ethereum balance
-func (c *Client) GetEthereumBalance(address string) (*big.Int, error) {
+// rpcEthereumBalance fetches the balance of an address from the node
+func (c *Client) rpcEthereumBalance(ctx context.Context, address string) (*big.Int, error) {
 	// Use network-specific Ethereum RPC
 	url := c.GetEthereumRPC()
 
@@ -29,7 +61,7 @@ func (c *Client) GetEthereumBalance(address string) (*big.Int, error) {
 		"id":      1,
 	}
 
-	response, err := c.postJSON(url, payload)
+	response, err := c.postJSON(ctx, url, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -57,18 +89,30 @@ func (c *Client) GetEthereumBalance(address string) (*big.Int, error) {
 	return balance, nil
 }
 
-// GetEthereumNonce fetches Ethereum nonce
-func (c *Client) GetEthereumNonce(address string) (uint64, error) {
+// GetEthereumNonce fetches the number of transactions of an address that
+// have been mined
+func (c *Client) GetEthereumNonce(ctx context.Context, address string) (uint64, error) {
+	return c.getTransactionCount(ctx, address, "latest")
+}
+
+// GetEthereumPendingNonce fetches the next nonce of an address, counting the
+// transactions waiting in the node's pending pool
+func (c *Client) GetEthereumPendingNonce(ctx context.Context, address string) (uint64, error) {
+	return c.getTransactionCount(ctx, address, "pending")
+}
+
+// getTransactionCount fetches the transaction count of an address at a block tag
+func (c *Client) getTransactionCount(ctx context.Context, address, block string) (uint64, error) {
 	url := c.GetEthereumRPC()
 
 	payload := map[string]interface{}{
 		"jsonrpc": "2.0",
 		"method":  "eth_getTransactionCount",
-		"params":  []string{address, "latest"},
+		"params":  []string{address, block},
 		"id":      1,
 	}
 
-	response, err := c.postJSON(url, payload)
+	response, err := c.postJSON(ctx, url, payload)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch nonce: %w", err)
 	}
@@ -97,7 +141,7 @@ func (c *Client) GetEthereumNonce(address string) (uint64, error) {
 }
 
 // GetEthereumGasPrice fetches current gas price
-func (c *Client) GetEthereumGasPrice() (*big.Int, error) {
+func (c *Client) GetEthereumGasPrice(ctx context.Context) (*big.Int, error) {
 	url := c.GetEthereumRPC()
 
 	payload := map[string]interface{}{
@@ -107,7 +151,7 @@ func (c *Client) GetEthereumGasPrice() (*big.Int, error) {
 		"id":      1,
 	}
 
-	response, err := c.postJSON(url, payload)
+	response, err := c.postJSON(ctx, url, payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch gas price: %w", err)
 	}
@@ -135,8 +179,17 @@ func (c *Client) GetEthereumGasPrice() (*big.Int, error) {
 	return gasPrice, nil
 }
 
-// SendEthereumTransaction sends an Ethereum transaction
-func (c *Client) SendEthereumTransaction(signedTx string) (string, error) {
+// SendEthereumTransaction sends an Ethereum transaction with the configured provider
+func (c *Client) SendEthereumTransaction(ctx context.Context, signedTx string) (string, error) {
+	provider, err := c.BroadcastProvider("ethereum")
+	if err != nil {
+		return "", err
+	}
+	return provider.Broadcast(ctx, signedTx)
+}
+
+// rpcSendEthereumTransaction sends a signed transaction to the node
+func (c *Client) rpcSendEthereumTransaction(ctx context.Context, signedTx string) (string, error) {
 	url := c.GetEthereumRPC()
 
 	payload := map[string]interface{}{
@@ -146,7 +199,7 @@ func (c *Client) SendEthereumTransaction(signedTx string) (string, error) {
 		"id":      1,
 	}
 
-	response, err := c.postJSON(url, payload)
+	response, err := c.postJSON(ctx, url, payload)
 	if err != nil {
 		return "", fmt.Errorf("failed to send transaction: %w", err)
 	}
@@ -172,58 +225,165 @@ func (c *Client) SendEthereumTransaction(signedTx string) (string, error) {
 	return txHash, nil
 }
 
-// GetEthereumTransactions fetches transaction history for an Ethereum address
-func (c *Client) GetEthereumTransactions(address string) ([]Transaction, error) {
-	url := c.GetEthereumRPC()
+// EthereumTransactionKnown returns true if the node knows a transaction,
+// either pending or mined
+func (c *Client) EthereumTransactionKnown(ctx context.Context, txHash string) (bool, error) {
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "eth_getTransactionByHash",
+		"params":  []string{txHash},
+		"id":      1,
+	}
 
-	// For testnets, we'll use a more direct approach instead of logs filtering
-	// since many test networks don't have great log support
-	if c.IsTestnet() {
-		return c.getEthereumTransactionsDirect(address)
+	response, err := c.postJSON(ctx, c.GetEthereumRPC(), payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch transaction: %w", err)
 	}
 
-	// Get block number for filtering (last 10000 blocks)
-	blockPayload := map[string]interface{}{
+	var rpcResp EthereumRPCResponse
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return false, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return false, fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+
+	return rpcResp.Result != nil, nil
+}
+
+// EthereumTransactionConfirmed returns true if a transaction has been mined
+// successfully
+func (c *Client) EthereumTransactionConfirmed(ctx context.Context, txHash string) (bool, error) {
+	payload := map[string]interface{}{
 		"jsonrpc": "2.0",
+		"method":  "eth_getTransactionReceipt",
+		"params":  []string{txHash},
 		"id":      1,
-		"method":  "eth_blockNumber",
-		"params":  []interface{}{},
 	}
 
-	blockResp, err := c.postJSON(url, blockPayload)
+	response, err := c.postJSON(ctx, c.GetEthereumRPC(), payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch block number: %w", err)
+		return false, fmt.Errorf("failed to fetch transaction receipt: %w", err)
 	}
 
-	var blockResult struct {
-		Result string `json:"result"`
+	var rpcResp struct {
+		Result *struct {
+			BlockNumber string `json:"blockNumber"`
+			Status      string `json:"status"`
+		} `json:"result"`
+		Error *RPCError `json:"error"`
+	}
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return false, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if err := json.Unmarshal(blockResp, &blockResult); err != nil {
-		return nil, fmt.Errorf("failed to parse block number: %w", err)
+	if rpcResp.Error != nil {
+		return false, fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+
+	// No receipt yet while the transaction is pending
+	if rpcResp.Result == nil || rpcResp.Result.BlockNumber == "" {
+		return false, nil
+	}
+	if rpcResp.Result.Status == "0x0" {
+		return false, fmt.Errorf("transaction %s reverted", txHash)
+	}
+
+	return true, nil
+}
+
+// CallEthereumContract runs a read-only contract call and returns the raw result
+func (c *Client) CallEthereumContract(ctx context.Context, to string, data []byte) ([]byte, error) {
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "eth_call",
+		"params": []interface{}{
+			map[string]string{"to": to, "data": fmt.Sprintf("0x%x", data)},
+			"latest",
+		},
+		"id": 1,
+	}
+
+	response, err := c.postJSON(ctx, c.GetEthereumRPC(), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call contract: %w", err)
+	}
+
+	var rpcResp EthereumRPCResponse
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+
+	resultStr, ok := rpcResp.Result.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid call result format")
+	}
+
+	// Addresses without code return "0x"
+	if strings.TrimPrefix(resultStr, "0x") == "" {
+		return nil, fmt.Errorf("no contract found at %s", to)
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(resultStr, "0x"))
+}
+
+// Number of blocks scanned per page of Ethereum history
+const (
+	ethereumLogWindow    = 10000 // eth_getLogs range on mainnet
+	ethereumDirectWindow = 50    // full block scan on testnets
+)
+
+// GetEthereumTransactions fetches a page of transaction history for an Ethereum
+// address from the configured provider
+func (c *Client) GetEthereumTransactions(ctx context.Context, address string, limit int, cursor string) (*TransactionPage, error) {
+	ctx = withOperation(ctx, config.OperationHistory)
+	provider, err := c.HistoryProvider("ethereum")
+	if err != nil {
+		return nil, err
 	}
+	return provider.History(ctx, address, limit, cursor)
+}
 
-	// Parse current block number
-	currentBlockHex := blockResult.Result
-	currentBlock, err := parseHexInt(currentBlockHex)
+// rpcEthereumTransactions scans the node for the transactions of an address.
+// The cursor is the highest block number (inclusive) to scan from; an empty
+// cursor starts at the latest block.
+func (c *Client) rpcEthereumTransactions(ctx context.Context, address string, limit int, cursor string) (*TransactionPage, error) {
+	url := c.GetEthereumRPC()
+
+	toBlock, err := c.resolveEthereumCursor(ctx, cursor)
 	if err != nil {
-		return nil, fmt.Errorf("invalid block number: %w", err)
+		return nil, err
+	}
+
+	// For testnets, we'll use a more direct approach instead of logs filtering
+	// since many test networks don't have great log support
+	if c.IsTestnet() {
+		return c.getEthereumTransactionsDirect(ctx, address, limit, toBlock)
+	}
+
+	// Scan a fixed window of blocks ending at the cursor
+	fromBlock := uint64(0)
+	if toBlock >= ethereumLogWindow {
+		fromBlock = toBlock - ethereumLogWindow + 1
 	}
 
-	// Create filter for transactions
-	fromBlock := fmt.Sprintf("0x%x", currentBlock-10000) // Last ~10000 blocks
 	filterPayload := map[string]interface{}{
 		"jsonrpc": "2.0",
 		"id":      1,
 		"method":  "eth_getLogs",
 		"params": []interface{}{map[string]interface{}{
-			"fromBlock": fromBlock,
-			"toBlock":   "latest",
+			"fromBlock": fmt.Sprintf("0x%x", fromBlock),
+			"toBlock":   fmt.Sprintf("0x%x", toBlock),
 			"address":   []string{address},
 		}},
 	}
 
-	filterResp, err := c.postJSON(url, filterPayload)
+	filterResp, err := c.postJSON(ctx, url, filterPayload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch logs: %w", err)
 	}
@@ -255,7 +415,7 @@ func (c *Client) GetEthereumTransactions(address string) ([]Transaction, error)
 			"params":  []interface{}{txHash},
 		}
 
-		txResp, err := c.postJSON(url, txPayload)
+		txResp, err := c.postJSON(ctx, url, txPayload)
 		if err != nil {
 			continue // Skip this transaction
 		}
@@ -288,7 +448,7 @@ func (c *Client) GetEthereumTransactions(address string) ([]Transaction, error)
 			"params":  []interface{}{txResult.Result.BlockNumber, false},
 		}
 
-		blockResp, err := c.postJSON(url, blockPayload)
+		blockResp, err := c.postJSON(ctx, url, blockPayload)
 		if err != nil {
 			continue // Skip this transaction
 		}
@@ -323,10 +483,10 @@ func (c *Client) GetEthereumTransactions(address string) ([]Transaction, error)
 
 		tx := Transaction{
 			Hash:        txResult.Result.Hash,
-			From:        txResult.Result.From,
-			To:          txResult.Result.To,
-			Amount:      fmt.Sprintf("%.6f ETH", valueEth),
-			Fee:         fmt.Sprintf("%.6f ETH", feeEth),
+			From:        checksumAddress(txResult.Result.From),
+			To:          checksumAddress(txResult.Result.To),
+			Amount:      valueEth.StringFixed(6) + " ETH",
+			Fee:         feeEth.StringFixed(6) + " ETH",
 			BlockNumber: int64(blockNumber),
 			Timestamp:   time.Unix(int64(timestamp), 0),
 			IsIncoming:  isIncoming,
@@ -335,56 +495,45 @@ func (c *Client) GetEthereumTransactions(address string) ([]Transaction, error)
 		transactions = append(transactions, tx)
 	}
 
-	return transactions, nil
-}
-
-// getEthereumTransactionsDirect gets transactions using a simpler approach for testnets
-// that works better with Sepolia and other test networks
-func (c *Client) getEthereumTransactionsDirect(address string) ([]Transaction, error) {
-	url := c.GetEthereumRPC()
-
-	// Get the latest block number
-	blockPayload := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"id":      1,
-		"method":  "eth_blockNumber",
-		"params":  []interface{}{},
-	}
-
-	blockResp, err := c.postJSON(url, blockPayload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch block number: %w", err)
-	}
+	// Newest first, so the page can be cut at a block boundary
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].BlockNumber > transactions[j].BlockNumber
+	})
 
-	var blockResult struct {
-		Result string `json:"result"`
+	page := &TransactionPage{Transactions: []Transaction{}}
+	for _, tx := range transactions {
+		if len(page.Transactions) >= limit {
+			lastBlock := page.Transactions[len(page.Transactions)-1].BlockNumber
+			if tx.BlockNumber != lastBlock {
+				// Continue below the last fully included block
+				page.NextCursor = strconv.FormatInt(lastBlock-1, 10)
+				return page, nil
+			}
+		}
+		page.Transactions = append(page.Transactions, tx)
 	}
 
-	if err := json.Unmarshal(blockResp, &blockResult); err != nil {
-		return nil, fmt.Errorf("failed to parse block number: %w", err)
+	// Window exhausted, continue with the next older window
+	if fromBlock > 0 {
+		page.NextCursor = strconv.FormatUint(fromBlock-1, 10)
 	}
 
-	// Parse current block number
-	currentBlockHex := blockResult.Result
-	currentBlock, err := parseHexInt(currentBlockHex)
-	if err != nil {
-		return nil, fmt.Errorf("invalid block number: %w", err)
-	}
+	return page, nil
+}
 
-	var transactions []Transaction
+// getEthereumTransactionsDirect gets transactions using a simpler approach for testnets
+// that works better with Sepolia and other test networks
+func (c *Client) getEthereumTransactionsDirect(ctx context.Context, address string, limit int, toBlock uint64) (*TransactionPage, error) {
+	url := c.GetEthereumRPC()
 
-	// Check for transactions in last 50 blocks (limit our search for performance)
-	blocksToCheck := uint64(50)
-	if currentBlock < blocksToCheck {
-		blocksToCheck = currentBlock
-	}
+	page := &TransactionPage{Transactions: []Transaction{}}
 
 	// Map to keep track of processed transactions to avoid duplicates
 	processedTxs := make(map[string]bool)
 
-	// We'll check each block for transactions to/from the address
-	for i := uint64(0); i < blocksToCheck; i++ {
-		blockNumber := currentBlock - i
+	// Check a limited number of blocks per page for performance
+	blockNumber := toBlock
+	for scanned := 0; scanned < ethereumDirectWindow; scanned++ {
 		blockNumberHex := fmt.Sprintf("0x%x", blockNumber)
 
 		// Get block with transactions
@@ -395,92 +544,126 @@ func (c *Client) getEthereumTransactionsDirect(address string) ([]Transaction, e
 			"params":  []interface{}{blockNumberHex, true},
 		}
 
-		blockWithTxsResp, err := c.postJSON(url, blockWithTxsPayload)
-		if err != nil {
-			continue // Skip this block if we can't fetch it
-		}
+		blockWithTxsResp, err := c.postJSON(ctx, url, blockWithTxsPayload)
+		if err == nil {
+			var blockWithTxs struct {
+				Result struct {
+					Transactions []struct {
+						Hash     string `json:"hash"`
+						From     string `json:"from"`
+						To       string `json:"to"`
+						Value    string `json:"value"`
+						Gas      string `json:"gas"`
+						GasPrice string `json:"gasPrice"`
+					} `json:"transactions"`
+					Timestamp string `json:"timestamp"`
+				} `json:"result"`
+			}
 
-		var blockWithTxs struct {
-			Result struct {
-				Transactions []struct {
-					Hash     string `json:"hash"`
-					From     string `json:"from"`
-					To       string `json:"to"`
-					Value    string `json:"value"`
-					Gas      string `json:"gas"`
-					GasPrice string `json:"gasPrice"`
-				} `json:"transactions"`
-				Timestamp string `json:"timestamp"`
-			} `json:"result"`
+			if err := json.Unmarshal(blockWithTxsResp, &blockWithTxs); err == nil {
+				// Get timestamp
+				timestamp, _ := parseHexInt(blockWithTxs.Result.Timestamp)
+
+				// Loop through transactions in the block
+				for _, tx := range blockWithTxs.Result.Transactions {
+					// Skip if transaction doesn't involve our address
+					if !strings.EqualFold(tx.From, address) && !strings.EqualFold(tx.To, address) {
+						continue
+					}
+
+					// Skip if we've already processed this transaction
+					if processedTxs[tx.Hash] {
+						continue
+					}
+
+					processedTxs[tx.Hash] = true
+
+					// Parse values
+					value, _ := parseHexBigInt(tx.Value)
+					gasPrice, _ := parseHexBigInt(tx.GasPrice)
+					gas, _ := parseHexInt(tx.Gas)
+
+					// Calculate fee (gas * gasPrice)
+					gasBigInt := big.NewInt(int64(gas))
+					fee := new(big.Int).Mul(gasBigInt, gasPrice)
+
+					// Convert values
+					valueEth := weiToEth(value)
+					feeEth := weiToEth(fee)
+
+					// Determine if incoming or outgoing
+					isIncoming := strings.EqualFold(tx.To, address)
+
+					page.Transactions = append(page.Transactions, Transaction{
+						Hash:        tx.Hash,
+						From:        checksumAddress(tx.From),
+						To:          checksumAddress(tx.To),
+						Amount:      valueEth.StringFixed(6) + " ETH",
+						Fee:         feeEth.StringFixed(6) + " ETH",
+						BlockNumber: int64(blockNumber),
+						Timestamp:   time.Unix(int64(timestamp), 0),
+						IsIncoming:  isIncoming,
+					})
+				}
+			}
 		}
 
-		if err := json.Unmarshal(blockWithTxsResp, &blockWithTxs); err != nil {
-			continue // Skip this block if we can't parse it
+		// Reached genesis, nothing older to scan
+		if blockNumber == 0 {
+			return page, nil
 		}
+		blockNumber--
 
-		// Skip if block has no transactions
-		if blockWithTxs.Result.Transactions == nil {
-			continue
+		// Only stop at block boundaries so no transaction is split across pages
+		if len(page.Transactions) >= limit {
+			break
 		}
+	}
 
-		// Get timestamp
-		timestamp, _ := parseHexInt(blockWithTxs.Result.Timestamp)
+	page.NextCursor = strconv.FormatUint(blockNumber, 10)
+	return page, nil
+}
 
-		// Loop through transactions in the block
-		for _, tx := range blockWithTxs.Result.Transactions {
-			// Check if transaction involves our address
-			addrLower := strings.ToLower(address)
-			fromLower := strings.ToLower(tx.From)
-			toLower := strings.ToLower(tx.To)
+// resolveEthereumCursor returns the block number a history page starts from
+func (c *Client) resolveEthereumCursor(ctx context.Context, cursor string) (uint64, error) {
+	if cursor != "" {
+		block, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid Ethereum cursor %q: must be a block number", cursor)
+		}
+		return block, nil
+	}
 
-			// Skip if transaction doesn't involve our address
-			if fromLower != addrLower && toLower != addrLower {
-				continue
-			}
+	blockPayload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "eth_blockNumber",
+		"params":  []interface{}{},
+	}
 
-			// Skip if we've already processed this transaction
-			if processedTxs[tx.Hash] {
-				continue
-			}
+	blockResp, err := c.postJSON(ctx, c.GetEthereumRPC(), blockPayload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch block number: %w", err)
+	}
 
-			processedTxs[tx.Hash] = true
-
-			// Parse values
-			value, _ := parseHexBigInt(tx.Value)
-			gasPrice, _ := parseHexBigInt(tx.GasPrice)
-			gas, _ := parseHexInt(tx.Gas)
-
-			// Calculate fee (gas * gasPrice)
-			gasBigInt := big.NewInt(int64(gas))
-			fee := new(big.Int).Mul(gasBigInt, gasPrice)
-
-			// Convert values
-			valueEth := weiToEth(value)
-			feeEth := weiToEth(fee)
-
-			// Determine if incoming or outgoing
-			isIncoming := strings.EqualFold(tx.To, address)
-
-			transaction := Transaction{
-				Hash:        tx.Hash,
-				From:        tx.From,
-				To:          tx.To,
-				Amount:      fmt.Sprintf("%.6f ETH", valueEth),
-				Fee:         fmt.Sprintf("%.6f ETH", feeEth),
-				BlockNumber: int64(blockNumber),
-				Timestamp:   time.Unix(int64(timestamp), 0),
-				IsIncoming:  isIncoming,
-			}
+	var blockResult struct {
+		Result string `json:"result"`
+	}
 
-			transactions = append(transactions, transaction)
-		}
+	if err := json.Unmarshal(blockResp, &blockResult); err != nil {
+		return 0, fmt.Errorf("failed to parse block number: %w", err)
 	}
 
-	return transactions, nil
+	currentBlock, err := parseHexInt(blockResult.Result)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block number: %w", err)
+	}
+
+	return currentBlock, nil
 }
 
 // GetEthereumGasEstimate estimates the gas needed for an ETH transaction
-func (c *Client) GetEthereumGasEstimate(from string, to string, value *big.Int, data []byte) (uint64, error) {
+func (c *Client) GetEthereumGasEstimate(ctx context.Context, from string, to string, value *big.Int, data []byte) (uint64, error) {
 	url := c.GetEthereumRPC()
 
 	// Prepare transaction object for gas estimation
@@ -506,7 +689,7 @@ func (c *Client) GetEthereumGasEstimate(from string, to string, value *big.Int,
 		"params":  []interface{}{txObject},
 	}
 
-	response, err := c.postJSON(url, payload)
+	response, err := c.postJSON(ctx, url, payload)
 	if err != nil {
 		// If estimation fails, use a conservative default
 		return 50000, nil
@@ -539,6 +722,15 @@ func (c *Client) GetEthereumGasEstimate(from string, to string, value *big.Int,
 	return gas, nil
 }
 
+// checksumAddress returns an address as nodes report it, in lowercase, in
+// its EIP-55 checksummed form. Anything else is returned unchanged.
+func checksumAddress(address string) string {
+	if !common.IsHexAddress(address) {
+		return address
+	}
+	return common.HexToAddress(address).Hex()
+}
+
 // Helper to convert hex string to int
 func parseHexInt(hexStr string) (uint64, error) {
 	// Remove '0x' prefix if present
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/config"
+)
+
+// GetEtherscanABI fetches the ABI of a verified contract from Etherscan
+func (c *Client) GetEtherscanABI(ctx context.Context, address, apiKey string) (string, error) {
+	if apiKey == "" {
+		return "", fmt.Errorf("an Etherscan API key is required. Set one with 'odyssey config set etherscan.api_key <key>'")
+	}
+
+	params := url.Values{}
+	params.Set("chainid", strconv.FormatInt(c.EthereumChainID(), 10))
+	params.Set("module", "contract")
+	params.Set("action", "getabi")
+	params.Set("address", address)
+	params.Set("apikey", apiKey)
+
+	resp, err := c.get(ctx, EtherscanAPI+"?"+params.Encode())
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch ABI: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != 200 {
+		return "", &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Result  string `json:"result"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Errors such as unverified contracts are reported in result
+	if result.Status != "1" {
+		return "", fmt.Errorf("etherscan: %s", result.Result)
+	}
+
+	return result.Result, nil
+}
+
+// etherscanProvider serves Ethereum balances, history and broadcasts from
+// the Etherscan API of the network's chain
+type etherscanProvider struct {
+	c      *Client
+	apiKey string
+}
+
+func newEtherscanProvider(c *Client) (interface{}, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	apiKey := cfg.Get(config.KeyEtherscanAPIKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("an Etherscan API key is required. Set one with 'odyssey config set %s <key>'", config.KeyEtherscanAPIKey)
+	}
+	return etherscanProvider{c: c, apiKey: apiKey}, nil
+}
+
+// Balance returns the balance of an address in wei
+func (p etherscanProvider) Balance(ctx context.Context, address string) (*big.Int, error) {
+	params := url.Values{}
+	params.Set("module", "account")
+	params.Set("action", "balance")
+	params.Set("address", address)
+	params.Set("tag", "latest")
+
+	var balance string
+	if err := p.call(ctx, params, &balance); err != nil {
+		return nil, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+	value, ok := new(big.Int).SetString(balance, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid balance format")
+	}
+	return value, nil
+}
+
+// History returns a page of the transactions of an address. The cursor is
+// the number of the page; an empty cursor starts at the newest.
+func (p etherscanProvider) History(ctx context.Context, address string, limit int, cursor string) (*TransactionPage, error) {
+	pageNumber := 1
+	if cursor != "" {
+		var err error
+		pageNumber, err = strconv.Atoi(cursor)
+		if err != nil || pageNumber < 1 {
+			return nil, fmt.Errorf("invalid Etherscan cursor %q: must be a page number", cursor)
+		}
+	}
+
+	params := url.Values{}
+	params.Set("module", "account")
+	params.Set("action", "txlist")
+	params.Set("address", address)
+	params.Set("page", strconv.Itoa(pageNumber))
+	params.Set("offset", strconv.Itoa(limit))
+	params.Set("sort", "desc")
+
+	var result []struct {
+		Hash        string `json:"hash"`
+		From        string `json:"from"`
+		To          string `json:"to"`
+		Value       string `json:"value"`
+		GasUsed     string `json:"gasUsed"`
+		GasPrice    string `json:"gasPrice"`
+		BlockNumber string `json:"blockNumber"`
+		TimeStamp   string `json:"timeStamp"`
+	}
+	if err := p.call(ctx, params, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
+	}
+
+	page := &TransactionPage{Transactions: make([]Transaction, 0, len(result))}
+	if len(result) == limit {
+		page.NextCursor = strconv.Itoa(pageNumber + 1)
+	}
+
+	for _, tx := range result {
+		value := parseEtherscanInt(tx.Value)
+		fee := new(big.Int).Mul(parseEtherscanInt(tx.GasUsed), parseEtherscanInt(tx.GasPrice))
+		blockNumber, _ := strconv.ParseInt(tx.BlockNumber, 10, 64)
+		timestamp, _ := strconv.ParseInt(tx.TimeStamp, 10, 64)
+
+		page.Transactions = append(page.Transactions, Transaction{
+			Hash:        tx.Hash,
+			From:        checksumAddress(tx.From),
+			To:          checksumAddress(tx.To),
+			Amount:      weiToEth(value).StringFixed(6) + " ETH",
+			Fee:         weiToEth(fee).StringFixed(6) + " ETH",
+			BlockNumber: blockNumber,
+			Timestamp:   time.Unix(timestamp, 0),
+			IsIncoming:  strings.EqualFold(tx.To, address),
+		})
+	}
+	return page, nil
+}
+
+// Broadcast sends a signed transaction through Etherscan's node proxy
+func (p etherscanProvider) Broadcast(ctx context.Context, signedTx string) (string, error) {
+	params := url.Values{}
+	params.Set("module", "proxy")
+	params.Set("action", "eth_sendRawTransaction")
+	params.Set("hex", signedTx)
+
+	var response struct {
+		Status string          `json:"status"`
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := p.request(ctx, params, &response); err != nil {
+		return "", fmt.Errorf("failed to send transaction: %w", err)
+	}
+	if response.Error != nil {
+		return "", fmt.Errorf("RPC error: %s", response.Error.Message)
+	}
+
+	// Errors of Etherscan itself, e.g. an invalid API key, come as the result
+	var result string
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		return "", fmt.Errorf("invalid transaction hash format")
+	}
+	if response.Status == "0" {
+		return "", fmt.Errorf("etherscan: %s", result)
+	}
+	return result, nil
+}
+
+// call makes an account API request and decodes its result. Lookups that
+// find nothing are not an error.
+func (p etherscanProvider) call(ctx context.Context, params url.Values, result interface{}) error {
+	var response struct {
+		Status  string          `json:"status"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := p.request(ctx, params, &response); err != nil {
+		return err
+	}
+
+	if response.Status != "1" && !strings.HasPrefix(response.Message, "No transactions found") {
+		var reason string
+		if json.Unmarshal(response.Result, &reason) != nil || reason == "" {
+			reason = response.Message
+		}
+		return fmt.Errorf("etherscan: %s", reason)
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// request sends a request for the network's chain and decodes the response
+func (p etherscanProvider) request(ctx context.Context, params url.Values, response interface{}) error {
+	params.Set("chainid", strconv.FormatInt(p.c.EthereumChainID(), 10))
+	params.Set("apikey", p.apiKey)
+
+	resp, err := p.c.get(ctx, EtherscanAPI+"?"+params.Encode())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != 200 {
+		return &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if err := json.Unmarshal(body, response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// parseEtherscanInt parses a decimal amount, zero if it is missing or invalid
+func parseEtherscanInt(value string) *big.Int {
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return n
+}
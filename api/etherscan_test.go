@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testEthereumAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	testEthereumPeer    = "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"
+)
+
+// etherscanHandler replays the recorded response of each action, checking
+// the chain and API key of every request
+func etherscanHandler(t *testing.T, responses map[string]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.Host != "api.etherscan.io" || r.URL.Path != "/v2/api" {
+			t.Errorf("request to %s%s, want the Etherscan API", r.Host, r.URL.Path)
+		}
+		if query.Get("chainid") != "1" || query.Get("apikey") != "test-key" {
+			t.Errorf("chainid = %q, apikey = %q, want 1 and test-key", query.Get("chainid"), query.Get("apikey"))
+		}
+
+		name, ok := responses[query.Get("action")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(fixture(t, name))
+	})
+}
+
+func TestEtherscanBalance(t *testing.T) {
+	c := testClient(t, etherscanHandler(t, map[string]string{"balance": "etherscan_balance.json"}))
+	p := etherscanProvider{c: c, apiKey: "test-key"}
+
+	balance, err := p.Balance(context.Background(), testEthereumAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.String() != "1234500000000000000" {
+		t.Errorf("balance = %s wei, want 1234500000000000000", balance)
+	}
+}
+
+func TestEtherscanHistory(t *testing.T) {
+	c := testClient(t, etherscanHandler(t, map[string]string{"txlist": "etherscan_txlist.json"}))
+	p := etherscanProvider{c: c, apiKey: "test-key"}
+
+	page, err := p.History(context.Background(), testEthereumAddress, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Transactions) != 2 {
+		t.Fatalf("got %d transactions, want 2", len(page.Transactions))
+	}
+	if page.NextCursor != "2" {
+		t.Errorf("next cursor = %q, want the second page", page.NextCursor)
+	}
+
+	sent := page.Transactions[0]
+	if sent.IsIncoming || sent.From != testEthereumAddress || sent.To != testEthereumPeer {
+		t.Errorf("first transaction = %+v, want sent to %s", sent, testEthereumPeer)
+	}
+	if sent.Amount != "0.250000 ETH" || sent.Fee != "0.000630 ETH" {
+		t.Errorf("amount %s, fee %s, want 0.250000 ETH and 0.000630 ETH", sent.Amount, sent.Fee)
+	}
+
+	received := page.Transactions[1]
+	if !received.IsIncoming || received.From != testEthereumPeer {
+		t.Errorf("second transaction = %+v, want received from %s", received, testEthereumPeer)
+	}
+	if received.Amount != "0.500000 ETH" || received.BlockNumber != 19000001 || !received.Timestamp.Equal(time.Unix(1705000000, 0)) {
+		t.Errorf("second transaction = %+v, want 0.5 ETH in block 19000001", received)
+	}
+}
+
+func TestEtherscanHistoryWithoutTransactions(t *testing.T) {
+	c := testClient(t, etherscanHandler(t, map[string]string{"txlist": "etherscan_no_transactions.json"}))
+	p := etherscanProvider{c: c, apiKey: "test-key"}
+
+	page, err := p.History(context.Background(), testEthereumAddress, 10, "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Transactions) != 0 || page.NextCursor != "" {
+		t.Errorf("page = %+v, want an empty last page", page)
+	}
+}
+
+func TestEtherscanError(t *testing.T) {
+	c := testClient(t, etherscanHandler(t, map[string]string{"balance": "etherscan_invalid_key.json"}))
+	p := etherscanProvider{c: c, apiKey: "test-key"}
+
+	_, err := p.Balance(context.Background(), testEthereumAddress)
+	if err == nil || !strings.Contains(err.Error(), "Invalid API Key") {
+		t.Errorf("error = %v, want Etherscan's reason", err)
+	}
+}
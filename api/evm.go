@@ -0,0 +1,210 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// EVMChain is an EVM compatible chain besides Ethereum. The Ethereum key
+// and address are valid on every one of them.
+type EVMChain struct {
+	Name        string   // name used on the command line
+	Aliases     []string // other accepted names
+	DisplayName string
+	Symbol      string // native asset
+	PriceID     string // CoinGecko ID of the native asset
+
+	MainnetChainID  int64
+	TestnetChainID  int64
+	MainnetRPC      string
+	TestnetRPC      string
+	MainnetExplorer string
+	TestnetExplorer string
+	TestnetName     string
+
+	// Gas handling
+	GasPriceBump int64 // percent added to the node's gas price for faster inclusion
+	MinGasPrice  int64 // wei, nodes of some chains reject lower prices
+	L1DataFee    bool  // OP Stack rollup, pays an extra fee for posting to Ethereum
+
+	BlockTime time.Duration // average time between blocks
+}
+
+// EVMChains are the supported EVM chains besides Ethereum
+var EVMChains = []EVMChain{
+	{
+		Name:            "polygon",
+		Aliases:         []string{"matic", "pol"},
+		DisplayName:     "Polygon",
+		Symbol:          "POL",
+		PriceID:         "polygon-ecosystem-token",
+		MainnetChainID:  137,
+		TestnetChainID:  80002,
+		MainnetRPC:      "https://polygon-rpc.com",
+		TestnetRPC:      "https://rpc-amoy.polygon.technology",
+		MainnetExplorer: "https://polygonscan.com",
+		TestnetExplorer: "https://amoy.polygonscan.com",
+		TestnetName:     "Amoy",
+		GasPriceBump:    20,
+		MinGasPrice:     25_000_000_000, // 25 gwei minimum priority fee
+		BlockTime:       2 * time.Second,
+	},
+	{
+		Name:            "arbitrum",
+		Aliases:         []string{"arb"},
+		DisplayName:     "Arbitrum One",
+		Symbol:          "ETH",
+		PriceID:         "ethereum",
+		MainnetChainID:  42161,
+		TestnetChainID:  421614,
+		MainnetRPC:      "https://arb1.arbitrum.io/rpc",
+		TestnetRPC:      "https://sepolia-rollup.arbitrum.io/rpc",
+		MainnetExplorer: "https://arbiscan.io",
+		TestnetExplorer: "https://sepolia.arbiscan.io",
+		TestnetName:     "Sepolia",
+		// The gas estimate already includes the L1 cost, the base fee rarely moves
+		GasPriceBump: 10,
+		BlockTime:    250 * time.Millisecond,
+	},
+	{
+		Name:            "optimism",
+		Aliases:         []string{"op"},
+		DisplayName:     "OP Mainnet",
+		Symbol:          "ETH",
+		PriceID:         "ethereum",
+		MainnetChainID:  10,
+		TestnetChainID:  11155420,
+		MainnetRPC:      "https://mainnet.optimism.io",
+		TestnetRPC:      "https://sepolia.optimism.io",
+		MainnetExplorer: "https://optimistic.etherscan.io",
+		TestnetExplorer: "https://sepolia-optimism.etherscan.io",
+		TestnetName:     "Sepolia",
+		GasPriceBump:    10,
+		L1DataFee:       true,
+		BlockTime:       2 * time.Second,
+	},
+	{
+		Name:            "base",
+		DisplayName:     "Base",
+		Symbol:          "ETH",
+		PriceID:         "ethereum",
+		MainnetChainID:  8453,
+		TestnetChainID:  84532,
+		MainnetRPC:      "https://mainnet.base.org",
+		TestnetRPC:      "https://sepolia.base.org",
+		MainnetExplorer: "https://basescan.org",
+		TestnetExplorer: "https://sepolia.basescan.org",
+		TestnetName:     "Sepolia",
+		GasPriceBump:    10,
+		L1DataFee:       true,
+		BlockTime:       2 * time.Second,
+	},
+	{
+		Name:            "bsc",
+		Aliases:         []string{"bnb", "binance"},
+		DisplayName:     "BNB Smart Chain",
+		Symbol:          "BNB",
+		PriceID:         "binancecoin",
+		MainnetChainID:  56,
+		TestnetChainID:  97,
+		MainnetRPC:      "https://bsc-dataseed.bnbchain.org",
+		TestnetRPC:      "https://data-seed-prebsc-1-s1.bnbchain.org:8545",
+		MainnetExplorer: "https://bscscan.com",
+		TestnetExplorer: "https://testnet.bscscan.com",
+		TestnetName:     "Testnet",
+		GasPriceBump:    0, // fixed gas price, a bump only overpays
+		BlockTime:       750 * time.Millisecond,
+	},
+}
+
+// FindEVMChain looks up an EVM chain by name or alias
+func FindEVMChain(name string) (*EVMChain, bool) {
+	name = strings.ToLower(name)
+	for i := range EVMChains {
+		chain := &EVMChains[i]
+		if chain.Name == name {
+			return chain, true
+		}
+		for _, alias := range chain.Aliases {
+			if alias == name {
+				return chain, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// EVMChainNames lists the names of the supported EVM chains
+func EVMChainNames() []string {
+	names := make([]string, len(EVMChains))
+	for i, chain := range EVMChains {
+		names[i] = chain.Name
+	}
+	return names
+}
+
+// ChainID returns the chain ID on mainnet or testnet
+func (e *EVMChain) ChainID(testnet bool) *big.Int {
+	if testnet {
+		return big.NewInt(e.TestnetChainID)
+	}
+	return big.NewInt(e.MainnetChainID)
+}
+
+// Explorer returns the block explorer URL on mainnet or testnet
+func (e *EVMChain) Explorer(testnet bool) string {
+	if testnet {
+		return e.TestnetExplorer
+	}
+	return e.MainnetExplorer
+}
+
+// Label names the chain including the testnet in use
+func (e *EVMChain) Label(testnet bool) string {
+	if testnet {
+		return fmt.Sprintf("%s (%s)", e.DisplayName, e.TestnetName)
+	}
+	return e.DisplayName
+}
+
+// ForEVMChain returns a client whose Ethereum calls go to the node of another
+// EVM chain, on the same network as c
+func (c *Client) ForEVMChain(chain *EVMChain) *Client {
+	rpc := chain.MainnetRPC
+	if c.IsTestnet() {
+		rpc = chain.TestnetRPC
+	}
+	return &Client{httpClient: c.httpClient, network: c.network, net: c.net, ethereumRPC: rpc, endpoints: c.endpoints, timeouts: c.timeouts}
+}
+
+// OPStackGasPriceOracle is the predeploy that prices L1 data on OP Stack chains
+const OPStackGasPriceOracle = "0x420000000000000000000000000000000000000F"
+
+// GetL1DataFee returns the fee an OP Stack chain charges on top of the gas
+// for posting a signed transaction to Ethereum
+func (c *Client) GetL1DataFee(ctx context.Context, signedTx []byte) (*big.Int, error) {
+	// getL1Fee(bytes) with the transaction as the only dynamic argument
+	data := "49948e0e" + fmt.Sprintf("%064x", 32) + fmt.Sprintf("%064x", len(signedTx)) + hex.EncodeToString(signedTx)
+	if padding := len(signedTx) % 32; padding != 0 {
+		data += strings.Repeat("00", 32-padding)
+	}
+
+	raw, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode getL1Fee: %w", err)
+	}
+
+	result, err := c.CallEthereumContract(ctx, OPStackGasPriceOracle, raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 data fee: %w", err)
+	}
+	if len(result) < 32 {
+		return nil, fmt.Errorf("failed to get L1 data fee: unexpected result")
+	}
+
+	return new(big.Int).SetBytes(result[:32]), nil
+}
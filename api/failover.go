@@ -0,0 +1,142 @@
+package api
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chinmay1088/odyssey/config"
+)
+
+const (
+	// A node that failed is skipped for rpcCooldown, doubled for every
+	// further failure in a row up to maxRPCCooldown
+	rpcCooldown    = 30 * time.Second
+	maxRPCCooldown = 5 * time.Minute
+)
+
+// rpcHealth is what this process learned about a node
+type rpcHealth struct {
+	failures  int       // failures in a row
+	downUntil time.Time // skipped until then
+}
+
+var (
+	rpcHealthMu sync.Mutex
+	rpcNodes    = make(map[string]*rpcHealth)
+)
+
+// defaultRPCChains maps the built-in nodes to their chain and network, so the
+// nodes configured for that chain can be tried in their place
+func defaultRPCChains() map[string][2]string {
+	defaults := map[string][2]string{
+		MainnetEthereumRPC: {"ethereum", NetworkMainnet},
+		TestnetEthereumRPC: {"ethereum", NetworkTestnet},
+		MainnetSolanaRPC:   {"solana", NetworkMainnet},
+		TestnetSolanaRPC:   {"solana", NetworkTestnet},
+	}
+	for _, chain := range EVMChains {
+		defaults[chain.MainnetRPC] = [2]string{chain.Name, NetworkMainnet}
+		defaults[chain.TestnetRPC] = [2]string{chain.Name, NetworkTestnet}
+	}
+	return defaults
+}
+
+// loadRPCEndpoints returns the nodes to try for each built-in node that has
+// nodes configured: the configured ones in order, then the built-in one
+func loadRPCEndpoints() map[string][]string {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+
+	endpoints := make(map[string][]string)
+	for url, chain := range defaultRPCChains() {
+		configured := cfg.RPCEndpoints(chain[0], chain[1])
+		if len(configured) == 0 {
+			continue
+		}
+		list := configured
+		if !containsString(configured, url) {
+			list = append(list, url)
+		}
+		endpoints[url] = list
+	}
+	return endpoints
+}
+
+// rpcEndpoints returns the nodes to try for a request to url, healthy ones
+// first. Nodes cooling down after a failure are kept as a last resort.
+func (c *Client) rpcEndpoints(url string) []string {
+	list, ok := c.endpoints[url]
+	if !ok {
+		return []string{url}
+	}
+
+	rpcHealthMu.Lock()
+	defer rpcHealthMu.Unlock()
+
+	now := time.Now()
+	ordered := append([]string(nil), list...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := rpcNodes[ordered[i]], rpcNodes[ordered[j]]
+		aDown := a != nil && a.downUntil.After(now)
+		bDown := b != nil && b.downUntil.After(now)
+		if aDown != bDown {
+			return !aDown
+		}
+		if aDown {
+			// The one that comes back first
+			return a.downUntil.Before(b.downUntil)
+		}
+		return false
+	})
+	return ordered
+}
+
+// rpcSucceeded marks a node healthy again
+func rpcSucceeded(url string) {
+	rpcHealthMu.Lock()
+	defer rpcHealthMu.Unlock()
+
+	if health, ok := rpcNodes[url]; ok {
+		health.failures = 0
+		health.downUntil = time.Time{}
+	}
+}
+
+// rpcFailed puts a node on cooldown after a transient failure
+func rpcFailed(url string) {
+	rpcHealthMu.Lock()
+	defer rpcHealthMu.Unlock()
+
+	health := rpcNode(url)
+	health.failures++
+	cooldown := rpcCooldown
+	for i := 1; i < health.failures && cooldown < maxRPCCooldown; i++ {
+		cooldown *= 2
+	}
+	if cooldown > maxRPCCooldown {
+		cooldown = maxRPCCooldown
+	}
+	health.downUntil = time.Now().Add(cooldown)
+}
+
+// rpcNode returns the health of a node, rpcHealthMu must be held
+func rpcNode(url string) *rpcHealth {
+	health, ok := rpcNodes[url]
+	if !ok {
+		health = &rpcHealth{}
+		rpcNodes[url] = health
+	}
+	return health
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// resetRPCHealth forgets the failures of nodes once the test is done
+func resetRPCHealth(t *testing.T) {
+	t.Cleanup(func() {
+		rpcHealthMu.Lock()
+		defer rpcHealthMu.Unlock()
+		rpcNodes = make(map[string]*rpcHealth)
+	})
+}
+
+func TestRPCFailover(t *testing.T) {
+	resetRPCHealth(t)
+
+	// node-a is down, node-b answers
+	var hosts []string
+	replay := rpcHandler(t, map[string]string{"eth_blockNumber": "rpc_eth_blockNumber.json"}, nil)
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.Host)
+		if r.Host == "node-a.example" {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return
+		}
+		replay.ServeHTTP(w, r)
+	}))
+	c.endpoints = map[string][]string{
+		MainnetEthereumRPC: {"https://node-a.example", "https://node-b.example", MainnetEthereumRPC},
+	}
+
+	if _, err := c.CallRPC(context.Background(), "ethereum", "eth_blockNumber", nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"node-a.example", "node-b.example"}; !reflect.DeepEqual(hosts, want) {
+		t.Errorf("asked %v, want the configured order %v", hosts, want)
+	}
+
+	// The failed node cools down behind the others
+	want := []string{"https://node-b.example", MainnetEthereumRPC, "https://node-a.example"}
+	if got := c.rpcEndpoints(MainnetEthereumRPC); !reflect.DeepEqual(got, want) {
+		t.Errorf("endpoints = %v, want %v", got, want)
+	}
+
+	hosts = nil
+	if _, err := c.CallRPC(context.Background(), "ethereum", "eth_blockNumber", nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"node-b.example"}; !reflect.DeepEqual(hosts, want) {
+		t.Errorf("asked %v, want %v", hosts, want)
+	}
+}
+
+func TestRPCFailoverStopsAtFinalErrors(t *testing.T) {
+	resetRPCHealth(t)
+
+	// A rejected request would be rejected by every node
+	var hosts []string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.Host)
+		http.Error(w, "invalid request", http.StatusBadRequest)
+	}))
+	c.endpoints = map[string][]string{
+		MainnetEthereumRPC: {"https://node-a.example", "https://node-b.example", MainnetEthereumRPC},
+	}
+
+	_, err := c.CallRPC(context.Background(), "ethereum", "eth_blockNumber", nil)
+	if err == nil {
+		t.Fatal("want the node's error")
+	}
+	if fmt.Sprint(hosts) != "[node-a.example]" {
+		t.Errorf("asked %v, want only node-a.example", hosts)
+	}
+}
+
+func TestRPCCooldown(t *testing.T) {
+	resetRPCHealth(t)
+
+	cooldown := func() time.Duration {
+		rpcHealthMu.Lock()
+		defer rpcHealthMu.Unlock()
+		return time.Until(rpcNodes["https://node-a.example"].downUntil).Round(time.Second)
+	}
+
+	// Doubled for every failure in a row, up to the maximum
+	for _, want := range []time.Duration{rpcCooldown, 2 * rpcCooldown, 4 * rpcCooldown, 8 * rpcCooldown, maxRPCCooldown, maxRPCCooldown} {
+		rpcFailed("https://node-a.example")
+		if got := cooldown(); got != want {
+			t.Fatalf("cooldown = %s, want %s", got, want)
+		}
+	}
+
+	rpcSucceeded("https://node-a.example")
+	c := &Client{endpoints: map[string][]string{
+		MainnetEthereumRPC: {"https://node-a.example", MainnetEthereumRPC},
+	}}
+	if got := c.rpcEndpoints(MainnetEthereumRPC); got[0] != "https://node-a.example" {
+		t.Errorf("endpoints = %v, want the recovered node first", got)
+	}
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+)
+
+// BitcoinFeeTiers are the recommended fee rates in sat/vB
+type BitcoinFeeTiers struct {
+	Fastest  int64 `json:"fastestFee"`
+	HalfHour int64 `json:"halfHourFee"`
+	Hour     int64 `json:"hourFee"`
+	Economy  int64 `json:"economyFee"`
+	Minimum  int64 `json:"minimumFee"`
+}
+
+// EthereumFeeHistory is the base fee of the next block and the priority fees
+// paid in recent blocks at the requested percentiles
+type EthereumFeeHistory struct {
+	BaseFee      *big.Int
+	Percentiles  []float64
+	PriorityFees []*big.Int // median over the sampled blocks, one per percentile
+}
+
+// GetBitcoinFeeTiers fetches the recommended fee rates from mempool.space, or
+// estimates them with the user's own node
+func (c *Client) GetBitcoinFeeTiers(ctx context.Context) (*BitcoinFeeTiers, error) {
+	if !c.net.HasBitcoin() {
+		return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+
+	if node, err := configuredBitcoinNode(); err != nil || node != nil {
+		if err != nil {
+			return nil, err
+		}
+		return c.bitcoinNodeFeeTiers(ctx, node)
+	}
+
+	resp, err := c.get(ctx, "https://mempool.space/api/v1/fees/recommended")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fee rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch fee rates: status %d", resp.StatusCode)
+	}
+
+	var tiers BitcoinFeeTiers
+	if err := json.NewDecoder(resp.Body).Decode(&tiers); err != nil {
+		return nil, fmt.Errorf("failed to parse fee rates: %w", err)
+	}
+	if tiers.HalfHour <= 0 {
+		return nil, fmt.Errorf("no fee rates in response")
+	}
+
+	return &tiers, nil
+}
+
+// bitcoinNodeFeeTiers estimates the tiers for confirmation in the next block,
+// about half an hour, an hour and a day
+func (c *Client) bitcoinNodeFeeTiers(ctx context.Context, node *bitcoinNode) (*BitcoinFeeTiers, error) {
+	var tiers BitcoinFeeTiers
+	targets := []struct {
+		blocks int
+		rate   *int64
+	}{{1, &tiers.Fastest}, {3, &tiers.HalfHour}, {6, &tiers.Hour}, {144, &tiers.Economy}}
+	for _, target := range targets {
+		rate, err := c.bitcoinNodeFeeRate(ctx, node, target.blocks)
+		if err != nil {
+			return nil, err
+		}
+		*target.rate = rate
+	}
+
+	minimum, err := c.bitcoinNodeMinimumFeeRate(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	tiers.Minimum = minimum
+	return &tiers, nil
+}
+
+// GetEthereumFeeHistory samples the priority fees of the last blocks with
+// eth_feeHistory
+func (c *Client) GetEthereumFeeHistory(ctx context.Context, blocks int, percentiles []float64) (*EthereumFeeHistory, error) {
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "eth_feeHistory",
+		"params":  []interface{}{fmt.Sprintf("0x%x", blocks), "latest", percentiles},
+		"id":      1,
+	}
+
+	response, err := c.postJSON(ctx, c.GetEthereumRPC(), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fee history: %w", err)
+	}
+
+	var rpcResp struct {
+		Result *struct {
+			BaseFeePerGas []string   `json:"baseFeePerGas"`
+			Reward        [][]string `json:"reward"`
+		} `json:"result"`
+		Error *RPCError `json:"error"`
+	}
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return nil, rpcResp.Error
+	}
+	if rpcResp.Result == nil || len(rpcResp.Result.BaseFeePerGas) == 0 {
+		return nil, fmt.Errorf("no fee history in response")
+	}
+
+	// The last base fee is the one of the next block
+	history := &EthereumFeeHistory{Percentiles: percentiles}
+	history.BaseFee, err = parseHexBigInt(rpcResp.Result.BaseFeePerGas[len(rpcResp.Result.BaseFeePerGas)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range percentiles {
+		var fees []*big.Int
+		for _, reward := range rpcResp.Result.Reward {
+			if i >= len(reward) {
+				continue
+			}
+			fee, err := parseHexBigInt(reward[i])
+			if err != nil {
+				return nil, err
+			}
+			fees = append(fees, fee)
+		}
+		if len(fees) == 0 {
+			history.PriorityFees = append(history.PriorityFees, big.NewInt(0))
+			continue
+		}
+		sort.Slice(fees, func(a, b int) bool { return fees[a].Cmp(fees[b]) < 0 })
+		history.PriorityFees = append(history.PriorityFees, fees[len(fees)/2])
+	}
+
+	return history, nil
+}
+
+// GetSolanaPrioritizationFees returns the prioritization fees in
+// micro-lamports per compute unit paid in recent slots, sorted ascending
+func (c *Client) GetSolanaPrioritizationFees(ctx context.Context) ([]uint64, error) {
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "getRecentPrioritizationFees",
+		"params":  []interface{}{},
+		"id":      1,
+	}
+
+	response, err := c.postJSON(ctx, c.GetSolanaRPC(), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch prioritization fees: %w", err)
+	}
+
+	var rpcResp struct {
+		Result []struct {
+			Slot              uint64 `json:"slot"`
+			PrioritizationFee uint64 `json:"prioritizationFee"`
+		} `json:"result"`
+		Error *RPCError `json:"error"`
+	}
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return nil, rpcResp.Error
+	}
+
+	fees := make([]uint64, len(rpcResp.Result))
+	for i, entry := range rpcResp.Result {
+		fees[i] = entry.PrioritizationFee
+	}
+	sort.Slice(fees, func(a, b int) bool { return fees[a] < fees[b] })
+
+	return fees, nil
+}
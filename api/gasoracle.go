@@ -0,0 +1,306 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/shopspring/decimal"
+)
+
+// gasEstimateTTL is how long a gas estimate is reused, about a block on
+// Ethereum
+const gasEstimateTTL = 12 * time.Second
+
+// gasHistoryBlocks is how many recent blocks the priority fees are sampled
+// from
+const gasHistoryBlocks = 20
+
+// gasTierPercentiles are the priority fee percentiles of recent blocks paid by
+// the slow, normal and fast tiers
+var gasTierPercentiles = []float64{10, 50, 90}
+
+// Sources of gas prices
+const (
+	GasSourceFeeHistory = "fee history" // eth_feeHistory of the node
+	GasSourceNode       = "node"        // eth_gasPrice of the node
+	GasSourceEtherscan  = "etherscan"   // the Etherscan gas tracker
+)
+
+// GasEstimate is the gas price of each tier in wei, the median of the
+// sources that answered
+type GasEstimate struct {
+	Slow   *big.Int `json:"slow"`
+	Normal *big.Int `json:"normal"`
+	Fast   *big.Int `json:"fast"`
+
+	// From the fee history, nil without it
+	BaseFee      *big.Int   `json:"base_fee,omitempty"`      // of the next block
+	PriorityFees []*big.Int `json:"priority_fees,omitempty"` // p10, p50 and p90 of recent blocks
+
+	Sources []string `json:"sources"`
+}
+
+// Price returns the gas price of a tier, the normal one for unknown tiers
+func (e *GasEstimate) Price(tier string) *big.Int {
+	switch tier {
+	case config.GasTierSlow:
+		return new(big.Int).Set(e.Slow)
+	case config.GasTierFast:
+		return new(big.Int).Set(e.Fast)
+	default:
+		return new(big.Int).Set(e.Normal)
+	}
+}
+
+// ExpectedBlocks estimates in how many blocks a transaction paying price is
+// included, by the priority fees recent blocks took. It is 0 if price is below
+// the base fee of the next block, the transaction then waits for the base fee
+// to drop.
+func (e *GasEstimate) ExpectedBlocks(price *big.Int) int {
+	if e.BaseFee == nil || len(e.PriorityFees) != len(gasTierPercentiles) {
+		switch {
+		case price.Cmp(e.Fast) >= 0:
+			return 1
+		case price.Cmp(e.Normal) >= 0:
+			return 2
+		case price.Cmp(e.Slow) >= 0:
+			return 5
+		default:
+			return 20
+		}
+	}
+
+	if price.Cmp(e.BaseFee) < 0 {
+		return 0
+	}
+	tip := new(big.Int).Sub(price, e.BaseFee)
+	switch {
+	case tip.Cmp(e.PriorityFees[2]) >= 0:
+		return 1
+	case tip.Cmp(e.PriorityFees[1]) >= 0:
+		return 2
+	case tip.Cmp(e.PriorityFees[0]) >= 0:
+		return 5
+	default:
+		return 20
+	}
+}
+
+// gasQuote is the tiers of one source
+type gasQuote struct {
+	source             string
+	slow, normal, fast *big.Int
+}
+
+// GetGasEstimate combines the fee history and gas price of the node with the
+// Etherscan gas tracker, when an API key is set and the node is Ethereum
+// mainnet, into slow, normal and fast gas prices. bump is the percent the
+// chain's gas price is usually raised by for faster inclusion. Estimates are
+// cached for about a block, so a payment and its fee checks see one price.
+func (c *Client) GetGasEstimate(ctx context.Context, bump int64) (*GasEstimate, error) {
+	cachePath := c.gasEstimatePath(bump)
+	if estimate, ok := readGasEstimate(cachePath); ok {
+		slog.Debug("gas estimate answered from cache", "sources", estimate.Sources)
+		return estimate, nil
+	}
+
+	estimate := &GasEstimate{}
+	var quotes []gasQuote
+	var lastErr error
+
+	// Legacy transactions pay the base fee out of the gas price, the normal
+	// and fast tiers leave room for it to rise by a block or two
+	if history, err := c.GetEthereumFeeHistory(ctx, gasHistoryBlocks, gasTierPercentiles); err == nil {
+		estimate.BaseFee = history.BaseFee
+		estimate.PriorityFees = history.PriorityFees
+		quotes = append(quotes, gasQuote{
+			source: GasSourceFeeHistory,
+			slow:   new(big.Int).Add(history.BaseFee, history.PriorityFees[0]),
+			normal: new(big.Int).Add(percentOf(history.BaseFee, 112), history.PriorityFees[1]),
+			fast:   new(big.Int).Add(percentOf(history.BaseFee, 125), history.PriorityFees[2]),
+		})
+	} else {
+		slog.Debug("gas source failed", "source", GasSourceFeeHistory, "error", err)
+		lastErr = err
+	}
+
+	if price, err := c.GetEthereumGasPrice(ctx); err == nil && price.Sign() > 0 {
+		quotes = append(quotes, gasQuote{
+			source: GasSourceNode,
+			slow:   price,
+			normal: percentOf(price, 100+bump),
+			fast:   percentOf(price, 100+2*bump),
+		})
+	} else if err != nil {
+		slog.Debug("gas source failed", "source", GasSourceNode, "error", err)
+		lastErr = err
+	}
+
+	if quote, err := c.etherscanGasQuote(ctx); err == nil && quote != nil {
+		quotes = append(quotes, *quote)
+	} else if err != nil {
+		slog.Debug("gas source failed", "source", GasSourceEtherscan, "error", err)
+	}
+
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("no gas price source answered: %w", lastErr)
+	}
+
+	var slow, normal, fast []*big.Int
+	for _, quote := range quotes {
+		slow = append(slow, quote.slow)
+		normal = append(normal, quote.normal)
+		fast = append(fast, quote.fast)
+		estimate.Sources = append(estimate.Sources, quote.source)
+	}
+	estimate.Slow, estimate.Normal, estimate.Fast = medianOf(slow), medianOf(normal), medianOf(fast)
+
+	// Medians of different sources can cross
+	if estimate.Normal.Cmp(estimate.Slow) < 0 {
+		estimate.Normal = estimate.Slow
+	}
+	if estimate.Fast.Cmp(estimate.Normal) < 0 {
+		estimate.Fast = estimate.Normal
+	}
+
+	writeGasEstimate(cachePath, estimate)
+	return estimate, nil
+}
+
+// etherscanGasQuote reads the Etherscan gas tracker, nil if no API key is set
+// or the node isn't Ethereum mainnet, the only chain it tracks
+func (c *Client) etherscanGasQuote(ctx context.Context) (*gasQuote, error) {
+	if c.ethereumRPC != "" || c.EthereumChainID() != 1 {
+		return nil, nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	apiKey := cfg.Get(config.KeyEtherscanAPIKey)
+	if apiKey == "" {
+		return nil, nil
+	}
+
+	params := url.Values{}
+	params.Set("module", "gastracker")
+	params.Set("action", "gasoracle")
+
+	var result struct {
+		SafeGasPrice    string `json:"SafeGasPrice"`
+		ProposeGasPrice string `json:"ProposeGasPrice"`
+		FastGasPrice    string `json:"FastGasPrice"`
+	}
+	if err := (etherscanProvider{c: c, apiKey: apiKey}).call(ctx, params, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch gas tracker: %w", err)
+	}
+
+	quote := &gasQuote{source: GasSourceEtherscan}
+	for _, tier := range []struct {
+		gwei  string
+		price **big.Int
+	}{{result.SafeGasPrice, &quote.slow}, {result.ProposeGasPrice, &quote.normal}, {result.FastGasPrice, &quote.fast}} {
+		gwei, err := decimal.NewFromString(tier.gwei)
+		if err != nil || !gwei.IsPositive() {
+			return nil, fmt.Errorf("invalid gas tracker price %q", tier.gwei)
+		}
+		*tier.price = gwei.Shift(9).BigInt()
+	}
+	return quote, nil
+}
+
+// percentOf returns percent of value
+func percentOf(value *big.Int, percent int64) *big.Int {
+	result := new(big.Int).Mul(value, big.NewInt(percent))
+	return result.Div(result, big.NewInt(100))
+}
+
+// medianOf returns the median of values, the mean of the middle two for an
+// even count
+func medianOf(values []*big.Int) *big.Int {
+	sorted := append([]*big.Int(nil), values...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].Cmp(sorted[b]) < 0 })
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return new(big.Int).Set(sorted[middle])
+	}
+	sum := new(big.Int).Add(sorted[middle-1], sorted[middle])
+	return sum.Div(sum, big.NewInt(2))
+}
+
+// gasEstimatePath returns where the estimate of the client's node is cached,
+// empty if there is no cache directory
+func (c *Client) gasEstimatePath(bump int64) string {
+	dir, err := cacheDir()
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(c.GetEthereumRPC() + " " + strconv.FormatInt(bump, 10)))
+	return filepath.Join(dir, "gas", hex.EncodeToString(hash[:8])+".json")
+}
+
+// cachedGasEstimate is a gas estimate as stored on disk
+type cachedGasEstimate struct {
+	Expires  time.Time    `json:"expires"`
+	Estimate *GasEstimate `json:"estimate"`
+}
+
+// readGasEstimate returns the estimate at path if it is still fresh
+func readGasEstimate(path string) (*GasEstimate, bool) {
+	if path == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var cached cachedGasEstimate
+	if json.Unmarshal(data, &cached) != nil || cached.Estimate == nil || time.Now().After(cached.Expires) {
+		return nil, false
+	}
+	estimate := cached.Estimate
+	if estimate.Slow == nil || estimate.Normal == nil || estimate.Fast == nil {
+		return nil, false
+	}
+	return estimate, true
+}
+
+// writeGasEstimate caches an estimate, a cache that can't be written is
+// skipped silently
+func writeGasEstimate(path string, estimate *GasEstimate) {
+	if path == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	data, err := json.Marshal(cachedGasEstimate{Expires: time.Now().Add(gasEstimateTTL), Estimate: estimate})
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".gas-*")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
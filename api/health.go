@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NodeCheck is the outcome of probing one node
+type NodeCheck struct {
+	URL        string
+	Latency    time.Duration
+	ServerTime time.Time // from the Date header, zero if the node sent none
+	Err        error
+}
+
+type noRetryKey struct{}
+
+// withoutRetries makes the requests made with ctx fail on the first error,
+// so a probe measures the node and not the retries
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// RPCNodes returns the nodes used for a chain on the current network in the
+// order they are tried: the configured ones, then the built-in one
+func (c *Client) RPCNodes(chain string) ([]string, error) {
+	var url string
+	switch chain {
+	case "ethereum":
+		url = c.GetEthereumRPC()
+	case "solana":
+		url = c.GetSolanaRPC()
+	case "bitcoin":
+		if !c.net.HasBitcoin() {
+			return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
+		}
+		node, err := configuredBitcoinNode()
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			return []string{node.url}, nil
+		}
+		return []string{c.GetBitcoinRPC()}, nil
+	default:
+		evmChain, ok := FindEVMChain(chain)
+		if !ok {
+			return nil, fmt.Errorf("unsupported chain: %s", chain)
+		}
+		url = c.ForEVMChain(evmChain).GetEthereumRPC()
+	}
+
+	if list, ok := c.endpoints[url]; ok {
+		return append([]string(nil), list...), nil
+	}
+	return []string{url}, nil
+}
+
+// CheckNode sends one cheap request to a node of a chain, without retries or
+// failover, and measures how long the answer took
+func (c *Client) CheckNode(ctx context.Context, chain, url string) NodeCheck {
+	check := NodeCheck{URL: url}
+	ctx = withoutRetries(ctx)
+
+	var req *http.Request
+	var err error
+	switch {
+	case chain == "bitcoin":
+		if node, nodeErr := configuredBitcoinNode(); nodeErr == nil && node != nil && node.url == url {
+			start := time.Now()
+			check.Err = c.bitcoindCall(ctx, node, "getblockcount", nil, nil)
+			check.Latency = time.Since(start)
+			return check
+		}
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+"/q/getblockcount", nil)
+	default:
+		method := "eth_blockNumber"
+		if chain == "solana" {
+			method = "getHealth"
+		}
+		payload := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":[]}`, method)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(payload))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	}
+	if err != nil {
+		check.Err = err
+		return check
+	}
+
+	start := time.Now()
+	resp, err := c.do(req)
+	if err != nil {
+		check.Err = err
+		return check
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	check.Latency = time.Since(start)
+	if err != nil {
+		check.Err = fmt.Errorf("failed to read response: %w", err)
+		return check
+	}
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		check.ServerTime = date
+	}
+	if resp.StatusCode != http.StatusOK {
+		check.Err = &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return check
+}
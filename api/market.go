@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MarketData is the market overview of a cryptocurrency
+type MarketData struct {
+	ID        string    `json:"id"`
+	Symbol    string    `json:"symbol"`
+	Name      string    `json:"name"`
+	PriceUSD  float64   `json:"price_usd"`
+	MarketCap float64   `json:"market_cap_usd"`
+	Change24h float64   `json:"change_24h_percent"`
+	Change7d  float64   `json:"change_7d_percent"`
+	Sparkline []float64 `json:"sparkline_7d"` // hourly prices of the last 7 days, oldest first
+}
+
+// GetMarketData fetches price, changes, market cap and the prices of the
+// last 7 days of several cryptocurrencies by CoinGecko id in one call. Ids
+// CoinGecko doesn't know are missing from the result.
+func (c *Client) GetMarketData(ctx context.Context, ids []string) (map[string]*MarketData, error) {
+	url := fmt.Sprintf("https://api.coingecko.com/api/v3/coins/markets?vs_currency=usd&ids=%s&sparkline=true&price_change_percentage=24h,7d", strings.Join(ids, ","))
+
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch market data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result []struct {
+		ID            string   `json:"id"`
+		Symbol        string   `json:"symbol"`
+		Name          string   `json:"name"`
+		CurrentPrice  float64  `json:"current_price"`
+		MarketCap     float64  `json:"market_cap"`
+		Change24h     *float64 `json:"price_change_percentage_24h_in_currency"`
+		Change7d      *float64 `json:"price_change_percentage_7d_in_currency"`
+		SparklineIn7d struct {
+			Price []float64 `json:"price"`
+		} `json:"sparkline_in_7d"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	markets := make(map[string]*MarketData, len(result))
+	for _, coin := range result {
+		market := &MarketData{
+			ID:        coin.ID,
+			Symbol:    strings.ToUpper(coin.Symbol),
+			Name:      coin.Name,
+			PriceUSD:  coin.CurrentPrice,
+			MarketCap: coin.MarketCap,
+			Sparkline: coin.SparklineIn7d.Price,
+		}
+		if coin.Change24h != nil {
+			market.Change24h = *coin.Change24h
+		}
+		if coin.Change7d != nil {
+			market.Change7d = *coin.Change7d
+		}
+		markets[coin.ID] = market
+	}
+	return markets, nil
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// maxNFTPages limits how many pages of NFTs are fetched for one owner
+const maxNFTPages = 10
+
+// alchemyNetworks maps chains to the Alchemy network names on mainnet and testnet
+var alchemyNetworks = map[string][2]string{
+	"ethereum": {"eth-mainnet", "eth-sepolia"},
+	"polygon":  {"polygon-mainnet", "polygon-amoy"},
+	"arbitrum": {"arb-mainnet", "arb-sepolia"},
+	"optimism": {"opt-mainnet", "opt-sepolia"},
+	"base":     {"base-mainnet", "base-sepolia"},
+}
+
+func init() {
+	for _, networks := range alchemyNetworks {
+		for _, network := range networks {
+			providerNames[network+".g.alchemy.com"] = "Alchemy"
+		}
+	}
+}
+
+// NFT is a non-fungible token owned by an address
+type NFT struct {
+	Contract   string `json:"contract"`
+	Collection string `json:"collection"`
+	TokenID    string `json:"token_id"` // decimal
+	Standard   string `json:"standard"` // ERC721 or ERC1155
+	Name       string `json:"name"`
+	Balance    string `json:"balance"` // number of copies, above 1 only for ERC-1155
+}
+
+// SupportsNFTs returns true if NFTs of a chain can be listed
+func SupportsNFTs(chain string) bool {
+	_, ok := alchemyNetworks[chain]
+	return ok
+}
+
+// GetNFTs lists the NFTs an address owns on a chain through the Alchemy NFT API
+func (c *Client) GetNFTs(ctx context.Context, chain, owner, apiKey string) ([]NFT, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("an Alchemy API key is required to list NFTs. Get one at https://dashboard.alchemy.com and set it with 'odyssey config set nft.alchemy_api_key <key>'")
+	}
+
+	networks, ok := alchemyNetworks[chain]
+	if !ok {
+		return nil, fmt.Errorf("listing NFTs is not supported on %s", chain)
+	}
+	network := networks[0]
+	if c.IsTestnet() {
+		network = networks[1]
+	}
+
+	var nfts []NFT
+	pageKey := ""
+	for page := 0; page < maxNFTPages; page++ {
+		params := url.Values{}
+		params.Set("owner", owner)
+		params.Set("withMetadata", "true")
+		params.Set("pageSize", "100")
+		if pageKey != "" {
+			params.Set("pageKey", pageKey)
+		}
+
+		endpoint := fmt.Sprintf("https://%s.g.alchemy.com/nft/v3/%s/getNFTsForOwner?%s", network, url.PathEscape(apiKey), params.Encode())
+		resp, err := c.get(ctx, endpoint)
+		if err != nil {
+			// The URL contains the API key, keep it out of the error
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+			return nil, fmt.Errorf("failed to fetch NFTs: %w", err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		if resp.StatusCode != 200 {
+			return nil, fmt.Errorf("failed to fetch NFTs: %w", &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)})
+		}
+
+		var result struct {
+			OwnedNFTs []struct {
+				Contract struct {
+					Address string `json:"address"`
+					Name    string `json:"name"`
+				} `json:"contract"`
+				TokenID   string `json:"tokenId"`
+				TokenType string `json:"tokenType"`
+				Name      string `json:"name"`
+				Balance   string `json:"balance"`
+			} `json:"ownedNfts"`
+			PageKey string `json:"pageKey"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		for _, owned := range result.OwnedNFTs {
+			nfts = append(nfts, NFT{
+				Contract:   owned.Contract.Address,
+				Collection: owned.Contract.Name,
+				TokenID:    owned.TokenID,
+				Standard:   owned.TokenType,
+				Name:       owned.Name,
+				Balance:    owned.Balance,
+			})
+		}
+
+		if result.PageKey == "" {
+			break
+		}
+		pageKey = result.PageKey
+	}
+
+	return nfts, nil
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// historicalPriceWindow is how many days around a requested day one range
+// request fetches. CoinGecko returns hourly prices for up to 90 days.
+const historicalPriceWindow = 45
+
+// historicalPrices are daily USD prices by CoinGecko id and day (2006-01-02),
+// kept in ~/.odyssey/cache/prices/historical.json. Past prices don't change,
+// so they never expire.
+var (
+	historicalPrices   map[string]map[string]float64
+	historicalPricesMu sync.Mutex
+)
+
+// GetHistoricalPrice fetches the USD price of a cryptocurrency on the day of
+// date, the first price CoinGecko recorded after 00:00 UTC. Days are fetched
+// in ranges and cached on disk, so valuing a transaction history takes a
+// request per few months rather than per transaction. Today's price is the
+// current price.
+func (c *Client) GetHistoricalPrice(ctx context.Context, symbol string, date time.Time) (*PriceData, error) {
+	if c.net.NoFiat {
+		return nil, ErrNoFiat
+	}
+
+	day := date.UTC().Truncate(24 * time.Hour)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if !day.Before(today) {
+		return c.GetPrice(ctx, symbol)
+	}
+	key := day.Format(time.DateOnly)
+
+	historicalPricesMu.Lock()
+	defer historicalPricesMu.Unlock()
+	loadHistoricalPrices()
+
+	if price, ok := historicalPrices[symbol][key]; ok {
+		return historicalPriceData(symbol, price), nil
+	}
+
+	from := day.AddDate(0, 0, -historicalPriceWindow)
+	to := day.AddDate(0, 0, historicalPriceWindow)
+	if to.After(today) {
+		to = today
+	}
+	days, err := c.getPriceRange(ctx, symbol, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if historicalPrices[symbol] == nil {
+		historicalPrices[symbol] = make(map[string]float64)
+	}
+	for d, price := range days {
+		historicalPrices[symbol][d] = price
+	}
+	saveHistoricalPrices()
+
+	if price, ok := days[key]; ok {
+		return historicalPriceData(symbol, price), nil
+	}
+	return nil, fmt.Errorf("no price for %s on %s", symbol, key)
+}
+
+// getPriceRange fetches the prices of a cryptocurrency between two days,
+// reduced to the first price of every complete day
+func (c *Client) getPriceRange(ctx context.Context, symbol string, from, to time.Time) (map[string]float64, error) {
+	url := fmt.Sprintf("https://api.coingecko.com/api/v3/coins/%s/market_chart/range?vs_currency=usd&from=%d&to=%d", symbol, from.Unix(), to.Unix())
+
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch historical prices: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result struct {
+		Prices [][2]float64 `json:"prices"` // milliseconds, price
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	days := make(map[string]float64)
+	for _, point := range result.Prices {
+		at := time.UnixMilli(int64(point[0])).UTC()
+		if !at.Before(to) {
+			continue
+		}
+		// Prices are in chronological order, the first of a day wins
+		key := at.Format(time.DateOnly)
+		if _, ok := days[key]; !ok {
+			days[key] = point[1]
+		}
+	}
+	return days, nil
+}
+
+// historicalPriceData returns a cached daily price as PriceData
+func historicalPriceData(symbol string, price float64) *PriceData {
+	return &PriceData{
+		Symbol: symbol,
+		Price:  decimal.NewFromFloat(price),
+		USD:    decimal.NewFromFloat(price),
+	}
+}
+
+// loadHistoricalPrices reads the price cache once, a missing or unreadable
+// cache starts empty
+func loadHistoricalPrices() {
+	if historicalPrices != nil {
+		return
+	}
+	historicalPrices = make(map[string]map[string]float64)
+	path, err := historicalPricesPath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	if json.Unmarshal(data, &historicalPrices) != nil || historicalPrices == nil {
+		historicalPrices = make(map[string]map[string]float64)
+	}
+}
+
+// saveHistoricalPrices writes the price cache, a cache that can't be written
+// is skipped silently
+func saveHistoricalPrices() {
+	path, err := historicalPricesPath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	data, err := json.Marshal(historicalPrices)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".prices-*")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// historicalPricesPath returns the location of the historical price cache,
+// in a directory of its own so pruning the response cache leaves it alone
+func historicalPricesPath() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "prices", "historical.json"), nil
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/chinmay1088/odyssey/config"
+)
+
+// BalanceProvider looks up the native balance of an address, in the smallest
+// unit of the chain's asset
+type BalanceProvider interface {
+	Balance(ctx context.Context, address string) (*big.Int, error)
+}
+
+// HistoryProvider looks up a page of the transactions of an address. The
+// cursor is the provider's own, an empty cursor starts at the newest.
+type HistoryProvider interface {
+	History(ctx context.Context, address string, limit int, cursor string) (*TransactionPage, error)
+}
+
+// BroadcastProvider sends a signed transaction and returns its hash
+type BroadcastProvider interface {
+	Broadcast(ctx context.Context, signedTx string) (string, error)
+}
+
+// batchBalanceProvider is a BalanceProvider that looks up several addresses in
+// one request
+type batchBalanceProvider interface {
+	Balances(ctx context.Context, addresses []string) (map[string]*big.Int, error)
+}
+
+// providers open the backends of each chain by the names the
+// provider.<chain>.<kind> settings accept
+var providers = map[string]map[string]func(c *Client) (interface{}, error){
+	"ethereum": {
+		"rpc":       func(c *Client) (interface{}, error) { return ethereumRPCProvider{c: c}, nil },
+		"etherscan": newEtherscanProvider,
+	},
+	"bitcoin": {
+		"blockchain.info": func(c *Client) (interface{}, error) { return blockchainInfoProvider{c: c}, nil },
+		"blockstream":     func(c *Client) (interface{}, error) { return esploraProvider{c: c, api: BlockstreamAPI}, nil },
+		"mempool":         func(c *Client) (interface{}, error) { return esploraProvider{c: c, api: MempoolAPI}, nil },
+		"electrum":        newElectrumProvider,
+	},
+	"solana": {
+		"rpc":    func(c *Client) (interface{}, error) { return solanaRPCProvider{c: c, url: c.GetSolanaRPC()}, nil },
+		"helius": newHeliusProvider,
+	},
+}
+
+// BalanceProvider returns the provider of a chain's balances
+func (c *Client) BalanceProvider(chain string) (BalanceProvider, error) {
+	name, provider, err := c.openProvider(chain, config.ProviderBalance)
+	if err != nil {
+		return nil, err
+	}
+	balances, ok := provider.(BalanceProvider)
+	if !ok {
+		return nil, fmt.Errorf("the %s provider doesn't serve %s balances", name, chain)
+	}
+	return balances, nil
+}
+
+// HistoryProvider returns the provider of a chain's transaction history
+func (c *Client) HistoryProvider(chain string) (HistoryProvider, error) {
+	name, provider, err := c.openProvider(chain, config.ProviderHistory)
+	if err != nil {
+		return nil, err
+	}
+	history, ok := provider.(HistoryProvider)
+	if !ok {
+		return nil, fmt.Errorf("the %s provider doesn't serve %s transaction history", name, chain)
+	}
+	return history, nil
+}
+
+// BroadcastProvider returns the provider that sends a chain's transactions
+func (c *Client) BroadcastProvider(chain string) (BroadcastProvider, error) {
+	name, provider, err := c.openProvider(chain, config.ProviderBroadcast)
+	if err != nil {
+		return nil, err
+	}
+	broadcast, ok := provider.(BroadcastProvider)
+	if !ok {
+		return nil, fmt.Errorf("the %s provider doesn't broadcast %s transactions", name, chain)
+	}
+	return broadcast, nil
+}
+
+// openProvider opens the provider set for a kind of data of a chain. Other
+// EVM chains always use their own node.
+func (c *Client) openProvider(chain, kind string) (string, interface{}, error) {
+	name := config.Providers(chain, kind)[0]
+	if cfg, err := config.Load(); err == nil {
+		name = cfg.Provider(chain, kind)
+	}
+	if chain == "ethereum" && c.ethereumRPC != "" {
+		name = "rpc"
+	}
+
+	open, ok := providers[chain][name]
+	if !ok {
+		return name, nil, fmt.Errorf("unknown %s provider: %s", chain, name)
+	}
+	provider, err := open(c)
+	return name, provider, err
+}
+
+// providerBalances looks up the balances of several addresses, in one request
+// if the provider can
+func providerBalances(ctx context.Context, provider BalanceProvider, addresses []string) (map[string]*big.Int, error) {
+	if batch, ok := provider.(batchBalanceProvider); ok {
+		return batch.Balances(ctx, addresses)
+	}
+
+	balances := make(map[string]*big.Int, len(addresses))
+	for _, address := range addresses {
+		balance, err := provider.Balance(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		balances[address] = balance
+	}
+	return balances, nil
+}
+
+// ethereumRPCProvider serves Ethereum from the network's node
+type ethereumRPCProvider struct {
+	c *Client
+}
+
+// Balance returns the balance of an address in wei
+func (p ethereumRPCProvider) Balance(ctx context.Context, address string) (*big.Int, error) {
+	return p.c.rpcEthereumBalance(ctx, address)
+}
+
+// History scans the node's recent blocks for the transactions of an address
+func (p ethereumRPCProvider) History(ctx context.Context, address string, limit int, cursor string) (*TransactionPage, error) {
+	return p.c.rpcEthereumTransactions(ctx, address, limit, cursor)
+}
+
+// Broadcast sends a signed transaction to the node
+func (p ethereumRPCProvider) Broadcast(ctx context.Context, signedTx string) (string, error) {
+	return p.c.rpcSendEthereumTransaction(ctx, signedTx)
+}
+
+// solanaRPCProvider serves Solana from a JSON-RPC node, the network's own or
+// one of a provider like Helius
+type solanaRPCProvider struct {
+	c   *Client
+	url string
+}
+
+// Balance returns the balance of an address in lamports
+func (p solanaRPCProvider) Balance(ctx context.Context, address string) (*big.Int, error) {
+	balance, err := p.c.solanaBalance(ctx, p.url, address)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(balance), nil
+}
+
+// History returns a page of the transactions of an address
+func (p solanaRPCProvider) History(ctx context.Context, address string, limit int, cursor string) (*TransactionPage, error) {
+	return p.c.solanaTransactions(ctx, p.url, address, limit, cursor)
+}
+
+// Broadcast sends a signed transaction to the node
+func (p solanaRPCProvider) Broadcast(ctx context.Context, signedTx string) (string, error) {
+	return p.c.sendSolanaTransaction(ctx, p.url, signedTx)
+}
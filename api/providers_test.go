@@ -0,0 +1,121 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chinmay1088/odyssey/config"
+)
+
+// fixture returns a response recorded from a provider, from testdata
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// replayTransport sends every request to a test server instead of its host.
+// The host stays in the Host header, so handlers can tell providers apart.
+type replayTransport struct {
+	server *url.URL
+	next   http.RoundTripper
+}
+
+func (t replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	replayed := req.Clone(req.Context())
+	replayed.URL.Scheme = t.server.Scheme
+	replayed.URL.Host = t.server.Host
+	replayed.Host = req.URL.Host
+	return t.next.RoundTrip(replayed)
+}
+
+// testClient returns a mainnet client whose requests are served by handler.
+// Settings are read from an empty home directory.
+func testClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Transport: replayTransport{server: serverURL, next: server.Client().Transport}},
+		network:    NetworkMainnet,
+		net: &config.Network{
+			Name:            config.NetworkMainnet,
+			Keys:            config.NetworkMainnet,
+			EthereumRPC:     MainnetEthereumRPC,
+			EthereumChainID: 1,
+			SolanaRPC:       MainnetSolanaRPC,
+		},
+	}
+}
+
+func TestProvidersDefaultToTheFirstOfTheirChain(t *testing.T) {
+	c := testClient(t, http.NotFoundHandler())
+
+	balance, err := c.BalanceProvider("ethereum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != (ethereumRPCProvider{c: c}) {
+		t.Errorf("ethereum balance provider = %#v, want the node", balance)
+	}
+
+	balance, err = c.BalanceProvider("bitcoin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != (blockchainInfoProvider{c: c}) {
+		t.Errorf("bitcoin balance provider = %#v, want blockchain.info", balance)
+	}
+
+	broadcast, err := c.BroadcastProvider("bitcoin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if broadcast != (esploraProvider{c: c, api: MempoolAPI}) {
+		t.Errorf("bitcoin broadcast provider = %#v, want mempool.space", broadcast)
+	}
+}
+
+func TestProvidersFollowSettings(t *testing.T) {
+	c := testClient(t, http.NotFoundHandler())
+
+	settings := `{"provider.bitcoin.balance": "electrum", "bitcoin.electrum_server": "tcp://127.0.0.1:50001", "provider.bitcoin.history": "blockstream"}`
+	dir := filepath.Join(os.Getenv("HOME"), ".odyssey")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(settings), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	balance, err := c.BalanceProvider("bitcoin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	electrum, ok := balance.(electrumProvider)
+	if !ok || electrum.server.Host != "127.0.0.1:50001" {
+		t.Errorf("bitcoin balance provider = %#v, want the Electrum server", balance)
+	}
+
+	history, err := c.HistoryProvider("bitcoin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history != (esploraProvider{c: c, api: BlockstreamAPI}) {
+		t.Errorf("bitcoin history provider = %#v, want Blockstream", history)
+	}
+}
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRateLimitWait is the longest a request waits for a throttled provider
+	// before failing with a RateLimitError
+	maxRateLimitWait = 10 * time.Second
+
+	// maxRateLimitRetries limits how often a throttled request is repeated
+	maxRateLimitRetries = 2
+
+	// defaultRetryAfter is assumed when a provider doesn't say how long to wait
+	defaultRetryAfter = 5 * time.Second
+
+	// Requests to a provider that throttled us are spaced out, starting at
+	// minPacing and doubling on every further limit up to maxPacing
+	minPacing = 500 * time.Millisecond
+	maxPacing = 5 * time.Second
+)
+
+// RateLimitError is returned when a provider throttles our requests
+type RateLimitError struct {
+	Provider   string
+	RetryAfter time.Duration // zero if the provider didn't say
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s is rate limiting requests, try again in %s", e.Provider, e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("%s is rate limiting requests, try again in a minute", e.Provider)
+}
+
+// providerNames maps API hosts to the names shown to the user
+var providerNames = map[string]string{
+	"api.coingecko.com":                    "CoinGecko",
+	"blockchain.info":                      "blockchain.info",
+	"api.blockchain.info":                  "blockchain.info",
+	"api.blockchair.com":                   "Blockchair",
+	"mempool.space":                        "mempool.space",
+	"blockstream.info":                     "Blockstream",
+	"mainnet.helius-rpc.com":               "Helius",
+	"devnet.helius-rpc.com":                "Helius",
+	"ethereum-rpc.publicnode.com":          "the public Ethereum RPC (PublicNode)",
+	"ethereum-sepolia.publicnode.com":      "the public Sepolia RPC (PublicNode)",
+	"api.mainnet-beta.solana.com":          "the public Solana RPC",
+	"api.devnet.solana.com":                "the public Solana Devnet RPC",
+	"api.etherscan.io":                     "Etherscan",
+	"safe-transaction-mainnet.safe.global": "the Safe transaction service",
+	"safe-transaction-sepolia.safe.global": "the Safe transaction service",
+	"thornode.ninerealms.com":              "THORChain",
+	"lite-api.jup.ag":                      "Jupiter",
+	"api.0x.org":                           "0x",
+	"api.tenderly.co":                      "Tenderly",
+}
+
+func init() {
+	// Nodes of the other EVM chains
+	for _, chain := range EVMChains {
+		for _, rpc := range []string{chain.MainnetRPC, chain.TestnetRPC} {
+			if parsed, err := url.Parse(rpc); err == nil {
+				providerNames[parsed.Hostname()] = "the public " + chain.DisplayName + " RPC"
+			}
+		}
+	}
+}
+
+// providerName returns the display name of the provider behind a host
+func providerName(host string) string {
+	if name, ok := providerNames[host]; ok {
+		return name
+	}
+	return host
+}
+
+// hostPacing tracks how requests to one host are spaced out
+type hostPacing struct {
+	next     time.Time     // earliest time of the next request
+	interval time.Duration // minimum spacing, zero until the host throttled us
+}
+
+var (
+	pacingMu sync.Mutex
+	pacing   = make(map[string]*hostPacing)
+)
+
+// reserveSlot returns how long a request to host has to wait for its turn and
+// books the slot after it, so concurrent requests queue up behind each other
+func reserveSlot(host string) time.Duration {
+	pacingMu.Lock()
+	defer pacingMu.Unlock()
+
+	state, ok := pacing[host]
+	if !ok {
+		return 0
+	}
+
+	now := time.Now()
+	start := now
+	if state.next.After(now) {
+		start = state.next
+	}
+	state.next = start.Add(state.interval)
+	return start.Sub(now)
+}
+
+// throttled records a rate limit by host, pausing it for retryAfter and
+// slowing down the requests that follow
+func throttled(host string, retryAfter time.Duration) {
+	pacingMu.Lock()
+	defer pacingMu.Unlock()
+
+	state, ok := pacing[host]
+	if !ok {
+		state = &hostPacing{}
+		pacing[host] = state
+	}
+
+	state.interval *= 2
+	if state.interval < minPacing {
+		state.interval = minPacing
+	}
+	if state.interval > maxPacing {
+		state.interval = maxPacing
+	}
+
+	if until := time.Now().Add(retryAfter); until.After(state.next) {
+		state.next = until
+	}
+}
+
+// rateLimitTransport paces requests per host and turns throttling responses
+// into RateLimitErrors naming the provider
+type rateLimitTransport struct {
+	next http.RoundTripper
+}
+
+func (t rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+
+	for attempt := 0; ; attempt++ {
+		if wait := reserveSlot(host); wait > 0 {
+			if wait > maxRateLimitWait {
+				return nil, &RateLimitError{Provider: providerName(host), RetryAfter: wait}
+			}
+			slog.Info("waiting for rate limited provider", "provider", providerName(host), "wait", wait.Round(time.Millisecond))
+			if err := sleepContext(req, wait); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		retryAfter, limited := rateLimited(resp)
+		if !limited {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		if retryAfter == 0 {
+			retryAfter = defaultRetryAfter
+		}
+		throttled(host, retryAfter)
+
+		// Only repeat requests whose body can be sent again, and give up if
+		// the provider wants us to wait longer than a user would
+		limitErr := &RateLimitError{Provider: providerName(host), RetryAfter: retryAfter}
+		if attempt >= maxRateLimitRetries || retryAfter > maxRateLimitWait {
+			return nil, limitErr
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return nil, limitErr
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, limitErr
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// sleepContext waits for d unless the request is cancelled first
+func sleepContext(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+// rateLimited reports whether a response means we are being throttled and
+// how long the provider asked us to wait
+func rateLimited(resp *http.Response) (time.Duration, bool) {
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return retryAfter, true
+	case http.StatusServiceUnavailable:
+		// Only a throttle if the server says when to come back
+		return retryAfter, retryAfter > 0
+	case http.StatusPaymentRequired, 430:
+		// Blockchair answers 402 when the daily limit is used up and 430
+		// when the address sent too many requests
+		if resp.Request != nil && resp.Request.URL.Hostname() == "api.blockchair.com" {
+			return retryAfter, true
+		}
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as a date
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// rpcRateLimitError detects limits that JSON-RPC nodes report inside a
+// successful response instead of with a status code
+func rpcRateLimitError(endpoint string, body []byte) error {
+	if !bytes.Contains(body, []byte(`"error"`)) {
+		return nil
+	}
+
+	var rpcResp struct {
+		Error *RPCError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &rpcResp); err != nil || rpcResp.Error == nil {
+		return nil
+	}
+
+	message := strings.ToLower(rpcResp.Error.Message)
+	if rpcResp.Error.Code != -32005 && !strings.Contains(message, "rate limit") &&
+		!strings.Contains(message, "too many requests") && !strings.Contains(message, "limit exceeded") {
+		return nil
+	}
+
+	host := endpoint
+	if parsed, err := url.Parse(endpoint); err == nil {
+		host = parsed.Hostname()
+	}
+	throttled(host, defaultRetryAfter)
+	return &RateLimitError{Provider: providerName(host), RetryAfter: defaultRetryAfter}
+}
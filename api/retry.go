@@ -0,0 +1,106 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+)
+
+// maxRetryBackoff caps the exponential backoff between two attempts
+const maxRetryBackoff = 8 * time.Second
+
+// retryTransport repeats requests that failed for a transient reason: a
+// dropped connection or a 5xx answer. The waits grow exponentially with
+// jitter, so many clients failing together don't retry in lockstep. Rate
+// limits are handled by rateLimitTransport below it.
+type retryTransport struct {
+	next     http.RoundTripper
+	attempts int           // total tries, 1 disables retries
+	backoff  time.Duration // wait before the first retry
+}
+
+func (t retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+
+		retryAfter, retry := retryable(req, resp, err)
+		if !retry || attempt >= t.attempts {
+			return resp, err
+		}
+
+		// Only repeat requests whose body can be sent again
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		wait := backoffDelay(t.backoff, attempt)
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		slog.Debug("retrying request", "url", req.URL.String(), "attempt", attempt+1, "wait", wait.Round(time.Millisecond))
+		if err := sleepContext(req, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryable reports whether a failed attempt may succeed when repeated, and
+// how long the server asked us to wait first
+func retryable(req *http.Request, resp *http.Response, err error) (time.Duration, bool) {
+	// The caller gave up or the client timeout passed
+	if req.Context().Err() != nil {
+		return 0, false
+	}
+	// Probes measure a single attempt, see withoutRetries
+	if req.Context().Value(noRetryKey{}) != nil {
+		return 0, false
+	}
+
+	if err != nil {
+		var limitErr *RateLimitError
+		if errors.As(err, &limitErr) || errors.Is(err, ErrOffline) {
+			return 0, false
+		}
+		var netErr net.Error
+		return 0, errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		// Waiting longer than a user would isn't worth it
+		return retryAfter, retryAfter <= maxRateLimitWait
+	}
+	return 0, false
+}
+
+// backoffDelay returns the wait before retry number attempt: the base doubled
+// per attempt up to maxRetryBackoff, with a random half taken off as jitter
+func backoffDelay(base time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
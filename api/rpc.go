@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RPCError is an error returned by a JSON-RPC node
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+// GetRPC returns the JSON-RPC endpoint of a chain on the current network
+func (c *Client) GetRPC(chain string) (string, error) {
+	switch chain {
+	case "ethereum":
+		return c.GetEthereumRPC(), nil
+	case "solana":
+		return c.GetSolanaRPC(), nil
+	case "bitcoin":
+		return "", fmt.Errorf("bitcoin uses a REST API (%s) and has no JSON-RPC endpoint", c.GetBitcoinRPC())
+	default:
+		return "", fmt.Errorf("unsupported chain: %s", chain)
+	}
+}
+
+// CallRPC sends an arbitrary JSON-RPC request to a chain's node and returns
+// the raw result. params must be a JSON array or object, or empty.
+func (c *Client) CallRPC(ctx context.Context, chain, method string, params json.RawMessage) (json.RawMessage, error) {
+	url, err := c.GetRPC(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+	}
+	if len(params) > 0 {
+		payload["params"] = params
+	}
+
+	response, err := c.postJSON(ctx, url, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *RPCError       `json:"error"`
+	}
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return nil, rpcResp.Error
+	}
+
+	return rpcResp.Result, nil
+}
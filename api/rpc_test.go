@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// rpcHandler replays the recorded response of each JSON-RPC method and
+// records the hosts asked, in order
+func rpcHandler(t *testing.T, responses map[string]string, hosts *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hosts != nil {
+			*hosts = append(*hosts, r.Host)
+		}
+		var request struct {
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("invalid request: %v", err)
+		}
+		name, ok := responses[request.Method]
+		if !ok {
+			name = "rpc_method_not_found.json"
+		}
+		w.Write(fixture(t, name))
+	})
+}
+
+func TestEthereumRPCBalance(t *testing.T) {
+	var hosts []string
+	c := testClient(t, rpcHandler(t, map[string]string{"eth_getBalance": "rpc_eth_getBalance.json"}, &hosts))
+
+	balance, err := ethereumRPCProvider{c: c}.Balance(context.Background(), testEthereumAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.String() != "2000000000000000000" {
+		t.Errorf("balance = %s wei, want 2000000000000000000", balance)
+	}
+	if len(hosts) != 1 || hosts[0] != "ethereum-rpc.publicnode.com" {
+		t.Errorf("asked %v, want the network's node", hosts)
+	}
+}
+
+func TestCallRPC(t *testing.T) {
+	c := testClient(t, rpcHandler(t, map[string]string{"eth_blockNumber": "rpc_eth_blockNumber.json"}, nil))
+
+	result, err := c.CallRPC(context.Background(), "ethereum", "eth_blockNumber", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != `"0x12a05f2"` {
+		t.Errorf("result = %s, want the raw block number", result)
+	}
+
+	// Errors of the node keep their code
+	_, err = c.CallRPC(context.Background(), "ethereum", "eth_foo", json.RawMessage(`[]`))
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Errorf("error = %v, want RPC error -32601", err)
+	}
+
+	if _, err := c.CallRPC(context.Background(), "bitcoin", "getblockcount", nil); err == nil {
+		t.Error("bitcoin has no JSON-RPC node, want an error")
+	}
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SafeNumber is a number the Safe transaction service encodes either as a
+// JSON number or as a string, depending on the service version
+type SafeNumber string
+
+// UnmarshalJSON accepts both encodings
+func (n *SafeNumber) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = "0"
+		return nil
+	}
+	unquoted, err := strconv.Unquote(string(data))
+	if err != nil {
+		unquoted = string(data)
+	}
+	*n = SafeNumber(unquoted)
+	return nil
+}
+
+// SafeConfirmation is an owner signature collected by the service
+type SafeConfirmation struct {
+	Owner     string `json:"owner"`
+	Signature string `json:"signature"`
+}
+
+// SafeMultisigTransaction is a Safe transaction known to the service
+type SafeMultisigTransaction struct {
+	Safe                  string             `json:"safe"`
+	To                    string             `json:"to"`
+	Value                 SafeNumber         `json:"value"`
+	Data                  *string            `json:"data"`
+	Operation             int                `json:"operation"`
+	SafeTxGas             SafeNumber         `json:"safeTxGas"`
+	BaseGas               SafeNumber         `json:"baseGas"`
+	GasPrice              SafeNumber         `json:"gasPrice"`
+	GasToken              string             `json:"gasToken"`
+	RefundReceiver        string             `json:"refundReceiver"`
+	Nonce                 SafeNumber         `json:"nonce"`
+	SafeTxHash            string             `json:"safeTxHash"`
+	IsExecuted            bool               `json:"isExecuted"`
+	ConfirmationsRequired int                `json:"confirmationsRequired"`
+	Confirmations         []SafeConfirmation `json:"confirmations"`
+	TransactionHash       *string            `json:"transactionHash"`
+}
+
+// SafeProposal is a new Safe transaction signed by its proposer
+type SafeProposal struct {
+	To                      string  `json:"to"`
+	Value                   string  `json:"value"`
+	Data                    *string `json:"data"`
+	Operation               int     `json:"operation"`
+	SafeTxGas               string  `json:"safeTxGas"`
+	BaseGas                 string  `json:"baseGas"`
+	GasPrice                string  `json:"gasPrice"`
+	GasToken                string  `json:"gasToken"`
+	RefundReceiver          string  `json:"refundReceiver"`
+	Nonce                   string  `json:"nonce"`
+	ContractTransactionHash string  `json:"contractTransactionHash"`
+	Sender                  string  `json:"sender"`
+	Signature               string  `json:"signature"`
+	Origin                  string  `json:"origin"`
+}
+
+// GetSafeServiceAPI returns the Safe transaction service for the current network
+func (c *Client) GetSafeServiceAPI() string {
+	if c.IsTestnet() {
+		return TestnetSafeServiceAPI
+	}
+	return MainnetSafeServiceAPI
+}
+
+// GetSafePendingTransactions returns the unexecuted transactions of a Safe
+// with a nonce of at least minNonce, ordered by nonce
+func (c *Client) GetSafePendingTransactions(ctx context.Context, safe string, minNonce uint64) ([]SafeMultisigTransaction, error) {
+	url := fmt.Sprintf("%s/api/v1/safes/%s/multisig-transactions/?executed=false&nonce__gte=%d&ordering=nonce&limit=100",
+		c.GetSafeServiceAPI(), safe, minNonce)
+
+	var page struct {
+		Results []SafeMultisigTransaction `json:"results"`
+	}
+	if err := c.getSafeService(ctx, url, &page); err != nil {
+		return nil, fmt.Errorf("failed to fetch pending Safe transactions: %w", err)
+	}
+
+	return page.Results, nil
+}
+
+// GetSafeTransaction fetches a Safe transaction by its Safe transaction hash
+func (c *Client) GetSafeTransaction(ctx context.Context, safeTxHash string) (*SafeMultisigTransaction, error) {
+	url := fmt.Sprintf("%s/api/v1/multisig-transactions/%s/", c.GetSafeServiceAPI(), safeTxHash)
+
+	var tx SafeMultisigTransaction
+	if err := c.getSafeService(ctx, url, &tx); err != nil {
+		return nil, fmt.Errorf("failed to fetch Safe transaction: %w", err)
+	}
+
+	return &tx, nil
+}
+
+// ProposeSafeTransaction submits a new signed transaction to the service
+func (c *Client) ProposeSafeTransaction(ctx context.Context, safe string, proposal SafeProposal) error {
+	url := fmt.Sprintf("%s/api/v1/safes/%s/multisig-transactions/", c.GetSafeServiceAPI(), safe)
+
+	if _, err := c.postJSON(ctx, url, proposal); err != nil {
+		return fmt.Errorf("failed to propose Safe transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmSafeTransaction adds an owner signature to a proposed transaction
+func (c *Client) ConfirmSafeTransaction(ctx context.Context, safeTxHash, signature string) error {
+	url := fmt.Sprintf("%s/api/v1/multisig-transactions/%s/confirmations/", c.GetSafeServiceAPI(), safeTxHash)
+
+	payload := map[string]string{"signature": signature}
+	if _, err := c.postJSON(ctx, url, payload); err != nil {
+		return fmt.Errorf("failed to confirm Safe transaction: %w", err)
+	}
+
+	return nil
+}
+
+// getSafeService fetches and decodes a Safe transaction service resource
+func (c *Client) getSafeService(ctx context.Context, url string, out interface{}) error {
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == 404 {
+		return fmt.Errorf("not found on the Safe transaction service")
+	}
+	if resp.StatusCode != 200 {
+		return &HTTPStatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// SanctionsMatch is a sanctions list an address is on
+type SanctionsMatch struct {
+	Category    string `json:"category"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+}
+
+// ScreenAddress checks an address of any chain against the Chainalysis
+// sanctions API. It returns the lists the address is on, none if it isn't
+// listed.
+func (c *Client) ScreenAddress(ctx context.Context, address, apiKey string) ([]SanctionsMatch, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("a Chainalysis API key is required to screen addresses. Get one at https://go.chainalysis.com/free-sanctions-screening-tools.html and set it with 'odyssey config set screening.chainalysis_api_key <key>'")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ChainalysisSanctionsAPI+"/"+url.PathEscape(address), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to screen address: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result struct {
+		Identifications []SanctionsMatch `json:"identifications"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result.Identifications, nil
+}
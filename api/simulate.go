@@ -0,0 +1,448 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/config"
+)
+
+// Simulation is the predicted outcome of a transaction that hasn't been sent
+type Simulation struct {
+	Provider       string // what ran the simulation, e.g. node trace or Tenderly
+	Success        bool
+	Error          string // why the transaction would fail
+	GasUsed        uint64 // gas on EVM chains, compute units on Solana
+	Logs           []SimulatedLog
+	ProgramLogs    []string // Solana only
+	BalanceChanges []BalanceChange
+	Detailed       bool // false if only success or failure could be predicted
+}
+
+// SimulatedLog is an event a simulated EVM transaction would emit
+type SimulatedLog struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+// BalanceChange is a predicted change of an address balance
+type BalanceChange struct {
+	Address string
+	Token   string   // token contract, empty for the native asset
+	TokenID *big.Int // set for ERC-721 and ERC-1155 transfers
+	Amount  *big.Int // raw units, negative when the balance drops
+}
+
+// callTrace is a frame of the callTracer output of debug_traceCall
+type callTrace struct {
+	Type    string         `json:"type"`
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	Value   string         `json:"value"`
+	GasUsed string         `json:"gasUsed"`
+	Error   string         `json:"error"`
+	Revert  string         `json:"revertReason"`
+	Calls   []callTrace    `json:"calls"`
+	Logs    []SimulatedLog `json:"logs"`
+}
+
+// SimulateEthereumTransaction predicts the outcome of an EVM transaction.
+// Tenderly is used when configured, otherwise the node traces the call with
+// debug_traceCall. Nodes that don't support tracing only tell if it succeeds.
+func (c *Client) SimulateEthereumTransaction(ctx context.Context, chainID *big.Int, from, to string, value *big.Int, data []byte, gas uint64, gasPrice *big.Int) (*Simulation, error) {
+	if cfg, err := config.Load(); err == nil {
+		account, project, accessKey := cfg.Get(config.KeyTenderlyAccount), cfg.Get(config.KeyTenderlyProject), cfg.Get(config.KeyTenderlyAccessKey)
+		if account != "" && project != "" && accessKey != "" {
+			return c.simulateWithTenderly(ctx, account, project, accessKey, chainID, from, to, value, data, gas, gasPrice)
+		}
+	}
+
+	call := map[string]string{
+		"from":     from,
+		"to":       to,
+		"gas":      fmt.Sprintf("0x%x", gas),
+		"gasPrice": "0x" + gasPrice.Text(16),
+		"value":    "0x" + value.Text(16),
+		"data":     fmt.Sprintf("0x%x", data),
+	}
+
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "debug_traceCall",
+		"params": []interface{}{
+			call,
+			"latest",
+			map[string]interface{}{"tracer": "callTracer", "tracerConfig": map[string]bool{"withLog": true}},
+		},
+		"id": 1,
+	}
+
+	response, err := c.postJSON(ctx, c.GetEthereumRPC(), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+
+	var rpcResp struct {
+		Result *callTrace `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Public nodes rarely allow tracing, a plain call still predicts failures
+	if rpcResp.Error != nil || rpcResp.Result == nil {
+		return c.simulateWithCall(ctx, call)
+	}
+
+	trace := rpcResp.Result
+	sim := &Simulation{Provider: "node trace", Success: trace.Error == "", Detailed: true}
+	if !sim.Success {
+		sim.Error = trace.Error
+		if trace.Revert != "" {
+			sim.Error += ": " + trace.Revert
+		}
+	}
+	if used, err := parseHexInt(trace.GasUsed); err == nil {
+		sim.GasUsed = used
+	}
+
+	var changes balanceChanges
+	if sim.Success {
+		collectTrace(trace, &sim.Logs, &changes)
+		changes.logTransfers(sim.Logs)
+	}
+	// The sender pays for the gas either way
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(sim.GasUsed))
+	changes.add(from, "", nil, new(big.Int).Neg(fee))
+	sim.BalanceChanges = changes.list
+
+	return sim, nil
+}
+
+// simulateWithCall runs a transaction as eth_call, which only tells if it succeeds
+func (c *Client) simulateWithCall(ctx context.Context, call map[string]string) (*Simulation, error) {
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "eth_call",
+		"params":  []interface{}{call, "latest"},
+		"id":      1,
+	}
+
+	response, err := c.postJSON(ctx, c.GetEthereumRPC(), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+
+	var rpcResp EthereumRPCResponse
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	sim := &Simulation{Provider: "eth_call", Success: rpcResp.Error == nil}
+	if rpcResp.Error != nil {
+		sim.Error = rpcResp.Error.Message
+	}
+	return sim, nil
+}
+
+// simulateWithTenderly runs a transaction through the Tenderly simulation API
+func (c *Client) simulateWithTenderly(ctx context.Context, account, project, accessKey string, chainID *big.Int, from, to string, value *big.Int, data []byte, gas uint64, gasPrice *big.Int) (*Simulation, error) {
+	payload := map[string]interface{}{
+		"network_id":      chainID.String(),
+		"from":            from,
+		"to":              to,
+		"input":           fmt.Sprintf("0x%x", data),
+		"gas":             gas,
+		"gas_price":       gasPrice.String(),
+		"value":           value.String(),
+		"save":            false,
+		"simulation_type": "full",
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/account/%s/project/%s/simulate", TenderlyAPI, account, project)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Access-Key", accessKey)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+	defer resp.Body.Close()
+
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to simulate transaction: %w", &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(response)})
+	}
+
+	var result struct {
+		Transaction struct {
+			Status          bool   `json:"status"`
+			ErrorMessage    string `json:"error_message"`
+			GasUsed         uint64 `json:"gas_used"`
+			TransactionInfo struct {
+				Logs []struct {
+					Raw SimulatedLog `json:"raw"`
+				} `json:"logs"`
+				BalanceDiff []struct {
+					Address  string `json:"address"`
+					Original string `json:"original"`
+					Dirty    string `json:"dirty"`
+					IsMiner  bool   `json:"is_miner"`
+				} `json:"balance_diff"`
+			} `json:"transaction_info"`
+		} `json:"transaction"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	tx := result.Transaction
+	sim := &Simulation{
+		Provider: "Tenderly",
+		Success:  tx.Status,
+		Error:    tx.ErrorMessage,
+		GasUsed:  tx.GasUsed,
+		Detailed: true,
+	}
+	for _, log := range tx.TransactionInfo.Logs {
+		sim.Logs = append(sim.Logs, log.Raw)
+	}
+
+	var changes balanceChanges
+	for _, diff := range tx.TransactionInfo.BalanceDiff {
+		original, okOriginal := new(big.Int).SetString(diff.Original, 10)
+		dirty, okDirty := new(big.Int).SetString(diff.Dirty, 10)
+		if diff.IsMiner || !okOriginal || !okDirty {
+			continue
+		}
+		changes.add(diff.Address, "", nil, dirty.Sub(dirty, original))
+	}
+	if sim.Success {
+		changes.logTransfers(sim.Logs)
+	}
+	sim.BalanceChanges = changes.list
+
+	return sim, nil
+}
+
+// SimulateSolanaTransaction predicts the outcome of a signed Solana
+// transaction and the SOL balance changes of the given accounts
+func (c *Client) SimulateSolanaTransaction(ctx context.Context, signedTx string, accounts []string) (*Simulation, error) {
+	before := make([]uint64, len(accounts))
+	for i, account := range accounts {
+		balance, err := c.GetSolanaBalance(ctx, account)
+		if err != nil {
+			return nil, err
+		}
+		before[i] = balance
+	}
+
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "simulateTransaction",
+		"params": []interface{}{
+			signedTx,
+			map[string]interface{}{
+				"encoding":               "base58",
+				"commitment":             "confirmed",
+				"replaceRecentBlockhash": true,
+				"accounts":               map[string]interface{}{"encoding": "base64", "addresses": accounts},
+			},
+		},
+		"id": 1,
+	}
+
+	response, err := c.postJSON(ctx, c.GetSolanaRPC(), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+
+	var rpcResp struct {
+		Result *struct {
+			Value struct {
+				Err           json.RawMessage `json:"err"`
+				Logs          []string        `json:"logs"`
+				UnitsConsumed uint64          `json:"unitsConsumed"`
+				Accounts      []*struct {
+					Lamports uint64 `json:"lamports"`
+				} `json:"accounts"`
+			} `json:"value"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("failed to simulate transaction: RPC error: %s", rpcResp.Error.Message)
+	}
+	if rpcResp.Result == nil {
+		return nil, fmt.Errorf("no result in response")
+	}
+
+	value := rpcResp.Result.Value
+	sim := &Simulation{
+		Provider:    "Solana RPC",
+		Success:     len(value.Err) == 0 || string(value.Err) == "null",
+		GasUsed:     value.UnitsConsumed,
+		ProgramLogs: value.Logs,
+		Detailed:    true,
+	}
+	if !sim.Success {
+		sim.Error = DecodeSolanaError(value.Err, value.Logs).Error()
+		return sim, nil
+	}
+
+	var changes balanceChanges
+	for i, account := range value.Accounts {
+		if i >= len(accounts) {
+			break
+		}
+		after := uint64(0)
+		if account != nil {
+			after = account.Lamports
+		}
+		delta := new(big.Int).SetUint64(after)
+		changes.add(accounts[i], "", nil, delta.Sub(delta, new(big.Int).SetUint64(before[i])))
+	}
+	sim.BalanceChanges = changes.list
+
+	return sim, nil
+}
+
+// Event signatures of token transfers
+const (
+	transferTopic       = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" // Transfer(address,address,uint256)
+	transferSingleTopic = "0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62" // TransferSingle(address,address,address,uint256,uint256)
+)
+
+// balanceChanges sums balance changes per address and asset, in the order
+// they are first seen
+type balanceChanges struct {
+	list []BalanceChange
+}
+
+func (b *balanceChanges) add(address, token string, tokenID, amount *big.Int) {
+	if amount.Sign() == 0 {
+		return
+	}
+	for i := range b.list {
+		change := &b.list[i]
+		if strings.EqualFold(change.Address, address) && strings.EqualFold(change.Token, token) && sameTokenID(change.TokenID, tokenID) {
+			change.Amount.Add(change.Amount, amount)
+			return
+		}
+	}
+	b.list = append(b.list, BalanceChange{Address: address, Token: token, TokenID: tokenID, Amount: new(big.Int).Set(amount)})
+}
+
+// logTransfers adds the token transfers found in event logs
+func (b *balanceChanges) logTransfers(logs []SimulatedLog) {
+	for _, transfer := range tokenTransfers(logs) {
+		b.add(transfer.From, transfer.Token, transfer.TokenID, new(big.Int).Neg(transfer.Amount))
+		b.add(transfer.To, transfer.Token, transfer.TokenID, transfer.Amount)
+	}
+}
+
+// tokenTransfers decodes the ERC-20, ERC-721 and ERC-1155 transfers in event logs
+func tokenTransfers(logs []SimulatedLog) []TokenTransfer {
+	var transfers []TokenTransfer
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		payload := hexBytes(log.Data)
+
+		switch strings.ToLower(log.Topics[0]) {
+		case transferTopic:
+			switch len(log.Topics) {
+			case 3: // ERC-20, the amount is in the data
+				if len(payload) < 32 {
+					continue
+				}
+				amount := new(big.Int).SetBytes(payload[:32])
+				transfers = append(transfers, TokenTransfer{Token: log.Address, From: topicAddress(log.Topics[1]), To: topicAddress(log.Topics[2]), Amount: amount})
+			case 4: // ERC-721, the token ID is indexed
+				tokenID := new(big.Int).SetBytes(hexBytes(log.Topics[3]))
+				transfers = append(transfers, TokenTransfer{Token: log.Address, From: topicAddress(log.Topics[1]), To: topicAddress(log.Topics[2]), TokenID: tokenID, Amount: big.NewInt(1)})
+			}
+		case transferSingleTopic:
+			if len(log.Topics) != 4 || len(payload) < 64 {
+				continue
+			}
+			tokenID := new(big.Int).SetBytes(payload[:32])
+			amount := new(big.Int).SetBytes(payload[32:64])
+			transfers = append(transfers, TokenTransfer{Token: log.Address, From: topicAddress(log.Topics[2]), To: topicAddress(log.Topics[3]), TokenID: tokenID, Amount: amount})
+		}
+	}
+	return transfers
+}
+
+// collectTrace gathers the logs and value transfers of a call and its subcalls
+func collectTrace(trace *callTrace, logs *[]SimulatedLog, changes *balanceChanges) {
+	if trace.Error != "" {
+		// Reverted subcalls have no effect
+		return
+	}
+	*logs = append(*logs, trace.Logs...)
+
+	if trace.Type != "DELEGATECALL" && trace.Type != "STATICCALL" {
+		if value, err := parseHexBigInt(trace.Value); err == nil && value.Sign() > 0 {
+			changes.add(trace.From, "", nil, new(big.Int).Neg(value))
+			changes.add(trace.To, "", nil, value)
+		}
+	}
+
+	for i := range trace.Calls {
+		collectTrace(&trace.Calls[i], logs, changes)
+	}
+}
+
+// topicAddress extracts the address from an indexed event argument
+func topicAddress(topic string) string {
+	raw := strings.TrimPrefix(topic, "0x")
+	if len(raw) < 40 {
+		return ""
+	}
+	return checksumAddress("0x" + raw[len(raw)-40:])
+}
+
+// hexBytes decodes 0x prefixed hex, returning nothing if it is invalid
+func hexBytes(value string) []byte {
+	raw, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+func sameTokenID(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Cmp(b) == 0
+}
@@ -1,24 +1,59 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"math"
+	"log/slog"
+	"net/url"
 	"strings"
 	"time"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/shopspring/decimal"
 )
 
 // GetSolanaRPC returns the appropriate Solana RPC URL
 func (c *Client) GetSolanaRPC() string {
+	return c.net.SolanaRPC
+}
+
+// newHeliusProvider serves Solana from Helius nodes, on mainnet and devnet
+func newHeliusProvider(c *Client) (interface{}, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	apiKey := cfg.Get(config.KeyHeliusAPIKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("a Helius API key is required. Set one with 'odyssey config set %s <key>'", config.KeyHeliusAPIKey)
+	}
+
+	node := HeliusMainnetRPC
 	if c.IsTestnet() {
-		return TestnetSolanaRPC
+		if c.net.SolanaCluster != "devnet" {
+			return nil, fmt.Errorf("helius only serves Solana mainnet and devnet")
+		}
+		node = HeliusDevnetRPC
 	}
-	return MainnetSolanaRPC
+	return solanaRPCProvider{c: c, url: node + "/?api-key=" + url.QueryEscape(apiKey)}, nil
 }
 
-// GetSolanaBalance fetches Solana balance
-func (c *Client) GetSolanaBalance(address string) (uint64, error) {
-	url := c.GetSolanaRPC()
+// GetSolanaBalance fetches Solana balance from the configured provider
+func (c *Client) GetSolanaBalance(ctx context.Context, address string) (uint64, error) {
+	provider, err := c.BalanceProvider("solana")
+	if err != nil {
+		return 0, err
+	}
+	balance, err := provider.Balance(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	return balance.Uint64(), nil
+}
+
+// solanaBalance fetches the balance of an address from a Solana node
+func (c *Client) solanaBalance(ctx context.Context, url, address string) (uint64, error) {
 
 	payload := map[string]interface{}{
 		"jsonrpc": "2.0",
@@ -27,7 +62,7 @@ func (c *Client) GetSolanaBalance(address string) (uint64, error) {
 		"id":      1,
 	}
 
-	response, err := c.postJSON(url, payload)
+	response, err := c.postJSON(ctx, url, payload)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch Solana balance: %w", err)
 	}
@@ -68,32 +103,138 @@ func (c *Client) GetSolanaBalance(address string) (uint64, error) {
 }
 
 // GetSolanaRecentBlockhash gets a recent blockhash for Solana transactions
-func (c *Client) GetSolanaRecentBlockhash() (string, error) {
-	url := c.GetSolanaRPC()
-
-	fmt.Printf("🔍 Debug: Getting blockhash from: %s\n", url)
+func (c *Client) GetSolanaRecentBlockhash(ctx context.Context) (string, error) {
+	blockhash, _, err := c.GetSolanaLatestBlockhash(ctx)
+	return blockhash, err
+}
 
+// GetSolanaLatestBlockhash returns a recent blockhash and the last block
+// height at which transactions using it are still processed
+func (c *Client) GetSolanaLatestBlockhash(ctx context.Context) (string, uint64, error) {
+	var result struct {
+		Value struct {
+			Blockhash            string `json:"blockhash"`
+			LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+		} `json:"value"`
+	}
 	// Use "finalized" commitment for the freshest blockhash that's already confirmed
+	params := []interface{}{map[string]interface{}{"commitment": "finalized"}}
+	if err := c.solanaCall(ctx, "getLatestBlockhash", params, &result); err != nil {
+		return "", 0, fmt.Errorf("failed to get recent blockhash: %w", err)
+	}
+	if result.Value.Blockhash == "" {
+		return "", 0, fmt.Errorf("missing 'blockhash' in result")
+	}
+
+	slog.Debug("got Solana blockhash", "blockhash", result.Value.Blockhash, "last_valid_block_height", result.Value.LastValidBlockHeight)
+	return result.Value.Blockhash, result.Value.LastValidBlockHeight, nil
+}
+
+// GetSolanaBlockHeight returns the current block height
+func (c *Client) GetSolanaBlockHeight(ctx context.Context) (uint64, error) {
+	var height uint64
+	params := []interface{}{map[string]interface{}{"commitment": "confirmed"}}
+	if err := c.solanaCall(ctx, "getBlockHeight", params, &height); err != nil {
+		return 0, fmt.Errorf("failed to get block height: %w", err)
+	}
+	return height, nil
+}
+
+// SolanaSignatureStatus is how far a transaction has been confirmed
+type SolanaSignatureStatus struct {
+	Slot               uint64          `json:"slot"`
+	ConfirmationStatus string          `json:"confirmationStatus"` // processed, confirmed or finalized
+	Err                json.RawMessage `json:"err"`                // set if the transaction failed
+}
+
+// Failed returns true if the transaction failed, see DecodeSolanaError
+func (s *SolanaSignatureStatus) Failed() bool {
+	return len(s.Err) > 0 && string(s.Err) != "null"
+}
+
+// GetSolanaSignatureStatus returns the status of a transaction, or nil if the
+// cluster hasn't seen it
+func (c *Client) GetSolanaSignatureStatus(ctx context.Context, signature string) (*SolanaSignatureStatus, error) {
+	var result struct {
+		Value []*SolanaSignatureStatus `json:"value"`
+	}
+	params := []interface{}{[]string{signature}, map[string]interface{}{"searchTransactionHistory": true}}
+	if err := c.solanaCall(ctx, "getSignatureStatuses", params, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch signature status: %w", err)
+	}
+	if len(result.Value) == 0 {
+		return nil, nil
+	}
+	return result.Value[0], nil
+}
+
+// SendSolanaTransaction sends a Solana transaction with the configured provider
+func (c *Client) SendSolanaTransaction(ctx context.Context, signedTx string) (string, error) {
+	provider, err := c.BroadcastProvider("solana")
+	if err != nil {
+		return "", err
+	}
+	return provider.Broadcast(ctx, signedTx)
+}
+
+// skipPreflightKey marks a context whose Solana transactions are sent
+// without preflight, see WithSkipPreflight
+type skipPreflightKey struct{}
+
+// WithSkipPreflight makes the Solana transactions sent with ctx skip the
+// node's preflight simulation. Congested nodes fail preflight for
+// transactions that would land, and without it errors only show up in the
+// signature status.
+func WithSkipPreflight(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipPreflightKey{}, true)
+}
+
+// skipPreflight returns true if ctx was made by WithSkipPreflight
+func skipPreflight(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipPreflightKey{}).(bool)
+	return skip
+}
+
+// sendSolanaTransaction sends a signed transaction to a Solana node. A
+// failed preflight is returned as a *SolanaTransactionError.
+func (c *Client) sendSolanaTransaction(ctx context.Context, url, signedTx string) (string, error) {
+
+	slog.Debug("sending Solana transaction", "rpc", url, "length", len(signedTx), "skip_preflight", skipPreflight(ctx))
+
 	payload := map[string]interface{}{
 		"jsonrpc": "2.0",
-		"id":      1,
-		"method":  "getLatestBlockhash",
-		"params":  []interface{}{map[string]interface{}{"commitment": "finalized"}},
+		"method":  "sendTransaction",
+		"params": []interface{}{
+			signedTx,
+			map[string]interface{}{"encoding": "base58", "skipPreflight": skipPreflight(ctx)},
+		},
+		"id": 1,
 	}
 
-	response, err := c.postJSON(url, payload)
+	response, err := c.postJSON(ctx, url, payload)
 	if err != nil {
-		return "", fmt.Errorf("failed to get recent blockhash: %w", err)
+		return "", fmt.Errorf("failed to send transaction: %w", err)
 	}
 
-	fmt.Printf("🔍 Debug: Blockhash response: %s\n", string(response))
-
-	var rpcResp SolanaRPCResponse
+	var rpcResp struct {
+		Result interface{} `json:"result"`
+		Error  *RPCError   `json:"error"`
+	}
 	if err := json.Unmarshal(response, &rpcResp); err != nil {
 		return "", fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if rpcResp.Error != nil {
+		slog.Debug("Solana transaction rejected", "code", rpcResp.Error.Code, "message", rpcResp.Error.Message)
+
+		// A failed preflight carries the simulation's error and logs
+		var preflight struct {
+			Err  json.RawMessage `json:"err"`
+			Logs []string        `json:"logs"`
+		}
+		if json.Unmarshal(rpcResp.Error.Data, &preflight) == nil && len(preflight.Err) > 0 && string(preflight.Err) != "null" {
+			return "", DecodeSolanaError(preflight.Err, preflight.Logs)
+		}
 		return "", fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
 	}
 
@@ -101,76 +242,189 @@ func (c *Client) GetSolanaRecentBlockhash() (string, error) {
 		return "", fmt.Errorf("no result in response")
 	}
 
-	// Parse result as map
-	resultMap, ok := rpcResp.Result.(map[string]interface{})
+	txHash, ok := rpcResp.Result.(string)
 	if !ok {
-		return "", fmt.Errorf("unexpected result format")
+		return "", fmt.Errorf("invalid transaction hash format")
 	}
 
-	// For the standard response format from getLatestBlockhash
-	valueMap, ok := resultMap["value"].(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("missing 'value' in result")
+	return txHash, nil
+}
+
+// RequestSolanaAirdrop asks the devnet faucet to send lamports to an address
+// and returns the airdrop transaction signature
+func (c *Client) RequestSolanaAirdrop(ctx context.Context, address string, lamports uint64) (string, error) {
+	if !c.IsTestnet() {
+		return "", fmt.Errorf("airdrops are only available on devnet")
 	}
 
-	blockhash, ok := valueMap["blockhash"].(string)
-	if !ok {
-		return "", fmt.Errorf("missing 'blockhash' in result")
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "requestAirdrop",
+		"params":  []interface{}{address, lamports},
 	}
 
-	fmt.Printf("🔍 Debug: Got blockhash: %s\n", blockhash)
-	return blockhash, nil
+	response, err := c.postJSON(ctx, c.GetSolanaRPC(), payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to request airdrop: %w", err)
+	}
+
+	var rpcResp SolanaRPCResponse
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return "", fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+
+	signature, ok := rpcResp.Result.(string)
+	if !ok || signature == "" {
+		return "", fmt.Errorf("no signature in response")
+	}
+
+	return signature, nil
 }
 
-// SendSolanaTransaction sends a Solana transaction
-func (c *Client) SendSolanaTransaction(signedTx string) (string, error) {
-	url := c.GetSolanaRPC()
+// GetSolanaRentExemptMinimum returns the balance in lamports an account with
+// dataSize bytes of data needs to be exempt from rent
+func (c *Client) GetSolanaRentExemptMinimum(ctx context.Context, dataSize int) (uint64, error) {
+	var lamports uint64
+	if err := c.solanaCall(ctx, "getMinimumBalanceForRentExemption", []interface{}{dataSize}, &lamports); err != nil {
+		return 0, fmt.Errorf("failed to fetch rent-exempt minimum: %w", err)
+	}
+	return lamports, nil
+}
 
-	// Debug logging
-	fmt.Printf("🔍 Debug: Sending to RPC: %s\n", url)
-	fmt.Printf("🔍 Debug: Transaction length: %d chars\n", len(signedTx))
+// SolanaNonceAccount is the state of a durable nonce account
+type SolanaNonceAccount struct {
+	Authority string // may advance the nonce and withdraw from the account
+	Nonce     string // used in place of a recent blockhash
+	Lamports  uint64
+}
 
+// GetSolanaNonceAccount returns the state of a durable nonce account, or nil
+// if the account doesn't exist
+func (c *Client) GetSolanaNonceAccount(ctx context.Context, address string) (*SolanaNonceAccount, error) {
+	var result struct {
+		Value *struct {
+			Lamports uint64 `json:"lamports"`
+			Data     struct {
+				Program string `json:"program"`
+				Parsed  struct {
+					Type string `json:"type"`
+					Info struct {
+						Authority string `json:"authority"`
+						Blockhash string `json:"blockhash"`
+					} `json:"info"`
+				} `json:"parsed"`
+			} `json:"data"`
+		} `json:"value"`
+	}
+	params := []interface{}{address, map[string]interface{}{"encoding": "jsonParsed", "commitment": "confirmed"}}
+	if err := c.solanaCall(ctx, "getAccountInfo", params, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch nonce account: %w", err)
+	}
+	if result.Value == nil {
+		return nil, nil
+	}
+
+	data := result.Value.Data
+	if data.Program != "nonce" || data.Parsed.Type != "initialized" {
+		return nil, fmt.Errorf("%s is not an initialized nonce account", address)
+	}
+	return &SolanaNonceAccount{
+		Authority: data.Parsed.Info.Authority,
+		Nonce:     data.Parsed.Info.Blockhash,
+		Lamports:  result.Value.Lamports,
+	}, nil
+}
+
+// IsSolanaBlockhashValid returns true while transactions using the blockhash
+// can still be processed
+func (c *Client) IsSolanaBlockhashValid(ctx context.Context, blockhash string) (bool, error) {
 	payload := map[string]interface{}{
 		"jsonrpc": "2.0",
-		"method":  "sendTransaction",
-		"params":  []string{signedTx},
 		"id":      1,
+		"method":  "isBlockhashValid",
+		"params":  []interface{}{blockhash, map[string]interface{}{"commitment": "processed"}},
 	}
 
-	response, err := c.postJSON(url, payload)
+	response, err := c.postJSON(ctx, c.GetSolanaRPC(), payload)
 	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
+		return false, fmt.Errorf("failed to check blockhash: %w", err)
 	}
 
-	// Debug logging
-	fmt.Printf("🔍 Debug: Raw response: %s\n", string(response))
-
-	var rpcResp SolanaRPCResponse
+	var rpcResp struct {
+		Result *struct {
+			Value bool `json:"value"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
 	if err := json.Unmarshal(response, &rpcResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return false, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if rpcResp.Error != nil {
-		fmt.Printf("🔍 Debug: RPC Error Code: %v\n", rpcResp.Error.Code)
-		fmt.Printf("🔍 Debug: RPC Error Message: %s\n", rpcResp.Error.Message)
-		return "", fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+		return false, fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
 	}
-
 	if rpcResp.Result == nil {
-		return "", fmt.Errorf("no result in response")
+		return false, fmt.Errorf("no result in response")
 	}
 
-	txHash, ok := rpcResp.Result.(string)
-	if !ok {
-		return "", fmt.Errorf("invalid transaction hash format")
+	return rpcResp.Result.Value, nil
+}
+
+// SolanaTransactionKnown returns true if the cluster has seen a transaction signature
+func (c *Client) SolanaTransactionKnown(ctx context.Context, signature string) (bool, error) {
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getSignatureStatuses",
+		"params":  []interface{}{[]string{signature}, map[string]interface{}{"searchTransactionHistory": true}},
 	}
 
-	return txHash, nil
+	response, err := c.postJSON(ctx, c.GetSolanaRPC(), payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch signature status: %w", err)
+	}
+
+	var rpcResp struct {
+		Result *struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return false, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return false, fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+
+	return rpcResp.Result != nil && len(rpcResp.Result.Value) > 0 && rpcResp.Result.Value[0] != nil, nil
 }
 
-// GetSolanaTransactions fetches transaction history for a Solana address
-func (c *Client) GetSolanaTransactions(address string) ([]Transaction, error) {
-	url := c.GetSolanaRPC()
+// GetSolanaTransactions fetches a page of transaction history for a Solana
+// address from the configured provider
+func (c *Client) GetSolanaTransactions(ctx context.Context, address string, limit int, cursor string) (*TransactionPage, error) {
+	ctx = withOperation(ctx, config.OperationHistory)
+	provider, err := c.HistoryProvider("solana")
+	if err != nil {
+		return nil, err
+	}
+	return provider.History(ctx, address, limit, cursor)
+}
+
+// solanaTransactions fetches a page of the transactions of an address from a
+// Solana node. The cursor is the signature to page backwards from; an empty
+// cursor starts at the newest.
+func (c *Client) solanaTransactions(ctx context.Context, url, address string, limit int, cursor string) (*TransactionPage, error) {
 
 	// First check if account exists
 	balancePayload := map[string]interface{}{
@@ -180,7 +434,7 @@ func (c *Client) GetSolanaTransactions(address string) ([]Transaction, error) {
 		"params":  []interface{}{address},
 	}
 
-	balanceResp, err := c.postJSON(url, balancePayload)
+	balanceResp, err := c.postJSON(ctx, url, balancePayload)
 	if err == nil {
 		var balanceResult SolanaRPCResponse
 		if err := json.Unmarshal(balanceResp, &balanceResult); err == nil {
@@ -189,20 +443,25 @@ func (c *Client) GetSolanaTransactions(address string) ([]Transaction, error) {
 			if balanceResult.Error != nil &&
 				strings.Contains(balanceResult.Error.Message, "could not find account") {
 				// Return empty list - no transactions for non-existent account
-				return []Transaction{}, nil
+				return &TransactionPage{Transactions: []Transaction{}}, nil
 			}
 		}
 	}
 
 	// Get signature history first
+	options := map[string]interface{}{"limit": limit}
+	if cursor != "" {
+		options["before"] = cursor
+	}
+
 	payload := map[string]interface{}{
 		"jsonrpc": "2.0",
 		"id":      1,
 		"method":  "getSignaturesForAddress",
-		"params":  []interface{}{address, map[string]interface{}{"limit": 20}},
+		"params":  []interface{}{address, options},
 	}
 
-	signaturesResp, err := c.postJSON(url, payload)
+	signaturesResp, err := c.postJSON(ctx, url, payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch signatures: %w", err)
 	}
@@ -227,18 +486,23 @@ func (c *Client) GetSolanaTransactions(address string) ([]Transaction, error) {
 	if signaturesResult.Error != nil {
 		// This error is normal for accounts that don't exist yet
 		if strings.Contains(signaturesResult.Error.Message, "could not find account") {
-			return []Transaction{}, nil
+			return &TransactionPage{Transactions: []Transaction{}}, nil
 		}
 		return nil, fmt.Errorf("RPC error: %s", signaturesResult.Error.Message)
 	}
 
 	if len(signaturesResult.Result) == 0 {
 		// No transactions found
-		return []Transaction{}, nil
+		return &TransactionPage{Transactions: []Transaction{}}, nil
 	}
 
 	// Now get transaction details for each signature
-	transactions := make([]Transaction, 0, len(signaturesResult.Result))
+	page := &TransactionPage{Transactions: make([]Transaction, 0, len(signaturesResult.Result))}
+
+	// A full page means older signatures may exist
+	if len(signaturesResult.Result) == limit {
+		page.NextCursor = signaturesResult.Result[len(signaturesResult.Result)-1].Signature
+	}
 
 	for _, sig := range signaturesResult.Result {
 		// Get transaction details with parsed data
@@ -249,7 +513,7 @@ func (c *Client) GetSolanaTransactions(address string) ([]Transaction, error) {
 			"params":  []interface{}{sig.Signature, map[string]interface{}{"encoding": "jsonParsed", "maxSupportedTransactionVersion": 0}},
 		}
 
-		txResp, err := c.postJSON(url, txPayload)
+		txResp, err := c.postJSON(ctx, url, txPayload)
 		if err != nil {
 			continue // Skip this transaction if we can't fetch it
 		}
@@ -301,10 +565,10 @@ func (c *Client) GetSolanaTransactions(address string) ([]Transaction, error) {
 
 		// Determine direction and amount
 		isIncoming := balChange > 0
-		amount := math.Abs(float64(balChange)) / 1000000000.0 // Convert lamports to SOL
+		amount := decimal.New(balChange, -9).Abs() // Convert lamports to SOL
 
 		// Fee is always paid by the first account
-		fee := float64(txResult.Result.Meta.Fee) / 1000000000.0
+		fee := decimal.New(txResult.Result.Meta.Fee, -9)
 
 		// Get from/to addresses (simplification - first two accounts)
 		from := txResult.Result.Transaction.Message.AccountKeys[0].Pubkey
@@ -313,17 +577,17 @@ func (c *Client) GetSolanaTransactions(address string) ([]Transaction, error) {
 			to = txResult.Result.Transaction.Message.AccountKeys[1].Pubkey
 		}
 
-		transactions = append(transactions, Transaction{
+		page.Transactions = append(page.Transactions, Transaction{
 			Hash:        sig.Signature,
 			From:        from,
 			To:          to,
-			Amount:      fmt.Sprintf("%.9f SOL", amount),
-			Fee:         fmt.Sprintf("%.9f SOL", fee),
+			Amount:      amount.StringFixed(9) + " SOL",
+			Fee:         fee.StringFixed(9) + " SOL",
 			BlockNumber: sig.Slot,
 			Timestamp:   time.Unix(sig.BlockTime, 0),
 			IsIncoming:  isIncoming,
 		})
 	}
 
-	return transactions, nil
+	return page, nil
 }
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SolanaTransactionError is a transaction error reported by a Solana node,
+// decoded into a readable message
+type SolanaTransactionError struct {
+	Name    string   // e.g. InsufficientFundsForRent or the program error
+	Message string   // what went wrong, in plain words
+	Logs    []string // program logs of the failed simulation, if any
+}
+
+func (e *SolanaTransactionError) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Message, e.Name)
+}
+
+// solanaTransactionErrors describes the errors of a whole transaction
+var solanaTransactionErrors = map[string]string{
+	"AccountInUse":                   "an account is in use by another transaction, try again",
+	"AccountNotFound":                "the fee payer account doesn't exist, it has to receive SOL first",
+	"AlreadyProcessed":               "the transaction has already been processed",
+	"BlockhashNotFound":              "the blockhash has expired or the node hasn't seen it yet, sign the transaction again",
+	"InsufficientFundsForFee":        "not enough SOL to pay the transaction fee",
+	"InsufficientFundsForRent":       "an account would be left with less than its rent-exempt minimum",
+	"SignatureFailure":               "a signature is invalid",
+	"WouldExceedMaxBlockCostLimit":   "the block is full, try again",
+	"WouldExceedMaxAccountCostLimit": "an account in the transaction is too busy, try again",
+}
+
+// solanaInstructionErrors describes the built-in errors of an instruction
+var solanaInstructionErrors = map[string]string{
+	"AccountAlreadyInitialized":   "the account already exists",
+	"ComputationalBudgetExceeded": "the transaction ran out of compute units",
+	"InsufficientFunds":           "insufficient funds",
+	"InvalidAccountData":          "an account holds unexpected data",
+	"MissingRequiredSignature":    "a required signature is missing",
+	"ProgramFailedToComplete":     "the program ran out of compute units",
+}
+
+// solanaProgramErrors describes the custom errors of well-known programs, by
+// program ID and error code
+var solanaProgramErrors = map[string]map[uint64]string{
+	// System program
+	"11111111111111111111111111111111": {
+		0: "the account already exists",
+		1: "insufficient funds for the transfer",
+		5: "the address doesn't match its seed",
+		6: "the cluster has no recent blockhashes for the nonce yet",
+		7: "the nonce was already used in this block, wait for the next one",
+		8: "the durable nonce has been advanced, sign the transaction again",
+	},
+	// SPL Token
+	"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA": {
+		0:  "the token account isn't rent exempt",
+		1:  "insufficient token balance",
+		3:  "the token account holds another token",
+		4:  "the token account belongs to someone else",
+		17: "the token account is frozen",
+	},
+	// Jupiter aggregator v6
+	"JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4": {
+		6001: "the price moved beyond the slippage tolerance, try again or allow more slippage",
+	},
+}
+
+// DecodeSolanaError turns the err of a failed Solana transaction, as found in
+// simulations, preflight failures and signature statuses, into a readable
+// error. The logs name the program of a custom program error.
+func DecodeSolanaError(raw json.RawMessage, logs []string) *SolanaTransactionError {
+	// Errors without details are a bare string, the others an object with
+	// one key
+	var name string
+	var detail json.RawMessage
+	if err := json.Unmarshal(raw, &name); err != nil {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil || len(fields) != 1 {
+			return &SolanaTransactionError{Name: "Unknown", Message: "the transaction failed: " + string(raw), Logs: logs}
+		}
+		for key, value := range fields {
+			name, detail = key, value
+		}
+	}
+
+	if name == "InstructionError" {
+		return decodeSolanaInstructionError(detail, logs)
+	}
+
+	message, ok := solanaTransactionErrors[name]
+	if !ok {
+		message = "the transaction was rejected"
+	}
+	return &SolanaTransactionError{Name: name, Message: message, Logs: logs}
+}
+
+// decodeSolanaInstructionError decodes the [index, error] of an instruction
+// error, where error is a name or {"Custom": code}
+func decodeSolanaInstructionError(detail json.RawMessage, logs []string) *SolanaTransactionError {
+	var parts []json.RawMessage
+	var index int
+	if json.Unmarshal(detail, &parts) != nil || len(parts) != 2 || json.Unmarshal(parts[0], &index) != nil {
+		return &SolanaTransactionError{Name: "InstructionError", Message: "an instruction failed: " + string(detail), Logs: logs}
+	}
+
+	var name string
+	if json.Unmarshal(parts[1], &name) == nil {
+		message, ok := solanaInstructionErrors[name]
+		if !ok {
+			message = "the program rejected it"
+		}
+		return &SolanaTransactionError{Name: name, Message: fmt.Sprintf("instruction %d failed: %s", index, message), Logs: logs}
+	}
+
+	var custom struct {
+		Custom *uint64 `json:"Custom"`
+	}
+	if json.Unmarshal(parts[1], &custom) != nil || custom.Custom == nil {
+		return &SolanaTransactionError{Name: "InstructionError", Message: fmt.Sprintf("instruction %d failed: %s", index, parts[1]), Logs: logs}
+	}
+
+	code := *custom.Custom
+	name = "custom program error 0x" + strconv.FormatUint(code, 16)
+	program := failedSolanaProgram(logs)
+	message, ok := solanaProgramErrors[program][code]
+	switch {
+	case ok:
+	case program != "":
+		message = "program " + program + " returned an error"
+	default:
+		message = "the program returned an error"
+	}
+	return &SolanaTransactionError{Name: name, Message: fmt.Sprintf("instruction %d failed: %s", index, message), Logs: logs}
+}
+
+// failedSolanaProgram finds the program that failed in the logs of a
+// transaction, e.g. "Program 1111... failed: custom program error: 0x1"
+func failedSolanaProgram(logs []string) string {
+	for i := len(logs) - 1; i >= 0; i-- {
+		fields := strings.Fields(logs[i])
+		if len(fields) > 2 && fields[0] == "Program" && fields[2] == "failed:" {
+			return fields[1]
+		}
+	}
+	return ""
+}
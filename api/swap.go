@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/shopspring/decimal"
+)
+
+// ThorchainDecimals is the fixed precision THORChain uses for every asset amount
+const ThorchainDecimals = 8
+
+// thorchainAssets maps chain names to THORChain pool assets
+var thorchainAssets = map[string]string{
+	"ethereum": "ETH.ETH",
+	"bitcoin":  "BTC.BTC",
+}
+
+// SwapFees holds the fees of a swap quote, in units of the destination asset
+type SwapFees struct {
+	Asset       string          `json:"asset"`
+	Outbound    decimal.Decimal `json:"outbound"`
+	Liquidity   decimal.Decimal `json:"liquidity"`
+	Total       decimal.Decimal `json:"total"`
+	SlippageBps int             `json:"slippage_bps"`
+	TotalBps    int             `json:"total_bps"`
+}
+
+// SwapQuote is a cross-chain swap quote. The swap is executed by sending
+// AmountIn to InboundAddress (through Router on EVM chains) with Memo attached.
+type SwapQuote struct {
+	FromChain         string          `json:"from_chain"`
+	ToChain           string          `json:"to_chain"`
+	AmountIn          decimal.Decimal `json:"amount_in"`
+	ExpectedAmountOut decimal.Decimal `json:"expected_amount_out"`
+	InboundAddress    string          `json:"inbound_address"`
+	Router            string          `json:"router,omitempty"`
+	Memo              string          `json:"memo"`
+	Expiry            int64           `json:"expiry"`
+	DustThreshold     decimal.Decimal `json:"dust_threshold"`
+	RecommendedMinIn  decimal.Decimal `json:"recommended_min_amount_in"`
+	EstimatedSeconds  int64           `json:"estimated_seconds"`
+	Fees              SwapFees        `json:"fees"`
+	Warning           string          `json:"warning,omitempty"`
+}
+
+// thorchainQuoteResponse is the raw quote returned by thornode
+type thorchainQuoteResponse struct {
+	InboundAddress    string `json:"inbound_address"`
+	Router            string `json:"router"`
+	Memo              string `json:"memo"`
+	Expiry            int64  `json:"expiry"`
+	ExpectedAmountOut string `json:"expected_amount_out"`
+	DustThreshold     string `json:"dust_threshold"`
+	RecommendedMinIn  string `json:"recommended_min_amount_in"`
+	TotalSwapSeconds  int64  `json:"total_swap_seconds"`
+	Warning           string `json:"warning"`
+	Fees              struct {
+		Asset       string `json:"asset"`
+		Outbound    string `json:"outbound"`
+		Liquidity   string `json:"liquidity"`
+		Total       string `json:"total"`
+		SlippageBps int    `json:"slippage_bps"`
+		TotalBps    int    `json:"total_bps"`
+	} `json:"fees"`
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// ThorchainChain returns the chain name of a THORChain chain code such as
+// BTC, or an empty string if the chain isn't supported
+func ThorchainChain(code string) string {
+	for chain, asset := range thorchainAssets {
+		if strings.EqualFold(strings.SplitN(asset, ".", 2)[0], code) {
+			return chain
+		}
+	}
+	return ""
+}
+
+// SupportsSwap returns true if the swap provider has a route between two chains
+func (c *Client) SupportsSwap(fromChain, toChain string) bool {
+	_, fromOK := thorchainAssets[fromChain]
+	_, toOK := thorchainAssets[toChain]
+	return fromOK && toOK && fromChain != toChain && !c.IsTestnet()
+}
+
+// GetSwapQuote requests a quote for swapping amount of fromChain's native asset
+// into toChain's native asset, paid out to destination. toleranceBps limits the
+// accepted price movement, the swap is refunded when it is exceeded.
+func (c *Client) GetSwapQuote(ctx context.Context, fromChain, toChain string, amount decimal.Decimal, destination string, toleranceBps int) (*SwapQuote, error) {
+	if c.IsTestnet() {
+		return nil, fmt.Errorf("swaps are only supported on mainnet")
+	}
+
+	fromAsset, ok := thorchainAssets[fromChain]
+	if !ok {
+		return nil, fmt.Errorf("no swap route for %s", fromChain)
+	}
+	toAsset, ok := thorchainAssets[toChain]
+	if !ok {
+		return nil, fmt.Errorf("no swap route for %s", toChain)
+	}
+
+	params := url.Values{}
+	params.Set("from_asset", fromAsset)
+	params.Set("to_asset", toAsset)
+	params.Set("amount", amount.Shift(ThorchainDecimals).Truncate(0).String())
+	params.Set("destination", destination)
+	if toleranceBps > 0 {
+		params.Set("tolerance_bps", fmt.Sprintf("%d", toleranceBps))
+	}
+
+	resp, err := c.get(ctx, thornodeAPI()+"/thorchain/quote/swap?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch swap quote: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result thorchainQuoteResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if result.Error != "" || result.Message != "" {
+		return nil, fmt.Errorf("swap quote error: %s", strings.TrimSpace(result.Error+" "+result.Message))
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("swap quote failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	if result.InboundAddress == "" || result.Memo == "" {
+		return nil, fmt.Errorf("swap quote is missing the inbound address or memo")
+	}
+
+	return &SwapQuote{
+		FromChain:         fromChain,
+		ToChain:           toChain,
+		AmountIn:          amount,
+		ExpectedAmountOut: thorchainAmount(result.ExpectedAmountOut),
+		InboundAddress:    result.InboundAddress,
+		Router:            result.Router,
+		Memo:              result.Memo,
+		Expiry:            result.Expiry,
+		DustThreshold:     thorchainAmount(result.DustThreshold),
+		RecommendedMinIn:  thorchainAmount(result.RecommendedMinIn),
+		EstimatedSeconds:  result.TotalSwapSeconds,
+		Fees: SwapFees{
+			Asset:       result.Fees.Asset,
+			Outbound:    thorchainAmount(result.Fees.Outbound),
+			Liquidity:   thorchainAmount(result.Fees.Liquidity),
+			Total:       thorchainAmount(result.Fees.Total),
+			SlippageBps: result.Fees.SlippageBps,
+			TotalBps:    result.Fees.TotalBps,
+		},
+		Warning: result.Warning,
+	}, nil
+}
+
+// thorchainAmount converts a THORChain base unit amount into a decimal
+func thorchainAmount(value string) decimal.Decimal {
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero
+	}
+	return amount.Shift(-ThorchainDecimals)
+}
+
+// thornodeAPI returns the THORNode API to use, the configured one if set
+func thornodeAPI() string {
+	if cfg, err := config.Load(); err == nil {
+		if node := cfg.Get(config.KeyThornodeURL); node != "" {
+			return strings.TrimSuffix(node, "/")
+		}
+	}
+	return ThorchainAPI
+}
+
+// SwapOutbound is a payout of a swap on the destination chain
+type SwapOutbound struct {
+	Chain  string          `json:"chain"` // THORChain chain code, e.g. BTC
+	Hash   string          `json:"hash"`
+	Amount decimal.Decimal `json:"amount"`
+	Asset  string          `json:"asset"`
+}
+
+// SwapStatus is the progress of a cross-chain swap, from THORChain observing
+// the deposit to the payout on the destination chain
+type SwapStatus struct {
+	Observed             bool           `json:"observed"`               // deposit seen by THORChain
+	ConfirmationSeconds  int64          `json:"confirmation_seconds"`   // left until the deposit counts as confirmed
+	Finalised            bool           `json:"finalised"`              // deposit confirmed
+	Swapped              bool           `json:"swapped"`                // swap executed in the pool
+	OutboundDelaySeconds int64          `json:"outbound_delay_seconds"` // left until the payout is scheduled
+	Completed            bool           `json:"completed"`              // payout signed and sent
+	Refunded             bool           `json:"refunded"`               // paid back instead of swapped
+	Outbound             []SwapOutbound `json:"outbound,omitempty"`
+}
+
+// GetSwapStatus returns the progress of a swap by the hash of its deposit
+func (c *Client) GetSwapStatus(ctx context.Context, depositHash string) (*SwapStatus, error) {
+	hash := strings.ToUpper(strings.TrimPrefix(depositHash, "0x"))
+
+	resp, err := c.get(ctx, thornodeAPI()+"/thorchain/tx/status/"+url.PathEscape(hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch swap status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("swap status failed: %w", &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)})
+	}
+
+	var result struct {
+		PlannedOutTxs []struct {
+			Refund bool `json:"refund"`
+		} `json:"planned_out_txs"`
+		OutTxs []struct {
+			ID    string `json:"id"`
+			Chain string `json:"chain"`
+			Memo  string `json:"memo"`
+			Coins []struct {
+				Asset  string `json:"asset"`
+				Amount string `json:"amount"`
+			} `json:"coins"`
+		} `json:"out_txs"`
+		Stages struct {
+			InboundObserved struct {
+				Completed bool `json:"completed"`
+			} `json:"inbound_observed"`
+			InboundConfirmationCounted struct {
+				RemainingConfirmationSeconds int64 `json:"remaining_confirmation_seconds"`
+				Completed                    bool  `json:"completed"`
+			} `json:"inbound_confirmation_counted"`
+			InboundFinalised struct {
+				Completed bool `json:"completed"`
+			} `json:"inbound_finalised"`
+			SwapFinalised struct {
+				Completed bool `json:"completed"`
+			} `json:"swap_finalised"`
+			OutboundDelay struct {
+				RemainingDelaySeconds int64 `json:"remaining_delay_seconds"`
+				Completed             bool  `json:"completed"`
+			} `json:"outbound_delay"`
+			OutboundSigned struct {
+				Completed bool `json:"completed"`
+			} `json:"outbound_signed"`
+		} `json:"stages"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	stages := result.Stages
+	status := &SwapStatus{
+		Observed:             stages.InboundObserved.Completed,
+		ConfirmationSeconds:  stages.InboundConfirmationCounted.RemainingConfirmationSeconds,
+		Finalised:            stages.InboundFinalised.Completed,
+		Swapped:              stages.SwapFinalised.Completed,
+		OutboundDelaySeconds: stages.OutboundDelay.RemainingDelaySeconds,
+		Completed:            stages.OutboundSigned.Completed,
+	}
+	for _, planned := range result.PlannedOutTxs {
+		if planned.Refund {
+			status.Refunded = true
+		}
+	}
+	for _, out := range result.OutTxs {
+		if strings.HasPrefix(strings.ToUpper(out.Memo), "REFUND") {
+			status.Refunded = true
+		}
+		outbound := SwapOutbound{Chain: out.Chain, Hash: out.ID}
+		if len(out.Coins) > 0 {
+			outbound.Asset = out.Coins[0].Asset
+			outbound.Amount = thorchainAmount(out.Coins[0].Amount)
+		}
+		status.Outbound = append(status.Outbound, outbound)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/chinmay1088/odyssey/config"
+)
+
+// timeoutOverride replaces every configured timeout when set, see SetTimeout
+var timeoutOverride time.Duration
+
+// SetTimeout makes every request of clients created afterwards time out after
+// d instead of the configured timeouts, zero restores them
+func SetTimeout(d time.Duration) {
+	timeoutOverride = d
+}
+
+type operationKey struct{}
+
+// withOperation marks the requests made with ctx as part of an operation with
+// its own timeout, e.g. config.OperationHistory
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// requestTimeout returns the timeout of a request, by the chain its host
+// belongs to and the operation it is part of
+func (c *Client) requestTimeout(req *http.Request) time.Duration {
+	if timeoutOverride > 0 {
+		return timeoutOverride
+	}
+
+	operation, _ := req.Context().Value(operationKey{}).(string)
+	if req.URL.Host == "api.coingecko.com" {
+		operation = config.OperationPrice
+	}
+
+	chain := ""
+	endpoint := req.URL.String()
+	for url, nodeChain := range defaultRPCChains() {
+		if endpoint == url || containsString(c.endpoints[url], endpoint) {
+			chain = nodeChain[0]
+			break
+		}
+	}
+	switch req.URL.Hostname() {
+	case "blockchain.info", "api.blockchain.info", "api.blockchair.com", "mempool.space", "blockstream.info":
+		chain = "bitcoin"
+	case "mainnet.helius-rpc.com", "devnet.helius-rpc.com":
+		chain = "solana"
+	}
+
+	if c.timeouts == nil {
+		return config.DefaultNetworkTimeout
+	}
+	return c.timeouts.RequestTimeout(chain, operation)
+}
+
+// do sends a request that times out after the timeout of its chain and
+// operation. The timeout also covers reading the body, so the body must be
+// closed to release it.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	timeout := c.requestTimeout(req)
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		// Tell the timeout apart from the command being interrupted
+		if ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+			return nil, fmt.Errorf("request to %s timed out after %s: %w", req.URL.Host, timeout, err)
+		}
+		return nil, err
+	}
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the timeout of a request once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ERC-20 metadata getters, each without arguments
+var (
+	erc20NameSelector     = hexutil.MustDecode("0x06fdde03") // name()
+	erc20SymbolSelector   = hexutil.MustDecode("0x95d89b41") // symbol()
+	erc20DecimalsSelector = hexutil.MustDecode("0x313ce567") // decimals()
+)
+
+// lookedUpTokens are the tokens found by LookupToken, by network, chain and
+// address, kept in ~/.odyssey/cache/tokens/metadata.json. A token's metadata
+// doesn't change, so they never expire.
+var (
+	lookedUpTokens   map[string]Token
+	lookedUpTokensMu sync.Mutex
+)
+
+// GetERC20Metadata reads the name, symbol and decimals of an ERC-20 contract.
+// Symbols and names returned as bytes32 by older tokens are read too; a token
+// without a name is named after its symbol.
+func (c *Client) GetERC20Metadata(ctx context.Context, address string) (*Token, error) {
+	result, err := c.CallEthereumContract(ctx, address, erc20DecimalsSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read decimals: %w", err)
+	}
+	if len(result) < 32 {
+		return nil, fmt.Errorf("%s is not an ERC-20 token: decimals() returned %d bytes", address, len(result))
+	}
+	decimals := new(big.Int).SetBytes(result[:32])
+	if !decimals.IsInt64() || decimals.Int64() > 255 {
+		return nil, fmt.Errorf("%s is not an ERC-20 token: decimals() returned %s", address, decimals.String())
+	}
+
+	result, err = c.CallEthereumContract(ctx, address, erc20SymbolSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read symbol: %w", err)
+	}
+	symbol, ok := decodeABIString(result)
+	if !ok || symbol == "" {
+		return nil, fmt.Errorf("%s is not an ERC-20 token: symbol() returned no text", address)
+	}
+
+	name := symbol
+	if result, err := c.CallEthereumContract(ctx, address, erc20NameSelector); err == nil {
+		if decoded, ok := decodeABIString(result); ok && decoded != "" {
+			name = decoded
+		}
+	}
+
+	return &Token{Symbol: symbol, Name: name, Address: address, Decimals: int32(decimals.Int64())}, nil
+}
+
+// GetSPLTokenMetadata reads the decimals of an SPL mint from the chain, and
+// its name and symbol from the metadata extension of Token-2022 mints or the
+// Jupiter token list. Mints known to neither have no symbol.
+func (c *Client) GetSPLTokenMetadata(ctx context.Context, mint string) (*Token, error) {
+	var result struct {
+		Value *struct {
+			Data struct {
+				Program string `json:"program"`
+				Parsed  struct {
+					Type string `json:"type"`
+					Info struct {
+						Decimals   int32 `json:"decimals"`
+						Extensions []struct {
+							Extension string `json:"extension"`
+							State     struct {
+								Name   string `json:"name"`
+								Symbol string `json:"symbol"`
+							} `json:"state"`
+						} `json:"extensions"`
+					} `json:"info"`
+				} `json:"parsed"`
+			} `json:"data"`
+		} `json:"value"`
+	}
+	params := []interface{}{mint, map[string]interface{}{"encoding": "jsonParsed", "commitment": "confirmed"}}
+	if err := c.solanaCall(ctx, "getAccountInfo", params, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch mint: %w", err)
+	}
+	if result.Value == nil {
+		return nil, fmt.Errorf("mint %s not found", mint)
+	}
+	data := result.Value.Data
+	if (data.Program != "spl-token" && data.Program != "spl-token-2022") || data.Parsed.Type != "mint" {
+		return nil, fmt.Errorf("%s is not an SPL token mint", mint)
+	}
+
+	token := &Token{Address: mint, Decimals: data.Parsed.Info.Decimals}
+	for _, extension := range data.Parsed.Info.Extensions {
+		if extension.Extension == "tokenMetadata" {
+			token.Symbol, token.Name = extension.State.Symbol, extension.State.Name
+		}
+	}
+
+	if token.Symbol == "" && !c.IsTestnet() {
+		if listed, err := c.jupiterToken(ctx, mint); err == nil && listed != nil {
+			token.Symbol, token.Name = listed.Symbol, listed.Name
+		}
+	}
+	if token.Name == "" {
+		token.Name = token.Symbol
+	}
+	return token, nil
+}
+
+// jupiterToken looks up a mint in the Jupiter token list, nil if it isn't
+// listed
+func (c *Client) jupiterToken(ctx context.Context, mint string) (*Token, error) {
+	resp, err := c.get(ctx, JupiterTokensAPI+"/search?query="+url.QueryEscape(mint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token list: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var listed []struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Symbol   string `json:"symbol"`
+		Decimals int32  `json:"decimals"`
+	}
+	if err := json.Unmarshal(body, &listed); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	for _, entry := range listed {
+		if entry.ID == mint {
+			return &Token{Symbol: entry.Symbol, Name: entry.Name, Address: mint, Decimals: entry.Decimals}, nil
+		}
+	}
+	return nil, nil
+}
+
+// LookupToken returns the token of chain (ethereum or solana) at address: a
+// tracked token, or one read from the chain once and cached. Tokens without a
+// symbol are named by their address.
+func (c *Client) LookupToken(ctx context.Context, chain, address string) (*Token, error) {
+	var tracked []Token
+	switch chain {
+	case "ethereum":
+		tracked = c.GetEthereumTokens()
+	case "solana":
+		tracked = c.GetSolanaTokens()
+	default:
+		return nil, fmt.Errorf("tokens are not supported on %s", chain)
+	}
+	for _, token := range tracked {
+		if strings.EqualFold(token.Address, address) {
+			return &token, nil
+		}
+	}
+
+	// Addresses are only unique per chain ID or cluster
+	key := c.net.Name + "/" + chain + "/" + strings.ToLower(address)
+	lookedUpTokensMu.Lock()
+	loadLookedUpTokens()
+	token, ok := lookedUpTokens[key]
+	lookedUpTokensMu.Unlock()
+	if ok {
+		return &token, nil
+	}
+
+	var found *Token
+	var err error
+	if chain == "ethereum" {
+		found, err = c.GetERC20Metadata(ctx, address)
+	} else {
+		found, err = c.GetSPLTokenMetadata(ctx, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if found.Symbol == "" {
+		found.Symbol = address[:4] + "..." + address[len(address)-4:]
+		found.Name = found.Symbol
+	}
+
+	lookedUpTokensMu.Lock()
+	lookedUpTokens[key] = *found
+	saveLookedUpTokens()
+	lookedUpTokensMu.Unlock()
+	return found, nil
+}
+
+// decodeABIString decodes the result of a call returning a string, or the
+// bytes32 older tokens return instead
+func decodeABIString(result []byte) (string, bool) {
+	if len(result) >= 64 {
+		offset := new(big.Int).SetBytes(result[:32])
+		if offset.IsInt64() && offset.Int64() == 32 {
+			length := new(big.Int).SetBytes(result[32:64])
+			if length.IsInt64() && 64+length.Int64() <= int64(len(result)) {
+				text := string(result[64 : 64+length.Int64()])
+				return text, utf8.ValidString(text)
+			}
+		}
+	}
+	if len(result) == 32 {
+		text := string(bytes.TrimRight(result, "\x00"))
+		return text, utf8.ValidString(text)
+	}
+	return "", false
+}
+
+// tokensCachePath returns where looked up tokens are cached
+func tokensCachePath() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tokens", "metadata.json"), nil
+}
+
+// loadLookedUpTokens reads the looked up tokens once
+func loadLookedUpTokens() {
+	if lookedUpTokens != nil {
+		return
+	}
+	lookedUpTokens = make(map[string]Token)
+	path, err := tokensCachePath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	if json.Unmarshal(data, &lookedUpTokens) != nil || lookedUpTokens == nil {
+		lookedUpTokens = make(map[string]Token)
+	}
+}
+
+// saveLookedUpTokens writes the looked up tokens, a cache that can't be
+// written is skipped silently
+func saveLookedUpTokens() {
+	path, err := tokensCachePath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	data, err := json.Marshal(lookedUpTokens)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tokens-*")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/shopspring/decimal"
+)
+
+// Token describes a fungible token held alongside a chain's native asset
+type Token struct {
+	Symbol      string `json:"symbol"`
+	Name        string `json:"name"`
+	Address     string `json:"address"` // ERC-20 contract address or SPL mint
+	Decimals    int32  `json:"decimals"`
+	CoingeckoID string `json:"coingecko_id"`
+}
+
+// Well known mainnet tokens tracked by default
+var (
+	EthereumTokens = []Token{
+		{Symbol: "USDC", Name: "USD Coin", Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Decimals: 6, CoingeckoID: "usd-coin"},
+		{Symbol: "USDT", Name: "Tether", Address: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Decimals: 6, CoingeckoID: "tether"},
+		{Symbol: "DAI", Name: "Dai", Address: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Decimals: 18, CoingeckoID: "dai"},
+		{Symbol: "stETH", Name: "Lido Staked ETH", Address: "0xae7ab96520DE3A18E5e111B5EaAb095312D7fE84", Decimals: 18, CoingeckoID: "staked-ether"},
+		{Symbol: "rETH", Name: "Rocket Pool ETH", Address: "0xae78736Cd615f374D3085123A210448E74Fc6393", Decimals: 18, CoingeckoID: "rocket-pool-eth"},
+	}
+
+	SolanaTokens = []Token{
+		{Symbol: "USDC", Name: "USD Coin", Address: "EPjFWdd5AufqSSqeM2qN1xzyXTmzp8wvK2xrXqC4UvyJ", Decimals: 6, CoingeckoID: "usd-coin"},
+		{Symbol: "USDT", Name: "Tether", Address: "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB", Decimals: 6, CoingeckoID: "tether"},
+	}
+)
+
+// GetEthereumTokens returns the tokens tracked on the current Ethereum
+// network: the well known ones and those added with 'odyssey token add'
+func (c *Client) GetEthereumTokens() []Token {
+	var tokens []Token
+	// Token contracts above only exist on mainnet
+	if !c.IsTestnet() {
+		tokens = append(tokens, EthereumTokens...)
+	}
+	// Custom tokens are Ethereum's, not another EVM chain's
+	if c.ethereumRPC != "" {
+		return tokens
+	}
+	return append(tokens, customTokens("ethereum", c.net.Name)...)
+}
+
+// GetSolanaTokens returns the tokens tracked on the current Solana cluster:
+// the well known ones and those added with 'odyssey token add'
+func (c *Client) GetSolanaTokens() []Token {
+	var tokens []Token
+	// Token mints above only exist on mainnet
+	if !c.IsTestnet() {
+		tokens = append(tokens, SolanaTokens...)
+	}
+	return append(tokens, customTokens("solana", c.net.Name)...)
+}
+
+// customTokens returns the custom tokens of a chain on a network, except those
+// put on the blocklist since they were added
+func customTokens(chain, network string) []Token {
+	blocklist, err := config.LoadBlocklist()
+	if err != nil {
+		slog.Debug("failed to read blocklist", "error", err)
+	}
+
+	var tokens []Token
+	for _, custom := range config.CustomTokensOf(chain, network) {
+		if blocklist.Token(chain, custom.Address) != nil {
+			continue
+		}
+		tokens = append(tokens, Token{
+			Symbol:      custom.Symbol,
+			Name:        custom.Name,
+			Address:     custom.Address,
+			Decimals:    custom.Decimals,
+			CoingeckoID: custom.CoingeckoID,
+		})
+	}
+	return tokens
+}
+
+// GetERC20Balance fetches the raw balance of an ERC-20 token for an address
+func (c *Client) GetERC20Balance(ctx context.Context, tokenAddress, owner string) (*big.Int, error) {
+	url := c.GetEthereumRPC()
+
+	// balanceOf(address) selector followed by the left-padded owner address
+	data := "0x70a08231" + fmt.Sprintf("%064s", strings.ToLower(strings.TrimPrefix(owner, "0x")))
+
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "eth_call",
+		"params": []interface{}{
+			map[string]string{"to": tokenAddress, "data": data},
+			"latest",
+		},
+		"id": 1,
+	}
+
+	response, err := c.postJSON(ctx, url, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token balance: %w", err)
+	}
+
+	var rpcResp EthereumRPCResponse
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+
+	resultStr, ok := rpcResp.Result.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid token balance format")
+	}
+
+	// Contracts without code return "0x"
+	if strings.TrimPrefix(resultStr, "0x") == "" {
+		return big.NewInt(0), nil
+	}
+
+	return parseHexBigInt(resultStr)
+}
+
+// GetSPLTokenBalance fetches the raw balance of an SPL token mint across all of
+// the owner's token accounts
+func (c *Client) GetSPLTokenBalance(ctx context.Context, mint, owner string) (*big.Int, error) {
+	url := c.GetSolanaRPC()
+
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getTokenAccountsByOwner",
+		"params": []interface{}{
+			owner,
+			map[string]string{"mint": mint},
+			map[string]string{"encoding": "jsonParsed"},
+		},
+	}
+
+	response, err := c.postJSON(ctx, url, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token accounts: %w", err)
+	}
+
+	var result struct {
+		Result struct {
+			Value []struct {
+				Account struct {
+					Data struct {
+						Parsed struct {
+							Info struct {
+								TokenAmount struct {
+									Amount string `json:"amount"`
+								} `json:"tokenAmount"`
+							} `json:"info"`
+						} `json:"parsed"`
+					} `json:"data"`
+				} `json:"account"`
+			} `json:"value"`
+		} `json:"result"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if result.Error != nil {
+		// Accounts that never received SOL have no token accounts either
+		if strings.Contains(result.Error.Message, "could not find account") {
+			return big.NewInt(0), nil
+		}
+		return nil, fmt.Errorf("RPC error: %s", result.Error.Message)
+	}
+
+	total := big.NewInt(0)
+	for _, account := range result.Result.Value {
+		amount, ok := new(big.Int).SetString(account.Account.Data.Parsed.Info.TokenAmount.Amount, 10)
+		if !ok {
+			continue // Skip malformed token accounts
+		}
+		total.Add(total, amount)
+	}
+
+	return total, nil
+}
+
+// TokenAmount converts a raw token amount into whole units
+func TokenAmount(raw *big.Int, decimals int32) decimal.Decimal {
+	if raw == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(raw, -decimals)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/logging"
+)
+
+// maxTraceBody limits how much of a request or response body is logged
+const maxTraceBody = 4096
+
+// logTransport logs every attempt of a request with -v and its raw request
+// and response with -vv. It sits below the retries, so each attempt shows up.
+type logTransport struct {
+	next http.RoundTripper
+}
+
+func (t logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !logging.Enabled(slog.LevelDebug) {
+		return t.next.RoundTrip(req)
+	}
+
+	var reqBody []byte
+	if req.GetBody != nil {
+		if reader, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(reader)
+			reader.Close()
+		}
+	}
+	attrs := []any{"method", req.Method, "url", req.URL.String()}
+	if method := rpcMethod(reqBody); method != "" {
+		attrs = append(attrs, "rpc_method", method)
+	}
+
+	if logging.Enabled(logging.LevelTrace) {
+		headers := make([]string, 0, len(req.Header))
+		for name, values := range req.Header {
+			headers = append(headers, name+": "+logging.Redact(name, strings.Join(values, ", ")))
+		}
+		logging.Trace("http request", append(attrs, "headers", strings.Join(headers, "; "), "body", traceBody(reqBody))...)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		slog.Debug("http request failed", append(attrs, "elapsed", elapsed, "error", err)...)
+		return nil, err
+	}
+	slog.Debug("http request", append(attrs, "status", resp.StatusCode, "elapsed", elapsed)...)
+
+	if logging.Enabled(logging.LevelTrace) {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		logging.Trace("http response", append(attrs, "status", resp.StatusCode, "body", traceBody(body))...)
+	}
+	return resp, nil
+}
+
+// rpcMethod returns the method of a JSON-RPC request body, if it is one
+func rpcMethod(body []byte) string {
+	var call struct {
+		Method string `json:"method"`
+	}
+	if len(body) == 0 || json.Unmarshal(body, &call) != nil {
+		return ""
+	}
+	return call.Method
+}
+
+// traceBody shortens a body for logging
+func traceBody(body []byte) string {
+	if len(body) > maxTraceBody {
+		return string(body[:maxTraceBody]) + "...(truncated)"
+	}
+	return string(body)
+}
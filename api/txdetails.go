@@ -0,0 +1,517 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Statuses of a transaction looked up on chain
+const (
+	TxStatusPending = "pending"
+	TxStatusSuccess = "success"
+	TxStatusFailed  = "failed"
+)
+
+// TransactionDetails is the on-chain state of a transaction, as printed by
+// 'odyssey tx show --output json'. Amounts are in base units: wei, satoshis
+// or lamports.
+type TransactionDetails struct {
+	Chain         string     `json:"chain"`
+	Hash          string     `json:"hash"`
+	Status        string     `json:"status"`
+	Error         string     `json:"error,omitempty"`
+	Block         uint64     `json:"block,omitempty"` // slot on Solana
+	BlockTime     *time.Time `json:"block_time,omitempty"`
+	Confirmations uint64     `json:"confirmations"`
+	From          string     `json:"from,omitempty"`
+	To            string     `json:"to,omitempty"`
+	Value         *big.Int   `json:"value"`
+	Fee           *big.Int   `json:"fee"`
+
+	// EVM chains
+	Nonce    *uint64        `json:"nonce,omitempty"`
+	GasUsed  uint64         `json:"gas_used,omitempty"`
+	GasLimit uint64         `json:"gas_limit,omitempty"`
+	GasPrice *big.Int       `json:"gas_price,omitempty"` // effective price
+	Input    string         `json:"input,omitempty"`
+	Logs     []SimulatedLog `json:"logs,omitempty"`
+
+	// Bitcoin
+	Inputs  []TransactionOutput `json:"inputs,omitempty"` // the outputs spent
+	Outputs []TransactionOutput `json:"outputs,omitempty"`
+	VSize   int64               `json:"vsize,omitempty"`
+
+	// Solana
+	Instructions []TransactionInstruction `json:"instructions,omitempty"`
+	ProgramLogs  []string                 `json:"program_logs,omitempty"`
+
+	TokenTransfers []TokenTransfer `json:"token_transfers,omitempty"`
+}
+
+// TransactionOutput is a Bitcoin output
+type TransactionOutput struct {
+	Address string `json:"address"`
+	Value   int64  `json:"value"`
+}
+
+// TransactionInstruction is a Solana instruction, with the fields the node
+// could decode
+type TransactionInstruction struct {
+	Program string                 `json:"program"`
+	Type    string                 `json:"type,omitempty"`
+	Info    map[string]interface{} `json:"info,omitempty"`
+}
+
+// TokenTransfer is a token moved by a transaction
+type TokenTransfer struct {
+	Token   string   `json:"token"` // contract or mint
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	TokenID *big.Int `json:"token_id,omitempty"` // NFTs only
+	Amount  *big.Int `json:"amount"`
+}
+
+// GetEthereumTransactionDetails looks up a transaction and its receipt
+func (c *Client) GetEthereumTransactionDetails(ctx context.Context, txHash string) (*TransactionDetails, error) {
+	var tx *struct {
+		From        string  `json:"from"`
+		To          *string `json:"to"`
+		Value       string  `json:"value"`
+		Nonce       string  `json:"nonce"`
+		Gas         string  `json:"gas"`
+		GasPrice    string  `json:"gasPrice"`
+		Input       string  `json:"input"`
+		BlockNumber *string `json:"blockNumber"`
+	}
+	if err := c.ethereumCall(ctx, "eth_getTransactionByHash", []interface{}{txHash}, &tx); err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction: %w", err)
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %s not found", txHash)
+	}
+
+	details := &TransactionDetails{
+		Hash:   txHash,
+		Status: TxStatusPending,
+		From:   checksumAddress(tx.From),
+		Input:  tx.Input,
+		Fee:    big.NewInt(0),
+	}
+	if tx.To != nil {
+		details.To = checksumAddress(*tx.To)
+	}
+	details.Value, _ = parseHexBigInt(tx.Value)
+	if nonce, err := parseHexInt(tx.Nonce); err == nil {
+		details.Nonce = &nonce
+	}
+	details.GasLimit, _ = parseHexInt(tx.Gas)
+	details.GasPrice, _ = parseHexBigInt(tx.GasPrice)
+
+	if tx.BlockNumber == nil {
+		return details, nil
+	}
+
+	var receipt *struct {
+		Status            string         `json:"status"`
+		BlockNumber       string         `json:"blockNumber"`
+		GasUsed           string         `json:"gasUsed"`
+		EffectiveGasPrice string         `json:"effectiveGasPrice"`
+		ContractAddress   *string        `json:"contractAddress"`
+		L1Fee             string         `json:"l1Fee"` // OP Stack rollups
+		Logs              []SimulatedLog `json:"logs"`
+	}
+	if err := c.ethereumCall(ctx, "eth_getTransactionReceipt", []interface{}{txHash}, &receipt); err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction receipt: %w", err)
+	}
+	if receipt == nil {
+		return details, nil
+	}
+
+	details.Status = TxStatusSuccess
+	if receipt.Status == "0x0" {
+		details.Status = TxStatusFailed
+		details.Error = "execution reverted"
+	}
+	details.Block, _ = parseHexInt(receipt.BlockNumber)
+	details.GasUsed, _ = parseHexInt(receipt.GasUsed)
+	if price, err := parseHexBigInt(receipt.EffectiveGasPrice); err == nil {
+		details.GasPrice = price
+	}
+	if details.GasPrice != nil {
+		details.Fee = new(big.Int).Mul(details.GasPrice, new(big.Int).SetUint64(details.GasUsed))
+	}
+	if l1Fee, err := parseHexBigInt(receipt.L1Fee); err == nil && receipt.L1Fee != "" {
+		details.Fee.Add(details.Fee, l1Fee)
+	}
+	if details.To == "" && receipt.ContractAddress != nil {
+		details.To = checksumAddress(*receipt.ContractAddress)
+	}
+	details.Logs = receipt.Logs
+	details.TokenTransfers = tokenTransfers(receipt.Logs)
+
+	var head string
+	if err := c.ethereumCall(ctx, "eth_blockNumber", []interface{}{}, &head); err == nil {
+		if latest, err := parseHexInt(head); err == nil && latest >= details.Block {
+			details.Confirmations = latest - details.Block + 1
+		}
+	}
+
+	var block *struct {
+		Timestamp string `json:"timestamp"`
+	}
+	if err := c.ethereumCall(ctx, "eth_getBlockByNumber", []interface{}{receipt.BlockNumber, false}, &block); err == nil && block != nil {
+		if timestamp, err := parseHexInt(block.Timestamp); err == nil {
+			blockTime := time.Unix(int64(timestamp), 0)
+			details.BlockTime = &blockTime
+		}
+	}
+
+	return details, nil
+}
+
+// GetBitcoinTransactionDetails looks up a transaction on mempool.space
+func (c *Client) GetBitcoinTransactionDetails(ctx context.Context, txid string) (*TransactionDetails, error) {
+	if !c.net.HasBitcoin() {
+		return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+	if c.net.IsRegtest() {
+		return nil, fmt.Errorf("bitcoin transaction details come from explorers, which don't index regtest")
+	}
+
+	var tx struct {
+		Vin []struct {
+			Prevout *struct {
+				Address string `json:"scriptpubkey_address"`
+				Value   int64  `json:"value"`
+			} `json:"prevout"`
+		} `json:"vin"`
+		Vout []struct {
+			Address string `json:"scriptpubkey_address"`
+			Value   int64  `json:"value"`
+		} `json:"vout"`
+		Fee    int64 `json:"fee"`
+		Weight int64 `json:"weight"`
+		Status struct {
+			Confirmed   bool   `json:"confirmed"`
+			BlockHeight uint64 `json:"block_height"`
+			BlockTime   int64  `json:"block_time"`
+		} `json:"status"`
+	}
+	if err := c.getMempoolJSON(ctx, fmt.Sprintf("https://mempool.space/api/tx/%s", txid), &tx); err != nil {
+		return nil, err
+	}
+
+	details := &TransactionDetails{
+		Hash:   txid,
+		Status: TxStatusPending,
+		Fee:    big.NewInt(tx.Fee),
+		VSize:  (tx.Weight + 3) / 4,
+	}
+
+	for _, input := range tx.Vin {
+		if input.Prevout != nil {
+			details.Inputs = append(details.Inputs, TransactionOutput{Address: input.Prevout.Address, Value: input.Prevout.Value})
+		}
+	}
+	total := int64(0)
+	for _, output := range tx.Vout {
+		details.Outputs = append(details.Outputs, TransactionOutput{Address: output.Address, Value: output.Value})
+		total += output.Value
+	}
+	details.Value = big.NewInt(total)
+	if len(details.Inputs) > 0 {
+		details.From = details.Inputs[0].Address
+	}
+	if len(details.Outputs) > 0 {
+		details.To = details.Outputs[0].Address
+	}
+
+	if tx.Status.Confirmed {
+		details.Status = TxStatusSuccess
+		details.Block = tx.Status.BlockHeight
+		blockTime := time.Unix(tx.Status.BlockTime, 0)
+		details.BlockTime = &blockTime
+
+		var tip uint64
+		if err := c.getMempoolJSON(ctx, "https://mempool.space/api/blocks/tip/height", &tip); err == nil && tip >= details.Block {
+			details.Confirmations = tip - details.Block + 1
+		}
+	}
+
+	return details, nil
+}
+
+// GetSolanaTransactionDetails looks up a transaction with its parsed instructions
+func (c *Client) GetSolanaTransactionDetails(ctx context.Context, signature string) (*TransactionDetails, error) {
+	params := []interface{}{signature, map[string]interface{}{
+		"encoding":                       "jsonParsed",
+		"commitment":                     "confirmed",
+		"maxSupportedTransactionVersion": 0,
+	}}
+
+	var tx *struct {
+		Slot      uint64 `json:"slot"`
+		BlockTime *int64 `json:"blockTime"`
+		Meta      *struct {
+			Err               interface{}          `json:"err"`
+			Fee               uint64               `json:"fee"`
+			LogMessages       []string             `json:"logMessages"`
+			PreTokenBalances  []solanaTokenBalance `json:"preTokenBalances"`
+			PostTokenBalances []solanaTokenBalance `json:"postTokenBalances"`
+		} `json:"meta"`
+		Transaction struct {
+			Message struct {
+				AccountKeys []struct {
+					Pubkey string `json:"pubkey"`
+				} `json:"accountKeys"`
+				Instructions []struct {
+					Program   string `json:"program"`
+					ProgramID string `json:"programId"`
+					Parsed    *struct {
+						Type string                 `json:"type"`
+						Info map[string]interface{} `json:"info"`
+					} `json:"parsed"`
+				} `json:"instructions"`
+			} `json:"message"`
+		} `json:"transaction"`
+	}
+	if err := c.solanaCall(ctx, "getTransaction", params, &tx); err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction: %w", err)
+	}
+	if tx == nil {
+		// Not in a block yet, or unknown
+		known, err := c.SolanaTransactionKnown(ctx, signature)
+		if err != nil {
+			return nil, err
+		}
+		if !known {
+			return nil, fmt.Errorf("transaction %s not found", signature)
+		}
+		return &TransactionDetails{Hash: signature, Status: TxStatusPending, Value: big.NewInt(0), Fee: big.NewInt(0)}, nil
+	}
+
+	details := &TransactionDetails{
+		Hash:   signature,
+		Status: TxStatusSuccess,
+		Block:  tx.Slot,
+		Value:  big.NewInt(0),
+		Fee:    big.NewInt(0),
+	}
+	if tx.BlockTime != nil {
+		blockTime := time.Unix(*tx.BlockTime, 0)
+		details.BlockTime = &blockTime
+	}
+	if keys := tx.Transaction.Message.AccountKeys; len(keys) > 0 {
+		details.From = keys[0].Pubkey // the fee payer
+	}
+
+	for _, instruction := range tx.Transaction.Message.Instructions {
+		decoded := TransactionInstruction{Program: instruction.Program}
+		if decoded.Program == "" {
+			decoded.Program = instruction.ProgramID
+		}
+		if instruction.Parsed != nil {
+			decoded.Type = instruction.Parsed.Type
+			decoded.Info = instruction.Parsed.Info
+			// Native transfers make up the value
+			if decoded.Program == "system" && decoded.Type == "transfer" {
+				if lamports, ok := decoded.Info["lamports"].(float64); ok {
+					details.Value.Add(details.Value, new(big.Int).SetUint64(uint64(lamports)))
+				}
+				if to, ok := decoded.Info["destination"].(string); ok && details.To == "" {
+					details.To = to
+				}
+			}
+		}
+		details.Instructions = append(details.Instructions, decoded)
+	}
+
+	if meta := tx.Meta; meta != nil {
+		details.Fee = new(big.Int).SetUint64(meta.Fee)
+		details.ProgramLogs = meta.LogMessages
+		if meta.Err != nil {
+			details.Status = TxStatusFailed
+			encoded, _ := json.Marshal(meta.Err)
+			details.Error = string(encoded)
+		}
+		details.TokenTransfers = splTokenTransfers(meta.PreTokenBalances, meta.PostTokenBalances)
+	}
+
+	var statuses struct {
+		Value []*struct {
+			Confirmations      *uint64 `json:"confirmations"`
+			ConfirmationStatus string  `json:"confirmationStatus"`
+		} `json:"value"`
+	}
+	statusParams := []interface{}{[]string{signature}, map[string]bool{"searchTransactionHistory": true}}
+	if err := c.solanaCall(ctx, "getSignatureStatuses", statusParams, &statuses); err == nil && len(statuses.Value) > 0 && statuses.Value[0] != nil {
+		if confirmations := statuses.Value[0].Confirmations; confirmations != nil {
+			details.Confirmations = *confirmations
+		} else {
+			// Finalized, the node stops counting
+			details.Confirmations = solanaFinalizedConfirmations
+		}
+	}
+
+	return details, nil
+}
+
+// solanaFinalizedConfirmations is reported for finalized transactions, whose
+// confirmations the node no longer counts
+const solanaFinalizedConfirmations = 32
+
+// solanaTokenBalance is an SPL token balance before or after a transaction
+type solanaTokenBalance struct {
+	AccountIndex  int    `json:"accountIndex"`
+	Mint          string `json:"mint"`
+	Owner         string `json:"owner"`
+	UITokenAmount struct {
+		Amount string `json:"amount"`
+	} `json:"uiTokenAmount"`
+}
+
+// splTokenTransfers pairs the token accounts that lost tokens with the ones
+// that gained tokens of the same mint
+func splTokenTransfers(pre, post []solanaTokenBalance) []TokenTransfer {
+	type key struct {
+		index int
+		mint  string
+	}
+	deltas := make(map[key]*big.Int)
+	owners := make(map[key]string)
+	var order []key
+
+	for _, balance := range post {
+		k := key{balance.AccountIndex, balance.Mint}
+		amount, _ := new(big.Int).SetString(balance.UITokenAmount.Amount, 10)
+		if amount == nil {
+			continue
+		}
+		deltas[k] = amount
+		owners[k] = balance.Owner
+		order = append(order, k)
+	}
+	for _, balance := range pre {
+		k := key{balance.AccountIndex, balance.Mint}
+		amount, _ := new(big.Int).SetString(balance.UITokenAmount.Amount, 10)
+		if amount == nil {
+			continue
+		}
+		if _, ok := deltas[k]; !ok {
+			deltas[k] = big.NewInt(0)
+			owners[k] = balance.Owner
+			order = append(order, k)
+		}
+		deltas[k].Sub(deltas[k], amount)
+	}
+
+	var transfers []TokenTransfer
+	for _, sender := range order {
+		for deltas[sender].Sign() < 0 {
+			matched := false
+			for _, receiver := range order {
+				if receiver.mint != sender.mint || deltas[receiver].Sign() <= 0 {
+					continue
+				}
+				amount := new(big.Int).Neg(deltas[sender])
+				if amount.Cmp(deltas[receiver]) > 0 {
+					amount.Set(deltas[receiver])
+				}
+				transfers = append(transfers, TokenTransfer{Token: sender.mint, From: owners[sender], To: owners[receiver], Amount: amount})
+				deltas[sender].Add(deltas[sender], amount)
+				deltas[receiver].Sub(deltas[receiver], amount)
+				matched = true
+				break
+			}
+			if !matched {
+				// Burned
+				transfers = append(transfers, TokenTransfer{Token: sender.mint, From: owners[sender], Amount: new(big.Int).Neg(deltas[sender])})
+				break
+			}
+		}
+	}
+	for _, receiver := range order {
+		if deltas[receiver].Sign() > 0 {
+			// Minted
+			transfers = append(transfers, TokenTransfer{Token: receiver.mint, To: owners[receiver], Amount: deltas[receiver]})
+		}
+	}
+
+	return transfers
+}
+
+// ethereumCall sends a JSON-RPC request to the Ethereum node and decodes the result
+func (c *Client) ethereumCall(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	return c.rpcCall(ctx, c.GetEthereumRPC(), method, params, result)
+}
+
+// solanaCall sends a JSON-RPC request to the Solana node and decodes the result
+func (c *Client) solanaCall(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	return c.rpcCall(ctx, c.GetSolanaRPC(), method, params, result)
+}
+
+func (c *Client) rpcCall(ctx context.Context, url, method string, params []interface{}, result interface{}) error {
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	}
+
+	response, err := c.postJSON(ctx, url, payload)
+	if err != nil {
+		return err
+	}
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *RPCError       `json:"error"`
+	}
+	if err := json.Unmarshal(response, &rpcResp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+	if len(rpcResp.Result) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return fmt.Errorf("failed to parse result: %w", err)
+	}
+	return nil
+}
+
+// getMempoolJSON fetches and decodes a mempool.space endpoint
+func (c *Client) getMempoolJSON(ctx context.Context, url string, out interface{}) error {
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch transaction: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusBadRequest:
+		return fmt.Errorf("transaction not found: %s", strings.TrimSpace(string(body)))
+	default:
+		return &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
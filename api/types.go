@@ -18,11 +18,20 @@ type Transaction struct {
 	IsIncoming  bool      `json:"is_incoming"` // true for receiving, false for sending
 }
 
+// TransactionPage represents a single page of transaction history
+type TransactionPage struct {
+	Transactions []Transaction `json:"transactions"`
+	NextCursor   string        `json:"next_cursor"` // empty when there are no older transactions
+}
+
 // PriceData represents cryptocurrency price information
 type PriceData struct {
 	Symbol string          `json:"symbol"`
 	Price  decimal.Decimal `json:"current_price"`
 	USD    decimal.Decimal `json:"usd"`
+
+	// Change24h is the 24 hour price change in percent, when requested
+	Change24h decimal.Decimal `json:"usd_24h_change"`
 }
 
 // EthereumRPCResponse represents Ethereum RPC response
@@ -38,10 +47,10 @@ type EthereumRPCResponse struct {
 
 // BitcoinUTXO represents a Bitcoin UTXO
 type BitcoinUTXO struct {
-	TxID   string  `json:"txid"`
-	Vout   uint32  `json:"vout"`
-	Value  float64 `json:"value"`
-	Script string  `json:"scriptPubKey"`
+	TxID   string `json:"txid"`
+	Vout   uint32 `json:"vout"`
+	Value  int64  `json:"value"` // satoshis
+	Script string `json:"scriptPubKey"`
 }
 
 // SolanaRPCResponse represents Solana RPC response
@@ -0,0 +1,50 @@
+package chains
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ParseAmount parses an amount typed by the user into the smallest unit of an
+// asset with the given number of decimals. Only plain decimal numbers are
+// accepted: no sign, exponent or thousands separators, and no more decimal
+// places than the asset has, so an amount is never rounded behind the user's
+// back.
+func ParseAmount(s string, decimals int32) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole+fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return nil, fmt.Errorf("%q is not an amount, use a number like 0.5", s)
+	}
+	if int32(len(fraction)) > decimals {
+		return nil, fmt.Errorf("%s has more than %d decimal places", s, decimals)
+	}
+
+	digits := whole + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+	amount, _ := new(big.Int).SetString(digits, 10)
+	return amount, nil
+}
+
+// ToSmallestUnit converts an amount in whole units to the smallest unit,
+// dropping digits beyond the asset's decimals
+func ToSmallestUnit(amount decimal.Decimal, decimals int32) *big.Int {
+	return amount.Shift(decimals).Truncate(0).BigInt()
+}
+
+// FromSmallestUnit converts an amount in the smallest unit to whole units
+func FromSmallestUnit(amount *big.Int, decimals int32) decimal.Decimal {
+	return decimal.NewFromBigInt(amount, -decimals)
+}
+
+// isDigits returns true if s only holds the digits 0-9
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,319 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+)
+
+// defaultFeeRate is used in sat/vB when no fee estimate is available
+const defaultFeeRate = 10
+
+// ErrNoUTXOs is returned when a transfer has nothing to spend
+var ErrNoUTXOs = errors.New("the wallet has no unspent outputs")
+
+// Chain is Bitcoin, spending from every address type of the wallet
+type Chain struct {
+	manager *wallet.Manager
+	client  *api.Client
+
+	// CoinSelection is the strategy choosing the UTXOs a transfer spends,
+	// the configured one if empty
+	CoinSelection string
+
+	// Memo is added to transfers as an OP_RETURN output
+	Memo string
+}
+
+// NewChain returns Bitcoin on the current network
+func NewChain(manager *wallet.Manager, client *api.Client) *Chain {
+	return &Chain{manager: manager, client: client}
+}
+
+// Name returns bitcoin
+func (c *Chain) Name() string {
+	return "bitcoin"
+}
+
+// Symbol returns BTC
+func (c *Chain) Symbol() string {
+	return "BTC"
+}
+
+// Decimals returns 8, Bitcoin counts in satoshis
+func (c *Chain) Decimals() int32 {
+	return Decimals
+}
+
+// Address returns the address of the address type in use
+func (c *Chain) Address() (string, error) {
+	address, err := c.manager.GetBitcoinAddress()
+	if err != nil {
+		return "", err
+	}
+	return address.String(), nil
+}
+
+// Balance returns the combined balance of every address type in satoshis
+func (c *Chain) Balance(ctx context.Context) (*big.Int, error) {
+	accounts, err := c.manager.GetBitcoinAccounts()
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, len(accounts))
+	for i, account := range accounts {
+		addresses[i] = account.Address.String()
+	}
+
+	balances, err := c.client.GetBitcoinBalances(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+	total := int64(0)
+	for _, balance := range balances {
+		total += balance
+	}
+	return big.NewInt(total), nil
+}
+
+// History returns a page of the transactions of an address
+func (c *Chain) History(ctx context.Context, address string, limit int, cursor string) (*api.TransactionPage, error) {
+	return c.client.GetBitcoinTransactions(ctx, address, limit, cursor)
+}
+
+// FeeRate returns the recommended fee rate in sat/vB
+func (c *Chain) FeeRate(ctx context.Context) int64 {
+	feeRate, err := c.client.GetBitcoinFeeEstimate(ctx)
+	if err != nil {
+		return defaultFeeRate
+	}
+	return feeRate
+}
+
+// FeeEstimate returns the fee of spending one input of the address in use
+// to a recipient and change
+func (c *Chain) FeeEstimate(ctx context.Context) (*big.Int, error) {
+	address, err := c.manager.GetBitcoinAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	// Any outpoint sizes the same, the outputs go to the same address type
+	utxo := &UTXO{TxID: strings.Repeat("0", 64), Address: address}
+	tx := NewTransaction()
+	if err := tx.AddInput(utxo, nil, address); err != nil {
+		return nil, err
+	}
+	for i := 0; i < 2; i++ {
+		if err := tx.AddOutput(DustThreshold, address); err != nil {
+			return nil, err
+		}
+	}
+	vsize, err := tx.VirtualSize([]*UTXO{utxo})
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(vsize * c.FeeRate(ctx)), nil
+}
+
+// UTXOs returns the UTXOs of every address type of the wallet and the keys
+// that sign them, by address. Funds on older addresses are spent too.
+func (c *Chain) UTXOs(ctx context.Context) ([]*UTXO, map[string]*btcec.PrivateKey, error) {
+	accounts, err := c.manager.GetBitcoinAccounts()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get sender address: %w", err)
+	}
+
+	addresses := make([]string, len(accounts))
+	for i, account := range accounts {
+		addresses[i] = account.Address.String()
+	}
+
+	// Only look up the UTXOs of funded addresses, if the balances are
+	// unavailable every address is checked
+	balances, err := c.client.GetBitcoinBalances(ctx, addresses)
+	if err != nil {
+		balances = nil
+	}
+
+	var utxos []*UTXO
+	keys := make(map[string]*btcec.PrivateKey)
+	for i, account := range accounts {
+		if i > 0 && balances != nil && balances[addresses[i]] == 0 {
+			continue
+		}
+
+		apiUtxos, err := c.client.GetBitcoinUTXOs(ctx, addresses[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get UTXOs: %w", err)
+		}
+
+		for _, apiUtxo := range apiUtxos {
+			// Not every provider returns the script, signing then uses
+			// the script of the address
+			script, err := hex.DecodeString(apiUtxo.Script)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid script of UTXO %s:%d: %w", apiUtxo.TxID, apiUtxo.Vout, err)
+			}
+			utxos = append(utxos, &UTXO{
+				TxID:    apiUtxo.TxID,
+				Vout:    apiUtxo.Vout,
+				Value:   apiUtxo.Value,
+				Script:  script,
+				Address: account.Address,
+			})
+		}
+		keys[account.Address.EncodeAddress()] = account.Key
+	}
+
+	return utxos, keys, nil
+}
+
+// CoinSelectionStrategy returns CoinSelection, or the configured strategy if
+// it is empty
+func (c *Chain) CoinSelectionStrategy() string {
+	if c.CoinSelection != "" {
+		return c.CoinSelection
+	}
+	if cfg, err := config.Load(); err == nil {
+		return cfg.CoinSelection()
+	}
+	return config.CoinSelectionFee
+}
+
+// Payment is the transaction of a Bitcoin transfer and what signing it needs
+type Payment struct {
+	Tx         *Transaction
+	UTXOs      []*UTXO
+	Keys       map[string]*btcec.PrivateKey
+	TotalInput int64
+	FeeRate    int64 // sat/vB
+	VSize      int64 // virtual size once signed, in vbytes
+
+	// Change goes to a fresh address on the internal chain, it must be
+	// recorded once the transaction is sent
+	Change        int64
+	ChangeAccount *wallet.BitcoinAccount
+}
+
+// BuildTransfer spends UTXOs chosen by the coin selection strategy to the
+// recipient, the rest minus the fee returns as change. The fee is paid on the
+// virtual size of the signed transaction and change below the dust threshold
+// is left to it. Transactions nodes wouldn't relay are refused. Tx is a
+// *bitcoin.Payment.
+func (c *Chain) BuildTransfer(ctx context.Context, to string, amount *big.Int) (*chains.Transfer, error) {
+	recipient, err := ParseAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	value := amount.Int64()
+	if err := ValidateAmount(value, recipient); err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	senderAddress, err := c.manager.GetBitcoinAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender address: %w", err)
+	}
+
+	utxos, keys, err := c.UTXOs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(utxos) == 0 {
+		return nil, ErrNoUTXOs
+	}
+
+	feeRate := c.FeeRate(ctx)
+
+	tx := NewTransaction()
+	if err := tx.AddOutput(value, recipient); err != nil {
+		return nil, fmt.Errorf("failed to add output: %w", err)
+	}
+	if c.Memo != "" {
+		if err := tx.AddDataOutput([]byte(c.Memo)); err != nil {
+			return nil, fmt.Errorf("invalid memo: %w", err)
+		}
+	}
+
+	// Change goes to a fresh address, only recorded once the transaction is sent
+	changeAccount, err := c.manager.NextBitcoinChangeAccount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive change address: %w", err)
+	}
+
+	utxos = SelectCoins(utxos, tx, feeRate, changeAccount.Address, c.CoinSelectionStrategy())
+
+	totalInput := int64(0)
+	for _, utxo := range utxos {
+		totalInput += utxo.Value
+		if err := tx.AddInput(utxo, nil, senderAddress); err != nil {
+			return nil, fmt.Errorf("failed to add input: %w", err)
+		}
+	}
+	fee, change, vsize, err := tx.SettleFee(utxos, totalInput, value, feeRate, changeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.CheckStandard(vsize); err != nil {
+		return nil, err
+	}
+	if change == 0 {
+		changeAccount = nil
+	}
+
+	return &chains.Transfer{
+		From:   senderAddress.String(),
+		To:     recipient.String(),
+		Amount: amount,
+		Fee:    big.NewInt(fee),
+		Tx: &Payment{
+			Tx:            tx,
+			UTXOs:         utxos,
+			Keys:          keys,
+			TotalInput:    totalInput,
+			FeeRate:       feeRate,
+			VSize:         vsize,
+			Change:        change,
+			ChangeAccount: changeAccount,
+		},
+	}, nil
+}
+
+// Sign signs every input with the key of its address
+func (c *Chain) Sign(ctx context.Context, transfer *chains.Transfer) error {
+	payment, ok := transfer.Tx.(*Payment)
+	if !ok {
+		return fmt.Errorf("not a Bitcoin transfer")
+	}
+
+	if err := payment.Tx.SignInputs(payment.UTXOs, payment.Keys); err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	signedTx, err := payment.Tx.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize transaction: %w", err)
+	}
+	txID, err := TransactionID(signedTx)
+	if err != nil {
+		return err
+	}
+
+	transfer.Signed = signedTx
+	transfer.ID = txID
+	return nil
+}
+
+// Broadcast sends a signed transfer
+func (c *Chain) Broadcast(ctx context.Context, transfer *chains.Transfer) (string, error) {
+	return c.client.SendBitcoinTransaction(ctx, transfer.Signed)
+}
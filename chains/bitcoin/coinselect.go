@@ -0,0 +1,341 @@
+package bitcoin
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/chinmay1088/odyssey/config"
+)
+
+// Limits of the searches for a selection
+const (
+	bnbMaxTries        = 100000
+	knapsackIterations = 1000
+)
+
+// coin is a UTXO valued at its effective value: what it adds to a
+// transaction after paying for its own input at the fee rate. For the
+// privacy strategy a coin is every UTXO of an address, spent together.
+type coin struct {
+	utxos []*UTXO
+	value int64 // effective value
+	fee   int64 // fee of its inputs
+}
+
+// selection is a set of coins and what spending them costs beyond the
+// payments and the fixed part of the fee
+type selection struct {
+	coins []*coin
+	total int64 // effective value
+	fee   int64 // fee of the inputs
+	waste int64 // excess given to the fee without change, the cost of change with it
+}
+
+func (s *selection) add(c *coin) {
+	s.coins = append(s.coins, c)
+	s.total += c.value
+	s.fee += c.fee
+}
+
+// cost is what the selection spends beyond the payments
+func (s *selection) cost() int64 {
+	return s.fee + s.waste
+}
+
+func (s *selection) utxos() []*UTXO {
+	var utxos []*UTXO
+	for _, c := range s.coins {
+		utxos = append(utxos, c.utxos...)
+	}
+	return utxos
+}
+
+// SelectCoins picks the UTXOs that pay for the outputs of a transaction
+// without inputs, at feeRate sat/vB, with a strategy of config's coin
+// selection strategies:
+//
+//   - fee: branch and bound looks for UTXOs that pay the amount and fee
+//     exactly enough that change isn't worth creating, otherwise the smallest
+//     UTXO that covers them, a knapsack search and the largest UTXOs are
+//     compared by fee and waste
+//   - inputs: the largest UTXOs first, for the fewest inputs
+//   - privacy: whole addresses are spent, as few as possible, so addresses
+//     aren't linked and later payments don't spend their leftovers
+//   - all: every UTXO
+//
+// UTXOs worth less than the fee of their input are left alone. If no
+// selection covers the outputs every UTXO is returned, the fee then shows the
+// shortfall.
+func SelectCoins(utxos []*UTXO, tx *Transaction, feeRate int64, changeAddress btcutil.Address, strategy string) []*UTXO {
+	if strategy == config.CoinSelectionAll || len(utxos) == 0 {
+		return utxos
+	}
+
+	amount := int64(0)
+	for _, output := range tx.Outputs {
+		amount += output.Value
+	}
+
+	// Version, locktime, input and output counts, the outputs and the SegWit
+	// marker and flag
+	baseWeight := 4 * (4 + 4 + 1 + wire.VarIntSerializeSize(uint64(len(tx.Outputs))))
+	for _, output := range tx.Outputs {
+		baseWeight += 4 * output.SerializeSize()
+	}
+	baseWeight += 2
+
+	changeFee, changeCost := int64(0), int64(0)
+	if script, err := txscript.PayToAddrScript(changeAddress); err == nil {
+		changeFee = vbytes(4*wire.NewTxOut(0, script).SerializeSize()) * feeRate
+		changeCost = changeFee + vbytes(inputWeight(changeAddress))*feeRate
+	}
+
+	// Without change the coins must cover target, with change target plus
+	// the change output and change worth keeping
+	target := amount + vbytes(baseWeight)*feeRate
+	withChange := target + changeFee + DustThreshold
+
+	coins := makeCoins(utxos, feeRate, strategy == config.CoinSelectionPrivacy)
+
+	var best *selection
+	switch strategy {
+	case config.CoinSelectionInputs:
+		best = largestFirst(coins, withChange, changeCost)
+	default:
+		best = selectForFee(coins, target, withChange, changeCost)
+	}
+
+	// Funds spread over addresses are spent coin by coin
+	if best == nil && strategy == config.CoinSelectionPrivacy {
+		coins = makeCoins(utxos, feeRate, false)
+		best = selectForFee(coins, target, withChange, changeCost)
+	}
+
+	if best == nil {
+		return utxos
+	}
+	return best.utxos()
+}
+
+// selectForFee returns the cheapest selection of the fee strategy: coins
+// needing no change between target and target plus the cost of change, or
+// coins covering withChange
+func selectForFee(coins []*coin, target, withChange, changeCost int64) *selection {
+	return cheapest(
+		branchAndBound(coins, target, changeCost),
+		smallestCovering(coins, withChange, changeCost),
+		knapsack(coins, withChange, changeCost),
+		largestFirst(coins, withChange, changeCost),
+	)
+}
+
+// makeCoins values the UTXOs at their effective value, largest first. With
+// byAddress the UTXOs of an address make up one coin.
+func makeCoins(utxos []*UTXO, feeRate int64, byAddress bool) []*coin {
+	var coins []*coin
+	byKey := make(map[string]*coin)
+	for _, utxo := range utxos {
+		fee := vbytes(inputWeight(utxo.Address)) * feeRate
+		if !byAddress {
+			if utxo.Value > fee {
+				coins = append(coins, &coin{utxos: []*UTXO{utxo}, value: utxo.Value - fee, fee: fee})
+			}
+			continue
+		}
+
+		key := utxo.Address.EncodeAddress()
+		c, ok := byKey[key]
+		if !ok {
+			c = &coin{}
+			byKey[key] = c
+			coins = append(coins, c)
+		}
+		c.utxos = append(c.utxos, utxo)
+		c.value += utxo.Value - fee
+		c.fee += fee
+	}
+
+	// An address worth less than its inputs is left alone as a whole
+	kept := coins[:0]
+	for _, c := range coins {
+		if c.value > 0 {
+			kept = append(kept, c)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].value > kept[j].value })
+	return kept
+}
+
+// branchAndBound searches depth first for coins worth between target and
+// target plus the cost of change, so the transaction needs no change output.
+// Of those found the one giving the least excess to the fee wins. coins must
+// be sorted largest first.
+func branchAndBound(coins []*coin, target, changeCost int64) *selection {
+	// remaining[i] is the value of coins[i:]
+	remaining := make([]int64, len(coins)+1)
+	for i := len(coins) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + coins[i].value
+	}
+	if remaining[0] < target {
+		return nil
+	}
+
+	var best []int
+	bestExcess := changeCost + 1
+	var picked []int
+	tries := 0
+
+	var search func(i int, total int64)
+	search = func(i int, total int64) {
+		tries++
+		if tries > bnbMaxTries || total > target+changeCost || total+remaining[i] < target {
+			return
+		}
+		if total >= target {
+			if excess := total - target; excess < bestExcess {
+				bestExcess = excess
+				best = append(best[:0], picked...)
+			}
+			return
+		}
+		if i == len(coins) {
+			return
+		}
+
+		picked = append(picked, i)
+		search(i+1, total+coins[i].value)
+		picked = picked[:len(picked)-1]
+
+		// Leaving out a coin worth the same as the one just left out only
+		// repeats the search
+		j := i + 1
+		for j < len(coins) && coins[j].value == coins[i].value {
+			j++
+		}
+		search(j, total)
+	}
+	search(0, 0)
+
+	if best == nil {
+		return nil
+	}
+	s := &selection{}
+	for _, i := range best {
+		s.add(coins[i])
+	}
+	s.waste = s.total - target
+	return s
+}
+
+// knapsack looks for the subset of coins worth the least that still covers
+// target, by random passes over the coins as Bitcoin Core does. coins must be
+// sorted largest first.
+func knapsack(coins []*coin, target, changeCost int64) *selection {
+	total := int64(0)
+	for _, c := range coins {
+		total += c.value
+	}
+	if total < target {
+		return nil
+	}
+
+	bestIncluded := make([]bool, len(coins))
+	for i := range bestIncluded {
+		bestIncluded[i] = true
+	}
+	bestTotal := total
+
+	included := make([]bool, len(coins))
+	for iteration := 0; iteration < knapsackIterations && bestTotal != target; iteration++ {
+		for i := range included {
+			included[i] = false
+		}
+		sum := int64(0)
+		reached := false
+
+		// The first pass picks coins at random, the second fills up with
+		// the ones left out
+		for pass := 0; pass < 2 && !reached; pass++ {
+			for i, c := range coins {
+				if included[i] || (pass == 0 && rand.Intn(2) == 0) {
+					continue
+				}
+				sum += c.value
+				included[i] = true
+				if sum >= target {
+					reached = true
+					if sum < bestTotal {
+						bestTotal = sum
+						copy(bestIncluded, included)
+					}
+					// Try without this coin for a closer match
+					sum -= c.value
+					included[i] = false
+				}
+			}
+		}
+	}
+
+	s := &selection{}
+	for i, c := range coins {
+		if bestIncluded[i] {
+			s.add(c)
+		}
+	}
+	s.waste = changeCost
+	return s
+}
+
+// smallestCovering returns the smallest single coin that covers target, so
+// larger coins stay for larger payments. coins must be sorted largest first.
+func smallestCovering(coins []*coin, target, changeCost int64) *selection {
+	var s *selection
+	for _, c := range coins {
+		if c.value < target {
+			break
+		}
+		s = &selection{}
+		s.add(c)
+		s.waste = changeCost
+	}
+	return s
+}
+
+// largestFirst adds the largest coins until target is covered
+func largestFirst(coins []*coin, target, changeCost int64) *selection {
+	s := &selection{}
+	for _, c := range coins {
+		s.add(c)
+		if s.total >= target {
+			s.waste = changeCost
+			return s
+		}
+	}
+	return nil
+}
+
+// cheapest returns the selection costing the least, nil if there is none
+func cheapest(selections ...*selection) *selection {
+	var best *selection
+	for _, s := range selections {
+		if s != nil && (best == nil || s.cost() < best.cost()) {
+			best = s
+		}
+	}
+	return best
+}
+
+// inputWeight returns the weight of an input spending an output of address
+// once signed
+func inputWeight(address btcutil.Address) int {
+	input := signedInput(&wire.TxIn{}, address)
+	return 4*input.SerializeSize() + input.Witness.SerializeSize()
+}
+
+// vbytes converts weight to virtual bytes, rounding up
+func vbytes(weight int) int64 {
+	return int64((weight + 3) / 4)
+}
@@ -0,0 +1,247 @@
+package bitcoin
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/chinmay1088/odyssey/config"
+)
+
+const testFeeRate = 10 // sat/vB
+
+// testAddress returns a P2WPKH address, distinct for each seed
+func testAddress(t *testing.T, seed byte) btcutil.Address {
+	t.Helper()
+	address, err := btcutil.NewAddressWitnessPubKeyHash(bytes.Repeat([]byte{seed}, 20), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return address
+}
+
+// testUTXOs returns UTXOs of the values, each paid to its own address
+func testUTXOs(t *testing.T, values ...int64) []*UTXO {
+	t.Helper()
+	utxos := make([]*UTXO, len(values))
+	for i, value := range values {
+		utxos[i] = &UTXO{
+			TxID:    fmt.Sprintf("%064x", i+1),
+			Vout:    uint32(i),
+			Value:   value,
+			Address: testAddress(t, byte(i+1)),
+		}
+	}
+	return utxos
+}
+
+// testPayment returns a transaction paying amount, without inputs
+func testPayment(t *testing.T, amount int64) *Transaction {
+	t.Helper()
+	tx := NewTransaction()
+	if err := tx.AddOutput(amount, testAddress(t, 0xff)); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+// settle adds the selected UTXOs to a copy of the payment and settles its fee
+func settle(t *testing.T, amount int64, utxos []*UTXO) (fee, change, totalInput int64) {
+	t.Helper()
+	tx := testPayment(t, amount)
+	for _, utxo := range utxos {
+		totalInput += utxo.Value
+		if err := tx.AddInput(utxo, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fee, change, _, err := tx.SettleFee(utxos, totalInput, amount, testFeeRate, testAddress(t, 0xfe))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fee, change, totalInput
+}
+
+// noChangeAmount returns the amount that utxos pay exactly, leaving excess
+// to the fee, without a change output
+func noChangeAmount(t *testing.T, utxos []*UTXO, excess int64) int64 {
+	t.Helper()
+	tx := testPayment(t, 0)
+	total := int64(0)
+	for _, utxo := range utxos {
+		total += utxo.Value
+		if err := tx.AddInput(utxo, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	vsize, err := tx.VirtualSize(utxos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return total - vsize*testFeeRate - excess
+}
+
+func testCoins(values ...int64) []*coin {
+	coins := make([]*coin, len(values))
+	for i, value := range values {
+		coins[i] = &coin{value: value}
+	}
+	return coins
+}
+
+func coinValues(s *selection) []int64 {
+	var values []int64
+	for _, c := range s.coins {
+		values = append(values, c.value)
+	}
+	return values
+}
+
+func utxoValues(utxos []*UTXO) []int64 {
+	var values []int64
+	for _, utxo := range utxos {
+		values = append(values, utxo.Value)
+	}
+	return values
+}
+
+func TestSelectCoinsExactMatchWithoutChange(t *testing.T) {
+	utxos := testUTXOs(t, 200000, 50000, 30000, 20000)
+	amount := noChangeAmount(t, utxos[1:3], 50)
+
+	selected := SelectCoins(utxos, testPayment(t, amount), testFeeRate, testAddress(t, 0xfe), config.CoinSelectionFee)
+	if got := fmt.Sprint(utxoValues(selected)); got != "[50000 30000]" {
+		t.Fatalf("selected %s, want [50000 30000]", got)
+	}
+
+	fee, change, totalInput := settle(t, amount, selected)
+	if change != 0 {
+		t.Errorf("change = %d, want none", change)
+	}
+	if fee != totalInput-amount {
+		t.Errorf("fee = %d, want the leftover %d", fee, totalInput-amount)
+	}
+}
+
+func TestBranchAndBoundPicksLeastExcess(t *testing.T) {
+	coins := testCoins(9000, 6000, 5000, 4000)
+
+	s := branchAndBound(coins, 10000, 500)
+	if s == nil {
+		t.Fatal("no selection found")
+	}
+	if got := fmt.Sprint(coinValues(s)); got != "[6000 4000]" {
+		t.Errorf("selected %s, want [6000 4000]", got)
+	}
+	if s.waste != 0 {
+		t.Errorf("waste = %d, want 0", s.waste)
+	}
+}
+
+func TestKnapsackFallback(t *testing.T) {
+	coins := testCoins(7000, 5000, 3000)
+
+	// No subset lands between 8500 and 8600, change can't be avoided
+	if s := branchAndBound(coins, 8500, 100); s != nil {
+		t.Fatalf("branch and bound selected %v, want nothing", coinValues(s))
+	}
+
+	s := knapsack(coins, 8500, 100)
+	if s == nil {
+		t.Fatal("knapsack found no selection")
+	}
+	if s.total != 10000 {
+		t.Errorf("knapsack total = %d (%v), want the closest cover 10000", s.total, coinValues(s))
+	}
+	if s.waste != 100 {
+		t.Errorf("waste = %d, want the cost of change 100", s.waste)
+	}
+
+	if s := knapsack(coins, 20000, 100); s != nil {
+		t.Errorf("knapsack selected %v of too little, want nothing", coinValues(s))
+	}
+}
+
+func TestSmallestCovering(t *testing.T) {
+	coins := testCoins(10000, 6000, 4000)
+
+	s := smallestCovering(coins, 5000, 100)
+	if s == nil {
+		t.Fatal("no selection found")
+	}
+	if got := fmt.Sprint(coinValues(s)); got != "[6000]" {
+		t.Errorf("selected %s, want [6000]", got)
+	}
+
+	if s := smallestCovering(coins, 12000, 100); s != nil {
+		t.Errorf("selected %v, no single coin covers 12000", coinValues(s))
+	}
+}
+
+func TestLargestFirst(t *testing.T) {
+	coins := testCoins(10000, 6000, 4000)
+
+	s := largestFirst(coins, 12000, 100)
+	if s == nil {
+		t.Fatal("no selection found")
+	}
+	if got := fmt.Sprint(coinValues(s)); got != "[10000 6000]" {
+		t.Errorf("selected %s, want [10000 6000]", got)
+	}
+
+	if s := largestFirst(coins, 30000, 100); s != nil {
+		t.Errorf("selected %v, the coins don't cover 30000", coinValues(s))
+	}
+}
+
+func TestSelectCoinsInputsStrategy(t *testing.T) {
+	utxos := testUTXOs(t, 20000, 80000, 50000, 30000)
+
+	selected := SelectCoins(utxos, testPayment(t, 100000), testFeeRate, testAddress(t, 0xfe), config.CoinSelectionInputs)
+	if got := fmt.Sprint(utxoValues(selected)); got != "[80000 50000]" {
+		t.Errorf("selected %s, want the largest [80000 50000]", got)
+	}
+}
+
+func TestSelectCoinsAvoidsDustChange(t *testing.T) {
+	utxos := testUTXOs(t, 100000)
+	amount := noChangeAmount(t, utxos, DustThreshold-1)
+
+	fee, change, totalInput := settle(t, amount, SelectCoins(utxos, testPayment(t, amount), testFeeRate, testAddress(t, 0xfe), config.CoinSelectionFee))
+	if change != 0 {
+		t.Errorf("change = %d, want dust change dropped", change)
+	}
+	if fee != totalInput-amount {
+		t.Errorf("fee = %d, want the dust added to it: %d", fee, totalInput-amount)
+	}
+
+	// Change worth keeping gets its output
+	amount -= 10000
+	_, change, _ = settle(t, amount, utxos)
+	if change < DustThreshold {
+		t.Errorf("change = %d, want an output of at least %d", change, DustThreshold)
+	}
+}
+
+func TestSelectCoinsInsufficientFunds(t *testing.T) {
+	utxos := testUTXOs(t, 30000, 20000, 100)
+	amount := int64(60000)
+
+	// Without a covering selection every UTXO is spent, UTXOs worth less
+	// than their input included
+	selected := SelectCoins(utxos, testPayment(t, amount), testFeeRate, testAddress(t, 0xfe), config.CoinSelectionFee)
+	if len(selected) != len(utxos) {
+		t.Fatalf("selected %v, want every UTXO", utxoValues(selected))
+	}
+
+	// The fee then shows the shortfall
+	fee, change, totalInput := settle(t, amount, selected)
+	if change != 0 {
+		t.Errorf("change = %d, want none", change)
+	}
+	if fee <= totalInput-amount {
+		t.Errorf("fee = %d, want more than the %d the inputs leave", fee, totalInput-amount)
+	}
+}
@@ -0,0 +1,91 @@
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// MultisigDerivationPath is the BIP-48 account path for P2WSH multisig keys
+const MultisigDerivationPath = "m/48'/0'/0'/2'"
+
+// MaxMultisigKeys is the largest number of keys in a standard multisig script
+const MaxMultisigKeys = 15
+
+// MultisigScript builds the witness script of an m-of-n multisig with keys
+// sorted as in BIP-67, so every cosigner derives the same script
+func MultisigScript(publicKeys []*btcec.PublicKey, threshold int) ([]byte, error) {
+	if threshold < 1 || threshold > len(publicKeys) {
+		return nil, fmt.Errorf("threshold must be between 1 and %d", len(publicKeys))
+	}
+	if len(publicKeys) > MaxMultisigKeys {
+		return nil, fmt.Errorf("at most %d keys are supported", MaxMultisigKeys)
+	}
+
+	sorted := SortPublicKeys(publicKeys)
+	addresses := make([]*btcutil.AddressPubKey, len(sorted))
+	for i, key := range sorted {
+		address, err := btcutil.NewAddressPubKey(key.SerializeCompressed(), NetParams())
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+		addresses[i] = address
+	}
+
+	script, err := txscript.MultiSigScript(addresses, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multisig script: %w", err)
+	}
+
+	return script, nil
+}
+
+// SortPublicKeys sorts public keys by their compressed encoding (BIP-67)
+func SortPublicKeys(publicKeys []*btcec.PublicKey) []*btcec.PublicKey {
+	sorted := append([]*btcec.PublicKey(nil), publicKeys...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].SerializeCompressed(), sorted[j].SerializeCompressed()) < 0
+	})
+	return sorted
+}
+
+// MultisigAddress returns the P2WSH address of a witness script
+func MultisigAddress(witnessScript []byte) (btcutil.Address, error) {
+	hash := sha256.Sum256(witnessScript)
+	address, err := btcutil.NewAddressWitnessScriptHash(hash[:], NetParams())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create P2WSH address: %w", err)
+	}
+	return address, nil
+}
+
+// DeriveMultisigKey derives the public key of a cosigner for a branch
+// (0 receive, 1 change) and address index
+func DeriveMultisigKey(account *hdkeychain.ExtendedKey, branch, index uint32) (*btcec.PublicKey, error) {
+	branchKey, err := account.Derive(branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive branch: %w", err)
+	}
+	child, err := branchKey.Derive(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive index: %w", err)
+	}
+	return child.ECPubKey()
+}
+
+// EstimateMultisigVSize estimates the virtual size of a transaction spending
+// P2WSH m-of-n inputs, with one P2WPKH or P2WSH output each
+func EstimateMultisigVSize(inputs, threshold, keys, p2wpkhOutputs, p2wshOutputs int) int64 {
+	// Witness: item count, empty dummy, signatures, script with its length
+	scriptSize := 3 + keys*34
+	witness := 1 + 1 + threshold*73 + 1 + scriptSize
+	inputVSize := 41 + (witness+3)/4
+
+	return int64(11 + inputs*inputVSize + p2wpkhOutputs*31 + p2wshOutputs*43)
+}
@@ -0,0 +1,536 @@
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// PSBT key types (BIP-174) used for P2WSH multisig spends
+const (
+	psbtGlobalUnsignedTx = 0x00
+
+	psbtInWitnessUTXO        = 0x01
+	psbtInPartialSig         = 0x02
+	psbtInSighashType        = 0x03
+	psbtInWitnessScript      = 0x05
+	psbtInBip32Derivation    = 0x06
+	psbtInFinalScriptWitness = 0x08
+
+	psbtOutWitnessScript   = 0x01
+	psbtOutBip32Derivation = 0x02
+)
+
+var psbtMagic = []byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+// Bip32Derivation records which cosigner key a public key was derived from
+type Bip32Derivation struct {
+	PubKey      []byte
+	Fingerprint uint32
+	Path        []uint32
+}
+
+// PartialSig is one cosigner's signature of an input
+type PartialSig struct {
+	PubKey    []byte
+	Signature []byte // DER signature with sighash type appended
+}
+
+// PSBTInput holds the signing data of one input
+type PSBTInput struct {
+	WitnessUTXO        *wire.TxOut
+	PartialSigs        []PartialSig
+	SighashType        uint32
+	WitnessScript      []byte
+	Derivations        []Bip32Derivation
+	FinalScriptWitness []byte
+	unknown            []psbtKV
+}
+
+// PSBTOutput holds the data needed to recognise change outputs
+type PSBTOutput struct {
+	WitnessScript []byte
+	Derivations   []Bip32Derivation
+	unknown       []psbtKV
+}
+
+// PSBT is a partially signed Bitcoin transaction (BIP-174). Only the fields
+// needed for segwit multisig are interpreted, others are kept as they are.
+type PSBT struct {
+	Tx      *wire.MsgTx
+	Inputs  []PSBTInput
+	Outputs []PSBTOutput
+	unknown []psbtKV
+}
+
+type psbtKV struct {
+	key   []byte
+	value []byte
+}
+
+// NewPSBT creates a PSBT for an unsigned transaction
+func NewPSBT(tx *wire.MsgTx) *PSBT {
+	return &PSBT{
+		Tx:      tx,
+		Inputs:  make([]PSBTInput, len(tx.TxIn)),
+		Outputs: make([]PSBTOutput, len(tx.TxOut)),
+	}
+}
+
+// DecodePSBT parses a base64 encoded PSBT
+func DecodePSBT(encoded string) (*PSBT, error) {
+	raw, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace([]byte(encoded))))
+	if err != nil {
+		return nil, fmt.Errorf("PSBT is not valid base64: %w", err)
+	}
+
+	r := bytes.NewReader(raw)
+	magic := make([]byte, len(psbtMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, psbtMagic) {
+		return nil, fmt.Errorf("not a PSBT")
+	}
+
+	p := &PSBT{}
+	global, err := readPSBTMap(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range global {
+		if kv.key[0] == psbtGlobalUnsignedTx && len(kv.key) == 1 {
+			tx := wire.NewMsgTx(2)
+			if err := tx.DeserializeNoWitness(bytes.NewReader(kv.value)); err != nil {
+				return nil, fmt.Errorf("invalid unsigned transaction: %w", err)
+			}
+			p.Tx = tx
+			continue
+		}
+		p.unknown = append(p.unknown, kv)
+	}
+	if p.Tx == nil {
+		return nil, fmt.Errorf("PSBT has no unsigned transaction")
+	}
+
+	p.Inputs = make([]PSBTInput, len(p.Tx.TxIn))
+	for i := range p.Inputs {
+		kvs, err := readPSBTMap(r)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		if err := p.Inputs[i].parse(kvs); err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+	}
+
+	p.Outputs = make([]PSBTOutput, len(p.Tx.TxOut))
+	for i := range p.Outputs {
+		kvs, err := readPSBTMap(r)
+		if err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		if err := p.Outputs[i].parse(kvs); err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+	}
+
+	return p, nil
+}
+
+// Encode serializes the PSBT as base64
+func (p *PSBT) Encode() (string, error) {
+	var buf bytes.Buffer
+	buf.Write(psbtMagic)
+
+	var unsigned bytes.Buffer
+	if err := p.Tx.SerializeNoWitness(&unsigned); err != nil {
+		return "", fmt.Errorf("failed to serialize transaction: %w", err)
+	}
+	global := append([]psbtKV{{key: []byte{psbtGlobalUnsignedTx}, value: unsigned.Bytes()}}, p.unknown...)
+	writePSBTMap(&buf, global)
+
+	for _, input := range p.Inputs {
+		writePSBTMap(&buf, input.pairs())
+	}
+	for _, output := range p.Outputs {
+		writePSBTMap(&buf, output.pairs())
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// SignMultisigInputs adds a signature to every input whose witness script
+// contains the key's public key. It returns the number of inputs signed.
+func (p *PSBT) SignMultisigInputs(privateKey *btcec.PrivateKey) (int, error) {
+	fetcher := txscript.NewMultiPrevOutFetcher(nil)
+	for i, input := range p.Inputs {
+		if input.WitnessUTXO == nil {
+			return 0, fmt.Errorf("input %d has no witness UTXO", i)
+		}
+		fetcher.AddPrevOut(p.Tx.TxIn[i].PreviousOutPoint, input.WitnessUTXO)
+	}
+	hashes := txscript.NewTxSigHashes(p.Tx, fetcher)
+
+	pubKey := privateKey.PubKey().SerializeCompressed()
+	signed := 0
+	for i := range p.Inputs {
+		input := &p.Inputs[i]
+		if input.FinalScriptWitness != nil || !scriptHasKey(input.WitnessScript, pubKey) || input.hasSig(pubKey) {
+			continue
+		}
+
+		// Never sign a script that is not the one the output pays to
+		scriptHash := sha256.Sum256(input.WitnessScript)
+		expected := append([]byte{txscript.OP_0, txscript.OP_DATA_32}, scriptHash[:]...)
+		if !bytes.Equal(input.WitnessUTXO.PkScript, expected) {
+			return signed, fmt.Errorf("input %d witness script does not match its UTXO", i)
+		}
+
+		sig, err := txscript.RawTxInWitnessSignature(p.Tx, hashes, i, input.WitnessUTXO.Value,
+			input.WitnessScript, txscript.SigHashAll, privateKey)
+		if err != nil {
+			return signed, fmt.Errorf("failed to sign input %d: %w", i, err)
+		}
+
+		input.PartialSigs = append(input.PartialSigs, PartialSig{PubKey: pubKey, Signature: sig})
+		input.SighashType = uint32(txscript.SigHashAll)
+		signed++
+	}
+
+	return signed, nil
+}
+
+// Merge adds the signatures of another copy of the same PSBT
+func (p *PSBT) Merge(other *PSBT) error {
+	if p.Tx.TxHash() != other.Tx.TxHash() {
+		return fmt.Errorf("PSBTs spend different transactions")
+	}
+
+	for i := range p.Inputs {
+		for _, sig := range other.Inputs[i].PartialSigs {
+			if !p.Inputs[i].hasSig(sig.PubKey) {
+				p.Inputs[i].PartialSigs = append(p.Inputs[i].PartialSigs, sig)
+			}
+		}
+		if p.Inputs[i].FinalScriptWitness == nil {
+			p.Inputs[i].FinalScriptWitness = other.Inputs[i].FinalScriptWitness
+		}
+	}
+
+	return nil
+}
+
+// MultisigSignatures returns the number of signatures on an input and how many are required
+func (p *PSBT) MultisigSignatures(index int) (have, need int) {
+	input := p.Inputs[index]
+	_, _, need, err := txscript.ExtractPkScriptAddrs(input.WitnessScript, NetParams())
+	if err != nil {
+		return 0, 0
+	}
+	if input.FinalScriptWitness != nil {
+		return need, need
+	}
+	return len(input.PartialSigs), need
+}
+
+// FinalizeMultisig builds the witness of every input once it has enough signatures
+func (p *PSBT) FinalizeMultisig() error {
+	for i := range p.Inputs {
+		input := &p.Inputs[i]
+		if input.FinalScriptWitness != nil {
+			continue
+		}
+
+		_, addresses, need, err := txscript.ExtractPkScriptAddrs(input.WitnessScript, NetParams())
+		if err != nil || need == 0 {
+			return fmt.Errorf("input %d has no multisig witness script", i)
+		}
+
+		// Signatures must appear in the same order as their keys in the script
+		witness := wire.TxWitness{nil}
+		for _, address := range addresses {
+			key := address.ScriptAddress()
+			for _, sig := range input.PartialSigs {
+				if bytes.Equal(sig.PubKey, key) && len(witness) <= need {
+					witness = append(witness, sig.Signature)
+				}
+			}
+		}
+		if len(witness)-1 < need {
+			return fmt.Errorf("input %d has %d of %d signatures", i, len(witness)-1, need)
+		}
+		witness = append(witness, input.WitnessScript)
+
+		var buf bytes.Buffer
+		if err := writeWitness(&buf, witness); err != nil {
+			return err
+		}
+		input.FinalScriptWitness = buf.Bytes()
+		input.PartialSigs = nil
+	}
+
+	return nil
+}
+
+// Extract returns the signed transaction of a finalized PSBT
+func (p *PSBT) Extract() (*wire.MsgTx, error) {
+	tx := p.Tx.Copy()
+	for i, input := range p.Inputs {
+		if input.FinalScriptWitness == nil {
+			return nil, fmt.Errorf("input %d is not finalized", i)
+		}
+		witness, err := readWitness(bytes.NewReader(input.FinalScriptWitness))
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		tx.TxIn[i].Witness = witness
+	}
+	return tx, nil
+}
+
+// Fee returns the fee paid by the transaction in satoshis
+func (p *PSBT) Fee() (int64, error) {
+	var fee int64
+	for i, input := range p.Inputs {
+		if input.WitnessUTXO == nil {
+			return 0, fmt.Errorf("input %d has no witness UTXO", i)
+		}
+		fee += input.WitnessUTXO.Value
+	}
+	for _, output := range p.Tx.TxOut {
+		fee -= output.Value
+	}
+	return fee, nil
+}
+
+func (in *PSBTInput) hasSig(pubKey []byte) bool {
+	for _, sig := range in.PartialSigs {
+		if bytes.Equal(sig.PubKey, pubKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func (in *PSBTInput) parse(kvs []psbtKV) error {
+	for _, kv := range kvs {
+		keyData := kv.key[1:]
+		switch kv.key[0] {
+		case psbtInWitnessUTXO:
+			out, err := readTxOut(kv.value)
+			if err != nil {
+				return err
+			}
+			in.WitnessUTXO = out
+		case psbtInPartialSig:
+			in.PartialSigs = append(in.PartialSigs, PartialSig{PubKey: keyData, Signature: kv.value})
+		case psbtInSighashType:
+			if len(kv.value) != 4 {
+				return fmt.Errorf("invalid sighash type")
+			}
+			in.SighashType = binary.LittleEndian.Uint32(kv.value)
+		case psbtInWitnessScript:
+			in.WitnessScript = kv.value
+		case psbtInBip32Derivation:
+			derivation, err := parseDerivation(keyData, kv.value)
+			if err != nil {
+				return err
+			}
+			in.Derivations = append(in.Derivations, derivation)
+		case psbtInFinalScriptWitness:
+			in.FinalScriptWitness = kv.value
+		default:
+			in.unknown = append(in.unknown, kv)
+		}
+	}
+	return nil
+}
+
+func (in *PSBTInput) pairs() []psbtKV {
+	var kvs []psbtKV
+	if in.WitnessUTXO != nil {
+		var buf bytes.Buffer
+		wire.WriteTxOut(&buf, 0, 0, in.WitnessUTXO)
+		kvs = append(kvs, psbtKV{key: []byte{psbtInWitnessUTXO}, value: buf.Bytes()})
+	}
+	for _, sig := range in.PartialSigs {
+		kvs = append(kvs, psbtKV{key: append([]byte{psbtInPartialSig}, sig.PubKey...), value: sig.Signature})
+	}
+	if in.SighashType != 0 {
+		value := make([]byte, 4)
+		binary.LittleEndian.PutUint32(value, in.SighashType)
+		kvs = append(kvs, psbtKV{key: []byte{psbtInSighashType}, value: value})
+	}
+	if in.WitnessScript != nil {
+		kvs = append(kvs, psbtKV{key: []byte{psbtInWitnessScript}, value: in.WitnessScript})
+	}
+	for _, derivation := range in.Derivations {
+		kvs = append(kvs, derivation.pair(psbtInBip32Derivation))
+	}
+	if in.FinalScriptWitness != nil {
+		kvs = append(kvs, psbtKV{key: []byte{psbtInFinalScriptWitness}, value: in.FinalScriptWitness})
+	}
+	return append(kvs, in.unknown...)
+}
+
+func (out *PSBTOutput) parse(kvs []psbtKV) error {
+	for _, kv := range kvs {
+		switch kv.key[0] {
+		case psbtOutWitnessScript:
+			out.WitnessScript = kv.value
+		case psbtOutBip32Derivation:
+			derivation, err := parseDerivation(kv.key[1:], kv.value)
+			if err != nil {
+				return err
+			}
+			out.Derivations = append(out.Derivations, derivation)
+		default:
+			out.unknown = append(out.unknown, kv)
+		}
+	}
+	return nil
+}
+
+func (out *PSBTOutput) pairs() []psbtKV {
+	var kvs []psbtKV
+	if out.WitnessScript != nil {
+		kvs = append(kvs, psbtKV{key: []byte{psbtOutWitnessScript}, value: out.WitnessScript})
+	}
+	for _, derivation := range out.Derivations {
+		kvs = append(kvs, derivation.pair(psbtOutBip32Derivation))
+	}
+	return append(kvs, out.unknown...)
+}
+
+func (d Bip32Derivation) pair(keyType byte) psbtKV {
+	value := make([]byte, 4+4*len(d.Path))
+	binary.LittleEndian.PutUint32(value, d.Fingerprint)
+	for i, index := range d.Path {
+		binary.LittleEndian.PutUint32(value[4+4*i:], index)
+	}
+	return psbtKV{key: append([]byte{keyType}, d.PubKey...), value: value}
+}
+
+func parseDerivation(pubKey, value []byte) (Bip32Derivation, error) {
+	if len(value) < 4 || len(value)%4 != 0 {
+		return Bip32Derivation{}, fmt.Errorf("invalid BIP32 derivation")
+	}
+	derivation := Bip32Derivation{PubKey: pubKey, Fingerprint: binary.LittleEndian.Uint32(value)}
+	for i := 4; i < len(value); i += 4 {
+		derivation.Path = append(derivation.Path, binary.LittleEndian.Uint32(value[i:]))
+	}
+	return derivation, nil
+}
+
+// scriptHasKey returns true if a multisig script contains a public key
+func scriptHasKey(script, pubKey []byte) bool {
+	_, addresses, _, err := txscript.ExtractPkScriptAddrs(script, NetParams())
+	if err != nil {
+		return false
+	}
+	for _, address := range addresses {
+		if bytes.Equal(address.ScriptAddress(), pubKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func readPSBTMap(r *bytes.Reader) ([]psbtKV, error) {
+	var kvs []psbtKV
+	for {
+		keyLen, err := wire.ReadVarInt(r, 0)
+		if err != nil {
+			return nil, fmt.Errorf("truncated PSBT")
+		}
+		if keyLen == 0 {
+			break
+		}
+		key, err := readBytes(r, keyLen)
+		if err != nil {
+			return nil, err
+		}
+		valueLen, err := wire.ReadVarInt(r, 0)
+		if err != nil {
+			return nil, fmt.Errorf("truncated PSBT")
+		}
+		value, err := readBytes(r, valueLen)
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, psbtKV{key: key, value: value})
+	}
+	return kvs, nil
+}
+
+func writePSBTMap(w *bytes.Buffer, kvs []psbtKV) {
+	sort.SliceStable(kvs, func(i, j int) bool { return bytes.Compare(kvs[i].key, kvs[j].key) < 0 })
+	for _, kv := range kvs {
+		wire.WriteVarBytes(w, 0, kv.key)
+		wire.WriteVarBytes(w, 0, kv.value)
+	}
+	w.WriteByte(0x00)
+}
+
+func readBytes(r *bytes.Reader, n uint64) ([]byte, error) {
+	if n > uint64(r.Len()) {
+		return nil, fmt.Errorf("truncated PSBT")
+	}
+	data := make([]byte, n)
+	_, err := io.ReadFull(r, data)
+	return data, err
+}
+
+func readTxOut(data []byte) (*wire.TxOut, error) {
+	if len(data) < 9 {
+		return nil, fmt.Errorf("invalid witness UTXO")
+	}
+	r := bytes.NewReader(data[8:])
+	scriptLen, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid witness UTXO")
+	}
+	script, err := readBytes(r, scriptLen)
+	if err != nil {
+		return nil, fmt.Errorf("invalid witness UTXO")
+	}
+	return wire.NewTxOut(int64(binary.LittleEndian.Uint64(data[:8])), script), nil
+}
+
+func writeWitness(w *bytes.Buffer, witness wire.TxWitness) error {
+	if err := wire.WriteVarInt(w, 0, uint64(len(witness))); err != nil {
+		return err
+	}
+	for _, item := range witness {
+		if err := wire.WriteVarBytes(w, 0, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readWitness(r *bytes.Reader) (wire.TxWitness, error) {
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid witness")
+	}
+	witness := make(wire.TxWitness, 0, count)
+	for i := uint64(0); i < count; i++ {
+		size, err := wire.ReadVarInt(r, 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid witness")
+		}
+		item, err := readBytes(r, size)
+		if err != nil {
+			return nil, err
+		}
+		witness = append(witness, item)
+	}
+	return witness, nil
+}
@@ -1,17 +1,44 @@
 package bitcoin
 
 import (
-	"crypto/ecdsa"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"bytes"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/chinmay1088/odyssey/chains"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/shopspring/decimal"
+)
+
+// Decimals of BTC, Bitcoin counts in satoshis
+const Decimals = 8
+
+// DustThreshold is the smallest output value in satoshis that nodes relay to
+// any address type. Smaller outputs cost more in fees to spend than they are
+// worth. DustLimit is the threshold of a given address.
+const DustThreshold = 546
+
+// Standardness rules of Bitcoin Core, transactions breaking them aren't relayed
+const (
+	// MaxStandardWeight is the largest weight of a relayed transaction
+	MaxStandardWeight = 400000
+
+	// MaxDataSize is the largest OP_RETURN payload in bytes, at most one
+	// OP_RETURN output is relayed per transaction
+	MaxDataSize = txscript.MaxDataCarrierSize
+
+	// dustRelayFeeRate in sat/vB prices dust: an output is dust if spending
+	// it at this rate costs more than a third of its value
+	dustRelayFeeRate = 3
 )
 
 // UTXO represents an unspent transaction output
@@ -20,6 +47,9 @@ type UTXO struct {
 	Vout   uint32
 	Value  int64
 	Script []byte
+
+	// Address the output was paid to, its key signs the input
+	Address btcutil.Address
 }
 
 // Transaction represents a Bitcoin transaction
@@ -66,39 +96,148 @@ func (tx *Transaction) AddOutput(value int64, address btcutil.Address) error {
 	return nil
 }
 
-// SignTransaction signs all inputs in the transaction
+// AddDataOutput adds a zero value OP_RETURN output carrying data, e.g. a swap memo
+func (tx *Transaction) AddDataOutput(data []byte) error {
+	if len(data) > MaxDataSize {
+		return fmt.Errorf("data of %d bytes is longer than the %d bytes nodes relay", len(data), MaxDataSize)
+	}
+	script, err := txscript.NullDataScript(data)
+	if err != nil {
+		return fmt.Errorf("failed to create data script: %w", err)
+	}
+	output := wire.NewTxOut(0, script)
+	tx.Outputs = append(tx.Outputs, output)
+	return nil
+}
+
+// SignTransaction signs all inputs in the transaction, which must all spend
+// outputs of a single address
 func (tx *Transaction) SignTransaction(utxos []*UTXO, privateKey *btcec.PrivateKey, address btcutil.Address) error {
+	owned := make([]*UTXO, len(utxos))
+	for i, utxo := range utxos {
+		copied := *utxo
+		copied.Address = address
+		owned[i] = &copied
+	}
+	return tx.SignInputs(owned, map[string]*btcec.PrivateKey{address.EncodeAddress(): privateKey})
+}
+
+// SignInputs signs every input with the key of the address its UTXO was
+// paid to, so a transaction can spend from several address types at once.
+// Each input is signed for the script and value of the output it spends, the
+// UTXO's script if it was fetched and the script of its address otherwise.
+// keys maps encoded addresses to their private keys, for Taproot the
+// untweaked internal key. The signatures are verified against the outputs
+// they spend before returning.
+func (tx *Transaction) SignInputs(utxos []*UTXO, keys map[string]*btcec.PrivateKey) error {
+	if len(utxos) < len(tx.Inputs) {
+		return fmt.Errorf("insufficient UTXOs for signing")
+	}
+
+	// Taproot and SegWit signatures commit to the amounts and scripts of the
+	// outputs spent
 	wireTx := tx.toWireTx()
-	fetcher := txscript.NewMultiPrevOutFetcher(nil)
+	fetcher, scripts, err := tx.prevOuts(utxos)
+	if err != nil {
+		return err
+	}
 	hashes := txscript.NewTxSigHashes(wireTx, fetcher)
+
 	for i, input := range tx.Inputs {
-		if i >= len(utxos) {
-			return fmt.Errorf("insufficient UTXOs for signing")
+		utxo := utxos[i]
+		privateKey, ok := keys[utxo.Address.EncodeAddress()]
+		if !ok {
+			return fmt.Errorf("no key for input %d (%s)", i, utxo.Address.EncodeAddress())
+		}
+
+		switch class := txscript.GetScriptClass(scripts[i]); class {
+		case txscript.WitnessV1TaprootTy:
+			witness, err := txscript.TaprootWitnessSignature(wireTx, hashes, i, utxo.Value, scripts[i],
+				txscript.SigHashDefault, privateKey)
+			if err != nil {
+				return fmt.Errorf("failed to sign input %d: %w", i, err)
+			}
+			input.Witness = witness
+
+		case txscript.PubKeyHashTy:
+			sigScript, err := txscript.SignatureScript(wireTx, i, scripts[i], txscript.SigHashAll, privateKey, true)
+			if err != nil {
+				return fmt.Errorf("failed to sign input %d: %w", i, err)
+			}
+			input.SignatureScript = sigScript
+
+		case txscript.ScriptHashTy:
+			// Nested SegWit, the P2SH redeem script is the P2WPKH witness program
+			pubKeyHash := btcutil.Hash160(privateKey.PubKey().SerializeCompressed())
+			redeemScript := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, pubKeyHash...)
+			witness, err := txscript.WitnessSignature(wireTx, hashes, i, utxo.Value, redeemScript,
+				txscript.SigHashAll, privateKey, true)
+			if err != nil {
+				return fmt.Errorf("failed to sign input %d: %w", i, err)
+			}
+			sigScript, err := txscript.NewScriptBuilder().AddData(redeemScript).Script()
+			if err != nil {
+				return fmt.Errorf("failed to create signature script: %w", err)
+			}
+			input.SignatureScript = sigScript
+			input.Witness = witness
+
+		case txscript.WitnessV0PubKeyHashTy:
+			witness, err := txscript.WitnessSignature(wireTx, hashes, i, utxo.Value, scripts[i],
+				txscript.SigHashAll, privateKey, true)
+			if err != nil {
+				return fmt.Errorf("failed to sign input %d: %w", i, err)
+			}
+			input.Witness = witness
+
+		default:
+			return fmt.Errorf("cannot sign input %d: unsupported script type %s", i, class)
 		}
+	}
+
+	return tx.verifyInputs(wireTx, fetcher, scripts, utxos)
+}
+
+// prevOuts returns the outputs the inputs spend, by outpoint and in input
+// order. A fetched script must be the script of the UTXO's address, otherwise
+// the UTXO was attributed to the wrong address and its key can't sign it.
+func (tx *Transaction) prevOuts(utxos []*UTXO) (*txscript.MultiPrevOutFetcher, [][]byte, error) {
+	fetcher := txscript.NewMultiPrevOutFetcher(nil)
+	scripts := make([][]byte, len(tx.Inputs))
+	for i, input := range tx.Inputs {
 		utxo := utxos[i]
-		// For real SegWit, you need the correct scriptPubKey and value
-		script, err := txscript.PayToAddrScript(address)
-		if err != nil {
-			return fmt.Errorf("failed to create script: %w", err)
+		if utxo.Address == nil {
+			return nil, nil, fmt.Errorf("unknown owner of input %d", i)
 		}
-		sighash, err := txscript.CalcWitnessSigHash(script, hashes, txscript.SigHashAll, wireTx, i, utxo.Value)
+		script, err := txscript.PayToAddrScript(utxo.Address)
 		if err != nil {
-			return fmt.Errorf("failed to calculate sighash: %w", err)
+			return nil, nil, fmt.Errorf("failed to create script: %w", err)
+		}
+		if len(utxo.Script) > 0 {
+			if !bytes.Equal(utxo.Script, script) {
+				return nil, nil, fmt.Errorf("input %d spends script %x, not the script of %s", i, utxo.Script, utxo.Address.EncodeAddress())
+			}
+			script = utxo.Script
 		}
+		scripts[i] = script
+		fetcher.AddPrevOut(input.PreviousOutPoint, wire.NewTxOut(utxo.Value, script))
+	}
+	return fetcher, scripts, nil
+}
 
-		// Convert to ECDSA private key for signing
-		ecdsaPrivKey := privateKey.ToECDSA()
-		sig, err := ecdsa.SignASN1(nil, ecdsaPrivKey, sighash)
+// verifyInputs runs the script of every output spent against its signed
+// input, so a transaction that nodes would reject isn't broadcast
+func (tx *Transaction) verifyInputs(wireTx *wire.MsgTx, fetcher *txscript.MultiPrevOutFetcher, scripts [][]byte, utxos []*UTXO) error {
+	hashes := txscript.NewTxSigHashes(wireTx, fetcher)
+	for i := range tx.Inputs {
+		engine, err := txscript.NewEngine(scripts[i], wireTx, i, txscript.StandardVerifyFlags,
+			nil, hashes, utxos[i].Value, fetcher)
 		if err != nil {
-			return fmt.Errorf("failed to sign input %d: %w", i, err)
+			return fmt.Errorf("failed to verify input %d: %w", i, err)
 		}
-
-		pubKey := privateKey.PubKey()
-		witness := wire.TxWitness{
-			append(sig, byte(txscript.SigHashAll)),
-			pubKey.SerializeCompressed(),
+		if err := engine.Execute(); err != nil {
+			return fmt.Errorf("signature of input %d is invalid: %w", i, err)
 		}
-		input.Witness = witness
 	}
 	return nil
 }
@@ -114,6 +253,21 @@ func (tx *Transaction) Serialize() (string, error) {
 	return fmt.Sprintf("%x", buf.Bytes()), nil
 }
 
+// TransactionID returns the txid of a serialized transaction
+func TransactionID(signedTx string) (string, error) {
+	raw, err := hex.DecodeString(signedTx)
+	if err != nil {
+		return "", fmt.Errorf("invalid transaction hex: %w", err)
+	}
+
+	var wireTx wire.MsgTx
+	if err := wireTx.Deserialize(bytes.NewReader(raw)); err != nil {
+		return "", fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	return wireTx.TxHash().String(), nil
+}
+
 // toWireTx converts to wire.MsgTx
 func (tx *Transaction) toWireTx() *wire.MsgTx {
 	wireTx := wire.NewMsgTx(tx.Version)
@@ -132,48 +286,201 @@ func (tx *Transaction) CalculateFee(inputValue int64, outputValue int64) int64 {
 	return inputValue - outputValue
 }
 
-// EstimateFee estimates the transaction fee based on size
-func (tx *Transaction) EstimateFee(inputCount int, outputCount int, feeRate int64) int64 {
-	// Estimate transaction size
-	// Base size: 4 bytes version + 4 bytes locktime
-	baseSize := 8
+// Placeholder sizes in bytes of what signing adds to an input, at their
+// largest so a fee is never short
+const (
+	ecdsaSignatureSize   = 73 // DER signature and sighash type
+	schnorrSignatureSize = 64 // SigHashDefault adds no sighash byte
+	publicKeySize        = 33 // compressed
+)
+
+// VirtualSize returns the virtual size in vbytes the transaction will have
+// once signed, its weight over 4 rounded up. Witness data weighs a quarter of
+// the rest. Inputs are measured with placeholder signatures for the type of
+// address their UTXO was paid to, utxos lines up with the inputs.
+func (tx *Transaction) VirtualSize(utxos []*UTXO) (int64, error) {
+	if len(utxos) < len(tx.Inputs) {
+		return 0, fmt.Errorf("insufficient UTXOs for sizing")
+	}
+
+	wireTx := wire.NewMsgTx(tx.Version)
+	for i, input := range tx.Inputs {
+		wireTx.AddTxIn(signedInput(input, utxos[i].Address))
+	}
+	for _, output := range tx.Outputs {
+		wireTx.AddTxOut(output)
+	}
+	wireTx.LockTime = tx.LockTime
+
+	weight := wireTx.SerializeSizeStripped()*3 + wireTx.SerializeSize()
+	return int64((weight + 3) / 4), nil
+}
+
+// signedInput returns a copy of an input with placeholders the size of the
+// signature script and witness that spending an output of address needs
+func signedInput(input *wire.TxIn, address btcutil.Address) *wire.TxIn {
+	sized := wire.NewTxIn(&input.PreviousOutPoint, nil, nil)
+	sized.Sequence = input.Sequence
+
+	switch address.(type) {
+	case *btcutil.AddressTaproot:
+		sized.Witness = wire.TxWitness{make([]byte, schnorrSignatureSize)}
+	case *btcutil.AddressPubKeyHash:
+		sized.SignatureScript = make([]byte, 1+ecdsaSignatureSize+1+publicKeySize)
+	case *btcutil.AddressScriptHash:
+		// Nested SegWit pushes the 22 byte P2WPKH redeem script
+		sized.SignatureScript = make([]byte, 1+22)
+		sized.Witness = wire.TxWitness{make([]byte, ecdsaSignatureSize), make([]byte, publicKeySize)}
+	default:
+		sized.Witness = wire.TxWitness{make([]byte, ecdsaSignatureSize), make([]byte, publicKeySize)}
+	}
+	return sized
+}
 
-	// Input size: 32 bytes prev hash + 4 bytes index + 1 byte script length + 0 bytes script + 4 bytes sequence
-	inputSize := 41
+// EffectiveFeeRate returns the fee rate in sat/vB a fee pays for a virtual
+// size, above the target rate when dust change was left to the fee
+func EffectiveFeeRate(fee, vsize int64) decimal.Decimal {
+	if vsize <= 0 {
+		return decimal.Zero
+	}
+	return decimal.NewFromInt(fee).Div(decimal.NewFromInt(vsize))
+}
 
-	// Output size: 8 bytes value + 1 byte script length + 25 bytes P2WPKH script
-	outputSize := 34
+// SettleFee adds the change output to a transaction whose inputs and
+// payments are in place, and works out the fee at feeRate sat/vB from the
+// virtual size. spent is the total of the payments. Change below the dust
+// threshold isn't worth its output: it is dropped, the size measured again
+// and the leftover goes to the fee. A fee above totalInput - spent means the
+// inputs can't pay it. Returns the fee, the change (0 if there is no change
+// output) and the virtual size.
+func (tx *Transaction) SettleFee(utxos []*UTXO, totalInput, spent, feeRate int64, changeAddress btcutil.Address) (fee, change, vsize int64, err error) {
+	payments := len(tx.Outputs)
+	if err := tx.AddOutput(totalInput-spent, changeAddress); err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to add change output: %w", err)
+	}
 
-	// Witness size: 1 byte witness count + 1 byte signature length + 73 bytes signature + 1 byte pubkey length + 33 bytes pubkey
-	witnessSize := 109
+	for {
+		vsize, err = tx.VirtualSize(utxos)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		fee = vsize * feeRate
 
-	totalSize := baseSize + (inputCount * inputSize) + (outputCount * outputSize) + (inputCount * witnessSize)
+		if len(tx.Outputs) == payments {
+			if leftover := totalInput - spent; leftover > fee {
+				fee = leftover
+			}
+			return fee, 0, vsize, nil
+		}
 
-	// Convert to virtual bytes (weight units / 4)
-	virtualBytes := totalSize
+		change = totalInput - spent - fee
+		if change >= DustThreshold {
+			tx.Outputs[len(tx.Outputs)-1].Value = change
+			return fee, change, vsize, nil
+		}
+		tx.Outputs = tx.Outputs[:payments]
+	}
+}
 
-	return int64(virtualBytes) * feeRate
+// NetParams returns the Bitcoin network of the current network: mainnet, or
+// regtest on test networks with a regtest node
+func NetParams() *chaincfg.Params {
+	return config.ActiveNetwork().BitcoinParams()
 }
 
 // ParseAddress parses a Bitcoin address
 func ParseAddress(address string) (btcutil.Address, error) {
-	return btcutil.DecodeAddress(address, &chaincfg.MainNetParams)
+	return btcutil.DecodeAddress(address, NetParams())
 }
 
 // SatoshisToBTC converts satoshis to BTC
-func SatoshisToBTC(satoshis int64) float64 {
-	return float64(satoshis) / 100000000.0
+func SatoshisToBTC(satoshis int64) decimal.Decimal {
+	return decimal.New(satoshis, -Decimals)
 }
 
-// BTCToSatoshis converts BTC to satoshis
-func BTCToSatoshis(btc float64) int64 {
-	return int64(btc * 100000000.0)
+// BTCToSatoshis converts BTC to satoshis, dropping fractions of a satoshi
+func BTCToSatoshis(btc decimal.Decimal) int64 {
+	return btc.Shift(Decimals).IntPart()
+}
+
+// ParseBTC parses an amount of BTC typed by the user into satoshis
+func ParseBTC(amount string) (int64, error) {
+	satoshis, err := chains.ParseAmount(amount, Decimals)
+	if err != nil {
+		return 0, err
+	}
+	if !satoshis.IsInt64() {
+		return 0, fmt.Errorf("%s BTC is more than can exist", strings.TrimSpace(amount))
+	}
+	return satoshis.Int64(), nil
 }
 
 // FormatBalance formats balance in a human-readable format
 func FormatBalance(satoshis int64) string {
-	btc := SatoshisToBTC(satoshis)
-	return fmt.Sprintf("%.8f BTC", btc)
+	return SatoshisToBTC(satoshis).StringFixed(Decimals) + " BTC"
+}
+
+// ValidateAmount checks that an amount sent to a recipient can be relayed.
+// The dust threshold depends on the address type, without an address the
+// highest one applies.
+func ValidateAmount(satoshis int64, address btcutil.Address) error {
+	if satoshis <= 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+	limit := int64(DustThreshold)
+	if address != nil {
+		limit = DustLimit(address)
+	}
+	if satoshis < limit {
+		return fmt.Errorf("amount of %d satoshis is below the dust threshold of %d satoshis (%s). Nodes refuse to relay outputs this small because spending them would cost more in fees than they are worth",
+			satoshis, limit, FormatBalance(limit))
+	}
+	return nil
+}
+
+// DustLimit returns the smallest value in satoshis of a relayed output to
+// address, e.g. 294 for P2WPKH, 330 for P2TR and 546 for P2PKH
+func DustLimit(address btcutil.Address) int64 {
+	script, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return DustThreshold
+	}
+	return dustLimit(script)
+}
+
+// dustLimit follows Bitcoin Core: the output plus the input spending it, 67
+// vbytes for witness programs and 148 otherwise, at the dust relay fee rate
+func dustLimit(script []byte) int64 {
+	inputSize := int64(148)
+	if txscript.IsWitnessProgram(script) {
+		inputSize = 67
+	}
+	outputSize := int64(wire.NewTxOut(0, script).SerializeSize())
+	return (outputSize + inputSize) * dustRelayFeeRate
+}
+
+// CheckStandard checks the rules nodes relay transactions by: the weight once
+// signed (vsize as returned by VirtualSize), no dust outputs and at most one
+// OP_RETURN output
+func (tx *Transaction) CheckStandard(vsize int64) error {
+	if vsize*4 > MaxStandardWeight {
+		return fmt.Errorf("the transaction would weigh %d, more than the %d nodes relay. Send a smaller amount or consolidate UTXOs first", vsize*4, MaxStandardWeight)
+	}
+
+	dataOutputs := 0
+	for i, output := range tx.Outputs {
+		if txscript.GetScriptClass(output.PkScript) == txscript.NullDataTy {
+			dataOutputs++
+			if dataOutputs > 1 {
+				return fmt.Errorf("only one OP_RETURN output is relayed per transaction")
+			}
+			continue
+		}
+		if limit := dustLimit(output.PkScript); output.Value < limit {
+			return fmt.Errorf("output %d of %d satoshis is below the dust threshold of %d satoshis", i, output.Value, limit)
+		}
+	}
+	return nil
 }
 
 // ValidateAddress validates a Bitcoin address
@@ -185,15 +492,11 @@ func ValidateAddress(address string) error {
 // CreateP2WPKHAddress creates a P2WPKH address from public key
 func CreateP2WPKHAddress(publicKey *btcec.PublicKey) (btcutil.Address, error) {
 	pubKeyHash := btcutil.Hash160(publicKey.SerializeCompressed())
-	return btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, &chaincfg.MainNetParams)
+	return btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, NetParams())
 }
 
-// UpdateChangeOutput updates the value of the last output in the transaction (change output)
-func (tx *Transaction) UpdateChangeOutput(value int64) error {
-	if len(tx.Outputs) < 2 {
-		return fmt.Errorf("no change output to update")
-	}
-	// Last output is assumed to be the change output
-	tx.Outputs[len(tx.Outputs)-1].Value = value
-	return nil
+// CreateP2TRAddress creates a Taproot key path address from an internal public key
+func CreateP2TRAddress(publicKey *btcec.PublicKey) (btcutil.Address, error) {
+	outputKey := txscript.ComputeTaprootKeyNoScript(publicKey)
+	return btcutil.NewAddressTaproot(schnorr.SerializePubKey(outputKey), NetParams())
 }
@@ -0,0 +1,56 @@
+// Package chains describes what odyssey does with any blockchain, so that
+// commands work with a Chain instead of switching on the chain's name. The
+// ethereum, bitcoin and solana packages implement it.
+package chains
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/chinmay1088/odyssey/api"
+)
+
+// Chain is a blockchain the wallet holds a native asset on. Amounts are in
+// the smallest unit of the asset: wei, satoshis or lamports.
+type Chain interface {
+	// Name is the lowercase name, e.g. ethereum
+	Name() string
+	// Symbol is the ticker of the native asset, e.g. ETH
+	Symbol() string
+	// Decimals is the number of decimals of the smallest unit
+	Decimals() int32
+
+	// Address returns the wallet's receiving address
+	Address() (string, error)
+	// Balance returns the wallet's balance of the native asset
+	Balance(ctx context.Context) (*big.Int, error)
+	// History returns a page of the transactions of an address, usually one
+	// from Address. cursor is empty for the newest page.
+	History(ctx context.Context, address string, limit int, cursor string) (*api.TransactionPage, error)
+	// FeeEstimate returns the network fee of a plain transfer
+	FeeEstimate(ctx context.Context) (*big.Int, error)
+
+	// BuildTransfer prepares an unsigned transfer of amount to an address at
+	// the current fees. Whether the balance covers it is up to the caller.
+	BuildTransfer(ctx context.Context, to string, amount *big.Int) (*Transfer, error)
+	// Sign signs a transfer with the wallet's keys
+	Sign(ctx context.Context, transfer *Transfer) error
+	// Broadcast sends a signed transfer and returns its hash
+	Broadcast(ctx context.Context, transfer *Transfer) (string, error)
+}
+
+// Transfer is a payment of a chain's native asset, built unsigned and signed
+// before it is broadcast
+type Transfer struct {
+	From   string
+	To     string
+	Amount *big.Int
+	Fee    *big.Int // network fee, the most it can cost on Ethereum
+
+	// Tx is the chain's own transaction, e.g. *ethereum.Transaction, for
+	// details a command shows or changes before signing
+	Tx any
+
+	Signed string // signed transaction as broadcast, set by Sign
+	ID     string // transaction hash, set by Sign
+}
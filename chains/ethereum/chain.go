@@ -0,0 +1,267 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ethereumGasPriceBump is the percent added to the node's gas price on
+// Ethereum for faster inclusion
+const ethereumGasPriceBump = 20
+
+// ethereumBlockTime is the time between Ethereum blocks
+const ethereumBlockTime = 12 * time.Second
+
+// Chain is Ethereum, or another EVM chain paid from the Ethereum address
+type Chain struct {
+	manager *wallet.Manager
+	client  *api.Client   // talks to the chain's own node
+	evm     *api.EVMChain // nil for Ethereum
+
+	// GasTier is the gas price tier transfers pay, the configured one if
+	// empty
+	GasTier string
+}
+
+// NewChain returns Ethereum on the current network
+func NewChain(manager *wallet.Manager, client *api.Client) *Chain {
+	return &Chain{manager: manager, client: client}
+}
+
+// NewEVMChain returns another EVM chain, everything but prices goes to its node
+func NewEVMChain(manager *wallet.Manager, client *api.Client, evm *api.EVMChain) *Chain {
+	return &Chain{manager: manager, client: client.ForEVMChain(evm), evm: evm}
+}
+
+// Name returns ethereum or the name of the EVM chain
+func (c *Chain) Name() string {
+	if c.evm != nil {
+		return c.evm.Name
+	}
+	return "ethereum"
+}
+
+// Symbol returns ETH or the native asset of the EVM chain
+func (c *Chain) Symbol() string {
+	if c.evm != nil {
+		return c.evm.Symbol
+	}
+	return "ETH"
+}
+
+// Decimals returns 18, every EVM chain counts in wei
+func (c *Chain) Decimals() int32 {
+	return Decimals
+}
+
+// EVM returns the EVM chain, nil for Ethereum
+func (c *Chain) EVM() *api.EVMChain {
+	return c.evm
+}
+
+// Client returns the client of the chain's node
+func (c *Chain) Client() *api.Client {
+	return c.client
+}
+
+// Address returns the Ethereum address, it is the same on every EVM chain
+func (c *Chain) Address() (string, error) {
+	address, err := c.manager.GetEthereumAddress()
+	if err != nil {
+		return "", err
+	}
+	return address.Hex(), nil
+}
+
+// Balance returns the balance in wei
+func (c *Chain) Balance(ctx context.Context) (*big.Int, error) {
+	address, err := c.Address()
+	if err != nil {
+		return nil, err
+	}
+	return c.client.GetEthereumBalance(ctx, address)
+}
+
+// History returns a page of the transactions of an address
+func (c *Chain) History(ctx context.Context, address string, limit int, cursor string) (*api.TransactionPage, error) {
+	return c.client.GetEthereumTransactions(ctx, address, limit, cursor)
+}
+
+// GasPrice returns the gas price of the chain's gas tier, agreed by the
+// sources of the gas estimate, raised to the minimum the chain's nodes accept
+func (c *Chain) GasPrice(ctx context.Context) (*big.Int, error) {
+	estimate, err := c.GasEstimate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice := estimate.Price(c.GasPriceTier())
+	if minimum := c.MinGasPrice(); minimum != nil && gasPrice.Cmp(minimum) < 0 {
+		gasPrice = minimum
+	}
+	return gasPrice, nil
+}
+
+// GasEstimate returns the slow, normal and fast gas prices of the chain
+func (c *Chain) GasEstimate(ctx context.Context) (*api.GasEstimate, error) {
+	bump := int64(ethereumGasPriceBump)
+	if c.evm != nil {
+		bump = c.evm.GasPriceBump
+	}
+	estimate, err := c.client.GetGasEstimate(ctx, bump)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	return estimate, nil
+}
+
+// GasPriceTier returns GasTier, or the configured tier if it is empty
+func (c *Chain) GasPriceTier() string {
+	if c.GasTier != "" {
+		return c.GasTier
+	}
+	if cfg, err := config.Load(); err == nil {
+		return cfg.GasTier()
+	}
+	return config.GasTierNormal
+}
+
+// BlockTime returns the average time between blocks of the chain
+func (c *Chain) BlockTime() time.Duration {
+	if c.evm != nil {
+		return c.evm.BlockTime
+	}
+	return ethereumBlockTime
+}
+
+// MinGasPrice returns the lowest gas price the chain's nodes accept, nil if
+// they take any
+func (c *Chain) MinGasPrice() *big.Int {
+	if c.evm == nil {
+		return nil
+	}
+	return big.NewInt(c.evm.MinGasPrice)
+}
+
+// FeeEstimate returns the fee of a plain transfer at the current gas price
+func (c *Chain) FeeEstimate(ctx context.Context) (*big.Int, error) {
+	gasPrice, err := c.GasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(EstimateGasLimit(nil))), nil
+}
+
+// BuildTransfer prepares a transfer with the next free nonce of the address,
+// the gas limit estimated by the node and the gas price of the tier. Tx is an
+// *ethereum.Transaction.
+func (c *Chain) BuildTransfer(ctx context.Context, to string, amount *big.Int) (*chains.Transfer, error) {
+	recipient, err := ParseAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	if err := ValidateAmount(amount, nil); err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	from, err := c.Address()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender address: %w", err)
+	}
+
+	pending, err := c.client.GetEthereumPendingNonce(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	nonce, err := c.manager.NextNonce(c.Name(), c.manager.GetCurrentNetwork(), from, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice, err := c.GasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gasLimit, err := c.client.GetEthereumGasEstimate(ctx, from, recipient.Hex(), amount, nil)
+	if err != nil {
+		// Fall back to the basic estimator
+		gasLimit = EstimateGasLimit(nil)
+	}
+
+	tx := NewTransaction(nonce, recipient, amount, gasLimit, gasPrice, nil)
+	if c.evm != nil {
+		tx.ChainID = c.evm.ChainID(c.manager.IsTestnet())
+	}
+	if err := ValidateTransaction(tx); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	return &chains.Transfer{
+		From:   from,
+		To:     recipient.Hex(),
+		Amount: amount,
+		Fee:    MaxFee(tx),
+		Tx:     tx,
+	}, nil
+}
+
+// Sign signs a transfer with the Ethereum key. Its fee is worked out again
+// from the transaction, on OP Stack chains including the L1 data fee of the
+// signed bytes.
+func (c *Chain) Sign(ctx context.Context, transfer *chains.Transfer) error {
+	tx, ok := transfer.Tx.(*Transaction)
+	if !ok {
+		return fmt.Errorf("not an Ethereum transfer")
+	}
+
+	privateKey, err := c.manager.GetEthereumKey()
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	signedTx, err := SignTransaction(tx, privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	txID, err := TransactionHash(signedTx)
+	if err != nil {
+		return err
+	}
+
+	fee := MaxFee(tx)
+	if c.evm != nil && c.evm.L1DataFee {
+		raw, err := hexutil.Decode(signedTx)
+		if err != nil {
+			return fmt.Errorf("invalid transaction hex: %w", err)
+		}
+		l1Fee, err := c.client.GetL1DataFee(ctx, raw)
+		if err != nil {
+			return err
+		}
+		fee.Add(fee, l1Fee)
+	}
+
+	transfer.Signed = signedTx
+	transfer.ID = txID
+	transfer.Fee = fee
+	return nil
+}
+
+// Broadcast sends a signed transfer to the chain's node
+func (c *Chain) Broadcast(ctx context.Context, transfer *chains.Transfer) (string, error) {
+	return c.client.SendEthereumTransaction(ctx, transfer.Signed)
+}
+
+// MaxFee returns the most a transaction can pay for gas
+func MaxFee(tx *Transaction) *big.Int {
+	return new(big.Int).Mul(tx.GasPrice, new(big.Int).SetUint64(tx.GasLimit))
+}
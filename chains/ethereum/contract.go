@@ -0,0 +1,142 @@
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ParseContractABI parses a contract ABI in JSON form
+func ParseContractABI(abiJSON string) (abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("invalid ABI: %w", err)
+	}
+	return parsed, nil
+}
+
+// EncodeContractCall builds the call data for a method, converting command
+// line arguments to the types the method expects
+func EncodeContractCall(parsed abi.ABI, method string, args []string) ([]byte, error) {
+	m, ok := parsed.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found in ABI", method)
+	}
+	if len(args) != len(m.Inputs) {
+		return nil, fmt.Errorf("%s expects %d argument(s): %s", method, len(m.Inputs), m.Sig)
+	}
+
+	values := make([]interface{}, len(args))
+	for i, input := range m.Inputs {
+		value, err := parseContractArgument(input.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %d (%s): %w", i+1, input.Type.String(), err)
+		}
+		values[i] = value
+	}
+
+	data, err := parsed.Pack(method, values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+
+	return data, nil
+}
+
+// DecodeContractResult decodes the return values of a method call
+func DecodeContractResult(parsed abi.ABI, method string, result []byte) ([]interface{}, error) {
+	values, err := parsed.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return values, nil
+}
+
+// parseContractArgument converts a string to the Go type the ABI encoder expects
+func parseContractArgument(t abi.Type, arg string) (interface{}, error) {
+	switch t.T {
+	case abi.AddressTy:
+		return ParseAddress(arg)
+
+	case abi.BoolTy:
+		return strconv.ParseBool(arg)
+
+	case abi.StringTy:
+		return arg, nil
+
+	case abi.BytesTy:
+		return hexutil.Decode(arg)
+
+	case abi.FixedBytesTy:
+		raw, err := hexutil.Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(raw) > t.Size {
+			return nil, fmt.Errorf("expected at most %d bytes", t.Size)
+		}
+		value := reflect.New(t.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(common.RightPadBytes(raw, t.Size)))
+		return value.Interface(), nil
+
+	case abi.IntTy, abi.UintTy:
+		number, ok := new(big.Int).SetString(arg, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid number: %s", arg)
+		}
+		if t.T == abi.UintTy && number.Sign() < 0 {
+			return nil, fmt.Errorf("value must not be negative")
+		}
+		bits := t.Size
+		if t.T == abi.IntTy {
+			bits-- // sign bit
+		}
+		if number.BitLen() > bits {
+			return nil, fmt.Errorf("value does not fit in %s", t.String())
+		}
+
+		// Sizes up to 64 bits use native Go integers
+		goType := t.GetType()
+		if goType.Kind() == reflect.Ptr {
+			return number, nil
+		}
+		value := reflect.New(goType).Elem()
+		if t.T == abi.UintTy {
+			value.SetUint(number.Uint64())
+		} else {
+			value.SetInt(number.Int64())
+		}
+		return value.Interface(), nil
+
+	default:
+		return nil, fmt.Errorf("arguments of type %s are not supported", t.String())
+	}
+}
+
+// FormatContractValue formats a decoded return value for display
+func FormatContractValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		return v.String()
+	}
+
+	// Fixed size byte arrays
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		raw := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(raw), rv)
+		return hexutil.Encode(raw)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
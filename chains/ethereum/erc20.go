@@ -0,0 +1,29 @@
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// erc20ABI is the approve method of the ERC-20 standard
+const erc20ABI = `[{"name":"approve","type":"function","stateMutability":"nonpayable","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}]`
+
+// EncodeERC20Approve builds the call data allowing spender to transfer amount
+// of a token on behalf of the sender
+func EncodeERC20Approve(spender common.Address, amount *big.Int) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(erc20ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ERC-20 ABI: %w", err)
+	}
+
+	data, err := parsed.Pack("approve", spender, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode approve: %w", err)
+	}
+
+	return data, nil
+}
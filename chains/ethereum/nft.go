@@ -0,0 +1,85 @@
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NFT token standards
+const (
+	StandardERC721  = "ERC721"
+	StandardERC1155 = "ERC1155"
+)
+
+// ERC-165 interface IDs of the NFT standards
+var (
+	InterfaceERC721  = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	InterfaceERC1155 = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+)
+
+// nftABI holds the methods used to inspect and transfer ERC-721 and ERC-1155
+// tokens. Both standards name their transfer safeTransferFrom, so the ERC-1155
+// variant is packed with its own ABI below.
+const nftABI = `[
+	{"name":"supportsInterface","type":"function","stateMutability":"view","inputs":[{"name":"interfaceId","type":"bytes4"}],"outputs":[{"name":"","type":"bool"}]},
+	{"name":"ownerOf","type":"function","stateMutability":"view","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"address"}]},
+	{"name":"balanceOf","type":"function","stateMutability":"view","inputs":[{"name":"account","type":"address"},{"name":"id","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"safeTransferFrom","type":"function","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[]}
+]`
+
+const erc1155TransferABI = `[{"name":"safeTransferFrom","type":"function","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"id","type":"uint256"},{"name":"amount","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[]}]`
+
+// packNFTCall encodes a call against one of the NFT ABIs
+func packNFTCall(abiJSON, method string, args ...interface{}) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse NFT ABI: %w", err)
+	}
+
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+	return data, nil
+}
+
+// EncodeSupportsInterface builds the ERC-165 check for an interface ID
+func EncodeSupportsInterface(interfaceID [4]byte) ([]byte, error) {
+	return packNFTCall(nftABI, "supportsInterface", interfaceID)
+}
+
+// EncodeOwnerOf builds the ERC-721 owner lookup of a token
+func EncodeOwnerOf(tokenID *big.Int) ([]byte, error) {
+	return packNFTCall(nftABI, "ownerOf", tokenID)
+}
+
+// EncodeERC1155BalanceOf builds the ERC-1155 balance lookup of a token
+func EncodeERC1155BalanceOf(owner common.Address, tokenID *big.Int) ([]byte, error) {
+	return packNFTCall(nftABI, "balanceOf", owner, tokenID)
+}
+
+// EncodeNFTTransfer builds the safeTransferFrom call of a token. amount is
+// only used for ERC-1155 tokens.
+func EncodeNFTTransfer(standard string, from, to common.Address, tokenID, amount *big.Int) ([]byte, error) {
+	switch standard {
+	case StandardERC721:
+		return packNFTCall(nftABI, "safeTransferFrom", from, to, tokenID)
+	case StandardERC1155:
+		return packNFTCall(erc1155TransferABI, "safeTransferFrom", from, to, tokenID, amount, []byte{})
+	default:
+		return nil, fmt.Errorf("unsupported token standard: %s", standard)
+	}
+}
+
+// ParseTokenID parses a token ID given in decimal or as 0x prefixed hex
+func ParseTokenID(value string) (*big.Int, error) {
+	tokenID, ok := new(big.Int).SetString(value, 0)
+	if !ok || tokenID.Sign() < 0 {
+		return nil, fmt.Errorf("invalid token ID: %s", value)
+	}
+	return tokenID, nil
+}
@@ -0,0 +1,30 @@
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// routerABI is the deposit method of the THORChain router contract
+const routerABI = `[{"name":"depositWithExpiry","type":"function","stateMutability":"payable","inputs":[{"name":"vault","type":"address"},{"name":"asset","type":"address"},{"name":"amount","type":"uint256"},{"name":"memo","type":"string"},{"name":"expiration","type":"uint256"}],"outputs":[]}]`
+
+// EncodeRouterDeposit builds the call data for depositing native ETH into a
+// THORChain vault through its router
+func EncodeRouterDeposit(vault common.Address, amount *big.Int, memo string, expiry int64) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(routerABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse router ABI: %w", err)
+	}
+
+	// The zero address denotes native ETH
+	data, err := parsed.Pack("depositWithExpiry", vault, common.Address{}, amount, memo, big.NewInt(expiry))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode router deposit: %w", err)
+	}
+
+	return data, nil
+}
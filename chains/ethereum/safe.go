@@ -0,0 +1,207 @@
+package ethereum
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// safeABI holds the Safe contract methods used by the wallet
+const safeABI = `[
+{"name":"nonce","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+{"name":"getThreshold","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+{"name":"getOwners","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address[]"}]},
+{"name":"execTransaction","type":"function","stateMutability":"payable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},{"name":"signatures","type":"bytes"}],"outputs":[{"name":"success","type":"bool"}]}
+]`
+
+// EIP-712 type hashes used by Safe contracts v1.3.0 and later
+var (
+	safeDomainTypeHash = crypto.Keccak256([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	safeTxTypeHash     = crypto.Keccak256([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+)
+
+// SafeTransaction is a transaction executed by a Safe once enough owners signed it
+type SafeTransaction struct {
+	To             common.Address
+	Value          *big.Int
+	Data           []byte
+	Operation      uint8 // 0 = call, 1 = delegatecall
+	SafeTxGas      *big.Int
+	BaseGas        *big.Int
+	GasPrice       *big.Int
+	GasToken       common.Address
+	RefundReceiver common.Address
+	Nonce          *big.Int
+}
+
+// NewSafeTransfer creates a Safe transaction sending ETH without refunds
+func NewSafeTransfer(to common.Address, value *big.Int, nonce uint64) *SafeTransaction {
+	return &SafeTransaction{
+		To:        to,
+		Value:     value,
+		SafeTxGas: big.NewInt(0),
+		BaseGas:   big.NewInt(0),
+		GasPrice:  big.NewInt(0),
+		Nonce:     new(big.Int).SetUint64(nonce),
+	}
+}
+
+// SafeTransactionHash returns the EIP-712 hash that Safe owners sign
+func SafeTransactionHash(safe common.Address, chainID *big.Int, tx *SafeTransaction) common.Hash {
+	domainSeparator := crypto.Keccak256(
+		safeDomainTypeHash,
+		common.LeftPadBytes(chainID.Bytes(), 32),
+		common.LeftPadBytes(safe.Bytes(), 32),
+	)
+
+	structHash := crypto.Keccak256(
+		safeTxTypeHash,
+		common.LeftPadBytes(tx.To.Bytes(), 32),
+		common.LeftPadBytes(tx.Value.Bytes(), 32),
+		crypto.Keccak256(tx.Data),
+		common.LeftPadBytes([]byte{tx.Operation}, 32),
+		common.LeftPadBytes(tx.SafeTxGas.Bytes(), 32),
+		common.LeftPadBytes(tx.BaseGas.Bytes(), 32),
+		common.LeftPadBytes(tx.GasPrice.Bytes(), 32),
+		common.LeftPadBytes(tx.GasToken.Bytes(), 32),
+		common.LeftPadBytes(tx.RefundReceiver.Bytes(), 32),
+		common.LeftPadBytes(tx.Nonce.Bytes(), 32),
+	)
+
+	return common.BytesToHash(crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash))
+}
+
+// SignSafeTransactionHash signs a Safe transaction hash as an owner. Safe
+// expects v to be 27 or 28 for signatures over the EIP-712 hash.
+func SignSafeTransactionHash(hash common.Hash, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	signature, err := crypto.Sign(hash.Bytes(), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign Safe transaction: %w", err)
+	}
+	signature[64] += 27
+	return signature, nil
+}
+
+// RecoverSafeSigner returns the owner that produced an ECDSA signature over a
+// Safe transaction hash. Contract and pre-approved hash signatures (v of 0 or
+// 1) cannot be recovered and return an error.
+func RecoverSafeSigner(hash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != 65 {
+		return common.Address{}, fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+
+	sig := append([]byte(nil), signature...)
+	digest := hash.Bytes()
+	switch v := sig[64]; {
+	case v > 30:
+		// eth_sign signatures are made over the prefixed message hash
+		digest = accounts.TextHash(hash.Bytes())
+		sig[64] = v - 4 - 27
+	case v >= 27:
+		sig[64] = v - 27
+	default:
+		return common.Address{}, fmt.Errorf("unsupported signature type")
+	}
+
+	publicKey, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %w", err)
+	}
+
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// PackSafeSignatures concatenates owner signatures sorted by owner address, as
+// required by execTransaction
+func PackSafeSignatures(signatures map[common.Address][]byte) []byte {
+	owners := make([]common.Address, 0, len(signatures))
+	for owner := range signatures {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		return bytes.Compare(owners[i].Bytes(), owners[j].Bytes()) < 0
+	})
+
+	var packed []byte
+	for _, owner := range owners {
+		packed = append(packed, signatures[owner]...)
+	}
+	return packed
+}
+
+// EncodeSafeExecTransaction builds the call data for executing a Safe transaction
+func EncodeSafeExecTransaction(tx *SafeTransaction, signatures []byte) ([]byte, error) {
+	parsed, err := parseSafeABI()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parsed.Pack("execTransaction", tx.To, tx.Value, tx.Data, tx.Operation,
+		tx.SafeTxGas, tx.BaseGas, tx.GasPrice, tx.GasToken, tx.RefundReceiver, signatures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode execTransaction: %w", err)
+	}
+
+	return data, nil
+}
+
+// EncodeSafeCall builds the call data for a Safe view method without arguments
+func EncodeSafeCall(method string) ([]byte, error) {
+	parsed, err := parseSafeABI()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parsed.Pack(method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+
+	return data, nil
+}
+
+// DecodeSafeUint decodes the result of nonce or getThreshold
+func DecodeSafeUint(method string, result []byte) (*big.Int, error) {
+	parsed, err := parseSafeABI()
+	if err != nil {
+		return nil, err
+	}
+
+	var value *big.Int
+	if err := parsed.UnpackIntoInterface(&value, method, result); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", method, err)
+	}
+
+	return value, nil
+}
+
+// DecodeSafeOwners decodes the result of getOwners
+func DecodeSafeOwners(result []byte) ([]common.Address, error) {
+	parsed, err := parseSafeABI()
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []common.Address
+	if err := parsed.UnpackIntoInterface(&owners, "getOwners", result); err != nil {
+		return nil, fmt.Errorf("failed to decode getOwners: %w", err)
+	}
+
+	return owners, nil
+}
+
+func parseSafeABI() (abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(safeABI))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse Safe ABI: %w", err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,231 @@
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Liquid staking providers
+const (
+	StakingLido       = "lido"       // stETH, rebasing 1:1 with ETH
+	StakingRocketPool = "rocketpool" // rETH, worth more ETH as rewards accrue
+)
+
+// Mainnet contracts of the staking providers. Rocket Pool upgrades its
+// deposit pool, so it is looked up in Rocket Pool's storage contract.
+var (
+	LidoStETH           = common.HexToAddress("0xae7ab96520DE3A18E5e111B5EaAb095312D7fE84")
+	LidoWithdrawalQueue = common.HexToAddress("0x889edC2eDab5f40e902b864aD4d7AdE8E412F9B1")
+	RocketPoolStorage   = common.HexToAddress("0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46")
+	RocketPoolRETH      = common.HexToAddress("0xae78736Cd615f374D3085123A210448E74Fc6393")
+)
+
+// Limits of one Lido withdrawal request in stETH wei, larger withdrawals are
+// split into several requests
+var (
+	LidoMinWithdrawal = big.NewInt(100)
+	LidoMaxWithdrawal = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+)
+
+// RocketPoolMinDeposit is the smallest deposit Rocket Pool accepts, 0.01 ETH
+var RocketPoolMinDeposit = big.NewInt(1e16)
+
+// LidoWithdrawal is the state of a Lido withdrawal request
+type LidoWithdrawal struct {
+	ID            *big.Int
+	AmountOfStETH *big.Int
+	Owner         common.Address
+	RequestedAt   time.Time
+	IsFinalized   bool // ETH is set aside and can be claimed
+	IsClaimed     bool
+}
+
+// stakingABI holds the methods of the Lido and Rocket Pool contracts used to
+// stake, look up staked balances and withdraw
+const stakingABI = `[
+	{"name":"submit","type":"function","stateMutability":"payable","inputs":[{"name":"_referral","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"getCurrentStakeLimit","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"requestWithdrawals","type":"function","stateMutability":"nonpayable","inputs":[{"name":"_amounts","type":"uint256[]"},{"name":"_owner","type":"address"}],"outputs":[{"name":"requestIds","type":"uint256[]"}]},
+	{"name":"getWithdrawalRequests","type":"function","stateMutability":"view","inputs":[{"name":"_owner","type":"address"}],"outputs":[{"name":"requestsIds","type":"uint256[]"}]},
+	{"name":"getWithdrawalStatus","type":"function","stateMutability":"view","inputs":[{"name":"_requestIds","type":"uint256[]"}],"outputs":[{"name":"statuses","type":"tuple[]","components":[{"name":"amountOfStETH","type":"uint256"},{"name":"amountOfShares","type":"uint256"},{"name":"owner","type":"address"},{"name":"timestamp","type":"uint256"},{"name":"isFinalized","type":"bool"},{"name":"isClaimed","type":"bool"}]}]},
+	{"name":"claimWithdrawal","type":"function","stateMutability":"nonpayable","inputs":[{"name":"_requestId","type":"uint256"}],"outputs":[]},
+	{"name":"getAddress","type":"function","stateMutability":"view","inputs":[{"name":"_key","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"name":"deposit","type":"function","stateMutability":"payable","inputs":[],"outputs":[]},
+	{"name":"getMaximumDepositAmount","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"burn","type":"function","stateMutability":"nonpayable","inputs":[{"name":"_rethAmount","type":"uint256"}],"outputs":[]},
+	{"name":"getEthValue","type":"function","stateMutability":"view","inputs":[{"name":"_rethAmount","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"getRethValue","type":"function","stateMutability":"view","inputs":[{"name":"_ethAmount","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"getTotalCollateral","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+// packStakingCall encodes a call of one of the staking contracts
+func packStakingCall(method string, args ...interface{}) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(stakingABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse staking ABI: %w", err)
+	}
+
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+	return data, nil
+}
+
+// EncodeLidoSubmit builds the Lido deposit of the ETH sent with it, minting
+// as much stETH
+func EncodeLidoSubmit() ([]byte, error) {
+	return packStakingCall("submit", common.Address{})
+}
+
+// EncodeLidoStakeLimit builds the lookup of how much ETH Lido takes before
+// its staking rate limit is reached
+func EncodeLidoStakeLimit() ([]byte, error) {
+	return packStakingCall("getCurrentStakeLimit")
+}
+
+// EncodeLidoRequestWithdrawals builds withdrawal requests of stETH, at most
+// LidoMaxWithdrawal each, that owner claims once they are finalized. The
+// withdrawal queue must be approved to take the stETH first.
+func EncodeLidoRequestWithdrawals(amounts []*big.Int, owner common.Address) ([]byte, error) {
+	return packStakingCall("requestWithdrawals", amounts, owner)
+}
+
+// EncodeLidoWithdrawalRequests builds the lookup of the withdrawal requests
+// of owner that weren't claimed
+func EncodeLidoWithdrawalRequests(owner common.Address) ([]byte, error) {
+	return packStakingCall("getWithdrawalRequests", owner)
+}
+
+// EncodeLidoWithdrawalStatus builds the lookup of the state of withdrawal
+// requests
+func EncodeLidoWithdrawalStatus(ids []*big.Int) ([]byte, error) {
+	return packStakingCall("getWithdrawalStatus", ids)
+}
+
+// EncodeLidoClaimWithdrawal builds the claim of the ETH of a finalized
+// withdrawal request
+func EncodeLidoClaimWithdrawal(id *big.Int) ([]byte, error) {
+	return packStakingCall("claimWithdrawal", id)
+}
+
+// DecodeLidoWithdrawalRequests decodes the IDs of withdrawal requests
+func DecodeLidoWithdrawalRequests(result []byte) ([]*big.Int, error) {
+	var ids []*big.Int
+	if err := unpackStakingResult(&ids, "getWithdrawalRequests", result); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DecodeLidoWithdrawalStatus decodes the state of the withdrawal requests
+// ids, in the same order
+func DecodeLidoWithdrawalStatus(ids []*big.Int, result []byte) ([]LidoWithdrawal, error) {
+	// Fields in the order of the contract's WithdrawalRequestStatus
+	var statuses []struct {
+		AmountOfStETH  *big.Int
+		AmountOfShares *big.Int
+		Owner          common.Address
+		Timestamp      *big.Int
+		IsFinalized    bool
+		IsClaimed      bool
+	}
+	if err := unpackStakingResult(&statuses, "getWithdrawalStatus", result); err != nil {
+		return nil, err
+	}
+	if len(statuses) != len(ids) {
+		return nil, fmt.Errorf("expected %d withdrawal statuses, got %d", len(ids), len(statuses))
+	}
+
+	withdrawals := make([]LidoWithdrawal, len(statuses))
+	for i, status := range statuses {
+		withdrawals[i] = LidoWithdrawal{
+			ID:            ids[i],
+			AmountOfStETH: status.AmountOfStETH,
+			Owner:         status.Owner,
+			RequestedAt:   time.Unix(status.Timestamp.Int64(), 0),
+			IsFinalized:   status.IsFinalized,
+			IsClaimed:     status.IsClaimed,
+		}
+	}
+	return withdrawals, nil
+}
+
+// unpackStakingResult decodes the single return value of a staking method
+// into out
+func unpackStakingResult(out interface{}, method string, result []byte) error {
+	parsed, err := abi.JSON(strings.NewReader(stakingABI))
+	if err != nil {
+		return fmt.Errorf("failed to parse staking ABI: %w", err)
+	}
+	if err := parsed.UnpackIntoInterface(out, method, result); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// EncodeRocketPoolAddress builds the lookup of the current address of a
+// Rocket Pool contract in its storage contract, e.g. rocketDepositPool
+func EncodeRocketPoolAddress(contract string) ([]byte, error) {
+	var key [32]byte
+	copy(key[:], crypto.Keccak256([]byte("contract.address"+contract)))
+	return packStakingCall("getAddress", key)
+}
+
+// EncodeRocketPoolDeposit builds the Rocket Pool deposit of the ETH sent with
+// it, minting rETH at the current exchange rate less the deposit fee
+func EncodeRocketPoolDeposit() ([]byte, error) {
+	return packStakingCall("deposit")
+}
+
+// EncodeRocketPoolMaxDeposit builds the lookup of how much ETH the deposit
+// pool has room for
+func EncodeRocketPoolMaxDeposit() ([]byte, error) {
+	return packStakingCall("getMaximumDepositAmount")
+}
+
+// EncodeRETHBurn builds the redemption of rETH for ETH held by the rETH
+// contract and the deposit pool
+func EncodeRETHBurn(amount *big.Int) ([]byte, error) {
+	return packStakingCall("burn", amount)
+}
+
+// EncodeRETHEthValue builds the lookup of the ETH an amount of rETH is worth
+func EncodeRETHEthValue(amount *big.Int) ([]byte, error) {
+	return packStakingCall("getEthValue", amount)
+}
+
+// EncodeRETHRethValue builds the lookup of the rETH an amount of ETH buys
+func EncodeRETHRethValue(amount *big.Int) ([]byte, error) {
+	return packStakingCall("getRethValue", amount)
+}
+
+// EncodeRETHTotalCollateral builds the lookup of the ETH available to
+// redeem rETH with
+func EncodeRETHTotalCollateral() ([]byte, error) {
+	return packStakingCall("getTotalCollateral")
+}
+
+// SplitLidoWithdrawal splits an amount of stETH into withdrawal requests of
+// at most LidoMaxWithdrawal each
+func SplitLidoWithdrawal(amount *big.Int) []*big.Int {
+	var amounts []*big.Int
+	remaining := new(big.Int).Set(amount)
+	for remaining.Cmp(LidoMaxWithdrawal) > 0 {
+		amounts = append(amounts, new(big.Int).Set(LidoMaxWithdrawal))
+		remaining.Sub(remaining, LidoMaxWithdrawal)
+	}
+	// A last request below the minimum takes some of the one before
+	if len(amounts) > 0 && remaining.Cmp(LidoMinWithdrawal) < 0 {
+		shift := new(big.Int).Sub(LidoMinWithdrawal, remaining)
+		amounts[len(amounts)-1].Sub(amounts[len(amounts)-1], shift)
+		remaining.Add(remaining, shift)
+	}
+	return append(amounts, remaining)
+}
@@ -2,16 +2,19 @@ package ethereum
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
+	"log/slog"
 	"math/big"
-	"os"
-	"path/filepath"
 	"strings"
 	
+	"github.com/chinmay1088/odyssey/chains"
+	"github.com/chinmay1088/odyssey/config"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/shopspring/decimal"
 )
 
 const (
@@ -22,6 +25,9 @@ const (
 	// Network types
 	NetworkMainnet = "mainnet"
 	NetworkTestnet = "testnet"
+
+	// Decimals of ether, every EVM chain counts in wei
+	Decimals = 18
 )
 
 // Transaction represents an Ethereum transaction
@@ -35,45 +41,14 @@ type Transaction struct {
 	ChainID  *big.Int        `json:"chainId"`
 }
 
-// getCurrentNetwork returns the current network (mainnet or testnet)
-func getCurrentNetwork() string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return NetworkMainnet // Default to mainnet on error
-	}
-
-	networkPath := filepath.Join(homeDir, ".odyssey", "network.txt")
-
-	// Check if network file exists
-	if _, err := os.Stat(networkPath); os.IsNotExist(err) {
-		// File doesn't exist, default to mainnet
-		return NetworkMainnet
-	}
-
-	// Read network from file
-	data, err := os.ReadFile(networkPath)
-	if err != nil {
-		// Error reading file, default to mainnet
-		return NetworkMainnet
-	}
-
-	network := strings.TrimSpace(string(data))
-
-	// Validate network
-	if network != NetworkMainnet && network != NetworkTestnet {
-		// Invalid network, default to mainnet
-		return NetworkMainnet
-	}
-
-	return network
-}
-
-// GetChainID returns the correct chain ID based on the current network
+// GetChainID returns the chain ID of the Ethereum node of the current network,
+// 0 when the network leaves it out and the node can't be asked
 func GetChainID() *big.Int {
-	if getCurrentNetwork() == NetworkTestnet {
-		return big.NewInt(SepoliaChainID)
+	id, err := config.ActiveNetwork().ResolveEthereumChainID()
+	if err != nil {
+		slog.Debug("unknown chain ID", "error", err)
 	}
-	return big.NewInt(MainnetChainID)
+	return big.NewInt(id)
 }
 
 // NewTransaction creates a new Ethereum transaction
@@ -91,6 +66,11 @@ func NewTransaction(nonce uint64, to common.Address, value *big.Int, gasLimit ui
 
 // SignTransaction signs an Ethereum transaction with the provided private key
 func SignTransaction(tx *Transaction, privateKey *ecdsa.PrivateKey) (string, error) {
+	// Without a chain ID the signature would be valid on every chain
+	if tx.ChainID == nil || tx.ChainID.Sign() == 0 {
+		return "", fmt.Errorf("the chain ID of the Ethereum node is unknown, is the node running?")
+	}
+
 	// Create the transaction
 	ethereumTx := types.NewTransaction(
 		tx.Nonce,
@@ -116,34 +96,70 @@ func SignTransaction(tx *Transaction, privateKey *ecdsa.PrivateKey) (string, err
 	return hexutil.Encode(serialized), nil
 }
 
-// ParseAddress parses an Ethereum address
+// EncodeUnsigned returns the EIP-155 signing payload of a transaction, the
+// exact bytes SignTransaction signs
+func EncodeUnsigned(tx *Transaction) (string, error) {
+	payload := []interface{}{tx.Nonce, tx.GasPrice, tx.GasLimit, tx.To, tx.Value, tx.Data, tx.ChainID, uint(0), uint(0)}
+	serialized, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize transaction: %w", err)
+	}
+
+	return hexutil.Encode(serialized), nil
+}
+
+// TransactionHash returns the hash of a signed, hex encoded transaction
+func TransactionHash(signedTx string) (string, error) {
+	raw, err := hexutil.Decode(signedTx)
+	if err != nil {
+		return "", fmt.Errorf("invalid transaction hex: %w", err)
+	}
+
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return "", fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	return tx.Hash().Hex(), nil
+}
+
+// ErrChecksumMismatch is returned for a mixed-case address whose letters
+// don't match its EIP-55 checksum
+var ErrChecksumMismatch = errors.New("EIP-55 checksum mismatch")
+
+// ParseAddress parses an Ethereum address. Mixed-case addresses must carry a
+// valid EIP-55 checksum, addresses in one case have none to check.
 func ParseAddress(address string) (common.Address, error) {
 	if !common.IsHexAddress(address) {
 		return common.Address{}, fmt.Errorf("invalid Ethereum address: %s", address)
 	}
-	return common.HexToAddress(address), nil
+	parsed := common.HexToAddress(address)
+
+	digits := strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && "0x"+digits != parsed.Hex() {
+		return common.Address{}, fmt.Errorf("%w in %s: a character was mistyped or altered. Check the address at its source; if it is right, its checksummed form is %s", ErrChecksumMismatch, address, parsed.Hex())
+	}
+	return parsed, nil
 }
 
 // WeiToEther converts wei to ether
-func WeiToEther(wei *big.Int) float64 {
-	ether := new(big.Float).SetInt(wei)
-	ether.Quo(ether, big.NewFloat(1e18))
-	etherFloat, _ := ether.Float64()
-	return etherFloat
+func WeiToEther(wei *big.Int) decimal.Decimal {
+	return chains.FromSmallestUnit(wei, Decimals)
+}
+
+// EtherToWei converts ether to wei, dropping fractions of a wei
+func EtherToWei(ether decimal.Decimal) *big.Int {
+	return chains.ToSmallestUnit(ether, Decimals)
 }
 
-// EtherToWei converts ether to wei
-func EtherToWei(ether *big.Float) *big.Int {
-	wei := new(big.Float).Mul(ether, big.NewFloat(1e18))
-	weiInt := new(big.Int)
-	wei.Int(weiInt)
-	return weiInt
+// ParseEther parses an amount of ether typed by the user into wei
+func ParseEther(amount string) (*big.Int, error) {
+	return chains.ParseAmount(amount, Decimals)
 }
 
 // FormatBalance formats balance in a human-readable format
 func FormatBalance(balance *big.Int) string {
-	etherValue := WeiToEther(balance)
-	return fmt.Sprintf("%.18f ETH", etherValue)
+	return WeiToEther(balance).StringFixed(Decimals) + " ETH"
 }
 
 // EstimateGasLimit estimates gas limit for a transaction
@@ -159,6 +175,17 @@ func EstimateGasLimit(data []byte) uint64 {
 	return baseGas
 }
 
+// ValidateAmount rejects transfers that would only burn gas
+func ValidateAmount(value *big.Int, data []byte) error {
+	if value == nil || value.Sign() < 0 {
+		return fmt.Errorf("amount must not be negative")
+	}
+	if value.Sign() == 0 && len(data) == 0 {
+		return fmt.Errorf("sending 0 ETH without contract data does nothing except pay the gas fee")
+	}
+	return nil
+}
+
 // ValidateTransaction validates transaction parameters
 func ValidateTransaction(tx *Transaction) error {
 	if tx.To == nil {
@@ -0,0 +1,257 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/gagliardetto/solana-go"
+)
+
+// TransferFee is the fee in lamports of a transaction with one signature
+const TransferFee = uint64(5000)
+
+// Chain is Solana on the current network
+type Chain struct {
+	manager *wallet.Manager
+	client  *api.Client
+
+	// Memo and References are added to transfers that pay a Solana Pay request
+	Memo       string
+	References []string
+
+	// DurableNonce signs transfers with the nonce of the wallet's nonce
+	// account instead of a recent blockhash, so they stay valid until the
+	// nonce is advanced
+	DurableNonce bool
+}
+
+// ErrNoNonceAccount is returned when signing with a durable nonce before the
+// nonce account is created
+var ErrNoNonceAccount = errors.New("the wallet has no nonce account, create one with 'odyssey sol nonce create'")
+
+// NewChain returns Solana on the current network
+func NewChain(manager *wallet.Manager, client *api.Client) *Chain {
+	return &Chain{manager: manager, client: client}
+}
+
+// Name returns solana
+func (c *Chain) Name() string {
+	return "solana"
+}
+
+// Symbol returns SOL
+func (c *Chain) Symbol() string {
+	return "SOL"
+}
+
+// Decimals returns 9, Solana counts in lamports
+func (c *Chain) Decimals() int32 {
+	return Decimals
+}
+
+// RentExemptMinimum returns the balance in lamports a system account needs to
+// exist, as the cluster reports it
+func (c *Chain) RentExemptMinimum(ctx context.Context) uint64 {
+	lamports, err := c.client.GetSolanaRentExemptMinimum(ctx, 0)
+	if err != nil || lamports == 0 {
+		return RentExemptMinimum
+	}
+	return lamports
+}
+
+// Address returns the Solana address
+func (c *Chain) Address() (string, error) {
+	address, err := c.manager.GetSolanaAddress()
+	if err != nil {
+		return "", err
+	}
+	return address.String(), nil
+}
+
+// Balance returns the balance in lamports
+func (c *Chain) Balance(ctx context.Context) (*big.Int, error) {
+	address, err := c.Address()
+	if err != nil {
+		return nil, err
+	}
+	balance, err := c.client.GetSolanaBalance(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(balance), nil
+}
+
+// History returns a page of the transactions of an address
+func (c *Chain) History(ctx context.Context, address string, limit int, cursor string) (*api.TransactionPage, error) {
+	return c.client.GetSolanaTransactions(ctx, address, limit, cursor)
+}
+
+// FeeEstimate returns the fixed fee of a transfer
+func (c *Chain) FeeEstimate(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).SetUint64(TransferFee), nil
+}
+
+// NonceAccount returns the address of the wallet's durable nonce account
+func (c *Chain) NonceAccount() (string, error) {
+	authority, err := c.manager.GetSolanaAddress()
+	if err != nil {
+		return "", err
+	}
+	address, err := NonceAccountAddress(authority)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive nonce account: %w", err)
+	}
+	return address.String(), nil
+}
+
+// BuildCreateNonceAccount prepares the transaction that creates and funds
+// the wallet's nonce account with its rent-exempt minimum. Amount is the
+// rent. Tx is a *solana.Transaction.
+func (c *Chain) BuildCreateNonceAccount(ctx context.Context) (*chains.Transfer, error) {
+	authority, err := c.manager.GetSolanaAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender address: %w", err)
+	}
+	nonceAccount, err := NonceAccountAddress(authority)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive nonce account: %w", err)
+	}
+	rent, err := c.client.GetSolanaRentExemptMinimum(ctx, NonceAccountSize)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := NewTransaction(authority)
+	if err := tx.AddCreateNonceAccountInstructions(authority, rent); err != nil {
+		return nil, err
+	}
+
+	return &chains.Transfer{
+		From:   authority.String(),
+		To:     nonceAccount.String(),
+		Amount: new(big.Int).SetUint64(rent),
+		Fee:    new(big.Int).SetUint64(TransferFee),
+		Tx:     tx,
+	}, nil
+}
+
+// BuildTransfer prepares a transfer without a blockhash, Sign fetches a
+// fresh one right before signing. A memo goes right before the transfer, the
+// references into it. With DurableNonce an advance nonce instruction comes
+// first. Tx is a *solana.Transaction.
+func (c *Chain) BuildTransfer(ctx context.Context, to string, amount *big.Int) (*chains.Transfer, error) {
+	recipient, err := ParseAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	if !amount.IsUint64() || amount.Sign() == 0 {
+		return nil, fmt.Errorf("invalid amount: amount must be greater than zero")
+	}
+
+	sender, err := c.manager.GetSolanaAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender address: %w", err)
+	}
+
+	tx := NewTransaction(sender)
+	if c.Memo != "" {
+		tx.AddMemoInstruction(c.Memo)
+	}
+	tx.AddTransferInstruction(sender, recipient, amount.Uint64())
+	if len(c.References) > 0 {
+		if err := tx.AddReferences(c.References); err != nil {
+			return nil, fmt.Errorf("failed to create transaction: %w", err)
+		}
+	}
+	if c.DurableNonce {
+		nonceAccount, err := NonceAccountAddress(sender)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive nonce account: %w", err)
+		}
+		tx.AddAdvanceNonceInstruction(nonceAccount, sender)
+	}
+
+	return &chains.Transfer{
+		From:   sender.String(),
+		To:     recipient.String(),
+		Amount: amount,
+		Fee:    new(big.Int).SetUint64(TransferFee),
+		Tx:     tx,
+	}, nil
+}
+
+// Sign sets a fresh blockhash and signs with the Solana key. The transfer
+// must be broadcast before the blockhash expires, about a minute later. With
+// DurableNonce the current nonce is used instead and the transfer stays valid
+// until the nonce is advanced.
+func (c *Chain) Sign(ctx context.Context, transfer *chains.Transfer) error {
+	tx, ok := transfer.Tx.(*Transaction)
+	if !ok {
+		return fmt.Errorf("not a Solana transfer")
+	}
+
+	privateKey, err := c.manager.GetSolanaKey()
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %w", err)
+	}
+	tx.Signers = nil
+	tx.AddSigner(privateKey)
+
+	if c.DurableNonce {
+		nonce, err := c.durableNonce(ctx, privateKey.PublicKey())
+		if err != nil {
+			return err
+		}
+		tx.SetRecentBlockhash(nonce)
+		tx.LastValidBlockHeight = 0
+	} else {
+		recentBlockhash, lastValidBlockHeight, err := c.client.GetSolanaLatestBlockhash(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get blockhash: %w", err)
+		}
+		tx.SetRecentBlockhash(recentBlockhash)
+		tx.LastValidBlockHeight = lastValidBlockHeight
+	}
+
+	signedTx, err := tx.BuildAndSign()
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	txID, err := TransactionSignature(signedTx)
+	if err != nil {
+		return err
+	}
+
+	transfer.Signed = signedTx
+	transfer.ID = txID
+	return nil
+}
+
+// durableNonce returns the current nonce of the authority's nonce account
+func (c *Chain) durableNonce(ctx context.Context, authority solana.PublicKey) (string, error) {
+	nonceAccount, err := NonceAccountAddress(authority)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive nonce account: %w", err)
+	}
+	account, err := c.client.GetSolanaNonceAccount(ctx, nonceAccount.String())
+	if err != nil {
+		return "", err
+	}
+	if account == nil {
+		return "", ErrNoNonceAccount
+	}
+	if account.Authority != authority.String() {
+		return "", fmt.Errorf("nonce account %s is controlled by %s, not this wallet", nonceAccount, account.Authority)
+	}
+	return account.Nonce, nil
+}
+
+// Broadcast sends a signed transfer
+func (c *Chain) Broadcast(ctx context.Context, transfer *chains.Transfer) (string, error) {
+	return c.client.SendSolanaTransaction(ctx, transfer.Signed)
+}
@@ -0,0 +1,127 @@
+package solana
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+)
+
+// NonceAccountSize is the size in bytes of a durable nonce account
+const NonceAccountSize = 80
+
+// nonceSeed derives the wallet's nonce account from its Solana address, so
+// the account needs no key of its own and is found again after a restore
+const nonceSeed = "odyssey-nonce"
+
+// NonceAccountAddress returns the address of the durable nonce account of an
+// authority
+func NonceAccountAddress(authority solana.PublicKey) (solana.PublicKey, error) {
+	return solana.CreateWithSeed(authority, nonceSeed, solana.SystemProgramID)
+}
+
+// AddCreateNonceAccountInstructions adds the instructions that create the
+// nonce account of the authority with lamports for rent and initialize it.
+// The authority pays for the account.
+func (tx *Transaction) AddCreateNonceAccountInstructions(authority solana.PublicKey, lamports uint64) error {
+	nonceAccount, err := NonceAccountAddress(authority)
+	if err != nil {
+		return fmt.Errorf("failed to derive nonce account: %w", err)
+	}
+	tx.Instructions = append(tx.Instructions,
+		system.NewCreateAccountWithSeedInstruction(
+			authority,
+			nonceSeed,
+			lamports,
+			NonceAccountSize,
+			solana.SystemProgramID,
+			authority,
+			nonceAccount,
+			authority,
+		).Build(),
+		system.NewInitializeNonceAccountInstruction(
+			authority,
+			nonceAccount,
+			solana.SysVarRecentBlockHashesPubkey,
+			solana.SysVarRentPubkey,
+		).Build(),
+	)
+	return nil
+}
+
+// AddAdvanceNonceInstruction puts an advance nonce instruction first, which
+// the network requires of transactions signed with a durable nonce
+func (tx *Transaction) AddAdvanceNonceInstruction(nonceAccount, authority solana.PublicKey) {
+	instruction := system.NewAdvanceNonceAccountInstruction(
+		nonceAccount,
+		solana.SysVarRecentBlockHashesPubkey,
+		authority,
+	).Build()
+	tx.Instructions = append([]solana.Instruction{instruction}, tx.Instructions...)
+}
+
+// SignedTransfer describes a signed, base58 encoded transaction
+type SignedTransfer struct {
+	ID        string
+	FeePayer  string
+	Blockhash string // the nonce for durable nonce transactions
+
+	// NonceAccount is set if the transaction is signed with a durable nonce
+	NonceAccount string
+
+	// To and Lamports are set if the transaction holds a SOL transfer
+	To       string
+	Lamports uint64
+}
+
+// DecodeSignedTransfer decodes a signed transaction, finding its durable
+// nonce account and SOL transfer if it has them
+func DecodeSignedTransfer(signedTx string) (*SignedTransfer, error) {
+	stx, err := solana.TransactionFromBase58(signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	if len(stx.Signatures) == 0 || len(stx.Message.AccountKeys) == 0 {
+		return nil, fmt.Errorf("transaction is not signed")
+	}
+
+	transfer := &SignedTransfer{
+		ID:        stx.Signatures[0].String(),
+		FeePayer:  stx.Message.AccountKeys[0].String(),
+		Blockhash: stx.Message.RecentBlockhash.String(),
+	}
+
+	keys := stx.Message.AccountKeys
+	for i, instruction := range stx.Message.Instructions {
+		if int(instruction.ProgramIDIndex) >= len(keys) || !keys[instruction.ProgramIDIndex].Equals(solana.SystemProgramID) {
+			continue
+		}
+		data := instruction.Data
+		if len(data) < 4 {
+			continue
+		}
+		accounts := make([]solana.PublicKey, 0, len(instruction.Accounts))
+		for _, index := range instruction.Accounts {
+			if int(index) >= len(keys) {
+				return nil, fmt.Errorf("instruction %d refers to a missing account", i)
+			}
+			accounts = append(accounts, keys[index])
+		}
+
+		switch binary.LittleEndian.Uint32(data) {
+		case system.Instruction_AdvanceNonceAccount:
+			// Only an advance nonce instruction first makes the nonce durable
+			if i == 0 && len(accounts) > 0 {
+				transfer.NonceAccount = accounts[0].String()
+			}
+		case system.Instruction_Transfer:
+			if transfer.To == "" && len(accounts) > 1 && len(data) >= 12 {
+				transfer.To = accounts[1].String()
+				transfer.Lamports = binary.LittleEndian.Uint64(data[4:12])
+			}
+		}
+	}
+
+	return transfer, nil
+}
@@ -1,12 +1,16 @@
 package solana
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 
+	"github.com/chinmay1088/odyssey/chains"
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/programs/system"
 	"github.com/mr-tron/base58"
+	"github.com/shopspring/decimal"
 )
 
 // Transaction represents a Solana transaction
@@ -15,6 +19,10 @@ type Transaction struct {
 	Signers         []solana.PrivateKey
 	FeePayer        solana.PublicKey
 	RecentBlockhash string
+
+	// LastValidBlockHeight is the last block height at which the transaction
+	// is processed, 0 for durable nonce transactions
+	LastValidBlockHeight uint64
 }
 
 func NewTransaction(feePayer solana.PublicKey) *Transaction {
@@ -34,6 +42,39 @@ func (tx *Transaction) AddTransferInstruction(from solana.PublicKey, to solana.P
 	tx.Instructions = append(tx.Instructions, instruction)
 }
 
+// MemoProgramID is the SPL Memo program, which records a UTF-8 memo in the
+// transaction
+var MemoProgramID = solana.MustPublicKeyFromBase58("MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr")
+
+// AddMemoInstruction adds a memo without signers. Solana Pay expects it right
+// before the transfer.
+func (tx *Transaction) AddMemoInstruction(memo string) {
+	tx.Instructions = append(tx.Instructions, solana.NewInstruction(MemoProgramID, solana.AccountMetaSlice{}, []byte(memo)))
+}
+
+// AddReferences adds read-only reference accounts to the last instruction, so
+// the recipient of a Solana Pay request can find the transaction by them
+func (tx *Transaction) AddReferences(references []string) error {
+	if len(tx.Instructions) == 0 {
+		return fmt.Errorf("no instruction to add references to")
+	}
+	last := tx.Instructions[len(tx.Instructions)-1]
+	data, err := last.Data()
+	if err != nil {
+		return fmt.Errorf("failed to encode instruction: %w", err)
+	}
+	accounts := append(solana.AccountMetaSlice{}, last.Accounts()...)
+	for _, reference := range references {
+		key, err := ParseAddress(reference)
+		if err != nil {
+			return fmt.Errorf("invalid reference: %w", err)
+		}
+		accounts = append(accounts, solana.NewAccountMeta(key, false, false))
+	}
+	tx.Instructions[len(tx.Instructions)-1] = solana.NewInstruction(last.ProgramID(), accounts, data)
+	return nil
+}
+
 func (tx *Transaction) AddSigner(signer solana.PrivateKey) {
 	tx.Signers = append(tx.Signers, signer)
 }
@@ -105,6 +146,53 @@ func (tx *Transaction) BuildAndSign() (string, error) {
 	return base58.Encode(serialized), nil
 }
 
+// TransactionSignature returns the signature identifying a signed, base58
+// encoded transaction
+func TransactionSignature(signedTx string) (string, error) {
+	stx, err := solana.TransactionFromBase58(signedTx)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	if len(stx.Signatures) == 0 {
+		return "", fmt.Errorf("transaction is not signed")
+	}
+
+	return stx.Signatures[0].String(), nil
+}
+
+// SignSerializedTransaction signs a base64 encoded transaction built by
+// someone else, e.g. a swap aggregator, and returns it base58 encoded along
+// with its blockhash. The key must be the fee payer and the only signer.
+func SignSerializedTransaction(unsignedTx string, key solana.PrivateKey) (string, string, error) {
+	stx, err := solana.TransactionFromBase64(unsignedTx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	if len(stx.Message.AccountKeys) == 0 || !stx.Message.AccountKeys[0].Equals(key.PublicKey()) {
+		return "", "", fmt.Errorf("transaction is not paid by this wallet")
+	}
+
+	// Sign fails if any other signature is required
+	_, err = stx.Sign(func(signer solana.PublicKey) *solana.PrivateKey {
+		if signer.Equals(key.PublicKey()) {
+			return &key
+		}
+		return nil
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	serialized, err := stx.MarshalBinary()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to serialize transaction: %w", err)
+	}
+
+	return base58.Encode(serialized), stx.Message.RecentBlockhash.String(), nil
+}
+
 // ValidateBase58 validates that a string is valid Base58
 func ValidateBase58(s string) bool {
 	if s == "" {
@@ -142,17 +230,62 @@ func ParseAddress(address string) (solana.PublicKey, error) {
 	return pubKey, nil
 }
 
-func LamportsToSOL(lamports uint64) float64 {
-	return float64(lamports) / 1000000000.0
+// Decimals of SOL, Solana counts in lamports
+const Decimals = 9
+
+// RentExemptMinimum is the balance in lamports a system account without data
+// needs to exist on-chain. Transfers that would leave an account with less
+// (but more than zero) are rejected by the network. The cluster is asked
+// for the current value, this is used when it can't be.
+const RentExemptMinimum = uint64(890880)
+
+// ErrRentExemptRemainder is returned by ValidateAmount for transfers that
+// would leave the sender with a balance below the rent-exempt minimum
+var ErrRentExemptRemainder = errors.New("the remaining balance wouldn't be rent exempt")
+
+// ValidateAmount checks that a transfer won't be rejected for rent reasons.
+// recipientBalance is the current recipient balance, senderRemaining the
+// sender balance left after the transfer and its fee and rentExempt the
+// rent-exempt minimum of a system account.
+func ValidateAmount(lamports, recipientBalance, senderRemaining, rentExempt uint64) error {
+	if lamports == 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+	if recipientBalance == 0 && lamports < rentExempt {
+		return fmt.Errorf("the recipient account doesn't exist yet, so the first transfer must be at least %s to cover its rent-exempt minimum. Smaller transfers to new accounts are rejected by the network",
+			FormatBalance(rentExempt))
+	}
+	if senderRemaining > 0 && senderRemaining < rentExempt {
+		return fmt.Errorf("%w: this transfer would leave %s in your account, below the minimum of %s, and would be rejected by the network. Send less, or send your whole balance minus the fee to close the account",
+			ErrRentExemptRemainder, FormatBalance(senderRemaining), FormatBalance(rentExempt))
+	}
+	return nil
+}
+
+// LamportsToSOL converts lamports to SOL
+func LamportsToSOL(lamports uint64) decimal.Decimal {
+	return chains.FromSmallestUnit(new(big.Int).SetUint64(lamports), Decimals)
+}
+
+// SOLToLamports converts SOL to lamports, dropping fractions of a lamport
+func SOLToLamports(sol decimal.Decimal) uint64 {
+	return chains.ToSmallestUnit(sol, Decimals).Uint64()
 }
 
-func SOLToLamports(sol float64) uint64 {
-	return uint64(sol * 1000000000.0)
+// ParseSOL parses an amount of SOL typed by the user into lamports
+func ParseSOL(amount string) (uint64, error) {
+	lamports, err := chains.ParseAmount(amount, Decimals)
+	if err != nil {
+		return 0, err
+	}
+	if !lamports.IsUint64() {
+		return 0, fmt.Errorf("%s SOL is more than can exist", strings.TrimSpace(amount))
+	}
+	return lamports.Uint64(), nil
 }
 
 func FormatBalance(lamports uint64) string {
-	sol := LamportsToSOL(lamports)
-	return fmt.Sprintf("%.9f SOL", sol)
+	return LamportsToSOL(lamports).StringFixed(Decimals) + " SOL"
 }
 
 func ValidateAddress(address string) error {
@@ -0,0 +1,67 @@
+// Package clipboard copies text to and reads it from the system clipboard
+// with the clipboard tools of the operating system.
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrUnsupported is returned when no clipboard tool is available on this system
+var ErrUnsupported = errors.New("the clipboard is not supported on this system")
+
+// Write replaces the contents of the clipboard with text
+func Write(text string) error {
+	name, args, err := copyCommand()
+	if err != nil {
+		return err
+	}
+
+	// xclip and wl-copy stay in the background to serve the clipboard, their
+	// output isn't captured so waiting doesn't block on them
+	process := exec.Command(name, args...)
+	process.Stdin = strings.NewReader(text)
+	if err := process.Run(); err != nil {
+		return fmt.Errorf("failed to copy to the clipboard: %w", err)
+	}
+	return nil
+}
+
+// Read returns the text in the clipboard
+func Read() (string, error) {
+	name, args, err := pasteCommand()
+	if err != nil {
+		return "", err
+	}
+
+	output, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the clipboard: %w", err)
+	}
+	return string(output), nil
+}
+
+// ClearIf empties the clipboard if it still holds text, leaving anything
+// copied since alone
+func ClearIf(text string) error {
+	current, err := Read()
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(current) != strings.TrimSpace(text) {
+		return nil
+	}
+	return Write("")
+}
+
+// Available returns true if a clipboard tool is installed
+func Available() bool {
+	name, _, err := copyCommand()
+	if err != nil {
+		return false
+	}
+	_, err = exec.LookPath(name)
+	return err == nil
+}
@@ -0,0 +1,13 @@
+//go:build darwin
+
+package clipboard
+
+// copyCommand uses pbcopy, which comes with macOS
+func copyCommand() (string, []string, error) {
+	return "pbcopy", nil, nil
+}
+
+// pasteCommand uses pbpaste, which comes with macOS
+func pasteCommand() (string, []string, error) {
+	return "pbpaste", nil, nil
+}
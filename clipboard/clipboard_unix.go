@@ -0,0 +1,60 @@
+//go:build !darwin && !windows
+
+package clipboard
+
+import (
+	"os"
+	"os/exec"
+)
+
+// tool is a clipboard program with its arguments to copy and to paste
+type tool struct {
+	copy  []string
+	paste []string
+}
+
+// waylandTools work under Wayland, x11Tools under X11 and XWayland
+var (
+	waylandTools = []tool{
+		{copy: []string{"wl-copy"}, paste: []string{"wl-paste", "--no-newline"}},
+	}
+	x11Tools = []tool{
+		{copy: []string{"xclip", "-selection", "clipboard"}, paste: []string{"xclip", "-selection", "clipboard", "-o"}},
+		{copy: []string{"xsel", "--clipboard", "--input"}, paste: []string{"xsel", "--clipboard", "--output"}},
+	}
+)
+
+// findTool returns the first installed clipboard tool for the display server
+func findTool() (*tool, error) {
+	var candidates []tool
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append(candidates, waylandTools...)
+	}
+	if os.Getenv("DISPLAY") != "" {
+		candidates = append(candidates, x11Tools...)
+	}
+	for i := range candidates {
+		if _, err := exec.LookPath(candidates[i].copy[0]); err == nil {
+			return &candidates[i], nil
+		}
+	}
+	return nil, ErrUnsupported
+}
+
+// copyCommand uses wl-copy, xclip or xsel
+func copyCommand() (string, []string, error) {
+	t, err := findTool()
+	if err != nil {
+		return "", nil, err
+	}
+	return t.copy[0], t.copy[1:], nil
+}
+
+// pasteCommand uses wl-paste, xclip or xsel
+func pasteCommand() (string, []string, error) {
+	t, err := findTool()
+	if err != nil {
+		return "", nil, err
+	}
+	return t.paste[0], t.paste[1:], nil
+}
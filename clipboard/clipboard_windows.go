@@ -0,0 +1,17 @@
+//go:build windows
+
+package clipboard
+
+// setClipboardScript copies standard input, clip.exe would mangle non-ASCII
+// text and add a newline
+const setClipboardScript = `$text = [Console]::In.ReadToEnd(); if ($text) { Set-Clipboard -Value $text } else { Set-Clipboard -Value $null }`
+
+// copyCommand sets the clipboard with PowerShell
+func copyCommand() (string, []string, error) {
+	return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", setClipboardScript}, nil
+}
+
+// pasteCommand reads the clipboard with PowerShell
+func pasteCommand() (string, []string, error) {
+	return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", "Get-Clipboard -Raw"}, nil
+}
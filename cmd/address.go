@@ -3,42 +3,118 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/config"
 	"github.com/chinmay1088/odyssey/wallet"
 	"github.com/spf13/cobra"
 )
 
+var (
+	addressTypeFlag       string
+	addressCopyFlag       bool
+	addressClearAfterFlag int
+)
+
 var addressCmd = &cobra.Command{
 	Use:   "address [chain]",
 	Short: "Show wallet address",
 	Long: `Show your wallet address for the specified blockchain.
-Supported chains: eth, btc, sol
+Supported chains: eth, btc, sol, polygon, arbitrum, optimism, base, bsc
 
 Examples:
   odyssey address eth     # Show Ethereum address
   odyssey address btc     # Show Bitcoin address
   odyssey address sol     # Show Solana address
-  odyssey address         # Show all addresses`,
+  odyssey address         # Show all addresses
+  odyssey address --offline  # Guarantee that no network request is made
+  odyssey address btc --address-type p2tr  # Show the Taproot address
+  odyssey address eth --copy  # Copy the address, cleared after 30 seconds
+
+Bitcoin addresses are native SegWit (p2wpkh) by default. Taproot (p2tr)
+addresses can be shown with --address-type, or used for receiving and
+sending with:
+  odyssey config set bitcoin.address_type p2tr
+
+Funds received on the wallet's other Bitcoin addresses, including legacy
+(1...) and nested SegWit (3...) addresses from older wallets, are included
+in balances and spent by payments. 'odyssey address btc' lists them.
+
+Addresses are derived locally from your keys and never require network access.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runAddress,
 }
 
+func init() {
+	addressCmd.Flags().StringVar(&addressTypeFlag, "address-type", "", "Bitcoin address type to show (p2wpkh, p2tr)")
+	addressCmd.Flags().BoolVar(&addressCopyFlag, "copy", false, "Copy the address of the chain to the clipboard")
+	addressCmd.Flags().IntVar(&addressClearAfterFlag, "clear-after", 30, "Seconds until a copied address is cleared from the clipboard, 0 to keep it")
+}
+
 func runAddress(cmd *cobra.Command, args []string) error {
 	manager := wallet.NewManager()
 
 	// Check if wallet is unlocked
 	if !manager.IsUnlocked() {
-		return fmt.Errorf("wallet is locked. Run 'odyssey unlock' first")
+		return errWalletLocked
+	}
+
+	if addressTypeFlag != "" {
+		if err := manager.SetBitcoinAddressType(strings.ToLower(addressTypeFlag)); err != nil {
+			return err
+		}
 	}
 
 	// If no chain specified, show all addresses
 	if len(args) == 0 {
+		if addressCopyFlag {
+			return fmt.Errorf("--copy needs a chain, e.g. odyssey address eth --copy")
+		}
 		return showAllAddresses(manager)
 	}
 
 	// Show specific chain address
 	chain := strings.ToLower(args[0])
-	return showChainAddress(manager, chain)
+	if err := showChainAddress(manager, chain); err != nil {
+		return err
+	}
+	if addressCopyFlag {
+		return copyChainAddress(manager, chain)
+	}
+	return nil
+}
+
+// copyChainAddress copies the address of chain to the clipboard
+func copyChainAddress(manager *wallet.Manager, chain string) error {
+	var address string
+	switch chain {
+	case "btc", "bitcoin":
+		if !manager.BitcoinSupported() {
+			return fmt.Errorf("bitcoin is not supported in testnet mode")
+		}
+		btcAddress, err := manager.GetBitcoinAddress()
+		if err != nil {
+			return fmt.Errorf("failed to get Bitcoin address: %w", err)
+		}
+		address = btcAddress.String()
+	case "sol", "solana":
+		solAddress, err := manager.GetSolanaAddress()
+		if err != nil {
+			return fmt.Errorf("failed to get Solana address: %w", err)
+		}
+		address = solAddress.String()
+	default:
+		// Ethereum and every EVM chain
+		ethAddress, err := manager.GetEthereumAddress()
+		if err != nil {
+			return fmt.Errorf("failed to get Ethereum address: %w", err)
+		}
+		address = ethAddress.Hex()
+	}
+
+	fmt.Println()
+	return copyToClipboard(address, time.Duration(addressClearAfterFlag)*time.Second)
 }
 
 func showAllAddresses(manager *wallet.Manager) error {
@@ -58,18 +134,18 @@ func showAllAddresses(manager *wallet.Manager) error {
 		return fmt.Errorf("failed to get Ethereum address: %w", err)
 	}
 	if manager.IsTestnet() {
-		fmt.Printf("Ethereum (ETH - Sepolia): %s\n", ethAddress.Hex())
+		printResult("eth "+ethAddress.Hex(), "Ethereum (ETH - %s): %s\n", config.ActiveNetwork().EthereumName, ethAddress.Hex())
 	} else {
-		fmt.Printf("Ethereum (ETH): %s\n", ethAddress.Hex())
+		printResult("eth "+ethAddress.Hex(), "Ethereum (ETH): %s\n", ethAddress.Hex())
 	}
 
-	// Bitcoin address - only on mainnet
-	if !manager.IsTestnet() {
+	// Bitcoin address, on mainnet and regtest networks
+	if manager.BitcoinSupported() {
 		btcAddress, err := manager.GetBitcoinAddress()
 		if err != nil {
 			return fmt.Errorf("failed to get Bitcoin address: %w", err)
 		}
-		fmt.Printf("Bitcoin (BTC):  %s\n", btcAddress.String())
+		printResult("btc "+btcAddress.String(), "%s:  %s\n", bitcoinAddressLabel(manager), btcAddress.String())
 	} else {
 		fmt.Println("Bitcoin (BTC):  Not supported in testnet mode")
 	}
@@ -80,11 +156,28 @@ func showAllAddresses(manager *wallet.Manager) error {
 		return fmt.Errorf("failed to get Solana address: %w", err)
 	}
 	if manager.IsTestnet() {
-		fmt.Printf("Solana (SOL - Devnet): %s\n", solAddress.String())
+		printResult("sol "+solAddress.String(), "Solana (SOL - %s): %s\n", config.ActiveNetwork().SolanaName, solAddress.String())
 		fmt.Println("   📝 Note: Solana addresses need to be initialized by receiving SOL first.")
 		fmt.Println("   📝 The address is valid but shows as 'Account does not exist' until then.")
 	} else {
-		fmt.Printf("Solana (SOL): %s\n", solAddress.String())
+		printResult("sol "+solAddress.String(), "Solana (SOL): %s\n", solAddress.String())
+	}
+
+	// Accounts of imported keys, e.g. vanity addresses
+	imported, err := manager.GetImportedAccounts()
+	if err != nil {
+		return fmt.Errorf("failed to get imported accounts: %w", err)
+	}
+	if len(imported) > 0 {
+		fmt.Println()
+		fmt.Println("Imported accounts:")
+		for _, account := range imported {
+			symbol := "sol"
+			if account.Chain == "ethereum" {
+				symbol = "eth"
+			}
+			printResult(symbol+" "+account.Address, "   %s %s (%s)\n", strings.ToUpper(symbol), account.Address, account.Label)
+		}
 	}
 
 	return nil
@@ -105,20 +198,31 @@ func showChainAddress(manager *wallet.Manager, chain string) error {
 			return fmt.Errorf("failed to get Ethereum address: %w", err)
 		}
 		if manager.IsTestnet() {
-			fmt.Printf("Ethereum (ETH - Sepolia): %s\n", address.Hex())
+			printResult(address.Hex(), "Ethereum (ETH - %s): %s\n", config.ActiveNetwork().EthereumName, address.Hex())
 		} else {
-			fmt.Printf("Ethereum (ETH): %s\n", address.Hex())
+			printResult(address.Hex(), "Ethereum (ETH): %s\n", address.Hex())
 		}
 
 	case "btc", "bitcoin":
-		if manager.IsTestnet() {
+		if !manager.BitcoinSupported() {
 			fmt.Println("Bitcoin (BTC): Not supported in testnet mode")
 		} else {
 			address, err := manager.GetBitcoinAddress()
 			if err != nil {
 				return fmt.Errorf("failed to get Bitcoin address: %w", err)
 			}
-			fmt.Printf("Bitcoin (BTC): %s\n", address.String())
+			printResult(address.String(), "%s: %s\n", bitcoinAddressLabel(manager), address.String())
+
+			// Funds on the other address types are found and spent as well
+			accounts, err := manager.GetBitcoinAccounts()
+			if err != nil {
+				return fmt.Errorf("failed to get Bitcoin address: %w", err)
+			}
+			fmt.Println()
+			fmt.Println("Also included in balances and payments:")
+			for _, account := range accounts[1:] {
+				fmt.Printf("   %-14s %s (%s)\n", bitcoinAddressNames[account.Type], account.Address.String(), account.Path)
+			}
 		}
 
 	case "sol", "solana":
@@ -127,16 +231,33 @@ func showChainAddress(manager *wallet.Manager, chain string) error {
 			return fmt.Errorf("failed to get Solana address: %w", err)
 		}
 		if manager.IsTestnet() {
-			fmt.Printf("Solana (SOL - Devnet): %s\n", address.String())
+			printResult(address.String(), "Solana (SOL - %s): %s\n", config.ActiveNetwork().SolanaName, address.String())
 			fmt.Println("   📝 Note: Solana addresses need to be initialized by receiving SOL first.")
 			fmt.Println("   📝 The address is valid but shows as 'Account does not exist' until then.")
 		} else {
-			fmt.Printf("Solana (SOL): %s\n", address.String())
+			printResult(address.String(), "Solana (SOL): %s\n", address.String())
 		}
 
 	default:
-		return fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol", chain)
+		// Every EVM chain uses the Ethereum address
+		evmChain, ok := api.FindEVMChain(chain)
+		if !ok {
+			return fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol, %s", chain, strings.Join(api.EVMChainNames(), ", "))
+		}
+		address, err := manager.GetEthereumAddress()
+		if err != nil {
+			return fmt.Errorf("failed to get Ethereum address: %w", err)
+		}
+		printResult(address.Hex(), "%s (%s): %s\n", evmChain.Label(manager.IsTestnet()), evmChain.Symbol, address.Hex())
 	}
 
 	return nil
 }
+
+// bitcoinAddressLabel names the Bitcoin address type in use
+func bitcoinAddressLabel(manager *wallet.Manager) string {
+	if manager.BitcoinAddressType() == config.BitcoinAddressP2TR {
+		return "Bitcoin (BTC - Taproot)"
+	}
+	return "Bitcoin (BTC)"
+}
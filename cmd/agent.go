@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+// agentStartTimeout is how long start waits for the agent's socket
+const agentStartTimeout = 5 * time.Second
+
+var agentForegroundFlag bool
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Keep the unlocked wallet in a background agent",
+	Long: `Run a background agent, like ssh-agent, that keeps the decrypted wallet in
+its memory so no session is ever written to disk.
+
+Commands reach the agent over ~/.odyssey/agent.sock, which only your user
+can open. The agent also checks the user of every process that connects and
+keeps the recovery phrase in locked memory that isn't swapped to disk. It
+forgets the keys after the session timeout of inactivity or on
+'odyssey lock', and 'odyssey unlock' hands them to it again.
+
+Commands only use the agent with 'odyssey config set session.storage agent'.
+The agent is supported on Linux and macOS.
+
+Examples:
+  odyssey config set session.storage agent
+  odyssey agent start
+  odyssey agent status
+  odyssey agent stop`,
+}
+
+var agentStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the agent and unlock the wallet into it",
+	Args:  cobra.NoArgs,
+	RunE:  runAgentStart,
+}
+
+var agentStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Make the agent forget the keys and exit",
+	Args:  cobra.NoArgs,
+	RunE:  runAgentStop,
+}
+
+var agentStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the agent runs and holds the keys",
+	Args:  cobra.NoArgs,
+	RunE:  runAgentStatus,
+}
+
+// agentServeCmd is the agent process itself, started by agent start
+var agentServeCmd = &cobra.Command{
+	Use:    "serve",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE:   runAgentServe,
+}
+
+func init() {
+	agentStartCmd.Flags().BoolVar(&agentForegroundFlag, "foreground", false, "Run the agent in this terminal until Ctrl+C")
+	agentStartCmd.Flags().String("password-file", "", "Read the password from this file instead of prompting (also ODYSSEY_PASSWORD)")
+
+	agentCmd.AddCommand(agentStartCmd)
+	agentCmd.AddCommand(agentStopCmd)
+	agentCmd.AddCommand(agentStatusCmd)
+	agentCmd.AddCommand(agentServeCmd)
+}
+
+func runAgentStart(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.VaultExists() {
+		return fmt.Errorf("no wallet found. Run 'odyssey init' to create a new wallet")
+	}
+	if status, err := manager.AgentStatus(); err == nil {
+		return fmt.Errorf("an agent is already running (pid %d)", status.PID)
+	}
+
+	password, err := readWalletPassword(cmd, "Enter your wallet password: ")
+	if err != nil {
+		return err
+	}
+
+	if agentForegroundFlag {
+		fmt.Printf("🔐 Agent running on %s (Ctrl+C to stop)\n", manager.AgentSocketPath())
+		printAgentStorageHint()
+		return manager.ServeAgent(cmd.Context(), password)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the odyssey executable: %w", err)
+	}
+	// The agent serves the wallet this command was started for
+	process := exec.Command(executable, "agent", "serve", "--wallet", config.ActiveWallet())
+	detachProcess(process)
+	if err := process.Start(); err != nil {
+		return fmt.Errorf("failed to start agent: %w", err)
+	}
+	pid := process.Process.Pid
+	process.Process.Release()
+
+	// The socket shows up once the agent listens
+	deadline := time.Now().Add(agentStartTimeout)
+	for {
+		if _, err := manager.AgentStatus(); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("agent (pid %d) didn't start within %s", pid, agentStartTimeout)
+		}
+		if err := sleepContext(cmd.Context(), 100*time.Millisecond); err != nil {
+			return err
+		}
+	}
+
+	if err := manager.AddToAgent(password); err != nil {
+		manager.StopAgent()
+		return fmt.Errorf("failed to unlock wallet: %w", err)
+	}
+
+	fmt.Printf("✅ Agent started (pid %d) and holds the unlocked wallet\n", pid)
+	fmt.Printf("⏱️  Forgets the keys after %s of inactivity\n", formatSessionTimeout(manager.SessionTimeout()))
+	printAgentStorageHint()
+	return nil
+}
+
+// printAgentStorageHint points out that commands ignore the agent until
+// session.storage is agent
+func printAgentStorageHint() {
+	if cfg, err := config.Load(); err == nil && cfg.SessionStorage() != config.SessionStorageAgent {
+		fmt.Println("💡 Run 'odyssey config set session.storage agent' so commands use the agent")
+	}
+}
+
+func runAgentStop(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if err := manager.StopAgent(); err != nil {
+		if errors.Is(err, wallet.ErrAgentNotRunning) {
+			fmt.Println("🔒 No agent is running")
+			return nil
+		}
+		return err
+	}
+	fmt.Println("🔒 Agent stopped, the keys are forgotten")
+	return nil
+}
+
+func runAgentStatus(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	status, err := manager.AgentStatus()
+	if err != nil {
+		if errors.Is(err, wallet.ErrAgentNotRunning) {
+			fmt.Println("⚪ No agent is running, start one with 'odyssey agent start'")
+			return nil
+		}
+		return err
+	}
+
+	fmt.Printf("🔐 Agent running (pid %d)\n", status.PID)
+	fmt.Printf("   Socket: %s\n", status.Socket)
+	if status.Unlocked {
+		fmt.Printf("   Keys:   held, last used %s ago\n", formatElapsed(time.Since(status.LastUsed)))
+	} else {
+		fmt.Println("   Keys:   none, run 'odyssey unlock' to hand them to the agent")
+	}
+	fmt.Printf("   Used:   %s\n", agentStorageUse(manager))
+	return nil
+}
+
+// agentStorageUse tells whether commands use the agent
+func agentStorageUse(manager *wallet.Manager) string {
+	if manager.SessionStorage() == config.SessionStorageAgent {
+		return "by every command (session.storage agent)"
+	}
+	return "not by commands, session.storage is " + manager.SessionStorage()
+}
+
+func runAgentServe(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGTERM)
+	defer stop()
+	return wallet.NewManager().ServeAgent(ctx, "")
+}
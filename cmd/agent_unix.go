@@ -0,0 +1,14 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts a process in a session of its own, so it outlives
+// the terminal it was started from
+func detachProcess(process *exec.Cmd) {
+	process.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,6 @@
+package cmd
+
+import "os/exec"
+
+// detachProcess leaves the process as is, the agent doesn't run on Windows
+func detachProcess(process *exec.Cmd) {}
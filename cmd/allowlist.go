@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+// Strict modes for recipients off the allow-list
+const (
+	strictOff     = "off"
+	strictConfirm = "confirm"
+	strictRefuse  = "refuse"
+)
+
+var allowlistCmd = &cobra.Command{
+	Use:   "allowlist",
+	Short: "Manage the recipients odyssey pay trusts",
+	Long: `Manage the allow-list of recipient addresses, the whitelist of the
+spending policy.
+
+In strict mode 'odyssey pay' protects against malware that swaps addresses in
+the clipboard:
+  off      Any address may be paid (default)
+  confirm  Addresses off the allow-list must be typed again to confirm
+  refuse   Only addresses on the allow-list may be paid
+
+Addresses on the deny-list ('odyssey denylist') are refused in every mode.
+EVM chains share the Ethereum allow-list.
+
+Examples:
+  odyssey allowlist add eth 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6 exchange
+  odyssey allowlist add btc bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh
+  odyssey allowlist strict confirm
+  odyssey allowlist remove eth 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6
+  odyssey allowlist list`,
+	Args: cobra.NoArgs,
+	RunE: runAllowlistList,
+}
+
+var allowlistListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the allow-list and the strict mode",
+	Args:  cobra.NoArgs,
+	RunE:  runAllowlistList,
+}
+
+var allowlistAddCmd = &cobra.Command{
+	Use:   "add [chain] [address] [label]",
+	Short: "Add an address to the allow-list",
+	Args:  cobra.RangeArgs(2, 3),
+	RunE:  runPolicyWhitelistAdd,
+}
+
+var allowlistRemoveCmd = &cobra.Command{
+	Use:   "remove [chain] [address]",
+	Short: "Remove an address from the allow-list",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runPolicyWhitelistRemove,
+}
+
+var allowlistStrictCmd = &cobra.Command{
+	Use:       "strict [off|confirm|refuse]",
+	Short:     "Set what pay does for addresses off the allow-list",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{strictOff, strictConfirm, strictRefuse},
+	RunE:      runAllowlistStrict,
+}
+
+var denylistCmd = &cobra.Command{
+	Use:   "denylist",
+	Short: "Manage the recipients odyssey pay refuses",
+	Long: `Manage the deny-list of recipient addresses. 'odyssey pay' refuses to send
+to them, even if they are on the allow-list, e.g. known scam addresses or
+addresses of an account you closed. EVM chains share the Ethereum deny-list.
+
+Examples:
+  odyssey denylist add eth 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6 scam
+  odyssey denylist remove eth 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6
+  odyssey denylist list`,
+	Args: cobra.NoArgs,
+	RunE: runDenylistList,
+}
+
+var denylistListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the deny-list",
+	Args:  cobra.NoArgs,
+	RunE:  runDenylistList,
+}
+
+var denylistAddCmd = &cobra.Command{
+	Use:   "add [chain] [address] [label]",
+	Short: "Refuse payments to an address",
+	Args:  cobra.RangeArgs(2, 3),
+	RunE:  runDenylistAdd,
+}
+
+var denylistRemoveCmd = &cobra.Command{
+	Use:   "remove [chain] [address]",
+	Short: "Remove an address from the deny-list",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runDenylistRemove,
+}
+
+func init() {
+	allowlistCmd.AddCommand(allowlistListCmd)
+	allowlistCmd.AddCommand(allowlistAddCmd)
+	allowlistCmd.AddCommand(allowlistRemoveCmd)
+	allowlistCmd.AddCommand(allowlistStrictCmd)
+
+	denylistCmd.AddCommand(denylistListCmd)
+	denylistCmd.AddCommand(denylistAddCmd)
+	denylistCmd.AddCommand(denylistRemoveCmd)
+}
+
+func runAllowlistList(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🛡️  Strict mode: %s\n", allowlistStrictMode(policy))
+	if len(policy.Whitelist) == 0 {
+		fmt.Println("📭 The allow-list is empty")
+		fmt.Println("💡 Add an address with: odyssey allowlist add [chain] [address] [label]")
+		return nil
+	}
+	fmt.Println()
+	printPolicyRecipients(policy.Whitelist)
+	return nil
+}
+
+func runAllowlistStrict(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+	if err := authorizePolicyChange(cmd, manager); err != nil {
+		return err
+	}
+
+	mode := strings.ToLower(args[0])
+	switch mode {
+	case strictOff:
+		policy.WhitelistOnly = false
+		policy.ConfirmUnlisted = false
+	case strictConfirm:
+		policy.WhitelistOnly = false
+		policy.ConfirmUnlisted = true
+	case strictRefuse:
+		if len(policy.Whitelist) == 0 {
+			return fmt.Errorf("the allow-list is empty, add addresses first with 'odyssey allowlist add'")
+		}
+		policy.WhitelistOnly = true
+		policy.ConfirmUnlisted = false
+	default:
+		return fmt.Errorf("invalid mode: %s. Use off, confirm or refuse", args[0])
+	}
+
+	if err := manager.SaveSpendingPolicy(policy); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Strict mode set to %s\n", mode)
+	return nil
+}
+
+// allowlistStrictMode returns the strict mode of the policy
+func allowlistStrictMode(policy *wallet.SpendingPolicy) string {
+	switch {
+	case policy.WhitelistOnly:
+		return strictRefuse
+	case policy.ConfirmUnlisted:
+		return strictConfirm
+	}
+	return strictOff
+}
+
+func runDenylistList(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+
+	if len(policy.Denylist) == 0 {
+		fmt.Println("📭 The deny-list is empty")
+		fmt.Println("💡 Add an address with: odyssey denylist add [chain] [address] [label]")
+		return nil
+	}
+	fmt.Println("⛔ Deny-list: never paid")
+	fmt.Println()
+	printPolicyRecipients(policy.Denylist)
+	return nil
+}
+
+func runDenylistAdd(cmd *cobra.Command, args []string) error {
+	_, whitelistChain, err := parsePolicyChain(args[0])
+	if err != nil {
+		return err
+	}
+	address, err := normalizeWatchAddress(whitelistChain, strings.TrimSpace(args[1]))
+	if err != nil {
+		return err
+	}
+	label := ""
+	if len(args) == 3 {
+		label = strings.TrimSpace(args[2])
+	}
+
+	manager := wallet.NewManager()
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+	if policy.IsWhitelisted(whitelistChain, address) {
+		fmt.Printf("⚠️  %s is on the allow-list, the deny-list takes precedence\n", address)
+	}
+
+	replaced := false
+	for i, entry := range policy.Denylist {
+		if entry.Chain == whitelistChain && entry.Address == address {
+			policy.Denylist[i].Label = label
+			replaced = true
+		}
+	}
+	if !replaced {
+		policy.Denylist = append(policy.Denylist, wallet.WhitelistedRecipient{Chain: whitelistChain, Address: address, Label: label})
+	}
+
+	if err := manager.SaveSpendingPolicy(policy); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Denied %s address %s\n", whitelistChain, address)
+	return nil
+}
+
+func runDenylistRemove(cmd *cobra.Command, args []string) error {
+	_, whitelistChain, err := parsePolicyChain(args[0])
+	if err != nil {
+		return err
+	}
+
+	manager := wallet.NewManager()
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+	if err := authorizePolicyChange(cmd, manager); err != nil {
+		return err
+	}
+
+	var kept []wallet.WhitelistedRecipient
+	for _, entry := range policy.Denylist {
+		if entry.Chain != whitelistChain || !strings.EqualFold(entry.Address, args[1]) {
+			kept = append(kept, entry)
+		}
+	}
+	if len(kept) == len(policy.Denylist) {
+		return fmt.Errorf("%s is not on the %s deny-list", args[1], whitelistChain)
+	}
+	policy.Denylist = kept
+
+	if err := manager.SaveSpendingPolicy(policy); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Removed %s from the %s deny-list\n", args[1], whitelistChain)
+	return nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+var archiveRootFlag string
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive [file]",
+	Short: "Append known transactions to a tamper-evident archive",
+	Long: `Write all transactions and receipts known to this wallet to an append-only
+archive, so you can later prove your records weren't altered.
+
+Each record is stored with the SHA-256 hash of its content, and each entry
+commits to the hash of the entry before it. Running archive again only
+appends what is new; a transaction that confirmed since is added as a new
+entry and the old one is kept. The merkle root over all entries is printed,
+note it down or send it to someone you trust: the same root later proves
+that the archive up to that point is unchanged.
+
+Records come from what earlier commands have seen, run 'odyssey transactions'
+first to archive your full history. Receipts are transactions sent from this
+wallet and include the time they were broadcast.
+
+The archive is kept at ~/.odyssey/exports/archive.jsonl unless a file is given.
+
+Examples:
+  odyssey archive                              # Update the default archive
+  odyssey archive records.jsonl                # Update another archive
+  odyssey archive verify                       # Check the default archive
+  odyssey archive verify --root 3f2a...        # Check it against a noted root`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runArchive,
+}
+
+var archiveVerifyCmd = &cobra.Command{
+	Use:   "verify [file]",
+	Short: "Check an archive for changes",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runArchiveVerify,
+}
+
+func init() {
+	archiveVerifyCmd.Flags().StringVar(&archiveRootFlag, "root", "", "Merkle root printed earlier, checked against the entries archived at that time")
+
+	archiveCmd.AddCommand(archiveVerifyCmd)
+}
+
+func runArchive(cmd *cobra.Command, args []string) error {
+	path, err := archivePath(args)
+	if err != nil {
+		return err
+	}
+
+	entries, err := wallet.ReadArchive(path)
+	if err != nil {
+		return err
+	}
+	if err := wallet.VerifyArchive(entries); err != nil {
+		return fmt.Errorf("refusing to append to %s, it has been modified: %w", path, err)
+	}
+
+	manager := wallet.NewManager()
+	records, err := manager.ArchiveRecords()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 && len(entries) == 0 {
+		return fmt.Errorf("no transactions known yet. Run 'odyssey transactions' first")
+	}
+
+	added, err := wallet.AppendArchive(path, entries, records)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, added...)
+
+	root, err := wallet.ArchiveMerkleRoot(entries)
+	if err != nil {
+		return err
+	}
+
+	if len(added) == 0 {
+		fmt.Println("✅ Archive is up to date, nothing new to append")
+	} else {
+		fmt.Printf("✅ Appended %d record%s to the archive\n", len(added), plural(len(added)))
+	}
+	fmt.Printf("📍 File: %s\n", path)
+	fmt.Printf("📦 Entries: %d\n", len(entries))
+	fmt.Printf("🔗 Head: %s\n", entries[len(entries)-1].Hash)
+	fmt.Printf("🌳 Merkle root: %s\n", root)
+	fmt.Println()
+	fmt.Println("💡 Keep the merkle root somewhere else, 'odyssey archive verify --root <root>' proves the archive against it")
+
+	return nil
+}
+
+func runArchiveVerify(cmd *cobra.Command, args []string) error {
+	path, err := archivePath(args)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	entries, err := wallet.ReadArchive(path)
+	if err != nil {
+		return err
+	}
+	if err := wallet.VerifyArchive(entries); err != nil {
+		return fmt.Errorf("archive has been modified: %w", err)
+	}
+
+	root, err := wallet.ArchiveMerkleRoot(entries)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Hash chain intact, %d entries\n", len(entries))
+	fmt.Printf("🌳 Merkle root: %s\n", root)
+
+	if archiveRootFlag == "" {
+		return nil
+	}
+
+	// The archive may have grown since the root was noted, so look for the
+	// prefix of entries it was computed over
+	expected := strings.ToLower(strings.TrimSpace(archiveRootFlag))
+	for count := len(entries); count > 0; count-- {
+		prefixRoot, err := wallet.ArchiveMerkleRoot(entries[:count])
+		if err != nil {
+			return err
+		}
+		if prefixRoot == expected {
+			fmt.Printf("✅ Root matches the first %d entries, they are unchanged\n", count)
+			if count < len(entries) {
+				fmt.Printf("   Entries appended afterwards: %d\n", len(entries)-count)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("root %s does not match the archive, its records have been altered", expected)
+}
+
+// archivePath returns the archive file given on the command line or the default one
+func archivePath(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	exportDir, err := prepareExportDirectory()
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare export directory: %w", err)
+	}
+	return filepath.Join(exportDir, "archive.jsonl"), nil
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditLimitFlag  int
+	auditEventFlag  string
+	auditOutputFlag string
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Review the log of unlocks, key exports and sends",
+	Long: `Review the audit log of sensitive operations in ~/.odyssey/audit.log.
+
+Every unlock, display of the recovery phrase, export of key material, send
+(chain, amount, recipient and result) and deletion of the wallet is appended
+to the log. Each entry includes the hash of the one before, keyed with a
+secret sealed in the vault, and the vault keeps the latest entry. With the
+wallet unlocked, 'odyssey audit verify' detects entries that were changed,
+removed or reordered, including entries removed from the end.
+
+Examples:
+  odyssey audit show
+  odyssey audit show --event send --limit 10
+  odyssey audit verify`,
+	Args: cobra.NoArgs,
+	RunE: runAuditShow,
+}
+
+var auditShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the latest entries of the audit log",
+	Args:  cobra.NoArgs,
+	RunE:  runAuditShow,
+}
+
+var auditVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that no entry of the audit log was tampered with",
+	Args:  cobra.NoArgs,
+	RunE:  runAuditVerify,
+}
+
+func init() {
+	auditShowCmd.Flags().IntVarP(&auditLimitFlag, "limit", "n", 20, "Number of latest entries to show, 0 for all")
+	auditShowCmd.Flags().StringVar(&auditEventFlag, "event", "", "Only show one event (unlock, recovery-phrase, key-export, send, 2fa, wallet-delete)")
+	auditShowCmd.Flags().StringVarP(&auditOutputFlag, "output", "o", "text", "Output format (text, json)")
+
+	auditCmd.AddCommand(auditShowCmd)
+	auditCmd.AddCommand(auditVerifyCmd)
+}
+
+// recordAudit appends an event to the audit log, a log that can't be written
+// doesn't stop the command
+func recordAudit(manager *wallet.Manager, entry wallet.AuditEntry) {
+	if err := manager.RecordAudit(entry); err != nil {
+		slog.Warn("failed to write audit log", "event", entry.Event, "error", err)
+	}
+}
+
+func runAuditShow(cmd *cobra.Command, args []string) error {
+	output := strings.ToLower(auditOutputFlag)
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format: %s. Use 'text' or 'json'", auditOutputFlag)
+	}
+	switch auditEventFlag {
+	case "", wallet.AuditUnlock, wallet.AuditRecoveryPhraseShow, wallet.AuditKeyExport, wallet.AuditSend, wallet.AuditTwoFactor, wallet.AuditWalletDelete:
+	default:
+		return fmt.Errorf("invalid event: %s. Use unlock, recovery-phrase, key-export, send, 2fa or wallet-delete", auditEventFlag)
+	}
+
+	manager := wallet.NewManager()
+	entries, err := manager.GetAuditLog()
+	if err != nil {
+		return err
+	}
+
+	var shown []wallet.AuditEntry
+	for _, entry := range entries {
+		if auditEventFlag == "" || entry.Event == auditEventFlag {
+			shown = append(shown, entry)
+		}
+	}
+	if auditLimitFlag > 0 && len(shown) > auditLimitFlag {
+		shown = shown[len(shown)-auditLimitFlag:]
+	}
+
+	if output == "json" {
+		if shown == nil {
+			shown = []wallet.AuditEntry{}
+		}
+		data, err := json.MarshalIndent(shown, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode audit log: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(shown) == 0 {
+		fmt.Println("📭 No audited operations yet")
+		return nil
+	}
+
+	fmt.Println("🧾 Audit Log")
+	fmt.Println()
+	for _, entry := range shown {
+		fmt.Printf("%s #%d %s %s (%s)\n", auditResultIcon(entry.Result), entry.Seq,
+			entry.Time.Local().Format(time.DateTime), entry.Event, entry.Result)
+		if entry.Chain != "" {
+			fmt.Printf("   Chain:   %s\n", entry.Chain)
+		}
+		fmt.Printf("   Network: %s\n", entry.Network)
+		if entry.Amount != "" {
+			fmt.Printf("   Amount:  %s\n", entry.Amount)
+		}
+		if entry.Recipient != "" {
+			fmt.Printf("   To:      %s\n", entry.Recipient)
+		}
+		if entry.Detail != "" {
+			fmt.Printf("   Detail:  %s\n", entry.Detail)
+		}
+		fmt.Println()
+	}
+	fmt.Println("💡 Run 'odyssey audit verify' to check the log wasn't tampered with")
+	return nil
+}
+
+// auditResultIcon returns the icon for an audit result
+func auditResultIcon(result string) string {
+	switch result {
+	case wallet.AuditOK:
+		return "✅"
+	case wallet.AuditQueued:
+		return "⏳"
+	default:
+		return "❌"
+	}
+}
+
+func runAuditVerify(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+	count, err := manager.VerifyAuditLog()
+	if err != nil {
+		var auditErr *wallet.AuditError
+		if errors.As(err, &auditErr) {
+			fmt.Printf("❌ %d entr%s verified, then the chain breaks\n", count, auditEntries(count))
+		}
+		return err
+	}
+
+	if count == 0 {
+		fmt.Println("📭 No audited operations yet")
+		return nil
+	}
+
+	entries, err := manager.GetAuditLog()
+	if err != nil {
+		return err
+	}
+	last := entries[len(entries)-1]
+	fmt.Printf("✅ Audit log intact, %d entr%s verified\n", count, auditEntries(count))
+	fmt.Printf("   Latest: #%d at %s\n", last.Seq, last.Time.Local().Format(time.DateTime))
+	fmt.Printf("   Hash:   %s\n", last.Hash)
+	return nil
+}
+
+// auditEntries returns the ending of "entry" for count entries
+func auditEntries(count int) string {
+	if count == 1 {
+		return "y"
+	}
+	return "ies"
+}
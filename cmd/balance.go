@@ -1,64 +1,75 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"log/slog"
 	"math/big"
-	"strings"
+	"os"
+	"sort"
 
 	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains"
+	"github.com/chinmay1088/odyssey/chains/bitcoin"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/config"
 	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
+var balanceAllWalletsFlag bool
+
 var balanceCmd = &cobra.Command{
 	Use:   "balance [chain]",
 	Short: "Check cryptocurrency balances",
 	Long: `Check your cryptocurrency balances for supported chains.
+
+Without a chain, the total USD value of all chains and each chain's share of
+it are shown below the balances.
 	
-Supported chains: eth, btc, sol
+Supported chains: eth, btc, sol, polygon, arbitrum, optimism, base, bsc
 	
 Examples:
   odyssey balance        # Check all balances
   odyssey balance eth    # Check Ethereum balance
   odyssey balance btc    # Check Bitcoin balance
-  odyssey balance sol    # Check Solana balance`,
+  odyssey balance sol    # Check Solana balance
+  odyssey balance polygon  # Check the balance on another EVM chain
+  odyssey balance --all-wallets  # Combine your wallet and watch-only wallets`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runBalance,
 }
 
 func runBalance(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	manager := wallet.NewManager()
 	client := api.NewClient()
 
+	if balanceAllWalletsFlag {
+		if len(args) > 0 {
+			return fmt.Errorf("--all-wallets cannot be combined with a chain argument")
+		}
+		return runAllWalletsBalance(ctx, manager, client)
+	}
+
 	// Check if wallet is unlocked
 	if !manager.IsUnlocked() {
-		return fmt.Errorf("wallet is locked. Run 'odyssey unlock' first")
+		return errWalletLocked
 	}
 
 	// Determine which chains to check
-	var chains []string
+	var selected []chains.Chain
 	if len(args) == 0 {
-		if manager.IsTestnet() {
-			// Bitcoin not supported in testnet mode
-			chains = []string{"eth", "sol"}
-		} else {
-			chains = []string{"eth", "btc", "sol"}
-		}
+		selected = defaultChains(manager, client)
 	} else {
-		chain := strings.ToLower(args[0])
-		switch chain {
-		case "eth", "ethereum":
-			chains = []string{"eth"}
-		case "btc", "bitcoin":
-			if manager.IsTestnet() {
-				return fmt.Errorf("bitcoin is not supported in testnet mode")
-			}
-			chains = []string{"btc"}
-		case "sol", "solana":
-			chains = []string{"sol"}
-		default:
-			return fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol", chain)
+		chain, err := openChain(args[0], manager, client)
+		if err != nil {
+			return err
 		}
+		selected = []chains.Chain{chain}
 	}
 
 	fmt.Println("💰 Wallet Balances")
@@ -71,147 +82,532 @@ func runBalance(cmd *cobra.Command, args []string) error {
 	fmt.Printf("🌐 Network: %s\n", networkType)
 	fmt.Println()
 
-	for _, chain := range chains {
-		switch chain {
-		case "eth":
-			if err := displayEthereumBalance(manager, client); err != nil {
-				fmt.Printf("❌ Ethereum: Error - %v\n", err)
-			}
-		case "btc":
-			if err := displayBitcoinBalance(manager, client); err != nil {
-				fmt.Printf("❌ Bitcoin: Error - %v\n", err)
-			}
-		case "sol":
-			if err := displaySolanaBalance(manager, client); err != nil {
-				fmt.Printf("❌ Solana: Error - %v\n", err)
-			}
+	// All chains share one batched price request, testnet assets have no value
+	var prices *balancePrices
+	if len(selected) > 1 && showFiatValues(manager) {
+		ids := make([]string, len(selected))
+		for i, chain := range selected {
+			ids[i] = chainPriceID(chain)
 		}
+		prices = fetchBalancePrices(ctx, client, ids)
 	}
 
+	// Chains are fetched concurrently into their own buffers, each is shown
+	// in order as soon as the chains before it are done
+	results := make([]chainBalance, len(selected))
+	done := make([]chan struct{}, len(selected))
+	var g errgroup.Group
+	for i, chain := range selected {
+		done[i] = make(chan struct{})
+		g.Go(func() error {
+			defer close(done[i])
+			displayChainBalance(ctx, &results[i], manager, client, chain, prices)
+			return nil
+		})
+	}
+	for i := range selected {
+		<-done[i]
+		os.Stdout.Write(results[i].Bytes())
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if len(selected) > 1 {
+		printPortfolioTotal(results)
+	}
 	return nil
 }
 
-func displayEthereumBalance(manager *wallet.Manager, client *api.Client) error {
-	address, err := manager.GetEthereumAddress()
+// chainBalance is the output of one chain's balance and its USD value
+type chainBalance struct {
+	bytes.Buffer
+	name   string
+	usd    decimal.Decimal
+	priced bool
+}
+
+// setValue records the USD value of a chain's balance for the total
+func (b *chainBalance) setValue(name string, usd decimal.Decimal) {
+	b.name = name
+	b.usd = usd
+	b.priced = true
+}
+
+// printPortfolioTotal prints the USD value of all chains together and the
+// share of each. Chains without a price are left out and listed.
+func printPortfolioTotal(results []chainBalance) {
+	total := decimal.Zero
+	var unpriced int
+	for _, result := range results {
+		if result.priced {
+			total = total.Add(result.usd)
+		} else {
+			unpriced++
+		}
+	}
+	if unpriced == len(results) {
+		return
+	}
+
+	fmt.Printf("💰 Total Value: %s\n", formatUSD(total))
+	for _, result := range results {
+		if !result.priced {
+			continue
+		}
+		share := decimal.Zero
+		if total.IsPositive() {
+			share = result.usd.Div(total).Mul(decimal.NewFromInt(100))
+		}
+		fmt.Printf("   %-10s %14s %6s%%\n", result.name, formatUSD(result.usd), share.StringFixed(1))
+	}
+	if unpriced > 0 {
+		fmt.Println("   ⚠️  Chains without a price are not included")
+	}
+}
+
+// displayChainBalance shows the balance of one chain, or why it failed
+func displayChainBalance(ctx context.Context, out *chainBalance, manager *wallet.Manager, client *api.Client, chain chains.Chain, prices *balancePrices) {
+	var err error
+	if chain.Name() == "bitcoin" {
+		err = displayBitcoinBalance(ctx, out, manager, client, prices)
+	} else {
+		err = displayNativeBalance(ctx, out, manager, client, chain, prices)
+	}
+	if err != nil {
+		fmt.Fprintf(out, "❌ %s: Error - %v\n", chainLabel(chain), err)
+	}
+}
+
+// balancePrices are the prices of several assets fetched in one request in
+// the background
+type balancePrices struct {
+	wait func() (map[string]*api.PriceData, error)
+}
+
+// fetchBalancePrices starts fetching the prices of several assets at once
+func fetchBalancePrices(ctx context.Context, client *api.Client, ids []string) *balancePrices {
+	var g errgroup.Group
+	var prices map[string]*api.PriceData
+	g.Go(func() error {
+		var err error
+		prices, err = client.GetPrices(ctx, ids)
+		return err
+	})
+	return &balancePrices{wait: func() (map[string]*api.PriceData, error) {
+		err := g.Wait()
+		return prices, err
+	}}
+}
+
+// fetch returns a function that waits for the price of one asset, taken from
+// the batch or, without one, fetched on its own
+func (p *balancePrices) fetch(ctx context.Context, client *api.Client, id string) func() (*api.PriceData, error) {
+	if p == nil {
+		return fetchPrice(ctx, client, id)
+	}
+	return func() (*api.PriceData, error) {
+		prices, err := p.wait()
+		if err != nil {
+			return nil, err
+		}
+		price, ok := prices[id]
+		if !ok {
+			return nil, fmt.Errorf("no price for %s", id)
+		}
+		return price, nil
+	}
+}
+
+// fetchPrice starts fetching a price in the background, the returned
+// function waits for it
+func fetchPrice(ctx context.Context, client *api.Client, id string) func() (*api.PriceData, error) {
+	var g errgroup.Group
+	var price *api.PriceData
+	g.Go(func() error {
+		var err error
+		price, err = client.GetPrice(ctx, id)
+		return err
+	})
+	return func() (*api.PriceData, error) {
+		err := g.Wait()
+		return price, err
+	}
+}
+
+// displayNativeBalance shows the balance of a chain's native asset at the
+// wallet's address, with its USD value where assets have one
+func displayNativeBalance(ctx context.Context, out *chainBalance, manager *wallet.Manager, client *api.Client, chain chains.Chain, prices *balancePrices) error {
+	address, err := chain.Address()
 	if err != nil {
 		return fmt.Errorf("failed to get address: %w", err)
 	}
 
-	balance, err := client.GetEthereumBalance(address.Hex())
+	// The price is fetched while the balance is
+	var getPrice func() (*api.PriceData, error)
+	if showFiatValues(manager) {
+		getPrice = prices.fetch(ctx, client, chainPriceID(chain))
+	}
+
+	balance, err := chain.Balance(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch balance: %w", err)
 	}
+	noteBalance(balance.Sign() > 0)
 
-	ethBalance := formatEthereumBalance(balance)
-
-	if manager.IsTestnet() {
-		fmt.Printf("🔷 Ethereum (Sepolia): %s\n", ethBalance)
-	} else {
-		// Always show USD on mainnet
-		price, err := client.GetPrice("ethereum")
-		if err != nil {
-			fmt.Printf("🔷 Ethereum: %s\n", ethBalance)
-			fmt.Printf("   💵 USD: Error fetching price - %v\n", err)
-		} else {
-			ethValue := float64(balance.Uint64()) / 1e18
-			usdValue := ethValue * price.USD.InexactFloat64()
-			fmt.Printf("🔷 Ethereum: %s (~$%.2f)\n", ethBalance, usdValue)
+	amount := api.TokenAmount(balance, chain.Decimals())
+	_, places := nativeAssetFormat(chain.Name())
+	label := chainLabel(chain)
+	line := fmt.Sprintf("%s %s: %s %s", chainEmoji(chain), label, amount.StringFixed(places), chain.Symbol())
+	if getPrice == nil {
+		fmt.Fprintln(out, line)
+	} else if price, err := getPrice(); err != nil {
+		fmt.Fprintln(out, line)
+		if balance.Sign() > 0 {
+			fmt.Fprintf(out, "   💵 USD: Error fetching price - %v\n", err)
 		}
+	} else {
+		usdValue := fiatValue(balance, chain.Decimals(), price.USD)
+		out.setValue(label, usdValue)
+		fmt.Fprintf(out, "%s (~%s)\n", line, formatUSD(usdValue))
 	}
 
-	fmt.Printf("   📍 Address: %s\n", address.Hex())
-	fmt.Println()
+	// ETH staked with Lido or Rocket Pool is held as stETH and rETH
+	if chain.Name() == "ethereum" && client.EthereumChainID() == 1 {
+		displayStakedETH(ctx, out, client, address, label, getPrice)
+	}
+
+	// A Solana account without a balance doesn't exist on-chain yet
+	if chain.Name() == "solana" && balance.Sign() == 0 {
+		fmt.Fprintf(out, "   ℹ️ Note: This account doesn't exist on-chain yet. Send SOL to this address to activate it.\n")
+	}
+
+	fmt.Fprintf(out, "   📍 Address: %s\n", address)
+	fmt.Fprintln(out)
 	return nil
 }
 
-func displayBitcoinBalance(manager *wallet.Manager, client *api.Client) error {
-	// Bitcoin is only supported in mainnet
-	if manager.IsTestnet() {
+// displayStakedETH shows the ETH staked with Lido and Rocket Pool, if any
+func displayStakedETH(ctx context.Context, out *chainBalance, client *api.Client, address, label string, getPrice func() (*api.PriceData, error)) {
+	staked, err := getStakedETH(ctx, client, address)
+	if err != nil {
+		slog.Debug("failed to fetch staked ETH", "error", err)
+		return
+	}
+	if staked.stETH.Sign() > 0 {
+		fmt.Fprintf(out, "   🥩 Lido: %s stETH\n", ethereum.WeiToEther(staked.stETH).StringFixed(6))
+	}
+	if staked.rETH.Sign() > 0 {
+		fmt.Fprintf(out, "   🥩 Rocket Pool: %s rETH (~%s ETH)\n", ethereum.WeiToEther(staked.rETH).StringFixed(6), ethereum.WeiToEther(staked.rETHValue).StringFixed(6))
+	}
+	if staked.total().Sign() == 0 || getPrice == nil {
+		return
+	}
+	if price, err := getPrice(); err == nil {
+		usdValue := fiatValue(staked.total(), 18, price.USD)
+		// Staked ETH counts toward the chain's share of the portfolio
+		out.setValue(label, out.usd.Add(usdValue))
+		fmt.Fprintf(out, "   💵 Staked: ~%s\n", formatUSD(usdValue))
+	}
+}
+
+func displayBitcoinBalance(ctx context.Context, out *chainBalance, manager *wallet.Manager, client *api.Client, prices *balancePrices) error {
+	// Bitcoin is only supported in mainnet and regtest
+	if !manager.BitcoinSupported() {
 		return fmt.Errorf("bitcoin is not supported in testnet mode")
 	}
 
-	address, err := manager.GetBitcoinAddress()
+	// Older wallets may hold funds on legacy and nested SegWit addresses
+	accounts, err := manager.GetBitcoinAccounts()
 	if err != nil {
 		return fmt.Errorf("failed to get address: %w", err)
 	}
+	addresses := make([]string, len(accounts))
+	for i, account := range accounts {
+		addresses[i] = account.Address.String()
+	}
 
-	balance, err := client.GetBitcoinBalance(address.String())
+	getPrice := prices.fetch(ctx, client, "bitcoin")
+	balances, err := client.GetBitcoinBalances(ctx, addresses)
 	if err != nil {
 		return fmt.Errorf("failed to fetch balance: %w", err)
 	}
+	satoshis := int64(0)
+	for _, amount := range balances {
+		satoshis += amount
+	}
+	noteBalance(satoshis > 0)
+	balance := bitcoin.SatoshisToBTC(satoshis)
 
-	// Always show USD on mainnet (Bitcoin is mainnet only)
-	price, err := client.GetPrice("bitcoin")
+	// Show USD where the network has prices
+	price, err := getPrice()
 	if err != nil {
-		fmt.Printf("🟠 Bitcoin: %.8f BTC\n", balance)
-		fmt.Printf("   💵 USD: Error fetching price - %v\n", err)
+		fmt.Fprintf(out, "🟠 Bitcoin: %s BTC\n", balance.StringFixed(8))
+		fmt.Fprintf(out, "   💵 USD: Error fetching price - %v\n", err)
 	} else {
-		usdValue := balance * price.USD.InexactFloat64()
-		fmt.Printf("🟠 Bitcoin: %.8f BTC (~$%.2f)\n", balance, usdValue)
+		usdValue := balance.Mul(price.USD)
+		out.setValue("Bitcoin", usdValue)
+		fmt.Fprintf(out, "🟠 Bitcoin: %s BTC (~%s)\n", balance.StringFixed(8), formatUSD(usdValue))
 	}
 
-	fmt.Printf("   📍 Address: %s\n", address.String())
-	fmt.Println()
+	fmt.Fprintf(out, "   📍 Address: %s\n", addresses[0])
+	for i, account := range accounts[1:] {
+		if amount := balances[addresses[i+1]]; amount > 0 {
+			fmt.Fprintf(out, "   📍 %s on %s address %s\n", bitcoin.FormatBalance(amount), bitcoinAddressNames[account.Type], addresses[i+1])
+		}
+	}
+	fmt.Fprintln(out)
 	return nil
 }
 
-func displaySolanaBalance(manager *wallet.Manager, client *api.Client) error {
-	address, err := manager.GetSolanaAddress()
-	if err != nil {
-		return fmt.Errorf("failed to get address: %w", err)
+func init() {
+	balanceCmd.Flags().Bool("usd", false, "Show balances in USD")
+	balanceCmd.Flags().BoolVar(&balanceAllWalletsFlag, "all-wallets", false, "Show balances of every wallet and watch-only address")
+}
+
+// walletBalance is a single address balance in the --all-wallets overview
+type walletBalance struct {
+	wallet    string
+	watchOnly bool
+	chain     string
+	address   string
+	others    []string // further addresses of the same wallet, included in amount
+	amount    decimal.Decimal
+	err       error
+}
+
+// balanceChainOrder is the order chains are listed in within a wallet
+var balanceChainOrder = map[string]int{"ethereum": 0, "bitcoin": 1, "solana": 2}
+
+// runAllWalletsBalance fetches the balances of the unlocked wallet and every
+// watch-only address concurrently and prints them with per-wallet subtotals
+func runAllWalletsBalance(ctx context.Context, manager *wallet.Manager, client *api.Client) error {
+	var entries []walletBalance
+
+	if manager.IsUnlocked() {
+		ethAddress, err := manager.GetEthereumAddress()
+		if err != nil {
+			return fmt.Errorf("failed to get Ethereum address: %w", err)
+		}
+		entries = append(entries, walletBalance{wallet: "My Wallet", chain: "ethereum", address: ethAddress.Hex()})
+
+		// Bitcoin is only supported in mainnet and regtest
+		if manager.BitcoinSupported() {
+			btcAddresses, err := bitcoinWalletAddresses(manager)
+			if err != nil {
+				return fmt.Errorf("failed to get Bitcoin address: %w", err)
+			}
+			entries = append(entries, walletBalance{wallet: "My Wallet", chain: "bitcoin", address: btcAddresses[0], others: btcAddresses[1:]})
+		}
+
+		solAddress, err := manager.GetSolanaAddress()
+		if err != nil {
+			return fmt.Errorf("failed to get Solana address: %w", err)
+		}
+		entries = append(entries, walletBalance{wallet: "My Wallet", chain: "solana", address: solAddress.String()})
+
+		imported, err := manager.GetImportedAccounts()
+		if err != nil {
+			return fmt.Errorf("failed to get imported accounts: %w", err)
+		}
+		for _, account := range imported {
+			entries = append(entries, walletBalance{wallet: account.Label, chain: account.Chain, address: account.Address})
+		}
 	}
 
-	balance, err := client.GetSolanaBalance(address.String())
+	watched, err := manager.GetWatchAddresses()
 	if err != nil {
-		return fmt.Errorf("failed to fetch balance: %w", err)
+		return err
+	}
+	for _, entry := range watched {
+		entries = append(entries, walletBalance{wallet: entry.Label, watchOnly: true, chain: entry.Chain, address: entry.Address})
 	}
 
-	solBalance := float64(balance) / 1e9
+	if len(entries) == 0 {
+		return errWalletLocked
+	}
 
+	fmt.Println("💰 All Wallet Balances")
+
+	networkType := "Mainnet"
 	if manager.IsTestnet() {
-		fmt.Printf("🟣 Solana (Devnet): %.9f SOL\n", solBalance)
-	} else {
-		// Always show USD on mainnet
-		price, err := client.GetPrice("solana")
-		if err != nil {
-			fmt.Printf("🟣 Solana: %.9f SOL\n", solBalance)
-			if solBalance > 0 {
-				fmt.Printf("   💵 USD: Error fetching price - %v\n", err)
+		networkType = "Testnet"
+	}
+	fmt.Printf("🌐 Network: %s\n", networkType)
+	if !manager.IsUnlocked() {
+		fmt.Println("⚠️  Wallet is locked, showing watch-only wallets only")
+	}
+	fmt.Println()
+
+	// Fetch every balance concurrently, results keep their position
+	var g errgroup.Group
+	for i := range entries {
+		entry := &entries[i]
+		g.Go(func() error {
+			if entry.chain == "bitcoin" && !manager.BitcoinSupported() {
+				entry.err = fmt.Errorf("bitcoin is not supported in testnet mode")
+				return nil
 			}
-		} else {
-			usdValue := solBalance * price.USD.InexactFloat64()
-			fmt.Printf("🟣 Solana: %.9f SOL (~$%.2f)\n", solBalance, usdValue)
+			if len(entry.others) > 0 {
+				entry.amount, entry.err = fetchBitcoinWalletBalance(ctx, client, append([]string{entry.address}, entry.others...))
+				return nil
+			}
+			entry.amount, entry.err = fetchNativeBalance(ctx, client, entry.chain, entry.address)
+			return nil
+		})
+	}
+
+	// Prices are fetched once for all wallets, testnet assets have no value
+	var prices map[string]*api.PriceData
+	var pricesErr error
+	if !manager.IsTestnet() {
+		g.Go(func() error {
+			prices, pricesErr = client.GetPrices(ctx, []string{"ethereum", "bitcoin", "solana"})
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if pricesErr != nil {
+		prices = nil
+		fmt.Printf("⚠️  Prices unavailable: %v\n\n", pricesErr)
+	}
+
+	total := decimal.Zero
+	for start := 0; start < len(entries); {
+		end := start
+		for end < len(entries) && entries[end].wallet == entries[start].wallet {
+			end++
 		}
+		total = total.Add(printWalletBalances(entries[start:end], prices))
+		start = end
 	}
 
-	// If balance is 0, this account likely doesn't exist on-chain yet
-	if balance == 0 {
-		fmt.Printf("   ℹ️ Note: This account doesn't exist on-chain yet. Send SOL to this address to activate it.\n")
+	if prices != nil {
+		fmt.Printf("💰 Total Value: %s\n", formatUSD(total))
+	} else if manager.IsTestnet() {
+		fmt.Println("ℹ️ USD values are not shown for testnet assets")
 	}
 
-	fmt.Printf("   📍 Address: %s\n", address.String())
-	fmt.Println()
 	return nil
 }
 
-func formatEthereumBalance(balance interface{}) string {
-	// Convert different balance types to appropriate string representation
-	switch b := balance.(type) {
-	case *big.Int:
-		// Convert Wei to Ether (1 ETH = 10^18 Wei)
-		ether := new(big.Float).SetInt(b)
-		ether.Quo(ether, big.NewFloat(1e18))
+// printWalletBalances prints one wallet's balances and returns its USD subtotal
+func printWalletBalances(entries []walletBalance, prices map[string]*api.PriceData) decimal.Decimal {
+	if entries[0].watchOnly {
+		fmt.Printf("🏷️  %s (watch-only)\n", entries[0].wallet)
+	} else {
+		fmt.Printf("🔑 %s\n", entries[0].wallet)
+	}
+
+	sorted := make([]walletBalance, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return balanceChainOrder[sorted[i].chain] < balanceChainOrder[sorted[j].chain]
+	})
+
+	subtotal := decimal.Zero
+	for _, entry := range sorted {
+		if entry.err != nil {
+			fmt.Printf("   %-9s ❌ Error - %v\n", entry.chain, entry.err)
+			continue
+		}
+
+		symbol, places := nativeAssetFormat(entry.chain)
+		value := "N/A"
+		if price, ok := prices[entry.chain]; ok {
+			usd := entry.amount.Mul(price.USD)
+			subtotal = subtotal.Add(usd)
+			value = formatUSD(usd)
+		}
+
+		fmt.Printf("   %-9s %20s %-4s %14s   %s\n", entry.chain, entry.amount.StringFixed(places), symbol, value, entry.address)
+	}
+
+	if prices != nil {
+		fmt.Printf("   %-9s %40s\n", "Subtotal", formatUSD(subtotal))
+	}
+	fmt.Println()
+
+	return subtotal
+}
 
-		// Format to 6 decimal places
-		ethValue, _ := ether.Float64()
-		return fmt.Sprintf("%.6f ETH", ethValue)
-	case uint64:
-		return fmt.Sprintf("%.6f ETH", float64(b)/1e18)
+// fetchNativeBalance returns the native asset balance of an address in whole units
+func fetchNativeBalance(ctx context.Context, client *api.Client, chain, address string) (decimal.Decimal, error) {
+	switch chain {
+	case "ethereum":
+		balance, err := client.GetEthereumBalance(ctx, address)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		return api.TokenAmount(balance, 18), nil
+	case "bitcoin":
+		balance, err := client.GetBitcoinBalance(ctx, address)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		return bitcoin.SatoshisToBTC(balance), nil
+	case "solana":
+		balance, err := client.GetSolanaBalance(ctx, address)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(balance), -9), nil
 	default:
-		return fmt.Sprintf("%v ETH", balance)
+		return decimal.Zero, fmt.Errorf("unsupported chain: %s", chain)
 	}
 }
 
-func init() {
-	balanceCmd.Flags().Bool("usd", false, "Show balances in USD")
+// bitcoinAddressNames names the Bitcoin address types for display
+var bitcoinAddressNames = map[string]string{
+	config.BitcoinAddressP2WPKH:     "native SegWit",
+	config.BitcoinAddressP2TR:       "Taproot",
+	config.BitcoinAddressP2SHP2WPKH: "nested SegWit",
+	config.BitcoinAddressP2PKH:      "legacy",
+}
+
+// bitcoinWalletAddresses returns the address of every Bitcoin address type of
+// the wallet, the type in use first
+func bitcoinWalletAddresses(manager *wallet.Manager) ([]string, error) {
+	accounts, err := manager.GetBitcoinAccounts()
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, len(accounts))
+	for i, account := range accounts {
+		addresses[i] = account.Address.String()
+	}
+	return addresses, nil
+}
+
+// fetchBitcoinWalletBalance returns the combined balance of several Bitcoin
+// addresses in BTC
+func fetchBitcoinWalletBalance(ctx context.Context, client *api.Client, addresses []string) (decimal.Decimal, error) {
+	balances, err := client.GetBitcoinBalances(ctx, addresses)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	total := int64(0)
+	for _, balance := range balances {
+		total += balance
+	}
+	return bitcoin.SatoshisToBTC(total), nil
+}
+
+// nativeAssetFormat returns the symbol and display precision of a chain's native asset
+func nativeAssetFormat(chain string) (string, int32) {
+	switch chain {
+	case "ethereum":
+		return "ETH", 6
+	case "bitcoin":
+		return "BTC", 8
+	case "solana":
+		return "SOL", 9
+	}
+	if evmChain, ok := api.FindEVMChain(chain); ok {
+		return evmChain.Symbol, 6
+	}
+	return "", 6
 }
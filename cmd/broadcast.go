@@ -0,0 +1,454 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+)
+
+// Backoff between automatic broadcast retries
+const (
+	broadcastInitialBackoff = 2 * time.Second
+	broadcastMaxBackoff     = 30 * time.Second
+)
+
+// How often a sent Solana transaction is polled and resent, and how long
+// before waiting for its confirmation gives up
+const (
+	solanaConfirmInterval = 2 * time.Second
+	solanaConfirmTimeout  = 2 * time.Minute
+)
+
+// errPayloadExpired is returned when a signed payload can no longer be broadcast safely
+var errPayloadExpired = errors.New("signed transaction can no longer be broadcast")
+
+// errDuplicateSend is returned when the same payment was sent recently
+var errDuplicateSend = errors.New("the same payment was sent recently")
+
+// broadcastWithRetry sends a signed transaction. It is saved to the queue
+// first, so a send interrupted by a crash or Ctrl+C can be resumed with
+// 'odyssey tx retry', and tracked there until it is confirmed. Transient
+// failures are retried with backoff for the configured retry period. The
+// outcome is recorded in the audit log.
+func broadcastWithRetry(ctx context.Context, manager *wallet.Manager, client *api.Client, pending wallet.PendingBroadcast) (txHash string, err error) {
+	defer func() { auditSend(manager, &pending, txHash, err) }()
+
+	now := time.Now()
+	pending.CreatedAt = now
+	pending.LastAttempt = now
+	pending.Attempts = 1
+	pending.Status = wallet.PendingSigned
+	if saveErr := manager.SavePendingBroadcast(pending); saveErr != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  The transaction couldn't be saved, an interrupted send can't be resumed: %v\n", saveErr)
+	}
+
+	txHash, err = broadcastSignedTransaction(ctx, client, &pending)
+	if err == nil {
+		markPendingSent(manager, &pending)
+		recordSent(manager, &pending, txHash)
+		return txHash, nil
+	}
+	if ctx.Err() != nil {
+		// The node may have received it, the duplicate check covers a re-run
+		recordRecentSend(manager, &pending)
+		fmt.Printf("💾 Interrupted. Run 'odyssey tx retry %s' to resume the broadcast\n", shortID(pending.ID))
+		return "", err
+	}
+	if !api.IsTransientError(err) {
+		// Rejected, there is nothing to resume
+		_ = manager.RemovePendingBroadcast(pending.ID)
+		return "", err
+	}
+
+	period := config.DefaultBroadcastRetryPeriod
+	if cfg, cfgErr := config.Load(); cfgErr == nil {
+		period = cfg.BroadcastRetryPeriod()
+	}
+
+	pending.LastError = err.Error()
+	pending.Status = wallet.PendingQueued
+	if saveErr := manager.SavePendingBroadcast(pending); saveErr != nil {
+		return "", fmt.Errorf("%w (and the transaction could not be queued: %v)", err, saveErr)
+	}
+	recordRecentSend(manager, &pending)
+
+	fmt.Printf("⚠️  Broadcast failed: %v\n", err)
+	if period == 0 {
+		fmt.Printf("💾 Saved for later. Run 'odyssey tx retry %s' to try again\n", shortID(pending.ID))
+		return "", fmt.Errorf("broadcast failed: %w", err)
+	}
+	fmt.Printf("🔁 Retrying for up to %s...\n", period)
+
+	deadline := now.Add(period)
+	backoff := broadcastInitialBackoff
+	for time.Now().Add(backoff).Before(deadline) {
+		if sleepContext(ctx, backoff) != nil {
+			fmt.Printf("💾 Transaction is still queued. Run 'odyssey tx retry %s' to try again\n", shortID(pending.ID))
+			return "", fmt.Errorf("broadcast interrupted: %w", err)
+		}
+
+		txHash, err = retryPendingBroadcast(ctx, manager, client, &pending)
+		if err == nil {
+			return txHash, nil
+		}
+		if errors.Is(err, errPayloadExpired) || !api.IsTransientError(err) {
+			return "", err
+		}
+
+		fmt.Printf("   Attempt %d failed: %v\n", pending.Attempts, err)
+		backoff *= 2
+		if backoff > broadcastMaxBackoff {
+			backoff = broadcastMaxBackoff
+		}
+	}
+
+	fmt.Printf("💾 Transaction is still queued. Run 'odyssey tx retry %s' to try again\n", shortID(pending.ID))
+	return "", fmt.Errorf("broadcast did not succeed within %s: %w", period, err)
+}
+
+// retryPendingBroadcast makes one more attempt to broadcast a queued transaction.
+// The payload is checked for expiry first, and the queue is updated with the result.
+func retryPendingBroadcast(ctx context.Context, manager *wallet.Manager, client *api.Client, pending *wallet.PendingBroadcast) (string, error) {
+	landed, reason, err := checkPendingBroadcast(ctx, client, pending)
+	if err != nil {
+		return "", err
+	}
+
+	if landed {
+		markPendingSent(manager, pending)
+		return pending.ID, nil
+	}
+
+	if reason != "" {
+		pending.Status = wallet.PendingExpired
+		pending.LastError = reason
+		if err := manager.SavePendingBroadcast(*pending); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("%w: %s", errPayloadExpired, reason)
+	}
+
+	pending.Attempts++
+	pending.LastAttempt = time.Now()
+
+	txHash, err := broadcastSignedTransaction(ctx, client, pending)
+	if err != nil {
+		pending.LastError = err.Error()
+		if !api.IsTransientError(err) {
+			pending.Status = wallet.PendingFailed
+		}
+		if saveErr := manager.SavePendingBroadcast(*pending); saveErr != nil {
+			return "", saveErr
+		}
+		return "", err
+	}
+
+	markPendingSent(manager, pending)
+	recordSent(manager, pending, txHash)
+	return txHash, nil
+}
+
+// markPendingSent records in the queue that a node accepted the transaction,
+// it is tracked there until confirmed. Its spend is counted towards the daily
+// limit here, so a send replayed from the queue counts as well.
+func markPendingSent(manager *wallet.Manager, pending *wallet.PendingBroadcast) {
+	pending.Status = wallet.PendingSent
+	pending.LastError = ""
+	if err := manager.SavePendingBroadcast(*pending); err != nil {
+		slog.Debug("sent transaction not recorded in the queue", "id", pending.ID, "error", err)
+	}
+	recordPolicySpend(manager, pending)
+}
+
+// setPendingStatus records the final state of a transaction tracked in the
+// queue, if it is there
+func setPendingStatus(manager *wallet.Manager, id, status, reason string) {
+	entry, err := manager.FindPendingBroadcast(id)
+	if err != nil {
+		return
+	}
+	entry.Status = status
+	entry.LastError = reason
+	if status == wallet.PendingConfirmed {
+		entry.ConfirmedAt = time.Now()
+	}
+	if err := manager.SavePendingBroadcast(*entry); err != nil {
+		slog.Debug("transaction status not recorded in the queue", "id", id, "error", err)
+	}
+}
+
+// auditSend records the outcome of a broadcast in the audit log, sends left
+// in the retry queue are recorded as queued
+func auditSend(manager *wallet.Manager, pending *wallet.PendingBroadcast, txHash string, err error) {
+	entry := wallet.AuditEntry{
+		Event:     wallet.AuditSend,
+		Network:   pending.Network,
+		Chain:     pending.Chain,
+		Amount:    pending.Amount,
+		Recipient: pending.To,
+		Result:    wallet.AuditOK,
+		Detail:    txHash,
+	}
+	if err != nil {
+		entry.Result = wallet.AuditFailed
+		if queued, findErr := manager.FindPendingBroadcast(pending.ID); findErr == nil && queued.Status == wallet.PendingQueued {
+			entry.Result = wallet.AuditQueued
+		}
+		entry.Detail = err.Error()
+	}
+	recordAudit(manager, entry)
+}
+
+// checkDuplicateSend refuses a payment of exactly value, in the smallest unit
+// of the asset, to the same address as another one sent within
+// safety.duplicate_window, so re-running a command after a timeout doesn't pay
+// twice. A queued original is retried instead, otherwise its hash is shown to
+// track.
+func checkDuplicateSend(ctx context.Context, manager *wallet.Manager, client *api.Client, chain, to string, value *big.Int) error {
+	window := config.DefaultDuplicateWindow
+	if cfg, err := config.Load(); err == nil {
+		window = cfg.DuplicateWindow()
+	}
+	if window == 0 {
+		return nil
+	}
+
+	original, err := manager.FindRecentSend(chain, manager.GetCurrentNetwork(), to, value.String(), window)
+	if err != nil {
+		slog.Debug("recent sends unavailable", "error", err)
+		return nil
+	}
+	if original == nil {
+		return nil
+	}
+
+	fmt.Printf("⚠️  %s was already sent to %s %s ago\n", original.Amount, original.To, formatElapsed(time.Since(original.SentAt)))
+	fmt.Printf("📝 Original Transaction: %s\n", original.ID)
+	if queued, err := manager.FindPendingBroadcast(original.ID); err == nil && queued.Resumable() {
+		fmt.Println("🔁 The original hasn't reached the network yet, retrying it instead...")
+		if txHash, err := retryPendingBroadcast(ctx, manager, client, queued); err != nil {
+			fmt.Printf("⚠️  Retry failed: %v\n", err)
+		} else {
+			fmt.Printf("✅ Original transaction sent: %s\n", txHash)
+		}
+	}
+	printExplorerLink(explorerURL(original.Chain, "tx", original.ID))
+	return fmt.Errorf("%w, nothing new was sent. Check the original with 'odyssey tx show %s', or pay again with --force", errDuplicateSend, original.ID)
+}
+
+// recordRecentSend remembers a broadcast or queued payment for the duplicate check
+func recordRecentSend(manager *wallet.Manager, pending *wallet.PendingBroadcast) {
+	err := manager.RecordRecentSend(wallet.RecentSend{
+		ID:      pending.ID,
+		Chain:   pending.Chain,
+		Network: pending.Network,
+		To:      pending.To,
+		Amount:  pending.Amount,
+		Value:   pending.Value,
+	})
+	if err != nil {
+		slog.Debug("recent send not recorded", "error", err)
+	}
+}
+
+// recordSent caches a sent transaction for the summary on unlock, until the
+// history shows it confirmed. The nonce of an EVM transaction is tracked so
+// the next one doesn't reuse it before the node sees this one.
+func recordSent(manager *wallet.Manager, pending *wallet.PendingBroadcast, txHash string) {
+	recordRecentSend(manager, pending)
+	_ = manager.RecordTransactions([]wallet.ActivityTransaction{{
+		Chain:   pending.Chain,
+		Network: pending.Network,
+		Hash:    txHash,
+		Amount:  pending.Amount,
+		SentAt:  time.Now(),
+	}})
+
+	if _, evm := api.FindEVMChain(pending.Chain); evm || pending.Chain == "ethereum" {
+		_ = manager.RecordNonce(pending.Chain, pending.Network, pending.From, pending.Nonce)
+	}
+}
+
+// ethNonceFlag overrides the nonce of an EVM transaction, -1 picks the next free one
+var ethNonceFlag int64 = -1
+
+// nextEthereumNonce returns the --nonce flag, or the next nonce of address
+// after its pending transactions and the ones this wallet sent recently
+func nextEthereumNonce(ctx context.Context, manager *wallet.Manager, client *api.Client, chain, address string) (uint64, error) {
+	if ethNonceFlag >= 0 {
+		mined, err := client.GetEthereumNonce(ctx, address)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get nonce: %w", err)
+		}
+		if uint64(ethNonceFlag) < mined {
+			return 0, fmt.Errorf("nonce %d has already been used, the account is at nonce %d", ethNonceFlag, mined)
+		}
+		return uint64(ethNonceFlag), nil
+	}
+
+	pending, err := client.GetEthereumPendingNonce(ctx, address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	return manager.NextNonce(chain, manager.GetCurrentNetwork(), address, pending)
+}
+
+// checkPendingBroadcast reports whether a queued transaction already reached the
+// network, or why it can no longer be broadcast
+func checkPendingBroadcast(ctx context.Context, client *api.Client, pending *wallet.PendingBroadcast) (landed bool, expired string, err error) {
+	client, chain := broadcastChain(client, pending)
+	switch chain {
+	case "ethereum":
+		known, err := client.EthereumTransactionKnown(ctx, pending.ID)
+		if err != nil || known {
+			return known, "", err
+		}
+		// A mined transaction with the same nonce makes this one invalid
+		nonce, err := client.GetEthereumNonce(ctx, pending.From)
+		if err != nil {
+			return false, "", err
+		}
+		if nonce > pending.Nonce {
+			return false, fmt.Sprintf("nonce %d has already been used by another transaction", pending.Nonce), nil
+		}
+		return false, "", nil
+
+	case "bitcoin":
+		// Spent inputs are reported by the node when the transaction is sent
+		known, err := client.BitcoinTransactionKnown(ctx, pending.ID)
+		return known, "", err
+
+	case "solana":
+		known, err := client.SolanaTransactionKnown(ctx, pending.ID)
+		if err != nil || known {
+			return known, "", err
+		}
+		// A durable nonce stays valid until the nonce is advanced
+		if pending.NonceAccount != "" {
+			account, err := client.GetSolanaNonceAccount(ctx, pending.NonceAccount)
+			if err != nil {
+				return false, "", err
+			}
+			if account == nil || account.Nonce != pending.Blockhash {
+				return false, "the durable nonce has been advanced, the transaction must be signed again", nil
+			}
+			return false, "", nil
+		}
+		valid, err := client.IsSolanaBlockhashValid(ctx, pending.Blockhash)
+		if err != nil {
+			return false, "", err
+		}
+		if !valid {
+			return false, "recent blockhash has expired, the transaction must be signed again", nil
+		}
+		return false, "", nil
+
+	default:
+		return false, "", fmt.Errorf("unsupported chain: %s", pending.Chain)
+	}
+}
+
+// waitForSolanaConfirmation polls the status of a sent Solana transaction
+// until it is confirmed, resending the same signed bytes while the cluster
+// hasn't seen it. Nodes drop transactions under load, so a signature returned
+// by sendTransaction is no proof it will land. A transaction still unseen past
+// lastValidBlockHeight can never land and errPayloadExpired is returned; with
+// a lastValidBlockHeight of 0 its blockhash or durable nonce is checked
+// instead.
+func waitForSolanaConfirmation(ctx context.Context, manager *wallet.Manager, client *api.Client, pending *wallet.PendingBroadcast, lastValidBlockHeight uint64) error {
+	fmt.Println("⏳ Waiting for confirmation...")
+
+	deadline := time.Now().Add(solanaConfirmTimeout)
+	for {
+		status, err := client.GetSolanaSignatureStatus(ctx, pending.ID)
+		switch {
+		case err != nil:
+			slog.Debug("failed to poll Solana signature status", "signature", pending.ID, "error", err)
+
+		case status != nil && status.Failed():
+			failure := api.DecodeSolanaError(status.Err, nil)
+			setPendingStatus(manager, pending.ID, wallet.PendingFailed, failure.Error())
+			return fmt.Errorf("transaction failed on chain: %w", failure)
+
+		case status != nil && (status.ConfirmationStatus == "confirmed" || status.ConfirmationStatus == "finalized"):
+			setPendingStatus(manager, pending.ID, wallet.PendingConfirmed, "")
+			fmt.Printf("✅ Transaction %s\n", status.ConfirmationStatus)
+			return nil
+
+		case status == nil:
+			if reason := solanaPayloadExpiry(ctx, client, pending, lastValidBlockHeight); reason != "" {
+				setPendingStatus(manager, pending.ID, wallet.PendingExpired, reason)
+				return fmt.Errorf("%w: %s", errPayloadExpired, reason)
+			}
+			if _, err := client.SendSolanaTransaction(ctx, pending.SignedTx); err != nil {
+				slog.Debug("failed to resend Solana transaction", "signature", pending.ID, "error", err)
+			}
+		}
+
+		if time.Now().After(deadline) {
+			fmt.Printf("⚠️  Not confirmed after %s, it may still land. Check it with 'odyssey tx show sol %s'\n", solanaConfirmTimeout, pending.ID)
+			return nil
+		}
+		if err := sleepContext(ctx, solanaConfirmInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// solanaPayloadExpiry returns why a Solana transaction the cluster hasn't seen
+// can no longer land, or "" while it still can
+func solanaPayloadExpiry(ctx context.Context, client *api.Client, pending *wallet.PendingBroadcast, lastValidBlockHeight uint64) string {
+	if lastValidBlockHeight == 0 {
+		_, reason, err := checkPendingBroadcast(ctx, client, pending)
+		if err != nil {
+			return ""
+		}
+		return reason
+	}
+
+	height, err := client.GetSolanaBlockHeight(ctx)
+	if err != nil || height <= lastValidBlockHeight {
+		return ""
+	}
+	return "the transaction was dropped before it was confirmed and its blockhash has expired, nothing was sent"
+}
+
+// broadcastSignedTransaction sends a signed transaction once
+func broadcastSignedTransaction(ctx context.Context, client *api.Client, pending *wallet.PendingBroadcast) (string, error) {
+	client, chain := broadcastChain(client, pending)
+	switch chain {
+	case "ethereum":
+		return client.SendEthereumTransaction(ctx, pending.SignedTx)
+	case "bitcoin":
+		return client.SendBitcoinTransaction(ctx, pending.SignedTx)
+	case "solana":
+		return client.SendSolanaTransaction(ctx, pending.SignedTx)
+	default:
+		return "", fmt.Errorf("unsupported chain: %s", pending.Chain)
+	}
+}
+
+// broadcastChain returns the client and chain family of a queued transaction.
+// Other EVM chains are handled like Ethereum against their own node.
+func broadcastChain(client *api.Client, pending *wallet.PendingBroadcast) (*api.Client, string) {
+	if chain, ok := api.FindEVMChain(pending.Chain); ok {
+		return client.ForEVMChain(chain), "ethereum"
+	}
+	return client, pending.Chain
+}
+
+// shortID shortens a transaction ID for display
+func shortID(id string) string {
+	if len(id) <= 16 {
+		return id
+	}
+	return id[:16]
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains"
+	"github.com/chinmay1088/odyssey/chains/bitcoin"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/chains/solana"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+)
+
+// walletChain is a chain commands list by default, other EVM chains are only
+// used when named
+type walletChain struct {
+	name    string
+	aliases []string
+	emoji   string
+	label   func() string
+	open    func(manager *wallet.Manager, client *api.Client) chains.Chain
+}
+
+// walletChains are the chains of the wallet in display order
+var walletChains = []walletChain{
+	{
+		name: "ethereum", aliases: []string{"eth"}, emoji: "🔷", label: ethereumLabel,
+		open: func(manager *wallet.Manager, client *api.Client) chains.Chain {
+			return ethereum.NewChain(manager, client)
+		},
+	},
+	{
+		name: "bitcoin", aliases: []string{"btc"}, emoji: "🟠", label: bitcoinLabel,
+		open: func(manager *wallet.Manager, client *api.Client) chains.Chain {
+			return bitcoin.NewChain(manager, client)
+		},
+	},
+	{
+		name: "solana", aliases: []string{"sol"}, emoji: "🟣", label: solanaLabel,
+		open: func(manager *wallet.Manager, client *api.Client) chains.Chain {
+			return solana.NewChain(manager, client)
+		},
+	},
+}
+
+// openChain opens a chain of the wallet by name or alias. Bitcoin is only
+// available on networks that have it.
+func openChain(name string, manager *wallet.Manager, client *api.Client) (chains.Chain, error) {
+	name = strings.ToLower(name)
+	for _, entry := range walletChains {
+		if entry.name != name && !slices.Contains(entry.aliases, name) {
+			continue
+		}
+		if entry.name == "bitcoin" && !manager.BitcoinSupported() {
+			return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
+		}
+		return entry.open(manager, client), nil
+	}
+	if evmChain, ok := api.FindEVMChain(name); ok {
+		return ethereum.NewEVMChain(manager, client, evmChain), nil
+	}
+	return nil, fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol, %s", name, strings.Join(api.EVMChainNames(), ", "))
+}
+
+// defaultChains opens the chains shown when none is named: Ethereum, Bitcoin
+// where the network has it, and Solana
+func defaultChains(manager *wallet.Manager, client *api.Client) []chains.Chain {
+	var opened []chains.Chain
+	for _, entry := range walletChains {
+		if entry.name == "bitcoin" && !manager.BitcoinSupported() {
+			continue
+		}
+		opened = append(opened, entry.open(manager, client))
+	}
+	return opened
+}
+
+// chainLabel names a chain including the test network in use
+func chainLabel(chain chains.Chain) string {
+	for _, entry := range walletChains {
+		if entry.name == chain.Name() {
+			return entry.label()
+		}
+	}
+	if evmChain, ok := api.FindEVMChain(chain.Name()); ok {
+		return evmChain.Label(config.ActiveNetwork().IsTestnet())
+	}
+	return chain.Name()
+}
+
+// chainEmoji returns the icon a chain is listed with
+func chainEmoji(chain chains.Chain) string {
+	for _, entry := range walletChains {
+		if entry.name == chain.Name() {
+			return entry.emoji
+		}
+	}
+	return "🔷"
+}
+
+// chainPriceID returns the price API ID of a chain's native asset
+func chainPriceID(chain chains.Chain) string {
+	if evmChain, ok := api.FindEVMChain(chain.Name()); ok {
+		return evmChain.PriceID
+	}
+	return chain.Name()
+}
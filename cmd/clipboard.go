@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/clipboard"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// clipboardClearAfterFlag is how long the clearing process waits
+var clipboardClearAfterFlag time.Duration
+
+// clipboardClearCmd runs in the background after 'address --copy' and
+// empties the clipboard unless something else was copied meanwhile
+var clipboardClearCmd = &cobra.Command{
+	Use:    "clipboard-clear [text]",
+	Short:  "Clear the clipboard after a delay if it still holds text",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := sleepContext(cmd.Context(), clipboardClearAfterFlag); err != nil {
+			return err
+		}
+		return clipboard.ClearIf(args[0])
+	},
+}
+
+func init() {
+	clipboardClearCmd.Flags().DurationVar(&clipboardClearAfterFlag, "after", 30*time.Second, "Delay before clearing")
+}
+
+// copyToClipboard copies text and, for a positive clearAfter, starts a
+// background process that clears it again
+func copyToClipboard(text string, clearAfter time.Duration) error {
+	if err := clipboard.Write(text); err != nil {
+		return err
+	}
+	if clearAfter <= 0 {
+		fmt.Fprintln(promptOut(), "📋 Copied to the clipboard")
+		return nil
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the odyssey executable: %w", err)
+	}
+	process := exec.Command(executable, "clipboard-clear", "--after", clearAfter.String(), text)
+	detachProcess(process)
+	if err := process.Start(); err != nil {
+		return fmt.Errorf("failed to schedule clearing the clipboard: %w", err)
+	}
+	process.Process.Release()
+
+	fmt.Fprintf(promptOut(), "📋 Copied to the clipboard, cleared in %s\n", formatSessionTimeout(clearAfter))
+	return nil
+}
+
+// verifyPastedRecipient shows the recipient of a payment in its checksummed
+// form, in groups of 4 characters that are easier to compare, and points out
+// where it differs from an address in the clipboard
+func verifyPastedRecipient(chain, recipient string) {
+	_, whitelistChain, err := parsePolicyChain(chain)
+	if err != nil {
+		return
+	}
+	normalized, err := normalizeWatchAddress(whitelistChain, strings.TrimSpace(recipient))
+	if err != nil {
+		return // the payment reports the invalid address
+	}
+
+	out := promptOut()
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "📬 Recipient: %s\n", groupAddress(normalized))
+
+	pasted, err := clipboard.Read()
+	if err != nil {
+		return
+	}
+	pasted, err = normalizeWatchAddress(whitelistChain, strings.TrimSpace(pasted))
+	if err != nil {
+		return // no address of this chain in the clipboard
+	}
+	if pasted == normalized {
+		fmt.Fprintln(out, "   ✅ Matches the address in the clipboard")
+		return
+	}
+
+	fmt.Fprintln(out, "   ⚠️  The clipboard holds a different address, check which one is right:")
+	// Ethereum checksums differ with any change, only the hex digits count
+	differs := func(i int) bool {
+		if i >= len(normalized) {
+			return true
+		}
+		if whitelistChain == "ethereum" {
+			return !strings.EqualFold(pasted[i:i+1], normalized[i:i+1])
+		}
+		return pasted[i] != normalized[i]
+	}
+	fmt.Fprintf(out, "   Clipboard: %s\n", groupAddress(highlightDifferences(pasted, differs)))
+	if color.NoColor {
+		fmt.Fprintf(out, "              %s\n", strings.TrimRight(groupAddress(differenceMarkers(pasted, differs)), " "))
+	}
+}
+
+// groupAddress splits an address into groups of 4 characters after its 0x
+// prefix. Color codes added by highlightDifferences aren't counted.
+func groupAddress(address string) string {
+	var b strings.Builder
+	count := 0
+	if strings.HasPrefix(address, "0x") {
+		b.WriteString("0x")
+		address = address[2:]
+	}
+	inEscape := false
+	for _, r := range address {
+		switch {
+		case r == '\x1b':
+			inEscape = true
+		case inEscape:
+			inEscape = r != 'm'
+		default:
+			if count > 0 && count%4 == 0 {
+				b.WriteByte(' ')
+			}
+			count++
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// highlightDifferences colors the characters of address at the positions
+// differs reports
+func highlightDifferences(address string, differs func(int) bool) string {
+	highlight := color.New(color.FgRed, color.Bold, color.Underline)
+	var b strings.Builder
+	for i := 0; i < len(address); i++ {
+		if differs(i) {
+			b.WriteString(highlight.Sprint(address[i : i+1]))
+		} else {
+			b.WriteByte(address[i])
+		}
+	}
+	return b.String()
+}
+
+// differenceMarkers returns a line with ^ under the positions of address
+// differs reports, for terminals without colors
+func differenceMarkers(address string, differs func(int) bool) string {
+	markers := make([]byte, len(address))
+	for i := range markers {
+		markers[i] = ' '
+		if differs(i) {
+			markers[i] = '^'
+		}
+	}
+	return string(markers)
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+// Completions of the shells installed with 'odyssey completion', see
+// cobra's default completion command. Dynamic ones read the local wallet
+// files only and never need the wallet unlocked or the network.
+
+func init() {
+	allChains := append(coreChainCompletions("eth", "btc", "sol"), evmChainCompletions()...)
+
+	for _, cmd := range []*cobra.Command{addressCmd, balanceCmd, feesCmd, receiveCmd, transactionsCmd} {
+		cmd.ValidArgsFunction = completeArgs(allChains)
+	}
+	watchCmd.ValidArgsFunction = completeArgs(coreChainCompletions("eth", "btc", "sol"))
+	buyCmd.ValidArgsFunction = completeArgs(coreChainCompletions("eth", "btc", "sol"))
+	faucetCmd.ValidArgsFunction = completeArgs(coreChainCompletions("eth", "sol"))
+	rpcCmd.ValidArgsFunction = completeArgs(coreChainCompletions("eth", "sol"))
+	tokenAddCmd.ValidArgsFunction = completeArgs(coreChainCompletions("eth", "sol"))
+	tokenRemoveCmd.ValidArgsFunction = completeArgs(coreChainCompletions("eth", "sol"))
+	nftListCmd.ValidArgsFunction = completeArgs(append(coreChainCompletions("eth"), evmChainCompletions()...))
+	stakeCmd.ValidArgsFunction = completeArgs(coreChainCompletions("eth"))
+	stakeWithdrawCmd.ValidArgsFunction = completeArgs(coreChainCompletions("eth"))
+	networkCmd.ValidArgsFunction = completeNetworks
+	networkRemoveCmd.ValidArgsFunction = completeNetworks
+
+	payCmd.ValidArgsFunction = completePay(allChains)
+	txShowCmd.ValidArgsFunction = completeTxShow(allChains)
+	txRetryCmd.ValidArgsFunction = completePendingBroadcasts
+	txDropCmd.ValidArgsFunction = completePendingBroadcasts
+
+	for _, cmd := range []*cobra.Command{contractRemoveCmd, contractCallCmd, contractSendCmd} {
+		cmd.ValidArgsFunction = completeContracts
+	}
+	for _, cmd := range []*cobra.Command{multisigRemoveCmd, multisigAddressCmd, multisigBalanceCmd, multisigSpendCmd} {
+		cmd.ValidArgsFunction = completeMultisigWallets
+	}
+	watchlistAddCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) != 1 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return matchingCompletions(coreChainCompletions("eth", "btc", "sol"), toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+	watchlistRemoveCmd.ValidArgsFunction = completeWatchlistRemove
+
+	for _, cmd := range []*cobra.Command{configGetCmd, configSetCmd, configUnsetCmd} {
+		cmd.ValidArgsFunction = completeConfigKeys
+	}
+}
+
+// coreChainCompletions returns the short names of the built-in chains
+func coreChainCompletions(names ...string) []cobra.Completion {
+	descriptions := map[string]string{"eth": "Ethereum", "btc": "Bitcoin", "sol": "Solana"}
+	completions := make([]cobra.Completion, 0, len(names))
+	for _, name := range names {
+		completions = append(completions, cobra.CompletionWithDesc(name, descriptions[name]))
+	}
+	return completions
+}
+
+// evmChainCompletions returns the names of the other EVM chains
+func evmChainCompletions() []cobra.Completion {
+	completions := make([]cobra.Completion, 0, len(api.EVMChains))
+	for _, chain := range api.EVMChains {
+		completions = append(completions, cobra.CompletionWithDesc(chain.Name, chain.DisplayName))
+	}
+	return completions
+}
+
+// matchingCompletions keeps the completions starting with what was typed
+func matchingCompletions(completions []cobra.Completion, toComplete string) []cobra.Completion {
+	var matching []cobra.Completion
+	for _, completion := range completions {
+		if strings.HasPrefix(strings.ToLower(completion), strings.ToLower(toComplete)) {
+			matching = append(matching, completion)
+		}
+	}
+	return matching
+}
+
+// completeArgs completes the first argument from a fixed list
+func completeArgs(completions []cobra.Completion) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return matchingCompletions(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionChain returns the chain stored wallet files use for a chain
+// argument, EVM chains share the Ethereum addresses
+func completionChain(arg string) (chain string, evm bool) {
+	if chain, err := parseWatchChain(arg); err == nil {
+		return chain, chain == "ethereum"
+	}
+	if evmChain, ok := api.FindEVMChain(arg); ok {
+		return evmChain.Name, true
+	}
+	return "", false
+}
+
+// completePay completes the chain and suggests watchlist addresses of that
+// chain as recipients
+func completePay(chains []cobra.Completion) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return matchingCompletions(chains, toComplete), cobra.ShellCompDirectiveNoFileComp
+		case 2:
+			chain, evm := completionChain(args[0])
+			if evm {
+				chain = "ethereum"
+			}
+			addresses, err := wallet.NewManager().GetWatchAddresses()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var completions []cobra.Completion
+			for _, entry := range addresses {
+				if entry.Chain == chain {
+					completions = append(completions, cobra.CompletionWithDesc(entry.Address, entry.Label))
+				}
+			}
+			return matchingCompletions(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeTxShow completes the chain and suggests the hashes of transactions
+// seen on that chain, newest first
+func completeTxShow(chains []cobra.Completion) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return matchingCompletions(chains, toComplete), cobra.ShellCompDirectiveNoFileComp
+		case 1:
+			chain, _ := completionChain(args[0])
+			manager := wallet.NewManager()
+			activity, err := manager.GetActivity()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var completions []cobra.Completion
+			for _, tx := range activity.Transactions {
+				if tx.Chain != chain || tx.Network != manager.GetCurrentNetwork() {
+					continue
+				}
+				direction := "sent"
+				if tx.Incoming {
+					direction = "received"
+				}
+				completions = append(completions, cobra.CompletionWithDesc(tx.Hash, strings.TrimSpace(direction+" "+tx.Amount)))
+			}
+			return matchingCompletions(completions, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completePendingBroadcasts suggests the transactions in the broadcast queue
+func completePendingBroadcasts(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	pending, err := wallet.NewManager().GetPendingBroadcasts()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []cobra.Completion
+	for _, entry := range pending {
+		completions = append(completions, cobra.CompletionWithDesc(entry.ID,
+			fmt.Sprintf("%s %s to %s, %s", entry.Chain, entry.Amount, truncateAddress(entry.To), entry.Status)))
+	}
+	return matchingCompletions(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeContracts suggests the names of contracts saved on this network
+func completeContracts(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	manager := wallet.NewManager()
+	contracts, err := manager.GetContracts()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []cobra.Completion
+	for _, contract := range contracts {
+		if contract.Network == manager.GetCurrentNetwork() {
+			completions = append(completions, cobra.CompletionWithDesc(contract.Name, contract.Address))
+		}
+	}
+	return matchingCompletions(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeNetworks suggests the built-in and custom networks
+func completeNetworks(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	networks, err := config.Networks()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []cobra.Completion
+	for _, network := range networks {
+		if cmd == networkRemoveCmd && network.Builtin {
+			continue
+		}
+		completions = append(completions, cobra.CompletionWithDesc(network.Name, network.Description))
+	}
+	return matchingCompletions(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeMultisigWallets suggests the names of multisig wallets
+func completeMultisigWallets(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	wallets, err := wallet.NewManager().GetMultisigWallets()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []cobra.Completion
+	for _, w := range wallets {
+		completions = append(completions, cobra.CompletionWithDesc(w.Name, fmt.Sprintf("%d of %d", w.Threshold, len(w.Cosigners))))
+	}
+	return matchingCompletions(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWatchlistRemove suggests watchlist labels, then their chains
+func completeWatchlistRemove(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	addresses, err := wallet.NewManager().GetWatchAddresses()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	seen := make(map[string]bool)
+	var completions []cobra.Completion
+	for _, entry := range addresses {
+		choice, description := entry.Label, entry.Chain
+		if len(args) == 1 {
+			if entry.Label != args[0] {
+				continue
+			}
+			choice, description = entry.Chain, entry.Address
+		}
+		if !seen[choice] {
+			seen[choice] = true
+			completions = append(completions, cobra.CompletionWithDesc(choice, description))
+		}
+	}
+	return matchingCompletions(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigKeys suggests setting names
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []cobra.Completion
+	for _, key := range config.Keys() {
+		completions = append(completions, cobra.CompletionWithDesc(key.Name, key.Description))
+	}
+	return matchingCompletions(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show or change settings",
+	Long: `Show or change Odyssey settings stored in ~/.odyssey/config.json.
+
+Examples:
+  odyssey config                            # List all settings
+  odyssey config get session.timeout        # Show a single setting
+  odyssey config set session.timeout 10m    # Lock after 10 minutes of inactivity
+  odyssey config unset session.timeout      # Restore the default`,
+	Args: cobra.NoArgs,
+	RunE: runConfigList,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Show a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set [key] [value]",
+	Short: "Change a setting",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset [key]",
+	Short: "Restore the default of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigUnset,
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("⚙️  Settings")
+	fmt.Println()
+
+	for _, key := range config.Keys() {
+		source := "default"
+		if cfg.IsSet(key.Name) {
+			source = "set"
+		}
+		fmt.Printf("   %-24s %-12s (%s)\n", key.Name, cfg.Get(key.Name), source)
+		fmt.Printf("   %-24s %s\n", "", key.Description)
+	}
+
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	if _, err := config.LookupKey(args[0]); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(cfg.Get(args[0]))
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Set(args[0], args[1]); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ %s set to %s\n", args[0], args[1])
+	return nil
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Unset(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ %s restored to default (%s)\n", args[0], cfg.Get(args[0]))
+	return nil
+}
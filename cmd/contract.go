@@ -0,0 +1,486 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contractValueFlag  string
+	contractABIFlag    string
+	contractMethodFlag string
+	contractArgsFlag   []string
+)
+
+// contractNamePattern limits names to something easy to type
+var contractNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]{0,31}$`)
+
+var contractCmd = &cobra.Command{
+	Use:   "contract",
+	Short: "Manage known Ethereum contracts",
+	Long: `Keep a registry of Ethereum contracts you interact with, so they can be
+called by name and are labelled in your transaction history.
+
+Contracts are stored per network with their ABI. ABIs of verified contracts
+can be imported from Etherscan, which needs an API key:
+  odyssey config set etherscan.api_key <key>
+
+Any other contract can be called by address with an ABI file, without adding
+it first. Arguments are then given with --args, separated by commas.
+
+Examples:
+  odyssey contract                                   # List known contracts
+  odyssey contract import usdc 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48
+  odyssey contract add vault 0x1234... ./vault.abi.json
+  odyssey contract call usdc balanceOf 0x742d35Cc...
+  odyssey contract send vault deposit --value 0.1
+  odyssey contract call 0x1234... --abi token.json --method balanceOf --args 0x742d35Cc...
+  odyssey contract send 0x1234... --abi token.json --method transfer --args 0x742d35Cc...,1000
+  odyssey contract remove vault`,
+	Args: cobra.NoArgs,
+	RunE: runContractList,
+}
+
+var contractAddCmd = &cobra.Command{
+	Use:   "add [name] [address] [abi-file]",
+	Short: "Add a contract with an ABI from a file",
+	Args:  cobra.ExactArgs(3),
+	RunE:  runContractAdd,
+}
+
+var contractImportCmd = &cobra.Command{
+	Use:   "import [name] [address]",
+	Short: "Add a verified contract with its ABI from Etherscan",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runContractImport,
+}
+
+var contractRemoveCmd = &cobra.Command{
+	Use:   "remove [name]",
+	Short: "Remove a contract",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runContractRemove,
+}
+
+var contractCallCmd = &cobra.Command{
+	Use:   "call [name|address] [method] [args...]",
+	Short: "Call a read-only contract method",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runContractCall,
+}
+
+var contractSendCmd = &cobra.Command{
+	Use:   "send [name|address] [method] [args...]",
+	Short: "Send a transaction calling a contract method",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runContractSend,
+}
+
+func init() {
+	contractSendCmd.Flags().StringVar(&contractValueFlag, "value", "0", "ETH to send with the call")
+	contractSendCmd.Flags().Int64Var(&ethNonceFlag, "nonce", -1, "Nonce to use, reuse a pending nonce to replace that transaction")
+	addPreSignFlags(contractSendCmd)
+	for _, command := range []*cobra.Command{contractCallCmd, contractSendCmd} {
+		command.Flags().StringVar(&contractABIFlag, "abi", "", "ABI file, to call a contract by address without adding it")
+		command.Flags().StringVar(&contractMethodFlag, "method", "", "Method to call, instead of giving it after the contract")
+		command.Flags().StringSliceVar(&contractArgsFlag, "args", nil, "Method arguments, separated by commas")
+	}
+
+	contractCmd.AddCommand(contractAddCmd)
+	contractCmd.AddCommand(contractImportCmd)
+	contractCmd.AddCommand(contractRemoveCmd)
+	contractCmd.AddCommand(contractCallCmd)
+	contractCmd.AddCommand(contractSendCmd)
+}
+
+func runContractList(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+
+	contracts, err := manager.GetContracts()
+	if err != nil {
+		return err
+	}
+
+	network := manager.GetCurrentNetwork()
+	found := false
+	for _, contract := range contracts {
+		if contract.Network != network {
+			continue
+		}
+		if !found {
+			fmt.Printf("📜 Known Contracts (%s)\n", network)
+			fmt.Println()
+			found = true
+		}
+
+		methods := 0
+		if parsed, err := ethereum.ParseContractABI(contract.ABI); err == nil {
+			methods = len(parsed.Methods)
+		}
+		fmt.Printf("   %-16s %s (%d methods)\n", contract.Name, contract.Address, methods)
+	}
+
+	if !found {
+		fmt.Printf("📭 No known contracts on %s\n", network)
+		fmt.Println("💡 Add one with: odyssey contract import [name] [address]")
+	}
+
+	return nil
+}
+
+func runContractAdd(cmd *cobra.Command, args []string) error {
+	abiJSON, err := os.ReadFile(args[2])
+	if err != nil {
+		return fmt.Errorf("failed to read ABI file: %w", err)
+	}
+
+	return saveContract(wallet.NewManager(), args[0], args[1], string(abiJSON))
+}
+
+func runContractImport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	address, err := ethereum.ParseAddress(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid contract address: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient()
+	fmt.Println("🔄 Fetching verified ABI from Etherscan...")
+	abiJSON, err := client.GetEtherscanABI(ctx, address.Hex(), cfg.Get(config.KeyEtherscanAPIKey))
+	if err != nil {
+		return err
+	}
+
+	return saveContract(wallet.NewManager(), args[0], address.Hex(), abiJSON)
+}
+
+func runContractRemove(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+
+	removed, err := manager.RemoveContract(args[0], manager.GetCurrentNetwork())
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("no known contract named '%s' on %s", args[0], manager.GetCurrentNetwork())
+	}
+
+	fmt.Printf("✅ Removed contract '%s'\n", args[0])
+	return nil
+}
+
+func runContractCall(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	contract, methodName, methodArgs, err := resolveContractCall(manager, args)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := ethereum.ParseContractABI(contract.ABI)
+	if err != nil {
+		return err
+	}
+
+	data, err := ethereum.EncodeContractCall(parsed, methodName, methodArgs)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient()
+	result, err := client.CallEthereumContract(ctx, contract.Address, data)
+	if err != nil {
+		return err
+	}
+
+	values, err := ethereum.DecodeContractResult(parsed, methodName, result)
+	if err != nil {
+		return err
+	}
+
+	outputs := parsed.Methods[methodName].Outputs
+	for i, value := range values {
+		name := outputs[i].Name
+		if name == "" {
+			name = outputs[i].Type.String()
+		}
+		fmt.Printf("%s: %s\n", name, ethereum.FormatContractValue(value))
+	}
+
+	return nil
+}
+
+func runContractSend(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	contract, methodName, methodArgs, err := resolveContractCall(manager, args)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := ethereum.ParseContractABI(contract.ABI)
+	if err != nil {
+		return err
+	}
+
+	data, err := ethereum.EncodeContractCall(parsed, methodName, methodArgs)
+	if err != nil {
+		return err
+	}
+	method := parsed.Methods[methodName]
+
+	amount, err := decimal.NewFromString(contractValueFlag)
+	if err != nil || amount.IsNegative() {
+		return fmt.Errorf("invalid value: %s", contractValueFlag)
+	}
+	value := amount.Shift(18).BigInt()
+	if value.Sign() > 0 && !method.IsPayable() {
+		return fmt.Errorf("%s is not payable and cannot receive ETH", methodName)
+	}
+
+	to, err := ethereum.ParseAddress(contract.Address)
+	if err != nil {
+		return err
+	}
+
+	sender, err := manager.GetEthereumAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get sender address: %w", err)
+	}
+
+	client := api.NewClient()
+
+	nonce, err := nextEthereumNonce(ctx, manager, client, "ethereum", sender.Hex())
+	if err != nil {
+		return err
+	}
+
+	gasPrice, err := client.GetEthereumGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	// Add 20% to gas price to ensure faster inclusion
+	gasPrice.Mul(gasPrice, big.NewInt(120))
+	gasPrice.Div(gasPrice, big.NewInt(100))
+
+	gasLimit, err := client.GetEthereumGasEstimate(ctx, sender.Hex(), to.Hex(), value, data)
+	if err != nil {
+		gasLimit = ethereum.EstimateGasLimit(data)
+	}
+
+	maxFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+	totalCost := new(big.Int).Add(value, maxFee)
+
+	balance, err := client.GetEthereumBalance(ctx, sender.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+	if balance.Cmp(totalCost) < 0 {
+		return fmt.Errorf("%w. The call needs about %s ETH including gas but your balance is only %s ETH", api.ErrInsufficientFunds,
+			ethereum.WeiToEther(totalCost).StringFixed(6), ethereum.WeiToEther(balance).StringFixed(6))
+	}
+
+	fmt.Printf("📊 Transaction Details:\n")
+	fmt.Printf("   From:     %s\n", sender.Hex())
+	if contract.Name != "" {
+		fmt.Printf("   Contract: %s (%s)\n", contract.Name, to.Hex())
+	} else {
+		fmt.Printf("   Contract: %s\n", to.Hex())
+	}
+	fmt.Printf("   Method:   %s\n", method.Sig)
+	if len(methodArgs) > 0 {
+		fmt.Printf("   Args:     %s\n", strings.Join(methodArgs, ", "))
+	}
+	fmt.Printf("   Value:    %s ETH\n", ethereum.WeiToEther(value).StringFixed(6))
+	fmt.Printf("   Max Fee:  ~%s ETH\n", ethereum.WeiToEther(maxFee).StringFixed(6))
+	fmt.Printf("   Network:  %s\n", manager.GetCurrentNetwork())
+	fmt.Println()
+
+	sim, simErr := client.SimulateEthereumTransaction(ctx, ethereum.GetChainID(), sender.Hex(), to.Hex(), value, data, gasLimit, gasPrice)
+	if _, err := reportSimulation(sim, simErr, simulationAssets{symbol: "ETH", decimals: 18, owner: sender.Hex(), tokens: client.GetEthereumTokens()}); err != nil {
+		return err
+	}
+
+	if err := confirmSigning(manager, "❌ Transaction cancelled"); err != nil {
+		return err
+	}
+	err = checkBeforeSigning(ctx, manager, client, outgoingValue{
+		chain:     "ethereum",
+		recipient: to.Hex(),
+		amount:    amount,
+		fee:       ethereum.WeiToEther(maxFee),
+	})
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := manager.GetEthereumKey()
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	tx := ethereum.NewTransaction(nonce, to, value, gasLimit, gasPrice, data)
+	signedTx, err := ethereum.SignTransaction(tx, privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	txID, err := ethereum.TransactionHash(signedTx)
+	if err != nil {
+		return err
+	}
+
+	label := contract.Name
+	if label == "" {
+		label = truncateAddress(to.Hex())
+	}
+
+	txHash, err := broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+		ID:          txID,
+		Chain:       "ethereum",
+		Network:     manager.GetCurrentNetwork(),
+		SignedTx:    signedTx,
+		From:        sender.Hex(),
+		To:          to.Hex(),
+		Amount:      fmt.Sprintf("%s ETH (%s.%s)", ethereum.WeiToEther(value).StringFixed(6), label, methodName),
+		Nonce:       nonce,
+		SpendChain:  "ethereum",
+		SpendAmount: amount,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	fmt.Printf("✅ Transaction sent successfully!\n")
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL("ethereum", "tx", txHash))
+
+	return nil
+}
+
+// resolveContractCall returns the contract, method and arguments of a call or
+// send. The contract is a known one unless --abi is given, in which case it is
+// called by address with that ABI.
+func resolveContractCall(manager *wallet.Manager, args []string) (*wallet.Contract, string, []string, error) {
+	methodName := contractMethodFlag
+	methodArgs := args[1:]
+	if methodName == "" {
+		if len(args) < 2 {
+			return nil, "", nil, fmt.Errorf("no method given. Name it after the contract or with --method")
+		}
+		methodName = args[1]
+		methodArgs = args[2:]
+	}
+	methodArgs = append(append([]string{}, methodArgs...), contractArgsFlag...)
+
+	if contractABIFlag == "" {
+		contract, err := manager.FindContract(args[0], manager.GetCurrentNetwork())
+		if err != nil {
+			if _, addrErr := ethereum.ParseAddress(args[0]); addrErr == nil {
+				return nil, "", nil, fmt.Errorf("%w, or give its ABI with --abi", err)
+			}
+			return nil, "", nil, err
+		}
+		return contract, methodName, methodArgs, nil
+	}
+
+	address, err := ethereum.ParseAddress(args[0])
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("invalid contract address: %w", err)
+	}
+	abiJSON, err := os.ReadFile(contractABIFlag)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to read ABI file: %w", err)
+	}
+
+	contract := &wallet.Contract{
+		Chain:   "ethereum",
+		Network: manager.GetCurrentNetwork(),
+		Address: address.Hex(),
+		ABI:     string(abiJSON),
+	}
+	return contract, methodName, methodArgs, nil
+}
+
+// saveContract validates and stores a contract on the current network
+func saveContract(manager *wallet.Manager, name, address, abiJSON string) error {
+	if !contractNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid name '%s'. Use up to 32 letters, digits, '_', '-' or '.', starting with a letter", name)
+	}
+
+	parsedAddress, err := ethereum.ParseAddress(address)
+	if err != nil {
+		return fmt.Errorf("invalid contract address: %w", err)
+	}
+
+	parsed, err := ethereum.ParseContractABI(abiJSON)
+	if err != nil {
+		return err
+	}
+
+	contract := wallet.Contract{
+		Name:    name,
+		Chain:   "ethereum",
+		Network: manager.GetCurrentNetwork(),
+		Address: parsedAddress.Hex(),
+		ABI:     abiJSON,
+	}
+	if err := manager.SaveContract(contract); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Saved contract '%s' on %s\n", name, contract.Network)
+	fmt.Printf("   📍 Address: %s\n", contract.Address)
+	fmt.Printf("   📜 Methods: %d\n", len(parsed.Methods))
+	return nil
+}
+
+// contractLabels maps lowercase addresses of known contracts to their names,
+// used to label addresses in the transaction history
+var contractLabels map[string]string
+
+// loadContractLabels loads the names of known contracts on the current network
+func loadContractLabels(manager *wallet.Manager) {
+	contracts, err := manager.GetContracts()
+	if err != nil {
+		return
+	}
+
+	contractLabels = make(map[string]string)
+	for _, contract := range contracts {
+		if contract.Network == manager.GetCurrentNetwork() {
+			contractLabels[strings.ToLower(contract.Address)] = contract.Name
+		}
+	}
+}
+
+// displayAddress shortens an address and adds the name of a known contract
+func displayAddress(address string) string {
+	if name, ok := contractLabels[strings.ToLower(address)]; ok {
+		return fmt.Sprintf("%s (%s)", name, truncateAddress(address))
+	}
+	return truncateAddress(address)
+}
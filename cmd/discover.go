@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	discoverGapFlag   int
+	discoverChainFlag string
+)
+
+var discoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Find addresses of the recovery phrase that have history",
+	Long: `Scan the addresses derived from your recovery phrase for history, to find
+funds another wallet kept beyond the first address of each chain.
+
+Every chain is scanned until --gap addresses in a row have no history, the
+BIP-44 gap limit. Bitcoin scans the receive and change addresses of the
+legacy (m/44'), nested SegWit (m/49'), native SegWit (m/44' and m/84') and
+Taproot (m/86') accounts, Ethereum the address indexes of m/44'/60'/0'/0 and
+Solana one account per index.
+
+Addresses found are registered:
+  Bitcoin            included in balances and payments
+  Ethereum, Solana   watch-only wallets named "Account N", shown by
+                     'odyssey balance --all-wallets'. See their history
+                     with 'odyssey transactions [chain] --address'
+
+Examples:
+  odyssey discover
+  odyssey discover --chain btc
+  odyssey discover --gap 50`,
+	Args: cobra.NoArgs,
+	RunE: runDiscover,
+}
+
+func init() {
+	discoverCmd.Flags().IntVar(&discoverGapFlag, "gap", wallet.DiscoveryGapLimit, "Unused addresses in a row after which a chain is done")
+	discoverCmd.Flags().StringVar(&discoverChainFlag, "chain", "", "Scan only one chain (eth, btc or sol)")
+}
+
+// discoveryHit is an address with history found while scanning
+type discoveryHit struct {
+	index   uint32
+	account wallet.DiscoveredAccount
+}
+
+func runDiscover(cmd *cobra.Command, args []string) error {
+	if discoverGapFlag < 1 || discoverGapFlag > 1000 {
+		return fmt.Errorf("gap must be between 1 and 1000")
+	}
+	chain := ""
+	if discoverChainFlag != "" {
+		var err error
+		if chain, err = parseWatchChain(discoverChainFlag); err != nil {
+			return err
+		}
+	}
+
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+	if chain == "bitcoin" && manager.IsTestnet() {
+		return fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+
+	return discoverAccounts(cmd.Context(), manager, api.NewClient(), chain, uint32(discoverGapFlag))
+}
+
+// discoverAccounts scans the discovery chains of chain, or every chain if it
+// is empty, and registers the addresses with history
+func discoverAccounts(ctx context.Context, manager *wallet.Manager, client *api.Client, chain string, gap uint32) error {
+	owned, err := walletAddresses(manager)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🔍 Account Discovery")
+	networkType := "Mainnet"
+	if manager.IsTestnet() {
+		networkType = "Testnet"
+	}
+	fmt.Printf("🌐 Network: %s\n", networkType)
+	fmt.Printf("📏 Gap limit: %d unused addresses\n", gap)
+	fmt.Println()
+
+	var found []discoveryHit
+	for _, discoveryChain := range manager.DiscoveryChains() {
+		if chain != "" && discoveryChain.Chain != chain {
+			continue
+		}
+
+		fmt.Printf("%s %s\n", discoveryChainTitle(discoveryChain), discoveryChain.Name())
+		hits, scanned, err := scanDiscoveryChain(ctx, manager, client, discoveryChain, gap)
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", discoveryChain.Name(), err)
+		}
+
+		for _, hit := range hits {
+			note := ""
+			if owned[hit.account.Address] {
+				note = " (already in the wallet)"
+			} else {
+				found = append(found, hit)
+			}
+			fmt.Printf("   ✅ %-20s %s%s\n", hit.account.Path, hit.account.Address, note)
+		}
+		if len(hits) == 0 {
+			fmt.Printf("   No history in %d addresses\n", scanned)
+		} else {
+			fmt.Printf("   %d of %d addresses have history\n", len(hits), scanned)
+		}
+	}
+	fmt.Println()
+
+	if len(found) == 0 {
+		fmt.Println("✅ No further addresses with history, the wallet already shows everything")
+		return nil
+	}
+
+	if err := registerDiscoveredAccounts(manager, found); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Registered %d new address(es)\n", len(found))
+	fmt.Println("   - Bitcoin addresses are included in balances and payments")
+	fmt.Println("   - Ethereum and Solana accounts are shown by 'odyssey balance --all-wallets'")
+	fmt.Println("   - See their history with 'odyssey transactions [chain] --address [address]'")
+	return nil
+}
+
+// walletAddresses returns the addresses the wallet already shows
+func walletAddresses(manager *wallet.Manager) (map[string]bool, error) {
+	owned := make(map[string]bool)
+
+	ethAddress, err := manager.GetEthereumAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+	owned[ethAddress.Hex()] = true
+
+	solAddress, err := manager.GetSolanaAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Solana address: %w", err)
+	}
+	owned[solAddress.String()] = true
+
+	// Bitcoin is only supported in mainnet and regtest
+	if manager.BitcoinSupported() {
+		accounts, err := manager.GetBitcoinAccounts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Bitcoin address: %w", err)
+		}
+		for _, account := range accounts {
+			owned[account.Address.String()] = true
+		}
+	}
+
+	watched, err := manager.GetWatchAddresses()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range watched {
+		owned[entry.Address] = true
+	}
+	return owned, nil
+}
+
+// discoveryChainTitle names a discovery chain for display
+func discoveryChainTitle(chain wallet.DiscoveryChain) string {
+	switch chain.Chain {
+	case "ethereum":
+		return "🔷 Ethereum"
+	case "bitcoin":
+		kind := "receive"
+		if chain.Internal {
+			kind = "change"
+		}
+		return fmt.Sprintf("🟠 Bitcoin %s %s", bitcoinAddressNames[chain.Type], kind)
+	default:
+		return "🟣 Solana"
+	}
+}
+
+// scanDiscoveryChain checks the addresses of a chain in batches of gap until
+// gap addresses in a row have no history. It returns the addresses with
+// history and the number of addresses scanned.
+func scanDiscoveryChain(ctx context.Context, manager *wallet.Manager, client *api.Client, chain wallet.DiscoveryChain, gap uint32) ([]discoveryHit, uint32, error) {
+	var hits []discoveryHit
+	unused := uint32(0)
+	scanned := uint32(0)
+	for unused < gap {
+		batch, err := manager.DeriveDiscoveryAddresses(chain, scanned, gap)
+		if err != nil {
+			return nil, 0, err
+		}
+		addresses := make([]string, len(batch))
+		for i, account := range batch {
+			addresses[i] = account.Address
+		}
+
+		used, err := addressesUsed(ctx, client, chain.Chain, addresses)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i, account := range batch {
+			scanned++
+			if used[i] {
+				hits = append(hits, discoveryHit{index: scanned - 1, account: account})
+				unused = 0
+				continue
+			}
+			if unused++; unused >= gap {
+				break
+			}
+		}
+	}
+	return hits, scanned, nil
+}
+
+// addressesUsed reports for each address whether it has any history
+func addressesUsed(ctx context.Context, client *api.Client, chain string, addresses []string) ([]bool, error) {
+	used := make([]bool, len(addresses))
+
+	// Bitcoin addresses are checked in one request
+	if chain == "bitcoin" {
+		counts, err := client.GetBitcoinTxCounts(ctx, addresses)
+		if err != nil {
+			return nil, err
+		}
+		for i, address := range addresses {
+			used[i] = counts[address] > 0
+		}
+		return used, nil
+	}
+
+	var g errgroup.Group
+	g.SetLimit(4)
+	for i, address := range addresses {
+		g.Go(func() error {
+			var err error
+			used[i], err = addressUsed(ctx, client, chain, address)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return used, nil
+}
+
+// addressUsed reports whether an Ethereum or Solana address has any history.
+// An Ethereum address that never sent has a balance if it ever received.
+func addressUsed(ctx context.Context, client *api.Client, chain, address string) (bool, error) {
+	if chain == "solana" {
+		page, err := client.GetSolanaTransactions(ctx, address, 1, "")
+		if err != nil {
+			return false, err
+		}
+		return len(page.Transactions) > 0, nil
+	}
+
+	nonce, err := client.GetEthereumNonce(ctx, address)
+	if err != nil {
+		return false, err
+	}
+	if nonce > 0 {
+		return true, nil
+	}
+	balance, err := client.GetEthereumBalance(ctx, address)
+	if err != nil {
+		return false, err
+	}
+	return balance.Sign() > 0, nil
+}
+
+// registerDiscoveredAccounts stores the addresses found, Ethereum and Solana
+// accounts also become watch-only wallets named after their index
+func registerDiscoveredAccounts(manager *wallet.Manager, found []discoveryHit) error {
+	accounts := make([]wallet.DiscoveredAccount, len(found))
+	for i, hit := range found {
+		accounts[i] = hit.account
+		if hit.account.Chain == "bitcoin" {
+			continue
+		}
+		label := fmt.Sprintf("Account %d", hit.index+1)
+		if err := manager.AddWatchAddress(wallet.WatchAddress{Label: label, Chain: hit.account.Chain, Address: hit.account.Address}); err != nil {
+			return err
+		}
+	}
+	return manager.AddDiscoveredAccounts(accounts)
+}
+
+// offerDiscovery asks to scan for addresses used by other wallets after a
+// recovery phrase was imported
+func offerDiscovery(ctx context.Context, manager *wallet.Manager) error {
+	fmt.Println()
+	fmt.Fprint(promptOut(), "🔍 Scan for addresses this phrase used in other wallets? (y/N): ")
+
+	var response string
+	fmt.Scanln(&response)
+
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		fmt.Println("💡 Scan later with 'odyssey discover'")
+		return nil
+	}
+	fmt.Println()
+	return discoverAccounts(ctx, manager, api.NewClient(), "", wallet.DiscoveryGapLimit)
+}
@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/logging"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+// Thresholds of the doctor checks
+const (
+	doctorSlowNode     = 2 * time.Second
+	doctorMaxClockSkew = 30 * time.Second // HTTP dates have a resolution of one second
+	doctorMinFreeSpace = 100 << 20        // bytes, enough for the cache, history and archives
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose problems with the wallet setup",
+	Long: `Check the wallet files, the session, the configuration, the nodes used for
+every chain, the system clock and the free disk space, and print how to fix
+whatever is wrong.
+
+No password is needed and nothing is changed. Warnings don't fail the
+command, errors do.
+
+Examples:
+  odyssey doctor
+  odyssey doctor --offline   # Skip the node and clock checks`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+// doctorReport collects the outcome of the checks
+type doctorReport struct {
+	warnings int
+	errors   int
+}
+
+func (r *doctorReport) ok(format string, args ...any) {
+	fmt.Printf("   ✅ %s\n", fmt.Sprintf(format, args...))
+}
+
+func (r *doctorReport) warn(fix, format string, args ...any) {
+	r.warnings++
+	fmt.Printf("   ⚠️  %s\n", fmt.Sprintf(format, args...))
+	if fix != "" {
+		fmt.Printf("      → %s\n", fix)
+	}
+}
+
+func (r *doctorReport) fail(fix, format string, args ...any) {
+	r.errors++
+	fmt.Printf("   ❌ %s\n", fmt.Sprintf(format, args...))
+	if fix != "" {
+		fmt.Printf("      → %s\n", fix)
+	}
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	report := &doctorReport{}
+
+	fmt.Println("🩺 Odyssey Doctor")
+	fmt.Printf("🌐 Network: %s\n", manager.GetCurrentNetwork())
+	fmt.Println()
+
+	fmt.Println("🔐 Vault")
+	checkDoctorVault(report, manager)
+	fmt.Println()
+
+	fmt.Println("🔓 Session")
+	checkDoctorSession(report, manager)
+	fmt.Println()
+
+	fmt.Println("⚙️  Configuration")
+	checkDoctorConfig(report)
+	fmt.Println()
+
+	fmt.Println("📡 Nodes")
+	serverTimes := checkDoctorNodes(ctx, report, manager)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	fmt.Println("🕒 Clock")
+	checkDoctorClock(report, serverTimes)
+	fmt.Println()
+
+	fmt.Println("💾 Disk")
+	checkDoctorDisk(report, filepath.Dir(manager.VaultPath()))
+	fmt.Println()
+
+	switch {
+	case report.errors > 0:
+		return fmt.Errorf("doctor found %d error(s) and %d warning(s)", report.errors, report.warnings)
+	case report.warnings > 0:
+		fmt.Printf("⚠️  %d warning(s), everything else looks fine\n", report.warnings)
+	default:
+		fmt.Println("✅ Everything looks fine")
+	}
+	return nil
+}
+
+// checkDoctorVault checks that the vault exists and only its owner can read it
+func checkDoctorVault(report *doctorReport, manager *wallet.Manager) {
+	path := manager.VaultPath()
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		report.fail("Run 'odyssey init' to create a wallet or import one", "No vault at %s", path)
+		return
+	}
+	if err != nil {
+		report.fail("Check that your user can read the file", "Can't read the vault: %v", err)
+		return
+	}
+	report.ok("Vault found at %s", path)
+
+	if !checkPermissions {
+		return
+	}
+	dir := filepath.Dir(path)
+	if dirInfo, err := os.Stat(dir); err == nil && dirInfo.Mode().Perm()&0077 != 0 {
+		report.warn(fmt.Sprintf("Run 'chmod 700 %s'", dir), "%s is accessible by other users (%s)", dir, dirInfo.Mode().Perm())
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		report.fail(fmt.Sprintf("Run 'chmod 600 %s'", path), "Vault is readable by other users (%s)", info.Mode().Perm())
+		return
+	}
+	report.ok("Vault is only readable by you (%s)", info.Mode().Perm())
+}
+
+// checkDoctorSession reports whether a session is active for the current
+// network, without renewing it
+func checkDoctorSession(report *doctorReport, manager *wallet.Manager) {
+	if !manager.VaultExists() {
+		report.ok("No session, there is no wallet yet")
+		return
+	}
+	switch manager.SessionStorage() {
+	case config.SessionStorageNone:
+		report.ok("Sessions aren't stored, every command asks for the password")
+		return
+	case config.SessionStorageAgent:
+		status, err := manager.AgentStatus()
+		switch {
+		case err != nil:
+			report.warn("Run 'odyssey agent start'", "No agent is running, every command asks for the password")
+		case !status.Unlocked:
+			report.ok("Agent is running (pid %d) without keys, 'odyssey unlock' hands them to it", status.PID)
+		default:
+			report.ok("Wallet is unlocked in the agent (pid %d, idle timeout %s)", status.PID, manager.SessionTimeout())
+		}
+		return
+	}
+
+	network := manager.ActiveSessionNetwork()
+	switch {
+	case network == "":
+		report.ok("Wallet is locked, 'odyssey unlock' starts a session (idle timeout %s)", manager.SessionTimeout())
+	case network != manager.GetCurrentNetwork():
+		report.warn(fmt.Sprintf("Run 'odyssey network %s' or unlock again on %s", network, manager.GetCurrentNetwork()),
+			"Session belongs to %s, the wallet is on %s", network, manager.GetCurrentNetwork())
+	default:
+		report.ok("Wallet is unlocked (idle timeout %s)", manager.SessionTimeout())
+	}
+}
+
+// checkDoctorConfig checks that the config file parses and holds only known,
+// valid settings
+func checkDoctorConfig(report *doctorReport) {
+	cfg, err := config.Load()
+	if err != nil {
+		report.fail("Fix or delete ~/.odyssey/config.json, all settings fall back to their defaults meanwhile", "%v", err)
+		return
+	}
+
+	problems := cfg.Problems()
+	for _, problem := range problems {
+		report.warn("Run 'odyssey config unset' with the setting's name, or 'odyssey config set' with a valid value", "%v", problem)
+	}
+	if len(problems) == 0 {
+		report.ok("Settings in %s are valid", cfg.Path())
+	}
+}
+
+// doctorNode is a node of a chain to probe
+type doctorNode struct {
+	label string
+	chain string
+	url   string
+	check api.NodeCheck
+}
+
+// checkDoctorNodes probes every node used on the current network concurrently
+// and returns the server times they reported
+func checkDoctorNodes(ctx context.Context, report *doctorReport, manager *wallet.Manager) []time.Time {
+	if api.IsOffline() {
+		report.ok("Skipped in offline mode")
+		return nil
+	}
+
+	client := api.NewClient()
+	type doctorChain struct{ label, chain string }
+	var chains []doctorChain
+	if manager.BitcoinSupported() {
+		chains = append(chains, doctorChain{"Bitcoin", "bitcoin"})
+	}
+	chains = append(chains, doctorChain{"Ethereum", "ethereum"}, doctorChain{"Solana", "solana"})
+	for _, evmChain := range api.EVMChains {
+		chains = append(chains, doctorChain{evmChain.Label(manager.IsTestnet()), evmChain.Name})
+	}
+
+	var nodes []*doctorNode
+	for _, chain := range chains {
+		urls, err := client.RPCNodes(chain.chain)
+		if err != nil {
+			report.fail("Check the bitcoin.rpc_* settings with 'odyssey config'", "%s: %v", chain.label, err)
+			continue
+		}
+		for _, url := range urls {
+			nodes = append(nodes, &doctorNode{label: chain.label, chain: chain.chain, url: url})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node *doctorNode) {
+			defer wg.Done()
+			node.check = client.CheckNode(ctx, node.chain, node.url)
+		}(node)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	var serverTimes []time.Time
+	for _, node := range nodes {
+		url := logging.RedactURL(node.url)
+		check := node.check
+		switch {
+		case check.Err != nil:
+			report.fail(fmt.Sprintf("Check your connection, or configure another node with 'odyssey config set %s'", doctorRPCKey(node.chain, manager)),
+				"%s: %s unreachable: %v", node.label, url, check.Err)
+		case check.Latency > doctorSlowNode:
+			report.warn(fmt.Sprintf("Configure a faster node with 'odyssey config set %s'", doctorRPCKey(node.chain, manager)),
+				"%s: %s is slow (%s)", node.label, url, check.Latency.Round(time.Millisecond))
+		default:
+			report.ok("%s: %s (%s)", node.label, url, check.Latency.Round(time.Millisecond))
+		}
+		if !check.ServerTime.IsZero() {
+			serverTimes = append(serverTimes, check.ServerTime)
+		}
+	}
+	return serverTimes
+}
+
+// doctorRPCKey returns the setting that configures the nodes of a chain on
+// the current network
+func doctorRPCKey(chain string, manager *wallet.Manager) string {
+	if chain == "bitcoin" {
+		return "bitcoin.rpc_url"
+	}
+	return config.RPCKey(chain, manager.GetCurrentNetwork())
+}
+
+// checkDoctorClock compares the local clock to the median of the times the
+// nodes reported, a wrong clock breaks TLS and makes sessions expire early
+func checkDoctorClock(report *doctorReport, serverTimes []time.Time) {
+	if len(serverTimes) == 0 {
+		report.ok("Skipped, no node reported its time")
+		return
+	}
+
+	sort.Slice(serverTimes, func(i, j int) bool { return serverTimes[i].Before(serverTimes[j]) })
+	drift := time.Since(serverTimes[len(serverTimes)/2]).Round(time.Second)
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift > doctorMaxClockSkew {
+		report.warn("Enable automatic time synchronization in your system settings", "System clock is off by about %s", drift)
+		return
+	}
+	report.ok("System clock is in sync (within %s)", doctorMaxClockSkew)
+}
+
+// checkDoctorDisk checks the free space on the disk holding the wallet files
+func checkDoctorDisk(report *doctorReport, dir string) {
+	// Before init the wallet directory doesn't exist yet
+	for {
+		if _, err := os.Stat(dir); err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	free, err := freeDiskSpace(dir)
+	if err != nil {
+		report.warn("", "Can't determine the free space of %s: %v", dir, err)
+		return
+	}
+	if free < doctorMinFreeSpace {
+		report.warn("Free up disk space, saving the vault or a session may fail", "Only %s free on the disk of %s", formatBytes(free), dir)
+		return
+	}
+	report.ok("%s free on the disk of %s", formatBytes(free), dir)
+}
+
+// formatBytes formats a size with a binary unit
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
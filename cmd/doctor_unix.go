@@ -0,0 +1,17 @@
+//go:build !windows
+
+package cmd
+
+import "golang.org/x/sys/unix"
+
+// freeDiskSpace returns the bytes available to this user on the disk of path
+func freeDiskSpace(path string) (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
+
+// checkPermissions is true as file modes show who can read the wallet files
+const checkPermissions = true
@@ -0,0 +1,21 @@
+//go:build windows
+
+package cmd
+
+import "golang.org/x/sys/windows"
+
+// freeDiskSpace returns the bytes available to this user on the disk of path
+func freeDiskSpace(path string) (uint64, error) {
+	dir, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	var free uint64
+	if err := windows.GetDiskFreeSpaceEx(dir, &free, nil, nil); err != nil {
+		return 0, err
+	}
+	return free, nil
+}
+
+// checkPermissions is false as Windows guards files with ACLs, not modes
+const checkPermissions = false
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/chinmay1088/odyssey/chains/bitcoin"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+)
+
+// payDryRunFlag stops pay once the transaction is built, before signing
+var payDryRunFlag bool
+
+// printEthereumDryRun prints the unsigned transaction an EVM payment would send
+func printEthereumDryRun(tx *ethereum.Transaction, from, symbol string, l1DataFee bool) error {
+	unsigned, err := ethereum.EncodeUnsigned(tx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🧾 Dry run, this transaction would be signed and sent:")
+	fmt.Printf("   Chain ID:  %s\n", tx.ChainID.String())
+	fmt.Printf("   From:      %s\n", from)
+	fmt.Printf("   To:        %s\n", tx.To.Hex())
+	fmt.Printf("   Nonce:     %d\n", tx.Nonce)
+	fmt.Printf("   Value:     %s wei (%s %s)\n", tx.Value.String(), ethereum.WeiToEther(tx.Value).StringFixed(6), symbol)
+	fmt.Printf("   Gas Limit: %d\n", tx.GasLimit)
+	fmt.Printf("   Gas Price: %s wei\n", tx.GasPrice.String())
+	if len(tx.Data) > 0 {
+		fmt.Printf("   Data:      0x%x\n", tx.Data)
+	}
+	printResult(unsigned, "   Unsigned:  %s\n", unsigned)
+	if l1DataFee {
+		fmt.Println("   💡 The L1 data fee is priced from the signed transaction and isn't included")
+	}
+	fmt.Println()
+	fmt.Println("🧪 Nothing was signed or sent")
+
+	return nil
+}
+
+// printBitcoinDryRun prints the unsigned transaction a Bitcoin payment would send
+func printBitcoinDryRun(tx *bitcoin.Transaction, utxos []*bitcoin.UTXO, recipient btcutil.Address, value int64, memo string, changeAddress btcutil.Address, change, fee, vsize int64) error {
+	unsigned, err := tx.Serialize()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🧾 Dry run, this transaction would be signed and sent:")
+	fmt.Printf("   Inputs (%d):\n", len(utxos))
+	for _, utxo := range utxos {
+		fmt.Printf("     %s:%d  %s  %s\n", utxo.TxID, utxo.Vout, bitcoin.FormatBalance(utxo.Value), utxo.Address.String())
+	}
+	fmt.Println("   Outputs:")
+	fmt.Printf("     %s  %s\n", recipient.String(), bitcoin.FormatBalance(value))
+	if memo != "" {
+		fmt.Printf("     OP_RETURN  %q\n", memo)
+	}
+	if change > 0 {
+		fmt.Printf("     %s  %s (change)\n", changeAddress.String(), bitcoin.FormatBalance(change))
+	}
+	fmt.Printf("   Fee:       %d sat (%s sat/vB, %d vB signed)\n", fee, bitcoin.EffectiveFeeRate(fee, vsize).StringFixed(1), vsize)
+	printResult(unsigned, "   Unsigned:  %s\n", unsigned)
+	fmt.Println()
+	fmt.Println("🧪 Nothing was signed or sent")
+
+	return nil
+}
+
+// printSolanaDryRun prints the transfer a Solana payment would send
+func printSolanaDryRun(from, to string, lamports, fee uint64) error {
+	fmt.Println("🧾 Dry run, this transaction would be signed and sent:")
+	fmt.Println("   Program:   System Program (transfer)")
+	fmt.Printf("   From:      %s\n", from)
+	fmt.Printf("   To:        %s\n", to)
+	fmt.Printf("   Lamports:  %d\n", lamports)
+	fmt.Printf("   Fee:       %d lamports\n", fee)
+	fmt.Println("   💡 The recent blockhash is fetched right before signing")
+	fmt.Println()
+	fmt.Println("🧪 Nothing was signed or sent")
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var duressImportFlag bool
+
+var duressCmd = &cobra.Command{
+	Use:   "duress",
+	Short: "Set up a decoy wallet unlocked with a duress password",
+	Long: `Manage a decoy wallet for use under coercion.
+
+A duress password unlocks a second wallet with a different recovery phrase,
+stored in the same vault. Keep small funds on it: if you are forced to unlock
+your wallet, give the duress password and only the decoy is exposed. Every
+command behaves the same with either wallet.
+
+Every vault has a second slot filled with random data when no decoy is set,
+so the vault doesn't reveal whether one exists. Local files that aren't
+encrypted, like the audit log, the activity history and the address book,
+are shared by both wallets.
+
+The decoy has its own two-factor authentication: unlock it with the duress
+password and run 'odyssey 2fa enable' to give it a secret.
+
+Examples:
+  odyssey duress set
+  odyssey duress set --import
+  odyssey duress remove`,
+}
+
+var duressSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Create or replace the decoy wallet",
+	Args:  cobra.NoArgs,
+	RunE:  runDuressSet,
+}
+
+var duressRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove the decoy wallet",
+	Args:  cobra.NoArgs,
+	RunE:  runDuressRemove,
+}
+
+func init() {
+	duressSetCmd.Flags().BoolVar(&duressImportFlag, "import", false, "Use an existing recovery phrase for the decoy instead of a new one")
+
+	duressCmd.AddCommand(duressSetCmd)
+	duressCmd.AddCommand(duressRemoveCmd)
+}
+
+func runDuressSet(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.VaultExists() {
+		return fmt.Errorf("no wallet found. Run 'odyssey init' to create a new wallet")
+	}
+	if !term.IsTerminal(int(syscall.Stdin)) {
+		return fmt.Errorf("setting a duress password needs a terminal")
+	}
+
+	fmt.Println("🎭 Set Up a Decoy Wallet")
+	fmt.Println()
+
+	mainPassword, err := readWalletPassword(cmd, "Enter your main wallet password: ")
+	if err != nil {
+		return err
+	}
+
+	var decoyMnemonic string
+	if duressImportFlag {
+		fmt.Fprint(promptOut(), "Enter the decoy recovery phrase (24 words): ")
+		reader := bufio.NewReader(os.Stdin)
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read mnemonic: %w", err)
+		}
+		decoyMnemonic = strings.TrimSpace(line)
+		if !isValidMnemonic(decoyMnemonic) {
+			return fmt.Errorf("invalid mnemonic. Must be 24 words")
+		}
+	}
+
+	fmt.Fprint(promptOut(), "Enter a duress password: ")
+	password, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	fmt.Fprintln(promptOut())
+
+	if len(password) < 8 {
+		return fmt.Errorf("password must be at least 8 characters long")
+	}
+
+	fmt.Fprint(promptOut(), "Confirm duress password: ")
+	confirmPassword, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return fmt.Errorf("failed to read password confirmation: %w", err)
+	}
+	fmt.Fprintln(promptOut())
+
+	if string(password) != string(confirmPassword) {
+		return fmt.Errorf("passwords do not match")
+	}
+
+	mnemonic, err := manager.SetDecoyWallet(mainPassword, decoyMnemonic, string(password))
+	if err != nil {
+		return fmt.Errorf("failed to set up the decoy wallet: %w", err)
+	}
+
+	fmt.Println("✅ Decoy wallet set up")
+	fmt.Println()
+	if !duressImportFlag {
+		fmt.Println("🔐 Decoy Recovery Phrase (24 words):")
+		fmt.Println()
+		fmt.Printf("   %s\n", mnemonic)
+		fmt.Println()
+	}
+	fmt.Println("💡 Next steps:")
+	fmt.Println("   - Unlock with the duress password and run 'odyssey address' to see the decoy addresses")
+	fmt.Println("   - Send small funds to them, an empty wallet is less convincing")
+	if manager.TwoFactorEnabled() {
+		fmt.Println("   - Unlock with the duress password and run 'odyssey 2fa enable', so the decoy asks for a code too")
+	}
+	fmt.Println("   - Never reveal that a duress password exists")
+
+	return nil
+}
+
+func runDuressRemove(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.VaultExists() {
+		return fmt.Errorf("no wallet found. Run 'odyssey init' to create a new wallet")
+	}
+
+	mainPassword, err := readWalletPassword(cmd, "Enter your main wallet password: ")
+	if err != nil {
+		return err
+	}
+	if err := manager.RemoveDecoyWallet(mainPassword); err != nil {
+		return fmt.Errorf("failed to remove the decoy wallet: %w", err)
+	}
+
+	fmt.Println("✅ Decoy wallet removed, the duress password no longer unlocks anything")
+	return nil
+}
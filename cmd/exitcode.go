@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/wallet"
+)
+
+// Exit codes for scripts, see the README
+const (
+	ExitOK                = 0
+	ExitError             = 1
+	ExitLocked            = 2
+	ExitInsufficientFunds = 3
+	ExitNetwork           = 4
+	ExitCancelled         = 5
+	ExitInvalidAddress    = 6
+	ExitInterrupted       = 130
+)
+
+var (
+	// errWalletLocked is returned by commands that need the unlocked wallet
+	errWalletLocked = errors.New("wallet is locked. Run 'odyssey unlock' first")
+
+	// errCancelled is returned when the user declines a confirmation. The
+	// command has already said so, so it isn't printed as an error.
+	errCancelled = errors.New("cancelled by user")
+
+	// errInterrupted is returned when Ctrl+C stopped the command
+	errInterrupted = errors.New("interrupted")
+)
+
+// ExitCode returns the exit code for the error a command returned
+func ExitCode(err error) int {
+	var rpcErr *api.RPCError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, errInterrupted), errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case errors.Is(err, errCancelled):
+		return ExitCancelled
+	case errors.Is(err, errInvalidAddress):
+		return ExitInvalidAddress
+	case errors.Is(err, errWalletLocked), errors.Is(err, wallet.ErrLocked):
+		return ExitLocked
+	case errors.Is(err, api.ErrInsufficientFunds):
+		return ExitInsufficientFunds
+	case errors.As(err, &rpcErr) && strings.Contains(strings.ToLower(rpcErr.Message), "insufficient funds"):
+		// Nodes reject payments they can't cover
+		return ExitInsufficientFunds
+	case errors.Is(err, api.ErrOffline), api.IsTransientError(err):
+		return ExitNetwork
+	default:
+		return ExitError
+	}
+}
+
+// IsCancelled returns true if the user declined to go on, which isn't
+// reported as an error
+func IsCancelled(err error) bool {
+	return errors.Is(err, errCancelled)
+}
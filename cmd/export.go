@@ -1,18 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/bitcoin"
 	"github.com/chinmay1088/odyssey/wallet"
 	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
+	"github.com/xuri/excelize/v2"
 )
 
 var exportCmd = &cobra.Command{
@@ -21,20 +26,41 @@ var exportCmd = &cobra.Command{
 	Long: `Export your wallet data including balances and transaction history.
 	
 File formats:
-  --csv        Export to CSV format (default)
+  --csv        Export to CSV format (default), balances and transactions
+               in separate files with one field per column
   --json       Export to JSON format
   --txt        Export to txt format
+  --xlsx       Export to an Excel workbook with a Balances and a
+               Transactions sheet
+
+Tax reports:
+  --format koinly        Koinly universal CSV
+  --format cointracking  CoinTracking CSV import
+  --format 8949          IRS Form 8949 disposals
+
+Tax reports list every ETH, BTC and SOL transfer with its date, type,
+amount, fee and USD value at the time of the transaction, using historical
+prices. They are only available on mainnet. Form 8949 disposals are matched
+against acquisitions with the tax.cost_basis method, see 'odyssey gains'.
   
 Data exported:
   • All supported currencies (ETH, BTC, SOL)
   • Current balances with USD values
-  • Transaction history (capped at 50 per chain)
+  • Transaction history (capped at 50 per chain), valued at the price
+    of the day of each transaction
   • Data from your current network (mainnet or testnet)
   
 Examples:
   odyssey export                    # Export to CSV (default)
   odyssey export --json            # Export to JSON
-  odyssey export --csv --json      # Export to both formats`,
+  odyssey export --csv --json      # Export to both formats
+  odyssey export --xlsx            # Export to a workbook
+  odyssey export --format koinly   # Tax report for Koinly
+  odyssey export --direction out --since 2025-01-01 --until 2025-03-31
+
+Transactions can be filtered with --since, --until, --direction and
+--min-amount, see 'odyssey transactions --help'. Balances are always
+exported.`,
 	RunE: runExport,
 }
 
@@ -42,27 +68,42 @@ var (
 	csvFlag  bool
 	jsonFlag bool
 	txtFlag  bool
+	xlsxFlag bool
 )
 
 func init() {
 	exportCmd.Flags().BoolVar(&csvFlag, "csv", false, "Export to CSV format")
 	exportCmd.Flags().BoolVar(&jsonFlag, "json", false, "Export to JSON format")
 	exportCmd.Flags().BoolVar(&txtFlag, "txt", false, "Export to txt format")
+	exportCmd.Flags().BoolVar(&xlsxFlag, "xlsx", false, "Export to an Excel workbook")
+	exportCmd.Flags().StringVar(&exportFormatFlag, "format", "", "Export a tax report (koinly, cointracking, 8949)")
+	addTxFilterFlags(exportCmd)
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	manager := wallet.NewManager()
 	client := api.NewClient()
 	if !manager.IsUnlocked() {
-		return fmt.Errorf("wallet is locked. Run 'odyssey unlock' first")
+		return errWalletLocked
 	}
-	if !csvFlag && !jsonFlag && !txtFlag {
+	filter, err := parseTxFilter()
+	if err != nil {
+		return err
+	}
+	if exportFormatFlag != "" {
+		return runTaxExport(ctx, manager, client, filter)
+	}
+	if !csvFlag && !jsonFlag && !txtFlag && !xlsxFlag {
 		csvFlag = true
 	}
 	currentNetwork := manager.GetCurrentNetwork()
 
 	fmt.Printf("🌐 Current Network: %s\n", strings.ToUpper(currentNetwork))
 	fmt.Printf("📊 Exporting %s data...\n", strings.ToUpper(currentNetwork))
+	if filter.active() {
+		fmt.Printf("🔎 Filter: %s\n", filter)
+	}
 	fmt.Println()
 	exportData := &ExportData{
 		ExportDate:     time.Now().Format("2006-01-02 15:04:05"),
@@ -72,6 +113,7 @@ func runExport(cmd *cobra.Command, args []string) error {
 	fmt.Println("📊 Preparing export data...")
 	bar := progressbar.NewOptions(100,
 		progressbar.OptionEnableColorCodes(true),
+		progressbar.OptionSetVisibility(!plainMode),
 		progressbar.OptionShowBytes(false),
 		progressbar.OptionSetWidth(50),
 		progressbar.OptionSetDescription("[cyan][1/3][reset] Collecting data..."),
@@ -86,7 +128,7 @@ func runExport(cmd *cobra.Command, args []string) error {
 	// collect data for the current network
 	bar.Set(0)
 	isTestnet := currentNetwork == "testnet"
-	if err := collectNetworkData(manager, client, exportData.Data, isTestnet, bar); err != nil {
+	if err := collectNetworkData(ctx, manager, client, exportData.Data, isTestnet, filter, bar); err != nil {
 		return fmt.Errorf("failed to collect data: %w", err)
 	}
 
@@ -155,19 +197,20 @@ type TransactionData struct {
 	Direction   string `json:"direction"`
 	Timestamp   string `json:"timestamp"`
 	BlockNumber int64  `json:"block_number"`
+	Explorer    string `json:"explorer,omitempty"`
 }
 
-func collectNetworkData(manager *wallet.Manager, client *api.Client, networkData *NetworkData, isTestnet bool, bar *progressbar.ProgressBar) error {
+func collectNetworkData(ctx context.Context, manager *wallet.Manager, client *api.Client, networkData *NetworkData, isTestnet bool, filter txFilter, bar *progressbar.ProgressBar) error {
 	// collect ethereum data
-	if err := collectEthereumData(manager, client, networkData, isTestnet); err != nil {
+	if err := collectEthereumData(ctx, manager, client, networkData, isTestnet, filter); err != nil {
 		// log error but continue with other currencies
 		fmt.Printf("⚠️  Warning: Failed to collect Ethereum data: %v\n", err)
 	}
 	bar.Add(20)
 
-	// collect bitcoin data (mainnet only)
-	if !isTestnet {
-		if err := collectBitcoinData(manager, client, networkData); err != nil {
+	// collect bitcoin data on mainnet and regtest
+	if manager.BitcoinSupported() {
+		if err := collectBitcoinData(ctx, manager, client, networkData, filter); err != nil {
 			fmt.Printf("⚠️  Warning: Failed to collect Bitcoin data: %v\n", err)
 		}
 		bar.Add(20) 
@@ -177,7 +220,7 @@ func collectNetworkData(manager *wallet.Manager, client *api.Client, networkData
 	}
 
 	// collect solana data
-	if err := collectSolanaData(manager, client, networkData, isTestnet); err != nil {
+	if err := collectSolanaData(ctx, manager, client, networkData, isTestnet, filter); err != nil {
 		fmt.Printf("⚠️  Warning: Failed to collect Solana data: %v\n", err)
 	}
 	bar.Add(20)
@@ -186,14 +229,14 @@ func collectNetworkData(manager *wallet.Manager, client *api.Client, networkData
 	return nil
 }
 
-func collectEthereumData(manager *wallet.Manager, client *api.Client, networkData *NetworkData, isTestnet bool) error {
+func collectEthereumData(ctx context.Context, manager *wallet.Manager, client *api.Client, networkData *NetworkData, isTestnet bool, filter txFilter) error {
 	address, err := manager.GetEthereumAddress()
 	if err != nil {
 		return err
 	}
 
 	// get eth balance
-	balance, err := client.GetEthereumBalance(address.Hex())
+	balance, err := client.GetEthereumBalance(ctx, address.Hex())
 	if err != nil {
 		return err
 	}
@@ -201,10 +244,9 @@ func collectEthereumData(manager *wallet.Manager, client *api.Client, networkDat
 	// get usd value
 	var usdValue string
 	if !isTestnet {
-		price, err := client.GetPrice("ethereum")
+		price, err := client.GetPrice(ctx, "ethereum")
 		if err == nil {
-			ethValue := float64(balance.Uint64()) / 1e18
-			usdValue = fmt.Sprintf("$%.2f", ethValue*price.USD.InexactFloat64())
+			usdValue = formatUSD(fiatValue(balance, 18, price.USD))
 		} else {
 			usdValue = "N/A"
 		}
@@ -216,31 +258,26 @@ func collectEthereumData(manager *wallet.Manager, client *api.Client, networkDat
 	networkData.Currencies = append(networkData.Currencies, CurrencyData{
 		Symbol:   "ETH",
 		Name:     "Ethereum",
-		Balance:  fmt.Sprintf("%.6f ETH", float64(balance.Uint64())/1e18),
+		Balance:  formatAssetAmount(balance, 18, 6, "ETH"),
 		USDValue: usdValue,
 		Address:  address.Hex(),
 	})
 
-	// get transactions (capped at 50)
-	transactions, err := client.GetEthereumTransactions(address.Hex())
+	// get transactions (capped at 50 that pass the filters)
+	page, err := filter.wrap(func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+		return client.GetEthereumTransactions(ctx, address.Hex(), limit, cursor)
+	})(ctx, 50, "")
 	if err != nil {
 		// continue without transactions
 		return nil
 	}
-	if len(transactions) > 50 {
-		transactions = transactions[:50]
-	}
-	for _, tx := range transactions {
+	for _, tx := range page.Transactions {
 		var txUSDValue string
 		if !isTestnet {
-			price, err := client.GetPrice("ethereum")
+			price, err := transactionPrice(ctx, client, "ethereum", tx.Timestamp)
 			if err == nil {
-				if strings.Contains(tx.Amount, "ETH") {
-					ethStr := strings.TrimSpace(strings.Replace(tx.Amount, "ETH", "", -1))
-					if ethAmount, err := parseFloat(ethStr); err == nil {
-						usdVal := ethAmount * price.USD.InexactFloat64()
-						txUSDValue = fmt.Sprintf("$%.2f", usdVal)
-					}
+				if ethAmount, ok := parseAssetAmount(tx.Amount, "ETH"); ok {
+					txUSDValue = formatUSD(ethAmount.Mul(price.USD))
 				}
 			}
 		}
@@ -264,54 +301,50 @@ func collectEthereumData(manager *wallet.Manager, client *api.Client, networkDat
 			Direction:   direction,
 			Timestamp:   tx.Timestamp.Format("2006-01-02 15:04:05"),
 			BlockNumber: tx.BlockNumber,
+			Explorer:    explorerURL("ethereum", "tx", tx.Hash),
 		})
 	}
 
 	return nil
 }
 
-func collectBitcoinData(manager *wallet.Manager, client *api.Client, networkData *NetworkData) error {
+func collectBitcoinData(ctx context.Context, manager *wallet.Manager, client *api.Client, networkData *NetworkData, filter txFilter) error {
 	address, err := manager.GetBitcoinAddress()
 	if err != nil {
 		return err
 	}
-	balance, err := client.GetBitcoinBalance(address.String())
+	satoshis, err := client.GetBitcoinBalance(ctx, address.String())
 	if err != nil {
 		return err
 	}
+	balance := bitcoin.SatoshisToBTC(satoshis)
 	var usdValue string
-	price, err := client.GetPrice("bitcoin")
+	price, err := client.GetPrice(ctx, "bitcoin")
 	if err == nil {
-		usdVal := balance * price.USD.InexactFloat64()
-		usdValue = fmt.Sprintf("$%.2f", usdVal)
+		usdValue = formatUSD(balance.Mul(price.USD))
 	} else {
 		usdValue = "N/A"
 	}
 	networkData.Currencies = append(networkData.Currencies, CurrencyData{
 		Symbol:   "BTC",
 		Name:     "Bitcoin",
-		Balance:  fmt.Sprintf("%.8f BTC", balance),
+		Balance:  bitcoin.FormatBalance(satoshis),
 		USDValue: usdValue,
 		Address:  address.String(),
 	})
 
-	transactions, err := client.GetBitcoinTransactions(address.String())
+	page, err := filter.wrap(func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+		return client.GetBitcoinTransactions(ctx, address.String(), limit, cursor)
+	})(ctx, 50, "")
 	if err != nil {
 		return nil
 	}
-	if len(transactions) > 50 {
-		transactions = transactions[:50]
-	}	
-	for _, tx := range transactions {
+	for _, tx := range page.Transactions {
 		var txUSDValue string
-		price, err := client.GetPrice("bitcoin")
+		price, err := transactionPrice(ctx, client, "bitcoin", tx.Timestamp)
 		if err == nil {
-			if strings.Contains(tx.Amount, "BTC") {
-				btcStr := strings.TrimSpace(strings.Replace(tx.Amount, "BTC", "", -1))
-				if btcAmount, err := parseFloat(btcStr); err == nil {
-					usdVal := btcAmount * price.USD.InexactFloat64()
-					txUSDValue = fmt.Sprintf("$%.2f", usdVal)
-				}
+			if btcAmount, ok := parseAssetAmount(tx.Amount, "BTC"); ok {
+				txUSDValue = formatUSD(btcAmount.Mul(price.USD))
 			}
 		}
 		if txUSDValue == "" {
@@ -334,29 +367,28 @@ func collectBitcoinData(manager *wallet.Manager, client *api.Client, networkData
 			Direction:   direction,
 			Timestamp:   tx.Timestamp.Format("2006-01-02 15:04:05"),
 			BlockNumber: tx.BlockNumber,
+			Explorer:    explorerURL("bitcoin", "tx", tx.Hash),
 		})
 	}
 
 	return nil
 }
 
-func collectSolanaData(manager *wallet.Manager, client *api.Client, networkData *NetworkData, isTestnet bool) error {
+func collectSolanaData(ctx context.Context, manager *wallet.Manager, client *api.Client, networkData *NetworkData, isTestnet bool, filter txFilter) error {
 	// get solana address
 	address, err := manager.GetSolanaAddress()
 	if err != nil {
 		return err
 	}
-	balance, err := client.GetSolanaBalance(address.String())
+	balance, err := client.GetSolanaBalance(ctx, address.String())
 	if err != nil {
 		return err
 	}
 	var usdValue string
 	if !isTestnet {
-		price, err := client.GetPrice("solana")
+		price, err := client.GetPrice(ctx, "solana")
 		if err == nil {
-			solValue := float64(balance) / 1e9
-			usdVal := solValue * price.USD.InexactFloat64()
-			usdValue = fmt.Sprintf("$%.2f", usdVal)
+			usdValue = formatUSD(fiatValue(new(big.Int).SetUint64(balance), 9, price.USD))
 		} else {
 			usdValue = "N/A"
 		}
@@ -366,31 +398,25 @@ func collectSolanaData(manager *wallet.Manager, client *api.Client, networkData
 	networkData.Currencies = append(networkData.Currencies, CurrencyData{
 		Symbol:   "SOL",
 		Name:     "Solana",
-		Balance:  fmt.Sprintf("%.9f SOL", float64(balance)/1e9),
+		Balance:  formatAssetAmount(new(big.Int).SetUint64(balance), 9, 9, "SOL"),
 		USDValue: usdValue,
 		Address:  address.String(),
 	})
 
-	transactions, err := client.GetSolanaTransactions(address.String())
+	page, err := filter.wrap(func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+		return client.GetSolanaTransactions(ctx, address.String(), limit, cursor)
+	})(ctx, 50, "")
 	if err != nil {
 		return nil
 	}
 
-	if len(transactions) > 50 {
-		transactions = transactions[:50]
-	}
-
-	for _, tx := range transactions {
+	for _, tx := range page.Transactions {
 		var txUSDValue string
 		if !isTestnet {
-			price, err := client.GetPrice("solana")
+			price, err := transactionPrice(ctx, client, "solana", tx.Timestamp)
 			if err == nil {
-				if strings.Contains(tx.Amount, "SOL") {
-					solStr := strings.TrimSpace(strings.Replace(tx.Amount, "SOL", "", -1))
-					if solAmount, err := parseFloat(solStr); err == nil {
-						usdVal := solAmount * price.USD.InexactFloat64()
-						txUSDValue = fmt.Sprintf("$%.2f", usdVal)
-					}
+				if solAmount, ok := parseAssetAmount(tx.Amount, "SOL"); ok {
+					txUSDValue = formatUSD(solAmount.Mul(price.USD))
 				}
 			}
 		}
@@ -414,6 +440,7 @@ func collectSolanaData(manager *wallet.Manager, client *api.Client, networkData
 			Direction:   direction,
 			Timestamp:   tx.Timestamp.Format("2006-01-02 15:04:05"),
 			BlockNumber: tx.BlockNumber,
+			Explorer:    explorerURL("solana", "tx", tx.Hash),
 		})
 	}
 
@@ -457,6 +484,14 @@ func writeExportFiles(exportData *ExportData, exportDir string, bar *progressbar
 		bar.Add(5)
 	}
 
+	// write xlsx files
+	if xlsxFlag {
+		if err := writeXLSXExport(exportData, exportDir, timestamp, networkSuffix); err != nil {
+			return fmt.Errorf("failed to write XLSX export: %w", err)
+		}
+		bar.Add(5)
+	}
+
 	// write txt files
 	if txtFlag {
 		if err := writeTXTExport(exportData, exportDir, timestamp, networkSuffix); err != nil {
@@ -468,58 +503,192 @@ func writeExportFiles(exportData *ExportData, exportDir string, bar *progressbar
 	return nil
 }
 
+// writeCSVExport writes balances and transactions to separate CSV files with
+// one field per column, so spreadsheets can sort and filter them
 func writeCSVExport(exportData *ExportData, exportDir, timestamp, networkSuffix string) error {
-	// write data for the specified network
-	if len(exportData.Data.Currencies) > 0 || len(exportData.Data.Transactions) > 0 {
-		filename := filepath.Join(exportDir, fmt.Sprintf("odyssey_%s_%s.csv", networkSuffix, timestamp))
-		if err := writeCSVFile(filename, exportData.Data, exportData.CurrentNetwork); err != nil {
+	sheets := exportSheets(exportData)
+	for _, sheet := range sheets {
+		filename := filepath.Join(exportDir, fmt.Sprintf("odyssey_%s_%s_%s.csv", networkSuffix, timestamp, sheet.file))
+		if err := writeCSVFile(filename, sheet); err != nil {
 			return err
 		}
 	}
-
 	return nil
 }
 
-func writeCSVFile(filename string, networkData *NetworkData, networkType string) error {
-	file, err := os.Create(filename)
+func writeCSVFile(filename string, sheet exportSheet) error {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
 	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// write header
-	if err := writer.Write([]string{"Network", "Data Type", "Details"}); err != nil {
+	names := make([]string, len(sheet.columns))
+	for i, column := range sheet.columns {
+		names[i] = column.name
+	}
+	if err := writer.Write(names); err != nil {
 		return err
 	}
+	if err := writer.WriteAll(sheet.rows); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// writeXLSXExport writes a workbook with a Balances and a Transactions sheet
+func writeXLSXExport(exportData *ExportData, exportDir, timestamp, networkSuffix string) error {
+	workbook := excelize.NewFile()
+	defer workbook.Close()
 
-	// write currency data
-	for _, currency := range networkData.Currencies {
-		if err := writer.Write([]string{
-			networkType,
-			"Currency",
-			fmt.Sprintf("%s (%s): %s = %s | Address: %s",
-				currency.Name, currency.Symbol, currency.Balance, currency.USDValue, currency.Address),
-		}); err != nil {
+	for i, sheet := range exportSheets(exportData) {
+		if i == 0 {
+			if err := workbook.SetSheetName(workbook.GetSheetName(0), sheet.name); err != nil {
+				return err
+			}
+		} else if _, err := workbook.NewSheet(sheet.name); err != nil {
 			return err
 		}
+		if err := writeXLSXSheet(workbook, sheet); err != nil {
+			return fmt.Errorf("failed to write %s sheet: %w", sheet.name, err)
+		}
+	}
+
+	// Written by hand, SaveAs would create the file readable by everyone
+	filename := filepath.Join(exportDir, fmt.Sprintf("odyssey_%s_%s.xlsx", networkSuffix, timestamp))
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := workbook.Write(file); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// writeXLSXSheet fills a sheet with a frozen, filterable header row. Numeric
+// columns are stored as numbers so they can be summed.
+func writeXLSXSheet(workbook *excelize.File, sheet exportSheet) error {
+	header := make([]interface{}, len(sheet.columns))
+	for i, column := range sheet.columns {
+		header[i] = column.name
+	}
+	if err := workbook.SetSheetRow(sheet.name, "A1", &header); err != nil {
+		return err
 	}
 
-	// write transaction data
-	for _, tx := range networkData.Transactions {
-		if err := writer.Write([]string{
-			networkType,
-			"Transaction",
-			fmt.Sprintf("%s | %s | %s -> %s | Amount: %s (%s) | Fee: %s | Hash: %s | Time: %s",
-				tx.Chain, tx.Direction, tx.From, tx.To, tx.Amount, tx.USDValue, tx.Fee, tx.Hash, tx.Timestamp),
-		}); err != nil {
+	for r, row := range sheet.rows {
+		cells := make([]interface{}, len(row))
+		for i, value := range row {
+			cells[i] = value
+			if sheet.columns[i].numeric {
+				if number, err := strconv.ParseFloat(value, 64); err == nil {
+					cells[i] = number
+				}
+			}
+		}
+		cell, err := excelize.CoordinatesToCellName(1, r+2)
+		if err != nil {
+			return err
+		}
+		if err := workbook.SetSheetRow(sheet.name, cell, &cells); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	lastCell, err := excelize.CoordinatesToCellName(len(sheet.columns), len(sheet.rows)+1)
+	if err != nil {
+		return err
+	}
+	if err := workbook.AutoFilter(sheet.name, "A1:"+lastCell, nil); err != nil {
+		return err
+	}
+	return workbook.SetPanes(sheet.name, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+}
+
+// exportColumn is a column of an exported table
+type exportColumn struct {
+	name    string
+	numeric bool
+}
+
+// exportSheet is a table of the export, a CSV file or a workbook sheet
+type exportSheet struct {
+	name    string // sheet name
+	file    string // suffix of the CSV file
+	columns []exportColumn
+	rows    [][]string
+}
+
+// exportSheets lays out the balances and transactions of an export with one
+// field per column. Amounts are plain numbers, their unit has a column of its
+// own, and values that aren't known are left empty.
+func exportSheets(exportData *ExportData) []exportSheet {
+	network := exportData.CurrentNetwork
+	balances := exportSheet{
+		name: "Balances",
+		file: "balances",
+		columns: []exportColumn{
+			{name: "Network"}, {name: "Symbol"}, {name: "Name"}, {name: "Balance", numeric: true},
+			{name: "USD Value", numeric: true}, {name: "Address"},
+		},
+	}
+	for _, currency := range exportData.Data.Currencies {
+		balance, _ := splitExportAmount(currency.Balance)
+		balances.rows = append(balances.rows, []string{
+			network, currency.Symbol, currency.Name, balance, exportUSDValue(currency.USDValue), currency.Address,
+		})
+	}
+
+	transactions := exportSheet{
+		name: "Transactions",
+		file: "transactions",
+		columns: []exportColumn{
+			{name: "Network"}, {name: "Chain"}, {name: "Date"}, {name: "Direction"}, {name: "Amount", numeric: true},
+			{name: "Currency"}, {name: "Fee", numeric: true}, {name: "Fee Currency"}, {name: "USD Value", numeric: true},
+			{name: "From"}, {name: "To"}, {name: "Hash"}, {name: "Block", numeric: true},
+			{name: "Explorer"},
+		},
+	}
+	for _, tx := range exportData.Data.Transactions {
+		amount, currency := splitExportAmount(tx.Amount)
+		fee, feeCurrency := splitExportAmount(tx.Fee)
+		block := ""
+		if tx.BlockNumber > 0 {
+			block = strconv.FormatInt(tx.BlockNumber, 10)
+		}
+		transactions.rows = append(transactions.rows, []string{
+			network, tx.Chain, tx.Timestamp, tx.Direction, amount, currency, fee, feeCurrency,
+			exportUSDValue(tx.USDValue), tx.From, tx.To, tx.Hash, block,
+			tx.Explorer,
+		})
+	}
+
+	return []exportSheet{balances, transactions}
+}
+
+// splitExportAmount splits an amount such as "0.100000 ETH" into number and unit
+func splitExportAmount(amount string) (string, string) {
+	fields := strings.Fields(amount)
+	switch len(fields) {
+	case 0:
+		return "", ""
+	case 1:
+		return fields[0], ""
+	default:
+		return fields[0], strings.Join(fields[1:], " ")
+	}
+}
+
+// exportUSDValue turns "$12.34" into 12.34, and "N/A" into nothing
+func exportUSDValue(value string) string {
+	number := strings.ReplaceAll(strings.TrimPrefix(value, "$"), ",", "")
+	if _, err := strconv.ParseFloat(number, 64); err != nil {
+		return ""
+	}
+	return number
 }
 
 func writeJSONExport(exportData *ExportData, exportDir, timestamp, networkSuffix string) error {
@@ -570,6 +739,9 @@ func writeTXTExport(exportData *ExportData, exportDir, timestamp, networkSuffix
 			content.WriteString(fmt.Sprintf("     Amount: %s (%s) | Fee: %s\n",
 				tx.Amount, tx.USDValue, tx.Fee))
 			content.WriteString(fmt.Sprintf("     Hash: %s | Time: %s\n", tx.Hash, tx.Timestamp))
+			if tx.Explorer != "" {
+				content.WriteString(fmt.Sprintf("     Explorer: %s\n", tx.Explorer))
+			}
 		}
 	}
 
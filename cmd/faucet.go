@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/chains/solana"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	faucetAmountFlag string
+	faucetWaitFlag   bool
+)
+
+// Devnet rejects airdrops above 2 SOL per request, in lamports
+const maxSolanaAirdrop = 2 * 1000000000
+
+// How long --wait polls for faucet funds to arrive
+const (
+	faucetWaitTimeout  = 10 * time.Minute
+	faucetPollInterval = 10 * time.Second
+)
+
+// sepoliaFaucets lists public Sepolia faucets, {address} is replaced with the wallet address
+var sepoliaFaucets = []struct {
+	Name string
+	URL  string
+	Note string
+}{
+	{"Google Cloud Web3", "https://cloud.google.com/application/web3/faucet/ethereum/sepolia", "Google account, 0.05 ETH per day"},
+	{"Alchemy", "https://www.alchemy.com/faucets/ethereum-sepolia", "Alchemy account, needs a small mainnet balance"},
+	{"PoW Faucet", "https://sepolia-faucet.pk910.de/#/?address={address}", "No account, mine in the browser"},
+}
+
+var faucetCmd = &cobra.Command{
+	Use:   "faucet [chain]",
+	Short: "Fund your testnet wallet",
+	Long: `Get free test coins for your testnet wallet.
+
+For Solana the devnet faucet is asked for an airdrop directly. Ethereum Sepolia
+faucets require a browser, so their links are printed with your address filled
+in. Use --wait to watch your balance until the funds arrive.
+
+This command only works in testnet mode.
+
+Supported chains: eth, sol
+
+Examples:
+  odyssey faucet sol              # Airdrop 1 SOL on devnet
+  odyssey faucet sol --amount 2   # Airdrop 2 SOL
+  odyssey faucet eth --wait       # Show Sepolia faucets and wait for the funds`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFaucet,
+}
+
+func init() {
+	faucetCmd.Flags().StringVarP(&faucetAmountFlag, "amount", "a", "1", "SOL to request from the devnet faucet (max 2)")
+	faucetCmd.Flags().BoolVarP(&faucetWaitFlag, "wait", "w", false, "Wait until the funds arrive")
+}
+
+func runFaucet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	if !manager.IsTestnet() {
+		return fmt.Errorf("faucets only work in testnet mode. Run 'odyssey network testnet' first")
+	}
+
+	client := api.NewClient()
+
+	switch strings.ToLower(args[0]) {
+	case "sol", "solana":
+		return runSolanaFaucet(ctx, manager, client)
+	case "eth", "ethereum":
+		return runSepoliaFaucet(ctx, manager, client)
+	case "btc", "bitcoin":
+		return fmt.Errorf("bitcoin is only supported on mainnet")
+	default:
+		return fmt.Errorf("unsupported chain: %s. Supported chains: eth, sol", args[0])
+	}
+}
+
+func runSolanaFaucet(ctx context.Context, manager *wallet.Manager, client *api.Client) error {
+	lamports, err := solana.ParseSOL(faucetAmountFlag)
+	if err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+	if lamports == 0 || lamports > maxSolanaAirdrop {
+		return fmt.Errorf("amount must be greater than 0 and at most %s", solana.FormatBalance(maxSolanaAirdrop))
+	}
+
+	address, err := manager.GetSolanaAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get Solana address: %w", err)
+	}
+
+	startBalance, err := client.GetSolanaBalance(ctx, address.String())
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+
+	fmt.Printf("🟣 Requesting %s SOL from the devnet faucet...\n", solana.LamportsToSOL(lamports).String())
+	signature, err := client.RequestSolanaAirdrop(ctx, address.String(), lamports)
+	if err != nil {
+		if strings.Contains(err.Error(), "429") || strings.Contains(strings.ToLower(err.Error()), "limit") {
+			return fmt.Errorf("the devnet faucet is rate limited, try again later or use https://faucet.solana.com: %w", err)
+		}
+		return err
+	}
+
+	fmt.Printf("✅ Airdrop requested\n")
+	printResult(signature, "📝 Transaction Hash: %s\n", signature)
+	printExplorerLink(explorerURL("solana", "tx", signature))
+
+	if !faucetWaitFlag {
+		return nil
+	}
+
+	return waitForFaucetFunds(ctx, func() (decimal.Decimal, bool, error) {
+		balance, err := client.GetSolanaBalance(ctx, address.String())
+		if err != nil {
+			return decimal.Zero, false, err
+		}
+		return solana.LamportsToSOL(balance), balance > startBalance, nil
+	}, "SOL")
+}
+
+func runSepoliaFaucet(ctx context.Context, manager *wallet.Manager, client *api.Client) error {
+	address, err := manager.GetEthereumAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+
+	startBalance, err := client.GetEthereumBalance(ctx, address.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+
+	fmt.Println("🔷 Sepolia Faucets")
+	fmt.Printf("📍 Your address: %s\n", address.Hex())
+	fmt.Println()
+
+	for _, faucet := range sepoliaFaucets {
+		fmt.Printf("   %s (%s)\n", faucet.Name, faucet.Note)
+		fmt.Printf("   %s\n", strings.ReplaceAll(faucet.URL, "{address}", address.Hex()))
+		fmt.Println()
+	}
+
+	if !faucetWaitFlag {
+		fmt.Println("💡 Paste your address into a faucet, then run 'odyssey balance eth'")
+		return nil
+	}
+
+	return waitForFaucetFunds(ctx, func() (decimal.Decimal, bool, error) {
+		balance, err := client.GetEthereumBalance(ctx, address.Hex())
+		if err != nil {
+			return decimal.Zero, false, err
+		}
+		return ethereum.WeiToEther(balance), balance.Cmp(startBalance) > 0, nil
+	}, "ETH")
+}
+
+// waitForFaucetFunds polls a balance until it increases or the wait times out
+func waitForFaucetFunds(ctx context.Context, check func() (balance decimal.Decimal, arrived bool, err error), symbol string) error {
+	fmt.Printf("⏳ Waiting up to %s for the funds to arrive (Ctrl+C to stop)...\n", faucetWaitTimeout)
+
+	deadline := time.Now().Add(faucetWaitTimeout)
+	for time.Now().Before(deadline) {
+		balance, arrived, err := check()
+		if err != nil && !api.IsTransientError(err) {
+			return fmt.Errorf("failed to check balance: %w", err)
+		}
+		if arrived {
+			fmt.Printf("✅ Funds received! Balance: %s %s\n", balance.StringFixed(6), symbol)
+			notifyUser("Odyssey: faucet funds received", fmt.Sprintf("Balance: %s %s", balance.StringFixed(6), symbol))
+			return nil
+		}
+		if err := sleepContext(ctx, faucetPollInterval); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("no funds arrived within %s. Check 'odyssey balance' later", faucetWaitTimeout)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"golang.org/x/term"
+)
+
+// errFeeTooHigh is returned when a payment's fee is above the safety limits
+// and it can't be confirmed
+var errFeeTooHigh = errors.New("the fee is above the safety limit")
+
+// payAllowHighFeeFlag sends payments whose fee is above the safety limits
+// without asking
+var payAllowHighFeeFlag bool
+
+// checkFeeSanity asks for an extra confirmation of a payment whose fee is
+// more than safety.max_fee_percent of the amount, or on mainnet worth more
+// than safety.max_fee_usd. fee and amount are in whole coins of the asset
+// priced by priceID. Scripts and --yes get errFeeTooHigh unless
+// --allow-high-fee is given, a mistyped gas price is never paid unattended.
+func checkFeeSanity(ctx context.Context, manager *wallet.Manager, client *api.Client, priceID, symbol string, fee, amount decimal.Decimal) error {
+	if payAllowHighFeeFlag {
+		return nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	var reasons []string
+	if limit := decimal.NewFromFloat(cfg.MaxFeePercent()); limit.IsPositive() && amount.IsPositive() {
+		percent := fee.Div(amount).Mul(decimal.NewFromInt(100))
+		if percent.GreaterThan(limit) {
+			reasons = append(reasons, fmt.Sprintf("%s%% of the amount, above the %s%% of %s", percent.StringFixed(1), limit.String(), config.KeyMaxFeePercent))
+		}
+	}
+	// Testnet coins are worth nothing, their fees aren't priced
+	if limit := decimal.NewFromFloat(cfg.MaxFeeUSD()); limit.IsPositive() && !manager.IsTestnet() {
+		if price, err := client.GetPrice(ctx, priceID); err == nil {
+			if feeUSD := fee.Mul(price.USD); feeUSD.GreaterThan(limit) {
+				reasons = append(reasons, fmt.Sprintf("%s, above the %s of %s", formatUSD(feeUSD), formatUSD(limit), config.KeyMaxFeeUSD))
+			}
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	out := promptOut()
+	fmt.Fprintf(out, "⚠️  The fee of %s %s is %s\n", fee.String(), symbol, strings.Join(reasons, " and "))
+	if payYesFlag || !term.IsTerminal(int(syscall.Stdin)) {
+		return fmt.Errorf("%w: send again with --allow-high-fee to pay it, or raise the limit with 'odyssey config set'", errFeeTooHigh)
+	}
+	fmt.Fprint(out, "Pay this fee anyway? (y/n): ")
+
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		fmt.Println("❌ Transaction cancelled by user")
+		return errCancelled
+	}
+	fmt.Println()
+	return nil
+}
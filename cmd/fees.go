@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// Sizes of a simple transfer used for the cost estimates
+const (
+	bitcoinTransferVBytes      = 141   // one P2WPKH input, recipient and change outputs
+	ethereumTransferGas        = 21000 // a plain value transfer
+	solanaBaseFee              = 5000  // lamports per signature
+	solanaTransferComputeUnits = 1000  // a transfer with a compute budget instruction
+	feeHistoryBlocks           = 20
+)
+
+// feePercentiles are the priority fee percentiles shown for EVM chains and Solana
+var feePercentiles = []float64{10, 50, 90}
+
+var feesCmd = &cobra.Command{
+	Use:   "fees [chain]",
+	Short: "Show current network fees",
+	Long: `Show current network fees and the cost of a simple transfer.
+
+Bitcoin shows the recommended sat/vB tiers, Ethereum and other EVM chains the
+base fee and priority fee percentiles of the last blocks with the slow, normal
+and fast gas prices payments pay, and Solana the prioritization fee
+percentiles of recent slots.
+
+Supported chains: eth, btc, sol, polygon, arbitrum, optimism, base, bsc
+
+Examples:
+  odyssey fees          # Fees on Bitcoin, Ethereum and Solana
+  odyssey fees btc      # Bitcoin fee tiers
+  odyssey fees base     # Fees on Base`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFees,
+}
+
+func runFees(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	var chains []string
+	if len(args) == 0 {
+		if manager.IsTestnet() {
+			chains = []string{"eth", "sol"}
+		} else {
+			chains = []string{"btc", "eth", "sol"}
+		}
+	} else {
+		chain := strings.ToLower(args[0])
+		switch chain {
+		case "eth", "ethereum":
+			chains = []string{"eth"}
+		case "btc", "bitcoin":
+			if !manager.BitcoinSupported() {
+				return fmt.Errorf("bitcoin is not supported in testnet mode")
+			}
+			chains = []string{"btc"}
+		case "sol", "solana":
+			chains = []string{"sol"}
+		default:
+			evmChain, ok := api.FindEVMChain(chain)
+			if !ok {
+				return fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol, %s", chain, strings.Join(api.EVMChainNames(), ", "))
+			}
+			chains = []string{evmChain.Name}
+		}
+	}
+
+	fmt.Println("⛽ Network Fees")
+	networkType := "Mainnet"
+	if manager.IsTestnet() {
+		networkType = "Testnet"
+	}
+	fmt.Printf("🌐 Network: %s\n", networkType)
+	fmt.Println()
+
+	for _, chain := range chains {
+		var err error
+		var name string
+		switch chain {
+		case "btc":
+			name = "Bitcoin"
+			err = displayBitcoinFees(ctx, manager, client)
+		case "eth":
+			name = "Ethereum"
+			err = displayEVMFees(ctx, manager, ethereum.NewChain(manager, client), name, "ethereum", false)
+		case "sol":
+			name = "Solana"
+			err = displaySolanaFees(ctx, manager, client)
+		default:
+			evmChain, _ := api.FindEVMChain(chain)
+			name = evmChain.Label(manager.IsTestnet())
+			err = displayEVMFees(ctx, manager, ethereum.NewEVMChain(manager, client, evmChain), name, evmChain.PriceID, evmChain.L1DataFee)
+		}
+		if err != nil {
+			fmt.Printf("❌ %s: Error - %v\n", name, err)
+			fmt.Println()
+		}
+	}
+
+	printReferencePriceNote(manager)
+	return nil
+}
+
+func displayBitcoinFees(ctx context.Context, manager *wallet.Manager, client *api.Client) error {
+	tiers, err := client.GetBitcoinFeeTiers(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🟠 Bitcoin")
+	for _, tier := range []struct {
+		name string
+		rate int64
+	}{
+		{"Next block", tiers.Fastest},
+		{"30 minutes", tiers.HalfHour},
+		{"1 hour", tiers.Hour},
+		{"Economy", tiers.Economy},
+		{"Minimum", tiers.Minimum},
+	} {
+		fmt.Printf("   %-11s %4d sat/vB\n", tier.name+":", tier.rate)
+	}
+
+	cost := decimal.NewFromInt(tiers.HalfHour * bitcoinTransferVBytes).Shift(-8)
+	fmt.Printf("   💸 Transfer (~%d vB, 30 minutes): %s BTC%s\n", bitcoinTransferVBytes, cost.StringFixed(8), fiatSuffix(ctx, manager, client, "bitcoin", cost))
+	fmt.Println()
+	return nil
+}
+
+func displayEVMFees(ctx context.Context, manager *wallet.Manager, chain *ethereum.Chain, label, priceID string, l1DataFee bool) error {
+	client, symbol := chain.Client(), chain.Symbol()
+	history, err := client.GetEthereumFeeHistory(ctx, feeHistoryBlocks, feePercentiles)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔷 %s\n", label)
+	fmt.Printf("   Base fee:   %s gwei\n", formatGweiFixed(history.BaseFee))
+	for i, percentile := range history.Percentiles {
+		fmt.Printf("   Priority p%-2.0f %s gwei\n", percentile, formatGweiFixed(history.PriorityFees[i]))
+	}
+
+	// Priced at the base fee plus the median priority fee, or at the gas price
+	// payments pay
+	median := history.PriorityFees[len(history.PriorityFees)/2]
+	price := new(big.Int).Add(history.BaseFee, median)
+	pricedAt := "p50"
+	if estimate, err := chain.GasEstimate(ctx); err == nil {
+		for _, tier := range []string{config.GasTierSlow, config.GasTierNormal, config.GasTierFast} {
+			tierPrice := estimate.Price(tier)
+			wait := time.Duration(max(1, estimate.ExpectedBlocks(tierPrice))) * chain.BlockTime()
+			fmt.Printf("   %-11s %s gwei, %s\n", strings.ToUpper(tier[:1])+tier[1:]+":", formatGweiFixed(tierPrice), formatWait(wait))
+		}
+		fmt.Printf("   Sources:    %s\n", strings.Join(estimate.Sources, ", "))
+		pricedAt = chain.GasPriceTier()
+		price = estimate.Price(pricedAt)
+	}
+
+	cost := decimal.NewFromBigInt(new(big.Int).Mul(price, big.NewInt(ethereumTransferGas)), -18)
+	fmt.Printf("   💸 Transfer (%d gas, %s): %s %s%s\n", ethereumTransferGas, pricedAt, cost.StringFixed(8), symbol, fiatSuffix(ctx, manager, client, priceID, cost))
+	if l1DataFee {
+		fmt.Println("   💡 The L1 data fee of a rollup transaction is charged on top")
+	}
+	fmt.Println()
+	return nil
+}
+
+func displaySolanaFees(ctx context.Context, manager *wallet.Manager, client *api.Client) error {
+	fees, err := client.GetSolanaPrioritizationFees(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🟣 Solana")
+	fmt.Printf("   Base fee:   %d lamports per signature\n", solanaBaseFee)
+	if len(fees) == 0 {
+		fmt.Println("   No prioritization fees in recent slots")
+	}
+
+	var median uint64
+	for _, percentile := range feePercentiles {
+		fee := percentileOf(fees, percentile)
+		if len(fees) > 0 {
+			fmt.Printf("   Priority p%-2.0f %d micro-lamports/CU\n", percentile, fee)
+		}
+		if percentile == 50 {
+			median = fee
+		}
+	}
+
+	lamports := solanaBaseFee + median*solanaTransferComputeUnits/1_000_000
+	cost := decimal.NewFromInt(int64(lamports)).Shift(-9)
+	fmt.Printf("   💸 Transfer (%d CU, p50): %s SOL%s\n", solanaTransferComputeUnits, cost.StringFixed(9), fiatSuffix(ctx, manager, client, "solana", cost))
+	fmt.Println()
+	return nil
+}
+
+// fiatSuffix returns the USD value of a fee, or nothing if it can't be shown
+func fiatSuffix(ctx context.Context, manager *wallet.Manager, client *api.Client, priceID string, amount decimal.Decimal) string {
+	if !showFiatValues(manager) {
+		return ""
+	}
+	price, err := client.GetPrice(ctx, priceID)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(" (~$%s)", amount.Mul(price.USD).StringFixed(4))
+}
+
+// percentileOf returns the nearest-rank percentile of sorted values
+func percentileOf(sorted []uint64, percentile float64) uint64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(percentile / 100 * float64(len(sorted)))
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+// formatGweiFixed formats a price in wei as gwei with three decimals
+func formatGweiFixed(wei *big.Int) string {
+	return decimal.NewFromBigInt(wei, -9).StringFixed(3)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/shopspring/decimal"
+)
+
+// fiatValue returns the USD value of an amount in the smallest unit of an
+// asset, e.g. wei. The math is exact for any balance, where converting the
+// raw amount with Uint64 wraps above ~18 ETH.
+func fiatValue(raw *big.Int, decimals int32, price decimal.Decimal) decimal.Decimal {
+	return api.TokenAmount(raw, decimals).Mul(price)
+}
+
+// formatUSD formats a USD value to the cent, e.g. $1234.57
+func formatUSD(value decimal.Decimal) string {
+	return "$" + value.StringFixed(2)
+}
+
+// formatSignedUSD formats a gain or loss in dollars with its sign, e.g. +12.50
+func formatSignedUSD(value decimal.Decimal) string {
+	value = value.Round(2)
+	if value.IsNegative() {
+		return value.StringFixed(2)
+	}
+	return "+" + value.StringFixed(2)
+}
+
+// formatAssetAmount formats an amount in the smallest unit of an asset with a
+// number of decimal places, e.g. 1.500000 ETH
+func formatAssetAmount(raw *big.Int, decimals, places int32, symbol string) string {
+	return api.TokenAmount(raw, decimals).StringFixed(places) + " " + symbol
+}
+
+// parseAssetAmount parses an amount as transaction lists show it, e.g.
+// "0.5 ETH", into whole units
+func parseAssetAmount(amount, symbol string) (decimal.Decimal, bool) {
+	number, ok := strings.CutSuffix(strings.TrimSpace(amount), " "+symbol)
+	if !ok {
+		return decimal.Zero, false
+	}
+	value, err := decimal.NewFromString(strings.TrimSpace(number))
+	if err != nil {
+		return decimal.Zero, false
+	}
+	return value, true
+}
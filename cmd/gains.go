@@ -0,0 +1,385 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gainsMethodFlag string
+	gainsYearFlag   int
+	gainsOutputFlag string
+)
+
+// taxPriceIDs are the CoinGecko ids of the assets in tax reports and gains
+var taxPriceIDs = map[string]string{"ETH": "ethereum", "BTC": "bitcoin", "SOL": "solana"}
+
+var gainsCmd = &cobra.Command{
+	Use:   "gains",
+	Short: "Show cost basis and realized and unrealized gains",
+	Long: `Show the cost basis of your ETH, BTC and SOL and the gains you realized and
+haven't realized yet, per asset.
+
+Every incoming transfer is an acquisition at its USD value on the day it
+arrived, every outgoing transfer and its fee a disposal at the USD value on
+the day it was sent. Transfers between your own addresses don't count.
+Disposals are matched against acquisitions by the cost basis method:
+
+  fifo  First in, first out: the oldest coins are sold first (default)
+  lifo  Last in, first out: the newest coins are sold first
+  hifo  Highest in, first out: the most expensive coins are sold first
+
+The method is set with 'odyssey config set tax.cost_basis hifo' and applies
+to 'odyssey export --format 8949' too. Gains on coins held for more than a
+year are long-term.
+
+Coins that were sent but never received by this wallet, e.g. because the
+history is incomplete, have no cost basis, their whole proceeds count as gain.
+
+Examples:
+  odyssey gains
+  odyssey gains --year 2025
+  odyssey gains --method hifo --output json`,
+	Args: cobra.NoArgs,
+	RunE: runGains,
+}
+
+func init() {
+	gainsCmd.Flags().StringVar(&gainsMethodFlag, "method", "", "Cost basis method (fifo, lifo, hifo), default from tax.cost_basis")
+	gainsCmd.Flags().IntVar(&gainsYearFlag, "year", 0, "Only count gains realized in this year")
+	gainsCmd.Flags().StringVarP(&gainsOutputFlag, "output", "o", "text", "Output format (text, json)")
+}
+
+// AssetGains are the gains of one asset, or of all of them
+type AssetGains struct {
+	Asset         string          `json:"asset"`
+	RealizedUSD   decimal.Decimal `json:"realized_usd"`
+	ShortTermUSD  decimal.Decimal `json:"short_term_usd"`
+	LongTermUSD   decimal.Decimal `json:"long_term_usd"`
+	ProceedsUSD   decimal.Decimal `json:"proceeds_usd"`
+	Holding       string          `json:"holding,omitempty"`
+	CostBasisUSD  decimal.Decimal `json:"cost_basis_usd"`
+	ValueUSD      decimal.Decimal `json:"value_usd"`
+	UnrealizedUSD decimal.Decimal `json:"unrealized_usd"`
+	Unmatched     string          `json:"unmatched,omitempty"` // disposed without an acquisition
+}
+
+// GainsReport is the result of 'odyssey gains', as printed by --output json
+type GainsReport struct {
+	Network string       `json:"network"`
+	Method  string       `json:"method"`
+	Year    int          `json:"year,omitempty"`
+	Assets  []AssetGains `json:"assets"`
+	Total   AssetGains   `json:"total"`
+}
+
+func runGains(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	output := strings.ToLower(gainsOutputFlag)
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format: %s. Use 'text' or 'json'", gainsOutputFlag)
+	}
+	method, err := costBasisMethod(gainsMethodFlag)
+	if err != nil {
+		return err
+	}
+
+	manager := wallet.NewManager()
+	client := api.NewClient()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+	if manager.IsTestnet() {
+		return fmt.Errorf("gains are only tracked on mainnet, testnet assets have no value")
+	}
+
+	if output == "text" {
+		fmt.Println("🔄 Loading transaction history...")
+	}
+	transactions := collectTaxTransactions(ctx, manager, client)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if output == "text" {
+		fmt.Println("💵 Looking up prices at the time of each transaction...")
+	}
+	unpriced := priceTaxTransactions(ctx, client, transactions)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	basis := computeCostBasis(transactions, method)
+
+	ids := make([]string, 0, len(taxPriceIDs))
+	for _, id := range taxPriceIDs {
+		ids = append(ids, id)
+	}
+	prices, err := client.GetPrices(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to fetch current prices: %w", err)
+	}
+
+	report := buildGainsReport(basis, prices, gainsYearFlag)
+	report.Network = manager.GetCurrentNetwork()
+	report.Method = method
+
+	if output == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode gains: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printGainsReport(report)
+	if unpriced > 0 {
+		fmt.Printf("⚠️  %d transaction(s) have no historical price and count with a value of $0\n", unpriced)
+	}
+	return nil
+}
+
+// costBasisMethod returns the method passed with a flag, or the configured one
+func costBasisMethod(flag string) (string, error) {
+	if flag != "" {
+		method := strings.ToLower(flag)
+		if err := config.ValidateCostBasisMethod(method); err != nil {
+			return "", fmt.Errorf("invalid cost basis method %s: %w", flag, err)
+		}
+		return method, nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return config.CostBasisFIFO, nil
+	}
+	return cfg.CostBasisMethod(), nil
+}
+
+// costBasisLot is an acquisition that hasn't been disposed of entirely
+type costBasisLot struct {
+	acquired time.Time
+	amount   decimal.Decimal
+	cost     decimal.Decimal // USD paid for amount
+}
+
+// realizedGain is the part of a disposal matched against one lot, a row of
+// Form 8949
+type realizedGain struct {
+	asset    string
+	amount   decimal.Decimal
+	acquired time.Time // zero if no lot was left to match
+	disposed time.Time
+	proceeds decimal.Decimal
+	cost     decimal.Decimal
+	hash     string
+}
+
+func (g realizedGain) gain() decimal.Decimal {
+	return g.proceeds.Sub(g.cost)
+}
+
+// longTerm returns true if the coins were held for more than a year
+func (g realizedGain) longTerm() bool {
+	return !g.acquired.IsZero() && g.disposed.After(g.acquired.AddDate(1, 0, 0))
+}
+
+// costBasis is the outcome of matching disposals against acquisitions
+type costBasis struct {
+	method    string
+	realized  []realizedGain
+	lots      map[string][]costBasisLot  // open lots by asset
+	unmatched map[string]decimal.Decimal // disposed amounts without a lot
+}
+
+// computeCostBasis matches the disposals of chronologically ordered
+// transactions against earlier acquisitions of the same asset
+func computeCostBasis(transactions []taxTransaction, method string) *costBasis {
+	basis := &costBasis{
+		method:    method,
+		lots:      make(map[string][]costBasisLot),
+		unmatched: make(map[string]decimal.Decimal),
+	}
+
+	for _, tx := range transactions {
+		if tx.incoming {
+			basis.lots[tx.asset] = append(basis.lots[tx.asset], costBasisLot{acquired: tx.time, amount: tx.amount, cost: tx.value})
+			continue
+		}
+
+		// The fee leaves the wallet too but earns nothing, so the proceeds
+		// are spread over amount and fee
+		remaining := tx.amount.Add(tx.fee)
+		proceedsPerCoin := tx.value.Div(remaining)
+		lots := basis.lots[tx.asset]
+		for remaining.IsPositive() && len(lots) > 0 {
+			i := nextCostBasisLot(lots, method)
+			lot := &lots[i]
+			take := decimal.Min(remaining, lot.amount)
+			cost := lot.cost.Mul(take).Div(lot.amount)
+			basis.realized = append(basis.realized, realizedGain{
+				asset:    tx.asset,
+				amount:   take,
+				acquired: lot.acquired,
+				disposed: tx.time,
+				proceeds: proceedsPerCoin.Mul(take),
+				cost:     cost,
+				hash:     tx.hash,
+			})
+
+			lot.amount = lot.amount.Sub(take)
+			lot.cost = lot.cost.Sub(cost)
+			if !lot.amount.IsPositive() {
+				lots = append(lots[:i], lots[i+1:]...)
+			}
+			remaining = remaining.Sub(take)
+		}
+		basis.lots[tx.asset] = lots
+
+		if remaining.IsPositive() {
+			basis.realized = append(basis.realized, realizedGain{
+				asset:    tx.asset,
+				amount:   remaining,
+				disposed: tx.time,
+				proceeds: proceedsPerCoin.Mul(remaining),
+				hash:     tx.hash,
+			})
+			basis.unmatched[tx.asset] = basis.unmatched[tx.asset].Add(remaining)
+		}
+	}
+	return basis
+}
+
+// nextCostBasisLot returns the index of the lot a disposal is matched against
+// next. Lots are in the order they were acquired.
+func nextCostBasisLot(lots []costBasisLot, method string) int {
+	switch method {
+	case config.CostBasisLIFO:
+		return len(lots) - 1
+	case config.CostBasisHIFO:
+		highest := 0
+		for i := range lots {
+			if lots[i].cost.Div(lots[i].amount).GreaterThan(lots[highest].cost.Div(lots[highest].amount)) {
+				highest = i
+			}
+		}
+		return highest
+	default:
+		return 0
+	}
+}
+
+// buildGainsReport sums the realized gains of a year, zero for all years, and
+// values the open lots at the current prices
+func buildGainsReport(basis *costBasis, prices map[string]*api.PriceData, year int) *GainsReport {
+	report := &GainsReport{Year: year, Assets: []AssetGains{}}
+	byAsset := make(map[string]*AssetGains)
+	asset := func(symbol string) *AssetGains {
+		if byAsset[symbol] == nil {
+			byAsset[symbol] = &AssetGains{Asset: symbol}
+		}
+		return byAsset[symbol]
+	}
+
+	for _, g := range basis.realized {
+		if year != 0 && g.disposed.UTC().Year() != year {
+			continue
+		}
+		a := asset(g.asset)
+		gain := g.gain()
+		a.RealizedUSD = a.RealizedUSD.Add(gain)
+		a.ProceedsUSD = a.ProceedsUSD.Add(g.proceeds)
+		if g.longTerm() {
+			a.LongTermUSD = a.LongTermUSD.Add(gain)
+		} else {
+			a.ShortTermUSD = a.ShortTermUSD.Add(gain)
+		}
+	}
+
+	for symbol, lots := range basis.lots {
+		holding, cost := decimal.Zero, decimal.Zero
+		for _, lot := range lots {
+			holding = holding.Add(lot.amount)
+			cost = cost.Add(lot.cost)
+		}
+		if holding.IsZero() {
+			continue
+		}
+		a := asset(symbol)
+		a.Holding = fmt.Sprintf("%s %s", holding.String(), symbol)
+		a.CostBasisUSD = cost
+		if price, ok := prices[taxPriceIDs[symbol]]; ok {
+			a.ValueUSD = holding.Mul(price.USD)
+			a.UnrealizedUSD = a.ValueUSD.Sub(a.CostBasisUSD)
+		}
+	}
+	for symbol, amount := range basis.unmatched {
+		asset(symbol).Unmatched = fmt.Sprintf("%s %s", amount.String(), symbol)
+	}
+
+	for _, a := range byAsset {
+		a.RealizedUSD, a.ShortTermUSD, a.LongTermUSD = a.RealizedUSD.Round(2), a.ShortTermUSD.Round(2), a.LongTermUSD.Round(2)
+		a.ProceedsUSD, a.CostBasisUSD = a.ProceedsUSD.Round(2), a.CostBasisUSD.Round(2)
+		a.ValueUSD, a.UnrealizedUSD = a.ValueUSD.Round(2), a.UnrealizedUSD.Round(2)
+		report.Assets = append(report.Assets, *a)
+
+		total := &report.Total
+		total.RealizedUSD = total.RealizedUSD.Add(a.RealizedUSD)
+		total.ShortTermUSD = total.ShortTermUSD.Add(a.ShortTermUSD)
+		total.LongTermUSD = total.LongTermUSD.Add(a.LongTermUSD)
+		total.ProceedsUSD = total.ProceedsUSD.Add(a.ProceedsUSD)
+		total.CostBasisUSD = total.CostBasisUSD.Add(a.CostBasisUSD)
+		total.ValueUSD = total.ValueUSD.Add(a.ValueUSD)
+		total.UnrealizedUSD = total.UnrealizedUSD.Add(a.UnrealizedUSD)
+	}
+	sort.Slice(report.Assets, func(i, j int) bool { return report.Assets[i].Asset < report.Assets[j].Asset })
+	report.Total.Asset = "Total"
+	return report
+}
+
+func printGainsReport(report *GainsReport) {
+	fmt.Println()
+	fmt.Printf("📈 Gains (%s)\n", strings.ToUpper(report.Method))
+	if report.Year != 0 {
+		fmt.Printf("🗓️  Realized in %d, unrealized as of today\n", report.Year)
+	}
+	fmt.Println()
+
+	if len(report.Assets) == 0 {
+		fmt.Println("📭 No transactions found")
+		return
+	}
+
+	fmt.Printf("   %-6s %12s %12s %12s %12s %12s %12s\n", "Asset", "Realized", "Short-term", "Long-term", "Cost basis", "Value", "Unrealized")
+	fmt.Printf("   %s\n", strings.Repeat("-", 84))
+	printRow := func(a AssetGains) {
+		fmt.Printf("   %-6s %12s %12s %12s %12s %12s %12s\n", a.Asset,
+			formatSignedUSD(a.RealizedUSD), formatSignedUSD(a.ShortTermUSD), formatSignedUSD(a.LongTermUSD),
+			formatUSD(a.CostBasisUSD), formatUSD(a.ValueUSD), formatSignedUSD(a.UnrealizedUSD))
+	}
+	for _, a := range report.Assets {
+		printRow(a)
+	}
+	fmt.Printf("   %s\n", strings.Repeat("-", 84))
+	printRow(report.Total)
+	fmt.Println()
+
+	for _, a := range report.Assets {
+		if a.Holding != "" {
+			fmt.Printf("   💰 Holding %s\n", a.Holding)
+		}
+	}
+	for _, a := range report.Assets {
+		if a.Unmatched != "" {
+			fmt.Printf("⚠️  %s was sent without being received by this wallet and has no cost basis\n", a.Unmatched)
+		}
+	}
+}
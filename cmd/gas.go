@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/shopspring/decimal"
+)
+
+// Bounds of the gas overrides, values outside are almost certainly typos
+const (
+	minGasLimit      = 21000      // a plain transfer
+	maxGasLimit      = 30_000_000 // a full block
+	maxGasPriceGwei  = 10000
+	gasWarnLowRatio  = 0.5 // warn below half the network price
+	gasWarnHighRatio = 3.0 // and above three times it
+)
+
+// Gas overrides of pay on EVM chains, empty or zero uses the estimate
+var (
+	payGasLimitFlag uint64
+	payGasPriceFlag string // gwei
+	payMaxFeeFlag   string // gwei
+	payGasTierFlag  string // slow, normal or fast
+)
+
+// gasOverridesSet returns true if any gas override was given
+func gasOverridesSet() bool {
+	return payGasLimitFlag != 0 || payGasPriceFlag != "" || payMaxFeeFlag != "" || payGasTierFlag != ""
+}
+
+// validateGasFlags checks the gas overrides before anything is fetched
+func validateGasFlags() error {
+	if payGasTierFlag != "" {
+		if err := config.ValidateGasTier(payGasTierFlag); err != nil {
+			return fmt.Errorf("invalid --gas-tier: %w", err)
+		}
+		if payGasPriceFlag != "" {
+			return fmt.Errorf("--gas-tier and --gas-price can't be combined, --gas-price sets the price itself")
+		}
+	}
+	for flag, value := range map[string]string{"--gas-price": payGasPriceFlag, "--max-fee": payMaxFeeFlag} {
+		if value == "" {
+			continue
+		}
+		if _, err := parseGwei(value, flag); err != nil {
+			return err
+		}
+	}
+	if payGasLimitFlag != 0 && (payGasLimitFlag < minGasLimit || payGasLimitFlag > maxGasLimit) {
+		return fmt.Errorf("--gas-limit must be between %d and %d", minGasLimit, maxGasLimit)
+	}
+	return nil
+}
+
+// applyGasOverrides replaces the estimated gas price and limit with the ones
+// given on the command line. minGasPrice is the lowest price the chain's
+// nodes accept. Values far from the estimates are allowed with a warning.
+func applyGasOverrides(gasPrice *big.Int, gasLimit uint64, minGasPrice *big.Int) (*big.Int, uint64, error) {
+	estimatedPrice := new(big.Int).Set(gasPrice)
+
+	if payGasPriceFlag != "" {
+		price, err := parseGwei(payGasPriceFlag, "--gas-price")
+		if err != nil {
+			return nil, 0, err
+		}
+		gasPrice = price
+	}
+
+	if payMaxFeeFlag != "" {
+		maxFee, err := parseGwei(payMaxFeeFlag, "--max-fee")
+		if err != nil {
+			return nil, 0, err
+		}
+		if payGasPriceFlag != "" && gasPrice.Cmp(maxFee) > 0 {
+			return nil, 0, fmt.Errorf("--gas-price %s gwei is above --max-fee %s gwei", payGasPriceFlag, payMaxFeeFlag)
+		}
+		if gasPrice.Cmp(maxFee) > 0 {
+			fmt.Printf("⚠️  The network gas price of %s gwei is above your max fee, capping it at %s gwei\n", formatGwei(gasPrice), formatGwei(maxFee))
+			gasPrice = maxFee
+		}
+	}
+
+	if minGasPrice != nil && gasPrice.Cmp(minGasPrice) < 0 {
+		return nil, 0, fmt.Errorf("gas price of %s gwei is below the minimum of %s gwei accepted on this chain", formatGwei(gasPrice), formatGwei(minGasPrice))
+	}
+
+	if (payGasPriceFlag != "" || payMaxFeeFlag != "") && estimatedPrice.Sign() > 0 {
+		ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(gasPrice), new(big.Float).SetInt(estimatedPrice)).Float64()
+		switch {
+		case ratio < gasWarnLowRatio:
+			fmt.Printf("⚠️  %s gwei is far below the network estimate of %s gwei, the transaction may not be mined for a long time\n", formatGwei(gasPrice), formatGwei(estimatedPrice))
+		case ratio > gasWarnHighRatio:
+			fmt.Printf("⚠️  %s gwei is far above the network estimate of %s gwei, you will overpay for gas\n", formatGwei(gasPrice), formatGwei(estimatedPrice))
+		}
+	}
+
+	if payGasLimitFlag != 0 {
+		if payGasLimitFlag < minGasLimit || payGasLimitFlag > maxGasLimit {
+			return nil, 0, fmt.Errorf("--gas-limit must be between %d and %d", minGasLimit, maxGasLimit)
+		}
+		// The estimate carries a 20% safety buffer
+		if needed := gasLimit * 5 / 6; payGasLimitFlag < needed {
+			fmt.Printf("⚠️  Gas limit %d is below the estimate of %d, the transaction will likely run out of gas and still cost the fee\n", payGasLimitFlag, needed)
+		} else if float64(payGasLimitFlag) > float64(gasLimit)*gasWarnHighRatio {
+			fmt.Printf("⚠️  Gas limit %d is far above the estimate of %d, unused gas is refunded but the balance check needs it\n", payGasLimitFlag, gasLimit)
+		}
+		gasLimit = payGasLimitFlag
+	}
+
+	return gasPrice, gasLimit, nil
+}
+
+// printGasWait shows when a transaction paying gasPrice is expected to be
+// included, and the tier and sources of the network price
+func printGasWait(ctx context.Context, chain *ethereum.Chain, gasPrice *big.Int) {
+	estimate, err := chain.GasEstimate(ctx)
+	if err != nil {
+		slog.Debug("failed to get gas estimate", "error", err)
+		return
+	}
+
+	tier := chain.GasPriceTier()
+	if gasPrice.Cmp(estimate.Price(tier)) != 0 {
+		tier = "custom"
+	}
+	sources := strings.Join(estimate.Sources, ", ")
+
+	blocks := estimate.ExpectedBlocks(gasPrice)
+	if blocks == 0 {
+		fmt.Printf("   Confirms: once the base fee drops below the gas price (%s tier, from %s)\n", tier, sources)
+		return
+	}
+	wait := time.Duration(blocks) * chain.BlockTime()
+	fmt.Printf("   Confirms: %s (%s tier, from %s)\n", formatWait(wait), tier, sources)
+}
+
+// formatWait formats an expected wait for confirmation
+func formatWait(wait time.Duration) string {
+	if wait < time.Minute {
+		return fmt.Sprintf("~%ds", max(1, int(wait.Seconds())))
+	}
+	return fmt.Sprintf("~%dm", int(wait.Round(time.Minute).Minutes()))
+}
+
+// parseGwei parses a gas price in gwei into wei
+func parseGwei(value, flag string) (*big.Int, error) {
+	gwei, err := decimal.NewFromString(value)
+	if err != nil || !gwei.IsPositive() {
+		return nil, fmt.Errorf("invalid %s: %s. Give a price in gwei, e.g. 12.5", flag, value)
+	}
+	if gwei.GreaterThan(decimal.NewFromInt(maxGasPriceGwei)) {
+		return nil, fmt.Errorf("%s of %s gwei is above the limit of %d gwei", flag, value, maxGasPriceGwei)
+	}
+
+	wei := gwei.Shift(9).BigInt()
+	if wei.Sign() == 0 {
+		return nil, fmt.Errorf("invalid %s: %s gwei is below 1 wei", flag, value)
+	}
+	return wei, nil
+}
+
+// formatGwei formats a price in wei as gwei
+func formatGwei(wei *big.Int) string {
+	return decimal.NewFromBigInt(wei, -9).String()
+}
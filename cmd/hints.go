@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+// hintExemptCommands never print hints, they either set up the wallet
+// themselves or don't depend on its state
+var hintExemptCommands = map[string]bool{
+	"init":            true,
+	"unlock":          true,
+	"lock":            true,
+	"recovery-phrase": true,
+	"network":         true,
+	"config":          true,
+	"rpc":             true,
+	"migrate":         true,
+	"update":          true,
+	"version":         true,
+	"help":            true,
+	"completion":      true,
+	"validate":        true,
+}
+
+// hintState is what the running command observed, read by the hooks
+var hintState struct {
+	mu             sync.Mutex
+	cmd            *cobra.Command
+	balanceChecked bool
+	fundsSeen      bool
+}
+
+// noteBalance records a balance shown by a command, so the post-run hook can
+// tell whether the wallet is empty
+func noteBalance(nonZero bool) {
+	// Balances of several chains are fetched concurrently
+	hintState.mu.Lock()
+	defer hintState.mu.Unlock()
+
+	hintState.balanceChecked = true
+	if nonZero {
+		hintState.fundsSeen = true
+	}
+}
+
+// hintsEnabled reports whether hints may be printed after cmd
+func hintsEnabled(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+
+	if hintExemptCommands[topLevelCommand(cmd).Name()] {
+		return false
+	}
+
+	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+		return false
+	}
+	// Machine readable output must stay parseable
+	if jsonOutput(cmd) {
+		return false
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return true
+	}
+	return cfg.Hints()
+}
+
+// printHint writes a suggestion to stderr, keeping stdout clean for pipes
+func printHint(format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, "💡 "+format+"\n", a...)
+}
+
+// recordHintCommand remembers the running command for the error hints
+func recordHintCommand(cmd *cobra.Command) {
+	hintState.cmd = cmd
+}
+
+// printPostRunHints suggests next steps after a command succeeded
+func printPostRunHints(cmd *cobra.Command, args []string) {
+	if !hintsEnabled(cmd) {
+		return
+	}
+
+	if hintState.balanceChecked && !hintState.fundsSeen {
+		manager := wallet.NewManager()
+		if manager.IsTestnet() {
+			printHint("Your wallet is empty. Run 'odyssey address' to receive funds or 'odyssey faucet' to get testnet coins")
+		} else {
+			printHint("Your wallet is empty. Run 'odyssey address' to receive funds or 'odyssey buy' to purchase crypto")
+		}
+	}
+}
+
+// PrintErrorHints suggests how to recover when the last command failed
+// because of the wallet state
+func PrintErrorHints() {
+	if !hintsEnabled(hintState.cmd) {
+		return
+	}
+
+	manager := wallet.NewManager()
+	if !manager.VaultExists() {
+		printHint("No wallet found. Run 'odyssey init' to create one or 'odyssey recovery-phrase import' to restore one")
+		return
+	}
+
+	if manager.SessionStorage() == config.SessionStorageAgent {
+		if status, err := manager.AgentStatus(); err != nil || !status.Unlocked {
+			printHint("The agent doesn't hold the keys. Run 'odyssey agent start', or 'odyssey unlock' if it is running")
+		}
+		return
+	}
+	if !manager.SessionStored() {
+		printHint("Sessions aren't stored, every command asks for the password. Scripts can set %s or pass --password-file", PasswordEnv)
+		return
+	}
+	if manager.IsUnlocked() {
+		return
+	}
+
+	if network := manager.ActiveSessionNetwork(); network != "" {
+		printHint("The wallet is unlocked on %s only. Run 'odyssey network %s' to switch, or 'odyssey unlock' to unlock %s",
+			network, network, manager.GetCurrentNetwork())
+		return
+	}
+	printHint("The wallet is locked. Run 'odyssey unlock', sessions lock again after %s of inactivity",
+		formatSessionTimeout(manager.SessionTimeout()))
+}
@@ -33,24 +33,24 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Get password from user
-	fmt.Print("Enter a password for your wallet: ")
+	fmt.Fprint(promptOut(), "Enter a password for your wallet: ")
 	password, err := term.ReadPassword(int(syscall.Stdin))
 	if err != nil {
 		return fmt.Errorf("failed to read password: %w", err)
 	}
-	fmt.Println()
+	fmt.Fprintln(promptOut())
 
 	if len(password) < 8 {
 		return fmt.Errorf("password must be at least 8 characters long")
 	}
 
 	// Confirm password
-	fmt.Print("Confirm password: ")
+	fmt.Fprint(promptOut(), "Confirm password: ")
 	confirmPassword, err := term.ReadPassword(int(syscall.Stdin))
 	if err != nil {
 		return fmt.Errorf("failed to read password confirmation: %w", err)
 	}
-	fmt.Println()
+	fmt.Fprintln(promptOut())
 
 	if string(password) != string(confirmPassword) {
 		return fmt.Errorf("passwords do not match")
@@ -63,18 +63,21 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize wallet: %w", err)
 	}
 
-	// Get and display recovery phrase
-	mnemonic, err := manager.GetMnemonic()
+	// Display recovery phrase
+	fmt.Println("✅ Wallet initialized successfully!")
+	fmt.Println()
+	err = manager.WithMnemonic(func(mnemonic []byte) error {
+		fmt.Println("🔐 Recovery Phrase (24 words):")
+		fmt.Println()
+		fmt.Printf("   %s\n", mnemonic)
+		fmt.Println()
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get recovery phrase: %w", err)
 	}
+	recordAudit(manager, wallet.AuditEntry{Event: wallet.AuditRecoveryPhraseShow, Result: wallet.AuditOK, Detail: "new wallet"})
 
-	fmt.Println("✅ Wallet initialized successfully!")
-	fmt.Println()
-	fmt.Println("🔐 Recovery Phrase (24 words):")
-	fmt.Println()
-	fmt.Printf("   %s\n", mnemonic)
-	fmt.Println()
 	fmt.Println("⚠️  IMPORTANT:")
 	fmt.Println("   - Write down this recovery phrase and store it securely")
 	fmt.Println("   - Anyone with this phrase can access your funds")
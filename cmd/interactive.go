@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+var interactiveCmd = &cobra.Command{
+	Use:   "interactive",
+	Short: "Check balances, receive and send with guided menus",
+	Long: `Guided mode for checking balances, receiving and sending without
+remembering commands. Pick an action, a chain and a recipient from numbered
+menus. Every action runs the same code as its command, so sending still
+shows the confirmation prompt of 'odyssey pay'.
+
+Recipients can be picked from the watchlist or typed in.
+
+Examples:
+  odyssey interactive`,
+	Args: cobra.NoArgs,
+	RunE: runInteractive,
+}
+
+// errMenuBack is returned when the user leaves a menu
+var errMenuBack = errors.New("back")
+
+func runInteractive(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	if !manager.VaultExists() {
+		return fmt.Errorf("no wallet found. Run 'odyssey init' to create one")
+	}
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	fmt.Println("🧭 Odyssey Interactive Mode")
+	fmt.Printf("🌐 Network: %s\n", networkTitle(config.ActiveNetwork()))
+
+	actions := []string{"Check balance", "Receive", "Send", "Quit"}
+	for {
+		fmt.Println()
+		action, err := chooseOption("What would you like to do?", actions)
+		if errors.Is(err, errMenuBack) || (err == nil && actions[action] == "Quit") {
+			fmt.Println("👋 Bye")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		switch actions[action] {
+		case "Check balance":
+			err = interactiveBalance(ctx)
+		case "Receive":
+			err = interactiveReceive(ctx)
+		case "Send":
+			err = interactiveSend(ctx, manager)
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err == nil, errors.Is(err, errMenuBack), errors.Is(err, errCancelled):
+			// The cancelled payment has been reported already
+		default:
+			fmt.Printf("❌ %v\n", err)
+		}
+	}
+}
+
+// interactiveBalance shows the balance of all chains or a picked one
+func interactiveBalance(ctx context.Context) error {
+	chain, err := chooseChain("Which balance?", true)
+	if err != nil {
+		return err
+	}
+	var args []string
+	if chain != "" {
+		args = []string{chain}
+	}
+	balanceCmd.SetContext(ctx)
+	return runBalance(balanceCmd, args)
+}
+
+// interactiveReceive shows the address to receive on a picked chain
+func interactiveReceive(ctx context.Context) error {
+	chain, err := chooseChain("Receive on which chain?", false)
+	if err != nil {
+		return err
+	}
+	addressCmd.SetContext(ctx)
+	return runAddress(addressCmd, []string{chain})
+}
+
+// interactiveSend asks for the chain, recipient and amount of a payment and
+// hands it to pay, which confirms before sending
+func interactiveSend(ctx context.Context, manager *wallet.Manager) error {
+	chain, err := chooseChain("Send on which chain?", false)
+	if err != nil {
+		return err
+	}
+
+	recipient, err := chooseRecipient(manager, chain)
+	if err != nil {
+		return err
+	}
+
+	amount, err := promptLine("Amount to send: ")
+	if err != nil {
+		return err
+	}
+	selected, err := openChain(chain, manager, api.NewClient())
+	if err != nil {
+		return err
+	}
+	if _, err := chains.ParseAmount(amount, selected.Decimals()); err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("💡 Same as: odyssey pay %s %s %s\n", chain, amount, recipient)
+	fmt.Println()
+	payCmd.SetContext(ctx)
+	return runPay(payCmd, []string{chain, amount, recipient})
+}
+
+// chooseChain asks for a chain, optionally offering all of them as ""
+func chooseChain(title string, allowAll bool) (string, error) {
+	chains := []string{"eth", "btc", "sol"}
+	labels := []string{"Ethereum (ETH)", "Bitcoin (BTC)", "Solana (SOL)"}
+	for _, chain := range api.EVMChains {
+		chains = append(chains, chain.Name)
+		labels = append(labels, fmt.Sprintf("%s (%s)", chain.DisplayName, chain.Symbol))
+	}
+	if allowAll {
+		chains = append([]string{""}, chains...)
+		labels = append([]string{"All chains"}, labels...)
+	}
+
+	choice, err := chooseOption(title, labels)
+	if err != nil {
+		return "", err
+	}
+	return chains[choice], nil
+}
+
+// chooseRecipient offers the watchlist addresses of a chain, or a typed one
+func chooseRecipient(manager *wallet.Manager, chain string) (string, error) {
+	watchChain := chain
+	if _, evm := api.FindEVMChain(chain); evm {
+		watchChain = "eth" // EVM chains share Ethereum addresses
+	}
+	watchChain, _ = parseWatchChain(watchChain)
+
+	var addresses []string
+	var labels []string
+	entries, err := manager.GetWatchAddresses()
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if entry.Chain == watchChain {
+			addresses = append(addresses, entry.Address)
+			labels = append(labels, fmt.Sprintf("%s (%s)", entry.Label, truncateAddress(entry.Address)))
+		}
+	}
+	if len(addresses) > 0 {
+		choice, err := chooseOption("Send to?", append(labels, "Enter an address"))
+		if err != nil {
+			return "", err
+		}
+		if choice < len(addresses) {
+			return addresses[choice], nil
+		}
+	}
+
+	address, err := promptLine("Recipient address: ")
+	if err != nil {
+		return "", err
+	}
+	if address == "" {
+		return "", errMenuBack
+	}
+	return address, nil
+}
+
+// chooseOption prints numbered options and returns the index of the picked
+// one. An empty answer or q leaves the menu with errMenuBack.
+func chooseOption(title string, options []string) (int, error) {
+	fmt.Println(title)
+	for i, option := range options {
+		fmt.Printf("  %d) %s\n", i+1, option)
+	}
+	for {
+		answer, err := promptLine(fmt.Sprintf("Choose 1-%d: ", len(options)))
+		if err != nil {
+			return 0, err
+		}
+		if answer == "" || strings.EqualFold(answer, "q") {
+			return 0, errMenuBack
+		}
+		choice, err := strconv.Atoi(answer)
+		if err == nil && choice >= 1 && choice <= len(options) {
+			return choice - 1, nil
+		}
+		fmt.Printf("⚠️  Enter a number between 1 and %d\n", len(options))
+	}
+}
+
+// promptLine asks a question and reads the answer. Stdin is read a byte at a
+// time, so nothing is buffered away from the prompts of the commands run
+// afterwards. The end of input leaves the menu.
+func promptLine(question string) (string, error) {
+	fmt.Print(question)
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if n == 1 {
+			if buf[0] == '\n' {
+				return strings.TrimSpace(string(line)), nil
+			}
+			line = append(line, buf[0])
+			continue
+		}
+		if err == io.EOF {
+			fmt.Println()
+			return "", errMenuBack
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read answer: %w", err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chinmay1088/odyssey/crypto"
+	"github.com/chinmay1088/odyssey/wallet"
+)
+
+// withStdin makes input the answers read from stdin
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		writer.WriteString(input)
+		writer.Close()
+	}()
+
+	stdin := os.Stdin
+	os.Stdin = reader
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		reader.Close()
+	})
+}
+
+func TestInteractiveAsksTwoFactorForEveryPayment(t *testing.T) {
+	testWallet(t)
+	refuseDials(t)
+
+	secret, err := crypto.NewTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager := wallet.NewManager()
+	if err := manager.EnableTwoFactor("test-password", secret, crypto.TOTPCode(secret, time.Now())); err != nil {
+		t.Fatal(err)
+	}
+
+	// Send, on Ethereum, to the wallet itself, confirm and enter the code,
+	// twice. The payments fail on the refused balance request after 2FA.
+	code := crypto.TOTPCode(secret, time.Now())
+	payment := strings.Join([]string{"3", "1", testEthereumAddress, "0.001", "y", code}, "\n") + "\n"
+	withStdin(t, payment+payment+"4\n")
+
+	output, err := runCommand(t, "interactive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asked := strings.Count(output, "Enter your 2FA code"); asked != 2 {
+		t.Errorf("asked for the 2FA code %d times in two payments, want 2:\n%s", asked, output)
+	}
+}
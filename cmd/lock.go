@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Lock wallet and end the session",
+	Long: `Lock your Odyssey wallet immediately.
+This removes the session for every terminal, so the wallet password is required
+again for any command that needs your keys.
+
+The wallet also locks itself after being idle for the configured timeout:
+  odyssey config set session.timeout 10m
+
+Examples:
+  odyssey lock    # Lock the wallet now`,
+	Args: cobra.NoArgs,
+	RunE: runLock,
+}
+
+func runLock(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+
+	// Check if wallet exists
+	if !manager.VaultExists() {
+		return fmt.Errorf("no wallet found. Run 'odyssey init' to create a new wallet")
+	}
+
+	// Without stored sessions there is nothing to end, leftovers are removed
+	wasUnlocked := manager.SessionStored() && manager.IsUnlocked()
+	if manager.SessionStorage() == config.SessionStorageAgent {
+		status, err := manager.AgentStatus()
+		wasUnlocked = err == nil && status.Unlocked
+	}
+	manager.Lock()
+	if manager.SessionStorage() == config.SessionStorageNone {
+		fmt.Println("🔒 Sessions aren't stored, the wallet is locked after every command")
+		return nil
+	}
+
+	if wasUnlocked {
+		fmt.Println("🔒 Wallet locked")
+	} else {
+		fmt.Println("🔒 Wallet is already locked")
+	}
+
+	return nil
+}
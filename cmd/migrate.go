@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/crypto"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var migrateOverwriteFlag bool
+
+// migratePurpose binds the encrypted bundle to its use
+const migratePurpose = "odyssey-migration"
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move labels and settings to a new machine",
+	Long: `Export the wallet's metadata to an encrypted file and import it on another
+machine, so restoring the wallet from the recovery phrase doesn't lose your
+curated context.
+
+The bundle contains settings, the selected network, watch-only addresses,
+known contracts, multisig wallets and balance snapshots. It never contains
+keys or the recovery phrase. It is encrypted with a password you choose.
+
+The backup written by 'odyssey wallet delete --backup' imports the same way
+with the wallet password, and restores the vault if none exists.
+
+Examples:
+  odyssey migrate export odyssey-backup.bundle
+  odyssey migrate import odyssey-backup.bundle
+  odyssey migrate import odyssey-backup.bundle --overwrite`,
+}
+
+var migrateExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export metadata to an encrypted file",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runMigrateExport,
+}
+
+var migrateImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import metadata from an encrypted file",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runMigrateImport,
+}
+
+func init() {
+	migrateImportCmd.Flags().BoolVar(&migrateOverwriteFlag, "overwrite", false, "Replace files that already exist on this machine")
+
+	migrateCmd.AddCommand(migrateExportCmd)
+	migrateCmd.AddCommand(migrateImportCmd)
+}
+
+func runMigrateExport(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(args[0]); err == nil {
+		return fmt.Errorf("%s already exists", args[0])
+	}
+
+	manager := wallet.NewManager()
+	bundle, err := manager.ExportMetadata()
+	if err != nil {
+		return err
+	}
+	if len(bundle.Files) == 0 {
+		return fmt.Errorf("nothing to export, no settings or labels have been saved yet")
+	}
+
+	fmt.Fprint(promptOut(), "Enter a password for the export file: ")
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	fmt.Fprintln(promptOut())
+
+	if len(password) < 8 {
+		return fmt.Errorf("password must be at least 8 characters long")
+	}
+
+	fmt.Fprint(promptOut(), "Confirm password: ")
+	confirmPassword, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to read password confirmation: %w", err)
+	}
+	fmt.Fprintln(promptOut())
+
+	if string(password) != string(confirmPassword) {
+		return fmt.Errorf("passwords do not match")
+	}
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle: %w", err)
+	}
+	sealed, err := crypto.SealWithPassword(string(password), data, migratePurpose)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt bundle: %w", err)
+	}
+	out, err := json.MarshalIndent(sealed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle: %w", err)
+	}
+
+	if err := os.WriteFile(args[0], out, 0600); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	fmt.Printf("✅ Exported %s to %s\n", migrationFileList(bundle), args[0])
+	fmt.Println("💡 On the new machine restore the wallet from the recovery phrase, then run 'odyssey migrate import'")
+	return nil
+}
+
+func runMigrateImport(cmd *cobra.Command, args []string) error {
+	raw, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read export file: %w", err)
+	}
+
+	var sealed crypto.PasswordSealed
+	if err := json.Unmarshal(raw, &sealed); err != nil {
+		return fmt.Errorf("not an odyssey export file: %w", err)
+	}
+
+	fmt.Fprint(promptOut(), "Enter the export file password: ")
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	fmt.Fprintln(promptOut())
+
+	data, err := sealed.Open(string(password), migratePurpose)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt export file: %w", err)
+	}
+
+	var bundle wallet.MigrationBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return fmt.Errorf("failed to parse export file: %w", err)
+	}
+
+	manager := wallet.NewManager()
+	imported, skipped, err := manager.ImportMetadata(&bundle, migrateOverwriteFlag)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📦 Export from %s\n", bundle.CreatedAt.Local().Format("2006-01-02 15:04"))
+	if len(imported) > 0 {
+		fmt.Printf("✅ Imported: %s\n", strings.Join(imported, ", "))
+	}
+	if len(skipped) > 0 {
+		fmt.Printf("⚠️  Kept existing: %s\n", strings.Join(skipped, ", "))
+		fmt.Println("💡 Use --overwrite to replace them with the exported files")
+	}
+	return nil
+}
+
+// migrationFileList lists the files of a bundle in a stable order
+func migrationFileList(bundle *wallet.MigrationBundle) string {
+	var names []string
+	for _, name := range wallet.MigrationFiles {
+		if _, ok := bundle.Files[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
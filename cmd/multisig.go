@@ -0,0 +1,832 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/bitcoin"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	multisigIndexFlag     int
+	multisigOutputFlag    string
+	multisigBroadcastFlag bool
+)
+
+// multisigGapLimit is how many unused addresses past the last one handed out are scanned for funds
+const multisigGapLimit = 5
+
+// multisigAccountPath is the BIP-48 account path below the master key
+var multisigAccountPath = []uint32{
+	hdkeychain.HardenedKeyStart + 48,
+	hdkeychain.HardenedKeyStart + 0,
+	hdkeychain.HardenedKeyStart + 0,
+	hdkeychain.HardenedKeyStart + 2,
+}
+
+// cosignerPattern matches a key with its origin, e.g. [d34db33f/48'/0'/0'/2']xpub...
+var cosignerPattern = regexp.MustCompile(`^\[([0-9a-fA-F]{8})(/[0-9'h/]+)?\](\w+)$`)
+
+var multisigCmd = &cobra.Command{
+	Use:   "multisig",
+	Short: "Manage m-of-n Bitcoin multisig wallets",
+	Long: `Share Bitcoin funds with cosigners in an m-of-n P2WSH multisig wallet.
+
+Every cosigner exports their key with 'odyssey multisig xpub' (or from any
+wallet supporting BIP-48) and the same wallet is created on each side.
+Spends are passed between cosigners as PSBT files until enough of them
+have signed.
+
+Examples:
+  odyssey multisig xpub                                  # Share your cosigner key
+  odyssey multisig create vault 2 [fp/48'/0'/0'/2']xpub... [fp/48'/0'/0'/2']xpub...
+  odyssey multisig address vault                         # New receive address
+  odyssey multisig balance vault
+  odyssey multisig spend vault bc1q... 0.01              # Create and sign a PSBT
+  odyssey multisig sign vault-1a2b3c4d.psbt              # Add a cosigner signature
+  odyssey multisig combine a.psbt b.psbt --broadcast     # Merge and send`,
+	Args: cobra.NoArgs,
+	RunE: runMultisigList,
+}
+
+var multisigXpubCmd = &cobra.Command{
+	Use:   "xpub",
+	Short: "Show your cosigner key for multisig wallets",
+	Args:  cobra.NoArgs,
+	RunE:  runMultisigXpub,
+}
+
+var multisigCreateCmd = &cobra.Command{
+	Use:   "create [name] [threshold] [cosigner-keys...]",
+	Short: "Create a multisig wallet with your key and cosigner keys",
+	Args:  cobra.MinimumNArgs(3),
+	RunE:  runMultisigCreate,
+}
+
+var multisigRemoveCmd = &cobra.Command{
+	Use:   "remove [name]",
+	Short: "Remove a multisig wallet",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runMultisigRemove,
+}
+
+var multisigAddressCmd = &cobra.Command{
+	Use:   "address [name]",
+	Short: "Show a receive address of a multisig wallet",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runMultisigAddress,
+}
+
+var multisigBalanceCmd = &cobra.Command{
+	Use:   "balance [name]",
+	Short: "Show the balance of a multisig wallet",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runMultisigBalance,
+}
+
+var multisigSpendCmd = &cobra.Command{
+	Use:   "spend [name] [address] [amount]",
+	Short: "Create a PSBT spending from a multisig wallet and sign it",
+	Args:  cobra.ExactArgs(3),
+	RunE:  runMultisigSpend,
+}
+
+var multisigSignCmd = &cobra.Command{
+	Use:   "sign [psbt-file]",
+	Short: "Add your signature to a multisig PSBT",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runMultisigSign,
+}
+
+var multisigCombineCmd = &cobra.Command{
+	Use:   "combine [psbt-files...]",
+	Short: "Merge cosigner signatures and finalize the transaction",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runMultisigCombine,
+}
+
+func init() {
+	multisigAddressCmd.Flags().IntVar(&multisigIndexFlag, "index", -1, "Show the address at this index instead of a new one")
+	multisigSpendCmd.Flags().StringVar(&multisigOutputFlag, "output", "", "File to write the PSBT to")
+	multisigCombineCmd.Flags().StringVar(&multisigOutputFlag, "output", "", "File to write the merged PSBT to")
+	multisigCombineCmd.Flags().BoolVar(&multisigBroadcastFlag, "broadcast", false, "Broadcast the transaction once fully signed")
+
+	multisigCmd.AddCommand(multisigXpubCmd)
+	multisigCmd.AddCommand(multisigCreateCmd)
+	multisigCmd.AddCommand(multisigRemoveCmd)
+	multisigCmd.AddCommand(multisigAddressCmd)
+	multisigCmd.AddCommand(multisigBalanceCmd)
+	multisigCmd.AddCommand(multisigSpendCmd)
+	multisigCmd.AddCommand(multisigSignCmd)
+	multisigCmd.AddCommand(multisigCombineCmd)
+}
+
+func runMultisigList(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+
+	wallets, err := manager.GetMultisigWallets()
+	if err != nil {
+		return err
+	}
+
+	if len(wallets) == 0 {
+		fmt.Println("📭 No multisig wallets")
+		fmt.Println("💡 Create one with: odyssey multisig create [name] [threshold] [cosigner-keys...]")
+		return nil
+	}
+
+	fmt.Println("🔐 Multisig Wallets")
+	fmt.Println()
+	for _, w := range wallets {
+		fmt.Printf("   %-16s %d-of-%d  (%d receive addresses used)\n", w.Name, w.Threshold, len(w.Cosigners), w.NextReceive)
+	}
+
+	return nil
+}
+
+func runMultisigXpub(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	account, fingerprint, err := manager.GetMultisigAccount()
+	if err != nil {
+		return err
+	}
+	xpub, err := account.Neuter()
+	if err != nil {
+		return fmt.Errorf("failed to get account public key: %w", err)
+	}
+
+	fmt.Println("🔑 Your cosigner key (share it with the other cosigners):")
+	fmt.Println()
+	printResult(fmt.Sprintf("[%08x/48'/0'/0'/2']%s", fingerprint, xpub.String()), "[%08x/48'/0'/0'/2']%s\n", fingerprint, xpub.String())
+	return nil
+}
+
+func runMultisigCreate(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	name := args[0]
+	if !contractNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid name '%s': use up to 32 letters, digits, '_', '.' or '-'", name)
+	}
+	if _, err := manager.FindMultisigWallet(name); err == nil {
+		return fmt.Errorf("a multisig wallet named '%s' already exists", name)
+	}
+
+	threshold, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid threshold: %s", args[1])
+	}
+
+	// Our own key is always part of the wallet
+	account, fingerprint, err := manager.GetMultisigAccount()
+	if err != nil {
+		return err
+	}
+	ownXpub, err := account.Neuter()
+	if err != nil {
+		return fmt.Errorf("failed to get account public key: %w", err)
+	}
+	cosigners := []wallet.MultisigCosigner{{Fingerprint: fmt.Sprintf("%08x", fingerprint), XPub: ownXpub.String()}}
+
+	for _, arg := range args[2:] {
+		cosigner, err := parseCosigner(arg)
+		if err != nil {
+			return err
+		}
+		for _, existing := range cosigners {
+			if existing.XPub == cosigner.XPub {
+				return fmt.Errorf("cosigner key %s... is listed twice", cosigner.XPub[:16])
+			}
+		}
+		cosigners = append(cosigners, cosigner)
+	}
+
+	if len(cosigners) > bitcoin.MaxMultisigKeys {
+		return fmt.Errorf("at most %d keys are supported", bitcoin.MaxMultisigKeys)
+	}
+	if threshold < 1 || threshold > len(cosigners) {
+		return fmt.Errorf("threshold must be between 1 and %d", len(cosigners))
+	}
+
+	entry := wallet.MultisigWallet{Name: name, Threshold: threshold, Cosigners: cosigners}
+
+	// Derive the first address so a bad key is caught now
+	first, err := deriveMultisigAddress(&entry, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	if err := manager.SaveMultisigWallet(entry); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Created %d-of-%d multisig wallet '%s'\n", threshold, len(cosigners), name)
+	printResult(first.address, "📍 First address: %s\n", first.address)
+	fmt.Println("💡 Cosigners creating the same wallet must see the same first address")
+	return nil
+}
+
+func runMultisigRemove(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+
+	removed, err := manager.RemoveMultisigWallet(args[0])
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("no multisig wallet named '%s'", args[0])
+	}
+
+	fmt.Printf("✅ Removed multisig wallet '%s'\n", args[0])
+	return nil
+}
+
+func runMultisigAddress(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.BitcoinSupported() {
+		return fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+
+	w, err := manager.FindMultisigWallet(args[0])
+	if err != nil {
+		return err
+	}
+
+	index := uint32(multisigIndexFlag)
+	if multisigIndexFlag < 0 {
+		index = w.NextReceive
+	}
+
+	derived, err := deriveMultisigAddress(w, 0, index)
+	if err != nil {
+		return err
+	}
+
+	// Hand out a fresh address next time
+	if multisigIndexFlag < 0 {
+		w.NextReceive++
+		if err := manager.SaveMultisigWallet(*w); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("📍 %s receive address #%d (%d-of-%d):\n", w.Name, index, w.Threshold, len(w.Cosigners))
+	fmt.Println(derived.address)
+	return nil
+}
+
+func runMultisigBalance(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	if !manager.BitcoinSupported() {
+		return fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+
+	w, err := manager.FindMultisigWallet(args[0])
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient()
+	fmt.Printf("🔄 Scanning %s addresses...\n", w.Name)
+	utxos, err := findMultisigUTXOs(ctx, client, w)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, utxo := range utxos {
+		total += utxo.value
+	}
+
+	fmt.Println()
+	fmt.Printf("🔐 %s (%d-of-%d)\n", w.Name, w.Threshold, len(w.Cosigners))
+	fmt.Printf("   Balance: %s in %d output(s)\n", bitcoin.FormatBalance(total), len(utxos))
+	if price, err := client.GetPrice(ctx, "bitcoin"); err == nil {
+		fmt.Printf("   Value:   ~%s\n", formatUSD(bitcoin.SatoshisToBTC(total).Mul(price.USD)))
+	}
+	return nil
+}
+
+func runMultisigSpend(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	w, err := manager.FindMultisigWallet(args[0])
+	if err != nil {
+		return err
+	}
+
+	recipient, err := bitcoin.ParseAddress(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid Bitcoin address: %w", err)
+	}
+	recipientScript, err := txscript.PayToAddrScript(recipient)
+	if err != nil {
+		return fmt.Errorf("invalid Bitcoin address: %w", err)
+	}
+
+	value, err := bitcoin.ParseBTC(args[2])
+	if err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+	if err := bitcoin.ValidateAmount(value, recipient); err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+
+	client := api.NewClient()
+	fmt.Printf("🔄 Scanning %s addresses...\n", w.Name)
+	utxos, err := findMultisigUTXOs(ctx, client, w)
+	if err != nil {
+		return err
+	}
+	if len(utxos) == 0 {
+		return fmt.Errorf("multisig wallet '%s' has no funds", w.Name)
+	}
+
+	feeRate, err := client.GetBitcoinFeeEstimate(ctx)
+	if err != nil {
+		// Default to 10 sat/byte if estimation fails
+		feeRate = 10
+	}
+
+	// Spend all outputs, like regular payments
+	tx := wire.NewMsgTx(2)
+	var totalInput int64
+	for _, utxo := range utxos {
+		tx.AddTxIn(wire.NewTxIn(&utxo.outpoint, nil, nil))
+		totalInput += utxo.value
+	}
+	tx.AddTxOut(wire.NewTxOut(value, recipientScript))
+
+	// Recipients with 32 byte programs (P2WSH, taproot) are as large as our change output
+	p2wpkh, p2wsh := 1, 0
+	if len(recipientScript) == 34 {
+		p2wpkh, p2wsh = 0, 1
+	}
+	keys := len(w.Cosigners)
+	fee := bitcoin.EstimateMultisigVSize(len(utxos), w.Threshold, keys, p2wpkh, p2wsh) * feeRate
+	change := totalInput - value - fee
+	if change > bitcoin.DustThreshold {
+		fee = bitcoin.EstimateMultisigVSize(len(utxos), w.Threshold, keys, p2wpkh, p2wsh+1) * feeRate
+		change = totalInput - value - fee
+	}
+	if change < 0 {
+		return fmt.Errorf("%w: sending %s with about %s in fees but the wallet holds %s", api.ErrInsufficientFunds,
+			bitcoin.FormatBalance(value), bitcoin.FormatBalance(fee), bitcoin.FormatBalance(totalInput))
+	}
+
+	var changeAddress *multisigDerived
+	if change > bitcoin.DustThreshold {
+		changeAddress, err = deriveMultisigAddress(w, 1, w.NextChange)
+		if err != nil {
+			return err
+		}
+		tx.AddTxOut(wire.NewTxOut(change, changeAddress.pkScript))
+	} else {
+		// Dust change goes to the miners
+		fee += change
+		change = 0
+	}
+
+	packet := bitcoin.NewPSBT(tx)
+	for i, utxo := range utxos {
+		packet.Inputs[i] = bitcoin.PSBTInput{
+			WitnessUTXO:   wire.NewTxOut(utxo.value, utxo.derived.pkScript),
+			WitnessScript: utxo.derived.script,
+			Derivations:   utxo.derived.derivations,
+		}
+	}
+	if changeAddress != nil {
+		packet.Outputs[1] = bitcoin.PSBTOutput{
+			WitnessScript: changeAddress.script,
+			Derivations:   changeAddress.derivations,
+		}
+	}
+
+	printMultisigPSBT(packet)
+	if err := confirmSigning(manager, "❌ Transaction cancelled by user"); err != nil {
+		return err
+	}
+
+	signed, err := signMultisigPSBT(manager, packet)
+	if err != nil {
+		return err
+	}
+
+	if changeAddress != nil {
+		w.NextChange++
+		if err := manager.SaveMultisigWallet(*w); err != nil {
+			return err
+		}
+	}
+
+	path := multisigOutputFlag
+	if path == "" {
+		path = fmt.Sprintf("%s-%s.psbt", w.Name, tx.TxHash().String()[:8])
+	}
+	if err := writePSBTFile(path, packet); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Signed %d input(s) and saved the PSBT to %s\n", signed, path)
+	fmt.Printf("💡 Send it to %d more cosigner(s) to sign with 'odyssey multisig sign', then run 'odyssey multisig combine'\n", w.Threshold-1)
+	return nil
+}
+
+func runMultisigSign(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	packet, err := readPSBTFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	printMultisigPSBT(packet)
+	if err := confirmSigning(manager, "❌ Signing cancelled by user"); err != nil {
+		return err
+	}
+
+	signed, err := signMultisigPSBT(manager, packet)
+	if err != nil {
+		return err
+	}
+	if signed == 0 {
+		return fmt.Errorf("none of the inputs can be signed with this wallet's key")
+	}
+
+	if err := writePSBTFile(args[0], packet); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Signed %d input(s) in %s\n", signed, args[0])
+	return nil
+}
+
+func runMultisigCombine(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	packet, err := readPSBTFile(args[0])
+	if err != nil {
+		return err
+	}
+	for _, path := range args[1:] {
+		other, err := readPSBTFile(path)
+		if err != nil {
+			return err
+		}
+		if err := packet.Merge(other); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	complete := true
+	for i := range packet.Inputs {
+		have, need := packet.MultisigSignatures(i)
+		fmt.Printf("   Input %d: %d of %d signatures\n", i, have, need)
+		if have < need {
+			complete = false
+		}
+	}
+	fmt.Println()
+
+	if !complete {
+		path := multisigOutputFlag
+		if path == "" {
+			path = args[0]
+		}
+		if err := writePSBTFile(path, packet); err != nil {
+			return err
+		}
+		fmt.Printf("⏳ More signatures are needed. Merged PSBT saved to %s\n", path)
+		return nil
+	}
+
+	if err := packet.FinalizeMultisig(); err != nil {
+		return err
+	}
+	tx, err := packet.Extract()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return fmt.Errorf("failed to serialize transaction: %w", err)
+	}
+	signedTx := hex.EncodeToString(buf.Bytes())
+
+	if !multisigBroadcastFlag {
+		fmt.Println("✅ Transaction fully signed:")
+		fmt.Println(signedTx)
+		fmt.Println()
+		fmt.Println("💡 Broadcast it with: odyssey multisig combine [psbt-files...] --broadcast")
+		return nil
+	}
+
+	if !manager.BitcoinSupported() {
+		return fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+
+	from, to, amount := describeMultisigSpend(packet)
+	client := api.NewClient()
+	txHash, err := broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+		ID:       tx.TxHash().String(),
+		Chain:    "bitcoin",
+		Network:  manager.GetCurrentNetwork(),
+		SignedTx: signedTx,
+		From:     from,
+		To:       to,
+		Amount:   bitcoin.FormatBalance(amount),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	fmt.Printf("✅ Transaction sent successfully!\n")
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL("bitcoin", "tx", txHash))
+	return nil
+}
+
+// multisigDerived is a multisig address with everything needed to spend from it
+type multisigDerived struct {
+	address     string
+	script      []byte
+	pkScript    []byte
+	derivations []bitcoin.Bip32Derivation
+}
+
+// multisigUTXO is an unspent output of a multisig wallet
+type multisigUTXO struct {
+	outpoint wire.OutPoint
+	value    int64
+	derived  *multisigDerived
+}
+
+// parseCosigner parses a cosigner key, with or without its key origin
+func parseCosigner(arg string) (wallet.MultisigCosigner, error) {
+	fingerprint := "00000000"
+	xpub := arg
+	if match := cosignerPattern.FindStringSubmatch(arg); match != nil {
+		path := strings.ReplaceAll(match[2], "h", "'")
+		if path != "" && path != "/48'/0'/0'/2'" {
+			return wallet.MultisigCosigner{}, fmt.Errorf("cosigner keys must use the %s path, got m%s", bitcoin.MultisigDerivationPath, path)
+		}
+		fingerprint = strings.ToLower(match[1])
+		xpub = match[3]
+	}
+
+	key, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return wallet.MultisigCosigner{}, fmt.Errorf("invalid cosigner key %s: %w", arg, err)
+	}
+	if key.IsPrivate() {
+		return wallet.MultisigCosigner{}, fmt.Errorf("cosigner keys must be public (xpub), never share private keys")
+	}
+	if key.Depth() != uint8(len(multisigAccountPath)) {
+		return wallet.MultisigCosigner{}, fmt.Errorf("cosigner key is not a %s account key", bitcoin.MultisigDerivationPath)
+	}
+
+	// Keys exported as Zpub use another version prefix for the same key
+	key, err = key.CloneWithVersion(chaincfg.MainNetParams.HDPublicKeyID[:])
+	if err != nil {
+		return wallet.MultisigCosigner{}, fmt.Errorf("invalid cosigner key %s: %w", arg, err)
+	}
+
+	return wallet.MultisigCosigner{Fingerprint: fingerprint, XPub: key.String()}, nil
+}
+
+// deriveMultisigAddress derives the address of a multisig wallet on a branch
+// (0 receive, 1 change) at an index
+func deriveMultisigAddress(w *wallet.MultisigWallet, branch, index uint32) (*multisigDerived, error) {
+	derived := &multisigDerived{}
+	var publicKeys []*btcec.PublicKey
+	for _, cosigner := range w.Cosigners {
+		account, err := hdkeychain.NewKeyFromString(cosigner.XPub)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cosigner key: %w", err)
+		}
+		publicKey, err := bitcoin.DeriveMultisigKey(account, branch, index)
+		if err != nil {
+			return nil, err
+		}
+		fingerprint, err := strconv.ParseUint(cosigner.Fingerprint, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cosigner fingerprint: %s", cosigner.Fingerprint)
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+
+		path := append(append([]uint32{}, multisigAccountPath...), branch, index)
+		derived.derivations = append(derived.derivations, bitcoin.Bip32Derivation{
+			PubKey:      publicKey.SerializeCompressed(),
+			Fingerprint: byteSwap32(uint32(fingerprint)),
+			Path:        path,
+		})
+	}
+
+	script, err := bitcoin.MultisigScript(publicKeys, w.Threshold)
+	if err != nil {
+		return nil, err
+	}
+	address, err := bitcoin.MultisigAddress(script)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output script: %w", err)
+	}
+
+	derived.address = address.String()
+	derived.script = script
+	derived.pkScript = pkScript
+	return derived, nil
+}
+
+// findMultisigUTXOs collects unspent outputs on the receive and change
+// addresses handed out so far, plus a few unused ones past them
+func findMultisigUTXOs(ctx context.Context, client *api.Client, w *wallet.MultisigWallet) ([]multisigUTXO, error) {
+	var utxos []multisigUTXO
+	for branch, used := range []uint32{w.NextReceive, w.NextChange} {
+		for index := uint32(0); index < used+multisigGapLimit; index++ {
+			derived, err := deriveMultisigAddress(w, uint32(branch), index)
+			if err != nil {
+				return nil, err
+			}
+
+			apiUtxos, err := client.GetBitcoinUTXOs(ctx, derived.address)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get UTXOs: %w", err)
+			}
+			for _, apiUtxo := range apiUtxos {
+				hash, err := chainhash.NewHashFromStr(apiUtxo.TxID)
+				if err != nil {
+					continue
+				}
+				utxos = append(utxos, multisigUTXO{
+					outpoint: *wire.NewOutPoint(hash, apiUtxo.Vout),
+					value:    apiUtxo.Value,
+					derived:  derived,
+				})
+			}
+		}
+	}
+	return utxos, nil
+}
+
+// signMultisigPSBT signs every input that one of our derived keys can sign
+func signMultisigPSBT(manager *wallet.Manager, packet *bitcoin.PSBT) (int, error) {
+	account, fingerprint, err := manager.GetMultisigAccount()
+	if err != nil {
+		return 0, err
+	}
+
+	// Find our keys through the derivation paths recorded in the PSBT
+	seen := make(map[string]bool)
+	signed := 0
+	for _, input := range packet.Inputs {
+		for _, derivation := range input.Derivations {
+			if byteSwap32(derivation.Fingerprint) != fingerprint || len(derivation.Path) != len(multisigAccountPath)+2 {
+				continue
+			}
+			branch, index := derivation.Path[4], derivation.Path[5]
+			keyID := fmt.Sprintf("%d/%d", branch, index)
+			if seen[keyID] {
+				continue
+			}
+			seen[keyID] = true
+
+			child, err := account.Derive(branch)
+			if err == nil {
+				child, err = child.Derive(index)
+			}
+			if err != nil {
+				return signed, fmt.Errorf("failed to derive signing key: %w", err)
+			}
+			privateKey, err := child.ECPrivKey()
+			if err != nil {
+				return signed, fmt.Errorf("failed to derive signing key: %w", err)
+			}
+			if !bytes.Equal(privateKey.PubKey().SerializeCompressed(), derivation.PubKey) {
+				continue
+			}
+
+			count, err := packet.SignMultisigInputs(privateKey)
+			if err != nil {
+				return signed, err
+			}
+			signed += count
+		}
+	}
+
+	return signed, nil
+}
+
+// printMultisigPSBT shows what a PSBT spends so it can be checked before signing
+func printMultisigPSBT(packet *bitcoin.PSBT) {
+	fmt.Println()
+	fmt.Printf("📊 Multisig Transaction %s\n", shortID(packet.Tx.TxHash().String()))
+	for i, output := range packet.Tx.TxOut {
+		label := "To:    "
+		if packet.Outputs[i].WitnessScript != nil {
+			label = "Change:"
+		}
+		fmt.Printf("   %s  %s → %s\n", label, bitcoin.FormatBalance(output.Value), outputAddress(output.PkScript))
+	}
+	if fee, err := packet.Fee(); err == nil {
+		fmt.Printf("   Fee:     %s\n", bitcoin.FormatBalance(fee))
+	}
+	for i := range packet.Inputs {
+		have, need := packet.MultisigSignatures(i)
+		fmt.Printf("   Input %d: %d of %d signatures\n", i, have, need)
+	}
+}
+
+// describeMultisigSpend returns the source, recipient and amount of a spend for the pending queue
+func describeMultisigSpend(packet *bitcoin.PSBT) (string, string, int64) {
+	from := ""
+	if len(packet.Inputs) > 0 {
+		from = outputAddress(packet.Inputs[0].WitnessUTXO.PkScript)
+	}
+
+	to := ""
+	var amount int64
+	for i, output := range packet.Tx.TxOut {
+		if packet.Outputs[i].WitnessScript != nil {
+			continue
+		}
+		if to == "" {
+			to = outputAddress(output.PkScript)
+		}
+		amount += output.Value
+	}
+	return from, to, amount
+}
+
+// outputAddress returns the address an output script pays to
+func outputAddress(pkScript []byte) string {
+	_, addresses, _, err := txscript.ExtractPkScriptAddrs(pkScript, bitcoin.NetParams())
+	if err != nil || len(addresses) != 1 {
+		return "unknown script"
+	}
+	return addresses[0].String()
+}
+
+func readPSBTFile(path string) (*bitcoin.PSBT, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PSBT file: %w", err)
+	}
+	packet, err := bitcoin.DecodePSBT(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return packet, nil
+}
+
+func writePSBTFile(path string, packet *bitcoin.PSBT) error {
+	encoded, err := packet.Encode()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(encoded+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write PSBT file: %w", err)
+	}
+	return nil
+}
+
+// byteSwap32 converts between a fingerprint as written in hex and as stored in a PSBT
+func byteSwap32(v uint32) uint32 {
+	return v>>24 | (v>>8)&0xff00 | (v<<8)&0xff0000 | v<<24
+}
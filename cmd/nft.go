@@ -0,0 +1,471 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	nftChainFlag  string
+	nftAmountFlag int64
+)
+
+var nftCmd = &cobra.Command{
+	Use:   "nft",
+	Short: "List and send NFTs",
+	Long: `List the ERC-721 and ERC-1155 tokens owned by your Ethereum address and
+send them to someone else.
+
+Listing uses the Alchemy NFT API, which needs an API key:
+  odyssey config set nft.alchemy_api_key <key>
+
+NFTs of contracts on the blocklist (screening.blocklist_file), such as scam
+airdrops, are hidden from the list.
+
+Sending talks to the contract directly and needs no API key. The token
+standard is detected from the contract and the transfer is made with
+safeTransferFrom, so contracts that can't receive NFTs reject it.
+
+Token IDs can be given in decimal or as 0x prefixed hex.
+
+Examples:
+  odyssey nft list eth                                    # NFTs on Ethereum
+  odyssey nft list base                                   # NFTs on Base
+  odyssey nft send 0xBC4C...f13D 1234 0x742d35Cc...       # Send an ERC-721
+  odyssey nft send 0x7604...3a4e 7 0x742d35Cc... --amount 2  # Send 2 copies of an ERC-1155`,
+}
+
+var nftListCmd = &cobra.Command{
+	Use:   "list [chain]",
+	Short: "List your NFTs",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runNFTList,
+}
+
+var nftSendCmd = &cobra.Command{
+	Use:   "send [contract] [token-id] [to]",
+	Short: "Send an NFT",
+	Args:  cobra.ExactArgs(3),
+	RunE:  runNFTSend,
+}
+
+func init() {
+	nftSendCmd.Flags().StringVar(&nftChainFlag, "chain", "eth", "Chain the NFT is on (eth or an EVM chain like base)")
+	nftSendCmd.Flags().Int64Var(&nftAmountFlag, "amount", 1, "Copies to send, ERC-1155 only")
+	addPreSignFlags(nftSendCmd)
+	nftSendCmd.Flags().Int64Var(&ethNonceFlag, "nonce", -1, "Nonce to use, reuse a pending nonce to replace that transaction")
+
+	nftCmd.AddCommand(nftListCmd)
+	nftCmd.AddCommand(nftSendCmd)
+}
+
+// resolveNFTChain returns the chain name and, for chains besides Ethereum,
+// the EVM chain an NFT command runs on
+func resolveNFTChain(name string) (string, *api.EVMChain, error) {
+	switch strings.ToLower(name) {
+	case "", "eth", "ethereum":
+		return "ethereum", nil, nil
+	}
+	if chain, ok := api.FindEVMChain(name); ok {
+		return chain.Name, chain, nil
+	}
+	return "", nil, fmt.Errorf("unsupported NFT chain: %s. Supported chains: eth, polygon, arbitrum, optimism, base, bsc", name)
+}
+
+func runNFTList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	chainArg := ""
+	if len(args) > 0 {
+		chainArg = args[0]
+	}
+	chainName, evmChain, err := resolveNFTChain(chainArg)
+	if err != nil {
+		return err
+	}
+	if !api.SupportsNFTs(chainName) {
+		return fmt.Errorf("listing NFTs is not supported on %s. Supported chains: eth, polygon, arbitrum, optimism, base", chainName)
+	}
+
+	address, err := manager.GetEthereumAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get address: %w", err)
+	}
+
+	apiKey := ""
+	if cfg, cfgErr := config.Load(); cfgErr == nil {
+		apiKey = cfg.Get(config.KeyAlchemyAPIKey)
+	}
+
+	label := ethereumLabel()
+	if evmChain != nil {
+		label = evmChain.Label(manager.IsTestnet())
+	}
+
+	fmt.Printf("🖼️  Fetching NFTs on %s...\n", label)
+
+	client := api.NewClient()
+	nfts, err := client.GetNFTs(ctx, chainName, address.Hex(), apiKey)
+	if err != nil {
+		return err
+	}
+
+	// Scam airdrops of blocklisted contracts are hidden
+	blocklist, err := config.LoadBlocklist()
+	if err != nil {
+		slog.Debug("failed to read blocklist", "error", err)
+	}
+	var hidden int
+	listed := nfts[:0]
+	for _, nft := range nfts {
+		if blocklist.Token(chainName, nft.Contract) != nil {
+			hidden++
+			continue
+		}
+		listed = append(listed, nft)
+	}
+	nfts = listed
+
+	fmt.Println()
+	if len(nfts) == 0 {
+		fmt.Printf("No NFTs found for %s\n", address.Hex())
+		printHiddenNFTs(hidden)
+		return nil
+	}
+
+	// Group tokens by contract, largest collections first
+	collections := make(map[string][]api.NFT)
+	var contracts []string
+	for _, nft := range nfts {
+		key := strings.ToLower(nft.Contract)
+		if _, ok := collections[key]; !ok {
+			contracts = append(contracts, key)
+		}
+		collections[key] = append(collections[key], nft)
+	}
+	sort.SliceStable(contracts, func(i, j int) bool {
+		return len(collections[contracts[i]]) > len(collections[contracts[j]])
+	})
+
+	fmt.Printf("🖼️  %d NFT%s in %d collection%s\n", len(nfts), plural(len(nfts)), len(contracts), plural(len(contracts)))
+	for _, contract := range contracts {
+		tokens := collections[contract]
+		collection := tokens[0].Collection
+		if collection == "" {
+			collection = "Unnamed collection"
+		}
+
+		fmt.Println()
+		fmt.Printf("📦 %s (%s)\n", collection, tokens[0].Standard)
+		fmt.Printf("   Contract: %s\n", tokens[0].Contract)
+		for _, nft := range tokens {
+			line := fmt.Sprintf("   #%s", nft.TokenID)
+			if nft.Name != "" {
+				line += "  " + nft.Name
+			}
+			if nft.Standard == ethereum.StandardERC1155 && nft.Balance != "" && nft.Balance != "1" {
+				line += fmt.Sprintf("  ×%s", nft.Balance)
+			}
+			fmt.Println(line)
+		}
+	}
+
+	fmt.Println()
+	printHiddenNFTs(hidden)
+	fmt.Println("💡 Send one with 'odyssey nft send <contract> <token-id> <to>'")
+
+	return nil
+}
+
+// printHiddenNFTs says how many NFTs of blocklisted contracts were hidden
+func printHiddenNFTs(hidden int) {
+	if hidden > 0 {
+		fmt.Printf("🚫 %d NFT%s of blocklisted contracts hidden\n", hidden, plural(hidden))
+	}
+}
+
+func runNFTSend(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	chainName, evmChain, err := resolveNFTChain(nftChainFlag)
+	if err != nil {
+		return err
+	}
+	testnet := manager.IsTestnet()
+
+	contract, err := ethereum.ParseAddress(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid contract address: %w", err)
+	}
+	tokenID, err := ethereum.ParseTokenID(args[1])
+	if err != nil {
+		return err
+	}
+	recipient, err := ethereum.ParseAddress(args[2])
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+	if nftAmountFlag < 1 {
+		return fmt.Errorf("invalid amount: %d", nftAmountFlag)
+	}
+
+	sender, err := manager.GetEthereumAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get sender address: %w", err)
+	}
+	if sender == recipient {
+		return fmt.Errorf("the recipient is your own address")
+	}
+
+	// Prices come from the shared client, everything else from the chain's node
+	client := api.NewClient()
+	chainClient := client
+	symbol := "ETH"
+	if evmChain != nil {
+		chainClient = client.ForEVMChain(evmChain)
+		symbol = evmChain.Symbol
+	}
+
+	standard, err := detectNFTStandard(ctx, chainClient, contract)
+	if err != nil {
+		return err
+	}
+	if standard == ethereum.StandardERC721 && nftAmountFlag != 1 {
+		return fmt.Errorf("ERC-721 tokens are unique, --amount only applies to ERC-1155")
+	}
+	amount := big.NewInt(nftAmountFlag)
+
+	if err := checkNFTOwnership(ctx, chainClient, standard, contract, tokenID, sender, amount); err != nil {
+		return err
+	}
+
+	data, err := ethereum.EncodeNFTTransfer(standard, sender, recipient, tokenID, amount)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := nextEthereumNonce(ctx, manager, chainClient, chainName, sender.Hex())
+	if err != nil {
+		return err
+	}
+
+	gasPrice, err := chainClient.GetEthereumGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get gas price: %w", err)
+	}
+	if evmChain == nil {
+		// Add 20% to gas price to ensure faster inclusion
+		gasPrice.Mul(gasPrice, big.NewInt(120))
+		gasPrice.Div(gasPrice, big.NewInt(100))
+	} else {
+		if evmChain.GasPriceBump > 0 {
+			gasPrice.Mul(gasPrice, big.NewInt(100+evmChain.GasPriceBump))
+			gasPrice.Div(gasPrice, big.NewInt(100))
+		}
+		if minimum := big.NewInt(evmChain.MinGasPrice); gasPrice.Cmp(minimum) < 0 {
+			gasPrice = minimum
+		}
+	}
+
+	gasLimit, err := chainClient.GetEthereumGasEstimate(ctx, sender.Hex(), contract.Hex(), big.NewInt(0), data)
+	if err != nil {
+		gasLimit = ethereum.EstimateGasLimit(data)
+	}
+
+	tx := ethereum.NewTransaction(nonce, contract, big.NewInt(0), gasLimit, gasPrice, data)
+	if evmChain != nil {
+		tx.ChainID = evmChain.ChainID(testnet)
+	}
+	if err := ethereum.ValidateTransaction(tx); err != nil {
+		return fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	privateKey, err := manager.GetEthereumKey()
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	// Signing first lets OP Stack chains price the exact bytes
+	signedTx, err := ethereum.SignTransaction(tx, privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	maxFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+	if evmChain != nil && evmChain.L1DataFee {
+		raw, err := hexutil.Decode(signedTx)
+		if err != nil {
+			return fmt.Errorf("invalid transaction hex: %w", err)
+		}
+		l1Fee, err := chainClient.GetL1DataFee(ctx, raw)
+		if err != nil {
+			return err
+		}
+		maxFee.Add(maxFee, l1Fee)
+	}
+
+	balance, err := chainClient.GetEthereumBalance(ctx, sender.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+	if balance.Cmp(maxFee) < 0 {
+		return fmt.Errorf("%w for gas. The transfer needs about %s %s but your balance is only %s %s", api.ErrInsufficientFunds,
+			ethereum.WeiToEther(maxFee).StringFixed(6), symbol, ethereum.WeiToEther(balance).StringFixed(6), symbol)
+	}
+
+	fmt.Printf("📊 Transaction Details:\n")
+	if evmChain != nil {
+		fmt.Printf("   Chain:    %s\n", evmChain.Label(testnet))
+	}
+	fmt.Printf("   From:     %s\n", sender.Hex())
+	fmt.Printf("   To:       %s\n", recipient.Hex())
+	fmt.Printf("   Contract: %s (%s)\n", contract.Hex(), standard)
+	fmt.Printf("   Token ID: %s\n", tokenID.String())
+	if standard == ethereum.StandardERC1155 {
+		fmt.Printf("   Amount:   %d\n", nftAmountFlag)
+	}
+	fmt.Printf("   Max Fee:  ~%s %s\n", ethereum.WeiToEther(maxFee).StringFixed(6), symbol)
+	fmt.Printf("   Network:  %s\n", manager.GetCurrentNetwork())
+	fmt.Println()
+
+	// Catches transfers that would revert, e.g. to a contract that doesn't accept NFTs
+	sim, simErr := chainClient.SimulateEthereumTransaction(ctx, tx.ChainID, sender.Hex(), contract.Hex(), big.NewInt(0), data, gasLimit, gasPrice)
+	if _, err := reportSimulation(sim, simErr, simulationAssets{symbol: symbol, decimals: 18, owner: sender.Hex()}); err != nil {
+		return err
+	}
+
+	if err := confirmSigning(manager, "❌ Transaction cancelled"); err != nil {
+		return err
+	}
+	// The transfer pays no native asset, the recipient and the fee are checked
+	err = checkBeforeSigning(ctx, manager, client, outgoingValue{
+		chain:     chainName,
+		recipient: recipient.Hex(),
+		fee:       ethereum.WeiToEther(maxFee),
+	})
+	if err != nil {
+		return err
+	}
+
+	txID, err := ethereum.TransactionHash(signedTx)
+	if err != nil {
+		return err
+	}
+
+	description := fmt.Sprintf("NFT #%s", tokenID.String())
+	if standard == ethereum.StandardERC1155 {
+		description = fmt.Sprintf("%d × NFT #%s", nftAmountFlag, tokenID.String())
+	}
+
+	txHash, err := broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+		ID:       txID,
+		Chain:    chainName,
+		Network:  manager.GetCurrentNetwork(),
+		SignedTx: signedTx,
+		From:     sender.Hex(),
+		To:       recipient.Hex(),
+		Amount:   description,
+		Nonce:    nonce,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	fmt.Printf("✅ Transaction sent successfully!\n")
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	switch {
+	case evmChain != nil:
+		printExplorerLink(explorerURL(evmChain.Name, "tx", txHash))
+	default:
+		printExplorerLink(explorerURL("ethereum", "tx", txHash))
+	}
+
+	return nil
+}
+
+// detectNFTStandard asks a contract through ERC-165 which NFT standard it implements
+func detectNFTStandard(ctx context.Context, client *api.Client, contract common.Address) (string, error) {
+	for _, candidate := range []struct {
+		standard    string
+		interfaceID [4]byte
+	}{
+		{ethereum.StandardERC721, ethereum.InterfaceERC721},
+		{ethereum.StandardERC1155, ethereum.InterfaceERC1155},
+	} {
+		data, err := ethereum.EncodeSupportsInterface(candidate.interfaceID)
+		if err != nil {
+			return "", err
+		}
+		result, err := client.CallEthereumContract(ctx, contract.Hex(), data)
+		if err != nil {
+			// Contracts without ERC-165 revert, which only rules out this standard
+			continue
+		}
+		if len(result) >= 32 && new(big.Int).SetBytes(result[:32]).Sign() != 0 {
+			return candidate.standard, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s is not an ERC-721 or ERC-1155 contract", contract.Hex())
+}
+
+// checkNFTOwnership makes sure owner holds at least amount of a token
+func checkNFTOwnership(ctx context.Context, client *api.Client, standard string, contract common.Address, tokenID *big.Int, owner common.Address, amount *big.Int) error {
+	if standard == ethereum.StandardERC721 {
+		data, err := ethereum.EncodeOwnerOf(tokenID)
+		if err != nil {
+			return err
+		}
+		result, err := client.CallEthereumContract(ctx, contract.Hex(), data)
+		if err != nil {
+			return fmt.Errorf("failed to look up token #%s, it may not exist: %w", tokenID.String(), err)
+		}
+		if len(result) < 32 {
+			return fmt.Errorf("failed to look up token #%s: unexpected result", tokenID.String())
+		}
+		if current := common.BytesToAddress(result[12:32]); current != owner {
+			return fmt.Errorf("you don't own token #%s, it belongs to %s", tokenID.String(), current.Hex())
+		}
+		return nil
+	}
+
+	data, err := ethereum.EncodeERC1155BalanceOf(owner, tokenID)
+	if err != nil {
+		return err
+	}
+	result, err := client.CallEthereumContract(ctx, contract.Hex(), data)
+	if err != nil {
+		return fmt.Errorf("failed to look up token #%s: %w", tokenID.String(), err)
+	}
+	if len(result) < 32 {
+		return fmt.Errorf("failed to look up token #%s: unexpected result", tokenID.String())
+	}
+	if balance := new(big.Int).SetBytes(result[:32]); balance.Cmp(amount) < 0 {
+		return fmt.Errorf("insufficient copies of token #%s. You're trying to send %s but you only own %s", tokenID.String(), amount.String(), balance.String())
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/notify"
+)
+
+// notifyUser shows a desktop notification unless display.notifications is
+// off. Failures only cost the notification and are just logged, the same message
+// is always printed too.
+func notifyUser(title, message string) {
+	cfg, err := config.Load()
+	if err == nil && !cfg.Notifications() {
+		return
+	}
+	if err := notify.Send(title, message); err != nil {
+		slog.Debug("failed to send notification", "error", err)
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/wallet"
+)
+
+// The addresses of the first account of the all "abandon" test phrase. The
+// Bitcoin address is the native SegWit one of the m/44' key.
+const (
+	testMnemonic        = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	testEthereumAddress = "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+	testBitcoinAddress  = "bc1qmxrw6qdh5g3ztfcwm0et5l8mvws4eva24kmp8m"
+)
+
+// refuseDials makes every connection through the default transport fail and
+// returns the addresses that were dialed
+func refuseDials(t *testing.T) func() []string {
+	var mu sync.Mutex
+	var dialed []string
+
+	transport := http.DefaultTransport
+	http.DefaultTransport = &http.Transport{
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			dialed = append(dialed, address)
+			return nil, errors.New("dial refused by the test")
+		},
+	}
+	t.Cleanup(func() { http.DefaultTransport = transport })
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), dialed...)
+	}
+}
+
+// runCommand runs odyssey with args and returns what it printed
+func runCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+
+	rootCmd.SetArgs(args)
+	err = rootCmd.ExecuteContext(context.Background())
+	writer.Close()
+	return <-output, err
+}
+
+// testWallet creates an unlocked wallet of the test phrase in an empty home
+// directory
+func testWallet(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	for _, env := range []string{OfflineEnv, "ODYSSEY_WALLET", "ODYSSEY_NETWORK", "NO_COLOR"} {
+		t.Setenv(env, "")
+		os.Unsetenv(env)
+	}
+	t.Cleanup(func() { api.SetOffline(false) })
+
+	if err := wallet.NewManager().ImportFromMnemonic(testMnemonic, "test-password"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOfflineAddressMakesNoRequests(t *testing.T) {
+	testWallet(t)
+	dialed := refuseDials(t)
+
+	output, err := runCommand(t, "address", "--offline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, testEthereumAddress) || !strings.Contains(output, testBitcoinAddress) {
+		t.Errorf("output doesn't show the wallet's addresses:\n%s", output)
+	}
+	if hosts := dialed(); len(hosts) != 0 {
+		t.Errorf("dialed %v in offline mode", hosts)
+	}
+}
+
+func TestOfflineReceiveMakesNoRequests(t *testing.T) {
+	testWallet(t)
+	dialed := refuseDials(t)
+
+	for _, chain := range []string{"eth", "btc", "sol", "base"} {
+		output, err := runCommand(t, "receive", chain, "0.01", "--no-qr", "--offline")
+		if err != nil {
+			t.Fatalf("%s: %v", chain, err)
+		}
+		if !strings.Contains(output, "URI:") {
+			t.Errorf("%s: output has no payment request:\n%s", chain, output)
+		}
+	}
+	if hosts := dialed(); len(hosts) != 0 {
+		t.Errorf("dialed %v in offline mode", hosts)
+	}
+}
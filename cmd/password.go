@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// PasswordEnv holds the wallet password for scripts that can't type it.
+// --password-file takes precedence.
+const PasswordEnv = "ODYSSEY_PASSWORD"
+
+// readWalletPassword returns the wallet password from --password-file, from
+// ODYSSEY_PASSWORD or typed at the prompt, in that order. Passwords that
+// weren't typed come with a warning on stderr, even in quiet mode.
+func readWalletPassword(cmd *cobra.Command, prompt string) (string, error) {
+	if path, _ := cmd.Flags().GetString("password-file"); path != "" {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password file: %w", err)
+		}
+		if checkPermissions && info.Mode().Perm()&0077 != 0 {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: %s is readable by other users (%s), run 'chmod 600 %s'\n", path, info.Mode().Perm(), path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password file: %w", err)
+		}
+		// Editors and echo end the file with a newline that isn't part of it
+		password := strings.TrimRight(string(data), "\r\n")
+		if password == "" {
+			return "", fmt.Errorf("password file %s is empty", path)
+		}
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: using the wallet password stored in %s. Anyone who can read it can unlock your wallet.\n", path)
+		return password, nil
+	}
+
+	if password, ok := os.LookupEnv(PasswordEnv); ok && password != "" {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: using the wallet password from %s. Other processes of your user and your shell history may see it, prefer --password-file.\n", PasswordEnv)
+		return password, nil
+	}
+
+	if !term.IsTerminal(int(syscall.Stdin)) {
+		return "", fmt.Errorf("no terminal to ask for the password, use --password-file or %s", PasswordEnv)
+	}
+	fmt.Fprint(promptOut(), prompt)
+	password, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(promptOut()) // New line after password input
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return string(password), nil
+}
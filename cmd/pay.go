@@ -1,16 +1,44 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"os"
 	"strings"
+	"syscall"
 
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains"
 	"github.com/chinmay1088/odyssey/chains/bitcoin"
 	"github.com/chinmay1088/odyssey/chains/ethereum"
 	"github.com/chinmay1088/odyssey/chains/solana"
+	"github.com/chinmay1088/odyssey/config"
 	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// payYesFlag skips the confirmation prompt of pay
+var payYesFlag bool
+
+// payForceFlag sends a payment even if the same one was sent recently
+var payForceFlag bool
+
+// Durable nonce and preflight flags of Solana payments
+var (
+	payDurableNonceFlag  bool
+	paySignOnlyFlag      bool
+	paySkipPreflightFlag bool
+)
+
+// Coin selection and OP_RETURN memo of Bitcoin payments
+var (
+	payCoinSelectionFlag string
+	payMemoFlag          string
 )
 
 var payCmd = &cobra.Command{
@@ -18,29 +46,79 @@ var payCmd = &cobra.Command{
 	Short: "Send cryptocurrency",
 	Long: `Send cryptocurrency to another address.
 	
-Supported chains: eth, btc, sol
+Supported chains: eth, btc, sol, polygon, arbitrum, optimism, base, bsc
 	
 Examples:
-  odyssey pay eth 0.1 0x742d35Cc6634C0532925a3b8D4C9db96C4b4d8b6
+  odyssey pay eth 0.1 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6
+  odyssey pay arbitrum 0.1 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6
   odyssey pay btc 0.001 bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh
-  odyssey pay sol 1.5 7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU`,
+  odyssey pay sol 1.5 7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU
+  odyssey pay eth 0.1 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6 --simulate-only
+  odyssey pay btc 0.001 bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh --dry-run
+  odyssey pay btc 0.001 bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh --coin-selection privacy
+  odyssey pay btc 0.001 bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh --memo "invoice 1042"
+  odyssey pay eth 0.1 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6 --nonce 42   # Replace a stuck transaction
+  odyssey pay eth 0.1 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6 --gas-tier fast
+  odyssey pay eth 0.1 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6 --gas-price 3 --gas-limit 30000
+  odyssey pay sol 0.5 7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU --yes   # No confirmation, for scripts
+  odyssey pay sol 0.5 7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU --durable-nonce --sign-only
+
+Ethereum, EVM chain and Solana payments are simulated before they are sent,
+showing the balance changes and stopping if the transaction would fail. Use
+--simulate-only to stop after the simulation, or --dry-run to only build the
+transaction and print it without signing.
+
+EVM payments pay the gas price of the gas.tier setting, slow, normal or fast.
+Each tier is the median of the node's fee history and gas price and, on
+Ethereum mainnet with an Etherscan API key, the Etherscan gas tracker. The
+expected confirmation time is shown with the fee. --gas-tier overrides it for
+one payment.
+
+Bitcoin payments spend the UTXOs chosen by the bitcoin.coin_selection setting:
+fee looks for the lowest fee and avoids change where it can, inputs spends the
+largest UTXOs, privacy spends whole addresses so they aren't linked, and all
+consolidates every UTXO. --coin-selection overrides it for one payment.
+--memo records up to 80 bytes of text on chain in an OP_RETURN output.
+
+Payments whose fee is more than safety.max_fee_percent of the amount (25% by
+default) or, on mainnet, worth more than safety.max_fee_usd ($100 by default)
+ask for an extra confirmation. With --yes or in scripts they are refused
+unless --allow-high-fee is given.
+
+The same amount sent to the same address within safety.duplicate_window (10m
+by default) is refused, so re-running a payment after a timeout doesn't pay
+twice. The original transaction is shown instead, and retried if it is still
+queued. --force sends it again.
+
+Recipients on the blocklist (screening.blocklist_file) or, with
+screening.chainalysis_api_key set, the Chainalysis sanctions list are shown
+with the reason and need an extra confirmation. With --yes or in scripts they
+are refused unless --allow-flagged is given.
+
+Solana payments expire about a minute after signing. --durable-nonce signs
+with the nonce of the wallet's nonce account instead (see 'odyssey sol nonce
+create'), so the transaction stays valid until the nonce is advanced. Combine
+it with --sign-only to print the signed transaction and send it later with
+'odyssey sol broadcast'.
+
+Solana nodes check a transaction before accepting it. Congested nodes can fail
+that preflight check for payments that would land, --skip-preflight sends
+without it and failures show up once the transaction is processed.
+
+USD values are hidden on testnet. To rehearse with mainnet prices shown as
+reference: odyssey config set display.testnet_prices true`,
 	Args: cobra.ExactArgs(3),
 	RunE: runPay,
 }
 
 func runPay(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	manager := wallet.NewManager()
 	client := api.NewClient()
 
 	// Check if wallet is unlocked
 	if !manager.IsUnlocked() {
-		return fmt.Errorf("wallet is locked. Run 'odyssey unlock' first")
-	}
-
-	// Get confirmation before proceeding with any transaction
-	if !getTransactionConfirmation(manager) {
-		fmt.Println("❌ Transaction cancelled by user")
-		return nil
+		return errWalletLocked
 	}
 
 	chain := strings.ToLower(args[0])
@@ -49,19 +127,104 @@ func runPay(cmd *cobra.Command, args []string) error {
 
 	usdFlag, _ := cmd.Flags().GetBool("usd")
 
-	switch chain {
-	case "eth", "ethereum":
-		return sendEthereum(manager, client, amountStr, recipientAddress, usdFlag)
-	case "btc", "bitcoin":
-		return sendBitcoin(manager, client, amountStr, recipientAddress, usdFlag)
-	case "sol", "solana":
-		return sendSolana(manager, client, amountStr, recipientAddress, usdFlag)
+	if ethNonceFlag >= 0 || gasOverridesSet() {
+		if _, evm := api.FindEVMChain(chain); !evm && chain != "eth" && chain != "ethereum" {
+			return fmt.Errorf("--nonce and the gas flags only apply to Ethereum and other EVM chains")
+		}
+		if err := validateGasFlags(); err != nil {
+			return err
+		}
+	}
+
+	if payDurableNonceFlag || paySignOnlyFlag || paySkipPreflightFlag {
+		if chain != "sol" && chain != "solana" {
+			return fmt.Errorf("--durable-nonce, --sign-only and --skip-preflight only apply to Solana")
+		}
+		if paySignOnlyFlag && !payDurableNonceFlag {
+			return fmt.Errorf("--sign-only needs --durable-nonce, otherwise the transaction expires within a minute")
+		}
+	}
+
+	if payCoinSelectionFlag != "" || payMemoFlag != "" {
+		if chain != "btc" && chain != "bitcoin" {
+			return fmt.Errorf("--coin-selection and --memo only apply to Bitcoin")
+		}
+		if len(payMemoFlag) > bitcoin.MaxDataSize {
+			return fmt.Errorf("--memo is %d bytes, nodes relay at most %d", len(payMemoFlag), bitcoin.MaxDataSize)
+		}
+	}
+	if payCoinSelectionFlag != "" {
+		if err := config.ValidateCoinSelection(payCoinSelectionFlag); err != nil {
+			return fmt.Errorf("invalid --coin-selection: %w", err)
+		}
+	}
+
+	asset, err := findPayAsset(chain)
+	if err != nil {
+		return err
+	}
+
+	// A mistyped address is reported before asking to confirm
+	if _, evm := api.FindEVMChain(chain); evm || chain == "eth" || chain == "ethereum" {
+		if _, err := ethereum.ParseAddress(recipientAddress); err != nil {
+			return fmt.Errorf("invalid Ethereum address: %w", err)
+		}
+	}
+
+	// The amount is fixed before asking to confirm, an amount in USD at the
+	// current price
+	value, err := parsePayAmount(ctx, client, amountStr, usdFlag, asset.priceID, asset.symbol, asset.decimals)
+	if err != nil {
+		return err
+	}
+
+	// Recipients on the blocklist or a sanctions list are shown before asking
+	// to confirm
+	if _, whitelistChain, err := parsePolicyChain(chain); err == nil {
+		if err := screenRecipient(ctx, manager, client, whitelistChain, recipientAddress); err != nil {
+			return err
+		}
+	}
+
+	// Re-running a payment after a timeout must not pay twice, the original
+	// is shown instead of asking to confirm
+	if !payForceFlag && !paySimulateOnlyFlag && !payDryRunFlag {
+		if err := checkDuplicateSend(ctx, manager, client, asset.chain, asset.recipient(recipientAddress), value); err != nil {
+			return err
+		}
+	}
+
+	// Get confirmation before proceeding with any transaction, a dry run sends
+	// nothing. --yes confirms up front for scripts.
+	if !paySimulateOnlyFlag && !payDryRunFlag {
+		if payYesFlag {
+			fmt.Fprintln(os.Stderr, "⚠️  Confirmed with --yes, sending without asking")
+		} else {
+			verifyPastedRecipient(chain, recipientAddress)
+			if !getTransactionConfirmation(manager) {
+				fmt.Println("❌ Transaction cancelled by user")
+				return errCancelled
+			}
+		}
+		if err := requireTwoFactor(manager); err != nil {
+			return err
+		}
+	}
+
+	switch asset.chain {
+	case "ethereum":
+		return sendEthereum(ctx, manager, client, value, recipientAddress)
+	case "bitcoin":
+		return sendBitcoin(ctx, manager, client, value, recipientAddress)
+	case "solana":
+		return sendSolana(ctx, manager, client, value, recipientAddress)
 	default:
-		return fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol", chain)
+		evmChain, _ := api.FindEVMChain(asset.chain)
+		return sendEVM(ctx, manager, client, evmChain, value, recipientAddress)
 	}
 }
 
-func sendEthereum(manager *wallet.Manager, client *api.Client, amountStr, recipientAddress string, usdFlag bool) error {
+func sendEthereum(ctx context.Context, manager *wallet.Manager, client *api.Client, value *big.Int, recipientAddress string) error {
 	fmt.Println("🔷 Sending Ethereum Transaction")
 	fmt.Println()
 
@@ -77,31 +240,12 @@ func sendEthereum(manager *wallet.Manager, client *api.Client, amountStr, recipi
 		return fmt.Errorf("failed to get sender address: %w", err)
 	}
 
-	// Parse amount
-	var amount float64
-	if usdFlag {
-		// Convert USD to ETH
-		price, err := client.GetPrice("ethereum")
-		if err != nil {
-			return fmt.Errorf("failed to get ETH price: %w", err)
-		}
-		usdAmount, err := parseFloat(amountStr)
-		if err != nil {
-			return fmt.Errorf("invalid amount: %w", err)
-		}
-		amount = usdAmount / price.USD.InexactFloat64()
-	} else {
-		amount, err = parseFloat(amountStr)
-		if err != nil {
-			return fmt.Errorf("invalid amount: %w", err)
-		}
+	if err := ethereum.ValidateAmount(value, nil); err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
 	}
 
-	// Convert to Wei
-	value := ethereum.EtherToWei(big.NewFloat(amount))
-
 	// Check balance
-	balance, err := client.GetEthereumBalance(senderAddress.Hex())
+	balance, err := client.GetEthereumBalance(ctx, senderAddress.Hex())
 	if err != nil {
 		return fmt.Errorf("failed to check balance: %w", err)
 	}
@@ -110,45 +254,18 @@ func sendEthereum(manager *wallet.Manager, client *api.Client, amountStr, recipi
 	if balance.Cmp(value) < 0 {
 		ethAmount := ethereum.WeiToEther(value)
 		currentBalance := ethereum.WeiToEther(balance)
-		return fmt.Errorf("insufficient funds in your Ethereum wallet. You're trying to send %.6f ETH but your balance is only %.6f ETH. Please deposit more ETH to your address (%s) before making this payment", ethAmount, currentBalance, senderAddress.Hex())
-	}
-
-	// Get nonce
-	nonce, err := client.GetEthereumNonce(senderAddress.Hex())
-	if err != nil {
-		return fmt.Errorf("failed to get nonce: %w", err)
+		return fmt.Errorf("%w in your Ethereum wallet. You're trying to send %s ETH but your balance is only %s ETH. Please deposit more ETH to your address (%s) before making this payment", api.ErrInsufficientFunds, ethAmount.StringFixed(6), currentBalance.StringFixed(6), senderAddress.Hex())
 	}
 
-	// Get gas price
-	gasPrice, err := client.GetEthereumGasPrice()
+	chain := ethereum.NewChain(manager, client)
+	transfer, tx, err := buildEthereumTransfer(ctx, manager, chain, recipient.Hex(), value)
 	if err != nil {
-		return fmt.Errorf("failed to get gas price: %w", err)
-	}
-
-	// Add 20% to gas price to ensure faster inclusion
-	gasPrice.Mul(gasPrice, big.NewInt(120))
-	gasPrice.Div(gasPrice, big.NewInt(100))
-
-	// Dynamically estimate gas limit based on the transaction
-	estimatedGas, err := client.GetEthereumGasEstimate(senderAddress.Hex(), recipient.Hex(), value, nil)
-	if err != nil {
-		// Fall back to the basic estimator
-		estimatedGas = ethereum.EstimateGasLimit(nil)
-	}
-
-	// Use estimated gas with a 20% buffer for safety
-	gasLimit := estimatedGas
-
-	// Create transaction
-	tx := ethereum.NewTransaction(nonce, recipient, value, gasLimit, gasPrice, nil)
-
-	// Validate transaction
-	if err := ethereum.ValidateTransaction(tx); err != nil {
-		return fmt.Errorf("invalid transaction: %w", err)
+		return err
 	}
+	gasPrice, gasLimit := tx.GasPrice, tx.GasLimit
 
 	// Calculate max transaction fee
-	maxFee := new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))
+	maxFee := transfer.Fee
 	totalCost := new(big.Int).Add(value, maxFee)
 
 	// Ensure user has enough for value + gas
@@ -158,8 +275,8 @@ func sendEthereum(manager *wallet.Manager, client *api.Client, amountStr, recipi
 		totalEth := ethereum.WeiToEther(totalCost)
 		currentBalance := ethereum.WeiToEther(balance)
 
-		return fmt.Errorf("insufficient funds for transaction with gas. You're trying to send %.6f ETH with approximately %.6f ETH in gas fees (total %.6f ETH) but your balance is only %.6f ETH",
-			ethAmount, gasEth, totalEth, currentBalance)
+		return fmt.Errorf("%w for transaction with gas. You're trying to send %s ETH with approximately %s ETH in gas fees (total %s ETH) but your balance is only %s ETH", api.ErrInsufficientFunds,
+			ethAmount.StringFixed(6), gasEth.StringFixed(6), totalEth.StringFixed(6), currentBalance.StringFixed(6))
 	}
 
 	// Display transaction details for confirmation
@@ -170,260 +287,459 @@ func sendEthereum(manager *wallet.Manager, client *api.Client, amountStr, recipi
 	ethAmount := ethereum.WeiToEther(value)
 	feeAmount := ethereum.WeiToEther(maxFee)
 
-	// Show USD values for mainnet
-	if !manager.IsTestnet() {
-		price, err := client.GetPrice("ethereum")
+	// Show USD values for mainnet, or as reference on testnet when enabled
+	if showFiatValues(manager) {
+		price, err := client.GetPrice(ctx, "ethereum")
 		if err != nil {
-			fmt.Printf("   Amount:  %.6f ETH\n", ethAmount)
-			fmt.Printf("   Max Fee: ~%.6f ETH\n", feeAmount)
+			fmt.Printf("   Amount:  %s ETH\n", ethAmount.StringFixed(6))
+			fmt.Printf("   Max Fee: ~%s ETH\n", feeAmount.StringFixed(6))
 		} else {
-			amountUSD := ethAmount * price.USD.InexactFloat64()
-			feeUSD := feeAmount * price.USD.InexactFloat64()
-			fmt.Printf("   Amount:  %.6f ETH (~$%.2f)\n", ethAmount, amountUSD)
-			fmt.Printf("   Max Fee: ~%.6f ETH (~$%.2f)\n", feeAmount, feeUSD)
+			amountUSD := ethAmount.Mul(price.USD)
+			feeUSD := feeAmount.Mul(price.USD)
+			fmt.Printf("   Amount:  %s ETH (~%s)\n", ethAmount.StringFixed(6), formatUSD(amountUSD))
+			fmt.Printf("   Max Fee: ~%s ETH (~%s)\n", feeAmount.StringFixed(6), formatUSD(feeUSD))
 		}
 	} else {
-		fmt.Printf("   Amount:  %.6f ETH\n", ethAmount)
-		fmt.Printf("   Max Fee: ~%.6f ETH\n", feeAmount)
+		fmt.Printf("   Amount:  %s ETH\n", ethAmount.StringFixed(6))
+		fmt.Printf("   Max Fee: ~%s ETH\n", feeAmount.StringFixed(6))
 	}
 
 	fmt.Printf("   Gas:     %d units\n", gasLimit)
-	fmt.Printf("   Gas Price: %.2f Gwei\n", float64(gasPrice.Uint64())/1e9)
+	fmt.Printf("   Gas Price: %s Gwei\n", decimal.NewFromBigInt(gasPrice, -9).StringFixed(2))
+	printGasWait(ctx, chain, gasPrice)
 	fmt.Printf("   Network: %s\n", manager.GetCurrentNetwork())
+	printReferencePriceNote(manager)
 	fmt.Println()
 
-	// Get private key
-	privateKey, err := manager.GetEthereumKey()
-	if err != nil {
-		return fmt.Errorf("failed to get private key: %w", err)
+	if maxFee.Cmp(value) > 0 {
+		fmt.Println("⚠️  The network fee is higher than the amount you are sending")
+		fmt.Println()
+	}
+
+	if payDryRunFlag {
+		return printEthereumDryRun(tx, senderAddress.Hex(), "ETH", false)
+	}
+
+	sim, simErr := client.SimulateEthereumTransaction(ctx, tx.ChainID, senderAddress.Hex(), recipient.Hex(), value, nil, gasLimit, gasPrice)
+	if done, err := reportSimulation(sim, simErr, simulationAssets{symbol: "ETH", decimals: 18, owner: senderAddress.Hex()}); done || err != nil {
+		return err
+	}
+
+	if err := checkBeforeSigning(ctx, manager, client, outgoingValue{
+		chain:     "ethereum",
+		recipient: recipient.Hex(),
+		amount:    ethAmount,
+		fee:       feeAmount,
+		screened:  true,
+	}); err != nil {
+		return err
 	}
 
 	// Sign transaction
-	signedTx, err := ethereum.SignTransaction(tx, privateKey)
-	if err != nil {
-		return fmt.Errorf("failed to sign transaction: %w", err)
+	if err := chain.Sign(ctx, transfer); err != nil {
+		return err
 	}
 
 	// Send transaction
-	txHash, err := client.SendEthereumTransaction(signedTx)
+	txHash, err := broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+		ID:          transfer.ID,
+		Chain:       "ethereum",
+		Network:     manager.GetCurrentNetwork(),
+		SignedTx:    transfer.Signed,
+		From:        senderAddress.Hex(),
+		To:          recipient.Hex(),
+		Amount:      ethAmount.StringFixed(6) + " ETH",
+		Value:       value.String(),
+		Nonce:       tx.Nonce,
+		SpendChain:  "ethereum",
+		SpendAmount: ethAmount,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to send transaction: %w", err)
 	}
 
 	fmt.Printf("✅ Transaction sent successfully!\n")
-	fmt.Printf("📝 Transaction Hash: %s\n", txHash)
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
 
-	// Use appropriate explorer URL based on network
-	if manager.IsTestnet() {
-		fmt.Printf("🔗 Explorer: https://sepolia.etherscan.io/tx/%s\n", txHash)
-	} else {
-		fmt.Printf("🔗 Explorer: https://etherscan.io/tx/%s\n", txHash)
-	}
+	printExplorerLink(explorerURL("ethereum", "tx", txHash))
 
 	return nil
 }
 
-func sendBitcoin(manager *wallet.Manager, client *api.Client, amountStr, recipientAddress string, usdFlag bool) error {
-	fmt.Println("🟠 Sending Bitcoin Transaction")
+// sendEVM sends the native asset of an EVM chain other than Ethereum, with the
+// Ethereum key
+func sendEVM(ctx context.Context, manager *wallet.Manager, client *api.Client, chain *api.EVMChain, value *big.Int, recipientAddress string) error {
+	testnet := manager.IsTestnet()
+	fmt.Printf("🔷 Sending %s Transaction\n", chain.Label(testnet))
 	fmt.Println()
 
-	// Parse recipient address
-	recipient, err := bitcoin.ParseAddress(recipientAddress)
+	recipient, err := ethereum.ParseAddress(recipientAddress)
 	if err != nil {
-		return fmt.Errorf("invalid Bitcoin address: %w", err)
+		return fmt.Errorf("invalid %s address: %w", chain.DisplayName, err)
 	}
 
-	// Get sender address
-	senderAddress, err := manager.GetBitcoinAddress()
+	senderAddress, err := manager.GetEthereumAddress()
 	if err != nil {
 		return fmt.Errorf("failed to get sender address: %w", err)
 	}
 
-	// Parse amount
-	var amount float64
-	if usdFlag {
-		// Convert USD to BTC
-		price, err := client.GetPrice("bitcoin")
-		if err != nil {
-			return fmt.Errorf("failed to get BTC price: %w", err)
-		}
-		usdAmount, err := parseFloat(amountStr)
-		if err != nil {
-			return fmt.Errorf("invalid amount: %w", err)
-		}
-		amount = usdAmount / price.USD.InexactFloat64()
-	} else {
-		amount, err = parseFloat(amountStr)
-		if err != nil {
-			return fmt.Errorf("invalid amount: %w", err)
-		}
+	// Prices come from the shared client, everything else from the chain's node
+	chainClient := client.ForEVMChain(chain)
+
+	if err := ethereum.ValidateAmount(value, nil); err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
 	}
 
-	// Convert to satoshis
-	value := bitcoin.BTCToSatoshis(amount)
+	balance, err := chainClient.GetEthereumBalance(ctx, senderAddress.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+	if balance.Cmp(value) < 0 {
+		return fmt.Errorf("%w on %s. You're trying to send %s %s but your balance is only %s %s", api.ErrInsufficientFunds,
+			chain.DisplayName, ethereum.WeiToEther(value).StringFixed(6), chain.Symbol, ethereum.WeiToEther(balance).StringFixed(6), chain.Symbol)
+	}
 
-	// Get UTXOs
-	apiUtxos, err := client.GetBitcoinUTXOs(senderAddress.String())
+	evmChain := ethereum.NewEVMChain(manager, client, chain)
+	transfer, tx, err := buildEthereumTransfer(ctx, manager, evmChain, recipient.Hex(), value)
 	if err != nil {
-		return fmt.Errorf("failed to get UTXOs: %w", err)
+		return err
 	}
+	gasPrice, gasLimit := tx.GasPrice, tx.GasLimit
 
-	if len(apiUtxos) == 0 {
-		return fmt.Errorf("your Bitcoin wallet has no funds. You need to receive Bitcoin to your address (%s) before you can send any payments. Use 'odyssey balance btc' to check your current balance", senderAddress.String())
+	if payDryRunFlag {
+		return printEthereumDryRun(tx, senderAddress.Hex(), chain.Symbol, chain.L1DataFee)
 	}
 
-	// Convert API UTXOs to bitcoin UTXOs
-	var utxos []*bitcoin.UTXO
-	totalInput := int64(0)
-	for _, apiUtxo := range apiUtxos {
-		utxoValue := bitcoin.BTCToSatoshis(apiUtxo.Value)
-		totalInput += utxoValue
+	// Signing first lets OP Stack chains price the exact bytes, the fee
+	// then includes the L1 data fee. Nothing is sent before the checks below.
+	if err := evmChain.Sign(ctx, transfer); err != nil {
+		return err
+	}
+	maxFee := transfer.Fee
+	l1Fee := new(big.Int).Sub(maxFee, ethereum.MaxFee(tx))
 
-		utxo := &bitcoin.UTXO{
-			TxID:   apiUtxo.TxID,
-			Vout:   apiUtxo.Vout,
-			Value:  utxoValue,
-			Script: []byte(apiUtxo.Script),
+	totalCost := new(big.Int).Add(value, maxFee)
+	if balance.Cmp(totalCost) < 0 {
+		return fmt.Errorf("%w for transaction with gas. You're trying to send %s %s with approximately %s %s in fees (total %s %s) but your balance is only %s %s", api.ErrInsufficientFunds,
+			ethereum.WeiToEther(value).StringFixed(6), chain.Symbol, ethereum.WeiToEther(maxFee).StringFixed(6), chain.Symbol,
+			ethereum.WeiToEther(totalCost).StringFixed(6), chain.Symbol, ethereum.WeiToEther(balance).StringFixed(6), chain.Symbol)
+	}
+
+	fmt.Printf("📊 Transaction Details:\n")
+	fmt.Printf("   Chain:   %s (chain ID %s)\n", chain.Label(testnet), tx.ChainID.String())
+	fmt.Printf("   From:    %s\n", senderAddress.Hex())
+	fmt.Printf("   To:      %s\n", recipient.Hex())
+
+	nativeAmount := ethereum.WeiToEther(value)
+	feeAmount := ethereum.WeiToEther(maxFee)
+	if showFiatValues(manager) {
+		if price, err := client.GetPrice(ctx, chain.PriceID); err == nil {
+			fmt.Printf("   Amount:  %s %s (~%s)\n", nativeAmount.StringFixed(6), chain.Symbol, formatUSD(nativeAmount.Mul(price.USD)))
+			fmt.Printf("   Max Fee: ~%s %s (~%s)\n", feeAmount.StringFixed(6), chain.Symbol, formatUSD(feeAmount.Mul(price.USD)))
+		} else {
+			fmt.Printf("   Amount:  %s %s\n", nativeAmount.StringFixed(6), chain.Symbol)
+			fmt.Printf("   Max Fee: ~%s %s\n", feeAmount.StringFixed(6), chain.Symbol)
 		}
-		utxos = append(utxos, utxo)
+	} else {
+		fmt.Printf("   Amount:  %s %s\n", nativeAmount.StringFixed(6), chain.Symbol)
+		fmt.Printf("   Max Fee: ~%s %s\n", feeAmount.StringFixed(6), chain.Symbol)
 	}
+	fmt.Printf("   Gas:     %d units\n", gasLimit)
+	fmt.Printf("   Gas Price: %s Gwei\n", decimal.NewFromBigInt(gasPrice, -9).StringFixed(4))
+	printGasWait(ctx, evmChain, gasPrice)
+	if l1Fee.Sign() > 0 {
+		fmt.Printf("   L1 Fee:  %s %s (data posted to Ethereum)\n", ethereum.WeiToEther(l1Fee).StringFixed(8), chain.Symbol)
+	}
+	printReferencePriceNote(manager)
+	fmt.Println()
 
-	// Get dynamic fee rate
-	feeRate, err := client.GetBitcoinFeeEstimate()
+	if maxFee.Cmp(value) > 0 {
+		fmt.Println("⚠️  The network fee is higher than the amount you are sending")
+		fmt.Println()
+	}
+
+	sim, simErr := chainClient.SimulateEthereumTransaction(ctx, tx.ChainID, senderAddress.Hex(), recipient.Hex(), value, nil, gasLimit, gasPrice)
+	if done, err := reportSimulation(sim, simErr, simulationAssets{symbol: chain.Symbol, decimals: 18, owner: senderAddress.Hex()}); done || err != nil {
+		return err
+	}
+	if err := checkBeforeSigning(ctx, manager, client, outgoingValue{
+		chain:     chain.Name,
+		recipient: recipient.Hex(),
+		amount:    nativeAmount,
+		fee:       feeAmount,
+		screened:  true,
+	}); err != nil {
+		return err
+	}
+
+	txHash, err := broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+		ID:          transfer.ID,
+		Chain:       chain.Name,
+		Network:     manager.GetCurrentNetwork(),
+		SignedTx:    transfer.Signed,
+		From:        senderAddress.Hex(),
+		To:          recipient.Hex(),
+		Amount:      nativeAmount.StringFixed(6) + " " + chain.Symbol,
+		Value:       value.String(),
+		Nonce:       tx.Nonce,
+		SpendChain:  chain.Name,
+		SpendAmount: nativeAmount,
+	})
 	if err != nil {
-		// Default to 10 sat/byte if estimation fails
-		feeRate = 10
+		return fmt.Errorf("failed to send transaction: %w", err)
 	}
 
-	// Create transaction
-	tx := bitcoin.NewTransaction()
+	fmt.Printf("✅ Transaction sent successfully!\n")
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL(chain.Name, "tx", txHash))
 
-	// Add inputs
-	for _, utxo := range utxos {
-		err := tx.AddInput(utxo, nil, senderAddress)
+	return nil
+}
+
+// chooseSolanaRemainder offers to change a Solana payment that would leave
+// the account with less than the rent-exempt minimum: keep the minimum, or
+// send everything but the fee, which closes the account. Scripts get the
+// validation error instead.
+func chooseSolanaRemainder(validationErr error, balance, fee, rentExempt uint64) (uint64, error) {
+	if payYesFlag || !term.IsTerminal(int(syscall.Stdin)) {
+		return 0, validationErr
+	}
+
+	var amounts []uint64
+	var options []string
+	if balance > fee+rentExempt {
+		keep := balance - fee - rentExempt
+		amounts = append(amounts, keep)
+		options = append(options, fmt.Sprintf("Send %s and keep %s so the account stays open", solana.FormatBalance(keep), solana.FormatBalance(rentExempt)))
+	}
+	all := balance - fee
+	amounts = append(amounts, all)
+	options = append(options, fmt.Sprintf("Send %s, the whole balance minus the fee, and close the account", solana.FormatBalance(all)))
+
+	fmt.Printf("⚠️  This payment would leave less than the rent-exempt minimum of %s in your account, and the network would reject it\n", solana.FormatBalance(rentExempt))
+	choice, err := chooseOption("Send a different amount instead? (Enter to cancel)", options)
+	if errors.Is(err, errMenuBack) {
+		fmt.Println("❌ Transaction cancelled by user")
+		return 0, errCancelled
+	}
+	if err != nil {
+		return 0, err
+	}
+	fmt.Println()
+	return amounts[choice], nil
+}
+
+// buildEthereumTransfer prepares a transfer on Ethereum or another EVM chain
+// with the --gas-tier, --nonce, --gas-price, --max-fee and --gas-limit flags
+// applied
+func buildEthereumTransfer(ctx context.Context, manager *wallet.Manager, chain *ethereum.Chain, to string, value *big.Int) (*chains.Transfer, *ethereum.Transaction, error) {
+	chain.GasTier = payGasTierFlag
+	transfer, err := chain.BuildTransfer(ctx, to, value)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx := transfer.Tx.(*ethereum.Transaction)
+
+	if ethNonceFlag >= 0 {
+		tx.Nonce, err = nextEthereumNonce(ctx, manager, chain.Client(), chain.Name(), transfer.From)
 		if err != nil {
-			return fmt.Errorf("failed to add input: %w", err)
+			return nil, nil, err
 		}
 	}
 
-	// Add output
-	err = tx.AddOutput(value, recipient)
+	tx.GasPrice, tx.GasLimit, err = applyGasOverrides(tx.GasPrice, tx.GasLimit, chain.MinGasPrice())
 	if err != nil {
-		return fmt.Errorf("failed to add output: %w", err)
+		return nil, nil, err
 	}
+	if err := ethereum.ValidateTransaction(tx); err != nil {
+		return nil, nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	transfer.Fee = ethereum.MaxFee(tx)
+	return transfer, tx, nil
+}
 
-	// Estimate transaction size (simplified)
-	// P2WPKH: ~110 bytes per input + ~34 bytes per output + ~10 bytes overhead
-	txSize := 10 + (len(utxos) * 110) + (1 * 34) // 1 output initially
+// bitcoinInputAddresses returns the addresses other than sender that inputs
+// spend from, in order of first use
+func bitcoinInputAddresses(utxos []*bitcoin.UTXO, sender btcutil.Address) []string {
+	var others []string
+	seen := map[string]bool{sender.EncodeAddress(): true}
+	for _, utxo := range utxos {
+		address := utxo.Address.EncodeAddress()
+		if !seen[address] {
+			seen[address] = true
+			others = append(others, address)
+		}
+	}
+	return others
+}
 
-	// Calculate fee based on estimated size and fee rate
-	estimatedFee := int64(txSize) * feeRate
+func sendBitcoin(ctx context.Context, manager *wallet.Manager, client *api.Client, amount *big.Int, recipientAddress string) error {
+	fmt.Println("🟠 Sending Bitcoin Transaction")
+	fmt.Println()
 
-	// Calculate change
-	change := totalInput - value - estimatedFee
+	// Parse recipient address
+	recipient, err := bitcoin.ParseAddress(recipientAddress)
+	if err != nil {
+		return fmt.Errorf("invalid Bitcoin address: %w", err)
+	}
 
-	// If change is very small (dust), add it to the fee instead
-	dustThreshold := int64(546) // Standard dust threshold in satoshis
-	if change > 0 && change < dustThreshold {
-		estimatedFee += change
-		change = 0
+	// Get sender address
+	senderAddress, err := manager.GetBitcoinAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get sender address: %w", err)
 	}
 
-	// If we have change to return, add a change output
-	if change > 0 {
-		err = tx.AddOutput(change, senderAddress)
-		if err != nil {
-			return fmt.Errorf("failed to add change output: %w", err)
-		}
-		// Adjust size calculation for the additional output
-		txSize += 34
-		// Recalculate fee with the new size
-		newFee := int64(txSize) * feeRate
-		// If fee increased significantly, adjust change
-		if newFee > estimatedFee {
-			feeIncrease := newFee - estimatedFee
-			if change > feeIncrease {
-				change -= feeIncrease
-				// Update output with new change amount
-				tx.UpdateChangeOutput(change)
-			}
-		}
+	if !amount.IsInt64() {
+		return fmt.Errorf("invalid amount: more BTC than can exist")
+	}
+	value := amount.Int64()
+	if err := bitcoin.ValidateAmount(value, recipient); err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+
+	// UTXOs of every address type can be spent, funds on older addresses too
+	chain := bitcoin.NewChain(manager, client)
+	chain.CoinSelection = payCoinSelectionFlag
+	chain.Memo = payMemoFlag
+	transfer, err := chain.BuildTransfer(ctx, recipient.String(), big.NewInt(value))
+	if errors.Is(err, bitcoin.ErrNoUTXOs) {
+		return fmt.Errorf("your Bitcoin wallet has no funds. You need to receive Bitcoin to your address (%s) before you can send any payments. Use 'odyssey balance btc' to check your current balance", senderAddress.String())
+	}
+	if err != nil {
+		return err
+	}
+	payment := transfer.Tx.(*bitcoin.Payment)
+	tx, utxos, totalInput := payment.Tx, payment.UTXOs, payment.TotalInput
+	vsize, change := payment.VSize, payment.Change
+	estimatedFee := transfer.Fee.Int64()
+
+	changeAccount := payment.ChangeAccount
+	changeAddress := senderAddress
+	if changeAccount != nil {
+		changeAddress = changeAccount.Address
 	}
 
 	// Check if we have enough funds
 	if totalInput < value+estimatedFee {
-		btcAmount := float64(value) / 100000000.0
-		feeAmount := float64(estimatedFee) / 100000000.0
-		totalAmount := float64(value+estimatedFee) / 100000000.0
-		availableAmount := float64(totalInput) / 100000000.0
-
-		return fmt.Errorf("insufficient funds for transaction with fees. You're trying to send %.8f BTC with approximately %.8f BTC in fees (total %.8f BTC) but your available balance is only %.8f BTC",
-			btcAmount, feeAmount, totalAmount, availableAmount)
+		return fmt.Errorf("%w for transaction with fees. You're trying to send %s with approximately %s in fees (total %s) but your available balance is only %s", api.ErrInsufficientFunds,
+			bitcoin.FormatBalance(value), bitcoin.FormatBalance(estimatedFee), bitcoin.FormatBalance(value+estimatedFee), bitcoin.FormatBalance(totalInput))
 	}
 
 	// Display transaction details
 	fmt.Printf("📊 Transaction Details:\n")
 	fmt.Printf("   From:    %s\n", senderAddress.String())
+	if others := bitcoinInputAddresses(utxos, senderAddress); len(others) > 0 {
+		fmt.Printf("           + %s\n", strings.Join(others, "\n           + "))
+	}
 	fmt.Printf("   To:      %s\n", recipient.String())
+	if payMemoFlag != "" {
+		fmt.Printf("   Memo:    %s (OP_RETURN)\n", payMemoFlag)
+	}
 
-	btcAmount := float64(value) / 100000000.0
-	feeAmount := float64(estimatedFee) / 100000000.0
+	btcAmount := bitcoin.SatoshisToBTC(value)
+	feeAmount := bitcoin.SatoshisToBTC(estimatedFee)
+	feeRate := bitcoin.EffectiveFeeRate(estimatedFee, vsize).StringFixed(1)
 
-	// Always show USD for Bitcoin (Bitcoin is mainnet only)
-	price, err := client.GetPrice("bitcoin")
+	// Show USD values for mainnet, or as reference on testnet when enabled
+	var price *api.PriceData
+	err = api.ErrNoFiat
+	if showFiatValues(manager) {
+		price, err = client.GetPrice(ctx, "bitcoin")
+	}
 	if err != nil {
-		fmt.Printf("   Amount:  %.8f BTC\n", btcAmount)
-		fmt.Printf("   Fee:     %.8f BTC (%.0f sat/byte)\n", feeAmount, float64(feeRate))
+		fmt.Printf("   Amount:  %s BTC\n", btcAmount.StringFixed(8))
+		fmt.Printf("   Fee:     %s BTC (%s sat/vB, %d vB)\n", feeAmount.StringFixed(8), feeRate, vsize)
 	} else {
-		amountUSD := btcAmount * price.USD.InexactFloat64()
-		feeUSD := feeAmount * price.USD.InexactFloat64()
-		fmt.Printf("   Amount:  %.8f BTC (~$%.2f)\n", btcAmount, amountUSD)
-		fmt.Printf("   Fee:     %.8f BTC (~$%.2f) (%.0f sat/byte)\n", feeAmount, feeUSD, float64(feeRate))
+		amountUSD := btcAmount.Mul(price.USD)
+		feeUSD := feeAmount.Mul(price.USD)
+		fmt.Printf("   Amount:  %s BTC (~%s)\n", btcAmount.StringFixed(8), formatUSD(amountUSD))
+		fmt.Printf("   Fee:     %s BTC (~%s) (%s sat/vB, %d vB)\n", feeAmount.StringFixed(8), formatUSD(feeUSD), feeRate, vsize)
 	}
 
 	if change > 0 {
-		changeAmount := float64(change) / 100000000.0
+		changeAmount := bitcoin.SatoshisToBTC(change)
 		if err == nil {
-			changeUSD := changeAmount * price.USD.InexactFloat64()
-			fmt.Printf("   Change:  %.8f BTC (~$%.2f)\n", changeAmount, changeUSD)
+			changeUSD := changeAmount.Mul(price.USD)
+			fmt.Printf("   Change:  %s BTC (~%s)\n", changeAmount.StringFixed(8), formatUSD(changeUSD))
 		} else {
-			fmt.Printf("   Change:  %.8f BTC\n", changeAmount)
+			fmt.Printf("   Change:  %s BTC\n", changeAmount.StringFixed(8))
 		}
+		fmt.Printf("            to %s (%s)\n", changeAddress.String(), changeAccount.Path)
 	}
+	printReferencePriceNote(manager)
 	fmt.Println()
 
-	// Get private key
-	privateKey, err := manager.GetBitcoinKey()
-	if err != nil {
-		return fmt.Errorf("failed to get private key: %w", err)
+	if estimatedFee > value {
+		fmt.Println("⚠️  The network fee is higher than the amount you are sending")
+		fmt.Println()
 	}
 
-	// Sign transaction
-	err = tx.SignTransaction(utxos, privateKey, senderAddress)
-	if err != nil {
-		return fmt.Errorf("failed to sign transaction: %w", err)
+	if payDryRunFlag {
+		fee := totalInput - value
+		if change > 0 {
+			fee -= change
+		}
+		return printBitcoinDryRun(tx, utxos, recipient, value, payMemoFlag, changeAddress, change, fee, vsize)
 	}
 
-	// Serialize transaction
-	signedTx, err := tx.Serialize()
-	if err != nil {
-		return fmt.Errorf("failed to serialize transaction: %w", err)
+	if !paySimulateOnlyFlag {
+		err := checkBeforeSigning(ctx, manager, client, outgoingValue{
+			chain:     "bitcoin",
+			recipient: recipient.String(),
+			amount:    btcAmount,
+			fee:       feeAmount,
+			screened:  true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Sign transaction, every input with the key of its address
+	if err := chain.Sign(ctx, transfer); err != nil {
+		return err
+	}
+	txID := transfer.ID
+
+	// mempool.space can't test a transaction without broadcasting it
+	if paySimulateOnlyFlag {
+		fmt.Println("🧪 Bitcoin transactions can't be simulated, the transaction was built and signed but not sent")
+		fmt.Printf("📝 Transaction ID: %s\n", txID)
+		return nil
+	}
+
+	if changeAccount != nil {
+		if err := manager.RecordBitcoinChangeAccount(changeAccount); err != nil {
+			return err
+		}
 	}
 
 	// Send transaction
-	txHash, err := client.SendBitcoinTransaction(signedTx)
+	txHash, err := broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+		ID:          txID,
+		Chain:       "bitcoin",
+		Network:     manager.GetCurrentNetwork(),
+		SignedTx:    transfer.Signed,
+		From:        senderAddress.String(),
+		To:          recipient.String(),
+		Amount:      bitcoin.FormatBalance(value),
+		Value:       amount.String(),
+		SpendChain:  "bitcoin",
+		SpendAmount: btcAmount,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to send transaction: %w", err)
 	}
 
 	fmt.Printf("✅ Transaction sent successfully!\n")
-	fmt.Printf("📝 Transaction Hash: %s\n", txHash)
-	fmt.Printf("🔗 Explorer: https://blockstream.info/tx/%s\n", txHash)
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL("bitcoin", "tx", txHash))
 
 	return nil
 }
 
-func sendSolana(manager *wallet.Manager, client *api.Client, amountStr, recipientAddress string, usdFlag bool) error {
+func sendSolana(ctx context.Context, manager *wallet.Manager, client *api.Client, amount *big.Int, recipientAddress string) error {
 	fmt.Println("🟣 Sending Solana Transaction")
 	fmt.Println()
 
@@ -433,28 +749,10 @@ func sendSolana(manager *wallet.Manager, client *api.Client, amountStr, recipien
 		return fmt.Errorf("invalid Solana address: %w", err)
 	}
 
-	// Parse amount
-	var amount float64
-	if usdFlag {
-		// Convert USD to SOL
-		price, err := client.GetPrice("solana")
-		if err != nil {
-			return fmt.Errorf("failed to get SOL price: %w", err)
-		}
-		usdAmount, err := parseFloat(amountStr)
-		if err != nil {
-			return fmt.Errorf("invalid amount: %w", err)
-		}
-		amount = usdAmount / price.USD.InexactFloat64()
-	} else {
-		amount, err = parseFloat(amountStr)
-		if err != nil {
-			return fmt.Errorf("invalid amount: %w", err)
-		}
+	if !amount.IsUint64() {
+		return fmt.Errorf("invalid amount: more SOL than can exist")
 	}
-
-	// Convert to lamports
-	value := solana.SOLToLamports(amount)
+	value := amount.Uint64()
 
 	// Check balance
 	senderAddress, err := manager.GetSolanaAddress()
@@ -462,26 +760,42 @@ func sendSolana(manager *wallet.Manager, client *api.Client, amountStr, recipien
 		return fmt.Errorf("failed to get sender address: %w", err)
 	}
 
-	balance, err := client.GetSolanaBalance(senderAddress.String())
+	balance, err := client.GetSolanaBalance(ctx, senderAddress.String())
 	if err != nil {
 		return fmt.Errorf("failed to check balance: %w", err)
 	}
 
 	// Solana transaction fees are currently fixed at 5000 lamports (0.000005 SOL)
-	const solanaFee = uint64(5000)
+	solanaFee := solana.TransferFee
 
 	// Add some extra lamports for transaction fee
 	requiredBalance := value + solanaFee
 
 	// Check if balance is sufficient
 	if balance < requiredBalance {
-		solAmount := float64(value) / 1000000000.0
-		feeAmount := float64(solanaFee) / 1000000000.0
-		totalAmount := float64(requiredBalance) / 1000000000.0
-		currentBalance := float64(balance) / 1000000000.0
+		return fmt.Errorf("%w in your Solana wallet. You're trying to send %s plus %s in fees (total %s) but your balance is only %s. Please deposit more SOL to your address (%s) before making this payment", api.ErrInsufficientFunds,
+			solana.FormatBalance(value), solana.FormatBalance(solanaFee), solana.FormatBalance(requiredBalance), solana.FormatBalance(balance), senderAddress.String())
+	}
 
-		return fmt.Errorf("insufficient funds in your Solana wallet. You're trying to send %.9f SOL plus %.9f SOL in fees (total %.9f SOL) but your balance is only %.9f SOL. Please deposit more SOL to your address (%s) before making this payment",
-			solAmount, feeAmount, totalAmount, currentBalance, senderAddress.String())
+	// New accounts and leftover balances must be rent exempt
+	chain := solana.NewChain(manager, client)
+	rentExempt := chain.RentExemptMinimum(ctx)
+	recipientBalance, err := client.GetSolanaBalance(ctx, recipient.String())
+	if err != nil {
+		return fmt.Errorf("failed to check recipient balance: %w", err)
+	}
+	err = solana.ValidateAmount(value, recipientBalance, balance-requiredBalance, rentExempt)
+	if errors.Is(err, solana.ErrRentExemptRemainder) {
+		if value, err = chooseSolanaRemainder(err, balance, solanaFee, rentExempt); err == nil {
+			requiredBalance = value + solanaFee
+			err = solana.ValidateAmount(value, recipientBalance, balance-requiredBalance, rentExempt)
+		}
+	}
+	if errors.Is(err, errCancelled) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
 	}
 
 	// Display transaction details
@@ -489,64 +803,126 @@ func sendSolana(manager *wallet.Manager, client *api.Client, amountStr, recipien
 	fmt.Printf("   From:    %s\n", senderAddress.String())
 	fmt.Printf("   To:      %s\n", recipient.String())
 
-	solAmount := float64(value) / 1000000000.0
-	feeAmount := float64(solanaFee) / 1000000000.0
+	solAmount := solana.LamportsToSOL(value)
+	feeAmount := solana.LamportsToSOL(solanaFee)
 
-	// Show USD values for mainnet
-	if !manager.IsTestnet() {
-		price, err := client.GetPrice("solana")
+	// Show USD values for mainnet, or as reference on testnet when enabled
+	if showFiatValues(manager) {
+		price, err := client.GetPrice(ctx, "solana")
 		if err != nil {
-			fmt.Printf("   Amount:  %.9f SOL\n", solAmount)
-			fmt.Printf("   Fee:     %.9f SOL\n", feeAmount)
+			fmt.Printf("   Amount:  %s\n", solana.FormatBalance(value))
+			fmt.Printf("   Fee:     %s\n", solana.FormatBalance(solanaFee))
 		} else {
-			amountUSD := solAmount * price.USD.InexactFloat64()
-			feeUSD := feeAmount * price.USD.InexactFloat64()
-			fmt.Printf("   Amount:  %.9f SOL (~$%.2f)\n", solAmount, amountUSD)
-			fmt.Printf("   Fee:     %.9f SOL (~$%.2f)\n", feeAmount, feeUSD)
+			amountUSD := solAmount.Mul(price.USD)
+			feeUSD := feeAmount.Mul(price.USD)
+			fmt.Printf("   Amount:  %s (~%s)\n", solana.FormatBalance(value), formatUSD(amountUSD))
+			fmt.Printf("   Fee:     %s (~%s)\n", solana.FormatBalance(solanaFee), formatUSD(feeUSD))
 		}
 	} else {
-		fmt.Printf("   Amount:  %.9f SOL\n", solAmount)
-		fmt.Printf("   Fee:     %.9f SOL\n", feeAmount)
+		fmt.Printf("   Amount:  %s\n", solana.FormatBalance(value))
+		fmt.Printf("   Fee:     %s\n", solana.FormatBalance(solanaFee))
 	}
 
+	if paySolanaMemo != "" {
+		fmt.Printf("   Memo:    %s\n", paySolanaMemo)
+	}
 	fmt.Printf("   Network: %s\n", manager.GetCurrentNetwork())
+	printReferencePriceNote(manager)
 	fmt.Println()
 
-	// Get private key
-	privateKey, err := manager.GetSolanaKey()
-	if err != nil {
-		return fmt.Errorf("failed to get private key: %w", err)
+	if payDryRunFlag {
+		return printSolanaDryRun(senderAddress.String(), recipient.String(), value, solanaFee)
 	}
 
-	// Create transaction structure first (without blockhash)
+	if paySkipPreflightFlag {
+		ctx = api.WithSkipPreflight(ctx)
+	}
+
+	if !paySimulateOnlyFlag {
+		err := checkBeforeSigning(ctx, manager, client, outgoingValue{
+			chain:     "solana",
+			recipient: recipient.String(),
+			amount:    solAmount,
+			fee:       feeAmount,
+			screened:  true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// A Solana Pay memo goes right before the transfer, its references into it
 	fmt.Println("⏳ Preparing transaction...")
-	tx, err := solana.CreateTransferTransaction(privateKey, recipient, value, "")
+	chain.Memo = paySolanaMemo
+	chain.References = paySolanaReferences
+	chain.DurableNonce = payDurableNonceFlag
+	transfer, err := chain.BuildTransfer(ctx, recipient.String(), new(big.Int).SetUint64(value))
 	if err != nil {
-		return fmt.Errorf("failed to create transaction: %w", err)
+		return err
 	}
 
-	// Get blockhash IMMEDIATELY before sending
-	fmt.Println("⏳ Getting fresh blockhash and sending immediately...")
-	recentBlockhash, err := client.GetSolanaRecentBlockhash()
-	if err != nil {
-		return fmt.Errorf("failed to get blockhash: %w", err)
+	// The blockhash is fetched IMMEDIATELY before signing and sending, a
+	// durable nonce doesn't expire
+	if chain.DurableNonce {
+		fmt.Println("⏳ Signing with the durable nonce...")
+	} else {
+		fmt.Println("⏳ Getting fresh blockhash and sending immediately...")
+	}
+	if err := chain.Sign(ctx, transfer); err != nil {
+		return err
+	}
+	signedTx := transfer.Signed
+	recentBlockhash := transfer.Tx.(*solana.Transaction).RecentBlockhash
+	lastValidBlockHeight := transfer.Tx.(*solana.Transaction).LastValidBlockHeight
+
+	var nonceAccount string
+	if chain.DurableNonce {
+		if nonceAccount, err = chain.NonceAccount(); err != nil {
+			return err
+		}
+	}
+	if paySignOnlyFlag {
+		fmt.Println("✅ Transaction signed, it stays valid until the nonce account is advanced")
+		printResult(signedTx, "📝 Signed transaction:\n%s\n\n💡 Send it with: odyssey sol broadcast <signed-transaction>\n", signedTx)
+		return nil
 	}
 
-	// Set the fresh blockhash and sign immediately
-	tx.SetRecentBlockhash(recentBlockhash)
-	signedTx, err := tx.BuildAndSign()
-	if err != nil {
-		return fmt.Errorf("failed to sign transaction: %w", err)
+	sim, simErr := client.SimulateSolanaTransaction(ctx, signedTx, []string{senderAddress.String(), recipient.String()})
+	if done, err := reportSimulation(sim, simErr, simulationAssets{symbol: "SOL", decimals: 9, owner: senderAddress.String()}); done || err != nil {
+		return err
 	}
 
-	// Send immediately - no delay between blockhash fetch and send
-	txHash, err := client.SendSolanaTransaction(signedTx)
+	// Send immediately - no delay between blockhash fetch and send. The same
+	// transaction is resent until it is confirmed or its blockhash expires.
+	pending := wallet.PendingBroadcast{
+		ID:           transfer.ID,
+		Chain:        "solana",
+		Network:      manager.GetCurrentNetwork(),
+		SignedTx:     signedTx,
+		From:         senderAddress.String(),
+		To:           recipient.String(),
+		Amount:       solana.FormatBalance(value),
+		Value:        amount.String(),
+		Blockhash:    recentBlockhash,
+		NonceAccount: nonceAccount,
+		SpendChain:   "solana",
+		SpendAmount:  solAmount,
+	}
+	txHash, err := broadcastWithRetry(ctx, manager, client, pending)
+	if err == nil {
+		err = waitForSolanaConfirmation(ctx, manager, client, &pending, lastValidBlockHeight)
+	}
 	if err != nil {
-		// Check for common error patterns and provide user-friendly messages
-		if strings.Contains(err.Error(), "insufficient funds") || strings.Contains(err.Error(), "0x1") {
-			return fmt.Errorf("transaction failed: insufficient funds. Ensure your account has enough SOL for the payment plus network fees")
+		// Preflight and on-chain failures are decoded from the node's error
+		var txErr *api.SolanaTransactionError
+		if errors.As(err, &txErr) {
+			printProgramLogs(txErr.Logs)
+			if txErr.Name == "InsufficientFundsForFee" || strings.Contains(txErr.Message, "insufficient funds") {
+				return fmt.Errorf("transaction failed: %w: %v. Ensure your account has enough SOL for the payment plus network fees", api.ErrInsufficientFunds, txErr)
+			}
+			return fmt.Errorf("transaction failed: %w", err)
 		}
-		if strings.Contains(err.Error(), "blockhash expired") || strings.Contains(err.Error(), "0x1b") || strings.Contains(err.Error(), "BlockhashNotFound") {
+		if errors.Is(err, errPayloadExpired) {
 			return fmt.Errorf("transaction failed: blockhash expired. The network is busy, please try again")
 		}
 		if strings.Contains(err.Error(), "invalid base58") {
@@ -556,27 +932,44 @@ func sendSolana(manager *wallet.Manager, client *api.Client, amountStr, recipien
 	}
 
 	fmt.Printf("✅ Transaction sent successfully!\n")
-	fmt.Printf("📝 Transaction Hash: %s\n", txHash)
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
 
-	// Use appropriate explorer URL based on network
-	if manager.IsTestnet() {
-		fmt.Printf("🔗 Explorer: https://solscan.io/tx/%s?cluster=devnet\n", txHash)
-	} else {
-		fmt.Printf("🔗 Explorer: https://solscan.io/tx/%s\n", txHash)
-	}
+	printExplorerLink(explorerURL("solana", "tx", txHash))
 
 	return nil
 }
 
+// showFiatValues returns true if USD values should be shown in transaction
+// details. Testnet coins have no value, so they are only shown there when
+// display.testnet_prices is enabled, and never on networks without prices.
+func showFiatValues(manager *wallet.Manager) bool {
+	if config.ActiveNetwork().NoFiat {
+		return false
+	}
+	if !manager.IsTestnet() {
+		return true
+	}
+	cfg, err := config.Load()
+	return err == nil && cfg.TestnetPrices()
+}
+
+// printReferencePriceNote marks USD values shown on testnet as reference only
+func printReferencePriceNote(manager *wallet.Manager) {
+	if manager.IsTestnet() && showFiatValues(manager) {
+		fmt.Println("   💡 USD values use mainnet prices and are for reference only")
+	}
+}
+
 func getTransactionConfirmation(manager *wallet.Manager) bool {
-	fmt.Println()
+	out := promptOut()
+	fmt.Fprintln(out)
 	if manager.IsTestnet() {
-		fmt.Printf("⚠️ You are on testnet (Ethereum Sepolia Testnet / Solana Devnet). By confirming this transaction no real funds will be sent to this address.\n")
+		fmt.Fprintf(out, "⚠️ You are on testnet (%s / %s). By confirming this transaction no real funds will be sent to this address.\n", ethereumLabel(), solanaLabel())
 	} else {
-		fmt.Printf("🚨 You are on main network. By confirming this transaction real funds will be sent to this address.\n")
+		fmt.Fprintf(out, "🚨 You are on main network. By confirming this transaction real funds will be sent to this address.\n")
 	}
 
-	fmt.Printf("Press y to confirm or n to stop (y/n): ")
+	fmt.Fprintf(out, "Press y to confirm or n to stop (y/n): ")
 
 	var response string
 	fmt.Scanln(&response)
@@ -585,13 +978,100 @@ func getTransactionConfirmation(manager *wallet.Manager) bool {
 	return response == "y" || response == "yes"
 }
 
-func parseFloat(s string) (float64, error) {
-	// Simple float parsing - in production you'd want more robust parsing
-	var result float64
-	_, err := fmt.Sscanf(s, "%f", &result)
-	return result, err
+// parsePayAmount parses the amount of a payment into the smallest unit of a
+// native asset. With --usd the amount is in dollars and converted at the
+// current price, to the nearest smallest unit.
+func parsePayAmount(ctx context.Context, client *api.Client, amountStr string, usdFlag bool, priceID, symbol string, decimals int32) (*big.Int, error) {
+	if !usdFlag {
+		value, err := chains.ParseAmount(amountStr, decimals)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount: %w", err)
+		}
+		return value, nil
+	}
+
+	cents, err := chains.ParseAmount(amountStr, 2)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	price, err := client.GetPrice(ctx, priceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s price: %w", symbol, err)
+	}
+	if !price.USD.IsPositive() {
+		return nil, fmt.Errorf("failed to get %s price: no USD price", symbol)
+	}
+
+	usd := chains.FromSmallestUnit(cents, 2)
+	return chains.ToSmallestUnit(usd.DivRound(price.USD, decimals), decimals), nil
+}
+
+// payAsset is the native asset 'odyssey pay' sends on a chain
+type payAsset struct {
+	chain    string // ethereum, bitcoin, solana or the name of an EVM chain
+	priceID  string
+	symbol   string
+	decimals int32
+}
+
+// findPayAsset returns the native asset of chain as given to 'odyssey pay'
+func findPayAsset(chain string) (payAsset, error) {
+	switch chain {
+	case "eth", "ethereum":
+		return payAsset{chain: "ethereum", priceID: "ethereum", symbol: "ETH", decimals: ethereum.Decimals}, nil
+	case "btc", "bitcoin":
+		return payAsset{chain: "bitcoin", priceID: "bitcoin", symbol: "BTC", decimals: bitcoin.Decimals}, nil
+	case "sol", "solana":
+		return payAsset{chain: "solana", priceID: "solana", symbol: "SOL", decimals: solana.Decimals}, nil
+	}
+	if evmChain, ok := api.FindEVMChain(chain); ok {
+		return payAsset{chain: evmChain.Name, priceID: evmChain.PriceID, symbol: evmChain.Symbol, decimals: ethereum.Decimals}, nil
+	}
+	return payAsset{}, fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol, %s", chain, strings.Join(api.EVMChainNames(), ", "))
+}
+
+// recipient returns address the way payments to it are recorded, checksummed
+// on EVM chains. An invalid address is returned as is.
+func (a payAsset) recipient(address string) string {
+	switch a.chain {
+	case "bitcoin":
+		if parsed, err := bitcoin.ParseAddress(address); err == nil {
+			return parsed.String()
+		}
+	case "solana":
+		if parsed, err := solana.ParseAddress(address); err == nil {
+			return parsed.String()
+		}
+	default:
+		if parsed, err := ethereum.ParseAddress(address); err == nil {
+			return parsed.Hex()
+		}
+	}
+	return address
 }
 
 func init() {
 	payCmd.Flags().Bool("usd", false, "Specify amount in USD")
+	addPaySendFlags(payCmd)
+	addPaySendFlags(payURICmd)
+	payCmd.AddCommand(payURICmd)
+}
+
+// addPaySendFlags adds the flags that control how a payment is sent
+func addPaySendFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&paySimulateOnlyFlag, "simulate-only", false, "Simulate the payment and show the outcome without sending it")
+	cmd.Flags().Uint64Var(&payGasLimitFlag, "gas-limit", 0, "Gas limit on EVM chains instead of the estimate")
+	cmd.Flags().StringVar(&payGasPriceFlag, "gas-price", "", "Gas price in gwei on EVM chains instead of the network price")
+	cmd.Flags().StringVar(&payGasTierFlag, "gas-tier", "", "Gas price tier on EVM chains: slow, normal or fast (default from config)")
+	cmd.Flags().StringVar(&payMaxFeeFlag, "max-fee", "", "Highest gas price in gwei to pay on EVM chains, the network price is capped at it")
+	cmd.Flags().Int64Var(&ethNonceFlag, "nonce", -1, "Nonce to use on EVM chains, reuse a pending nonce to replace that transaction")
+	cmd.Flags().BoolVar(&payDryRunFlag, "dry-run", false, "Build the payment and print the transaction without signing or sending it")
+	cmd.Flags().BoolVarP(&payYesFlag, "yes", "y", false, "Send without asking for confirmation, for scripts")
+	cmd.Flags().BoolVar(&payForceFlag, "force", false, "Send even if the same amount was sent to the same address within safety.duplicate_window")
+	cmd.Flags().BoolVar(&payDurableNonceFlag, "durable-nonce", false, "Sign Solana payments with the wallet's durable nonce so they don't expire")
+	cmd.Flags().BoolVar(&paySignOnlyFlag, "sign-only", false, "Print the signed Solana payment instead of sending it, needs --durable-nonce")
+	cmd.Flags().BoolVar(&paySkipPreflightFlag, "skip-preflight", false, "Send Solana payments without the node's preflight check, for congested nodes")
+	cmd.Flags().StringVar(&payCoinSelectionFlag, "coin-selection", "", "UTXOs Bitcoin payments spend: fee, inputs, privacy or all (default from config)")
+	cmd.Flags().StringVar(&payMemoFlag, "memo", "", "Text of up to 80 bytes recorded in an OP_RETURN output of Bitcoin payments")
+	addPreSignFlags(cmd)
 }
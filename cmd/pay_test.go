@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/chinmay1088/odyssey/wallet"
+)
+
+func TestPayRefusesDuplicateBeforeConfirming(t *testing.T) {
+	testWallet(t)
+	refuseDials(t)
+
+	manager := wallet.NewManager()
+	err := manager.RecordRecentSend(wallet.RecentSend{
+		ID:      "0x01",
+		Chain:   "ethereum",
+		Network: manager.GetCurrentNetwork(),
+		To:      testEthereumAddress,
+		Amount:  "0.001000 ETH",
+		Value:   "1000000000000000",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The same amount written differently, to the lowercase address, is
+	// refused without asking to confirm
+	withStdin(t, "")
+	output, err := runCommand(t, "pay", "eth", "0.0010", strings.ToLower(testEthereumAddress))
+	if !errors.Is(err, errDuplicateSend) {
+		t.Fatalf("paying the same amount again returned %v, want a duplicate:\n%s", err, output)
+	}
+
+	// One wei more is another payment, it is only cancelled at the prompt
+	withStdin(t, "n\n")
+	output, err = runCommand(t, "pay", "eth", "0.001000000000000001", testEthereumAddress)
+	if !errors.Is(err, errCancelled) {
+		t.Fatalf("paying another amount returned %v, want it cancelled:\n%s", err, output)
+	}
+}
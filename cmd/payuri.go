@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// A Solana Pay request's memo and references go into the transfer, so the
+// recipient can match the payment to the request
+var (
+	paySolanaMemo       string
+	paySolanaReferences []string
+)
+
+var payURICmd = &cobra.Command{
+	Use:   "uri [payment-uri] [amount]",
+	Short: "Pay a payment request URI",
+	Long: `Pay a payment request pasted from a merchant or scanned from a QR code.
+The chain, recipient, amount and description are read from the URI.
+
+Supported formats:
+  bitcoin:<address>?amount=<BTC>&label=...&message=...        (BIP-21)
+  ethereum:<address>[@<chain id>]?value=<wei>                  (EIP-681)
+  solana:<recipient>?amount=<SOL>&reference=...&memo=...       (Solana Pay)
+
+EIP-681 chain IDs select Ethereum or another EVM chain, they must belong to
+the current network. Token transfers and Solana Pay transaction requests are
+not supported. A Solana Pay memo and references are included in the
+transaction so the merchant can find the payment.
+
+When the URI has no amount, pass it as the second argument. The payment is
+confirmed like any other, see 'odyssey pay --help'.
+
+Examples:
+  odyssey pay uri "bitcoin:bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh?amount=0.01&label=Shop"
+  odyssey pay uri "ethereum:0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6@8453?value=1e16"
+  odyssey pay uri "solana:7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU?amount=1.5&memo=Order%2042"
+  odyssey pay uri "solana:7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU" 0.25`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runPayURI,
+}
+
+// paymentRequest is what a payment request URI asks for
+type paymentRequest struct {
+	chain      string // as accepted by pay, e.g. btc or base
+	label      string // display name of the chain
+	recipient  string
+	amount     string // in whole coins, empty if the URI has none
+	symbol     string
+	name       string // BIP-21 and Solana Pay label, who is paid
+	message    string // shown to the payer only
+	memo       string // recorded on-chain, Solana Pay only
+	references []string
+}
+
+func runPayURI(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	request, err := parsePaymentURI(args[0], manager.IsTestnet())
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case request.amount == "" && len(args) < 2:
+		return fmt.Errorf("the payment request has no amount, pass it after the URI: odyssey pay uri <uri> <amount>")
+	case request.amount != "" && len(args) == 2:
+		return fmt.Errorf("the payment request already asks for %s %s, don't pass an amount", request.amount, request.symbol)
+	case request.amount == "":
+		request.amount = args[1]
+	}
+
+	fmt.Println("🧾 Payment Request")
+	fmt.Printf("   Chain:     %s\n", request.label)
+	fmt.Printf("   Recipient: %s\n", request.recipient)
+	fmt.Printf("   Amount:    %s %s\n", request.amount, request.symbol)
+	if request.name != "" {
+		fmt.Printf("   Label:     %s\n", request.name)
+	}
+	if request.message != "" {
+		fmt.Printf("   Message:   %s\n", request.message)
+	}
+	if len(request.references) > 0 {
+		fmt.Printf("   Reference: %s\n", strings.Join(request.references, ", "))
+	}
+	fmt.Println()
+
+	paySolanaMemo = request.memo
+	paySolanaReferences = request.references
+	return runPay(cmd, []string{request.chain, request.amount, request.recipient})
+}
+
+// parsePaymentURI parses a BIP-21, EIP-681 or Solana Pay URI
+func parsePaymentURI(raw string, testnet bool) (*paymentRequest, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment URI: %w", err)
+	}
+
+	// Some wallets write scheme://address
+	target := parsed.Opaque
+	if target == "" {
+		target = strings.Trim(parsed.Host+parsed.Path, "/")
+	}
+	if target == "" {
+		return nil, fmt.Errorf("payment URI has no recipient")
+	}
+	query, err := url.ParseQuery(parsed.RawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment URI parameters: %w", err)
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "bitcoin":
+		return parseBitcoinURI(target, query, testnet)
+	case "ethereum":
+		return parseEthereumURI(target, query, testnet)
+	case "solana":
+		return parseSolanaURI(target, query)
+	case "":
+		return nil, fmt.Errorf("not a payment URI, expected bitcoin:, ethereum: or solana:")
+	default:
+		return nil, fmt.Errorf("unsupported payment URI scheme %s:, supported are bitcoin:, ethereum: and solana:", parsed.Scheme)
+	}
+}
+
+// parseBitcoinURI parses a BIP-21 URI
+func parseBitcoinURI(target string, query url.Values, testnet bool) (*paymentRequest, error) {
+	if testnet && !config.ActiveNetwork().HasBitcoin() {
+		return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+
+	// Parameters starting with req- must be understood, none are
+	for name := range query {
+		if strings.HasPrefix(name, "req-") {
+			return nil, fmt.Errorf("payment request requires %s, which isn't supported", name)
+		}
+	}
+
+	// QR codes carry bech32 addresses in upper case
+	recipient := target
+	if strings.ToUpper(recipient) == recipient {
+		recipient = strings.ToLower(recipient)
+	}
+
+	amount, err := paymentURIAmount(query.Get("amount"))
+	if err != nil {
+		return nil, err
+	}
+	return &paymentRequest{
+		chain:     "btc",
+		label:     "Bitcoin",
+		recipient: recipient,
+		amount:    amount,
+		symbol:    "BTC",
+		name:      query.Get("label"),
+		message:   query.Get("message"),
+	}, nil
+}
+
+// parseEthereumURI parses an EIP-681 URI for a plain value transfer
+func parseEthereumURI(target string, query url.Values, testnet bool) (*paymentRequest, error) {
+	target = strings.TrimPrefix(target, "pay-")
+	if strings.Contains(target, "/") {
+		return nil, fmt.Errorf("payment request calls a contract function, only plain ETH transfers are supported")
+	}
+
+	recipient, chainID := target, int64(0)
+	if at := strings.Index(target, "@"); at >= 0 {
+		recipient = target[:at]
+		id, err := strconv.ParseInt(target[at+1:], 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid chain ID in payment request: %s", target[at+1:])
+		}
+		chainID = id
+	}
+	if !strings.HasPrefix(recipient, "0x") {
+		return nil, fmt.Errorf("payment request recipient %s is not an address, ENS names are not supported", recipient)
+	}
+
+	request := &paymentRequest{chain: "eth", label: "Ethereum", recipient: recipient, symbol: "ETH"}
+	switch {
+	case chainID == 0, chainID == ethereum.GetChainID().Int64():
+	default:
+		evmChain, chainTestnet, ok := evmChainByID(chainID)
+		if !ok {
+			if name := ethereumNetworkOf(chainID); name != "" {
+				return nil, fmt.Errorf("payment request is for Ethereum on %s, switch with 'odyssey network %s'", name, name)
+			}
+			return nil, fmt.Errorf("payment request is for chain ID %d, which isn't supported", chainID)
+		}
+		if chainTestnet != testnet {
+			return nil, fmt.Errorf("payment request is for %s on %s, switch with 'odyssey network %s'", evmChain.DisplayName, otherNetwork(testnet), otherNetwork(testnet))
+		}
+		request.chain = evmChain.Name
+		request.label = evmChain.Label(testnet)
+		request.symbol = evmChain.Symbol
+	}
+
+	// value is in wei and may use scientific notation, e.g. 2.014e18
+	if value := query.Get("value"); value != "" {
+		wei, err := decimal.NewFromString(value)
+		if err != nil || !wei.IsPositive() || !wei.IsInteger() {
+			return nil, fmt.Errorf("invalid value in payment request: %s", value)
+		}
+		request.amount = wei.Shift(-18).String()
+	}
+	return request, nil
+}
+
+// parseSolanaURI parses a Solana Pay transfer request
+func parseSolanaURI(target string, query url.Values) (*paymentRequest, error) {
+	if strings.HasPrefix(strings.ToLower(target), "https") {
+		return nil, fmt.Errorf("Solana Pay transaction requests are not supported, only transfer requests")
+	}
+	if query.Get("spl-token") != "" {
+		return nil, fmt.Errorf("payment request is for an SPL token, only SOL transfers are supported")
+	}
+
+	amount, err := paymentURIAmount(query.Get("amount"))
+	if err != nil {
+		return nil, err
+	}
+	return &paymentRequest{
+		chain:      "sol",
+		label:      "Solana",
+		recipient:  target,
+		amount:     amount,
+		symbol:     "SOL",
+		name:       query.Get("label"),
+		message:    query.Get("message"),
+		memo:       query.Get("memo"),
+		references: query["reference"],
+	}, nil
+}
+
+// paymentURIAmount validates an amount in whole coins, empty if there is none
+func paymentURIAmount(amount string) (string, error) {
+	if amount == "" {
+		return "", nil
+	}
+	value, err := decimal.NewFromString(amount)
+	if err != nil || !value.IsPositive() || strings.ContainsAny(amount, "eE") {
+		return "", fmt.Errorf("invalid amount in payment request: %s", amount)
+	}
+	return value.String(), nil
+}
+
+// evmChainByID finds the EVM chain a chain ID belongs to and whether it is its
+// testnet
+func evmChainByID(chainID int64) (*api.EVMChain, bool, bool) {
+	for i := range api.EVMChains {
+		chain := &api.EVMChains[i]
+		switch chainID {
+		case chain.MainnetChainID:
+			return chain, false, true
+		case chain.TestnetChainID:
+			return chain, true, true
+		}
+	}
+	return nil, false, false
+}
+
+// ethereumNetworkOf returns the first network whose Ethereum node has the
+// chain ID, or an empty string
+func ethereumNetworkOf(chainID int64) string {
+	networks, err := config.Networks()
+	if err != nil {
+		return ""
+	}
+	for _, network := range networks {
+		if network.EthereumChainID == chainID {
+			return network.Name
+		}
+	}
+	return ""
+}
+
+// otherNetwork returns the network that isn't the current one
+func otherNetwork(testnet bool) string {
+	if testnet {
+		return NetworkMainnet
+	}
+	return NetworkTestnet
+}
@@ -0,0 +1,496 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	performancePeriodFlag string
+	performanceOutputFlag string
+)
+
+// performancePeriods are the selectable lookback periods
+var performancePeriods = map[string]time.Duration{
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+	"90d": 90 * 24 * time.Hour,
+	"1y":  365 * 24 * time.Hour,
+	"all": 0,
+}
+
+// performanceHistoryPages limits how far back transaction history is fetched per chain
+const performanceHistoryPages = 20
+
+var performanceCmd = &cobra.Command{
+	Use:   "performance",
+	Short: "Show time-weighted and money-weighted returns",
+	Long: `Show how your portfolio performed over a period, per asset and in total.
+
+Every run of 'odyssey portfolio' or 'odyssey performance' stores a balance
+snapshot (at most one per hour). Deposits and withdrawals between snapshots
+are taken from the transaction history, so returns reflect price changes
+rather than money moved in or out.
+
+  TWR  Time-weighted return: the return of the assets themselves, not
+       affected by when you added or removed funds
+  MWR  Money-weighted return: the internal rate of return of your own
+       deposits and withdrawals over the period
+
+Only native ETH, BTC and SOL transfers are known deposits and withdrawals,
+token balance changes count as gains or losses.
+
+Periods: 7d, 30d, 90d, 1y, all
+
+Examples:
+  odyssey performance                  # Last 30 days
+  odyssey performance --period 1y
+  odyssey performance --output json`,
+	Args: cobra.NoArgs,
+	RunE: runPerformance,
+}
+
+func init() {
+	performanceCmd.Flags().StringVar(&performancePeriodFlag, "period", "30d", "Period to measure (7d, 30d, 90d, 1y, all)")
+	performanceCmd.Flags().StringVarP(&performanceOutputFlag, "output", "o", "text", "Output format (text, json)")
+}
+
+// AssetPerformance is the performance of one asset, or of the whole portfolio
+type AssetPerformance struct {
+	Symbol        string          `json:"symbol"`
+	Chain         string          `json:"chain,omitempty"`
+	StartValueUSD decimal.Decimal `json:"start_value_usd"`
+	EndValueUSD   decimal.Decimal `json:"end_value_usd"`
+	NetFlowsUSD   decimal.Decimal `json:"net_flows_usd"`
+	ProfitUSD     decimal.Decimal `json:"profit_usd"`
+	TWR           float64         `json:"twr_percent"`
+	MWR           float64         `json:"mwr_percent"`
+}
+
+// PerformanceReport is the result of 'odyssey performance', as printed by --output json
+type PerformanceReport struct {
+	Network   string             `json:"network"`
+	Period    string             `json:"period"`
+	From      time.Time          `json:"from"`
+	To        time.Time          `json:"to"`
+	Snapshots int                `json:"snapshots"`
+	Assets    []AssetPerformance `json:"assets"`
+	Total     AssetPerformance   `json:"total"`
+}
+
+// cashFlow is a deposit (positive) or withdrawal (negative) of an asset
+type cashFlow struct {
+	time   time.Time
+	asset  string // symbol/chain
+	amount decimal.Decimal
+}
+
+func runPerformance(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	period, ok := performancePeriods[strings.ToLower(performancePeriodFlag)]
+	if !ok {
+		return fmt.Errorf("invalid period: %s. Use 7d, 30d, 90d, 1y or all", performancePeriodFlag)
+	}
+	output := strings.ToLower(performanceOutputFlag)
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format: %s. Use 'text' or 'json'", performanceOutputFlag)
+	}
+
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+	if manager.IsTestnet() {
+		return fmt.Errorf("performance is only tracked on mainnet, testnet assets have no value")
+	}
+
+	if output == "text" {
+		fmt.Println("🔄 Loading portfolio...")
+	}
+
+	// The current portfolio is the end point of every period
+	holdings, err := collectPortfolioHoldings(ctx, manager, client)
+	if err != nil {
+		return err
+	}
+	summary := buildPortfolioSummary(ctx, manager, client, holdings)
+	if !summary.PricesAvailable {
+		return fmt.Errorf("prices unavailable, performance could not be calculated")
+	}
+	if err := recordBalanceSnapshot(manager, summary); err != nil {
+		return err
+	}
+
+	snapshots, err := manager.GetBalanceSnapshots(manager.GetCurrentNetwork())
+	if err != nil {
+		return err
+	}
+	snapshots = snapshotsInPeriod(snapshots, period)
+	if len(snapshots) < 2 {
+		fmt.Println("📭 Not enough history yet")
+		fmt.Println("💡 A balance snapshot was saved now. Run 'odyssey portfolio' or 'odyssey performance' again later to build up history")
+		return nil
+	}
+
+	if output == "text" {
+		fmt.Println("🔄 Loading deposits and withdrawals...")
+	}
+	flows := collectCashFlows(ctx, manager, client, snapshots[0].Time)
+
+	report := buildPerformanceReport(snapshots, flows)
+	report.Network = manager.GetCurrentNetwork()
+	report.Period = strings.ToLower(performancePeriodFlag)
+
+	if output == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode performance: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printPerformanceReport(report)
+	return nil
+}
+
+// recordBalanceSnapshot stores the balances and prices of a portfolio summary
+func recordBalanceSnapshot(manager *wallet.Manager, summary *PortfolioSummary) error {
+	if !summary.PricesAvailable {
+		return nil
+	}
+
+	snapshot := wallet.BalanceSnapshot{Time: time.Now().UTC(), Network: summary.Network}
+	for _, asset := range summary.Assets {
+		// A failed balance lookup must not look like a withdrawal
+		if asset.Error != "" {
+			return nil
+		}
+		amount, err := decimal.NewFromString(strings.TrimSpace(asset.Balance))
+		if err != nil {
+			continue
+		}
+		snapshot.Assets = append(snapshot.Assets, wallet.SnapshotAsset{
+			Symbol:   asset.Symbol,
+			Chain:    asset.Chain,
+			Amount:   amount,
+			PriceUSD: asset.PriceUSD,
+		})
+	}
+
+	return manager.SaveBalanceSnapshot(snapshot)
+}
+
+// snapshotsInPeriod returns the snapshots of a period, starting with the
+// last one taken before the period began
+func snapshotsInPeriod(snapshots []wallet.BalanceSnapshot, period time.Duration) []wallet.BalanceSnapshot {
+	if period == 0 || len(snapshots) == 0 {
+		return snapshots
+	}
+
+	start := time.Now().Add(-period)
+	first := 0
+	for i, snapshot := range snapshots {
+		if snapshot.Time.After(start) {
+			break
+		}
+		first = i
+	}
+	return snapshots[first:]
+}
+
+// collectCashFlows reads native transfers since a time from the history of every chain
+func collectCashFlows(ctx context.Context, manager *wallet.Manager, client *api.Client, since time.Time) []cashFlow {
+	type chainHistory struct {
+		asset string
+		parse func(string) (decimal.Decimal, bool)
+		fetch txPageFetcher
+	}
+
+	var chains []chainHistory
+	if address, err := manager.GetEthereumAddress(); err == nil {
+		chains = append(chains, chainHistory{asset: "ETH/ethereum", parse: parseEthAmount,
+			fetch: func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+				return client.GetEthereumTransactions(ctx, address.Hex(), limit, cursor)
+			}})
+	}
+	if address, err := manager.GetBitcoinAddress(); err == nil {
+		chains = append(chains, chainHistory{asset: "BTC/bitcoin", parse: parseBtcAmount,
+			fetch: func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+				return client.GetBitcoinTransactions(ctx, address.String(), limit, cursor)
+			}})
+	}
+	if address, err := manager.GetSolanaAddress(); err == nil {
+		chains = append(chains, chainHistory{asset: "SOL/solana", parse: parseSolAmount,
+			fetch: func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+				return client.GetSolanaTransactions(ctx, address.String(), limit, cursor)
+			}})
+	}
+
+	var flows []cashFlow
+	for _, chain := range chains {
+		cursor := ""
+		for page := 0; page < performanceHistoryPages; page++ {
+			result, err := chain.fetch(ctx, 50, cursor)
+			if err != nil {
+				break
+			}
+
+			older := false
+			for _, tx := range result.Transactions {
+				if tx.Timestamp.Before(since) {
+					older = true
+					continue
+				}
+				// Transfers to ourselves move nothing
+				if strings.EqualFold(tx.From, tx.To) {
+					continue
+				}
+				amount, ok := chain.parse(tx.Amount)
+				if !ok || amount.IsZero() {
+					continue
+				}
+				if !tx.IsIncoming {
+					amount = amount.Neg()
+				}
+				flows = append(flows, cashFlow{time: tx.Timestamp, asset: chain.asset, amount: amount})
+			}
+
+			if older || result.NextCursor == "" {
+				break
+			}
+			cursor = result.NextCursor
+		}
+	}
+
+	sort.Slice(flows, func(i, j int) bool { return flows[i].time.Before(flows[j].time) })
+	return flows
+}
+
+// buildPerformanceReport computes returns per asset and for the whole portfolio
+func buildPerformanceReport(snapshots []wallet.BalanceSnapshot, flows []cashFlow) *PerformanceReport {
+	report := &PerformanceReport{
+		From:      snapshots[0].Time,
+		To:        snapshots[len(snapshots)-1].Time,
+		Snapshots: len(snapshots),
+	}
+
+	// Every asset seen in any snapshot
+	var assets []string
+	seen := make(map[string]bool)
+	for _, snapshot := range snapshots {
+		for _, asset := range snapshot.Assets {
+			key := asset.Symbol + "/" + asset.Chain
+			if !seen[key] {
+				seen[key] = true
+				assets = append(assets, key)
+			}
+		}
+	}
+
+	// USD value of each asset at each snapshot, and of each flow at the time it happened
+	values := make(map[string][]decimal.Decimal)
+	total := make([]decimal.Decimal, len(snapshots))
+	for _, key := range assets {
+		series := make([]decimal.Decimal, len(snapshots))
+		for i, snapshot := range snapshots {
+			if asset := findSnapshotAsset(snapshot, key); asset != nil {
+				series[i] = asset.Amount.Mul(decimal.NewFromFloat(asset.PriceUSD))
+				total[i] = total[i].Add(series[i])
+			}
+		}
+		values[key] = series
+	}
+
+	flowValues := make([]decimal.Decimal, len(flows))
+	for i, flow := range flows {
+		flowValues[i] = flow.amount.Mul(decimal.NewFromFloat(priceAt(snapshots, flow.asset, flow.time)))
+	}
+
+	for _, key := range assets {
+		var assetFlows []cashFlow
+		var assetFlowValues []decimal.Decimal
+		for i, flow := range flows {
+			if flow.asset == key {
+				assetFlows = append(assetFlows, flow)
+				assetFlowValues = append(assetFlowValues, flowValues[i])
+			}
+		}
+
+		parts := strings.SplitN(key, "/", 2)
+		result := measurePerformance(snapshots, values[key], assetFlows, assetFlowValues)
+		result.Symbol, result.Chain = parts[0], parts[1]
+		report.Assets = append(report.Assets, result)
+	}
+
+	report.Total = measurePerformance(snapshots, total, flows, flowValues)
+	report.Total.Symbol = "Total"
+
+	// Largest holdings first
+	sort.SliceStable(report.Assets, func(i, j int) bool {
+		return report.Assets[i].EndValueUSD.GreaterThan(report.Assets[j].EndValueUSD)
+	})
+
+	return report
+}
+
+// measurePerformance computes the returns of a value series with cash flows
+func measurePerformance(snapshots []wallet.BalanceSnapshot, values []decimal.Decimal, flows []cashFlow, flowValues []decimal.Decimal) AssetPerformance {
+	last := len(snapshots) - 1
+	result := AssetPerformance{
+		StartValueUSD: values[0].Round(2),
+		EndValueUSD:   values[last].Round(2),
+	}
+
+	netFlows := decimal.Sum(decimal.Zero, flowValues...)
+	result.NetFlowsUSD = netFlows.Round(2)
+	result.ProfitUSD = values[last].Sub(values[0]).Sub(netFlows).Round(2)
+
+	// TWR: chain the Modified Dietz return of every interval between snapshots
+	growth := decimal.NewFromInt(1)
+	for i := 0; i < last; i++ {
+		start, end := snapshots[i].Time, snapshots[i+1].Time
+		length := end.Sub(start).Seconds()
+		if length <= 0 {
+			continue
+		}
+
+		intervalFlows, weighted := decimal.Zero, decimal.Zero
+		for j, flow := range flows {
+			if flow.time.After(start) && !flow.time.After(end) {
+				intervalFlows = intervalFlows.Add(flowValues[j])
+				weighted = weighted.Add(flowValues[j].Mul(decimal.NewFromFloat(end.Sub(flow.time).Seconds() / length)))
+			}
+		}
+
+		base := values[i].Add(weighted)
+		if !base.IsPositive() {
+			continue
+		}
+		growth = growth.Mul(values[i+1].Sub(values[i]).Sub(intervalFlows).Div(base).Add(decimal.NewFromInt(1)))
+	}
+	result.TWR = growth.Sub(decimal.NewFromInt(1)).Shift(2).Round(2).InexactFloat64()
+
+	// MWR: the rate over the whole period that grows the start value and
+	// every flow into the end value
+	from, to := snapshots[0].Time, snapshots[last].Time
+	span := to.Sub(from).Seconds()
+	if span > 0 {
+		remaining := make([]float64, len(flows))
+		for j, flow := range flows {
+			remaining[j] = to.Sub(flow.time).Seconds() / span
+		}
+		// The rate is found numerically, so the values are floats here
+		futureValue := func(rate float64) float64 {
+			value := values[0].InexactFloat64() * (1 + rate)
+			for j := range flows {
+				value += flowValues[j].InexactFloat64() * math.Pow(1+rate, remaining[j])
+			}
+			return value - values[last].InexactFloat64()
+		}
+		if rate, ok := solveRate(futureValue); ok {
+			result.MWR = round2(rate * 100)
+		}
+	}
+
+	return result
+}
+
+// solveRate finds the rate where f crosses zero by bisection
+func solveRate(f func(float64) float64) (float64, bool) {
+	low, high := -0.9999, 1.0
+	for f(high) < 0 && high < 1e6 {
+		high *= 10
+	}
+	if f(low)*f(high) > 0 {
+		return 0, false
+	}
+
+	for i := 0; i < 200; i++ {
+		mid := (low + high) / 2
+		if f(low)*f(mid) <= 0 {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return (low + high) / 2, true
+}
+
+// priceAt interpolates the price of an asset between the snapshots around a time
+func priceAt(snapshots []wallet.BalanceSnapshot, key string, at time.Time) float64 {
+	var before, after *wallet.BalanceSnapshot
+	for i := range snapshots {
+		if findSnapshotAsset(snapshots[i], key) == nil {
+			continue
+		}
+		if !snapshots[i].Time.After(at) {
+			before = &snapshots[i]
+		} else if after == nil {
+			after = &snapshots[i]
+		}
+	}
+
+	switch {
+	case before == nil && after == nil:
+		return 0
+	case before == nil:
+		return findSnapshotAsset(*after, key).PriceUSD
+	case after == nil:
+		return findSnapshotAsset(*before, key).PriceUSD
+	}
+
+	p0 := findSnapshotAsset(*before, key).PriceUSD
+	p1 := findSnapshotAsset(*after, key).PriceUSD
+	fraction := at.Sub(before.Time).Seconds() / after.Time.Sub(before.Time).Seconds()
+	return p0 + (p1-p0)*fraction
+}
+
+func findSnapshotAsset(snapshot wallet.BalanceSnapshot, key string) *wallet.SnapshotAsset {
+	for i, asset := range snapshot.Assets {
+		if asset.Symbol+"/"+asset.Chain == key {
+			return &snapshot.Assets[i]
+		}
+	}
+	return nil
+}
+
+func printPerformanceReport(report *PerformanceReport) {
+	fmt.Println()
+	fmt.Println("📈 Portfolio Performance")
+	fmt.Printf("🗓️  %s → %s (%d snapshots)\n", report.From.Local().Format("2006-01-02 15:04"), report.To.Local().Format("2006-01-02 15:04"), report.Snapshots)
+	fmt.Println()
+
+	fmt.Printf("   %-6s %-10s %13s %13s %12s %12s %9s %9s\n", "Asset", "Chain", "Start", "End", "Net flows", "Profit", "TWR", "MWR")
+	fmt.Printf("   %s\n", strings.Repeat("-", 91))
+
+	printRow := func(p AssetPerformance) {
+		fmt.Printf("   %-6s %-10s %13s %13s %12s %12s %8.2f%% %8.2f%%\n", p.Symbol, p.Chain,
+			formatUSD(p.StartValueUSD), formatUSD(p.EndValueUSD),
+			formatSignedUSD(p.NetFlowsUSD), formatSignedUSD(p.ProfitUSD), p.TWR, p.MWR)
+	}
+	for _, asset := range report.Assets {
+		printRow(asset)
+	}
+	fmt.Printf("   %s\n", strings.Repeat("-", 91))
+	printRow(report.Total)
+
+	fmt.Println()
+	fmt.Println("ℹ️ TWR measures the assets, MWR measures your timing of deposits and withdrawals")
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
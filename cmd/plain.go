@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// PlainEnv enables plain output when set to 1 or true. NO_COLOR, see
+// https://no-color.org, enables it too.
+const PlainEnv = "ODYSSEY_PLAIN"
+
+var (
+	// plainMode is set by --plain: emoji, colors and progress bars are left
+	// out so the output reads well in logs, CI and screen readers
+	plainMode bool
+
+	// plainWriters copy the pipes that replace stdout and stderr in plain
+	// mode to the terminal, see FlushOutput
+	plainWriters sync.WaitGroup
+	plainPipes   []*os.File
+)
+
+// plainRequested returns true if the flag or the environment asks for plain
+// output
+func plainRequested(flag bool) bool {
+	if flag {
+		return true
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return true
+	}
+	env := strings.ToLower(os.Getenv(PlainEnv))
+	return env == "1" || env == "true"
+}
+
+// setPlain turns off colors and strips emoji from everything printed to
+// stdout and stderr from now on
+func setPlain(plain bool) error {
+	plainMode = plain
+	if !plain {
+		return nil
+	}
+	color.NoColor = true
+
+	stdout, err := plainPipe(os.Stdout)
+	if err != nil {
+		return err
+	}
+	stderr, err := plainPipe(os.Stderr)
+	if err != nil {
+		return err
+	}
+	os.Stdout, os.Stderr = stdout, stderr
+	return nil
+}
+
+// plainPipe returns a pipe whose output is copied to out without emoji
+func plainPipe(out *os.File) (*os.File, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output pipe: %w", err)
+	}
+	plainPipes = append(plainPipes, w)
+	plainWriters.Add(1)
+	go func() {
+		defer plainWriters.Done()
+		copyPlain(out, r)
+		r.Close()
+	}()
+	return w, nil
+}
+
+// FlushOutput waits until everything printed has been written, call it
+// before the process exits
+func FlushOutput() {
+	for _, w := range plainPipes {
+		w.Close()
+	}
+	plainPipes = nil
+	plainWriters.Wait()
+}
+
+// copyPlain copies r to w without emoji. Whatever is read is written right
+// away, so prompts without a newline show up before the answer is read.
+func copyPlain(w io.Writer, r io.Reader) {
+	buf := make([]byte, 4096)
+	var pending []byte
+	afterEmoji := false
+	for {
+		n, err := r.Read(buf)
+		pending = append(pending, buf[:n]...)
+
+		var out strings.Builder
+		for len(pending) > 0 && utf8.FullRune(pending) {
+			ch, size := utf8.DecodeRune(pending)
+			pending = pending[size:]
+			switch {
+			case isEmoji(ch):
+				afterEmoji = true
+			case afterEmoji && ch == ' ':
+				// Drop the spacing that followed the emoji
+			default:
+				afterEmoji = false
+				out.WriteRune(ch)
+			}
+		}
+		if out.Len() > 0 {
+			io.WriteString(w, out.String())
+		}
+		if err != nil {
+			if len(pending) > 0 {
+				w.Write(pending)
+			}
+			return
+		}
+	}
+}
+
+// isEmoji returns true for the pictographs odyssey decorates output with and
+// the selectors that turn symbols into emoji. Arrows, bullets and box drawing
+// are kept as they read fine as text.
+func isEmoji(ch rune) bool {
+	switch {
+	case ch == 0x2139, ch == 0x21A9, ch == 0x21AA: // ℹ ↩ ↪
+		return true
+	case ch >= 0x2300 && ch <= 0x23FF: // ⏳ ⌛ ⏱
+		return true
+	case ch >= 0x2600 && ch <= 0x27BF: // ⚠ ✅ ❌ ➡
+		return true
+	case ch >= 0x2B00 && ch <= 0x2BFF: // ⬅ ⬇ ⭐
+		return true
+	case ch >= 0x1F000 && ch <= 0x1FAFF:
+		return true
+	case ch == 0xFE0F, ch == 0x200D, ch == 0x20E3:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,506 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Spending policy rules
+const (
+	policyMaxTx         = "max-tx"
+	policyDaily         = "daily"
+	policyConfirmAbove  = "confirm-above"
+	policyWhitelistOnly = "whitelist-only"
+)
+
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Limit what the wallet may send",
+	Long: `Manage the spending policy enforced before a transaction is signed, by
+'odyssey pay' and every other command that signs: swaps, rebalancing,
+contract calls, NFT transfers and staking.
+
+Rules:
+  max-tx [chain] [amount]         Largest payment allowed on the chain
+  daily [chain] [amount]          Most that may be sent on the chain in 24 hours
+  confirm-above [chain] [amount]  Payments above need the recipient typed to confirm
+  whitelist-only [on|off]         Only pay addresses on the policy whitelist
+
+Amounts are in the chain's native asset, tokens sold in a swap don't count.
+The recipient is the address paid, or the contract called. The extra
+confirmation can't be skipped with --yes. EVM chains share the Ethereum
+whitelist. The whitelist and the deny-list are also managed with
+'odyssey allowlist' and 'odyssey denylist'.
+
+Changing the policy asks for the wallet password and, when two-factor
+authentication is enabled, a code.
+
+Examples:
+  odyssey policy set max-tx eth 0.5
+  odyssey policy set daily btc 0.05
+  odyssey policy set confirm-above sol 10
+  odyssey policy whitelist add eth 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6 exchange
+  odyssey policy set whitelist-only on
+  odyssey policy unset daily btc
+  odyssey policy list`,
+	Args: cobra.NoArgs,
+	RunE: runPolicyList,
+}
+
+var policyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the spending policy",
+	Args:  cobra.NoArgs,
+	RunE:  runPolicyList,
+}
+
+var policySetCmd = &cobra.Command{
+	Use:   "set [rule] [chain] [amount]",
+	Short: "Set a limit, or whitelist-only on or off",
+	Args:  cobra.RangeArgs(2, 3),
+	RunE:  runPolicySet,
+}
+
+var policyUnsetCmd = &cobra.Command{
+	Use:   "unset [rule] [chain]",
+	Short: "Remove a limit",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE:  runPolicyUnset,
+}
+
+var policyWhitelistCmd = &cobra.Command{
+	Use:   "whitelist",
+	Short: "Manage the addresses allowed in whitelist-only mode",
+}
+
+var policyWhitelistAddCmd = &cobra.Command{
+	Use:   "add [chain] [address] [label]",
+	Short: "Allow payments to an address",
+	Args:  cobra.RangeArgs(2, 3),
+	RunE:  runPolicyWhitelistAdd,
+}
+
+var policyWhitelistRemoveCmd = &cobra.Command{
+	Use:   "remove [chain] [address]",
+	Short: "Remove an address from the whitelist",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runPolicyWhitelistRemove,
+}
+
+func init() {
+	policyWhitelistCmd.AddCommand(policyWhitelistAddCmd)
+	policyWhitelistCmd.AddCommand(policyWhitelistRemoveCmd)
+
+	policyCmd.AddCommand(policyListCmd)
+	policyCmd.AddCommand(policySetCmd)
+	policyCmd.AddCommand(policyUnsetCmd)
+	policyCmd.AddCommand(policyWhitelistCmd)
+}
+
+func runPolicyList(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+
+	if len(policy.Limits) == 0 && !policy.WhitelistOnly && !policy.ConfirmUnlisted && len(policy.Whitelist) == 0 && len(policy.Denylist) == 0 {
+		fmt.Println("📭 No spending policy, payments are only limited by your balance")
+		fmt.Println("💡 Set a limit with: odyssey policy set max-tx [chain] [amount]")
+		return nil
+	}
+
+	fmt.Println("🛡️  Spending Policy")
+	fmt.Println()
+
+	chains := make([]string, 0, len(policy.Limits))
+	for chain := range policy.Limits {
+		chains = append(chains, chain)
+	}
+	sort.Strings(chains)
+
+	network := manager.GetCurrentNetwork()
+	now := time.Now()
+	for _, chain := range chains {
+		limits := policy.Limits[chain]
+		symbol := policyChainSymbol(chain)
+		fmt.Printf("%s\n", chain)
+		if !limits.MaxPerTx.IsZero() {
+			fmt.Printf("   Max per tx:    %s %s\n", limits.MaxPerTx, symbol)
+		}
+		if !limits.Daily.IsZero() {
+			spent := policy.SpentToday(chain, network, now)
+			fmt.Printf("   Daily:         %s %s (%s sent in the last 24h on %s)\n", limits.Daily, symbol, spent, network)
+		}
+		if !limits.ConfirmAbove.IsZero() {
+			fmt.Printf("   Confirm above: %s %s\n", limits.ConfirmAbove, symbol)
+		}
+		fmt.Println()
+	}
+
+	switch {
+	case policy.WhitelistOnly:
+		fmt.Println("🔐 Whitelist-only: payments go to whitelisted addresses only")
+	case policy.ConfirmUnlisted:
+		fmt.Println("🔐 Whitelist-only: off, other addresses must be typed to confirm")
+	default:
+		fmt.Println("🔓 Whitelist-only: off")
+	}
+	printPolicyRecipients(policy.Whitelist)
+
+	if len(policy.Denylist) > 0 {
+		fmt.Println()
+		fmt.Println("⛔ Deny-list: never paid")
+		printPolicyRecipients(policy.Denylist)
+	}
+
+	return nil
+}
+
+// printPolicyRecipients prints the addresses of the whitelist or deny-list
+func printPolicyRecipients(list []wallet.WhitelistedRecipient) {
+	for _, entry := range list {
+		if entry.Label != "" {
+			fmt.Printf("   %-9s %s (%s)\n", entry.Chain, entry.Address, entry.Label)
+		} else {
+			fmt.Printf("   %-9s %s\n", entry.Chain, entry.Address)
+		}
+	}
+}
+
+func runPolicySet(cmd *cobra.Command, args []string) error {
+	rule := strings.ToLower(args[0])
+	manager := wallet.NewManager()
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+	if err := authorizePolicyChange(cmd, manager); err != nil {
+		return err
+	}
+
+	if rule == policyWhitelistOnly {
+		if len(args) != 2 {
+			return fmt.Errorf("usage: odyssey policy set whitelist-only [on|off]")
+		}
+		switch strings.ToLower(args[1]) {
+		case "on", "true", "yes":
+			if len(policy.Whitelist) == 0 {
+				return fmt.Errorf("the whitelist is empty, add addresses first with 'odyssey policy whitelist add'")
+			}
+			policy.WhitelistOnly = true
+			policy.ConfirmUnlisted = false
+		case "off", "false", "no":
+			policy.WhitelistOnly = false
+		default:
+			return fmt.Errorf("invalid value: %s. Use 'on' or 'off'", args[1])
+		}
+		if err := manager.SaveSpendingPolicy(policy); err != nil {
+			return err
+		}
+		fmt.Printf("✅ whitelist-only set to %s\n", strings.ToLower(args[1]))
+		return nil
+	}
+
+	if len(args) != 3 {
+		return fmt.Errorf("usage: odyssey policy set %s [chain] [amount]", rule)
+	}
+	chain, _, err := parsePolicyChain(args[1])
+	if err != nil {
+		return err
+	}
+	amount, err := decimal.NewFromString(args[2])
+	if err != nil || !amount.IsPositive() {
+		return fmt.Errorf("invalid amount: %s. Use a positive number", args[2])
+	}
+
+	limits := policy.Limits[chain]
+	switch rule {
+	case policyMaxTx:
+		limits.MaxPerTx = amount
+	case policyDaily:
+		limits.Daily = amount
+	case policyConfirmAbove:
+		limits.ConfirmAbove = amount
+	default:
+		return fmt.Errorf("unknown rule: %s. Use max-tx, daily, confirm-above or whitelist-only", args[0])
+	}
+	policy.Limits[chain] = limits
+
+	if err := manager.SaveSpendingPolicy(policy); err != nil {
+		return err
+	}
+	fmt.Printf("✅ %s on %s set to %s %s\n", rule, chain, amount, policyChainSymbol(chain))
+	return nil
+}
+
+func runPolicyUnset(cmd *cobra.Command, args []string) error {
+	rule := strings.ToLower(args[0])
+	manager := wallet.NewManager()
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+	if err := authorizePolicyChange(cmd, manager); err != nil {
+		return err
+	}
+
+	if rule == policyWhitelistOnly {
+		policy.WhitelistOnly = false
+		if err := manager.SaveSpendingPolicy(policy); err != nil {
+			return err
+		}
+		fmt.Println("✅ whitelist-only set to off")
+		return nil
+	}
+
+	if len(args) != 2 {
+		return fmt.Errorf("usage: odyssey policy unset %s [chain]", rule)
+	}
+	chain, _, err := parsePolicyChain(args[1])
+	if err != nil {
+		return err
+	}
+
+	limits := policy.Limits[chain]
+	switch rule {
+	case policyMaxTx:
+		limits.MaxPerTx = decimal.Zero
+	case policyDaily:
+		limits.Daily = decimal.Zero
+	case policyConfirmAbove:
+		limits.ConfirmAbove = decimal.Zero
+	default:
+		return fmt.Errorf("unknown rule: %s. Use max-tx, daily, confirm-above or whitelist-only", args[0])
+	}
+	policy.Limits[chain] = limits
+
+	if err := manager.SaveSpendingPolicy(policy); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Removed %s on %s\n", rule, chain)
+	return nil
+}
+
+func runPolicyWhitelistAdd(cmd *cobra.Command, args []string) error {
+	_, whitelistChain, err := parsePolicyChain(args[0])
+	if err != nil {
+		return err
+	}
+	address, err := normalizeWatchAddress(whitelistChain, strings.TrimSpace(args[1]))
+	if err != nil {
+		return err
+	}
+	label := ""
+	if len(args) == 3 {
+		label = strings.TrimSpace(args[2])
+	}
+
+	manager := wallet.NewManager()
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+	if err := authorizePolicyChange(cmd, manager); err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, entry := range policy.Whitelist {
+		if entry.Chain == whitelistChain && entry.Address == address {
+			policy.Whitelist[i].Label = label
+			replaced = true
+		}
+	}
+	if !replaced {
+		policy.Whitelist = append(policy.Whitelist, wallet.WhitelistedRecipient{Chain: whitelistChain, Address: address, Label: label})
+	}
+
+	if err := manager.SaveSpendingPolicy(policy); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Whitelisted %s address %s\n", whitelistChain, address)
+	return nil
+}
+
+func runPolicyWhitelistRemove(cmd *cobra.Command, args []string) error {
+	_, whitelistChain, err := parsePolicyChain(args[0])
+	if err != nil {
+		return err
+	}
+
+	manager := wallet.NewManager()
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+	if err := authorizePolicyChange(cmd, manager); err != nil {
+		return err
+	}
+
+	var kept []wallet.WhitelistedRecipient
+	for _, entry := range policy.Whitelist {
+		if entry.Chain != whitelistChain || !strings.EqualFold(entry.Address, args[1]) {
+			kept = append(kept, entry)
+		}
+	}
+	if len(kept) == len(policy.Whitelist) {
+		return fmt.Errorf("%s is not on the %s whitelist", args[1], whitelistChain)
+	}
+	policy.Whitelist = kept
+	if len(kept) == 0 && policy.WhitelistOnly {
+		policy.WhitelistOnly = false
+		fmt.Println("⚠️  The whitelist is empty, whitelist-only is turned off")
+	}
+
+	if err := manager.SaveSpendingPolicy(policy); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Removed %s from the %s whitelist\n", args[1], whitelistChain)
+	return nil
+}
+
+// authorizePolicyChange asks for the wallet password and, when two-factor
+// authentication is enabled, a code before the policy is changed, so a
+// session left unlocked isn't enough to loosen it
+func authorizePolicyChange(cmd *cobra.Command, manager *wallet.Manager) error {
+	if !manager.VaultExists() {
+		return fmt.Errorf("no wallet found. Run 'odyssey init' to create a new wallet")
+	}
+	password, err := readWalletPassword(cmd, "Enter your wallet password: ")
+	if err != nil {
+		return err
+	}
+	if err := manager.CheckPassword(password); err != nil {
+		return err
+	}
+	return requireTwoFactor(manager)
+}
+
+// parsePolicyChain returns the chain limits are kept under and the chain of
+// its whitelist, EVM chains share Ethereum addresses
+func parsePolicyChain(arg string) (chain, whitelistChain string, err error) {
+	if chain, err := parseWatchChain(arg); err == nil {
+		return chain, chain, nil
+	}
+	if evmChain, ok := api.FindEVMChain(arg); ok {
+		return evmChain.Name, "ethereum", nil
+	}
+	return "", "", fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol, %s", arg, strings.Join(api.EVMChainNames(), ", "))
+}
+
+// policyChainSymbol returns the native asset of a chain limits are kept under
+func policyChainSymbol(chain string) string {
+	switch chain {
+	case "ethereum":
+		return "ETH"
+	case "bitcoin":
+		return "BTC"
+	case "solana":
+		return "SOL"
+	}
+	if evmChain, ok := api.FindEVMChain(chain); ok {
+		return evmChain.Symbol
+	}
+	return ""
+}
+
+// policyChainPriceID returns the price API ID of the native asset of a chain
+// limits are kept under
+func policyChainPriceID(chain string) string {
+	if evmChain, ok := api.FindEVMChain(chain); ok {
+		return evmChain.PriceID
+	}
+	return chain
+}
+
+// enforceSpendingPolicy checks a payment of amount in the native asset of
+// chain against the spending policy before it is signed. Payments above the
+// confirmation threshold need the end of the recipient typed, even with --yes.
+func enforceSpendingPolicy(manager *wallet.Manager, chain string, amount decimal.Decimal, recipient string) error {
+	policy, err := manager.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+	_, whitelistChain, err := parsePolicyChain(chain)
+	if err != nil {
+		return err
+	}
+
+	check, err := policy.CheckPayment(chain, whitelistChain, manager.GetCurrentNetwork(), amount, recipient)
+	if err != nil {
+		return fmt.Errorf("%w. Review it with 'odyssey policy list'", err)
+	}
+	if check.Unlisted {
+		if err := confirmUnlistedRecipient(whitelistChain, recipient); err != nil {
+			return err
+		}
+	}
+	if !check.NeedsConfirmation {
+		return nil
+	}
+
+	suffix := recipient
+	if len(suffix) > 4 {
+		suffix = suffix[len(suffix)-4:]
+	}
+	out := promptOut()
+	fmt.Fprintf(out, "🛡️  The policy asks for an extra confirmation above %s %s\n", check.ConfirmAbove, policyChainSymbol(chain))
+	fmt.Fprintf(out, "   Sending %s %s to %s\n", amount, policyChainSymbol(chain), recipient)
+	if !term.IsTerminal(int(syscall.Stdin)) {
+		return fmt.Errorf("%w: payments above %s %s need a confirmation typed in a terminal", wallet.ErrPolicyViolation, check.ConfirmAbove, policyChainSymbol(chain))
+	}
+	fmt.Fprint(out, "Type the last 4 characters of the recipient address to confirm: ")
+
+	var response string
+	fmt.Scanln(&response)
+	if !strings.EqualFold(strings.TrimSpace(response), suffix) {
+		fmt.Println("❌ Transaction cancelled, the characters didn't match")
+		return errCancelled
+	}
+	fmt.Println()
+	return nil
+}
+
+// confirmUnlistedRecipient asks for the address of a recipient that isn't on
+// the allow-list to be typed again, so an address swapped in the clipboard by
+// malware is caught before signing
+func confirmUnlistedRecipient(whitelistChain, recipient string) error {
+	out := promptOut()
+	fmt.Fprintf(out, "🛡️  %s is not on the allow-list\n", recipient)
+	if !term.IsTerminal(int(syscall.Stdin)) {
+		return fmt.Errorf("%w: payments to addresses off the allow-list need the address typed in a terminal", wallet.ErrPolicyViolation)
+	}
+	fmt.Fprint(out, "Type the recipient address from where you got it, not from the clipboard: ")
+
+	var response string
+	fmt.Scanln(&response)
+	response = strings.TrimSpace(response)
+	if response != recipient && !(whitelistChain == "ethereum" && strings.EqualFold(response, recipient)) {
+		fmt.Println("❌ Transaction cancelled, the address didn't match. Check that your clipboard wasn't tampered with")
+		return errCancelled
+	}
+	fmt.Println()
+	return nil
+}
+
+// recordPolicySpend counts a sent transaction towards the daily limit of the
+// chain it spends on, see checkBeforeSigning
+func recordPolicySpend(manager *wallet.Manager, pending *wallet.PendingBroadcast) {
+	if pending.SpendChain == "" || !pending.SpendAmount.IsPositive() {
+		return
+	}
+	if err := manager.RecordPolicySpend(pending.SpendChain, pending.Network, pending.SpendAmount); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  The payment couldn't be counted towards the daily limit: %v\n", err)
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/chinmay1088/odyssey/wallet"
+)
+
+func TestPolicyChangesNeedThePassword(t *testing.T) {
+	testWallet(t)
+	refuseDials(t)
+
+	t.Setenv(PasswordEnv, "wrong-password")
+	if _, err := runCommand(t, "policy", "set", "max-tx", "eth", "1"); err == nil {
+		t.Fatal("policy set succeeded with a wrong password")
+	}
+	policy, err := wallet.NewManager().GetSpendingPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := policy.Limits["ethereum"]; ok {
+		t.Fatalf("a wrong password changed the policy: %+v", policy.Limits)
+	}
+
+	t.Setenv(PasswordEnv, "test-password")
+	if _, err := runCommand(t, "policy", "set", "max-tx", "eth", "1"); err != nil {
+		t.Fatal(err)
+	}
+	policy, err = wallet.NewManager().GetSpendingPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limit := policy.Limits["ethereum"].MaxPerTx.String(); limit != "1" {
+		t.Errorf("max-tx on ethereum is %s, want 1", limit)
+	}
+}
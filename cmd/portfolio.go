@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+var portfolioOutputFlag string
+
+var portfolioCmd = &cobra.Command{
+	Use:   "portfolio",
+	Short: "Show portfolio summary with allocation breakdown",
+	Long: `Show a summary of all your holdings across chains, including tokens.
+
+Balances and prices are fetched concurrently. For every asset the summary shows
+its USD value, share of the total portfolio and 24h price change.
+
+Output formats:
+  --output text   Human readable table (default)
+  --output json   Machine readable JSON for dashboards
+
+Examples:
+  odyssey portfolio                 # Show portfolio summary
+  odyssey portfolio --output json   # Print summary as JSON`,
+	Args: cobra.NoArgs,
+	RunE: runPortfolio,
+}
+
+func init() {
+	portfolioCmd.Flags().StringVarP(&portfolioOutputFlag, "output", "o", "text", "Output format (text, json)")
+}
+
+// PortfolioAsset is a single holding in the portfolio summary
+type PortfolioAsset struct {
+	Symbol     string  `json:"symbol"`
+	Name       string  `json:"name"`
+	Chain      string  `json:"chain"`
+	Balance    string  `json:"balance"`
+	PriceUSD   float64 `json:"price_usd"`
+	ValueUSD   float64 `json:"value_usd"`
+	Change24h  float64 `json:"change_24h_percent"`
+	Allocation float64 `json:"allocation_percent"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// PortfolioSummary is the complete portfolio, as printed by --output json
+type PortfolioSummary struct {
+	Network         string           `json:"network"`
+	TotalUSD        float64          `json:"total_usd"`
+	Change24hUSD    float64          `json:"change_24h_usd"`
+	Change24hPct    float64          `json:"change_24h_percent"`
+	PricesAvailable bool             `json:"prices_available"`
+	Assets          []PortfolioAsset `json:"assets"`
+}
+
+// portfolioHolding is the raw result of a single balance lookup
+type portfolioHolding struct {
+	symbol  string
+	name    string
+	chain   string
+	priceID string
+	amount  decimal.Decimal
+	native  bool
+	err     error
+}
+
+func runPortfolio(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	output := strings.ToLower(portfolioOutputFlag)
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format: %s. Use 'text' or 'json'", portfolioOutputFlag)
+	}
+
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	if output == "text" {
+		fmt.Println("🔄 Loading portfolio...")
+	}
+
+	holdings, err := collectPortfolioHoldings(ctx, manager, client)
+	if err != nil {
+		return err
+	}
+
+	for _, holding := range holdings {
+		if holding.err == nil {
+			noteBalance(holding.amount.IsPositive())
+		}
+	}
+
+	summary := buildPortfolioSummary(ctx, manager, client, holdings)
+
+	// Snapshots feed 'odyssey performance', a failure to store one is not fatal
+	_ = recordBalanceSnapshot(manager, summary)
+
+	if output == "json" {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode portfolio: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printPortfolioSummary(summary)
+	return nil
+}
+
+// collectPortfolioHoldings fetches native and token balances for every chain concurrently
+func collectPortfolioHoldings(ctx context.Context, manager *wallet.Manager, client *api.Client) ([]portfolioHolding, error) {
+	ethAddress, err := manager.GetEthereumAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+
+	solAddress, err := manager.GetSolanaAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Solana address: %w", err)
+	}
+
+	resultChan := make(chan portfolioHolding, 16)
+	var wg sync.WaitGroup
+
+	fetch := func(holding portfolioHolding, balance func() (decimal.Decimal, error)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			holding.amount, holding.err = balance()
+			resultChan <- holding
+		}()
+	}
+
+	// Native assets
+	fetch(portfolioHolding{symbol: "ETH", name: "Ethereum", chain: "ethereum", priceID: "ethereum", native: true}, func() (decimal.Decimal, error) {
+		balance, err := client.GetEthereumBalance(ctx, ethAddress.Hex())
+		if err != nil {
+			return decimal.Zero, err
+		}
+		return api.TokenAmount(balance, 18), nil
+	})
+
+	// Bitcoin is only supported in mainnet and regtest
+	if manager.BitcoinSupported() {
+		btcAddresses, err := bitcoinWalletAddresses(manager)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Bitcoin address: %w", err)
+		}
+		fetch(portfolioHolding{symbol: "BTC", name: "Bitcoin", chain: "bitcoin", priceID: "bitcoin", native: true}, func() (decimal.Decimal, error) {
+			return fetchBitcoinWalletBalance(ctx, client, btcAddresses)
+		})
+	}
+
+	fetch(portfolioHolding{symbol: "SOL", name: "Solana", chain: "solana", priceID: "solana", native: true}, func() (decimal.Decimal, error) {
+		balance, err := client.GetSolanaBalance(ctx, solAddress.String())
+		if err != nil {
+			return decimal.Zero, err
+		}
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(balance), -9), nil
+	})
+
+	// Tokens
+	for _, token := range client.GetEthereumTokens() {
+		token := token
+		fetch(portfolioHolding{symbol: token.Symbol, name: token.Name, chain: "ethereum", priceID: token.CoingeckoID}, func() (decimal.Decimal, error) {
+			balance, err := client.GetERC20Balance(ctx, token.Address, ethAddress.Hex())
+			if err != nil {
+				return decimal.Zero, err
+			}
+			return api.TokenAmount(balance, token.Decimals), nil
+		})
+	}
+
+	for _, token := range client.GetSolanaTokens() {
+		token := token
+		fetch(portfolioHolding{symbol: token.Symbol, name: token.Name, chain: "solana", priceID: token.CoingeckoID}, func() (decimal.Decimal, error) {
+			balance, err := client.GetSPLTokenBalance(ctx, token.Address, solAddress.String())
+			if err != nil {
+				return decimal.Zero, err
+			}
+			return api.TokenAmount(balance, token.Decimals), nil
+		})
+	}
+
+	// Wait for all goroutines to complete
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	var holdings []portfolioHolding
+	for holding := range resultChan {
+		// Hide empty token balances, native assets are always shown
+		if !holding.native && holding.err == nil && holding.amount.IsZero() {
+			continue
+		}
+		holdings = append(holdings, holding)
+	}
+
+	return holdings, nil
+}
+
+// buildPortfolioSummary prices every holding and computes totals and allocations
+func buildPortfolioSummary(ctx context.Context, manager *wallet.Manager, client *api.Client, holdings []portfolioHolding) *PortfolioSummary {
+	summary := &PortfolioSummary{Network: manager.GetCurrentNetwork()}
+
+	// Fetch all prices in a single call, testnet assets have no value
+	var prices map[string]*api.PriceData
+	if !manager.IsTestnet() {
+		seen := make(map[string]bool)
+		var ids []string
+		for _, holding := range holdings {
+			// Tokens added without a CoinGecko ID have no price
+			if holding.priceID != "" && !seen[holding.priceID] {
+				seen[holding.priceID] = true
+				ids = append(ids, holding.priceID)
+			}
+		}
+		if fetched, err := client.GetPrices(ctx, ids); err == nil {
+			prices = fetched
+			summary.PricesAvailable = true
+		}
+	}
+
+	total := decimal.Zero
+	previousTotal := decimal.Zero
+	values := make([]decimal.Decimal, len(holdings))
+
+	for i, holding := range holdings {
+		asset := PortfolioAsset{
+			Symbol:  holding.symbol,
+			Name:    holding.name,
+			Chain:   holding.chain,
+			Balance: holding.amount.String(),
+		}
+		if holding.err != nil {
+			asset.Error = holding.err.Error()
+		}
+
+		if price, ok := prices[holding.priceID]; ok {
+			value := holding.amount.Mul(price.USD)
+			values[i] = value
+			total = total.Add(value)
+
+			// Value 24h ago = value / (1 + change/100)
+			divisor := decimal.NewFromInt(1).Add(price.Change24h.Div(decimal.NewFromInt(100)))
+			if !divisor.IsZero() {
+				previousTotal = previousTotal.Add(value.Div(divisor))
+			}
+
+			asset.PriceUSD = price.USD.InexactFloat64()
+			asset.ValueUSD = value.Round(2).InexactFloat64()
+			asset.Change24h = price.Change24h.Round(2).InexactFloat64()
+		}
+
+		summary.Assets = append(summary.Assets, asset)
+	}
+
+	// Allocation as a share of the total value
+	if total.IsPositive() {
+		for i := range summary.Assets {
+			summary.Assets[i].Allocation = values[i].Div(total).Mul(decimal.NewFromInt(100)).Round(2).InexactFloat64()
+		}
+	}
+
+	summary.TotalUSD = total.Round(2).InexactFloat64()
+	if previousTotal.IsPositive() {
+		change := total.Sub(previousTotal)
+		summary.Change24hUSD = change.Round(2).InexactFloat64()
+		summary.Change24hPct = change.Div(previousTotal).Mul(decimal.NewFromInt(100)).Round(2).InexactFloat64()
+	}
+
+	// Largest holdings first
+	sort.SliceStable(summary.Assets, func(i, j int) bool {
+		return summary.Assets[i].ValueUSD > summary.Assets[j].ValueUSD
+	})
+
+	return summary
+}
+
+func printPortfolioSummary(summary *PortfolioSummary) {
+	fmt.Println("📊 Portfolio Summary")
+	fmt.Printf("🌐 Network: %s\n", strings.ToUpper(summary.Network))
+	fmt.Println()
+
+	fmt.Printf("   %-6s %-10s %22s %14s %9s %8s\n", "Asset", "Chain", "Balance", "Value", "Alloc", "24h")
+	fmt.Printf("   %s\n", strings.Repeat("-", 74))
+
+	for _, asset := range summary.Assets {
+		if asset.Error != "" {
+			fmt.Printf("   %-6s %-10s ❌ Error - %s\n", asset.Symbol, asset.Chain, asset.Error)
+			continue
+		}
+
+		value, allocation, change := "N/A", "N/A", "N/A"
+		if summary.PricesAvailable {
+			value = fmt.Sprintf("$%.2f", asset.ValueUSD)
+			allocation = fmt.Sprintf("%.2f%%", asset.Allocation)
+			change = fmt.Sprintf("%+.2f%%", asset.Change24h)
+		}
+
+		fmt.Printf("   %-6s %-10s %22s %14s %9s %8s\n", asset.Symbol, asset.Chain, asset.Balance, value, allocation, change)
+	}
+
+	fmt.Println()
+	if summary.PricesAvailable {
+		fmt.Printf("💰 Total Value: $%.2f\n", summary.TotalUSD)
+		fmt.Printf("📈 24h Change:  %+.2f USD (%+.2f%%)\n", summary.Change24hUSD, summary.Change24hPct)
+	} else if summary.Network == NetworkTestnet {
+		fmt.Println("ℹ️ USD values are not shown for testnet assets")
+	} else {
+		fmt.Println("⚠️  Prices unavailable, USD values could not be calculated")
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// outgoingValue is what a transaction about to be signed sends out of the
+// wallet
+type outgoingValue struct {
+	chain     string          // chain of the spending limits, e.g. ethereum or polygon
+	recipient string          // address paid or contract called, empty for a swap program
+	amount    decimal.Decimal // native asset sent, in whole coins
+	fee       decimal.Decimal // network fee in whole coins of the native asset, zero if unknown
+	screened  bool            // the recipient was screened before asking to confirm
+}
+
+// checkBeforeSigning runs the checks every command runs before it signs a
+// transaction: the recipient is screened, a fee above the safety limits needs
+// a confirmation and the spending policy is enforced. The amount is counted
+// towards the daily limit once a node accepts the transaction, if the
+// broadcast has SpendChain and SpendAmount set.
+func checkBeforeSigning(ctx context.Context, manager *wallet.Manager, client *api.Client, out outgoingValue) error {
+	chain, whitelistChain, err := parsePolicyChain(out.chain)
+	if err != nil {
+		return err
+	}
+	if out.recipient != "" && !out.screened {
+		if err := screenRecipient(ctx, manager, client, whitelistChain, out.recipient); err != nil {
+			return err
+		}
+	}
+	if out.fee.IsPositive() {
+		if err := checkFeeSanity(ctx, manager, client, policyChainPriceID(chain), policyChainSymbol(chain), out.fee, out.amount); err != nil {
+			return err
+		}
+	}
+	return enforceSpendingPolicy(manager, chain, out.amount, out.recipient)
+}
+
+// addPreSignFlags adds the flags that override the checks of
+// checkBeforeSigning to a command that signs transactions
+func addPreSignFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&payAllowHighFeeFlag, "allow-high-fee", false, "Send even if the fee is above safety.max_fee_percent or safety.max_fee_usd")
+	cmd.Flags().BoolVar(&payAllowFlaggedFlag, "allow-flagged", false, "Send even if the recipient is on the blocklist or a sanctions list")
+}
+
+// refusedBeforeSigning returns true if err is a transaction the checks of
+// checkBeforeSigning refused, or one the user cancelled
+func refusedBeforeSigning(err error) bool {
+	return errors.Is(err, errCancelled) || errors.Is(err, errFeeTooHigh) ||
+		errors.Is(err, errFlaggedRecipient) || errors.Is(err, wallet.ErrPolicyViolation)
+}
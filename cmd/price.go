@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/spf13/cobra"
+)
+
+// sparklineWidth is the number of characters the 7 day chart is drawn with
+const sparklineWidth = 28
+
+var (
+	// sparklineLevels draw the chart from lowest to highest price, plain
+	// output uses ASCII
+	sparklineLevels      = []rune("▁▂▃▄▅▆▇█")
+	sparklinePlainLevels = []rune("_.-~^")
+)
+
+var priceOutputFlag string
+
+var priceCmd = &cobra.Command{
+	Use:   "price [symbol...]",
+	Short: "Show market prices with 24h and 7d changes",
+	Long: `Show the current USD price, the 24 hour and 7 day change, the market cap
+and a chart of the last 7 days of cryptocurrencies.
+
+Symbols are eth, btc, sol and the native assets of the EVM chains (pol, bnb),
+any other symbol is looked up as a CoinGecko id such as chainlink. Without a
+symbol ETH, BTC and SOL are shown.
+
+Examples:
+  odyssey price
+  odyssey price btc
+  odyssey price eth sol chainlink
+  odyssey price btc --output json`,
+	RunE: runPrice,
+}
+
+func init() {
+	priceCmd.Flags().StringVarP(&priceOutputFlag, "output", "o", "text", "Output format (text, json)")
+}
+
+func runPrice(cmd *cobra.Command, args []string) error {
+	output := strings.ToLower(priceOutputFlag)
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format: %s. Use 'text' or 'json'", priceOutputFlag)
+	}
+
+	if len(args) == 0 {
+		args = []string{"eth", "btc", "sol"}
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		id := priceIDForSymbol(arg)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	client := api.NewClient()
+	markets, err := client.GetMarketData(cmd.Context(), ids)
+	if err != nil {
+		return err
+	}
+	result := make([]*api.MarketData, 0, len(ids))
+	for _, id := range ids {
+		market, ok := markets[id]
+		if !ok {
+			return fmt.Errorf("unknown symbol: %s. Use eth, btc, sol, an EVM chain's coin or a CoinGecko id", id)
+		}
+		result = append(result, market)
+	}
+
+	if output == "json" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode prices: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println("📈 Market Prices (USD)")
+	fmt.Println()
+	for _, market := range result {
+		fmt.Printf("%s (%s)\n", market.Name, market.Symbol)
+		fmt.Printf("   Price:      $%s\n", formatMarketPrice(market.PriceUSD))
+		fmt.Printf("   24h:        %s\n", formatChange(market.Change24h))
+		fmt.Printf("   7d:         %s\n", formatChange(market.Change7d))
+		if market.MarketCap > 0 {
+			fmt.Printf("   Market cap: $%s\n", formatCompactUSD(market.MarketCap))
+		}
+		if line := sparkline(market.Sparkline, sparklineWidth); line != "" {
+			fmt.Printf("   7 days:     %s\n", line)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// priceIDForSymbol returns the CoinGecko id of a symbol, chain name or id
+func priceIDForSymbol(symbol string) string {
+	symbol = strings.ToLower(symbol)
+	switch symbol {
+	case "eth", "ethereum":
+		return "ethereum"
+	case "btc", "bitcoin":
+		return "bitcoin"
+	case "sol", "solana":
+		return "solana"
+	}
+	if chain, ok := api.FindEVMChain(symbol); ok {
+		return chain.PriceID
+	}
+	for _, chain := range api.EVMChains {
+		if strings.ToLower(chain.Symbol) == symbol {
+			return chain.PriceID
+		}
+	}
+	return symbol
+}
+
+// formatMarketPrice shows prices below a dollar with more decimals
+func formatMarketPrice(price float64) string {
+	switch {
+	case price >= 1:
+		return fmt.Sprintf("%.2f", price)
+	case price >= 0.01:
+		return fmt.Sprintf("%.4f", price)
+	default:
+		return fmt.Sprintf("%.8f", price)
+	}
+}
+
+// formatChange formats a change in percent with its sign, e.g. "+2.35%"
+func formatChange(percent float64) string {
+	return fmt.Sprintf("%+.2f%%", percent)
+}
+
+// formatCompactUSD shortens large amounts, e.g. 1.23T or 456.78B
+func formatCompactUSD(amount float64) string {
+	switch {
+	case amount >= 1e12:
+		return fmt.Sprintf("%.2fT", amount/1e12)
+	case amount >= 1e9:
+		return fmt.Sprintf("%.2fB", amount/1e9)
+	case amount >= 1e6:
+		return fmt.Sprintf("%.2fM", amount/1e6)
+	default:
+		return fmt.Sprintf("%.0f", amount)
+	}
+}
+
+// sparkline draws prices as a chart of width characters, each the average of
+// its share of the prices scaled between the lowest and highest average
+func sparkline(prices []float64, width int) string {
+	if len(prices) < 2 {
+		return ""
+	}
+	if len(prices) < width {
+		width = len(prices)
+	}
+
+	points := make([]float64, width)
+	for i := range points {
+		start := i * len(prices) / width
+		end := (i + 1) * len(prices) / width
+		sum := 0.0
+		for _, price := range prices[start:end] {
+			sum += price
+		}
+		points[i] = sum / float64(end-start)
+	}
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, point := range points {
+		low = math.Min(low, point)
+		high = math.Max(high, point)
+	}
+
+	levels := sparklineLevels
+	if plainMode {
+		levels = sparklinePlainLevels
+	}
+	var line strings.Builder
+	for _, point := range points {
+		level := 0
+		if high > low {
+			level = int((point - low) / (high - low) * float64(len(levels)-1))
+		}
+		line.WriteRune(levels[level])
+	}
+	return line.String()
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// quietMode is set by -q: decorative output is discarded and only the
+	// essential results of a command are printed, bare
+	quietMode bool
+
+	// resultOut is where results go, the real stdout even in quiet mode
+	resultOut io.Writer = os.Stdout
+)
+
+// setQuiet discards everything printed to stdout except results and moves
+// prompts to stderr, so they stay visible
+func setQuiet(quiet bool) error {
+	quietMode = quiet
+	if !quiet {
+		return nil
+	}
+	discard, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", os.DevNull, err)
+	}
+	resultOut = os.Stdout
+	os.Stdout = discard
+	return nil
+}
+
+// printResult prints an essential result of a command such as a transaction
+// hash or an address. Normally it prints format with args, in quiet mode just
+// result on a line of its own.
+func printResult(result, format string, args ...interface{}) {
+	if quietMode {
+		fmt.Fprintln(resultOut, result)
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
+// jsonOutput returns true if a command prints JSON with --output json
+func jsonOutput(cmd *cobra.Command) bool {
+	output := cmd.Flags().Lookup("output")
+	return output != nil && strings.EqualFold(output.Value.String(), "json")
+}
+
+// promptOut is where questions to the user are printed
+func promptOut() io.Writer {
+	if quietMode {
+		return os.Stderr
+	}
+	return os.Stdout
+}
@@ -0,0 +1,622 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/bitcoin"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rebalanceTargetFlag   string
+	rebalanceSlippageFlag float64
+	rebalanceMinTradeFlag float64
+)
+
+var rebalanceCmd = &cobra.Command{
+	Use:   "rebalance",
+	Short: "Rebalance holdings to a target allocation",
+	Long: `Compute the swaps needed to bring your holdings to a target allocation,
+show the plan with expected output, fees and slippage, and execute it after
+confirmation.
+
+Swaps are non-custodial cross-chain swaps through THORChain, funds are sent from
+your wallet and the output is paid to your own address on the other chain.
+Chains left out of the target are treated as 0%. Rebalancing is mainnet only.
+
+Currently swappable: eth, btc. Solana holdings are counted in the allocation
+but have no swap route yet.
+
+Every deposit goes through the checks of 'odyssey pay' before it is signed:
+the fee safety limits, the spending policy and the screening of the THORChain
+address. A deposit they refuse stops the rebalance, the remaining swaps were
+planned around it.
+
+Examples:
+  odyssey rebalance --target eth:50,btc:50
+  odyssey rebalance --target eth:50,btc:30,sol:20 --slippage 0.5
+  odyssey rebalance --target eth:60,btc:40 --min-trade 50`,
+	Args: cobra.NoArgs,
+	RunE: runRebalance,
+}
+
+func init() {
+	rebalanceCmd.Flags().StringVarP(&rebalanceTargetFlag, "target", "t", "", "Target allocation in percent, e.g. eth:50,btc:30,sol:20")
+	rebalanceCmd.Flags().Float64Var(&rebalanceSlippageFlag, "slippage", 1, "Maximum accepted slippage in percent")
+	rebalanceCmd.Flags().Float64Var(&rebalanceMinTradeFlag, "min-trade", 10, "Skip trades smaller than this USD value")
+	addPreSignFlags(rebalanceCmd)
+	rebalanceCmd.MarkFlagRequired("target")
+}
+
+// rebalanceAsset is the current and target position of a chain's native asset
+type rebalanceAsset struct {
+	chain   string
+	address string
+	balance decimal.Decimal
+	price   decimal.Decimal
+	value   decimal.Decimal
+	target  decimal.Decimal // percent
+	err     error
+}
+
+// rebalanceTrade is a single swap of the rebalancing plan
+type rebalanceTrade struct {
+	from     *rebalanceAsset
+	to       *rebalanceAsset
+	valueUSD decimal.Decimal
+	amountIn decimal.Decimal
+	quote    *api.SwapQuote
+	err      error
+}
+
+func runRebalance(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	if manager.IsTestnet() {
+		return fmt.Errorf("rebalancing is only supported on mainnet")
+	}
+
+	targets, err := parseRebalanceTarget(rebalanceTargetFlag)
+	if err != nil {
+		return err
+	}
+
+	if rebalanceSlippageFlag <= 0 || rebalanceSlippageFlag > 10 {
+		return fmt.Errorf("slippage must be between 0 and 10 percent")
+	}
+
+	fmt.Println("🔄 Loading balances and prices...")
+
+	assets, total, err := loadRebalanceAssets(ctx, manager, client, targets)
+	if err != nil {
+		return err
+	}
+
+	if !total.IsPositive() {
+		return fmt.Errorf("nothing to rebalance, your wallet has no funds")
+	}
+
+	trades := planRebalanceTrades(assets, total, decimal.NewFromFloat(rebalanceMinTradeFlag))
+	quoteRebalanceTrades(ctx, client, trades, int(rebalanceSlippageFlag*100))
+
+	printRebalancePlan(assets, total, trades)
+
+	var executable []*rebalanceTrade
+	for _, trade := range trades {
+		if trade.err == nil {
+			executable = append(executable, trade)
+		}
+	}
+
+	if len(executable) == 0 {
+		fmt.Println("✅ No executable swaps, nothing to do")
+		return nil
+	}
+
+	if err := confirmSigning(manager, "❌ Rebalance cancelled by user"); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	failed := 0
+	for _, trade := range executable {
+		fromSymbol, _ := nativeAssetFormat(trade.from.chain)
+		toSymbol, _ := nativeAssetFormat(trade.to.chain)
+		fmt.Printf("🔁 Swapping %s %s → %s\n", trade.amountIn.String(), fromSymbol, toSymbol)
+
+		txHash, err := sendSwapDeposit(ctx, manager, client, trade.quote)
+		if err != nil {
+			fmt.Printf("   ❌ Error - %v\n", err)
+			if refusedBeforeSigning(err) {
+				return err
+			}
+			failed++
+			continue
+		}
+
+		printResult(txHash, "   📝 Transaction Hash: %s\n", txHash)
+		if link := explorerURL(trade.from.chain, "tx", txHash); link != "" {
+			fmt.Printf("   🔗 Explorer: %s\n", link)
+		}
+		fmt.Printf("   🔍 Swap status: https://track.ninerealms.com/%s\n", strings.TrimPrefix(txHash, "0x"))
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d of %d swaps failed", failed, len(executable))
+	}
+
+	fmt.Println("✅ Rebalance submitted. Swaps usually complete within a few blocks of the source chain")
+	return nil
+}
+
+// parseRebalanceTarget parses a target like "eth:50,btc:30,sol:20" into
+// percentages per chain
+func parseRebalanceTarget(target string) (map[string]decimal.Decimal, error) {
+	targets := make(map[string]decimal.Decimal)
+	sum := decimal.Zero
+
+	for _, part := range strings.Split(target, ",") {
+		pieces := strings.Split(strings.TrimSpace(part), ":")
+		if len(pieces) != 2 {
+			return nil, fmt.Errorf("invalid target '%s'. Use chain:percent, e.g. eth:50,btc:30,sol:20", part)
+		}
+
+		chain, err := parseWatchChain(strings.TrimSpace(pieces[0]))
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := targets[chain]; exists {
+			return nil, fmt.Errorf("duplicate target for %s", chain)
+		}
+
+		percent, err := decimal.NewFromString(strings.TrimSpace(strings.TrimSuffix(pieces[1], "%")))
+		if err != nil || percent.IsNegative() {
+			return nil, fmt.Errorf("invalid percentage for %s: %s", chain, pieces[1])
+		}
+
+		targets[chain] = percent
+		sum = sum.Add(percent)
+	}
+
+	if !sum.Equal(decimal.NewFromInt(100)) {
+		return nil, fmt.Errorf("target allocation must add up to 100%%, got %s%%", sum.String())
+	}
+
+	return targets, nil
+}
+
+// loadRebalanceAssets fetches balances and prices of every chain concurrently
+func loadRebalanceAssets(ctx context.Context, manager *wallet.Manager, client *api.Client, targets map[string]decimal.Decimal) ([]*rebalanceAsset, decimal.Decimal, error) {
+	ethAddress, err := manager.GetEthereumAddress()
+	if err != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+	btcAddresses, err := bitcoinWalletAddresses(manager)
+	if err != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to get Bitcoin address: %w", err)
+	}
+	solAddress, err := manager.GetSolanaAddress()
+	if err != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to get Solana address: %w", err)
+	}
+
+	assets := []*rebalanceAsset{
+		{chain: "ethereum", address: ethAddress.Hex(), target: targets["ethereum"]},
+		{chain: "bitcoin", address: btcAddresses[0], target: targets["bitcoin"]},
+		{chain: "solana", address: solAddress.String(), target: targets["solana"]},
+	}
+
+	var wg sync.WaitGroup
+	for _, asset := range assets {
+		wg.Add(1)
+		go func(asset *rebalanceAsset) {
+			defer wg.Done()
+			if asset.chain == "bitcoin" {
+				asset.balance, asset.err = fetchBitcoinWalletBalance(ctx, client, btcAddresses)
+				return
+			}
+			asset.balance, asset.err = fetchNativeBalance(ctx, client, asset.chain, asset.address)
+		}(asset)
+	}
+
+	prices, priceErr := client.GetPrices(ctx, []string{"ethereum", "bitcoin", "solana"})
+	wg.Wait()
+
+	if priceErr != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to get prices: %w", priceErr)
+	}
+
+	total := decimal.Zero
+	for _, asset := range assets {
+		// A plan built on a missing balance would sell or buy the wrong amounts
+		if asset.err != nil {
+			return nil, decimal.Zero, fmt.Errorf("failed to get %s balance: %w", asset.chain, asset.err)
+		}
+		price, ok := prices[asset.chain]
+		if !ok {
+			return nil, decimal.Zero, fmt.Errorf("price not found for %s", asset.chain)
+		}
+		asset.price = price.USD
+		asset.value = asset.balance.Mul(asset.price)
+		total = total.Add(asset.value)
+	}
+
+	return assets, total, nil
+}
+
+// planRebalanceTrades matches assets above their target with assets below it,
+// largest differences first, and returns the swaps that close the gaps
+func planRebalanceTrades(assets []*rebalanceAsset, total, minTrade decimal.Decimal) []*rebalanceTrade {
+	type position struct {
+		asset  *rebalanceAsset
+		amount decimal.Decimal // USD
+	}
+
+	var sellers, buyers []*position
+	for _, asset := range assets {
+		delta := asset.target.Div(decimal.NewFromInt(100)).Mul(total).Sub(asset.value)
+		if delta.IsNegative() {
+			sellers = append(sellers, &position{asset: asset, amount: delta.Neg()})
+		} else if delta.IsPositive() {
+			buyers = append(buyers, &position{asset: asset, amount: delta})
+		}
+	}
+
+	sort.Slice(sellers, func(i, j int) bool { return sellers[i].amount.GreaterThan(sellers[j].amount) })
+	sort.Slice(buyers, func(i, j int) bool { return buyers[i].amount.GreaterThan(buyers[j].amount) })
+
+	var trades []*rebalanceTrade
+	for i, j := 0, 0; i < len(sellers) && j < len(buyers); {
+		seller, buyer := sellers[i], buyers[j]
+		amount := decimal.Min(seller.amount, buyer.amount)
+
+		if amount.GreaterThanOrEqual(minTrade) {
+			trades = append(trades, &rebalanceTrade{
+				from:     seller.asset,
+				to:       buyer.asset,
+				valueUSD: amount,
+				amountIn: amount.Div(seller.asset.price).Truncate(api.ThorchainDecimals),
+			})
+		}
+
+		seller.amount = seller.amount.Sub(amount)
+		buyer.amount = buyer.amount.Sub(amount)
+		if !seller.amount.IsPositive() {
+			i++
+		}
+		if !buyer.amount.IsPositive() {
+			j++
+		}
+	}
+
+	return trades
+}
+
+// quoteRebalanceTrades fetches a swap quote for every trade concurrently
+func quoteRebalanceTrades(ctx context.Context, client *api.Client, trades []*rebalanceTrade, toleranceBps int) {
+	var wg sync.WaitGroup
+	for _, trade := range trades {
+		if !client.SupportsSwap(trade.from.chain, trade.to.chain) {
+			trade.err = fmt.Errorf("no swap route from %s to %s", trade.from.chain, trade.to.chain)
+			continue
+		}
+
+		wg.Add(1)
+		go func(trade *rebalanceTrade) {
+			defer wg.Done()
+			trade.quote, trade.err = client.GetSwapQuote(ctx, trade.from.chain, trade.to.chain, trade.amountIn, trade.to.address, toleranceBps)
+			if trade.err == nil && trade.amountIn.LessThan(trade.quote.RecommendedMinIn) {
+				fromSymbol, _ := nativeAssetFormat(trade.from.chain)
+				trade.err = fmt.Errorf("amount is below the minimum of %s %s", trade.quote.RecommendedMinIn.String(), fromSymbol)
+			}
+		}(trade)
+	}
+	wg.Wait()
+}
+
+func printRebalancePlan(assets []*rebalanceAsset, total decimal.Decimal, trades []*rebalanceTrade) {
+	fmt.Println()
+	fmt.Println("⚖️  Rebalance Plan")
+	fmt.Println()
+
+	fmt.Printf("   %-9s %20s %14s %9s %9s\n", "Chain", "Balance", "Value", "Current", "Target")
+	fmt.Printf("   %s\n", strings.Repeat("-", 65))
+	for _, asset := range assets {
+		symbol, places := nativeAssetFormat(asset.chain)
+		current := asset.value.Div(total).Mul(decimal.NewFromInt(100))
+		fmt.Printf("   %-9s %20s %14s %8s%% %8s%%\n", asset.chain,
+			asset.balance.StringFixed(places)+" "+symbol,
+			formatUSD(asset.value),
+			current.StringFixed(2), asset.target.StringFixed(2))
+	}
+	fmt.Printf("   %-9s %20s %14s\n", "Total", "", formatUSD(total))
+	fmt.Println()
+
+	if len(trades) == 0 {
+		fmt.Println("✅ Holdings are already within the target allocation")
+		fmt.Println()
+		return
+	}
+
+	prices := make(map[string]decimal.Decimal)
+	for _, asset := range assets {
+		prices[asset.chain] = asset.price
+	}
+
+	fmt.Println("📋 Swaps:")
+	for i, trade := range trades {
+		fromSymbol, _ := nativeAssetFormat(trade.from.chain)
+		toSymbol, _ := nativeAssetFormat(trade.to.chain)
+		fmt.Printf("   %d. %s %s → %s (~$%s)\n", i+1, trade.amountIn.String(), fromSymbol, toSymbol, trade.valueUSD.StringFixed(2))
+
+		if trade.err != nil {
+			fmt.Printf("      ❌ Skipped - %v\n", trade.err)
+			continue
+		}
+
+		quote := trade.quote
+		fee := quote.Fees.Total.Mul(prices[trade.to.chain])
+		fmt.Printf("      Expected: %s %s\n", quote.ExpectedAmountOut.String(), toSymbol)
+		fmt.Printf("      Fees:     %s %s (~$%s, %.2f%%)\n", quote.Fees.Total.String(), toSymbol, fee.StringFixed(2), float64(quote.Fees.TotalBps)/100)
+		fmt.Printf("      Slippage: %.2f%%\n", float64(quote.Fees.SlippageBps)/100)
+		if quote.EstimatedSeconds > 0 {
+			fmt.Printf("      Time:     ~%s\n", (time.Duration(quote.EstimatedSeconds) * time.Second).String())
+		}
+		fmt.Printf("      Network fee of the %s deposit is added when sending\n", trade.from.chain)
+	}
+	fmt.Println()
+}
+
+// sendSwapDeposit sends the deposit that executes a swap quote and returns the
+// transaction hash
+func sendSwapDeposit(ctx context.Context, manager *wallet.Manager, client *api.Client, quote *api.SwapQuote) (string, error) {
+	switch quote.FromChain {
+	case "ethereum":
+		return sendEthereumSwapDeposit(ctx, manager, client, quote)
+	case "bitcoin":
+		return sendBitcoinSwapDeposit(ctx, manager, client, quote)
+	default:
+		return "", fmt.Errorf("unsupported swap source: %s", quote.FromChain)
+	}
+}
+
+// sendEthereumSwapDeposit deposits ETH through the THORChain router
+func sendEthereumSwapDeposit(ctx context.Context, manager *wallet.Manager, client *api.Client, quote *api.SwapQuote) (string, error) {
+	if quote.Router == "" {
+		return "", fmt.Errorf("swap quote has no router address")
+	}
+	router, err := ethereum.ParseAddress(quote.Router)
+	if err != nil {
+		return "", err
+	}
+	vault, err := ethereum.ParseAddress(quote.InboundAddress)
+	if err != nil {
+		return "", err
+	}
+
+	senderAddress, err := manager.GetEthereumAddress()
+	if err != nil {
+		return "", fmt.Errorf("failed to get sender address: %w", err)
+	}
+
+	value := quote.AmountIn.Shift(18).BigInt()
+	data, err := ethereum.EncodeRouterDeposit(vault, value, quote.Memo, quote.Expiry)
+	if err != nil {
+		return "", err
+	}
+
+	nonce, err := nextEthereumNonce(ctx, manager, client, "ethereum", senderAddress.Hex())
+	if err != nil {
+		return "", err
+	}
+
+	gasPrice, err := client.GetEthereumGasPrice(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	// Add 20% to gas price to ensure faster inclusion
+	gasPrice.Mul(gasPrice, big.NewInt(120))
+	gasPrice.Div(gasPrice, big.NewInt(100))
+
+	gasLimit, err := client.GetEthereumGasEstimate(ctx, senderAddress.Hex(), router.Hex(), value, data)
+	if err != nil {
+		// Router deposits use well below this
+		gasLimit = 120000
+	}
+
+	balance, err := client.GetEthereumBalance(ctx, senderAddress.Hex())
+	if err != nil {
+		return "", fmt.Errorf("failed to check balance: %w", err)
+	}
+
+	maxFee := new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))
+	if balance.Cmp(new(big.Int).Add(value, maxFee)) < 0 {
+		return "", fmt.Errorf("%w for swap with gas. Depositing %s ETH needs about %s ETH in gas but your balance is only %s ETH", api.ErrInsufficientFunds,
+			ethereum.WeiToEther(value).StringFixed(6), ethereum.WeiToEther(maxFee).StringFixed(6), ethereum.WeiToEther(balance).StringFixed(6))
+	}
+
+	tx := ethereum.NewTransaction(nonce, router, value, gasLimit, gasPrice, data)
+	if err := ethereum.ValidateTransaction(tx); err != nil {
+		return "", fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	deposit := ethereum.WeiToEther(value)
+	err = checkBeforeSigning(ctx, manager, client, outgoingValue{
+		chain:     "ethereum",
+		recipient: router.Hex(),
+		amount:    deposit,
+		fee:       ethereum.WeiToEther(maxFee),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	privateKey, err := manager.GetEthereumKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	signedTx, err := ethereum.SignTransaction(tx, privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	txID, err := ethereum.TransactionHash(signedTx)
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+		ID:          txID,
+		Chain:       "ethereum",
+		Network:     manager.GetCurrentNetwork(),
+		SignedTx:    signedTx,
+		From:        senderAddress.Hex(),
+		To:          router.Hex(),
+		Amount:      quote.AmountIn.String() + " ETH",
+		Nonce:       nonce,
+		SpendChain:  "ethereum",
+		SpendAmount: deposit,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	return txHash, nil
+}
+
+// sendBitcoinSwapDeposit sends BTC to the THORChain vault with the memo in an
+// OP_RETURN output
+func sendBitcoinSwapDeposit(ctx context.Context, manager *wallet.Manager, client *api.Client, quote *api.SwapQuote) (string, error) {
+	// OP_RETURN outputs are limited to 80 bytes
+	memo := []byte(quote.Memo)
+	if len(memo) > 80 {
+		return "", fmt.Errorf("swap memo is too long for a Bitcoin transaction (%d bytes)", len(memo))
+	}
+
+	vault, err := bitcoin.ParseAddress(quote.InboundAddress)
+	if err != nil {
+		return "", fmt.Errorf("invalid vault address: %w", err)
+	}
+
+	senderAddress, err := manager.GetBitcoinAddress()
+	if err != nil {
+		return "", fmt.Errorf("failed to get sender address: %w", err)
+	}
+
+	value := quote.AmountIn.Shift(8).IntPart()
+
+	chain := bitcoin.NewChain(manager, client)
+	utxos, keys, err := chain.UTXOs(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	feeRate, err := client.GetBitcoinFeeEstimate(ctx)
+	if err != nil {
+		// Default to 10 sat/vB if estimation fails
+		feeRate = 10
+	}
+
+	tx := bitcoin.NewTransaction()
+	if err := tx.AddOutput(value, vault); err != nil {
+		return "", fmt.Errorf("failed to add output: %w", err)
+	}
+	if err := tx.AddDataOutput(memo); err != nil {
+		return "", fmt.Errorf("failed to add memo output: %w", err)
+	}
+
+	// Dust change is left to the miners, the rest goes to a fresh address on
+	// the internal chain
+	changeAccount, err := manager.NextBitcoinChangeAccount()
+	if err != nil {
+		return "", fmt.Errorf("failed to derive change address: %w", err)
+	}
+
+	utxos = bitcoin.SelectCoins(utxos, tx, feeRate, changeAccount.Address, chain.CoinSelectionStrategy())
+	totalInput := int64(0)
+	for _, utxo := range utxos {
+		totalInput += utxo.Value
+		if err := tx.AddInput(utxo, nil, senderAddress); err != nil {
+			return "", fmt.Errorf("failed to add input: %w", err)
+		}
+	}
+	fee, change, _, err := tx.SettleFee(utxos, totalInput, value, feeRate, changeAccount.Address)
+	if err != nil {
+		return "", err
+	}
+	if totalInput < value+fee {
+		return "", fmt.Errorf("%w for swap with fees. Depositing %s BTC needs about %s BTC in fees but your balance is only %s BTC", api.ErrInsufficientFunds,
+			bitcoin.SatoshisToBTC(value).StringFixed(8), bitcoin.SatoshisToBTC(fee).StringFixed(8), bitcoin.SatoshisToBTC(totalInput).StringFixed(8))
+	}
+	if change == 0 {
+		changeAccount = nil
+	}
+
+	deposit := bitcoin.SatoshisToBTC(value)
+	err = checkBeforeSigning(ctx, manager, client, outgoingValue{
+		chain:     "bitcoin",
+		recipient: vault.String(),
+		amount:    deposit,
+		fee:       bitcoin.SatoshisToBTC(fee),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.SignInputs(utxos, keys); err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	signedTx, err := tx.Serialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize transaction: %w", err)
+	}
+
+	txID, err := bitcoin.TransactionID(signedTx)
+	if err != nil {
+		return "", err
+	}
+
+	if changeAccount != nil {
+		if err := manager.RecordBitcoinChangeAccount(changeAccount); err != nil {
+			return "", err
+		}
+	}
+
+	txHash, err := broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+		ID:          txID,
+		Chain:       "bitcoin",
+		Network:     manager.GetCurrentNetwork(),
+		SignedTx:    signedTx,
+		From:        senderAddress.String(),
+		To:          vault.String(),
+		Amount:      quote.AmountIn.String() + " BTC",
+		SpendChain:  "bitcoin",
+		SpendAmount: deposit,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	return txHash, nil
+}
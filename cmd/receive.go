@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/skip2/go-qrcode"
+	"github.com/spf13/cobra"
+)
+
+var (
+	receiveLabelFlag    string
+	receiveMessageFlag  string
+	receiveNoQRFlag     bool
+	receiveWaitFlag     bool
+	receiveIntervalFlag time.Duration
+	receiveTimeoutFlag  time.Duration
+)
+
+var receiveCmd = &cobra.Command{
+	Use:   "receive [chain] [amount]",
+	Short: "Create a payment request to receive funds",
+	Long: `Print a payment request URI and QR code for your address, to share with
+whoever pays you. Wallets that scan or open it fill in the recipient and
+amount by themselves.
+
+Bitcoin requests are BIP-21 URIs, Ethereum and EVM chain requests EIP-681
+URIs with the chain ID of the current network, and Solana requests Solana
+Pay transfer requests. Without an amount the payer picks one.
+
+With --wait the address is watched until a confirmed incoming payment of at
+least the amount arrives (eth, btc and sol only).
+
+Supported chains: eth, btc, sol, polygon, arbitrum, optimism, base, bsc
+
+Examples:
+  odyssey receive btc 0.01
+  odyssey receive sol 1.5 --label "Alice" --message "Dinner"
+  odyssey receive base
+  odyssey receive eth 0.05 --wait --timeout 1h
+  odyssey -q receive btc 0.01   # Print the URI only`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runReceive,
+}
+
+func init() {
+	receiveCmd.Flags().StringVar(&receiveLabelFlag, "label", "", "Name of the recipient shown to the payer (btc, sol)")
+	receiveCmd.Flags().StringVar(&receiveMessageFlag, "message", "", "Description of the payment shown to the payer (btc, sol)")
+	receiveCmd.Flags().BoolVar(&receiveNoQRFlag, "no-qr", false, "Don't print the QR code")
+	receiveCmd.Flags().BoolVar(&receiveWaitFlag, "wait", false, "Wait until the payment is received and confirmed")
+	receiveCmd.Flags().DurationVar(&receiveIntervalFlag, "interval", 15*time.Second, "Time between checks with --wait")
+	receiveCmd.Flags().DurationVar(&receiveTimeoutFlag, "timeout", 0, "Stop waiting after this long (0 waits until Ctrl+C)")
+}
+
+func runReceive(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	var amount decimal.Decimal
+	if len(args) == 2 {
+		var err error
+		amount, err = decimal.NewFromString(args[1])
+		if err != nil || !amount.IsPositive() {
+			return fmt.Errorf("invalid amount: %s", args[1])
+		}
+	}
+	if receiveWaitFlag && receiveIntervalFlag < minWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+	}
+	if receiveTimeoutFlag < 0 {
+		return fmt.Errorf("--timeout must not be negative")
+	}
+
+	request, err := buildPaymentRequest(manager, strings.ToLower(args[0]), amount)
+	if err != nil {
+		return err
+	}
+	if receiveWaitFlag && request.watchChain == "" {
+		return fmt.Errorf("--wait is only supported for eth, btc and sol")
+	}
+
+	fmt.Printf("📥 Payment Request (%s)\n", request.label)
+	fmt.Printf("   Address: %s\n", request.recipient)
+	if !amount.IsZero() {
+		fmt.Printf("   Amount:  %s %s\n", amount.String(), request.symbol)
+	}
+	printResult(request.uri, "   URI:     %s\n", request.uri)
+	fmt.Println()
+
+	if !receiveNoQRFlag && !quietMode {
+		code, err := qrcode.New(request.uri, qrcode.Medium)
+		if err != nil {
+			return fmt.Errorf("failed to create QR code: %w", err)
+		}
+		fmt.Print(code.ToSmallString(false))
+		fmt.Println()
+	}
+
+	if !receiveWaitFlag {
+		return nil
+	}
+	return waitForPayment(cmd.Context(), manager, request, amount)
+}
+
+// receiveRequest is a payment request for one of the wallet's addresses
+type receiveRequest struct {
+	label      string
+	symbol     string
+	recipient  string
+	uri        string
+	watchChain string // chain polled with --wait, empty if unsupported
+}
+
+// buildPaymentRequest builds the URI asking for amount, zero for any amount,
+// on the current network
+func buildPaymentRequest(manager *wallet.Manager, chain string, amount decimal.Decimal) (*receiveRequest, error) {
+	query := url.Values{}
+	if receiveLabelFlag != "" {
+		query.Set("label", receiveLabelFlag)
+	}
+	if receiveMessageFlag != "" {
+		query.Set("message", receiveMessageFlag)
+	}
+	if !amount.IsZero() {
+		query.Set("amount", amount.String())
+	}
+
+	switch chain {
+	case "btc", "bitcoin":
+		if !manager.BitcoinSupported() {
+			return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
+		}
+		if amount.Exponent() < -8 {
+			return nil, fmt.Errorf("bitcoin amounts have at most 8 decimals")
+		}
+		address, err := manager.GetBitcoinAddress()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Bitcoin address: %w", err)
+		}
+		return &receiveRequest{
+			label:      "Bitcoin",
+			symbol:     "BTC",
+			recipient:  address.String(),
+			uri:        paymentURI("bitcoin", address.String(), query),
+			watchChain: "bitcoin",
+		}, nil
+	case "sol", "solana":
+		if amount.Exponent() < -9 {
+			return nil, fmt.Errorf("solana amounts have at most 9 decimals")
+		}
+		address, err := manager.GetSolanaAddress()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Solana address: %w", err)
+		}
+		return &receiveRequest{
+			label:      "Solana",
+			symbol:     "SOL",
+			recipient:  address.String(),
+			uri:        paymentURI("solana", address.String(), query),
+			watchChain: "solana",
+		}, nil
+	}
+
+	// EIP-681 has no label or message and wants the value in wei
+	request := &receiveRequest{label: "Ethereum", symbol: "ETH", watchChain: "ethereum"}
+	chainID := ethereum.GetChainID().Int64()
+	if chain != "eth" && chain != "ethereum" {
+		evmChain, ok := api.FindEVMChain(chain)
+		if !ok {
+			return nil, fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol, %s", chain, strings.Join(api.EVMChainNames(), ", "))
+		}
+		request = &receiveRequest{label: evmChain.Label(manager.IsTestnet()), symbol: evmChain.Symbol}
+		chainID = evmChain.ChainID(manager.IsTestnet()).Int64()
+	}
+	if receiveLabelFlag != "" || receiveMessageFlag != "" {
+		fmt.Println("💡 --label and --message aren't part of EVM payment requests and are left out")
+	}
+
+	address, err := manager.GetEthereumAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+	request.recipient = address.Hex()
+
+	query = url.Values{}
+	if !amount.IsZero() {
+		wei := amount.Shift(18)
+		if !wei.IsInteger() {
+			return nil, fmt.Errorf("%s amounts have at most 18 decimals", request.symbol)
+		}
+		query.Set("value", wei.String())
+	}
+	request.uri = paymentURI("ethereum", fmt.Sprintf("%s@%d", request.recipient, chainID), query)
+	return request, nil
+}
+
+// paymentURI joins a scheme, target and parameters. Spaces are encoded as
+// %20, which every wallet decodes, unlike +.
+func paymentURI(scheme, target string, query url.Values) string {
+	uri := scheme + ":" + target
+	if len(query) > 0 {
+		uri += "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+	}
+	return uri
+}
+
+// waitForPayment polls the address of a request until a new, confirmed
+// incoming transaction of at least amount shows up
+func waitForPayment(ctx context.Context, manager *wallet.Manager, request *receiveRequest, amount decimal.Decimal) error {
+	client := api.NewClient()
+	watched, err := watchedAddresses(manager, client, []string{request.watchChain})
+	if err != nil {
+		return err
+	}
+	if receiveTimeoutFlag > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, receiveTimeoutFlag)
+		defer cancel()
+	}
+
+	// Only the address in the request counts, Bitcoin has several
+	var w watchedAddress
+	for _, candidate := range watched {
+		if candidate.address == request.recipient {
+			w = candidate
+		}
+	}
+	if w.fetch == nil {
+		return fmt.Errorf("failed to watch %s", request.recipient)
+	}
+
+	// Payments that were confirmed before waiting started don't count
+	page, err := w.fetch(ctx, watchPageSize)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s transactions: %w", w.chain, err)
+	}
+	before := make(map[string]bool)
+	for _, tx := range page.Transactions {
+		before[tx.Hash] = tx.BlockNumber > 0
+	}
+
+	fmt.Printf("⏳ Waiting for the payment, checking every %s (Ctrl+C to stop)\n", receiveIntervalFlag)
+	pending := make(map[string]bool)
+	ticker := time.NewTicker(receiveIntervalFlag)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("no matching payment was received within %s", receiveTimeoutFlag)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		page, err := w.fetch(ctx, watchPageSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				fmt.Printf("⚠️  %s: %v, retrying\n", w.chain, err)
+			}
+			continue
+		}
+		for _, tx := range page.Transactions {
+			if !tx.IsIncoming || before[tx.Hash] || !paymentCovers(tx.Amount, amount) {
+				continue
+			}
+			if tx.BlockNumber == 0 {
+				if !pending[tx.Hash] {
+					pending[tx.Hash] = true
+					fmt.Printf("[%s] %s ⬅️ IN %s from %s, pending\n", time.Now().Format("15:04:05"), w.emoji, tx.Amount, displayAddress(tx.From))
+				}
+				continue
+			}
+
+			recordActivity(manager, w.chain, []api.Transaction{tx})
+			fmt.Printf("[%s] %s ✅ Received %s from %s in block %d\n", time.Now().Format("15:04:05"), w.emoji, tx.Amount, displayAddress(tx.From), tx.BlockNumber)
+			printResult(tx.Hash, "           Hash: %s\n", tx.Hash)
+			notifyUser("Odyssey: payment received", fmt.Sprintf("%s from %s", tx.Amount, displayAddress(tx.From)))
+			return nil
+		}
+	}
+}
+
+// paymentCovers returns true if a transaction amount such as "0.010000 ETH"
+// is at least the requested amount, compared at the precision it is shown
+// with. Any amount covers a request without one.
+func paymentCovers(txAmount string, requested decimal.Decimal) bool {
+	if requested.IsZero() {
+		return true
+	}
+	fields := strings.Fields(txAmount)
+	if len(fields) == 0 {
+		return false
+	}
+	received, err := decimal.NewFromString(fields[0])
+	if err != nil {
+		return false
+	}
+	return received.GreaterThanOrEqual(requested.Truncate(-received.Exponent()))
+}
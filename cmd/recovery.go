@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -11,18 +12,38 @@ import (
 	"golang.org/x/term"
 )
 
+var (
+	sharesFlag    int
+	thresholdFlag int
+)
+
 var recoveryPhraseCmd = &cobra.Command{
-	Use:   "recovery-phrase [show|import]",
+	Use:   "recovery-phrase [show|import|split|combine]",
 	Short: "Manage recovery phrase",
 	Long: `Manage your wallet's recovery phrase (mnemonic).
 	
 Commands:
   show    - Display the recovery phrase (requires password)
-  import  - Import wallet from existing recovery phrase`,
+  import  - Import wallet from existing recovery phrase
+  split   - Split the recovery phrase into shares (requires password)
+  combine - Recover the phrase from shares and import the wallet
+
+Split creates Shamir secret shares written with the same word list as the
+recovery phrase. Any --threshold of the --shares recover the phrase, fewer
+reveal nothing about it. Store each share in a different place.
+
+Examples:
+  odyssey recovery-phrase split --shares 5 --threshold 3
+  odyssey recovery-phrase combine`,
 	Args: cobra.ExactArgs(1),
 	RunE: runRecoveryPhrase,
 }
 
+func init() {
+	recoveryPhraseCmd.Flags().IntVar(&sharesFlag, "shares", 5, "Number of shares to create (split)")
+	recoveryPhraseCmd.Flags().IntVar(&thresholdFlag, "threshold", 3, "Shares needed to recover the phrase (split)")
+}
+
 func runRecoveryPhrase(cmd *cobra.Command, args []string) error {
 	manager := wallet.NewManager()
 	action := strings.ToLower(args[0])
@@ -31,42 +52,33 @@ func runRecoveryPhrase(cmd *cobra.Command, args []string) error {
 	case "show":
 		return showRecoveryPhrase(manager)
 	case "import":
-		return importRecoveryPhrase(manager)
+		return importRecoveryPhrase(cmd.Context(), manager)
+	case "split":
+		return splitRecoveryPhrase(manager)
+	case "combine":
+		return combineRecoveryPhrase(cmd.Context(), manager)
 	default:
-		return fmt.Errorf("invalid action: %s. Use 'show' or 'import'", action)
+		return fmt.Errorf("invalid action: %s. Use 'show', 'import', 'split' or 'combine'", action)
 	}
 }
 
 func showRecoveryPhrase(manager *wallet.Manager) error {
-	// Check if wallet exists
-	if !manager.VaultExists() {
-		return fmt.Errorf("no wallet found. Run 'odyssey init' first")
-	}
-
-	// Get password from user
-	fmt.Print("Enter your wallet password: ")
-	password, err := term.ReadPassword(int(os.Stdin.Fd()))
-	if err != nil {
-		return fmt.Errorf("failed to read password: %w", err)
-	}
-	fmt.Println()
-
-	// Unlock wallet to get mnemonic
-	err = manager.Unlock(string(password))
-	if err != nil {
-		return fmt.Errorf("failed to unlock wallet: %w", err)
+	if err := unlockRecoveryPhrase(manager); err != nil {
+		return err
 	}
 
-	// Get mnemonic
-	mnemonic, err := manager.GetMnemonic()
+	err := manager.WithMnemonic(func(mnemonic []byte) error {
+		fmt.Println("🔐 Recovery Phrase:")
+		fmt.Println()
+		fmt.Printf("   %s\n", mnemonic)
+		fmt.Println()
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get mnemonic: %w", err)
 	}
+	recordAudit(manager, wallet.AuditEntry{Event: wallet.AuditRecoveryPhraseShow, Result: wallet.AuditOK})
 
-	fmt.Println("🔐 Recovery Phrase:")
-	fmt.Println()
-	fmt.Printf("   %s\n", mnemonic)
-	fmt.Println()
 	fmt.Println("⚠️  Security Warning:")
 	fmt.Println("   - Keep this phrase secure and private")
 	fmt.Println("   - Anyone with this phrase can access your funds")
@@ -76,7 +88,7 @@ func showRecoveryPhrase(manager *wallet.Manager) error {
 	return nil
 }
 
-func importRecoveryPhrase(manager *wallet.Manager) error {
+func importRecoveryPhrase(ctx context.Context, manager *wallet.Manager) error {
 	// Check if wallet already exists
 	if manager.VaultExists() {
 		return fmt.Errorf("wallet already exists. Remove existing wallet first")
@@ -86,7 +98,7 @@ func importRecoveryPhrase(manager *wallet.Manager) error {
 	fmt.Println()
 
 	// Get mnemonic from user
-	fmt.Print("Enter recovery phrase (24 words): ")
+	fmt.Fprint(promptOut(), "Enter recovery phrase (24 words): ")
 	reader := bufio.NewReader(os.Stdin)
 	mnemonic, err := reader.ReadString('\n')
 	if err != nil {
@@ -100,21 +112,27 @@ func importRecoveryPhrase(manager *wallet.Manager) error {
 		return fmt.Errorf("invalid mnemonic. Must be 24 words")
 	}
 
+	return importMnemonic(ctx, manager, mnemonic)
+}
+
+// importMnemonic asks for a new password and creates the wallet from a
+// mnemonic, then offers to discover addresses it used in other wallets
+func importMnemonic(ctx context.Context, manager *wallet.Manager, mnemonic string) error {
 	// Get password
-	fmt.Print("Enter password for new wallet: ")
+	fmt.Fprint(promptOut(), "Enter password for new wallet: ")
 	password, err := term.ReadPassword(int(os.Stdin.Fd()))
 	if err != nil {
 		return fmt.Errorf("failed to read password: %w", err)
 	}
-	fmt.Println()
+	fmt.Fprintln(promptOut())
 
 	// Confirm password
-	fmt.Print("Confirm password: ")
+	fmt.Fprint(promptOut(), "Confirm password: ")
 	confirmPassword, err := term.ReadPassword(int(os.Stdin.Fd()))
 	if err != nil {
 		return fmt.Errorf("failed to read password confirmation: %w", err)
 	}
-	fmt.Println()
+	fmt.Fprintln(promptOut())
 
 	if string(password) != string(confirmPassword) {
 		return fmt.Errorf("passwords do not match")
@@ -132,9 +150,130 @@ func importRecoveryPhrase(manager *wallet.Manager) error {
 	fmt.Println("   - Run 'odyssey unlock' to unlock the wallet")
 	fmt.Println("   - Run 'odyssey address' to see your addresses")
 
+	return offerDiscovery(ctx, manager)
+}
+
+// unlockRecoveryPhrase asks for the wallet password and the 2FA code before
+// the mnemonic is read with manager.WithMnemonic
+func unlockRecoveryPhrase(manager *wallet.Manager) error {
+	// Check if wallet exists
+	if !manager.VaultExists() {
+		return fmt.Errorf("no wallet found. Run 'odyssey init' first")
+	}
+
+	// Get password from user
+	fmt.Fprint(promptOut(), "Enter your wallet password: ")
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	fmt.Fprintln(promptOut())
+
+	// Unlock wallet to get mnemonic
+	err = manager.Unlock(string(password))
+	if err != nil {
+		return fmt.Errorf("failed to unlock wallet: %w", err)
+	}
+	return requireTwoFactor(manager)
+}
+
+func splitRecoveryPhrase(manager *wallet.Manager) error {
+	if thresholdFlag < 2 || thresholdFlag > sharesFlag {
+		return fmt.Errorf("threshold must be at least 2 and at most the number of shares")
+	}
+
+	if err := unlockRecoveryPhrase(manager); err != nil {
+		return err
+	}
+
+	var shares []string
+	err := manager.WithMnemonic(func(mnemonic []byte) error {
+		var err error
+		shares, err = wallet.SplitRecoveryPhrase(mnemonic, sharesFlag, thresholdFlag)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to split recovery phrase: %w", err)
+	}
+	recordAudit(manager, wallet.AuditEntry{
+		Event:  wallet.AuditKeyExport,
+		Result: wallet.AuditOK,
+		Detail: fmt.Sprintf("recovery phrase split into %d shares, %d needed", sharesFlag, thresholdFlag),
+	})
+
+	fmt.Printf("🧩 Recovery Phrase Shares (%d of %d needed)\n", thresholdFlag, sharesFlag)
+	fmt.Println()
+	for i, share := range shares {
+		fmt.Printf("Share %d:\n", i+1)
+		fmt.Printf("   %s\n", share)
+		fmt.Println()
+	}
+	fmt.Println("⚠️  Security Warning:")
+	fmt.Println("   - Store each share in a different, safe place")
+	fmt.Printf("   - Any %d shares give full access to your funds\n", thresholdFlag)
+	fmt.Println("   - Recover with 'odyssey recovery-phrase combine'")
+
 	return nil
 }
 
+func combineRecoveryPhrase(ctx context.Context, manager *wallet.Manager) error {
+	if manager.VaultExists() {
+		return fmt.Errorf("wallet already exists. Remove existing wallet first")
+	}
+
+	fmt.Println("🧩 Recover Wallet from Shares")
+	fmt.Println()
+
+	reader := bufio.NewReader(os.Stdin)
+	var shares []*wallet.RecoveryShare
+	for len(shares) == 0 || len(shares) < shares[0].Threshold {
+		fmt.Fprintf(promptOut(), "Enter share %d: ", len(shares)+1)
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read share: %w", err)
+		}
+
+		share, err := wallet.ParseRecoveryShare(line)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			continue
+		}
+		if len(shares) > 0 && share.ID != shares[0].ID {
+			fmt.Println("❌ This share belongs to a different split")
+			continue
+		}
+		if containsShare(shares, share.Index) {
+			fmt.Println("❌ This share was already entered")
+			continue
+		}
+
+		shares = append(shares, share)
+		if len(shares) == 1 {
+			fmt.Printf("✅ Share accepted, %d shares are needed\n", share.Threshold)
+		}
+	}
+
+	mnemonic, err := wallet.CombineRecoveryShares(shares)
+	if err != nil {
+		return fmt.Errorf("failed to recover phrase: %w", err)
+	}
+
+	fmt.Println("✅ Recovery phrase restored")
+	fmt.Println()
+
+	return importMnemonic(ctx, manager, mnemonic)
+}
+
+// containsShare returns true if a share with the index was already entered
+func containsShare(shares []*wallet.RecoveryShare, index int) bool {
+	for _, share := range shares {
+		if share.Index == index {
+			return true
+		}
+	}
+	return false
+}
+
 func isValidMnemonic(mnemonic string) bool {
 	words := strings.Fields(mnemonic)
 	return len(words) == 24
@@ -1,8 +1,19 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
 
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/logging"
+	"github.com/chinmay1088/odyssey/wallet"
 	"github.com/spf13/cobra"
 )
 
@@ -39,36 +50,244 @@ Security:
 Examples:
   odyssey init                    # Create new wallet
   odyssey unlock                  # Unlock wallet
+  odyssey lock                    # Lock wallet
   odyssey address                 # Show all addresses
   odyssey balance --usd           # Check balances with USD values
+  odyssey portfolio               # Show allocation across all assets
   odyssey pay eth 0.1 0x1234...  # Send 0.1 ETH
   odyssey network testnet        # Switch to testnet mode
-  odyssey update                  # Update to latest version`,
+  odyssey update                  # Update to latest version
+  odyssey address --offline       # Show addresses without network access`,
+	PersistentPreRunE: applyGlobalFlags,
+	PersistentPostRun: printPostRunHints,
+
+	// main prints errors, once and without usage, see ExitCode
+	SilenceErrors: true,
+}
+
+// OfflineEnv enables offline mode when set to 1 or true
+const OfflineEnv = "ODYSSEY_OFFLINE"
+
+// applyGlobalFlags applies persistent flags before any command runs
+func applyGlobalFlags(cmd *cobra.Command, args []string) error {
+	// Arguments were accepted, errors from here on are no usage errors
+	cmd.SilenceUsage = true
+
+	verbosity, _ := cmd.Flags().GetCount("verbose")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	logging.SetVerbosity(verbosity, quiet)
+
+	// JSON output is a result as a whole
+	plain, _ := cmd.Flags().GetBool("plain")
+	if err := setPlain(plainRequested(plain) && !jsonOutput(cmd)); err != nil {
+		return err
+	}
+	if err := setQuiet(quiet && !jsonOutput(cmd)); err != nil {
+		return err
+	}
+	slog.Debug("running command", "command", cmd.CommandPath(), "version", version)
+
+	offline, _ := cmd.Flags().GetBool("offline")
+	if env := os.Getenv(OfflineEnv); env == "1" || env == "true" {
+		offline = true
+	}
+
+	// Key and address operations never need the network, in offline mode any
+	// attempt to reach it fails instead
+	api.SetOffline(offline)
+
+	// Only commands that display data reuse cached responses, anything that
+	// moves funds or waits for changes fetches them fresh
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	api.SetCache(cachedCommands[topLevelCommand(cmd).Name()] && !noCache)
+
+	// watch and receive have a --timeout of their own, which shadows this one
+	timeout, _ := cmd.Root().PersistentFlags().GetDuration("timeout")
+	if timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative")
+	}
+	api.SetTimeout(timeout)
+
+	// Named wallets live in directories of their own, chosen before anything
+	// reads the vault or the network
+	walletName, _ := cmd.Flags().GetString("wallet")
+	if err := config.SelectWallet(walletName); err != nil {
+		return err
+	}
+	if err := checkActiveWallet(cmd); err != nil {
+		return err
+	}
+
+	// --network overrides the wallet's network for this command only
+	networkName, _ := cmd.Flags().GetString("network")
+	if err := config.SelectNetwork(strings.ToLower(networkName)); err != nil {
+		return err
+	}
+	config.SetChainIDDetector(api.DetectEthereumChainID)
+
+	// Without a stored session the keys are unlocked for this command only
+	noSession, _ := cmd.Flags().GetBool("no-session")
+	wallet.SetNoSession(noSession)
+	wallet.SetPasswordPrompt(func() (string, error) {
+		return readWalletPassword(cmd, "Enter your wallet password: ")
+	})
+
+	recordHintCommand(cmd)
+	return nil
 }
 
+// walletExemptCommands work without the active wallet's directory, to
+// create wallets or point wallet.default elsewhere
+var walletExemptCommands = map[string]bool{
+	"wallet":     true,
+	"config":     true,
+	"version":    true,
+	"help":       true,
+	"completion": true,
+}
+
+// checkActiveWallet fails if the active wallet is a named wallet that was
+// never created, so a typo doesn't start an empty wallet
+func checkActiveWallet(cmd *cobra.Command) error {
+	name := config.ActiveWallet()
+	if name == config.DefaultWallet || walletExemptCommands[topLevelCommand(cmd).Name()] {
+		return nil
+	}
+	dir, err := config.WalletDirOf(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return fmt.Errorf("wallet %q doesn't exist. Create it with 'odyssey wallet create %s' or see 'odyssey wallet list'", name, name)
+	}
+	return nil
+}
+
+// cachedCommands may answer from the response cache in ~/.odyssey/cache
+var cachedCommands = map[string]bool{
+	"balance":      true,
+	"transactions": true,
+	"portfolio":    true,
+	"performance":  true,
+	"watchlist":    true,
+}
+
+// topLevelCommand returns the command below root that cmd belongs to
+func topLevelCommand(cmd *cobra.Command) *cobra.Command {
+	top := cmd
+	for top.HasParent() && top.Parent() != cmd.Root() {
+		top = top.Parent()
+	}
+	return top
+}
+
+// interruptGrace is how long a command may take to stop after Ctrl+C before
+// the process exits, e.g. when it is blocked reading a prompt
+const interruptGrace = 2 * time.Second
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
-	return rootCmd.Execute()
+	// Ctrl+C cancels the context of the running command, which abandons its
+	// requests and waits so it can stop cleanly
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	go func() {
+		<-interrupts
+		cancel()
+		select {
+		case <-interrupts:
+		case <-time.After(interruptGrace):
+		}
+		FlushOutput()
+		os.Exit(ExitInterrupted)
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	if err != nil && errors.Is(err, context.Canceled) {
+		return errInterrupted
+	}
+	return err
+}
+
+// sleepContext waits for d, returning early with an error once ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 func init() {
 	// Global flags
-	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
-	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "suppress output")
+	rootCmd.PersistentFlags().CountP("verbose", "v", "log debug messages to stderr, -vv also raw RPC requests and responses")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "only print results such as transaction hashes and addresses")
+	rootCmd.PersistentFlags().Bool("plain", false, "print without emoji, colors and progress bars (also NO_COLOR or ODYSSEY_PLAIN=1)")
+	rootCmd.PersistentFlags().Bool("offline", false, "never access the network (also ODYSSEY_OFFLINE=1)")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "fetch balances, history and prices fresh instead of from the cache")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "time out every network request after this long instead of the configured timeouts")
+	rootCmd.PersistentFlags().String("wallet", "", "use a named wallet instead of the default one (also ODYSSEY_WALLET or wallet.default)")
+	rootCmd.PersistentFlags().String("network", "", "use this network for the command instead of the one set with 'odyssey network'")
+	rootCmd.PersistentFlags().Bool("no-session", false, "never write a session to disk, ask for the password when keys are needed (also session.storage none)")
 
 	// Add subcommands
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(unlockCmd)
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(agentCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(twoFactorCmd)
+	rootCmd.AddCommand(duressCmd)
 	rootCmd.AddCommand(addressCmd)
 	rootCmd.AddCommand(balanceCmd)
 	rootCmd.AddCommand(payCmd)
+	rootCmd.AddCommand(policyCmd)
+	rootCmd.AddCommand(allowlistCmd)
+	rootCmd.AddCommand(denylistCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(receiveCmd)
 	rootCmd.AddCommand(transactionsCmd)
+	rootCmd.AddCommand(txCmd)
 	rootCmd.AddCommand(recoveryPhraseCmd)
+	rootCmd.AddCommand(discoverCmd)
+	rootCmd.AddCommand(vanityCmd)
 	rootCmd.AddCommand(buyCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(networkCmd) // Add network command
-	rootCmd.AddCommand(exportCmd)  // Add export command
+	rootCmd.AddCommand(faucetCmd)
+	rootCmd.AddCommand(exportCmd) // Add export command
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(walletCmd)
+	rootCmd.AddCommand(portfolioCmd)
+	rootCmd.AddCommand(performanceCmd)
+	rootCmd.AddCommand(gainsCmd)
+	rootCmd.AddCommand(priceCmd)
+	rootCmd.AddCommand(watchlistCmd)
+	rootCmd.AddCommand(rebalanceCmd)
+	rootCmd.AddCommand(swapCmd)
+	rootCmd.AddCommand(nftCmd)
+	rootCmd.AddCommand(safeCmd)
+	rootCmd.AddCommand(multisigCmd)
+	rootCmd.AddCommand(solCmd)
+	rootCmd.AddCommand(contractCmd)
+	rootCmd.AddCommand(tokenCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(rpcCmd)
+	rootCmd.AddCommand(feesCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(interactiveCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(clipboardClearCmd)
+	rootCmd.AddCommand(stakeCmd)
 }
 
 // versionCmd represents the version command
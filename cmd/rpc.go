@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/spf13/cobra"
+)
+
+var rpcRawFlag bool
+
+var rpcCmd = &cobra.Command{
+	Use:   "rpc [chain] [method] [params-json]",
+	Short: "Send a raw JSON-RPC request",
+	Long: `Send a JSON-RPC request to the node used for a chain on the current network
+and print the result. Params are passed as a JSON array or object.
+
+This is a developer tool, no wallet is needed and nothing is signed.
+
+Supported chains: eth, sol
+
+Examples:
+  odyssey rpc eth eth_blockNumber
+  odyssey rpc eth eth_getBalance '["0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6", "latest"]'
+  odyssey rpc sol getSlot
+  odyssey rpc sol getAccountInfo '["7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU", {"encoding": "base64"}]'`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runRPC,
+}
+
+func init() {
+	rpcCmd.Flags().BoolVar(&rpcRawFlag, "raw", false, "Print the result without formatting")
+}
+
+func runRPC(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	chain, err := parseWatchChain(args[0])
+	if err != nil {
+		return err
+	}
+
+	method := strings.TrimSpace(args[1])
+	if method == "" {
+		return fmt.Errorf("method cannot be empty")
+	}
+
+	var params json.RawMessage
+	if len(args) == 3 {
+		params = json.RawMessage(strings.TrimSpace(args[2]))
+		if !json.Valid(params) {
+			return fmt.Errorf("params must be valid JSON")
+		}
+		if params[0] != '[' && params[0] != '{' {
+			return fmt.Errorf("params must be a JSON array or object")
+		}
+	}
+
+	client := api.NewClient()
+	result, err := client.CallRPC(ctx, chain, method, params)
+	if err != nil {
+		var rpcErr *api.RPCError
+		if errors.As(err, &rpcErr) && len(rpcErr.Data) > 0 {
+			return fmt.Errorf("%w (data: %s)", rpcErr, rpcErr.Data)
+		}
+		return err
+	}
+
+	if rpcRawFlag {
+		fmt.Println(string(result))
+		return nil
+	}
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, result, "", "  "); err != nil {
+		fmt.Println(string(result))
+		return nil
+	}
+	fmt.Println(pretty.String())
+	return nil
+}
@@ -0,0 +1,699 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+var safeNonceFlag int64
+
+// Gas estimates can fall back to a default that is too low for execTransaction
+const safeExecMinGas = uint64(120000)
+
+var safeCmd = &cobra.Command{
+	Use:   "safe",
+	Short: "Manage Safe (Gnosis Safe) multisig transactions",
+	Long: `Propose, confirm and execute transactions of a Safe multisig you co-own.
+
+Your Ethereum address must be one of the Safe owners. Proposals and
+confirmations are shared through the Safe transaction service, so the other
+owners can sign them in the Safe app or with Odyssey.
+
+Examples:
+  odyssey safe info 0x5afe...                    # Owners, threshold and nonce
+  odyssey safe pending 0x5afe...                 # Transactions waiting for signatures
+  odyssey safe propose 0x5afe... 0x742d... 0.5   # Propose sending 0.5 ETH
+  odyssey safe confirm 0x8f1c...                 # Sign a proposed transaction
+  odyssey safe execute 0x8f1c...                 # Execute once enough owners signed`,
+}
+
+var safeInfoCmd = &cobra.Command{
+	Use:   "info [safe]",
+	Short: "Show owners, threshold and nonce of a Safe",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSafeInfo,
+}
+
+var safePendingCmd = &cobra.Command{
+	Use:   "pending [safe]",
+	Short: "List transactions waiting for signatures or execution",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSafePending,
+}
+
+var safeProposeCmd = &cobra.Command{
+	Use:   "propose [safe] [to] [amount]",
+	Short: "Propose sending ETH from a Safe",
+	Args:  cobra.ExactArgs(3),
+	RunE:  runSafePropose,
+}
+
+var safeConfirmCmd = &cobra.Command{
+	Use:   "confirm [safe-tx-hash]",
+	Short: "Sign a proposed Safe transaction",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSafeConfirm,
+}
+
+var safeExecuteCmd = &cobra.Command{
+	Use:   "execute [safe-tx-hash]",
+	Short: "Execute a Safe transaction with enough signatures",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSafeExecute,
+}
+
+func init() {
+	safeProposeCmd.Flags().Int64Var(&safeNonceFlag, "nonce", -1, "Safe nonce to use, reuse a queued nonce to replace that transaction")
+
+	safeCmd.AddCommand(safeInfoCmd)
+	safeCmd.AddCommand(safePendingCmd)
+	safeCmd.AddCommand(safeProposeCmd)
+	safeCmd.AddCommand(safeConfirmCmd)
+	safeCmd.AddCommand(safeExecuteCmd)
+}
+
+// safeState is the on-chain configuration of a Safe
+type safeState struct {
+	Address   common.Address
+	Nonce     uint64
+	Threshold int
+	Owners    []common.Address
+}
+
+// isOwner returns true if address is an owner of the Safe
+func (s *safeState) isOwner(address common.Address) bool {
+	for _, owner := range s.Owners {
+		if owner == address {
+			return true
+		}
+	}
+	return false
+}
+
+func runSafeInfo(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	client := api.NewClient()
+	state, err := loadSafeState(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	address, err := manager.GetEthereumAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+
+	balance, err := client.GetEthereumBalance(ctx, state.Address.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+
+	fmt.Println("🔐 Safe")
+	fmt.Printf("   Address:   %s\n", state.Address.Hex())
+	fmt.Printf("   Balance:   %s ETH\n", ethereum.WeiToEther(balance).StringFixed(6))
+	fmt.Printf("   Threshold: %d of %d owners\n", state.Threshold, len(state.Owners))
+	fmt.Printf("   Nonce:     %d\n", state.Nonce)
+	fmt.Printf("   Network:   %s\n", manager.GetCurrentNetwork())
+	fmt.Println()
+	fmt.Println("👥 Owners:")
+	for _, owner := range state.Owners {
+		marker := ""
+		if owner == address {
+			marker = " (you)"
+		}
+		fmt.Printf("   %s%s\n", owner.Hex(), marker)
+	}
+
+	if !state.isOwner(address) {
+		fmt.Println()
+		fmt.Println("⚠️  Your address is not an owner of this Safe")
+	}
+
+	return nil
+}
+
+func runSafePending(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	client := api.NewClient()
+	state, err := loadSafeState(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	address, err := manager.GetEthereumAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+
+	pending, err := client.GetSafePendingTransactions(ctx, state.Address.Hex(), state.Nonce)
+	if err != nil {
+		return err
+	}
+
+	// Cache what still needs our confirmation for the summary on unlock
+	var approvals []wallet.ActivityApproval
+	for _, tx := range pending {
+		signed := false
+		for _, confirmation := range tx.Confirmations {
+			if common.HexToAddress(confirmation.Owner) == address {
+				signed = true
+			}
+		}
+		if !signed && len(tx.Confirmations) < state.Threshold {
+			approvals = append(approvals, wallet.ActivityApproval{
+				Kind:        "safe",
+				Source:      state.Address.Hex(),
+				Network:     manager.GetCurrentNetwork(),
+				ID:          tx.SafeTxHash,
+				Description: fmt.Sprintf("Safe %s nonce %s", truncateAddress(state.Address.Hex()), tx.Nonce),
+				SeenAt:      time.Now(),
+			})
+		}
+	}
+	_ = manager.SetApprovals("safe", state.Address.Hex(), manager.GetCurrentNetwork(), approvals)
+
+	if len(pending) == 0 {
+		fmt.Println("📭 No pending Safe transactions")
+		return nil
+	}
+
+	fmt.Printf("⏳ Pending Safe Transactions (threshold %d)\n", state.Threshold)
+	fmt.Println()
+
+	for _, tx := range pending {
+		value, _ := new(big.Int).SetString(string(tx.Value), 10)
+		if value == nil {
+			value = big.NewInt(0)
+		}
+
+		signed := false
+		for _, confirmation := range tx.Confirmations {
+			if common.HexToAddress(confirmation.Owner) == address {
+				signed = true
+			}
+		}
+
+		status := "🖊️ "
+		if len(tx.Confirmations) >= state.Threshold {
+			status = "✅"
+		}
+
+		fmt.Printf("%s Nonce %s: %s\n", status, tx.Nonce, tx.SafeTxHash)
+		fmt.Printf("   To:            %s\n", tx.To)
+		fmt.Printf("   Value:         %s ETH\n", ethereum.WeiToEther(value).StringFixed(6))
+		if tx.Data != nil && *tx.Data != "" && *tx.Data != "0x" {
+			fmt.Printf("   Data:          %d bytes\n", (len(*tx.Data)-2)/2)
+		}
+		fmt.Printf("   Confirmations: %d of %d", len(tx.Confirmations), state.Threshold)
+		if signed {
+			fmt.Print(" (signed by you)")
+		}
+		fmt.Println()
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func runSafePropose(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	client := api.NewClient()
+	state, err := loadSafeState(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	recipient, err := ethereum.ParseAddress(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid Ethereum address: %w", err)
+	}
+
+	amount, err := decimal.NewFromString(args[2])
+	if err != nil || !amount.IsPositive() {
+		return fmt.Errorf("invalid amount: %s", args[2])
+	}
+	value := amount.Shift(18).BigInt()
+
+	sender, err := manager.GetEthereumAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+	if !state.isOwner(sender) {
+		return fmt.Errorf("your address %s is not an owner of this Safe", sender.Hex())
+	}
+
+	balance, err := client.GetEthereumBalance(ctx, state.Address.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+	if balance.Cmp(value) < 0 {
+		return fmt.Errorf("%w in the Safe. You're trying to send %s ETH but the Safe balance is only %s ETH", api.ErrInsufficientFunds,
+			ethereum.WeiToEther(value).StringFixed(6), ethereum.WeiToEther(balance).StringFixed(6))
+	}
+
+	nonce, err := nextSafeNonce(ctx, client, state)
+	if err != nil {
+		return err
+	}
+
+	tx := ethereum.NewSafeTransfer(recipient, value, nonce)
+	safeTxHash := ethereum.SafeTransactionHash(state.Address, ethereum.GetChainID(), tx)
+
+	fmt.Println("🔐 Propose Safe Transaction")
+	fmt.Println()
+	fmt.Printf("📊 Transaction Details:\n")
+	fmt.Printf("   Safe:      %s\n", state.Address.Hex())
+	fmt.Printf("   To:        %s\n", recipient.Hex())
+	fmt.Printf("   Amount:    %s ETH\n", ethereum.WeiToEther(value).StringFixed(6))
+	fmt.Printf("   Nonce:     %d\n", nonce)
+	fmt.Printf("   Threshold: %d of %d owners\n", state.Threshold, len(state.Owners))
+	fmt.Printf("   Network:   %s\n", manager.GetCurrentNetwork())
+	fmt.Printf("   Safe Hash: %s\n", safeTxHash.Hex())
+
+	if err := confirmSigning(manager, "❌ Proposal cancelled"); err != nil {
+		return err
+	}
+
+	privateKey, err := manager.GetEthereumKey()
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	signature, err := ethereum.SignSafeTransactionHash(safeTxHash, privateKey)
+	if err != nil {
+		return err
+	}
+
+	err = client.ProposeSafeTransaction(ctx, state.Address.Hex(), api.SafeProposal{
+		To:                      recipient.Hex(),
+		Value:                   value.String(),
+		Operation:               int(tx.Operation),
+		SafeTxGas:               "0",
+		BaseGas:                 "0",
+		GasPrice:                "0",
+		GasToken:                tx.GasToken.Hex(),
+		RefundReceiver:          tx.RefundReceiver.Hex(),
+		Nonce:                   tx.Nonce.String(),
+		ContractTransactionHash: safeTxHash.Hex(),
+		Sender:                  sender.Hex(),
+		Signature:               hexutil.Encode(signature),
+		Origin:                  "odyssey",
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Transaction proposed and signed by you")
+	printResult(safeTxHash.Hex(), "📝 Safe Hash: %s\n", safeTxHash.Hex())
+	if state.Threshold > 1 {
+		fmt.Printf("💡 %d more owner signature(s) needed. Owners can run 'odyssey safe confirm %s'\n", state.Threshold-1, safeTxHash.Hex())
+	} else {
+		fmt.Printf("💡 Run 'odyssey safe execute %s' to execute it\n", safeTxHash.Hex())
+	}
+
+	return nil
+}
+
+func runSafeConfirm(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	client := api.NewClient()
+	serviceTx, tx, state, err := loadVerifiedSafeTransaction(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	signer, err := manager.GetEthereumAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+	if !state.isOwner(signer) {
+		return fmt.Errorf("your address %s is not an owner of this Safe", signer.Hex())
+	}
+	for _, confirmation := range serviceTx.Confirmations {
+		if common.HexToAddress(confirmation.Owner) == signer {
+			return fmt.Errorf("you have already signed this transaction")
+		}
+	}
+
+	printSafeTransaction(manager, state, serviceTx, tx)
+
+	if err := confirmSigning(manager, "❌ Confirmation cancelled"); err != nil {
+		return err
+	}
+
+	privateKey, err := manager.GetEthereumKey()
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	safeTxHash := common.HexToHash(serviceTx.SafeTxHash)
+	signature, err := ethereum.SignSafeTransactionHash(safeTxHash, privateKey)
+	if err != nil {
+		return err
+	}
+
+	if err := client.ConfirmSafeTransaction(ctx, safeTxHash.Hex(), hexutil.Encode(signature)); err != nil {
+		return err
+	}
+
+	confirmations := len(serviceTx.Confirmations) + 1
+	fmt.Printf("✅ Transaction signed (%d of %d)\n", confirmations, state.Threshold)
+	if confirmations >= state.Threshold {
+		fmt.Printf("💡 Run 'odyssey safe execute %s' to execute it\n", safeTxHash.Hex())
+	}
+
+	return nil
+}
+
+func runSafeExecute(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	client := api.NewClient()
+	serviceTx, tx, state, err := loadVerifiedSafeTransaction(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if tx.Nonce.Uint64() != state.Nonce {
+		return fmt.Errorf("the Safe is at nonce %d, transactions must be executed in order (this one has nonce %d)", state.Nonce, tx.Nonce.Uint64())
+	}
+
+	sender, err := manager.GetEthereumAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+
+	// Keep only valid signatures of current owners
+	safeTxHash := common.HexToHash(serviceTx.SafeTxHash)
+	signatures := make(map[common.Address][]byte)
+	for _, confirmation := range serviceTx.Confirmations {
+		signature, err := hexutil.Decode(confirmation.Signature)
+		if err != nil {
+			continue
+		}
+		owner, err := ethereum.RecoverSafeSigner(safeTxHash, signature)
+		if err != nil || owner != common.HexToAddress(confirmation.Owner) || !state.isOwner(owner) {
+			continue
+		}
+		signatures[owner] = signature
+	}
+
+	privateKey, err := manager.GetEthereumKey()
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	// An owner executing the transaction can add the last signature
+	if _, signed := signatures[sender]; !signed && len(signatures) < state.Threshold && state.isOwner(sender) {
+		signature, err := ethereum.SignSafeTransactionHash(safeTxHash, privateKey)
+		if err != nil {
+			return err
+		}
+		signatures[sender] = signature
+	}
+
+	if len(signatures) < state.Threshold {
+		return fmt.Errorf("transaction has %d of %d required signatures", len(signatures), state.Threshold)
+	}
+
+	data, err := ethereum.EncodeSafeExecTransaction(tx, ethereum.PackSafeSignatures(signatures))
+	if err != nil {
+		return err
+	}
+
+	nonce, err := nextEthereumNonce(ctx, manager, client, "ethereum", sender.Hex())
+	if err != nil {
+		return err
+	}
+
+	gasPrice, err := client.GetEthereumGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	// Add 20% to gas price to ensure faster inclusion
+	gasPrice.Mul(gasPrice, big.NewInt(120))
+	gasPrice.Div(gasPrice, big.NewInt(100))
+
+	gasLimit, err := client.GetEthereumGasEstimate(ctx, sender.Hex(), state.Address.Hex(), nil, data)
+	if err != nil || gasLimit < safeExecMinGas {
+		gasLimit = safeExecMinGas
+	}
+
+	maxFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+	balance, err := client.GetEthereumBalance(ctx, sender.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+	if balance.Cmp(maxFee) < 0 {
+		return fmt.Errorf("%w for gas. Executing needs about %s ETH but your balance is only %s ETH", api.ErrInsufficientFunds,
+			ethereum.WeiToEther(maxFee).StringFixed(6), ethereum.WeiToEther(balance).StringFixed(6))
+	}
+
+	printSafeTransaction(manager, state, serviceTx, tx)
+	fmt.Printf("   Signatures: %d of %d\n", len(signatures), state.Threshold)
+	fmt.Printf("   Max Fee:    ~%s ETH (paid by you)\n", ethereum.WeiToEther(maxFee).StringFixed(6))
+
+	if err := confirmSigning(manager, "❌ Execution cancelled"); err != nil {
+		return err
+	}
+
+	ethTx := ethereum.NewTransaction(nonce, state.Address, big.NewInt(0), gasLimit, gasPrice, data)
+	signedTx, err := ethereum.SignTransaction(ethTx, privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	txID, err := ethereum.TransactionHash(signedTx)
+	if err != nil {
+		return err
+	}
+
+	txHash, err := broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+		ID:       txID,
+		Chain:    "ethereum",
+		Network:  manager.GetCurrentNetwork(),
+		SignedTx: signedTx,
+		From:     sender.Hex(),
+		To:       state.Address.Hex(),
+		Amount:   "0 ETH (Safe execution)",
+		Nonce:    nonce,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	fmt.Printf("✅ Safe transaction executed!\n")
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL("ethereum", "tx", txHash))
+
+	return nil
+}
+
+// loadSafeState reads the owners, threshold and nonce of a Safe from the chain
+func loadSafeState(ctx context.Context, client *api.Client, safe string) (*safeState, error) {
+	address, err := ethereum.ParseAddress(safe)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Safe address: %w", err)
+	}
+
+	state := &safeState{Address: address}
+
+	for _, method := range []string{"nonce", "getThreshold"} {
+		data, err := ethereum.EncodeSafeCall(method)
+		if err != nil {
+			return nil, err
+		}
+		result, err := client.CallEthereumContract(ctx, address.Hex(), data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Safe %s: %w", method, err)
+		}
+		value, err := ethereum.DecodeSafeUint(method, result)
+		if err != nil {
+			return nil, fmt.Errorf("%s does not look like a Safe: %w", address.Hex(), err)
+		}
+		if method == "nonce" {
+			state.Nonce = value.Uint64()
+		} else {
+			state.Threshold = int(value.Int64())
+		}
+	}
+
+	data, err := ethereum.EncodeSafeCall("getOwners")
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.CallEthereumContract(ctx, address.Hex(), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Safe owners: %w", err)
+	}
+	if state.Owners, err = ethereum.DecodeSafeOwners(result); err != nil {
+		return nil, fmt.Errorf("%s does not look like a Safe: %w", address.Hex(), err)
+	}
+
+	return state, nil
+}
+
+// nextSafeNonce returns the --nonce flag, or the nonce after the last queued transaction
+func nextSafeNonce(ctx context.Context, client *api.Client, state *safeState) (uint64, error) {
+	if safeNonceFlag >= 0 {
+		if uint64(safeNonceFlag) < state.Nonce {
+			return 0, fmt.Errorf("nonce %d has already been executed, the Safe is at nonce %d", safeNonceFlag, state.Nonce)
+		}
+		return uint64(safeNonceFlag), nil
+	}
+
+	pending, err := client.GetSafePendingTransactions(ctx, state.Address.Hex(), state.Nonce)
+	if err != nil {
+		return 0, err
+	}
+
+	nonce := state.Nonce
+	for _, tx := range pending {
+		queued, ok := new(big.Int).SetString(string(tx.Nonce), 10)
+		if ok && queued.Uint64() >= nonce {
+			nonce = queued.Uint64() + 1
+		}
+	}
+
+	return nonce, nil
+}
+
+// loadVerifiedSafeTransaction fetches a proposed transaction and checks that its
+// fields hash to the requested Safe transaction hash, so the service cannot make
+// an owner sign something else
+func loadVerifiedSafeTransaction(ctx context.Context, client *api.Client, safeTxHash string) (*api.SafeMultisigTransaction, *ethereum.SafeTransaction, *safeState, error) {
+	if !strings.HasPrefix(safeTxHash, "0x") || len(safeTxHash) != 66 {
+		return nil, nil, nil, fmt.Errorf("invalid Safe transaction hash: %s", safeTxHash)
+	}
+
+	serviceTx, err := client.GetSafeTransaction(ctx, safeTxHash)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if serviceTx.IsExecuted {
+		return nil, nil, nil, fmt.Errorf("transaction has already been executed")
+	}
+
+	tx, err := parseSafeTransaction(serviceTx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	state, err := loadSafeState(ctx, client, serviceTx.Safe)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	computed := ethereum.SafeTransactionHash(state.Address, ethereum.GetChainID(), tx)
+	if computed != common.HexToHash(safeTxHash) {
+		return nil, nil, nil, fmt.Errorf("transaction details do not match hash %s, refusing to sign", safeTxHash)
+	}
+	if tx.Nonce.Uint64() < state.Nonce {
+		return nil, nil, nil, fmt.Errorf("nonce %d has already been used, this transaction can no longer be executed", tx.Nonce.Uint64())
+	}
+
+	return serviceTx, tx, state, nil
+}
+
+// parseSafeTransaction converts a service transaction to its signed fields
+func parseSafeTransaction(serviceTx *api.SafeMultisigTransaction) (*ethereum.SafeTransaction, error) {
+	numbers := make([]*big.Int, 5)
+	for i, field := range []api.SafeNumber{serviceTx.Value, serviceTx.SafeTxGas, serviceTx.BaseGas, serviceTx.GasPrice, serviceTx.Nonce} {
+		value, ok := new(big.Int).SetString(string(field), 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid number in Safe transaction: %s", field)
+		}
+		numbers[i] = value
+	}
+
+	var data []byte
+	if serviceTx.Data != nil && *serviceTx.Data != "" {
+		decoded, err := hexutil.Decode(*serviceTx.Data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data in Safe transaction: %w", err)
+		}
+		data = decoded
+	}
+
+	if serviceTx.Operation != 0 && serviceTx.Operation != 1 {
+		return nil, fmt.Errorf("invalid operation in Safe transaction: %d", serviceTx.Operation)
+	}
+
+	return &ethereum.SafeTransaction{
+		To:             common.HexToAddress(serviceTx.To),
+		Value:          numbers[0],
+		Data:           data,
+		Operation:      uint8(serviceTx.Operation),
+		SafeTxGas:      numbers[1],
+		BaseGas:        numbers[2],
+		GasPrice:       numbers[3],
+		GasToken:       common.HexToAddress(serviceTx.GasToken),
+		RefundReceiver: common.HexToAddress(serviceTx.RefundReceiver),
+		Nonce:          numbers[4],
+	}, nil
+}
+
+// printSafeTransaction shows the details of a Safe transaction before signing
+func printSafeTransaction(manager *wallet.Manager, state *safeState, serviceTx *api.SafeMultisigTransaction, tx *ethereum.SafeTransaction) {
+	fmt.Println("🔐 Safe Transaction")
+	fmt.Println()
+	fmt.Printf("📊 Transaction Details:\n")
+	fmt.Printf("   Safe:       %s\n", state.Address.Hex())
+	fmt.Printf("   To:         %s\n", tx.To.Hex())
+	fmt.Printf("   Amount:     %s ETH\n", ethereum.WeiToEther(tx.Value).StringFixed(6))
+	if len(tx.Data) > 0 {
+		fmt.Printf("   Data:       %d bytes\n", len(tx.Data))
+	}
+	if tx.Operation == 1 {
+		fmt.Println("   ⚠️  Operation: DELEGATECALL, the target runs code with full control of the Safe")
+	}
+	fmt.Printf("   Nonce:      %s\n", tx.Nonce)
+	fmt.Printf("   Network:    %s\n", manager.GetCurrentNetwork())
+	fmt.Printf("   Safe Hash:  %s\n", serviceTx.SafeTxHash)
+}
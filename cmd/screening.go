@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"golang.org/x/term"
+)
+
+// errFlaggedRecipient is returned for payments to a flagged recipient that
+// can't be confirmed
+var errFlaggedRecipient = errors.New("the recipient is flagged")
+
+// payAllowFlaggedFlag pays recipients on the blocklist or a sanctions list
+// without asking
+var payAllowFlaggedFlag bool
+
+// screenRecipient warns about a recipient on the blocklist or, with a
+// Chainalysis API key on mainnet, a sanctions list, and asks to confirm paying
+// it. Scripts and --yes get errFlaggedRecipient unless --allow-flagged is
+// given. Simulations and dry runs only show the warning, and a screening API
+// that can't be reached doesn't stop the payment.
+func screenRecipient(ctx context.Context, manager *wallet.Manager, client *api.Client, whitelistChain, recipient string) error {
+	reasons := recipientFlags(ctx, manager, client, whitelistChain, recipient)
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	out := promptOut()
+	fmt.Fprintf(out, "🚩 %s is flagged\n", recipient)
+	for _, reason := range reasons {
+		fmt.Fprintf(out, "   %s\n", reason)
+	}
+	if paySimulateOnlyFlag || payDryRunFlag {
+		fmt.Fprintln(out)
+		return nil
+	}
+	if payAllowFlaggedFlag {
+		fmt.Fprintln(out, "⚠️  Sending anyway, --allow-flagged is given")
+		fmt.Fprintln(out)
+		return nil
+	}
+	if payYesFlag || !term.IsTerminal(int(syscall.Stdin)) {
+		return fmt.Errorf("%w: check the address, or send again with --allow-flagged if you are sure", errFlaggedRecipient)
+	}
+	fmt.Fprint(out, "Send to this address anyway? (y/n): ")
+
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		fmt.Println("❌ Transaction cancelled by user")
+		return errCancelled
+	}
+	fmt.Println()
+	return nil
+}
+
+// recipientFlags returns why a recipient is flagged, nothing if it isn't
+func recipientFlags(ctx context.Context, manager *wallet.Manager, client *api.Client, whitelistChain, recipient string) []string {
+	var reasons []string
+
+	blocklist, err := config.LoadBlocklist()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  The blocklist couldn't be read: %v\n", err)
+	}
+	if entry := blocklist.Address(whitelistChain, recipient); entry != nil {
+		reasons = append(reasons, "On the blocklist: "+blocklistReason(entry))
+	}
+
+	// Sanctions lists name mainnet addresses only
+	cfg, err := config.Load()
+	if err != nil || manager.IsTestnet() {
+		return reasons
+	}
+	apiKey := cfg.Get(config.KeyChainalysisAPIKey)
+	if apiKey == "" {
+		return reasons
+	}
+	matches, err := client.ScreenAddress(ctx, recipient, apiKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  The recipient couldn't be screened for sanctions: %v\n", err)
+	}
+	for _, match := range matches {
+		reasons = append(reasons, "Sanctioned: "+match.Name)
+	}
+	return reasons
+}
+
+// blocklistReason returns why an entry is on the blocklist
+func blocklistReason(entry *config.BlocklistEntry) string {
+	if entry.Reason == "" {
+		return "no reason given"
+	}
+	return entry.Reason
+}
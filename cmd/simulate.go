@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/shopspring/decimal"
+)
+
+// paySimulateOnlyFlag stops pay after the simulation, nothing is sent
+var paySimulateOnlyFlag bool
+
+// maxSimulationLogs limits how many Solana program log lines are shown
+const maxSimulationLogs = 10
+
+// eventNames maps the signatures of common events to their names
+var eventNames = map[string]string{
+	"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef": "Transfer",
+	"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925": "Approval",
+	"0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62": "TransferSingle",
+	"0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31": "ApprovalForAll",
+	"0xe1fffcc4923d04b559f4d29a8bfc6cda04eb5b0d3c460751c2402c5c5cc9109c": "Deposit",
+	"0x7fcf532c15f0a6db0bd6d0e038bea71d30d808c7d98cb3bf7268a95bf5081b65": "Withdrawal",
+}
+
+// simulationAssets describes how the assets in a simulation are displayed
+type simulationAssets struct {
+	symbol   string // native asset
+	decimals int32
+	owner    string      // our address, marked in the balance changes
+	tokens   []api.Token // known tokens of the chain
+}
+
+// reportSimulation prints the predicted outcome of a transaction. It returns
+// an error if the transaction would fail, and done if --simulate-only asked
+// to stop here. A simulation that couldn't run is only a warning.
+func reportSimulation(sim *api.Simulation, simErr error, assets simulationAssets) (done bool, err error) {
+	if simErr != nil {
+		if paySimulateOnlyFlag {
+			return true, fmt.Errorf("failed to simulate transaction: %w", simErr)
+		}
+		fmt.Printf("⚠️  Could not simulate the transaction: %v\n", simErr)
+		fmt.Println()
+		return false, nil
+	}
+
+	fmt.Printf("🧪 Simulation (%s):\n", sim.Provider)
+	if !sim.Success {
+		fmt.Printf("   ❌ Would fail: %s\n", sim.Error)
+		printProgramLogs(sim.ProgramLogs)
+		fmt.Println()
+		return true, fmt.Errorf("the transaction would fail (%s), nothing was sent", sim.Error)
+	}
+
+	switch {
+	case sim.GasUsed == 0:
+		fmt.Println("   ✅ Succeeds")
+	case assets.symbol == "SOL":
+		fmt.Printf("   ✅ Succeeds, uses %d compute units\n", sim.GasUsed)
+	default:
+		fmt.Printf("   ✅ Succeeds, uses %d gas\n", sim.GasUsed)
+	}
+
+	if len(sim.BalanceChanges) > 0 {
+		fmt.Println("   Balance changes:")
+		for _, change := range sim.BalanceChanges {
+			who := truncateAddress(change.Address)
+			if strings.EqualFold(change.Address, assets.owner) {
+				who += " (you)"
+			}
+			fmt.Printf("     %-22s %s\n", who, formatBalanceChange(change, assets))
+		}
+	}
+
+	if len(sim.Logs) > 0 {
+		names := make([]string, 0, len(sim.Logs))
+		for _, log := range sim.Logs {
+			name := "unknown event"
+			if len(log.Topics) > 0 {
+				if known, ok := eventNames[strings.ToLower(log.Topics[0])]; ok {
+					name = known
+				}
+			}
+			names = append(names, fmt.Sprintf("%s (%s)", name, truncateAddress(log.Address)))
+		}
+		fmt.Printf("   Events:  %s\n", strings.Join(names, ", "))
+	}
+	printProgramLogs(sim.ProgramLogs)
+
+	if !sim.Detailed {
+		fmt.Println("   💡 The node doesn't support tracing, set a Tenderly account with 'odyssey config' for balance changes")
+	}
+	fmt.Println()
+
+	if paySimulateOnlyFlag {
+		fmt.Println("🧪 Dry run only, nothing was sent")
+		return true, nil
+	}
+	return false, nil
+}
+
+// formatBalanceChange formats a balance change with a sign and the asset
+func formatBalanceChange(change api.BalanceChange, assets simulationAssets) string {
+	sign := "+"
+	if change.Amount.Sign() < 0 {
+		sign = "-"
+	}
+	amount := new(big.Int).Abs(change.Amount)
+
+	if change.Token == "" {
+		value := decimal.NewFromBigInt(amount, -assets.decimals)
+		return fmt.Sprintf("%s%s %s", sign, value.String(), assets.symbol)
+	}
+	if change.TokenID != nil {
+		return fmt.Sprintf("%s%s NFT #%s (%s)", sign, amount.String(), change.TokenID.String(), truncateAddress(change.Token))
+	}
+	for _, token := range assets.tokens {
+		if strings.EqualFold(token.Address, change.Token) {
+			value := decimal.NewFromBigInt(amount, -token.Decimals)
+			return fmt.Sprintf("%s%s %s", sign, value.String(), token.Symbol)
+		}
+	}
+	return fmt.Sprintf("%s%s units of %s", sign, amount.String(), truncateAddress(change.Token))
+}
+
+// printProgramLogs prints the last lines of the Solana program logs
+func printProgramLogs(logs []string) {
+	if len(logs) == 0 {
+		return
+	}
+	fmt.Println("   Program logs:")
+	start := 0
+	if len(logs) > maxSimulationLogs {
+		start = len(logs) - maxSimulationLogs
+		fmt.Printf("     ... %d earlier lines\n", start)
+	}
+	for _, line := range logs[start:] {
+		fmt.Printf("     %s\n", line)
+	}
+}
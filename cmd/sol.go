@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/solana"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+var solCmd = &cobra.Command{
+	Use:   "sol",
+	Short: "Solana specific tools",
+	Long: `Tools that only apply to Solana.
+
+A Solana transaction names a recent blockhash and expires about a minute after
+it is signed. A durable nonce account holds a nonce that is used in its place:
+transactions signed with it stay valid until the nonce is advanced, which
+sending one of them does. This allows signing now and sending much later, or
+on another machine.
+
+The nonce account of the wallet is derived from its Solana address and costs
+its rent-exempt minimum, which stays in the account.
+
+Examples:
+  odyssey sol nonce create                                      # Create the nonce account
+  odyssey sol nonce                                             # Show the nonce account
+  odyssey pay sol 0.5 7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU --durable-nonce --sign-only
+  odyssey sol broadcast 4hXTCkRzt9WyecNzV1XPgCDfGAZzQKNxLXgynz5Q...  # Send a signed transaction`,
+	Args: cobra.NoArgs,
+}
+
+var solNonceCmd = &cobra.Command{
+	Use:   "nonce",
+	Short: "Show the durable nonce account of the wallet",
+	Args:  cobra.NoArgs,
+	RunE:  runSolNonceShow,
+}
+
+var solNonceCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create the durable nonce account of the wallet",
+	Args:  cobra.NoArgs,
+	RunE:  runSolNonceCreate,
+}
+
+var solBroadcastCmd = &cobra.Command{
+	Use:   "broadcast [signed-transaction]",
+	Short: "Send a signed Solana transaction",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSolBroadcast,
+}
+
+// solSkipPreflightFlag sends without the node's preflight check
+var solSkipPreflightFlag bool
+
+func init() {
+	solBroadcastCmd.Flags().BoolVar(&solSkipPreflightFlag, "skip-preflight", false, "Send without the node's preflight check, for congested nodes")
+	solNonceCmd.AddCommand(solNonceCreateCmd)
+	solCmd.AddCommand(solNonceCmd)
+	solCmd.AddCommand(solBroadcastCmd)
+}
+
+func runSolNonceShow(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+	client := api.NewClient()
+	chain := solana.NewChain(manager, client)
+
+	address, err := chain.NonceAccount()
+	if err != nil {
+		return err
+	}
+	account, err := client.GetSolanaNonceAccount(ctx, address)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		fmt.Printf("📭 No nonce account at %s\n", address)
+		fmt.Println("💡 Create it with: odyssey sol nonce create")
+		return nil
+	}
+
+	fmt.Println("🟣 Durable Nonce Account")
+	fmt.Printf("   Address:   %s\n", address)
+	fmt.Printf("   Authority: %s\n", account.Authority)
+	fmt.Printf("   Nonce:     %s\n", account.Nonce)
+	fmt.Printf("   Balance:   %s\n", solana.FormatBalance(account.Lamports))
+	fmt.Printf("   Network:   %s\n", manager.GetCurrentNetwork())
+	return nil
+}
+
+func runSolNonceCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+	client := api.NewClient()
+	chain := solana.NewChain(manager, client)
+
+	address, err := chain.NonceAccount()
+	if err != nil {
+		return err
+	}
+	existing, err := client.GetSolanaNonceAccount(ctx, address)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		fmt.Printf("✅ The nonce account already exists: %s\n", address)
+		return nil
+	}
+
+	transfer, err := chain.BuildCreateNonceAccount(ctx)
+	if err != nil {
+		return err
+	}
+	rent := transfer.Amount.Uint64()
+	fee := transfer.Fee.Uint64()
+
+	balance, err := client.GetSolanaBalance(ctx, transfer.From)
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+	if balance < rent+fee {
+		return fmt.Errorf("%w: the nonce account needs %s for rent plus %s in fees, your balance is %s",
+			api.ErrInsufficientFunds, solana.FormatBalance(rent), solana.FormatBalance(fee), solana.FormatBalance(balance))
+	}
+
+	fmt.Printf("📊 Nonce Account Details:\n")
+	fmt.Printf("   Address:   %s\n", address)
+	fmt.Printf("   Authority: %s\n", transfer.From)
+	fmt.Printf("   Rent:      %s\n", solana.FormatBalance(rent))
+	fmt.Printf("   Fee:       %s\n", solana.FormatBalance(fee))
+	fmt.Printf("   Network:   %s\n", manager.GetCurrentNetwork())
+
+	if err := confirmSigning(manager, "❌ Transaction cancelled by user"); err != nil {
+		return err
+	}
+
+	if err := chain.Sign(ctx, transfer); err != nil {
+		return err
+	}
+	tx := transfer.Tx.(*solana.Transaction)
+	pending := wallet.PendingBroadcast{
+		ID:        transfer.ID,
+		Chain:     "solana",
+		Network:   manager.GetCurrentNetwork(),
+		SignedTx:  transfer.Signed,
+		From:      transfer.From,
+		To:        address,
+		Amount:    solana.FormatBalance(rent),
+		Blockhash: tx.RecentBlockhash,
+	}
+	txHash, err := broadcastWithRetry(ctx, manager, client, pending)
+	if err == nil {
+		err = waitForSolanaConfirmation(ctx, manager, client, &pending, tx.LastValidBlockHeight)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create nonce account: %w", err)
+	}
+
+	fmt.Printf("✅ Nonce account created: %s\n", address)
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL("solana", "tx", txHash))
+	fmt.Println("💡 Sign payments that don't expire with: odyssey pay sol <amount> <address> --durable-nonce --sign-only")
+	return nil
+}
+
+func runSolBroadcast(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if solSkipPreflightFlag {
+		ctx = api.WithSkipPreflight(ctx)
+	}
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	signed, err := solana.DecodeSignedTransfer(strings.TrimSpace(args[0]))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📊 Transaction Details:\n")
+	fmt.Printf("   Signature: %s\n", signed.ID)
+	fmt.Printf("   Fee payer: %s\n", signed.FeePayer)
+	amount := ""
+	if signed.To != "" {
+		amount = solana.FormatBalance(signed.Lamports)
+		fmt.Printf("   To:        %s\n", signed.To)
+		fmt.Printf("   Amount:    %s\n", amount)
+	}
+	if signed.NonceAccount != "" {
+		fmt.Printf("   Nonce:     %s (durable)\n", signed.NonceAccount)
+	}
+	fmt.Printf("   Network:   %s\n", manager.GetCurrentNetwork())
+	fmt.Println()
+
+	// A durable nonce transaction is rejected once its nonce has been
+	// advanced, report that instead of the RPC error
+	if signed.NonceAccount != "" {
+		account, err := client.GetSolanaNonceAccount(ctx, signed.NonceAccount)
+		if err != nil {
+			return err
+		}
+		if account == nil || account.Nonce != signed.Blockhash {
+			return fmt.Errorf("%w: the durable nonce has been advanced, the transaction must be signed again", errPayloadExpired)
+		}
+	}
+
+	if err := requireTwoFactor(manager); err != nil {
+		return err
+	}
+
+	// The last valid block height isn't part of the transaction, expiry is
+	// checked against its blockhash or durable nonce. The policy was checked
+	// when the payment was signed, it counts towards the daily limit once sent.
+	pending := wallet.PendingBroadcast{
+		ID:           signed.ID,
+		Chain:        "solana",
+		Network:      manager.GetCurrentNetwork(),
+		SignedTx:     strings.TrimSpace(args[0]),
+		From:         signed.FeePayer,
+		To:           signed.To,
+		Amount:       amount,
+		Blockhash:    signed.Blockhash,
+		NonceAccount: signed.NonceAccount,
+		SpendChain:   "solana",
+		SpendAmount:  solana.LamportsToSOL(signed.Lamports),
+	}
+	txHash, err := broadcastWithRetry(ctx, manager, client, pending)
+	if err == nil {
+		err = waitForSolanaConfirmation(ctx, manager, client, &pending, 0)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	fmt.Printf("✅ Transaction sent successfully!\n")
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL("solana", "tx", txHash))
+	return nil
+}
@@ -0,0 +1,671 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// Gas limits of staking calls whose estimate fails, e.g. because they follow
+// an approval that isn't mined yet
+const (
+	stakingApproveGas    = 80000
+	stakingWithdrawalGas = 150000 // per Lido withdrawal request
+	stakingClaimGas      = 100000
+	stakingCallGas       = 300000
+)
+
+var stakeProviderFlag string
+
+var stakeCmd = &cobra.Command{
+	Use:   "stake [chain] [amount]",
+	Short: "Stake ETH with Lido or Rocket Pool",
+	Long: `Stake ETH with a liquid staking provider. The token you receive earns the
+staking rewards and can be held, sent or swapped like any other:
+
+  lido        stETH, worth 1 ETH, the balance grows as rewards are paid
+  rocketpool  rETH, the balance stays and its value in ETH grows
+
+Staked balances are shown by 'odyssey balance' and 'odyssey stake status'.
+
+'odyssey stake withdraw' returns the token for ETH. Lido withdrawals are
+requests queued until enough validators exit, usually 1 to 5 days, and the ETH
+is claimed with 'odyssey stake claim' once they are finalized. Rocket Pool
+burns rETH for ETH right away, as long as it holds enough ETH to pay it out;
+otherwise the rETH can be swapped with 'odyssey swap'.
+
+Staking is only available on Ethereum mainnet.
+
+Examples:
+  odyssey stake eth 1 --provider lido
+  odyssey stake eth 0.5 --provider rocketpool
+  odyssey stake status
+  odyssey stake withdraw eth 1 --provider lido
+  odyssey stake withdraw eth all --provider rocketpool
+  odyssey stake claim`,
+	Args: cobra.ExactArgs(2),
+	RunE: runStake,
+}
+
+var stakeStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show staked ETH and pending Lido withdrawals",
+	Args:  cobra.NoArgs,
+	RunE:  runStakeStatus,
+}
+
+var stakeWithdrawCmd = &cobra.Command{
+	Use:   "withdraw [chain] [amount|all]",
+	Short: "Withdraw staked ETH",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runStakeWithdraw,
+}
+
+var stakeClaimCmd = &cobra.Command{
+	Use:   "claim",
+	Short: "Claim the ETH of finalized Lido withdrawals",
+	Args:  cobra.NoArgs,
+	RunE:  runStakeClaim,
+}
+
+func init() {
+	for _, command := range []*cobra.Command{stakeCmd, stakeWithdrawCmd} {
+		command.Flags().StringVar(&stakeProviderFlag, "provider", ethereum.StakingLido, "Staking provider: lido or rocketpool")
+	}
+	for _, command := range []*cobra.Command{stakeCmd, stakeWithdrawCmd, stakeClaimCmd} {
+		addPreSignFlags(command)
+	}
+
+	stakeCmd.AddCommand(stakeStatusCmd)
+	stakeCmd.AddCommand(stakeWithdrawCmd)
+	stakeCmd.AddCommand(stakeClaimCmd)
+}
+
+// stakingCall is one transaction to a staking contract
+type stakingCall struct {
+	to          common.Address
+	value       *big.Int
+	data        []byte
+	gasLimit    uint64 // used if the node can't estimate it
+	description string // recorded as the amount of the transaction
+}
+
+// stakedETH is what an address holds with each staking provider
+type stakedETH struct {
+	stETH     *big.Int // Lido, worth as much ETH
+	rETH      *big.Int // Rocket Pool
+	rETHValue *big.Int // the ETH the rETH is worth
+}
+
+// total returns the ETH all staking tokens are worth
+func (s *stakedETH) total() *big.Int {
+	return new(big.Int).Add(s.stETH, s.rETHValue)
+}
+
+// stakingAddress checks that staking is possible and returns the wallet's
+// Ethereum address
+func stakingAddress(manager *wallet.Manager, client *api.Client) (common.Address, error) {
+	if !manager.IsUnlocked() {
+		return common.Address{}, errWalletLocked
+	}
+	// The staking contracts only exist on mainnet
+	if client.EthereumChainID() != 1 {
+		return common.Address{}, fmt.Errorf("staking is only supported on Ethereum mainnet")
+	}
+	address, err := manager.GetEthereumAddress()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get address: %w", err)
+	}
+	return address, nil
+}
+
+// parseStakingArgs checks the chain of a staking command and --provider
+func parseStakingArgs(chain string) (string, error) {
+	if parsed, err := parseWatchChain(chain); err != nil || parsed != "ethereum" {
+		return "", fmt.Errorf("unsupported chain: %s. Staking is supported on eth", chain)
+	}
+	provider := strings.ToLower(stakeProviderFlag)
+	if provider != ethereum.StakingLido && provider != ethereum.StakingRocketPool {
+		return "", fmt.Errorf("unknown provider: %s. Use lido or rocketpool", stakeProviderFlag)
+	}
+	return provider, nil
+}
+
+// parseStakingAmount parses an amount of ETH or of a staking token in wei
+func parseStakingAmount(amountStr string) (*big.Int, error) {
+	amount, err := decimal.NewFromString(amountStr)
+	if err != nil || !amount.IsPositive() {
+		return nil, fmt.Errorf("invalid amount: %s", amountStr)
+	}
+	if amount.Exponent() < -18 {
+		return nil, fmt.Errorf("invalid amount: %s has more than 18 decimals", amountStr)
+	}
+	return amount.Shift(18).BigInt(), nil
+}
+
+func runStake(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	provider, err := parseStakingArgs(args[0])
+	if err != nil {
+		return err
+	}
+	sender, err := stakingAddress(manager, client)
+	if err != nil {
+		return err
+	}
+	amount, err := parseStakingAmount(args[1])
+	if err != nil {
+		return err
+	}
+
+	var call stakingCall
+	var details []string
+	switch provider {
+	case ethereum.StakingLido:
+		// Lido limits how fast ETH can be staked
+		limit, err := callStakingUint(ctx, client, ethereum.LidoStETH, ethereum.EncodeLidoStakeLimit)
+		if err != nil {
+			return err
+		}
+		if amount.Cmp(limit) > 0 {
+			return fmt.Errorf("Lido takes at most %s ETH right now, its staking rate limit. Stake less or try again later", ethereum.WeiToEther(limit).StringFixed(6))
+		}
+
+		data, err := ethereum.EncodeLidoSubmit()
+		if err != nil {
+			return err
+		}
+		call = stakingCall{to: ethereum.LidoStETH, value: amount, data: data}
+		details = []string{
+			"Provider: Lido",
+			fmt.Sprintf("Receive:  ~%s stETH", ethereum.WeiToEther(amount).StringFixed(6)),
+		}
+
+	case ethereum.StakingRocketPool:
+		if amount.Cmp(ethereum.RocketPoolMinDeposit) < 0 {
+			return fmt.Errorf("Rocket Pool takes deposits of at least %s ETH", ethereum.WeiToEther(ethereum.RocketPoolMinDeposit).String())
+		}
+		pool, err := rocketDepositPool(ctx, client)
+		if err != nil {
+			return err
+		}
+		room, err := callStakingUint(ctx, client, pool, ethereum.EncodeRocketPoolMaxDeposit)
+		if err != nil {
+			return err
+		}
+		if amount.Cmp(room) > 0 {
+			return fmt.Errorf("the Rocket Pool deposit pool has room for %s ETH. Stake less, or with --provider lido", ethereum.WeiToEther(room).StringFixed(6))
+		}
+		reth, err := callStakingUint(ctx, client, ethereum.RocketPoolRETH, func() ([]byte, error) {
+			return ethereum.EncodeRETHRethValue(amount)
+		})
+		if err != nil {
+			return err
+		}
+
+		data, err := ethereum.EncodeRocketPoolDeposit()
+		if err != nil {
+			return err
+		}
+		call = stakingCall{to: pool, value: amount, data: data}
+		details = []string{
+			"Provider: Rocket Pool",
+			fmt.Sprintf("Receive:  ~%s rETH, less the deposit fee", ethereum.WeiToEther(reth).StringFixed(6)),
+		}
+	}
+	call.description = fmt.Sprintf("%s ETH (stake, %s)", ethereum.WeiToEther(amount).StringFixed(6), provider)
+	details = append([]string{fmt.Sprintf("Stake:    %s ETH", ethereum.WeiToEther(amount).StringFixed(6))}, details...)
+
+	txHash, err := sendStakingCalls(ctx, manager, client, sender, details, []stakingCall{call})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Staked %s ETH!\n", ethereum.WeiToEther(amount).StringFixed(6))
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL("ethereum", "tx", txHash))
+	fmt.Println("💡 See your staked ETH with 'odyssey stake status'")
+	return nil
+}
+
+func runStakeWithdraw(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	provider, err := parseStakingArgs(args[0])
+	if err != nil {
+		return err
+	}
+	sender, err := stakingAddress(manager, client)
+	if err != nil {
+		return err
+	}
+
+	token, symbol := ethereum.LidoStETH, "stETH"
+	if provider == ethereum.StakingRocketPool {
+		token, symbol = ethereum.RocketPoolRETH, "rETH"
+	}
+	balance, err := client.GetERC20Balance(ctx, token.Hex(), sender.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s balance: %w", symbol, err)
+	}
+	if balance.Sign() == 0 {
+		return fmt.Errorf("you hold no %s to withdraw", symbol)
+	}
+
+	amount := balance
+	if !strings.EqualFold(args[1], "all") {
+		if amount, err = parseStakingAmount(args[1]); err != nil {
+			return err
+		}
+		if amount.Cmp(balance) > 0 {
+			return fmt.Errorf("%w. You hold %s %s", api.ErrInsufficientFunds, ethereum.WeiToEther(balance).StringFixed(6), symbol)
+		}
+	}
+	formatted := ethereum.WeiToEther(amount).StringFixed(6)
+
+	var calls []stakingCall
+	var details []string
+	switch provider {
+	case ethereum.StakingLido:
+		if amount.Cmp(ethereum.LidoMinWithdrawal) < 0 {
+			return fmt.Errorf("Lido withdrawals take at least %s wei of stETH", ethereum.LidoMinWithdrawal.String())
+		}
+		// The withdrawal queue takes the stETH, so it is approved first
+		approve, err := ethereum.EncodeERC20Approve(ethereum.LidoWithdrawalQueue, amount)
+		if err != nil {
+			return err
+		}
+		amounts := ethereum.SplitLidoWithdrawal(amount)
+		request, err := ethereum.EncodeLidoRequestWithdrawals(amounts, sender)
+		if err != nil {
+			return err
+		}
+		calls = []stakingCall{
+			{to: ethereum.LidoStETH, value: big.NewInt(0), data: approve, gasLimit: stakingApproveGas, description: "0 ETH (approve stETH)"},
+			{to: ethereum.LidoWithdrawalQueue, value: big.NewInt(0), data: request, gasLimit: uint64(stakingWithdrawalGas * len(amounts)), description: fmt.Sprintf("%s stETH (withdraw, lido)", formatted)},
+		}
+		details = []string{
+			"Provider: Lido",
+			fmt.Sprintf("Requests: %d, claimed with 'odyssey stake claim' once finalized", len(amounts)),
+		}
+
+	case ethereum.StakingRocketPool:
+		value, err := callStakingUint(ctx, client, ethereum.RocketPoolRETH, func() ([]byte, error) {
+			return ethereum.EncodeRETHEthValue(amount)
+		})
+		if err != nil {
+			return err
+		}
+		collateral, err := callStakingUint(ctx, client, ethereum.RocketPoolRETH, ethereum.EncodeRETHTotalCollateral)
+		if err != nil {
+			return err
+		}
+		if value.Cmp(collateral) > 0 {
+			return fmt.Errorf("Rocket Pool holds %s ETH to pay out rETH, less than the %s ETH it is worth. Withdraw less, or swap the rETH with 'odyssey swap'",
+				ethereum.WeiToEther(collateral).StringFixed(6), ethereum.WeiToEther(value).StringFixed(6))
+		}
+
+		burn, err := ethereum.EncodeRETHBurn(amount)
+		if err != nil {
+			return err
+		}
+		calls = []stakingCall{{to: ethereum.RocketPoolRETH, value: big.NewInt(0), data: burn, description: fmt.Sprintf("%s rETH (withdraw, rocketpool)", formatted)}}
+		details = []string{
+			"Provider: Rocket Pool",
+			fmt.Sprintf("Receive:  ~%s ETH", ethereum.WeiToEther(value).StringFixed(6)),
+		}
+	}
+	details = append([]string{fmt.Sprintf("Withdraw: %s %s", formatted, symbol)}, details...)
+
+	txHash, err := sendStakingCalls(ctx, manager, client, sender, details, calls)
+	if err != nil {
+		return err
+	}
+
+	if provider == ethereum.StakingLido {
+		fmt.Printf("✅ Requested the withdrawal of %s stETH!\n", formatted)
+	} else {
+		fmt.Printf("✅ Withdrew %s rETH!\n", formatted)
+	}
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL("ethereum", "tx", txHash))
+	if provider == ethereum.StakingLido {
+		fmt.Println("💡 Check when it is finalized with 'odyssey stake status', usually within 1 to 5 days")
+	}
+	return nil
+}
+
+func runStakeClaim(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	sender, err := stakingAddress(manager, client)
+	if err != nil {
+		return err
+	}
+	withdrawals, err := getLidoWithdrawals(ctx, client, sender)
+	if err != nil {
+		return err
+	}
+
+	var calls []stakingCall
+	total := big.NewInt(0)
+	pending := 0
+	for _, withdrawal := range withdrawals {
+		if withdrawal.IsClaimed {
+			continue
+		}
+		if !withdrawal.IsFinalized {
+			pending++
+			continue
+		}
+		data, err := ethereum.EncodeLidoClaimWithdrawal(withdrawal.ID)
+		if err != nil {
+			return err
+		}
+		calls = append(calls, stakingCall{
+			to:          ethereum.LidoWithdrawalQueue,
+			value:       big.NewInt(0),
+			data:        data,
+			gasLimit:    stakingClaimGas,
+			description: fmt.Sprintf("%s ETH (claim, lido #%s)", ethereum.WeiToEther(withdrawal.AmountOfStETH).StringFixed(6), withdrawal.ID.String()),
+		})
+		total.Add(total, withdrawal.AmountOfStETH)
+	}
+
+	if len(calls) == 0 {
+		fmt.Println("📭 No Lido withdrawals are ready to claim")
+		if pending > 0 {
+			fmt.Printf("⏳ %d request%s still waiting to be finalized\n", pending, plural(pending))
+		}
+		return nil
+	}
+
+	details := []string{
+		"Provider: Lido",
+		fmt.Sprintf("Claim:    ~%s ETH from %d request%s", ethereum.WeiToEther(total).StringFixed(6), len(calls), plural(len(calls))),
+	}
+	txHash, err := sendStakingCalls(ctx, manager, client, sender, details, calls)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Claimed ~%s ETH!\n", ethereum.WeiToEther(total).StringFixed(6))
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL("ethereum", "tx", txHash))
+	return nil
+}
+
+func runStakeStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	sender, err := stakingAddress(manager, client)
+	if err != nil {
+		return err
+	}
+	staked, err := getStakedETH(ctx, client, sender.Hex())
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🥩 Staked ETH")
+	fmt.Printf("   Lido:        %s stETH\n", ethereum.WeiToEther(staked.stETH).StringFixed(6))
+	fmt.Printf("   Rocket Pool: %s rETH (~%s ETH)\n", ethereum.WeiToEther(staked.rETH).StringFixed(6), ethereum.WeiToEther(staked.rETHValue).StringFixed(6))
+	total := fmt.Sprintf("   Total:       ~%s ETH", ethereum.WeiToEther(staked.total()).StringFixed(6))
+	if showFiatValues(manager) {
+		if price, err := client.GetPrice(ctx, "ethereum"); err == nil {
+			total += fmt.Sprintf(" (~%s)", formatUSD(fiatValue(staked.total(), 18, price.USD)))
+		}
+	}
+	fmt.Println(total)
+
+	withdrawals, err := getLidoWithdrawals(ctx, client, sender)
+	if err != nil {
+		return err
+	}
+	ready := 0
+	for i, withdrawal := range withdrawals {
+		if i == 0 {
+			fmt.Println()
+			fmt.Println("⏳ Lido Withdrawals:")
+		}
+		state := "⏳ waiting to be finalized"
+		switch {
+		case withdrawal.IsClaimed:
+			state = "✅ claimed"
+		case withdrawal.IsFinalized:
+			state = "💰 ready to claim"
+			ready++
+		}
+		fmt.Printf("   #%-8s %s stETH, requested %s, %s\n", withdrawal.ID.String(), ethereum.WeiToEther(withdrawal.AmountOfStETH).StringFixed(6), withdrawal.RequestedAt.Local().Format("2006-01-02"), state)
+	}
+
+	fmt.Println()
+	switch {
+	case ready > 0:
+		fmt.Println("💡 Claim the ETH with 'odyssey stake claim'")
+	case staked.total().Sign() == 0:
+		fmt.Println("💡 Stake with 'odyssey stake eth [amount] --provider lido'")
+	}
+	return nil
+}
+
+// getStakedETH returns the staking tokens held by owner and their value
+func getStakedETH(ctx context.Context, client *api.Client, owner string) (*stakedETH, error) {
+	stETH, err := client.GetERC20Balance(ctx, ethereum.LidoStETH.Hex(), owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stETH balance: %w", err)
+	}
+	rETH, err := client.GetERC20Balance(ctx, ethereum.RocketPoolRETH.Hex(), owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rETH balance: %w", err)
+	}
+
+	staked := &stakedETH{stETH: stETH, rETH: rETH, rETHValue: big.NewInt(0)}
+	if rETH.Sign() > 0 {
+		staked.rETHValue, err = callStakingUint(ctx, client, ethereum.RocketPoolRETH, func() ([]byte, error) {
+			return ethereum.EncodeRETHEthValue(rETH)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return staked, nil
+}
+
+// getLidoWithdrawals returns the withdrawal requests of owner that weren't
+// claimed, oldest first
+func getLidoWithdrawals(ctx context.Context, client *api.Client, owner common.Address) ([]ethereum.LidoWithdrawal, error) {
+	data, err := ethereum.EncodeLidoWithdrawalRequests(owner)
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.CallEthereumContract(ctx, ethereum.LidoWithdrawalQueue.Hex(), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Lido withdrawals: %w", err)
+	}
+	ids, err := ethereum.DecodeLidoWithdrawalRequests(result)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	data, err = ethereum.EncodeLidoWithdrawalStatus(ids)
+	if err != nil {
+		return nil, err
+	}
+	result, err = client.CallEthereumContract(ctx, ethereum.LidoWithdrawalQueue.Hex(), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Lido withdrawals: %w", err)
+	}
+	return ethereum.DecodeLidoWithdrawalStatus(ids, result)
+}
+
+// rocketDepositPool looks up the current Rocket Pool deposit pool
+func rocketDepositPool(ctx context.Context, client *api.Client) (common.Address, error) {
+	data, err := ethereum.EncodeRocketPoolAddress("rocketDepositPool")
+	if err != nil {
+		return common.Address{}, err
+	}
+	result, err := client.CallEthereumContract(ctx, ethereum.RocketPoolStorage.Hex(), data)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to look up the Rocket Pool deposit pool: %w", err)
+	}
+	if len(result) < 32 {
+		return common.Address{}, fmt.Errorf("failed to look up the Rocket Pool deposit pool: %d bytes returned", len(result))
+	}
+	pool := common.BytesToAddress(result[12:32])
+	if pool == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("the Rocket Pool deposit pool is not registered")
+	}
+	return pool, nil
+}
+
+// callStakingUint calls a read-only staking method returning a number
+func callStakingUint(ctx context.Context, client *api.Client, contract common.Address, encode func() ([]byte, error)) (*big.Int, error) {
+	data, err := encode()
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.CallEthereumContract(ctx, contract.Hex(), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", contract.Hex(), err)
+	}
+	if len(result) < 32 {
+		return nil, fmt.Errorf("failed to call %s: %d bytes returned", contract.Hex(), len(result))
+	}
+	return new(big.Int).SetBytes(result[:32]), nil
+}
+
+// sendStakingCalls shows the details of staking transactions with their fee,
+// simulates a lone transaction, asks to confirm and sends them with
+// consecutive nonces. It returns the hash of the last one.
+func sendStakingCalls(ctx context.Context, manager *wallet.Manager, client *api.Client, sender common.Address, details []string, calls []stakingCall) (string, error) {
+	gasPrice, err := ethereum.NewChain(manager, client).GasPrice(ctx)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := nextEthereumNonce(ctx, manager, client, "ethereum", sender.Hex())
+	if err != nil {
+		return "", err
+	}
+
+	txs := make([]*ethereum.Transaction, len(calls))
+	maxFee := big.NewInt(0)
+	total := big.NewInt(0)
+	for i, call := range calls {
+		gasLimit, err := client.GetEthereumGasEstimate(ctx, sender.Hex(), call.to.Hex(), call.value, call.data)
+		if err != nil {
+			gasLimit = call.gasLimit
+			if gasLimit == 0 {
+				gasLimit = stakingCallGas
+			}
+		}
+		tx := ethereum.NewTransaction(nonce+uint64(i), call.to, call.value, gasLimit, gasPrice, call.data)
+		if err := ethereum.ValidateTransaction(tx); err != nil {
+			return "", fmt.Errorf("invalid transaction: %w", err)
+		}
+		txs[i] = tx
+		maxFee.Add(maxFee, ethereum.MaxFee(tx))
+		total.Add(total, call.value)
+	}
+	total.Add(total, maxFee)
+
+	balance, err := client.GetEthereumBalance(ctx, sender.Hex())
+	if err != nil {
+		return "", fmt.Errorf("failed to check balance: %w", err)
+	}
+	if balance.Cmp(total) < 0 {
+		return "", fmt.Errorf("%w. This needs about %s ETH including gas but your balance is only %s ETH", api.ErrInsufficientFunds,
+			ethereum.WeiToEther(total).StringFixed(6), ethereum.WeiToEther(balance).StringFixed(6))
+	}
+
+	fmt.Printf("📊 Transaction Details:\n")
+	fmt.Printf("   From:     %s\n", sender.Hex())
+	for _, line := range details {
+		fmt.Printf("   %s\n", line)
+	}
+	if len(txs) > 1 {
+		fmt.Printf("   Sends:    %d transactions\n", len(txs))
+	}
+	fmt.Printf("   Max Fee:  ~%s ETH\n", ethereum.WeiToEther(maxFee).StringFixed(6))
+	fmt.Printf("   Network:  %s\n", manager.GetCurrentNetwork())
+	fmt.Println()
+
+	// Later transactions depend on the earlier ones being mined, only a lone
+	// one can be simulated
+	if len(txs) == 1 {
+		tx := txs[0]
+		sim, simErr := client.SimulateEthereumTransaction(ctx, ethereum.GetChainID(), sender.Hex(), tx.To.Hex(), tx.Value, tx.Data, tx.GasLimit, gasPrice)
+		if _, err := reportSimulation(sim, simErr, simulationAssets{symbol: "ETH", decimals: 18, owner: sender.Hex(), tokens: client.GetEthereumTokens()}); err != nil {
+			return "", err
+		}
+	}
+
+	if err := confirmSigning(manager, "❌ Transaction cancelled"); err != nil {
+		return "", err
+	}
+	for _, tx := range txs {
+		err := checkBeforeSigning(ctx, manager, client, outgoingValue{
+			chain:     "ethereum",
+			recipient: tx.To.Hex(),
+			amount:    ethereum.WeiToEther(tx.Value),
+			fee:       ethereum.WeiToEther(ethereum.MaxFee(tx)),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	privateKey, err := manager.GetEthereumKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	var txHash string
+	for i, tx := range txs {
+		signedTx, err := ethereum.SignTransaction(tx, privateKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		txID, err := ethereum.TransactionHash(signedTx)
+		if err != nil {
+			return "", err
+		}
+
+		txHash, err = broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+			ID:          txID,
+			Chain:       "ethereum",
+			Network:     manager.GetCurrentNetwork(),
+			SignedTx:    signedTx,
+			From:        sender.Hex(),
+			To:          tx.To.Hex(),
+			Amount:      calls[i].description,
+			Nonce:       tx.Nonce,
+			SpendChain:  "ethereum",
+			SpendAmount: ethereum.WeiToEther(tx.Value),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to send transaction %d of %d: %w", i+1, len(txs), err)
+		}
+		if i < len(txs)-1 {
+			fmt.Printf("📤 Sent %s: %s\n", calls[i].description, txHash)
+		}
+	}
+	return txHash, nil
+}
@@ -0,0 +1,771 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/chains/solana"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	swapChainFlag    string
+	swapSlippageFlag float64
+	swapNoWaitFlag   bool
+)
+
+const (
+	// swapStatusInterval is how often the progress of a cross-chain swap is polled
+	swapStatusInterval = 20 * time.Second
+
+	// swapTrackTimeout stops tracking swaps that take unusually long
+	swapTrackTimeout = 3 * time.Hour
+)
+
+// Price impact above these percentages is highlighted before confirming
+var (
+	swapImpactWarning = decimal.NewFromInt(1)
+	swapImpactDanger  = decimal.NewFromInt(5)
+)
+
+var swapCmd = &cobra.Command{
+	Use:   "swap [from] [to] [amount]",
+	Short: "Swap tokens on a chain or across chains",
+	Long: `Swap one token for another. The swap is quoted first, and the quote is shown
+with the price impact or fees and the amount you will receive before anything
+is signed.
+
+Solana swaps are routed through Jupiter, Ethereum swaps through 0x. 0x needs
+a free API key from https://dashboard.0x.org:
+  odyssey config set swap.0x_api_key <key>
+
+Selling an ERC-20 token for the first time needs an approval transaction,
+which is sent right before the swap.
+
+Swappable: sol, usdc, usdt on Solana and eth, usdc, usdt, dai on Ethereum.
+When both tokens exist on both chains, pick one with --chain.
+
+Swaps between BTC and ETH are non-custodial cross-chain swaps through
+THORChain: the deposit is sent from your wallet and the output is paid to
+your own address on the other chain. The swap is tracked until the payout is
+confirmed, which usually takes 10 to 60 minutes. Stopping the tracking doesn't
+stop the swap, resume it with 'odyssey swap status'. Another THORNode can be
+used with 'odyssey config set swap.thornode_url <url>'.
+
+Swaps are mainnet only.
+
+Examples:
+  odyssey swap sol usdc 1.5
+  odyssey swap eth usdc 0.1 --slippage 1
+  odyssey swap usdc usdt 100 --chain sol
+  odyssey swap btc eth 0.01
+  odyssey swap status 0x5c50...`,
+	Args: cobra.ExactArgs(3),
+	RunE: runSwap,
+}
+
+var swapStatusCmd = &cobra.Command{
+	Use:   "status [deposit-hash]",
+	Short: "Track a cross-chain swap until the payout is confirmed",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSwapStatus,
+}
+
+func init() {
+	swapCmd.Flags().StringVar(&swapChainFlag, "chain", "", "Chain to swap on (eth or sol), needed when both tokens exist on both")
+	swapCmd.Flags().Float64Var(&swapSlippageFlag, "slippage", 0.5, "Maximum accepted slippage in percent")
+	swapCmd.Flags().BoolVar(&swapNoWaitFlag, "no-wait", false, "Don't track a cross-chain swap after sending the deposit")
+	addPreSignFlags(swapCmd)
+
+	swapCmd.AddCommand(swapStatusCmd)
+}
+
+func runSwap(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	// Check if wallet is unlocked
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	if manager.IsTestnet() {
+		return fmt.Errorf("token swaps are only supported on mainnet")
+	}
+
+	if swapSlippageFlag <= 0 || swapSlippageFlag > 10 {
+		return fmt.Errorf("slippage must be between 0 and 10 percent")
+	}
+	slippageBps := int(swapSlippageFlag * 100)
+
+	// Native assets of two different chains are swapped through THORChain
+	fromChain, fromErr := parseWatchChain(args[0])
+	toChain, toErr := parseWatchChain(args[1])
+	if fromErr == nil && toErr == nil && fromChain != toChain {
+		return runCrossChainSwap(ctx, manager, client, fromChain, toChain, args[2], slippageBps)
+	}
+
+	chain, err := resolveSwapChain(client, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	sell, _ := client.FindSwapToken(chain, args[0])
+	buy, _ := client.FindSwapToken(chain, args[1])
+	if sell.Symbol == buy.Symbol {
+		return fmt.Errorf("cannot swap %s for itself", sell.Symbol)
+	}
+
+	amount, err := decimal.NewFromString(args[2])
+	if err != nil || !amount.IsPositive() {
+		return fmt.Errorf("invalid amount: %s", args[2])
+	}
+
+	owner, err := swapOwner(manager, chain)
+	if err != nil {
+		return err
+	}
+
+	balance, err := swapTokenBalance(ctx, client, chain, sell, owner)
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+	if balance.LessThan(amount) {
+		return fmt.Errorf("%w. You're trying to swap %s %s but your balance is only %s %s", api.ErrInsufficientFunds, amount.String(), sell.Symbol, balance.String(), sell.Symbol)
+	}
+
+	fmt.Printf("🔄 Fetching quote for %s %s → %s...\n", amount.String(), sell.Symbol, buy.Symbol)
+
+	var quote *api.DEXQuote
+	if chain == "solana" {
+		quote, err = client.GetJupiterQuote(ctx, sell, buy, amount, slippageBps)
+	} else {
+		apiKey := ""
+		if cfg, cfgErr := config.Load(); cfgErr == nil {
+			apiKey = cfg.Get(config.KeyZeroExAPIKey)
+		}
+		quote, err = client.GetZeroExQuote(ctx, sell, buy, amount, owner, slippageBps, apiKey)
+	}
+	if err != nil {
+		return err
+	}
+
+	printSwapQuote(ctx, manager, client, quote)
+
+	if err := confirmSigning(manager, "❌ Swap cancelled by user"); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	var txHash string
+	if chain == "solana" {
+		txHash, err = sendSolanaSwap(ctx, manager, client, quote, owner)
+	} else {
+		txHash, err = sendEthereumSwap(ctx, manager, client, quote, owner)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Swap sent successfully!\n")
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL(chain, "tx", txHash))
+
+	return nil
+}
+
+// resolveSwapChain returns the chain both tokens can be swapped on
+func resolveSwapChain(client *api.Client, from, to string) (string, error) {
+	candidates := []string{"ethereum", "solana"}
+	if swapChainFlag != "" {
+		switch strings.ToLower(swapChainFlag) {
+		case "eth", "ethereum":
+			candidates = []string{"ethereum"}
+		case "sol", "solana":
+			candidates = []string{"solana"}
+		default:
+			return "", fmt.Errorf("unsupported swap chain: %s. Supported chains: eth, sol", swapChainFlag)
+		}
+	}
+
+	var chains []string
+	for _, chain := range candidates {
+		_, fromOK := client.FindSwapToken(chain, from)
+		_, toOK := client.FindSwapToken(chain, to)
+		if fromOK && toOK {
+			chains = append(chains, chain)
+		}
+	}
+
+	switch len(chains) {
+	case 1:
+		return chains[0], nil
+	case 0:
+		var supported []string
+		for _, chain := range candidates {
+			var symbols []string
+			for _, token := range client.SwapTokens(chain) {
+				symbols = append(symbols, strings.ToLower(token.Symbol))
+			}
+			supported = append(supported, fmt.Sprintf("%s (%s)", chain, strings.Join(symbols, ", ")))
+		}
+		return "", fmt.Errorf("no swap route for %s → %s. Swappable tokens: %s", from, to, strings.Join(supported, "; "))
+	default:
+		return "", fmt.Errorf("%s and %s exist on both Ethereum and Solana, choose one with --chain eth or --chain sol", strings.ToUpper(from), strings.ToUpper(to))
+	}
+}
+
+// swapOwner returns the wallet address that swaps on a chain
+func swapOwner(manager *wallet.Manager, chain string) (string, error) {
+	if chain == "solana" {
+		address, err := manager.GetSolanaAddress()
+		if err != nil {
+			return "", fmt.Errorf("failed to get Solana address: %w", err)
+		}
+		return address.String(), nil
+	}
+
+	address, err := manager.GetEthereumAddress()
+	if err != nil {
+		return "", fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+	return address.Hex(), nil
+}
+
+// swapTokenBalance returns the balance of a swappable token
+func swapTokenBalance(ctx context.Context, client *api.Client, chain string, token api.Token, owner string) (decimal.Decimal, error) {
+	var raw *big.Int
+	var err error
+
+	switch {
+	case token == api.NativeSOL:
+		var lamports uint64
+		lamports, err = client.GetSolanaBalance(ctx, owner)
+		raw = new(big.Int).SetUint64(lamports)
+	case token == api.NativeETH:
+		raw, err = client.GetEthereumBalance(ctx, owner)
+	case chain == "solana":
+		raw, err = client.GetSPLTokenBalance(ctx, token.Address, owner)
+	default:
+		raw, err = client.GetERC20Balance(ctx, token.Address, owner)
+	}
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return api.TokenAmount(raw, token.Decimals), nil
+}
+
+// printSwapQuote shows a quote with its USD values, price impact and route
+func printSwapQuote(ctx context.Context, manager *wallet.Manager, client *api.Client, quote *api.DEXQuote) {
+	sellPrice, buyPrice := decimal.Zero, decimal.Zero
+	if showFiatValues(manager) {
+		if price, err := client.GetPrice(ctx, quote.Sell.CoingeckoID); err == nil {
+			sellPrice = price.USD
+		}
+		if price, err := client.GetPrice(ctx, quote.Buy.CoingeckoID); err == nil {
+			buyPrice = price.USD
+		}
+	}
+
+	// Providers that don't report the price impact are compared to market prices
+	impact := quote.PriceImpact
+	if impact == nil && sellPrice.IsPositive() && buyPrice.IsPositive() {
+		sellValue := quote.SellAmount.Mul(sellPrice)
+		buyValue := quote.BuyAmount.Mul(buyPrice)
+		if sellValue.IsPositive() {
+			estimated := decimal.NewFromInt(1).Sub(buyValue.Div(sellValue)).Shift(2)
+			if estimated.IsNegative() {
+				estimated = decimal.Zero
+			}
+			impact = &estimated
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("📊 Swap Quote (%s):\n", quote.Provider)
+	fmt.Printf("   You pay:          %s %s%s\n", quote.SellAmount.String(), quote.Sell.Symbol, usdSuffix(quote.SellAmount, sellPrice))
+	fmt.Printf("   You receive:      ~%s %s%s\n", quote.BuyAmount.String(), quote.Buy.Symbol, usdSuffix(quote.BuyAmount, buyPrice))
+	fmt.Printf("   Minimum received: %s %s (slippage %.2f%%)\n", quote.MinBuyAmount.String(), quote.Buy.Symbol, float64(quote.SlippageBps)/100)
+	if impact != nil {
+		fmt.Printf("   Price impact:     %s%%\n", impact.StringFixed(2))
+	} else {
+		fmt.Printf("   Price impact:     unknown\n")
+	}
+	if len(quote.Route) > 0 {
+		fmt.Printf("   Route:            %s\n", strings.Join(quote.Route, ", "))
+	}
+	if quote.Transaction != nil {
+		fee := new(big.Int).Mul(quote.Transaction.GasPrice, new(big.Int).SetUint64(quote.Transaction.Gas))
+		fmt.Printf("   Network fee:      ~%s ETH\n", ethereum.WeiToEther(fee).StringFixed(6))
+	}
+	fmt.Printf("   Network: %s\n", manager.GetCurrentNetwork())
+	fmt.Println()
+
+	if impact != nil {
+		if impact.GreaterThanOrEqual(swapImpactDanger) {
+			fmt.Printf("🚨 Price impact is %s%%, you would lose a large part of the value. Consider a smaller amount\n", impact.StringFixed(2))
+			fmt.Println()
+		} else if impact.GreaterThanOrEqual(swapImpactWarning) {
+			fmt.Printf("⚠️  Price impact is %s%%, the pool has little liquidity for this size\n", impact.StringFixed(2))
+			fmt.Println()
+		}
+	}
+
+	if quote.AllowanceSpender != "" {
+		fmt.Printf("🔓 %s has to be approved for the swap first, this is sent as a separate transaction\n", quote.Sell.Symbol)
+		fmt.Println()
+	}
+}
+
+// usdSuffix formats the USD value of an amount, empty if the price is unknown
+func usdSuffix(amount, price decimal.Decimal) string {
+	if !price.IsPositive() {
+		return ""
+	}
+	return fmt.Sprintf(" (~%s)", formatUSD(amount.Mul(price)))
+}
+
+// sendSolanaSwap signs the Jupiter swap transaction of a quote and broadcasts it
+func sendSolanaSwap(ctx context.Context, manager *wallet.Manager, client *api.Client, quote *api.DEXQuote, owner string) (string, error) {
+	fmt.Println("⏳ Building swap transaction...")
+	unsignedTx, err := client.GetJupiterSwapTransaction(ctx, quote, owner)
+	if err != nil {
+		return "", err
+	}
+
+	// The swap program pays the output back to the wallet, only SOL sold
+	// counts towards the limits
+	sold := decimal.Zero
+	if quote.Sell.Address == api.NativeSOL.Address {
+		sold = quote.SellAmount
+	}
+	if err := checkBeforeSigning(ctx, manager, client, outgoingValue{chain: "solana", amount: sold}); err != nil {
+		return "", err
+	}
+
+	privateKey, err := manager.GetSolanaKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	signedTx, blockhash, err := solana.SignSerializedTransaction(unsignedTx, privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	txID, err := solana.TransactionSignature(signedTx)
+	if err != nil {
+		return "", err
+	}
+
+	pending := wallet.PendingBroadcast{
+		ID:          txID,
+		Chain:       "solana",
+		Network:     manager.GetCurrentNetwork(),
+		SignedTx:    signedTx,
+		From:        owner,
+		To:          quote.Provider,
+		Amount:      fmt.Sprintf("%s %s", quote.SellAmount.String(), quote.Sell.Symbol),
+		Blockhash:   blockhash,
+		SpendChain:  "solana",
+		SpendAmount: sold,
+	}
+	txHash, err := broadcastWithRetry(ctx, manager, client, pending)
+	if err == nil {
+		err = waitForSolanaConfirmation(ctx, manager, client, &pending, 0)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to send swap: %w", err)
+	}
+
+	return txHash, nil
+}
+
+// sendEthereumSwap signs the 0x swap transaction of a quote, preceded by an
+// approval when the token allowance is missing, and broadcasts it
+func sendEthereumSwap(ctx context.Context, manager *wallet.Manager, client *api.Client, quote *api.DEXQuote, owner string) (string, error) {
+	swapTx := quote.Transaction
+	target, err := ethereum.ParseAddress(swapTx.To)
+	if err != nil {
+		return "", err
+	}
+
+	privateKey, err := manager.GetEthereumKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	nonce, err := nextEthereumNonce(ctx, manager, client, "ethereum", owner)
+	if err != nil {
+		return "", err
+	}
+
+	// Add 20% to gas price to ensure faster inclusion
+	gasPrice := new(big.Int).Mul(swapTx.GasPrice, big.NewInt(120))
+	gasPrice.Div(gasPrice, big.NewInt(100))
+
+	var approval *ethereum.Transaction
+	if quote.AllowanceSpender != "" {
+		spender, err := ethereum.ParseAddress(quote.AllowanceSpender)
+		if err != nil {
+			return "", err
+		}
+		token, err := ethereum.ParseAddress(quote.Sell.Address)
+		if err != nil {
+			return "", err
+		}
+
+		data, err := ethereum.EncodeERC20Approve(spender, quote.SellAmount.Shift(quote.Sell.Decimals).BigInt())
+		if err != nil {
+			return "", err
+		}
+
+		gasLimit, err := client.GetEthereumGasEstimate(ctx, owner, token.Hex(), big.NewInt(0), data)
+		if err != nil {
+			// Approvals use well below this
+			gasLimit = 80000
+		}
+		approval = ethereum.NewTransaction(nonce, token, big.NewInt(0), gasLimit, gasPrice, data)
+	}
+
+	// The swap follows the approval
+	swapNonce := nonce
+	if approval != nil {
+		swapNonce++
+	}
+	tx := ethereum.NewTransaction(swapNonce, target, swapTx.Value, swapTx.Gas, gasPrice, swapTx.Data)
+	if err := ethereum.ValidateTransaction(tx); err != nil {
+		return "", fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	// Ensure the wallet can pay the value and gas of both transactions
+	maxFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tx.GasLimit))
+	if approval != nil {
+		maxFee.Add(maxFee, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(approval.GasLimit)))
+	}
+	balance, err := client.GetEthereumBalance(ctx, owner)
+	if err != nil {
+		return "", fmt.Errorf("failed to check balance: %w", err)
+	}
+	if balance.Cmp(new(big.Int).Add(swapTx.Value, maxFee)) < 0 {
+		return "", fmt.Errorf("%w for swap with gas. The swap needs about %s ETH in gas but your balance is only %s ETH", api.ErrInsufficientFunds,
+			ethereum.WeiToEther(maxFee).StringFixed(6), ethereum.WeiToEther(balance).StringFixed(6))
+	}
+
+	sold := ethereum.WeiToEther(swapTx.Value)
+	err = checkBeforeSigning(ctx, manager, client, outgoingValue{
+		chain:     "ethereum",
+		recipient: target.Hex(),
+		amount:    sold,
+		fee:       ethereum.WeiToEther(maxFee),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if approval != nil {
+		fmt.Printf("🔓 Approving %s...\n", quote.Sell.Symbol)
+		if _, err := broadcastSwapTransaction(ctx, manager, client, approval, privateKey, owner, "0 ETH", decimal.Zero); err != nil {
+			return "", fmt.Errorf("failed to send approval: %w", err)
+		}
+	}
+
+	txHash, err := broadcastSwapTransaction(ctx, manager, client, tx, privateKey, owner, fmt.Sprintf("%s %s", quote.SellAmount.String(), quote.Sell.Symbol), sold)
+	if err != nil {
+		return "", fmt.Errorf("failed to send swap: %w", err)
+	}
+
+	return txHash, nil
+}
+
+// broadcastSwapTransaction signs and sends one of the transactions of a swap,
+// spending sold ETH
+func broadcastSwapTransaction(ctx context.Context, manager *wallet.Manager, client *api.Client, tx *ethereum.Transaction, privateKey *ecdsa.PrivateKey, owner, amount string, sold decimal.Decimal) (string, error) {
+	signedTx, err := ethereum.SignTransaction(tx, privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	txID, err := ethereum.TransactionHash(signedTx)
+	if err != nil {
+		return "", err
+	}
+
+	return broadcastWithRetry(ctx, manager, client, wallet.PendingBroadcast{
+		ID:          txID,
+		Chain:       "ethereum",
+		Network:     manager.GetCurrentNetwork(),
+		SignedTx:    signedTx,
+		From:        owner,
+		To:          tx.To.Hex(),
+		Amount:      amount,
+		Nonce:       tx.Nonce,
+		SpendChain:  "ethereum",
+		SpendAmount: sold,
+	})
+}
+
+// runCrossChainSwap swaps the native asset of one chain for another through
+// THORChain and tracks the swap unless --no-wait is given
+func runCrossChainSwap(ctx context.Context, manager *wallet.Manager, client *api.Client, fromChain, toChain, amountStr string, slippageBps int) error {
+	fromSymbol, _ := nativeAssetFormat(fromChain)
+	toSymbol, _ := nativeAssetFormat(toChain)
+	if !client.SupportsSwap(fromChain, toChain) {
+		return fmt.Errorf("no cross-chain swap route for %s → %s. Currently swappable across chains: btc, eth", fromSymbol, toSymbol)
+	}
+
+	amount, err := decimal.NewFromString(amountStr)
+	if err != nil || !amount.IsPositive() {
+		return fmt.Errorf("invalid amount: %s", amountStr)
+	}
+	amount = amount.Truncate(api.ThorchainDecimals)
+
+	ethAddress, err := manager.GetEthereumAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get Ethereum address: %w", err)
+	}
+	btcAddresses, err := bitcoinWalletAddresses(manager)
+	if err != nil {
+		return fmt.Errorf("failed to get Bitcoin address: %w", err)
+	}
+
+	// The output is paid to this wallet's address on the destination chain
+	destination := ethAddress.Hex()
+	if toChain == "bitcoin" {
+		destination = btcAddresses[0]
+	}
+
+	var balance decimal.Decimal
+	if fromChain == "bitcoin" {
+		balance, err = fetchBitcoinWalletBalance(ctx, client, btcAddresses)
+	} else {
+		balance, err = fetchNativeBalance(ctx, client, fromChain, ethAddress.Hex())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check balance: %w", err)
+	}
+	if balance.LessThan(amount) {
+		return fmt.Errorf("%w. You're trying to swap %s %s but your balance is only %s %s", api.ErrInsufficientFunds, amount.String(), fromSymbol, balance.String(), fromSymbol)
+	}
+
+	fmt.Printf("🔄 Fetching THORChain quote for %s %s → %s...\n", amount.String(), fromSymbol, toSymbol)
+	quote, err := client.GetSwapQuote(ctx, fromChain, toChain, amount, destination, slippageBps)
+	if err != nil {
+		return err
+	}
+	if amount.LessThan(quote.RecommendedMinIn) {
+		return fmt.Errorf("amount is below the minimum of %s %s for this swap, smaller deposits would be lost to fees", quote.RecommendedMinIn.String(), fromSymbol)
+	}
+
+	printCrossChainQuote(ctx, manager, client, quote, destination)
+
+	if err := confirmSigning(manager, "❌ Swap cancelled by user"); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	txHash, err := sendSwapDeposit(ctx, manager, client, quote)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Deposit sent successfully!\n")
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	printExplorerLink(explorerURL(fromChain, "tx", txHash))
+	fmt.Printf("🔍 Swap status: https://track.ninerealms.com/%s\n", strings.TrimPrefix(txHash, "0x"))
+	fmt.Println()
+
+	if swapNoWaitFlag {
+		fmt.Printf("💡 Run 'odyssey swap status %s' to track the swap\n", txHash)
+		return nil
+	}
+
+	return trackSwap(ctx, manager, client, txHash)
+}
+
+// printCrossChainQuote shows a THORChain quote with its fees and timing
+func printCrossChainQuote(ctx context.Context, manager *wallet.Manager, client *api.Client, quote *api.SwapQuote, destination string) {
+	fromSymbol, _ := nativeAssetFormat(quote.FromChain)
+	toSymbol, _ := nativeAssetFormat(quote.ToChain)
+
+	var fromPrice, toPrice decimal.Decimal
+	if showFiatValues(manager) {
+		if prices, err := client.GetPrices(ctx, []string{quote.FromChain, quote.ToChain}); err == nil {
+			if price, ok := prices[quote.FromChain]; ok {
+				fromPrice = price.USD
+			}
+			if price, ok := prices[quote.ToChain]; ok {
+				toPrice = price.USD
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("📊 Swap Quote (THORChain):\n")
+	fmt.Printf("   You pay:      %s %s%s\n", quote.AmountIn.String(), fromSymbol, usdSuffix(quote.AmountIn, fromPrice))
+	fmt.Printf("   You receive:  ~%s %s%s\n", quote.ExpectedAmountOut.String(), toSymbol, usdSuffix(quote.ExpectedAmountOut, toPrice))
+	fmt.Printf("   Fees:         %s %s%s (%.2f%%)\n", quote.Fees.Total.String(), toSymbol, usdSuffix(quote.Fees.Total, toPrice), float64(quote.Fees.TotalBps)/100)
+	fmt.Printf("   Slippage:     %.2f%% (refunded above %.2f%%)\n", float64(quote.Fees.SlippageBps)/100, swapSlippageFlag)
+	if quote.EstimatedSeconds > 0 {
+		fmt.Printf("   Time:         ~%s\n", (time.Duration(quote.EstimatedSeconds) * time.Second).String())
+	}
+	fmt.Printf("   Deposit to:   %s\n", quote.InboundAddress)
+	if quote.Router != "" {
+		fmt.Printf("   Router:       %s\n", quote.Router)
+	}
+	fmt.Printf("   Paid out to:  %s\n", destination)
+	fmt.Printf("   Network: %s\n", manager.GetCurrentNetwork())
+	fmt.Printf("   Network fee of the %s deposit is added when sending\n", quote.FromChain)
+	fmt.Println()
+
+	if quote.Warning != "" {
+		fmt.Printf("⚠️  %s\n", quote.Warning)
+		fmt.Println()
+	}
+}
+
+func runSwapStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	if manager.IsTestnet() {
+		return fmt.Errorf("swaps are only supported on mainnet")
+	}
+
+	return trackSwap(ctx, manager, client, args[0])
+}
+
+// trackSwap polls the progress of a cross-chain swap, printing each stage as
+// it is reached, until the payout is confirmed on the destination chain
+func trackSwap(ctx context.Context, manager *wallet.Manager, client *api.Client, depositHash string) error {
+	fmt.Println("⏳ Tracking swap, press Ctrl+C to stop (the swap continues)")
+
+	deadline := time.Now().Add(swapTrackTimeout)
+	reported := make(map[string]bool)
+	report := func(stage, message string) {
+		if !reported[stage] {
+			reported[stage] = true
+			fmt.Printf("   %s %s\n", time.Now().Format("15:04:05"), message)
+		}
+	}
+
+	// Stopping with Ctrl+C ends the loop through the context
+	for ; time.Now().Before(deadline) && ctx.Err() == nil; sleepContext(ctx, swapStatusInterval) {
+		status, err := client.GetSwapStatus(ctx, depositHash)
+		if err != nil {
+			if api.IsTransientError(err) || ctx.Err() != nil {
+				continue
+			}
+			return err
+		}
+
+		if !status.Observed {
+			report("waiting", "📡 Waiting for THORChain to see the deposit...")
+			continue
+		}
+		report("observed", "👀 Deposit observed by THORChain")
+
+		if !status.Finalised {
+			if status.ConfirmationSeconds > 0 {
+				report("confirming", fmt.Sprintf("⏳ Waiting for deposit confirmations (~%s)", formatElapsed(time.Duration(status.ConfirmationSeconds)*time.Second)))
+			}
+			continue
+		}
+		report("finalised", "✅ Deposit confirmed")
+
+		if status.Refunded {
+			report("refunded", "↩️  Swap refunded, the price moved more than the slippage limit or the swap failed")
+		} else if status.Swapped {
+			report("swapped", "🔁 Swap executed")
+		}
+
+		if !status.Completed {
+			if status.OutboundDelaySeconds > 0 {
+				report("delayed", fmt.Sprintf("⏳ Payout scheduled, delayed ~%s for large swaps", formatElapsed(time.Duration(status.OutboundDelaySeconds)*time.Second)))
+			}
+			continue
+		}
+
+		if len(status.Outbound) == 0 {
+			continue
+		}
+		done, err := checkSwapPayout(ctx, manager, client, status, report)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("⚠️  The swap is still in progress. Run 'odyssey swap status %s' to keep tracking it\n", depositHash)
+	return nil
+}
+
+// checkSwapPayout reports the payout transactions of a swap and returns true
+// once all of them are confirmed on their chain
+func checkSwapPayout(ctx context.Context, manager *wallet.Manager, client *api.Client, status *api.SwapStatus, report func(stage, message string)) (bool, error) {
+	for _, out := range status.Outbound {
+		chain := api.ThorchainChain(out.Chain)
+		hash := out.Hash
+		if chain == "ethereum" {
+			hash = "0x" + strings.ToLower(strings.TrimPrefix(hash, "0x"))
+		}
+		symbol, _ := nativeAssetFormat(chain)
+
+		report("sent:"+hash, fmt.Sprintf("📤 Payout of %s %s sent: %s", out.Amount.String(), symbol, explorerURL(chain, "tx", hash)))
+
+		var confirmed bool
+		var err error
+		switch chain {
+		case "bitcoin":
+			confirmed, err = client.BitcoinTransactionConfirmed(ctx, hash)
+		case "ethereum":
+			confirmed, err = client.EthereumTransactionConfirmed(ctx, hash)
+		default:
+			return false, fmt.Errorf("payout on unsupported chain %s: %s", out.Chain, hash)
+		}
+		if err != nil {
+			if api.IsTransientError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if !confirmed {
+			return false, nil
+		}
+
+		_ = manager.RecordTransactions([]wallet.ActivityTransaction{{
+			Chain:     chain,
+			Network:   manager.GetCurrentNetwork(),
+			Hash:      hash,
+			Incoming:  true,
+			Amount:    fmt.Sprintf("%s %s", out.Amount.String(), symbol),
+			Timestamp: time.Now(),
+			Confirmed: true,
+		}})
+		report("confirmed:"+hash, fmt.Sprintf("✅ %s %s received and confirmed", out.Amount.String(), symbol))
+	}
+
+	fmt.Println()
+	if status.Refunded {
+		fmt.Println("↩️  Swap refunded to your wallet")
+		notifyUser("Odyssey: swap refunded", "The swap was refunded to your wallet")
+	} else {
+		fmt.Println("🎉 Swap complete!")
+		notifyUser("Odyssey: swap complete", "The swap payout is confirmed")
+	}
+	return true, nil
+}
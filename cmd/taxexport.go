@@ -0,0 +1,358 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+)
+
+// Tax report formats of export --format
+const (
+	TaxFormatKoinly       = "koinly"
+	TaxFormatCoinTracking = "cointracking"
+	TaxFormat8949         = "8949"
+)
+
+// taxHistoryPages limits how far back transaction history is fetched per chain
+const taxHistoryPages = 20
+
+var exportFormatFlag string
+
+// taxTransaction is a native transfer, one row of a tax report
+type taxTransaction struct {
+	time     time.Time
+	chain    string
+	hash     string
+	incoming bool
+	asset    string          // e.g. ETH
+	amount   decimal.Decimal // in whole coins
+	fee      decimal.Decimal // paid by this wallet, outgoing transfers only
+	value    decimal.Decimal // USD value of amount at the time of the transfer
+	priced   bool            // false if the historical price is unknown
+}
+
+// runTaxExport writes the transaction history of the current network as a
+// tax report that tax software imports. Only transactions that pass the
+// filter are listed, but Form 8949 cost basis comes from the whole history.
+func runTaxExport(ctx context.Context, manager *wallet.Manager, client *api.Client, filter txFilter) error {
+	format := strings.ToLower(exportFormatFlag)
+	switch format {
+	case TaxFormatKoinly, TaxFormatCoinTracking, TaxFormat8949:
+	default:
+		return fmt.Errorf("unknown tax report format: %s. Supported formats: %s, %s, %s", exportFormatFlag, TaxFormatKoinly, TaxFormatCoinTracking, TaxFormat8949)
+	}
+	if csvFlag || jsonFlag || txtFlag || xlsxFlag {
+		return fmt.Errorf("--format can't be combined with --csv, --json, --txt or --xlsx")
+	}
+	if manager.IsTestnet() {
+		return fmt.Errorf("tax reports are only available on mainnet, testnet coins have no value")
+	}
+
+	fmt.Println("📊 Collecting transaction history...")
+	transactions := collectTaxTransactions(ctx, manager, client)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if format != TaxFormat8949 {
+		transactions = filterTaxTransactions(transactions, filter)
+	}
+
+	fmt.Println("💵 Looking up prices at the time of each transaction...")
+	unpriced := priceTaxTransactions(ctx, client, transactions)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	exportDir, err := prepareExportDirectory()
+	if err != nil {
+		return fmt.Errorf("failed to prepare export directory: %w", err)
+	}
+	filename := filepath.Join(exportDir, fmt.Sprintf("odyssey_%s_%s_%s.csv", manager.GetCurrentNetwork(), format, time.Now().Format("20060102_150405")))
+
+	// Koinly and CoinTracking compute the cost basis themselves
+	var basis *costBasis
+	if format == TaxFormat8949 {
+		method, err := costBasisMethod("")
+		if err != nil {
+			return err
+		}
+		basis = computeCostBasis(transactions, method)
+
+		// Disposals are listed if their transaction passes the filter
+		selected := make(map[string]bool)
+		for _, tx := range filterTaxTransactions(transactions, filter) {
+			selected[tx.hash] = true
+		}
+		realized := basis.realized[:0]
+		for _, g := range basis.realized {
+			if selected[g.hash] {
+				realized = append(realized, g)
+			}
+		}
+		basis.realized = realized
+		transactions = filterTaxTransactions(transactions, filter)
+	}
+	rows := taxReportRows(format, transactions, basis)
+	if err := writeTaxReport(filename, rows); err != nil {
+		return fmt.Errorf("failed to write tax report: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println("📁 Tax report exported successfully!")
+	printResult(filename, "📍 File saved to: %s\n", filename)
+	fmt.Printf("   Transactions: %d\n", len(transactions))
+	if unpriced > 0 {
+		fmt.Printf("⚠️  %d transaction(s) have no historical price, their USD value is left empty\n", unpriced)
+	}
+	if basis != nil {
+		fmt.Printf("   Cost basis method: %s (tax.cost_basis)\n", strings.ToUpper(basis.method))
+		for asset, amount := range basis.unmatched {
+			fmt.Printf("⚠️  %s %s was sent without being received by this wallet, fill in its date acquired and cost basis from your records\n", amount.String(), asset)
+		}
+	}
+	return nil
+}
+
+// collectTaxTransactions reads the native transfers of every chain, oldest
+// first. Chains whose history can't be fetched are left out with a warning.
+func collectTaxTransactions(ctx context.Context, manager *wallet.Manager, client *api.Client) []taxTransaction {
+	type chainHistory struct {
+		name  string
+		asset string
+		fetch txPageFetcher
+	}
+
+	var chains []chainHistory
+	if address, err := manager.GetEthereumAddress(); err == nil {
+		chains = append(chains, chainHistory{name: "Ethereum", asset: "ETH",
+			fetch: func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+				return client.GetEthereumTransactions(ctx, address.Hex(), limit, cursor)
+			}})
+	}
+	if address, err := manager.GetBitcoinAddress(); err == nil {
+		chains = append(chains, chainHistory{name: "Bitcoin", asset: "BTC",
+			fetch: func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+				return client.GetBitcoinTransactions(ctx, address.String(), limit, cursor)
+			}})
+	}
+	if address, err := manager.GetSolanaAddress(); err == nil {
+		chains = append(chains, chainHistory{name: "Solana", asset: "SOL",
+			fetch: func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+				return client.GetSolanaTransactions(ctx, address.String(), limit, cursor)
+			}})
+	}
+
+	var transactions []taxTransaction
+	for _, chain := range chains {
+		cursor := ""
+		for page := 0; page < taxHistoryPages; page++ {
+			result, err := chain.fetch(ctx, 50, cursor)
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Printf("⚠️  Warning: Failed to fetch %s transactions: %v\n", chain.name, err)
+				}
+				break
+			}
+
+			for _, tx := range result.Transactions {
+				// Pending transactions and transfers to ourselves aren't taxable events
+				if tx.BlockNumber == 0 || strings.EqualFold(tx.From, tx.To) {
+					continue
+				}
+				amount, ok := parseTaxAmount(tx.Amount)
+				if !ok || amount.IsZero() {
+					continue
+				}
+				taxTx := taxTransaction{
+					time:     tx.Timestamp,
+					chain:    chain.name,
+					hash:     tx.Hash,
+					incoming: tx.IsIncoming,
+					asset:    chain.asset,
+					amount:   amount,
+				}
+				// The sender pays the fee
+				if !tx.IsIncoming {
+					taxTx.fee, _ = parseTaxAmount(tx.Fee)
+				}
+				transactions = append(transactions, taxTx)
+			}
+
+			if result.NextCursor == "" {
+				break
+			}
+			cursor = result.NextCursor
+		}
+	}
+
+	sort.Slice(transactions, func(i, j int) bool { return transactions[i].time.Before(transactions[j].time) })
+	return transactions
+}
+
+// filterTaxTransactions returns the transactions that pass a filter
+func filterTaxTransactions(transactions []taxTransaction, filter txFilter) []taxTransaction {
+	if !filter.active() {
+		return transactions
+	}
+	var selected []taxTransaction
+	for _, tx := range transactions {
+		if filter.match(tx.time, tx.incoming, tx.amount) {
+			selected = append(selected, tx)
+		}
+	}
+	return selected
+}
+
+// priceTaxTransactions values every transaction at the price of its day and
+// returns how many couldn't be priced
+func priceTaxTransactions(ctx context.Context, client *api.Client, transactions []taxTransaction) int {
+	// Days without a price aren't asked for again
+	type priceKey struct{ asset, day string }
+	failed := make(map[priceKey]bool)
+
+	unpriced := 0
+	for i := range transactions {
+		tx := &transactions[i]
+		key := priceKey{tx.asset, tx.time.UTC().Format(time.DateOnly)}
+		if failed[key] {
+			unpriced++
+			continue
+		}
+		price, err := client.GetHistoricalPrice(ctx, taxPriceIDs[tx.asset], tx.time)
+		if err != nil {
+			if ctx.Err() != nil {
+				return unpriced
+			}
+			failed[key] = true
+			unpriced++
+			continue
+		}
+		tx.value = tx.amount.Mul(price.USD)
+		tx.priced = true
+	}
+	return unpriced
+}
+
+// taxReportRows lays out the transactions in the columns of a tax report
+// format, header first. Form 8949 lists the realized gains of basis instead.
+func taxReportRows(format string, transactions []taxTransaction, basis *costBasis) [][]string {
+	var rows [][]string
+	switch format {
+	case TaxFormatKoinly:
+		// Koinly universal format
+		rows = append(rows, []string{"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency",
+			"Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash"})
+		for _, tx := range transactions {
+			row := make([]string, 12)
+			row[0] = tx.time.UTC().Format("2006-01-02 15:04:05 UTC")
+			if tx.incoming {
+				row[3], row[4] = tx.amount.String(), tx.asset
+			} else {
+				row[1], row[2] = tx.amount.String(), tx.asset
+			}
+			if tx.fee.IsPositive() {
+				row[5], row[6] = tx.fee.String(), tx.asset
+			}
+			if tx.priced {
+				row[7], row[8] = tx.value.StringFixed(2), "USD"
+			}
+			row[10] = tx.chain + " transfer"
+			row[11] = tx.hash
+			rows = append(rows, row)
+		}
+
+	case TaxFormatCoinTracking:
+		// CoinTracking CSV import, dates in UTC
+		rows = append(rows, []string{"Type", "Buy Amount", "Buy Currency", "Sell Amount", "Sell Currency", "Fee", "Fee Currency",
+			"Exchange", "Trade-Group", "Comment", "Date", "Tx-ID", "Buy Value in Account Currency", "Sell Value in Account Currency"})
+		for _, tx := range transactions {
+			row := make([]string, 14)
+			value := ""
+			if tx.priced {
+				value = tx.value.StringFixed(2)
+			}
+			if tx.incoming {
+				row[0], row[1], row[2], row[12] = "Deposit", tx.amount.String(), tx.asset, value
+			} else {
+				row[0], row[3], row[4], row[13] = "Withdrawal", tx.amount.String(), tx.asset, value
+			}
+			if tx.fee.IsPositive() {
+				row[5], row[6] = tx.fee.String(), tx.asset
+			}
+			row[7] = "Odyssey"
+			row[9] = tx.chain + " transfer"
+			row[10] = tx.time.UTC().Format("2006-01-02 15:04:05")
+			row[11] = tx.hash
+			rows = append(rows, row)
+		}
+
+	case TaxFormat8949:
+		// IRS Form 8949 columns (a) to (h), one row per lot a disposal was
+		// matched against, short-term (part I) before long-term (part II)
+		rows = append(rows, []string{"Description of property", "Date acquired", "Date sold or disposed of", "Proceeds",
+			"Cost or other basis", "Code", "Adjustment", "Gain or (loss)", "Term"})
+		for _, longTerm := range []bool{false, true} {
+			for _, g := range basis.realized {
+				if g.longTerm() != longTerm {
+					continue
+				}
+				acquired, cost, term := "", "", "Short"
+				if !g.acquired.IsZero() {
+					acquired, cost = g.acquired.UTC().Format("01/02/2006"), g.cost.StringFixed(2)
+				}
+				if longTerm {
+					term = "Long"
+				}
+				rows = append(rows, []string{
+					fmt.Sprintf("%s %s", g.amount.String(), g.asset),
+					acquired,
+					g.disposed.UTC().Format("01/02/2006"),
+					g.proceeds.StringFixed(2),
+					cost,
+					"", "",
+					g.gain().StringFixed(2),
+					term,
+				})
+			}
+		}
+	}
+	return rows
+}
+
+// writeTaxReport writes the rows of a tax report to a CSV file only the owner
+// can read
+func writeTaxReport(filename string, rows [][]string) error {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// parseTaxAmount parses an amount such as "0.100000 ETH"
+func parseTaxAmount(amount string) (decimal.Decimal, bool) {
+	fields := strings.Fields(amount)
+	if len(fields) == 0 {
+		return decimal.Zero, false
+	}
+	value, err := decimal.NewFromString(fields[0])
+	if err != nil {
+		return decimal.Zero, false
+	}
+	return value, true
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/chains/solana"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/spf13/cobra"
+)
+
+// Metadata of 'token add', read from the chain when left out
+var (
+	tokenSymbolFlag      string
+	tokenNameFlag        string
+	tokenDecimalsFlag    int32
+	tokenCoingeckoIDFlag string
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage the tokens tracked on Ethereum and Solana",
+	Long: `List the tokens whose balances are shown, and track other ERC-20 tokens and
+SPL mints.
+
+The name, symbol and decimals of a token are read from the chain: ERC-20
+contracts through their name(), symbol() and decimals() methods, SPL mints
+from the mint account and the Jupiter token list. --symbol, --name and
+--decimals set them by hand, e.g. for tokens that don't report them.
+--coingecko-id gives the token a price.
+
+Tokens are stored per network in ~/.odyssey/tokens.json and shown in the
+portfolio, transaction details and swaps. Tokens seen in transactions are
+named the same way even if they aren't tracked. Tokens on the blocklist
+(screening.blocklist_file) can't be added, and transfers of them are hidden
+from transaction details.
+
+Examples:
+  odyssey token                                   # List tracked tokens
+  odyssey token add eth 0x6982508145454Ce325dDbE47a25d4ec3d2311933
+  odyssey token add eth 0x1234... --symbol XYZ --decimals 18
+  odyssey token add sol JUPyiwrYJFskUPiHa7hkeR8VUtAeFoSYbKedZNsDvCN --coingecko-id jupiter-exchange-solana
+  odyssey token remove eth XYZ`,
+	Args: cobra.NoArgs,
+	RunE: runTokenList,
+}
+
+var tokenAddCmd = &cobra.Command{
+	Use:   "add [chain] [address]",
+	Short: "Track an ERC-20 token or SPL mint",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runTokenAdd,
+}
+
+var tokenRemoveCmd = &cobra.Command{
+	Use:   "remove [chain] [symbol|address]",
+	Short: "Stop tracking a token",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runTokenRemove,
+}
+
+func init() {
+	tokenAddCmd.Flags().StringVar(&tokenSymbolFlag, "symbol", "", "Symbol of the token instead of the one it reports")
+	tokenAddCmd.Flags().StringVar(&tokenNameFlag, "name", "", "Name of the token instead of the one it reports")
+	tokenAddCmd.Flags().Int32Var(&tokenDecimalsFlag, "decimals", -1, "Decimals of the token instead of the ones it reports")
+	tokenAddCmd.Flags().StringVar(&tokenCoingeckoIDFlag, "coingecko-id", "", "CoinGecko ID of the token, to show its value")
+
+	tokenCmd.AddCommand(tokenAddCmd)
+	tokenCmd.AddCommand(tokenRemoveCmd)
+}
+
+// tokenChain returns the chain of a token command, ethereum or solana
+func tokenChain(name string) (string, error) {
+	switch strings.ToLower(name) {
+	case "eth", "ethereum":
+		return "ethereum", nil
+	case "sol", "solana":
+		return "solana", nil
+	}
+	return "", fmt.Errorf("unsupported chain: %s. Tokens can be added on eth and sol", name)
+}
+
+func runTokenList(cmd *cobra.Command, args []string) error {
+	client := api.NewClient()
+	network := config.ActiveNetwork().Name
+
+	added := make(map[string]bool)
+	for _, token := range append(config.CustomTokensOf("ethereum", network), config.CustomTokensOf("solana", network)...) {
+		added[strings.ToLower(token.Address)] = true
+	}
+
+	fmt.Printf("🪙 Tracked Tokens (%s)\n", network)
+	fmt.Println()
+
+	blocklist, err := config.LoadBlocklist()
+	if err != nil {
+		fmt.Printf("⚠️  The blocklist couldn't be read: %v\n", err)
+	}
+
+	for _, chain := range []struct {
+		name   string
+		label  string
+		tokens []api.Token
+	}{
+		{"ethereum", ethereumLabel(), client.GetEthereumTokens()},
+		{"solana", solanaLabel(), client.GetSolanaTokens()},
+	} {
+		fmt.Printf("%s\n", chain.label)
+		if len(chain.tokens) == 0 {
+			fmt.Println("   No tokens tracked")
+		}
+		for _, token := range chain.tokens {
+			source := "built-in"
+			if added[strings.ToLower(token.Address)] {
+				source = "added"
+			}
+			fmt.Printf("   %-8s %-20s %s (%d decimals, %s)\n", token.Symbol, token.Name, token.Address, token.Decimals, source)
+		}
+		// Added tokens put on the blocklist later are hidden everywhere else
+		for _, token := range config.CustomTokensOf(chain.name, network) {
+			if entry := blocklist.Token(chain.name, token.Address); entry != nil {
+				fmt.Printf("   🚫 %-5s %-20s %s (hidden, blocklisted: %s)\n", token.Symbol, token.Name, token.Address, blocklistReason(entry))
+			}
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("💡 Track another token with: odyssey token add [chain] [address]")
+	return nil
+}
+
+func runTokenAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	chain, err := tokenChain(args[0])
+	if err != nil {
+		return err
+	}
+	network := config.ActiveNetwork().Name
+	client := api.NewClient()
+
+	address := args[1]
+	var tracked []api.Token
+	if chain == "ethereum" {
+		parsed, err := ethereum.ParseAddress(address)
+		if err != nil {
+			return fmt.Errorf("invalid token address: %w", err)
+		}
+		address = parsed.Hex()
+		tracked = client.GetEthereumTokens()
+	} else {
+		parsed, err := solana.ParseAddress(address)
+		if err != nil {
+			return fmt.Errorf("invalid mint address: %w", err)
+		}
+		address = parsed.String()
+		tracked = client.GetSolanaTokens()
+	}
+
+	// Built-in tokens are tracked on mainnet already
+	builtin := api.EthereumTokens
+	if chain == "solana" {
+		builtin = api.SolanaTokens
+	}
+	for _, token := range builtin {
+		if strings.EqualFold(token.Address, address) && !client.IsTestnet() {
+			return fmt.Errorf("%s is already tracked as %s", address, token.Symbol)
+		}
+	}
+
+	// Scam tokens often copy the name and symbol of the token they imitate
+	if blocklist, err := config.LoadBlocklist(); err == nil {
+		if entry := blocklist.Token(chain, address); entry != nil {
+			return fmt.Errorf("%s is on the blocklist: %s", address, blocklistReason(entry))
+		}
+	}
+
+	// Metadata the chain reports, flags take precedence
+	var reported *api.Token
+	if chain == "ethereum" {
+		reported, err = client.GetERC20Metadata(ctx, address)
+	} else {
+		reported, err = client.GetSPLTokenMetadata(ctx, address)
+	}
+	if err != nil {
+		if tokenSymbolFlag == "" || tokenDecimalsFlag < 0 {
+			return fmt.Errorf("failed to read the token's metadata: %w. Give --symbol and --decimals to add it anyway", err)
+		}
+		fmt.Printf("⚠️  Failed to read the token's metadata, adding it as given: %v\n", err)
+		reported = &api.Token{}
+	}
+
+	token := config.CustomToken{
+		Chain:       chain,
+		Network:     network,
+		Address:     address,
+		Symbol:      reported.Symbol,
+		Name:        reported.Name,
+		Decimals:    reported.Decimals,
+		CoingeckoID: tokenCoingeckoIDFlag,
+	}
+	if tokenSymbolFlag != "" {
+		token.Symbol = tokenSymbolFlag
+	}
+	if tokenNameFlag != "" {
+		token.Name = tokenNameFlag
+	}
+	if tokenDecimalsFlag >= 0 {
+		if reported.Address != "" && tokenDecimalsFlag != reported.Decimals {
+			fmt.Printf("⚠️  The token reports %d decimals, balances are shown with %d as given\n", reported.Decimals, tokenDecimalsFlag)
+		}
+		token.Decimals = tokenDecimalsFlag
+	}
+	if token.Symbol == "" {
+		return fmt.Errorf("the token reports no symbol. Give one with --symbol")
+	}
+	if token.Name == "" {
+		token.Name = token.Symbol
+	}
+
+	// A symbol shared with another token would make balances ambiguous
+	for _, existing := range tracked {
+		if strings.EqualFold(existing.Symbol, token.Symbol) && !strings.EqualFold(existing.Address, address) {
+			fmt.Printf("⚠️  %s is also the symbol of %s, check the address before trusting its balance\n", token.Symbol, existing.Address)
+		}
+	}
+
+	if err := config.AddToken(token); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Tracking %s (%s) on %s\n", token.Symbol, token.Name, network)
+	fmt.Printf("   Address:  %s\n", token.Address)
+	fmt.Printf("   Decimals: %d\n", token.Decimals)
+	if token.CoingeckoID == "" {
+		fmt.Println("💡 Give --coingecko-id to show its value")
+	}
+	return nil
+}
+
+func runTokenRemove(cmd *cobra.Command, args []string) error {
+	chain, err := tokenChain(args[0])
+	if err != nil {
+		return err
+	}
+	network := config.ActiveNetwork().Name
+
+	removed, err := config.RemoveToken(chain, network, args[1])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🗑️  Stopped tracking %s (%s) on %s\n", removed.Symbol, removed.Address, network)
+	return nil
+}
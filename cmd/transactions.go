@@ -3,73 +3,120 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/chains"
 	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 )
 
 var (
-	pageFlag  int
-	limitFlag int
+	pageFlag                int
+	limitFlag               int
+	cursorFlag              string
+	transactionsAddressFlag string
 )
 
+// transactionsFilter selects the transactions shown, see addTxFilterFlags
+var transactionsFilter txFilter
+
 type ChainResult struct {
 	Chain        string
 	Transactions []api.Transaction
 	Address      string
+	NextCursor   string
 	Error        error
 }
 
+// txPageFetcher fetches a single page of history for one chain
+type txPageFetcher func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error)
+
 var transactionsCmd = &cobra.Command{
 	Use:   "transactions [chain]",
 	Short: "Show transaction history with pagination",
 	Long: `Show transaction history for the specified blockchain with pagination support.
-Supported chains: eth, btc, sol
+Supported chains: eth, btc, sol, polygon, arbitrum, optimism, base, bsc
 
 Examples:
   odyssey transactions               # Show all transactions (page 1)
   odyssey transactions --page 2     # Show page 2 of all transactions
   odyssey transactions eth --page 1 # Show page 1 of Ethereum transactions
   odyssey transactions sol --limit 5 # Show 5 Solana transactions per page
+  odyssey transactions sol --cursor 5h6x...  # Continue from a cursor
+  odyssey transactions eth --direction out --since 2025-01-01 --until 2025-03-31
+  odyssey transactions btc --min-amount 0.01
+  odyssey transactions eth --address 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6
+
+Pagination: 10 transactions per page by default. Each page prints a cursor
+that can be passed to --cursor to continue from where it left off.
 
-Pagination: Max 3 pages, 10 transactions per page by default`,
+Filters: --since and --until take a date (whole days, local time) or an
+RFC 3339 time, --direction takes in or out, and --min-amount an amount in
+the chain's coin. Filtered pages are filled from as much history as needed,
+so they can hold a few more transactions than --limit.
+
+--address shows the history of another address of a chain, e.g. an account
+found by 'odyssey discover'.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runTransactions,
 }
 
 func init() {
-	transactionsCmd.Flags().IntVarP(&pageFlag, "page", "p", 1, "Page number (1-3)")
-	transactionsCmd.Flags().IntVarP(&limitFlag, "limit", "l", 10, "Transactions per page (1-20)")
+	transactionsCmd.Flags().IntVarP(&pageFlag, "page", "p", 1, "Page number, counted from the cursor")
+	transactionsCmd.Flags().IntVarP(&limitFlag, "limit", "l", 10, "Transactions per page (1-50)")
+	transactionsCmd.Flags().StringVarP(&cursorFlag, "cursor", "c", "", "Continue from a cursor printed by a previous page")
+	transactionsCmd.Flags().StringVar(&transactionsAddressFlag, "address", "", "Show the history of another address of the chain")
+	addTxFilterFlags(transactionsCmd)
 }
 
 func runTransactions(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	// Validate pagination parameters
-	if pageFlag < 1 || pageFlag > 3 {
-		return fmt.Errorf("page must be between 1 and 3")
+	if pageFlag < 1 {
+		return fmt.Errorf("page must be 1 or greater")
+	}
+	if limitFlag < 1 || limitFlag > 50 {
+		return fmt.Errorf("limit must be between 1 and 50")
 	}
-	if limitFlag < 1 || limitFlag > 20 {
-		return fmt.Errorf("limit must be between 1 and 20")
+	filter, err := parseTxFilter()
+	if err != nil {
+		return err
 	}
+	transactionsFilter = filter
 
 	manager := wallet.NewManager()
 	client := api.NewClient()
 
 	// Check if wallet is unlocked
 	if !manager.IsUnlocked() {
-		return fmt.Errorf("wallet is locked. Run 'odyssey unlock' first")
+		return errWalletLocked
 	}
 
+	// Label known contracts in the history
+	loadContractLabels(manager)
+
 	// Show loading indicator
 	fmt.Println("🔄 Loading transactions...")
+	if filter.active() {
+		fmt.Printf("🔎 Filter: %s\n", filter)
+	}
 	startTime := time.Now()
 
 	// If no chain specified, show all transactions
 	if len(args) == 0 {
-		err := showAllTransactionsPaginated(manager, client)
+		// Cursors are chain specific and can't be shared across chains
+		if cursorFlag != "" {
+			return fmt.Errorf("--cursor requires a chain, e.g. 'odyssey transactions eth --cursor %s'", cursorFlag)
+		}
+		if transactionsAddressFlag != "" {
+			return fmt.Errorf("--address requires a chain, e.g. 'odyssey transactions eth --address %s'", transactionsAddressFlag)
+		}
+		err := showAllTransactionsPaginated(ctx, manager, client)
 		elapsed := time.Since(startTime)
 		fmt.Printf("\n⏱️ Loaded in %v\n", elapsed.Round(time.Millisecond*10))
 		return err
@@ -77,209 +124,96 @@ func runTransactions(cmd *cobra.Command, args []string) error {
 
 	// Show specific chain transactions
 	chain := strings.ToLower(args[0])
-	err := showChainTransactionsPaginated(manager, client, chain)
+	err = showChainTransactionsPaginated(ctx, manager, client, chain)
 	elapsed := time.Since(startTime)
 	fmt.Printf("\n⏱️ Loaded in %v\n", elapsed.Round(time.Millisecond*10))
 	return err
 }
 
-func showAllTransactionsPaginated(manager *wallet.Manager, client *api.Client) error {
+// fetchTransactionPage follows cursors from --cursor to the requested --page,
+// counting only transactions that pass the filters. Every request times out
+// after network.timeout.history.
+func fetchTransactionPage(ctx context.Context, fetch txPageFetcher) (*api.TransactionPage, error) {
+	fetch = transactionsFilter.wrap(fetch)
+	cursor := cursorFlag
+	for i := 1; ; i++ {
+		page, err := fetch(ctx, limitFlag, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if i == pageFlag {
+			return page, nil
+		}
+		if page.NextCursor == "" {
+			// Ran out of history before reaching the requested page
+			return &api.TransactionPage{Transactions: []api.Transaction{}}, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+func showAllTransactionsPaginated(ctx context.Context, manager *wallet.Manager, client *api.Client) error {
 	// Display network information
 	networkType := "Mainnet"
 	if manager.IsTestnet() {
 		networkType = "Testnet"
 	}
 
-	fmt.Printf("📜 Transaction history (Page %d/%d):\n", pageFlag, 3)
+	fmt.Printf("📜 Transaction history (Page %d):\n", pageFlag)
 	fmt.Printf("🌐 Network: %s\n", networkType)
 	fmt.Println()
 
-	// Calculate offset for pagination
-	offset := (pageFlag - 1) * limitFlag
-
-	// Prepare channels for parallel fetching
-	resultChan := make(chan ChainResult, 3)
+	// Every chain resolves its address and fetches its page in the background
+	opened := defaultChains(manager, client)
+	results := make([]ChainResult, len(opened))
 	var wg sync.WaitGroup
-
-	// Fetch Ethereum transactions in parallel
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		address, err := manager.GetEthereumAddress()
-		if err != nil {
-			resultChan <- ChainResult{Chain: "ethereum", Error: err}
-			return
-		}
-
-		// Create context with timeout to avoid long waits
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer cancel()
-
-		// Channel for API result
-		txChan := make(chan []api.Transaction, 1)
-		errChan := make(chan error, 1)
-
-		// Fetch with timeout
-		go func() {
-			txs, err := client.GetEthereumTransactions(address.Hex())
-			if err != nil {
-				errChan <- err
-			} else {
-				txChan <- txs
-			}
-		}()
-
-		// Wait for result or timeout
-		var allTxs []api.Transaction
-		var fetchErr error
-
-		select {
-		case allTxs = <-txChan:
-			// Success
-		case fetchErr = <-errChan:
-			// Error
-		case <-ctx.Done():
-			fetchErr = fmt.Errorf("timeout fetching transactions (>60s)")
-		}
-
-		txs := applyPagination(allTxs, offset, limitFlag)
-		resultChan <- ChainResult{
-			Chain:        "ethereum",
-			Transactions: txs,
-			Address:      address.Hex(),
-			Error:        fetchErr,
-		}
-	}()
-
-	// Fetch Bitcoin transactions in parallel (only on mainnet)
-	if !manager.IsTestnet() {
+	for i, chain := range opened {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			address, err := manager.GetBitcoinAddress()
+			address, err := chain.Address()
 			if err != nil {
-				resultChan <- ChainResult{Chain: "bitcoin", Error: err}
+				results[i] = ChainResult{Chain: chain.Name(), Error: err}
 				return
 			}
 
-			// Create context with timeout to avoid long waits
-			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-			defer cancel()
-
-			// Channel for API result
-			txChan := make(chan []api.Transaction, 1)
-			errChan := make(chan error, 1)
-
-			// Fetch with timeout
-			go func() {
-				txs, err := client.GetBitcoinTransactions(address.String())
-				if err != nil {
-					errChan <- err
-				} else {
-					txChan <- txs
-				}
-			}()
-
-			// Wait for result or timeout
-			var allTxs []api.Transaction
-			var fetchErr error
-
-			select {
-			case allTxs = <-txChan:
-				// Success
-			case fetchErr = <-errChan:
-				// Error
-			case <-ctx.Done():
-				fetchErr = fmt.Errorf("timeout fetching transactions (>60s)")
-			}
+			page, err := fetchTransactionPage(ctx, func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+				return chain.History(ctx, address, limit, cursor)
+			})
 
-			txs := applyPagination(allTxs, offset, limitFlag)
-			resultChan <- ChainResult{
-				Chain:        "bitcoin",
-				Transactions: txs,
-				Address:      address.String(),
-				Error:        fetchErr,
+			result := ChainResult{Chain: chain.Name(), Address: address, Error: err}
+			if page != nil {
+				result.Transactions = page.Transactions
+				result.NextCursor = page.NextCursor
 			}
+			results[i] = result
 		}()
 	}
+	wg.Wait()
 
-	// Fetch Solana transactions in parallel
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		address, err := manager.GetSolanaAddress()
-		if err != nil {
-			resultChan <- ChainResult{Chain: "solana", Error: err}
-			return
-		}
-
-		// Create context with timeout to avoid long waits
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer cancel()
-
-		// Channel for API result
-		txChan := make(chan []api.Transaction, 1)
-		errChan := make(chan error, 1)
-
-		// Fetch with timeout
-		go func() {
-			txs, err := client.GetSolanaTransactions(address.String())
-			if err != nil {
-				errChan <- err
-			} else {
-				txChan <- txs
-			}
-		}()
-
-		// Wait for result or timeout
-		var allTxs []api.Transaction
-		var fetchErr error
-
-		select {
-		case allTxs = <-txChan:
-			// Success
-		case fetchErr = <-errChan:
-			// Error
-		case <-ctx.Done():
-			fetchErr = fmt.Errorf("timeout fetching transactions (>60s)")
-		}
-
-		txs := applyPagination(allTxs, offset, limitFlag)
-		resultChan <- ChainResult{
-			Chain:        "solana",
-			Transactions: txs,
-			Address:      address.String(),
-			Error:        fetchErr,
+	// Cache what was seen for the summary on unlock
+	for _, result := range results {
+		if result.Error == nil {
+			recordActivity(manager, result.Chain, result.Transactions)
 		}
-	}()
-
-	// Wait for all goroutines to complete
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	// Collect results
-	results := make(map[string]ChainResult)
-	for result := range resultChan {
-		results[result.Chain] = result
 	}
 
 	// Display results in order
-	displayChainResult(results["ethereum"], "🔷", "Ethereum", manager.IsTestnet(), client)
-
-	if !manager.IsTestnet() {
-		displayChainResult(results["bitcoin"], "🟠", "Bitcoin", false, client)
+	for i, chain := range opened {
+		displayChainResult(ctx, results[i], chain, manager.IsTestnet(), client)
 	}
 
-	displayChainResult(results["solana"], "🟣", "Solana", manager.IsTestnet(), client)
-
 	// Show pagination info
-	showPaginationInfo()
+	showPaginationInfo("")
 	return nil
 }
 
-func showChainTransactionsPaginated(manager *wallet.Manager, client *api.Client, chain string) error {
+func showChainTransactionsPaginated(ctx context.Context, manager *wallet.Manager, client *api.Client, name string) error {
+	chain, err := openChain(name, manager, client)
+	if err != nil {
+		return err
+	}
+
 	// Display network information
 	networkType := "Mainnet"
 	if manager.IsTestnet() {
@@ -289,235 +223,91 @@ func showChainTransactionsPaginated(manager *wallet.Manager, client *api.Client,
 	fmt.Printf("🌐 Network: %s\n", networkType)
 	fmt.Println()
 
-	// Calculate offset for pagination
-	offset := (pageFlag - 1) * limitFlag
-
-	switch chain {
-	case "eth", "ethereum":
-		address, err := manager.GetEthereumAddress()
-		if err != nil {
-			return fmt.Errorf("failed to get Ethereum address: %w", err)
-		}
-
-		chainName := "Ethereum (ETH)"
-		explorerBase := "https://etherscan.io"
-		if manager.IsTestnet() {
-			chainName = "Ethereum (Sepolia)"
-			explorerBase = "https://sepolia.etherscan.io"
-		}
-
-		fmt.Printf("🔷 %s transactions for: %s\n", chainName, address.Hex())
-		fmt.Printf("📄 Page %d/%d (%d per page)\n\n", pageFlag, 3, limitFlag)
-
-		// Fetch transactions with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer cancel()
-
-		txChan := make(chan []api.Transaction, 1)
-		errChan := make(chan error, 1)
-
-		go func() {
-			txs, err := client.GetEthereumTransactions(address.Hex())
-			if err != nil {
-				errChan <- err
-			} else {
-				txChan <- txs
-			}
-		}()
-
-		var allTxs []api.Transaction
-		var fetchErr error
-
-		select {
-		case allTxs = <-txChan:
-			// Success
-		case fetchErr = <-errChan:
-			// Error
-		case <-ctx.Done():
-			fetchErr = fmt.Errorf("timeout fetching transactions (>60s)")
-		}
-
-		txs := applyPagination(allTxs, offset, limitFlag)
-		if fetchErr != nil {
-			fmt.Printf("❌ Error fetching transactions: %v\n", fetchErr)
-			fmt.Printf("💡 View on Etherscan: %s/address/%s\n", explorerBase, address.Hex())
-		} else if len(txs) == 0 {
-			if pageFlag == 1 {
-				fmt.Println("No transactions found")
-			} else {
-				fmt.Println("No more transactions on this page")
-			}
-		} else {
-			printTransactionsPaginated(txs, client, "ethereum", manager.IsTestnet())
-		}
-
-	case "btc", "bitcoin":
-		if manager.IsTestnet() {
-			return fmt.Errorf("bitcoin is not supported in testnet mode")
-		}
-
-		address, err := manager.GetBitcoinAddress()
-		if err != nil {
-			return fmt.Errorf("failed to get Bitcoin address: %w", err)
-		}
-
-		fmt.Printf("🟠 Bitcoin (BTC) transactions for: %s\n", address.String())
-		fmt.Printf("📄 Page %d/%d (%d per page)\n\n", pageFlag, 3, limitFlag)
-
-		// Fetch transactions with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer cancel()
-
-		txChan := make(chan []api.Transaction, 1)
-		errChan := make(chan error, 1)
-
-		go func() {
-			txs, err := client.GetBitcoinTransactions(address.String())
-			if err != nil {
-				errChan <- err
-			} else {
-				txChan <- txs
-			}
-		}()
-
-		var allTxs []api.Transaction
-		var fetchErr error
+	own, err := chain.Address()
+	if err != nil {
+		return fmt.Errorf("failed to get %s address: %w", chainLabel(chain), err)
+	}
+	addressText, err := transactionsAddress(chain.Name(), own)
+	if err != nil {
+		return err
+	}
 
-		select {
-		case allTxs = <-txChan:
-			// Success
-		case fetchErr = <-errChan:
-			// Error
-		case <-ctx.Done():
-			fetchErr = fmt.Errorf("timeout fetching transactions (>60s)")
-		}
+	chainName := chainLabel(chain)
+	if !manager.IsTestnet() {
+		chainName = fmt.Sprintf("%s (%s)", chainName, chain.Symbol())
+	}
+	fmt.Printf("%s %s transactions for: %s\n", chainEmoji(chain), chainName, addressText)
+	fmt.Printf("📄 Page %d (%d per page)\n\n", pageFlag, limitFlag)
 
-		txs := applyPagination(allTxs, offset, limitFlag)
-		if fetchErr != nil {
-			fmt.Printf("❌ Error fetching transactions: %v\n", fetchErr)
-			fmt.Printf("💡 View on Blockstream: https://blockstream.info/address/%s\n", address.String())
-		} else if len(txs) == 0 {
-			if pageFlag == 1 {
-				fmt.Println("No transactions found")
-			} else {
-				fmt.Println("No more transactions on this page")
-			}
-		} else {
-			printTransactionsPaginated(txs, client, "bitcoin", manager.IsTestnet())
-		}
+	page, fetchErr := fetchTransactionPage(ctx, func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+		return chain.History(ctx, addressText, limit, cursor)
+	})
 
-	case "sol", "solana":
-		address, err := manager.GetSolanaAddress()
-		if err != nil {
-			return fmt.Errorf("failed to get Solana address: %w", err)
+	if fetchErr != nil {
+		fmt.Printf("❌ Error fetching transactions: %v\n", fetchErr)
+		if link := explorerURL(chain.Name(), "address", addressText); link != "" {
+			fmt.Printf("💡 View on explorer: %s\n", link)
 		}
-
-		chainName := "Solana"
-		explorerBase := "https://solscan.io/account"
-		clusterParam := ""
-		if manager.IsTestnet() {
-			chainName = "Solana (Devnet)"
-			clusterParam = "?cluster=devnet"
+	} else if len(page.Transactions) == 0 {
+		printEmptyPage(page.NextCursor)
+		if chain.Name() == "solana" && pageFlag == 1 && cursorFlag == "" {
+			fmt.Println("💡 Tip: Solana accounts don't exist until they receive SOL")
 		}
+	} else {
+		printTransactionsPaginated(ctx, page.Transactions, client, chain.Name(), manager.IsTestnet())
+		recordActivity(manager, chain.Name(), page.Transactions)
+	}
 
-		fmt.Printf("🟣 %s transactions for: %s\n", chainName, address.String())
-		fmt.Printf("📄 Page %d/%d (%d per page)\n", pageFlag, 3, limitFlag)
-		fmt.Printf("💡 View on Solscan: %s/%s%s\n\n", explorerBase, address.String(), clusterParam)
-
-		// Fetch transactions with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer cancel()
-
-		txChan := make(chan []api.Transaction, 1)
-		errChan := make(chan error, 1)
-
-		go func() {
-			txs, err := client.GetSolanaTransactions(address.String())
-			if err != nil {
-				errChan <- err
-			} else {
-				txChan <- txs
-			}
-		}()
-
-		var allTxs []api.Transaction
-		var fetchErr error
+	var nextCursor string
+	if page != nil {
+		nextCursor = page.NextCursor
+	}
 
-		select {
-		case allTxs = <-txChan:
-			// Success
-		case fetchErr = <-errChan:
-			// Error
-		case <-ctx.Done():
-			fetchErr = fmt.Errorf("timeout fetching transactions (>60s)")
-		}
+	// Show pagination info
+	showPaginationInfo(nextCursor)
+	return nil
+}
 
-		txs := applyPagination(allTxs, offset, limitFlag)
-		if fetchErr != nil {
-			fmt.Printf("❌ Error fetching transactions: %v\n", fetchErr)
-		} else if len(txs) == 0 {
-			if pageFlag == 1 {
-				fmt.Println("No transactions found")
-				fmt.Println("💡 Tip: Solana accounts don't exist until they receive SOL")
-			} else {
-				fmt.Println("No more transactions on this page")
-			}
-		} else {
-			printTransactionsPaginated(txs, client, "solana", manager.IsTestnet())
-		}
+// transactionsAddress returns the address whose history is shown, the
+// wallet's own unless --address names another one
+func transactionsAddress(chain, own string) (string, error) {
+	if transactionsAddressFlag == "" {
+		return own, nil
+	}
+	return normalizeWatchAddress(chain, strings.TrimSpace(transactionsAddressFlag))
+}
 
+// printEmptyPage explains why a page has no transactions
+func printEmptyPage(nextCursor string) {
+	switch {
+	case nextCursor != "":
+		// Block-range based pages can be empty while older history still exists
+		fmt.Println("No transactions in this range, older history may exist")
+	case pageFlag == 1 && cursorFlag == "":
+		fmt.Println("No transactions found")
 	default:
-		return fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol", chain)
+		fmt.Println("No more transactions on this page")
 	}
-
-	// Show pagination info
-	showPaginationInfo()
-	return nil
 }
 
-func displayChainResult(result ChainResult, emoji, name string, isTestnet bool, client *api.Client) {
+func displayChainResult(ctx context.Context, result ChainResult, chain chains.Chain, isTestnet bool, client *api.Client) {
 	// Handle case where result might be empty
 	if result.Chain == "" {
 		return
 	}
-	displayName := name
-	explorerBase := ""
-
-	switch name {
-	case "Ethereum":
-		if isTestnet {
-			displayName = "Ethereum (Sepolia)"
-			explorerBase = "https://sepolia.etherscan.io"
-		} else {
-			explorerBase = "https://etherscan.io"
-		}
-	case "Bitcoin":
-		explorerBase = "https://blockstream.info"
-	case "Solana":
-		if isTestnet {
-			displayName = "Solana (Devnet)"
-			explorerBase = "https://solscan.io"
-		} else {
-			explorerBase = "https://solscan.io"
-		}
-	}
 
-	fmt.Printf("%s %s:\n", emoji, displayName)
+	fmt.Printf("%s %s:\n", chainEmoji(chain), chainLabel(chain))
 	if result.Error != nil {
 		fmt.Printf("   ❌ Error fetching transactions: %v\n", result.Error)
-		if result.Address != "" {
-			if name == "Solana" && isTestnet {
-				fmt.Printf("   💡 View on explorer: %s/account/%s?cluster=devnet\n", explorerBase, result.Address)
-			} else if name == "Solana" {
-				fmt.Printf("   💡 View on explorer: %s/account/%s\n", explorerBase, result.Address)
-			} else {
-				fmt.Printf("   💡 View on explorer: %s/address/%s\n", explorerBase, result.Address)
-			}
+		if link := explorerURL(chain.Name(), "address", result.Address); result.Address != "" && link != "" {
+			fmt.Printf("   💡 View on explorer: %s\n", link)
 		}
 	} else if len(result.Transactions) == 0 {
-		if pageFlag == 1 {
+		if result.NextCursor != "" {
+			fmt.Println("   No transactions in this range, older history may exist")
+		} else if pageFlag == 1 {
 			fmt.Println("   No transactions found")
-			if name == "Solana" {
+			if chain.Name() == "solana" {
 				fmt.Println("   💡 Tip: Solana accounts don't exist until they receive SOL")
 			}
 		} else {
@@ -526,26 +316,16 @@ func displayChainResult(result ChainResult, emoji, name string, isTestnet bool,
 	} else {
 		fmt.Printf("   Address: %s\n", result.Address)
 		fmt.Println("   Recent transactions:")
+		printTransactionsIndented(ctx, result.Transactions, client, chain.Name(), isTestnet)
+	}
 
-		// Determine crypto symbol from chain name
-		var cryptoSymbol string
-		switch name {
-		case "Ethereum":
-			cryptoSymbol = "ethereum"
-		case "Bitcoin":
-			cryptoSymbol = "bitcoin"
-		case "Solana":
-			cryptoSymbol = "solana"
-		default:
-			cryptoSymbol = "unknown"
-		}
-
-		printTransactionsIndented(result.Transactions, client, cryptoSymbol, isTestnet)
+	if result.NextCursor != "" {
+		fmt.Printf("   ➡️  More: --cursor %s\n", result.NextCursor)
 	}
 	fmt.Println()
 }
 
-func printTransactionsPaginated(txs []api.Transaction, client *api.Client, cryptoSymbol string, isTestnet bool) {
+func printTransactionsPaginated(ctx context.Context, txs []api.Transaction, client *api.Client, cryptoSymbol string, isTestnet bool) {
 	for i, tx := range txs {
 		// Direction indicator
 		direction := "⬅️ IN"
@@ -557,12 +337,12 @@ func printTransactionsPaginated(txs []api.Transaction, client *api.Client, crypt
 		timeStr := tx.Timestamp.Format("2006-01-02 15:04:05")
 
 		// Truncate addresses for display
-		fromShort := truncateAddress(tx.From)
-		toShort := truncateAddress(tx.To)
+		fromShort := displayAddress(tx.From)
+		toShort := displayAddress(tx.To)
 
 		// Get USD values
-		amountUSD := getUSDValue(client, cryptoSymbol, tx.Amount, isTestnet)
-		feeUSD := getUSDValue(client, cryptoSymbol, tx.Fee, isTestnet)
+		amountUSD := getUSDValue(ctx, client, cryptoSymbol, tx.Amount, tx.Timestamp, isTestnet)
+		feeUSD := getUSDValue(ctx, client, cryptoSymbol, tx.Fee, tx.Timestamp, isTestnet)
 
 		fmt.Printf("%d. %s | %s\n", i+1, direction, timeStr)
 		fmt.Printf("   Hash: %s\n", tx.Hash)
@@ -587,7 +367,7 @@ func printTransactionsPaginated(txs []api.Transaction, client *api.Client, crypt
 	}
 }
 
-func printTransactionsIndented(txs []api.Transaction, client *api.Client, cryptoSymbol string, isTestnet bool) {
+func printTransactionsIndented(ctx context.Context, txs []api.Transaction, client *api.Client, cryptoSymbol string, isTestnet bool) {
 	for i, tx := range txs {
 		// Direction indicator
 		direction := "⬅️ IN"
@@ -599,12 +379,12 @@ func printTransactionsIndented(txs []api.Transaction, client *api.Client, crypto
 		timeStr := tx.Timestamp.Format("2006-01-02 15:04:05")
 
 		// Truncate addresses for display
-		fromShort := truncateAddress(tx.From)
-		toShort := truncateAddress(tx.To)
+		fromShort := displayAddress(tx.From)
+		toShort := displayAddress(tx.To)
 
 		// Get USD values
-		amountUSD := getUSDValue(client, cryptoSymbol, tx.Amount, isTestnet)
-		feeUSD := getUSDValue(client, cryptoSymbol, tx.Fee, isTestnet)
+		amountUSD := getUSDValue(ctx, client, cryptoSymbol, tx.Amount, tx.Timestamp, isTestnet)
+		feeUSD := getUSDValue(ctx, client, cryptoSymbol, tx.Fee, tx.Timestamp, isTestnet)
 
 		fmt.Printf("   %d. %s | %s\n", i+1, direction, timeStr)
 		fmt.Printf("      Hash: %s\n", tx.Hash)
@@ -629,42 +409,18 @@ func printTransactionsIndented(txs []api.Transaction, client *api.Client, crypto
 	}
 }
 
-func showPaginationInfo() {
+func showPaginationInfo(nextCursor string) {
 	fmt.Println()
 	fmt.Println("📄 Pagination:")
 	if pageFlag > 1 {
 		fmt.Printf("   ⬅️  Previous: --page %d\n", pageFlag-1)
 	}
-	if pageFlag < 3 {
-		fmt.Printf("   ➡️  Next: --page %d\n", pageFlag+1)
-	}
-	fmt.Printf("   📊 Showing page %d of 3 (%d transactions per page)\n", pageFlag, limitFlag)
-	fmt.Println("   💡 Use --limit to change transactions per page (max 20)")
-}
-
-func applyPagination(txs []api.Transaction, offset, limit int) []api.Transaction {
-	// Instead of fetching all and slicing, we should limit the fetch itself
-	// For now, return early to avoid slow sequential calls
-	if len(txs) == 0 {
-		return []api.Transaction{}
-	}
-
-	// Limit to first 30 transactions max to avoid slow API calls
-	maxFetch := 30
-	if len(txs) > maxFetch {
-		txs = txs[:maxFetch]
-	}
-
-	if offset >= len(txs) {
-		return []api.Transaction{}
-	}
-
-	end := offset + limit
-	if end > len(txs) {
-		end = len(txs)
+	fmt.Printf("   ➡️  Next: --page %d\n", pageFlag+1)
+	if nextCursor != "" {
+		fmt.Printf("   🔖 Cursor: --cursor %s\n", nextCursor)
 	}
-
-	return txs[offset:end]
+	fmt.Printf("   📊 Showing page %d (%d transactions per page)\n", pageFlag, limitFlag)
+	fmt.Println("   💡 Use --limit to change transactions per page (max 50)")
 }
 
 // truncateAddress shortens long blockchain addresses for display
@@ -675,77 +431,80 @@ func truncateAddress(address string) string {
 	return address[:6] + "..." + address[len(address)-6:]
 }
 
-// getUSDValue fetches price and converts crypto amount to USD
-func getUSDValue(client *api.Client, cryptoSymbol, amountStr string, isTestnet bool) string {
+// getUSDValue converts a crypto amount to USD at the price of the day it was
+// transferred, or at the current price if the time is unknown
+func getUSDValue(ctx context.Context, client *api.Client, cryptoSymbol, amountStr string, at time.Time, isTestnet bool) string {
 	// Don't show USD for testnet
 	if isTestnet {
 		return ""
 	}
 
 	// Get price
-	price, err := client.GetPrice(cryptoSymbol)
+	price, err := transactionPrice(ctx, client, cryptoSymbol, at)
 	if err != nil {
 		return ""
 	}
 
-	// Parse amount based on crypto type
-	var cryptoAmount float64
-	var success bool
-
+	// Parse amount based on crypto type, e.g. "0.123456 ETH"
+	var symbol string
 	switch cryptoSymbol {
 	case "ethereum":
-		// Parse ETH amount (format: "0.123456 ETH")
-		cryptoAmount, success = parseEthAmount(amountStr)
+		symbol = "ETH"
 	case "bitcoin":
-		// Parse BTC amount (format: "0.12345678 BTC")
-		cryptoAmount, success = parseBtcAmount(amountStr)
+		symbol = "BTC"
 	case "solana":
-		// Parse SOL amount (format: "1.234567890 SOL")
-		cryptoAmount, success = parseSolAmount(amountStr)
+		symbol = "SOL"
 	default:
 		return ""
 	}
 
-	if !success {
+	cryptoAmount, ok := parseAssetAmount(amountStr, symbol)
+	if !ok {
 		return ""
 	}
+	return "~" + formatUSD(cryptoAmount.Mul(price.USD))
+}
+
+// transactionPrice returns the price on the day of a transaction, or the
+// current price if its time is unknown, e.g. while it is pending
+func transactionPrice(ctx context.Context, client *api.Client, cryptoSymbol string, at time.Time) (*api.PriceData, error) {
+	if at.IsZero() {
+		return client.GetPrice(ctx, cryptoSymbol)
+	}
+	return client.GetHistoricalPrice(ctx, cryptoSymbol, at)
+}
 
-	usdValue := cryptoAmount * price.USD.InexactFloat64()
-	return fmt.Sprintf("~$%.2f", usdValue)
+// recordActivity caches transactions of the wallet for the summary on unlock,
+// failures only cost the summary and are ignored
+func recordActivity(manager *wallet.Manager, chain string, txs []api.Transaction) {
+	activity := make([]wallet.ActivityTransaction, 0, len(txs))
+	for _, tx := range txs {
+		activity = append(activity, wallet.ActivityTransaction{
+			Chain:     chain,
+			Network:   manager.GetCurrentNetwork(),
+			Hash:      tx.Hash,
+			Incoming:  tx.IsIncoming,
+			Amount:    tx.Amount,
+			Timestamp: tx.Timestamp,
+			Confirmed: tx.BlockNumber > 0,
+		})
+	}
+	if err := manager.RecordTransactions(activity); err != nil {
+		slog.Debug("failed to record activity", "chain", chain, "error", err)
+	}
 }
 
 // parseEthAmount extracts numeric value from ETH amount string
-func parseEthAmount(amountStr string) (float64, bool) {
-	// Remove "ETH" suffix and parse
-	if strings.HasSuffix(amountStr, " ETH") {
-		numStr := strings.TrimSuffix(amountStr, " ETH")
-		if amount, err := parseFloat(numStr); err == nil {
-			return amount, true
-		}
-	}
-	return 0, false
+func parseEthAmount(amountStr string) (decimal.Decimal, bool) {
+	return parseAssetAmount(amountStr, "ETH")
 }
 
 // parseBtcAmount extracts numeric value from BTC amount string
-func parseBtcAmount(amountStr string) (float64, bool) {
-	// Remove "BTC" suffix and parse
-	if strings.HasSuffix(amountStr, " BTC") {
-		numStr := strings.TrimSuffix(amountStr, " BTC")
-		if amount, err := parseFloat(numStr); err == nil {
-			return amount, true
-		}
-	}
-	return 0, false
+func parseBtcAmount(amountStr string) (decimal.Decimal, bool) {
+	return parseAssetAmount(amountStr, "BTC")
 }
 
 // parseSolAmount extracts numeric value from SOL amount string
-func parseSolAmount(amountStr string) (float64, bool) {
-	// Remove "SOL" suffix and parse
-	if strings.HasSuffix(amountStr, " SOL") {
-		numStr := strings.TrimSuffix(amountStr, " SOL")
-		if amount, err := parseFloat(numStr); err == nil {
-			return amount, true
-		}
-	}
-	return 0, false
+func parseSolAmount(amountStr string) (decimal.Decimal, bool) {
+	return parseAssetAmount(amountStr, "SOL")
 }
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/crypto"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/skip2/go-qrcode"
+	"github.com/spf13/cobra"
+)
+
+var twoFactorCmd = &cobra.Command{
+	Use:   "2fa",
+	Short: "Require an authenticator code to send or export keys",
+	Long: `Manage two-factor authentication with an authenticator app (TOTP).
+
+When enabled, every command that signs or broadcasts a transaction and the
+commands that export keys or reveal the recovery phrase ask for the 6-digit
+code of your authenticator app in addition to the unlocked wallet. The
+secret is stored in the vault, encrypted with a key derived from the
+recovery phrase.
+
+Scripts can pass the code in ODYSSEY_2FA_CODE.
+
+Examples:
+  odyssey 2fa enable
+  odyssey 2fa status
+  odyssey 2fa disable`,
+	Args: cobra.NoArgs,
+	RunE: runTwoFactorStatus,
+}
+
+var twoFactorEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Set up an authenticator app",
+	Args:  cobra.NoArgs,
+	RunE:  runTwoFactorEnable,
+}
+
+var twoFactorDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Stop asking for authenticator codes",
+	Args:  cobra.NoArgs,
+	RunE:  runTwoFactorDisable,
+}
+
+var twoFactorStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether two-factor authentication is enabled",
+	Args:  cobra.NoArgs,
+	RunE:  runTwoFactorStatus,
+}
+
+func init() {
+	twoFactorCmd.AddCommand(twoFactorEnableCmd)
+	twoFactorCmd.AddCommand(twoFactorDisableCmd)
+	twoFactorCmd.AddCommand(twoFactorStatusCmd)
+}
+
+func runTwoFactorStatus(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.VaultExists() {
+		return fmt.Errorf("no wallet found. Run 'odyssey init' to create a new wallet")
+	}
+
+	// The unlocked wallet's own setting is shown, the decoy has its own
+	manager.IsUnlocked()
+	if manager.TwoFactorEnabled() {
+		fmt.Println("🔐 Two-factor authentication is enabled")
+		fmt.Println("   Transactions and key exports ask for an authenticator code")
+	} else {
+		fmt.Println("🔓 Two-factor authentication is off")
+		fmt.Println("💡 Enable it with 'odyssey 2fa enable'")
+	}
+	return nil
+}
+
+func runTwoFactorEnable(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+	if manager.TwoFactorEnabled() {
+		return fmt.Errorf("two-factor authentication is already enabled")
+	}
+
+	secret, err := crypto.NewTOTPSecret()
+	if err != nil {
+		return err
+	}
+	defer crypto.ClearBytes(secret)
+
+	uri := crypto.TOTPURI(secret, "Odyssey", "wallet")
+	fmt.Println("🔐 Set Up Two-Factor Authentication")
+	fmt.Println()
+	fmt.Println("Scan the QR code with your authenticator app, or enter the secret:")
+	fmt.Println()
+	if !quietMode {
+		code, err := qrcode.New(uri, qrcode.Medium)
+		if err != nil {
+			return fmt.Errorf("failed to create QR code: %w", err)
+		}
+		fmt.Print(code.ToSmallString(false))
+		fmt.Println()
+	}
+	fmt.Printf("   Secret: %s\n", crypto.EncodeTOTPSecret(secret))
+	fmt.Println()
+	fmt.Println("⚠️  Keep your recovery phrase: it restores the wallet without the app")
+	fmt.Println()
+
+	code, err := readTwoFactorCode("Enter the code shown by the app to confirm: ")
+	if err != nil {
+		return err
+	}
+	password, err := readWalletPassword(cmd, "Enter your wallet password: ")
+	if err != nil {
+		return err
+	}
+	if err := manager.EnableTwoFactor(password, secret, code); err != nil {
+		if errors.Is(err, wallet.ErrInvalidTwoFactorCode) {
+			return fmt.Errorf("%w, two-factor authentication was not enabled. Check the clock of your device and try again", err)
+		}
+		return err
+	}
+
+	fmt.Println("✅ Two-factor authentication enabled")
+	return nil
+}
+
+func runTwoFactorDisable(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+	if !manager.TwoFactorEnabled() {
+		fmt.Println("🔓 Two-factor authentication is already off")
+		return nil
+	}
+
+	code, err := readTwoFactorCode("Enter your 2FA code: ")
+	if err != nil {
+		return err
+	}
+	if err := manager.DisableTwoFactor(code); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Two-factor authentication disabled")
+	return nil
+}
+
+// confirmSigning asks to confirm a transaction and, when two-factor
+// authentication is enabled, for the authenticator code. Every command that
+// signs goes through it. If the transaction isn't confirmed, cancelled is
+// printed and errCancelled returned.
+func confirmSigning(manager *wallet.Manager, cancelled string) error {
+	if !getTransactionConfirmation(manager) {
+		fmt.Println(cancelled)
+		return errCancelled
+	}
+	return requireTwoFactor(manager)
+}
+
+// requireTwoFactor asks for the authenticator code when two-factor
+// authentication is enabled, before signing, broadcasting or revealing keys.
+// An accepted code covers one operation only, so a session that sends twice,
+// like 'odyssey interactive', asks for a code each time.
+func requireTwoFactor(manager *wallet.Manager) error {
+	if !manager.TwoFactorEnabled() {
+		return nil
+	}
+
+	code, err := readTwoFactorCode("🔐 Enter your 2FA code: ")
+	if err != nil {
+		return err
+	}
+	if err := manager.VerifyTwoFactor(code); err != nil {
+		recordAudit(manager, wallet.AuditEntry{Event: wallet.AuditTwoFactor, Result: wallet.AuditFailed, Detail: err.Error()})
+		return err
+	}
+	return nil
+}
+
+// readTwoFactorCode reads a code from ODYSSEY_2FA_CODE or asks for it
+func readTwoFactorCode(prompt string) (string, error) {
+	if code := os.Getenv("ODYSSEY_2FA_CODE"); code != "" {
+		return strings.TrimSpace(code), nil
+	}
+
+	fmt.Fprint(promptOut(), prompt)
+	var code string
+	if _, err := fmt.Scanln(&code); err != nil {
+		return "", fmt.Errorf("failed to read 2FA code: %w", err)
+	}
+	return strings.TrimSpace(code), nil
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+var txCmd = &cobra.Command{
+	Use:   "tx",
+	Short: "Show transactions and track the ones this wallet sends",
+	Long: `Show the on-chain details of a transaction, and track signed transactions
+until they are confirmed.
+
+Every transaction is saved to a local queue as soon as it is signed, before it
+is sent, and moves through these states:
+
+  signed     the broadcast was interrupted, e.g. by a crash or Ctrl+C
+  queued     the broadcast failed on a timeout or server error
+  sent       a node accepted it, waiting to be included in a block
+  confirmed  included in a block, kept in the queue for a day
+  failed     rejected by the network or failed on chain
+  expired    can no longer be sent safely, e.g. its Solana blockhash is too
+             old or its Ethereum nonce has been used
+
+Queued transactions are retried automatically for a while. Signed and queued
+transactions can be resumed with 'tx retry', which sends the same signed
+bytes, so a transaction is never paid twice. 'tx pending' checks whether sent
+transactions have been confirmed.
+
+The automatic retry period can be changed with:
+  odyssey config set broadcast.retry_period 5m
+
+Examples:
+  odyssey tx show eth 0x5c50...  # Show the details of any transaction
+  odyssey tx pending             # List tracked transactions
+  odyssey tx retry 0x5c50...     # Resume or rebroadcast a transaction
+  odyssey tx drop 0x5c50...      # Remove a transaction from the queue`,
+	Args: cobra.NoArgs,
+	RunE: runTxPending,
+}
+
+var txPendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "List signed transactions until they are confirmed",
+	Args:  cobra.NoArgs,
+	RunE:  runTxPending,
+}
+
+var txRetryCmd = &cobra.Command{
+	Use:   "retry [id]",
+	Short: "Resume or rebroadcast a signed transaction",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTxRetry,
+}
+
+var txDropCmd = &cobra.Command{
+	Use:   "drop [id]",
+	Short: "Remove a transaction from the queue",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTxDrop,
+}
+
+func init() {
+	txCmd.AddCommand(txPendingCmd)
+	txCmd.AddCommand(txRetryCmd)
+	txCmd.AddCommand(txDropCmd)
+	txCmd.AddCommand(txShowCmd)
+}
+
+func runTxPending(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	pending, err := manager.GetPendingBroadcasts()
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
+		fmt.Println("📭 No transactions waiting to be broadcast or confirmed")
+		return nil
+	}
+	refreshSentBroadcasts(ctx, manager, api.NewClient(), pending)
+
+	fmt.Println("⏳ Pending Transactions")
+	fmt.Println()
+
+	for _, entry := range pending {
+		fmt.Printf("%s %s (%s)\n", pendingStatusIcon(entry.Status), entry.ID, entry.Status)
+		fmt.Printf("   Chain:    %s (%s)\n", entry.Chain, entry.Network)
+		fmt.Printf("   To:       %s\n", entry.To)
+		fmt.Printf("   Amount:   %s\n", entry.Amount)
+		fmt.Printf("   Created:  %s\n", entry.CreatedAt.Local().Format(time.DateTime))
+		fmt.Printf("   Attempts: %d\n", entry.Attempts)
+		if !entry.ConfirmedAt.IsZero() {
+			fmt.Printf("   Confirmed: %s\n", entry.ConfirmedAt.Local().Format(time.DateTime))
+		}
+		if entry.LastError != "" {
+			fmt.Printf("   Error:    %s\n", entry.LastError)
+		}
+		if entry.Resumable() {
+			fmt.Printf("   💡 Resume with: odyssey tx retry %s\n", shortID(entry.ID))
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// refreshSentBroadcasts looks up the sent transactions of the current network
+// on chain and records the ones that were confirmed or failed. Lookups that
+// fail leave a transaction as sent.
+func refreshSentBroadcasts(ctx context.Context, manager *wallet.Manager, client *api.Client, pending []wallet.PendingBroadcast) {
+	network := manager.GetCurrentNetwork()
+	for i := range pending {
+		entry := &pending[i]
+		if entry.Status != wallet.PendingSent || entry.Network != network {
+			continue
+		}
+
+		chainClient, chain := broadcastChain(client, entry)
+		var details *api.TransactionDetails
+		var err error
+		switch chain {
+		case "ethereum":
+			details, err = chainClient.GetEthereumTransactionDetails(ctx, entry.ID)
+		case "bitcoin":
+			details, err = chainClient.GetBitcoinTransactionDetails(ctx, entry.ID)
+		case "solana":
+			details, err = chainClient.GetSolanaTransactionDetails(ctx, entry.ID)
+		default:
+			continue
+		}
+		if err != nil {
+			slog.Debug("failed to look up sent transaction", "id", entry.ID, "error", err)
+			continue
+		}
+
+		switch {
+		case details.Status == api.TxStatusFailed:
+			setPendingStatus(manager, entry.ID, wallet.PendingFailed, details.Error)
+			entry.Status, entry.LastError = wallet.PendingFailed, details.Error
+		case details.Status == api.TxStatusSuccess && details.Confirmations > 0:
+			setPendingStatus(manager, entry.ID, wallet.PendingConfirmed, "")
+			entry.Status, entry.ConfirmedAt = wallet.PendingConfirmed, time.Now()
+		}
+	}
+}
+
+func runTxRetry(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manager := wallet.NewManager()
+
+	pending, err := manager.FindPendingBroadcast(args[0])
+	if err != nil {
+		return err
+	}
+
+	switch pending.Status {
+	case wallet.PendingConfirmed:
+		fmt.Printf("✅ %s is already confirmed\n", pending.ID)
+		return nil
+	case wallet.PendingExpired:
+		return fmt.Errorf("transaction has expired: %s. Drop it with 'odyssey tx drop %s' and send it again", pending.LastError, shortID(pending.ID))
+	case wallet.PendingFailed:
+		return fmt.Errorf("transaction was rejected by the network: %s. Drop it with 'odyssey tx drop %s'", pending.LastError, shortID(pending.ID))
+	}
+
+	if pending.Network != manager.GetCurrentNetwork() {
+		return fmt.Errorf("transaction was signed for %s. Run 'odyssey network %s' first", pending.Network, pending.Network)
+	}
+
+	if err := requireTwoFactor(manager); err != nil {
+		return err
+	}
+	client := api.NewClient()
+
+	// Sent transactions are rebroadcast, the same signed bytes can't pay twice
+	fmt.Printf("🔁 Retrying %s...\n", pending.ID)
+	txHash, err := retryPendingBroadcast(ctx, manager, client, pending)
+	auditSend(manager, pending, txHash, err)
+	if err == nil && pending.Chain == "solana" {
+		err = waitForSolanaConfirmation(ctx, manager, client, pending, 0)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	fmt.Printf("✅ Transaction sent successfully!\n")
+	printResult(txHash, "📝 Transaction Hash: %s\n", txHash)
+	return nil
+}
+
+func runTxDrop(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+
+	pending, err := manager.FindPendingBroadcast(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := manager.RemovePendingBroadcast(pending.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("🗑️  Dropped %s from the queue\n", pending.ID)
+	if pending.Resumable() || pending.Status == wallet.PendingSent {
+		fmt.Println("💡 The transaction may still have reached the network, check it before sending again")
+	}
+	return nil
+}
+
+// pendingStatusIcon returns the icon shown for a queue status
+func pendingStatusIcon(status string) string {
+	switch status {
+	case wallet.PendingSigned:
+		return "✍️"
+	case wallet.PendingSent:
+		return "📤"
+	case wallet.PendingConfirmed:
+		return "✅"
+	case wallet.PendingExpired:
+		return "⌛"
+	case wallet.PendingFailed:
+		return "❌"
+	default:
+		return "🔁"
+	}
+}
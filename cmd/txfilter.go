@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// filterScanPages limits how many pages of history are read to fill one
+// page of filtered transactions
+const filterScanPages = 20
+
+var (
+	txSinceFlag     string
+	txUntilFlag     string
+	txDirectionFlag string
+	txMinAmountFlag string
+)
+
+// addTxFilterFlags adds the flags that select transactions by date,
+// direction and amount
+func addTxFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&txSinceFlag, "since", "", "Only transactions on or after this date (2006-01-02 or RFC 3339)")
+	cmd.Flags().StringVar(&txUntilFlag, "until", "", "Only transactions on or before this date (2006-01-02 or RFC 3339)")
+	cmd.Flags().StringVar(&txDirectionFlag, "direction", "", "Only incoming (in) or outgoing (out) transactions")
+	cmd.Flags().StringVar(&txMinAmountFlag, "min-amount", "", "Only transactions of at least this amount, in the chain's coin")
+}
+
+// txFilter selects transactions, the zero value selects all of them
+type txFilter struct {
+	since     time.Time // inclusive
+	until     time.Time // exclusive
+	direction string    // in, out or empty for both
+	minAmount decimal.Decimal
+}
+
+// parseTxFilter reads the filter flags. Dates without a time are local days,
+// --until includes its whole day.
+func parseTxFilter() (txFilter, error) {
+	var filter txFilter
+	var err error
+	if txSinceFlag != "" {
+		if filter.since, _, err = parseFilterDate(txSinceFlag); err != nil {
+			return filter, fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if txUntilFlag != "" {
+		var dateOnly bool
+		if filter.until, dateOnly, err = parseFilterDate(txUntilFlag); err != nil {
+			return filter, fmt.Errorf("invalid --until: %w", err)
+		}
+		if dateOnly {
+			filter.until = filter.until.AddDate(0, 0, 1)
+		} else {
+			filter.until = filter.until.Add(time.Second)
+		}
+	}
+	if !filter.since.IsZero() && !filter.until.IsZero() && !filter.since.Before(filter.until) {
+		return filter, fmt.Errorf("--since must be before --until")
+	}
+
+	switch direction := strings.ToLower(txDirectionFlag); direction {
+	case "", "in", "out":
+		filter.direction = direction
+	default:
+		return filter, fmt.Errorf("invalid --direction: %s. Use 'in' or 'out'", txDirectionFlag)
+	}
+
+	if txMinAmountFlag != "" {
+		if filter.minAmount, err = decimal.NewFromString(txMinAmountFlag); err != nil || filter.minAmount.IsNegative() {
+			return filter, fmt.Errorf("invalid --min-amount: %s", txMinAmountFlag)
+		}
+	}
+	return filter, nil
+}
+
+// parseFilterDate parses a date or a time, and returns whether it was a date
+func parseFilterDate(value string) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("expected a date like 2025-01-31 or a time like 2025-01-31T18:00:00Z, got %s", value)
+}
+
+// String describes the filter, e.g. "out, since 2025-01-01"
+func (f txFilter) String() string {
+	var parts []string
+	if f.direction != "" {
+		parts = append(parts, f.direction)
+	}
+	if !f.since.IsZero() {
+		parts = append(parts, "since "+f.since.Format("2006-01-02 15:04"))
+	}
+	if !f.until.IsZero() {
+		parts = append(parts, "before "+f.until.Format("2006-01-02 15:04"))
+	}
+	if f.minAmount.IsPositive() {
+		parts = append(parts, "at least "+f.minAmount.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// active returns true if the filter selects less than everything
+func (f txFilter) active() bool {
+	return !f.since.IsZero() || !f.until.IsZero() || f.direction != "" || f.minAmount.IsPositive()
+}
+
+// match returns true if a transaction at a time, in a direction and of an
+// amount in whole coins is selected. Pending transactions have no time and
+// only match filters without dates.
+func (f txFilter) match(at time.Time, incoming bool, amount decimal.Decimal) bool {
+	if !f.since.IsZero() && (at.IsZero() || at.Before(f.since)) {
+		return false
+	}
+	if !f.until.IsZero() && (at.IsZero() || !at.Before(f.until)) {
+		return false
+	}
+	if (f.direction == "in" && !incoming) || (f.direction == "out" && incoming) {
+		return false
+	}
+	return amount.GreaterThanOrEqual(f.minAmount)
+}
+
+// matchTransaction applies the filter to a transaction of the history
+func (f txFilter) matchTransaction(tx api.Transaction) bool {
+	amount, ok := parseTaxAmount(tx.Amount)
+	if !ok {
+		return !f.minAmount.IsPositive() && f.match(tx.Timestamp, tx.IsIncoming, decimal.Zero)
+	}
+	return f.match(tx.Timestamp, tx.IsIncoming, amount)
+}
+
+// wrap returns a fetcher whose pages hold only matching transactions. It reads
+// pages of history until at least limit transactions match, so a page can
+// hold a few more, and stops at the first transaction older than --since.
+func (f txFilter) wrap(fetch txPageFetcher) txPageFetcher {
+	if !f.active() {
+		return fetch
+	}
+	return func(ctx context.Context, limit int, cursor string) (*api.TransactionPage, error) {
+		result := &api.TransactionPage{Transactions: []api.Transaction{}}
+		for scanned := 0; scanned < filterScanPages; scanned++ {
+			page, err := fetch(ctx, limit, cursor)
+			if err != nil {
+				return nil, err
+			}
+
+			older := false
+			for _, tx := range page.Transactions {
+				if !f.since.IsZero() && !tx.Timestamp.IsZero() && tx.Timestamp.Before(f.since) {
+					older = true
+					continue
+				}
+				if f.matchTransaction(tx) {
+					result.Transactions = append(result.Transactions, tx)
+				}
+			}
+
+			// History is newest first, nothing after this page matches
+			if older || page.NextCursor == "" {
+				result.NextCursor = ""
+				return result, nil
+			}
+			result.NextCursor = page.NextCursor
+			if len(result.Transactions) >= limit {
+				return result, nil
+			}
+			cursor = page.NextCursor
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// maxShownLogs limits how many event logs and program log lines are shown
+const maxShownLogs = 20
+
+var txShowOutputFlag string
+
+var txShowCmd = &cobra.Command{
+	Use:   "show [chain] [hash]",
+	Short: "Show the on-chain details of a transaction",
+	Long: `Show the on-chain details of any transaction: status, confirmations, block,
+fee, token transfers and the event logs or instructions it ran.
+
+Supported chains: eth, btc, sol, polygon, arbitrum, optimism, base, bsc
+
+Examples:
+  odyssey tx show eth 0x5c50...            # An Ethereum transaction
+  odyssey tx show sol 4sGjMW1s...          # A Solana transaction with its instructions
+  odyssey tx show btc 9f2c... --output json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTxShow,
+}
+
+func init() {
+	txShowCmd.Flags().StringVarP(&txShowOutputFlag, "output", "o", "text", "Output format (text, json)")
+}
+
+// txShowAssets is how amounts of a chain are displayed
+type txShowAssets struct {
+	name     string
+	symbol   string
+	decimals int32
+	tokens   []api.Token
+	explorer string // transaction URL, empty without an explorer
+	hidden   int    // token transfers of blocklisted tokens left out
+}
+
+func runTxShow(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	output := strings.ToLower(txShowOutputFlag)
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format: %s. Use 'text' or 'json'", txShowOutputFlag)
+	}
+
+	manager := wallet.NewManager()
+	client := api.NewClient()
+	testnet := manager.IsTestnet()
+	hash := strings.TrimSpace(args[1])
+
+	var details *api.TransactionDetails
+	var assets txShowAssets
+	var err error
+
+	chain := strings.ToLower(args[0])
+	switch chain {
+	case "eth", "ethereum":
+		chain = "ethereum"
+		assets = txShowAssets{name: ethereumLabel(), symbol: "ETH", decimals: 18, tokens: client.GetEthereumTokens(), explorer: explorerURL("ethereum", "tx", hash)}
+		details, err = client.GetEthereumTransactionDetails(ctx, hash)
+	case "btc", "bitcoin":
+		chain = "bitcoin"
+		assets = txShowAssets{name: "Bitcoin", symbol: "BTC", decimals: 8, explorer: explorerURL("bitcoin", "tx", hash)}
+		details, err = client.GetBitcoinTransactionDetails(ctx, hash)
+	case "sol", "solana":
+		chain = "solana"
+		assets = txShowAssets{name: solanaLabel(), symbol: "SOL", decimals: 9, tokens: client.GetSolanaTokens(), explorer: explorerURL("solana", "tx", hash)}
+		details, err = client.GetSolanaTransactionDetails(ctx, hash)
+	default:
+		evmChain, ok := api.FindEVMChain(chain)
+		if !ok {
+			return fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol, %s", chain, strings.Join(api.EVMChainNames(), ", "))
+		}
+		chain = evmChain.Name
+		assets = txShowAssets{name: evmChain.Label(testnet), symbol: evmChain.Symbol, decimals: 18, explorer: explorerURL(evmChain.Name, "tx", hash)}
+		details, err = client.ForEVMChain(evmChain).GetEthereumTransactionDetails(ctx, hash)
+	}
+	if err != nil {
+		return err
+	}
+	details.Chain = chain
+
+	if output == "json" {
+		data, err := json.MarshalIndent(details, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode transaction: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	// Transfers of blocklisted tokens are scam airdrops, left out so their
+	// fake symbols aren't shown
+	blocklist, err := config.LoadBlocklist()
+	if err != nil {
+		slog.Debug("failed to read blocklist", "error", err)
+	}
+	transfers := details.TokenTransfers[:0]
+	for _, transfer := range details.TokenTransfers {
+		if blocklist.Token(chain, transfer.Token) != nil {
+			assets.hidden++
+			continue
+		}
+		transfers = append(transfers, transfer)
+	}
+	details.TokenTransfers = transfers
+
+	// Tokens that aren't tracked are named from their metadata
+	if chain == "ethereum" || chain == "solana" {
+		assets.tokens = withTransferTokens(ctx, client, chain, assets.tokens, details.TokenTransfers)
+	}
+
+	printTransactionDetails(details, assets)
+	return nil
+}
+
+// withTransferTokens adds the tokens of transfers missing from tokens, looked
+// up on the chain. Tokens that can't be looked up stay unnamed.
+func withTransferTokens(ctx context.Context, client *api.Client, chain string, tokens []api.Token, transfers []api.TokenTransfer) []api.Token {
+	known := make(map[string]bool)
+	for _, token := range tokens {
+		known[strings.ToLower(token.Address)] = true
+	}
+	for _, transfer := range transfers {
+		if transfer.TokenID != nil || known[strings.ToLower(transfer.Token)] {
+			continue
+		}
+		known[strings.ToLower(transfer.Token)] = true
+		token, err := client.LookupToken(ctx, chain, transfer.Token)
+		if err != nil {
+			slog.Debug("failed to look up token", "token", transfer.Token, "error", err)
+			continue
+		}
+		tokens = append(tokens, *token)
+	}
+	return tokens
+}
+
+// printTransactionDetails renders a transaction for humans
+func printTransactionDetails(details *api.TransactionDetails, assets txShowAssets) {
+	fmt.Printf("🔎 %s Transaction\n", assets.name)
+	fmt.Printf("📝 Hash:          %s\n", details.Hash)
+
+	switch details.Status {
+	case api.TxStatusSuccess:
+		fmt.Println("✅ Status:        Success")
+	case api.TxStatusFailed:
+		fmt.Printf("❌ Status:        Failed (%s)\n", details.Error)
+	default:
+		fmt.Println("⏳ Status:        Pending")
+	}
+
+	if details.Block > 0 {
+		label := "Block"
+		if details.Chain == "solana" {
+			label = "Slot"
+		}
+		fmt.Printf("📦 %-14s %d\n", label+":", details.Block)
+		fmt.Printf("🔢 Confirmations: %d\n", details.Confirmations)
+	}
+	if details.BlockTime != nil {
+		fmt.Printf("🕒 Time:          %s\n", details.BlockTime.Local().Format("2006-01-02 15:04:05"))
+	}
+	fmt.Println()
+
+	if details.From != "" {
+		fmt.Printf("   From:      %s\n", details.From)
+	}
+	if details.To != "" {
+		fmt.Printf("   To:        %s\n", details.To)
+	}
+	if details.Value != nil {
+		fmt.Printf("   Value:     %s %s\n", decimal.NewFromBigInt(details.Value, -assets.decimals).String(), assets.symbol)
+	}
+	if details.Fee != nil {
+		fmt.Printf("   Fee:       %s %s\n", decimal.NewFromBigInt(details.Fee, -assets.decimals).String(), assets.symbol)
+	}
+	if details.Nonce != nil {
+		fmt.Printf("   Nonce:     %d\n", *details.Nonce)
+	}
+	if details.GasLimit > 0 {
+		if details.GasUsed > 0 {
+			fmt.Printf("   Gas:       %d used of %d (%.1f%%)\n", details.GasUsed, details.GasLimit, float64(details.GasUsed)/float64(details.GasLimit)*100)
+		} else {
+			fmt.Printf("   Gas Limit: %d\n", details.GasLimit)
+		}
+	}
+	if details.GasPrice != nil {
+		fmt.Printf("   Gas Price: %s gwei\n", formatGwei(details.GasPrice))
+	}
+	if details.VSize > 0 {
+		fmt.Printf("   Size:      %d vB (%.1f sat/vB)\n", details.VSize, float64(details.Fee.Int64())/float64(details.VSize))
+	}
+	if len(details.Input) > 2 {
+		input := details.Input
+		if len(input) > 74 {
+			input = input[:74] + "..."
+		}
+		fmt.Printf("   Input:     %s\n", input)
+	}
+
+	if len(details.Inputs) > 0 || len(details.Outputs) > 0 {
+		fmt.Println()
+		fmt.Printf("📥 Inputs (%d):\n", len(details.Inputs))
+		for _, input := range details.Inputs {
+			fmt.Printf("   %s  %s BTC\n", input.Address, decimal.NewFromInt(input.Value).Shift(-8).StringFixed(8))
+		}
+		fmt.Printf("📤 Outputs (%d):\n", len(details.Outputs))
+		for _, output := range details.Outputs {
+			address := output.Address
+			if address == "" {
+				address = "(no address)"
+			}
+			fmt.Printf("   %s  %s BTC\n", address, decimal.NewFromInt(output.Value).Shift(-8).StringFixed(8))
+		}
+	}
+
+	if len(details.TokenTransfers) > 0 || assets.hidden > 0 {
+		fmt.Println()
+		fmt.Println("🪙 Token Transfers:")
+		for _, transfer := range details.TokenTransfers {
+			from, to := truncateAddress(transfer.From), truncateAddress(transfer.To)
+			if isNullAddress(transfer.From) {
+				from = "(mint)"
+			}
+			if isNullAddress(transfer.To) {
+				to = "(burn)"
+			}
+			fmt.Printf("   %s → %s  %s\n", from, to, formatTokenTransfer(transfer, assets.tokens))
+		}
+		if assets.hidden > 0 {
+			fmt.Printf("   🚫 %d transfer%s of blocklisted tokens hidden\n", assets.hidden, plural(assets.hidden))
+		}
+	}
+
+	if len(details.Instructions) > 0 {
+		fmt.Println()
+		fmt.Printf("🧩 Instructions (%d):\n", len(details.Instructions))
+		for i, instruction := range details.Instructions {
+			name := instruction.Program
+			if instruction.Type != "" {
+				name += ": " + instruction.Type
+			}
+			fmt.Printf("   %d. %s\n", i+1, name)
+			keys := make([]string, 0, len(instruction.Info))
+			for key := range instruction.Info {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				fmt.Printf("      %s: %v\n", key, formatInstructionValue(instruction.Info[key]))
+			}
+		}
+	}
+
+	if len(details.Logs) > 0 {
+		fmt.Println()
+		fmt.Printf("📜 Logs (%d):\n", len(details.Logs))
+		for i, log := range details.Logs {
+			if i == maxShownLogs {
+				fmt.Printf("   ... %d more, use --output json for all\n", len(details.Logs)-maxShownLogs)
+				break
+			}
+			name := "unknown event"
+			if len(log.Topics) > 0 {
+				if known, ok := eventNames[strings.ToLower(log.Topics[0])]; ok {
+					name = known
+				}
+			}
+			fmt.Printf("   %d. %s from %s\n", i+1, name, log.Address)
+		}
+	}
+
+	if len(details.ProgramLogs) > 0 {
+		fmt.Println()
+		fmt.Println("📜 Program Logs:")
+		start := 0
+		if len(details.ProgramLogs) > maxShownLogs {
+			start = len(details.ProgramLogs) - maxShownLogs
+			fmt.Printf("   ... %d earlier lines\n", start)
+		}
+		for _, line := range details.ProgramLogs[start:] {
+			fmt.Printf("   %s\n", line)
+		}
+	}
+
+	if assets.explorer != "" {
+		fmt.Println()
+		fmt.Printf("🔗 Explorer: %s\n", assets.explorer)
+	}
+}
+
+// formatTokenTransfer formats the amount and asset of a token transfer
+func formatTokenTransfer(transfer api.TokenTransfer, tokens []api.Token) string {
+	if transfer.TokenID != nil {
+		return fmt.Sprintf("%s NFT #%s (%s)", transfer.Amount.String(), transfer.TokenID.String(), truncateAddress(transfer.Token))
+	}
+	for _, token := range tokens {
+		if strings.EqualFold(token.Address, transfer.Token) {
+			return fmt.Sprintf("%s %s", decimal.NewFromBigInt(transfer.Amount, -token.Decimals).String(), token.Symbol)
+		}
+	}
+	return fmt.Sprintf("%s units of %s", transfer.Amount.String(), truncateAddress(transfer.Token))
+}
+
+// formatInstructionValue formats a decoded instruction field, nested values as JSON
+func formatInstructionValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return new(big.Float).SetFloat64(v).Text('f', -1)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// isNullAddress returns true for a missing or zero address, which token
+// transfers use for mints and burns
+func isNullAddress(address string) bool {
+	return strings.TrimLeft(strings.TrimPrefix(address, "0x"), "0") == ""
+}
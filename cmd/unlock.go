@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"syscall"
+	"strings"
+	"time"
 
+	"github.com/chinmay1088/odyssey/config"
 	"github.com/chinmay1088/odyssey/wallet"
 	"github.com/spf13/cobra"
-	"golang.org/x/term"
 )
 
 var unlockCmd = &cobra.Command{
@@ -14,45 +16,271 @@ var unlockCmd = &cobra.Command{
 	Short: "Unlock wallet for session",
 	Long: `Unlock your Odyssey wallet for the current session.
 This command will decrypt your vault and load your keys into memory.
-The wallet will remain unlocked until you close the terminal or run 'odyssey lock'.
+The wallet stays unlocked until it is idle for the session timeout (30m by
+default, see 'odyssey config set session.timeout') or you run 'odyssey lock'.
 
-Example:
-  odyssey unlock`,
+By default the session is shared by every terminal. Use --terminal (or set
+ODYSSEY_SESSION_SCOPE=terminal) to keep the session bound to the current
+terminal, so other terminals and processes stay locked.
+
+With --no-session or 'odyssey config set session.storage none' no session is
+written to disk. The keys stay in memory for one command only, and every
+command that needs them asks for the password. With session.storage agent
+the keys are handed to the running 'odyssey agent' instead.
+
+On unlock a short summary of what happened since the previous unlock is
+shown: incoming transactions, confirmed sends, large price moves of held
+assets and pending approvals. It is built from what earlier commands saw
+and stored locally, so it never waits for the network.
+
+Scripts and cron jobs can pass the password with --password-file or the
+ODYSSEY_PASSWORD environment variable. Anyone who can read the file or the
+environment can unlock the wallet, so keep the file readable only by you.
+
+Examples:
+  odyssey unlock              # Unlock for all terminals
+  odyssey unlock --terminal   # Unlock for this terminal only
+  odyssey unlock --password-file ~/.odyssey-password`,
 	RunE: runUnlock,
 }
 
+func init() {
+	unlockCmd.Flags().Bool("terminal", false, "Only unlock the wallet for the current terminal")
+	unlockCmd.Flags().String("password-file", "", "Read the password from this file instead of prompting (also ODYSSEY_PASSWORD)")
+}
+
 func runUnlock(cmd *cobra.Command, args []string) error {
 	manager := wallet.NewManager()
 
+	if terminalOnly, _ := cmd.Flags().GetBool("terminal"); terminalOnly {
+		if err := manager.SetSessionScope(wallet.SessionScopeTerminal); err != nil {
+			return fmt.Errorf("failed to use terminal session: %w", err)
+		}
+	}
+
 	// Check if wallet exists
 	if !manager.VaultExists() {
 		return fmt.Errorf("no wallet found. Run 'odyssey init' to create a new wallet")
 	}
 
+	if manager.SessionStorage() == config.SessionStorageAgent {
+		return unlockAgent(cmd, manager)
+	}
+
 	// Check if already unlocked
-	if manager.IsUnlocked() {
+	if manager.SessionStored() && manager.IsUnlocked() {
 		fmt.Println("✅ Wallet is already unlocked")
 		return nil
 	}
 
-	// Get password from user
-	fmt.Print("Enter your wallet password: ")
-	password, err := term.ReadPassword(int(syscall.Stdin))
+	// Get password from user, or from a file or the environment in scripts
+	password, err := readWalletPassword(cmd, "Enter your wallet password: ")
 	if err != nil {
-		return fmt.Errorf("failed to read password: %w", err)
+		return err
 	}
-	fmt.Println() // New line after password input
 
 	// Unlock wallet
 	fmt.Println("Unlocking wallet...")
-	err = manager.Unlock(string(password))
+	err = manager.Unlock(password)
 	if err != nil {
 		return fmt.Errorf("failed to unlock wallet: %w", err)
 	}
 
+	if !manager.SessionStored() {
+		fmt.Println("✅ Password is correct")
+		fmt.Println("🔐 Sessions aren't stored, every command asks for the password")
+		return nil
+	}
+
 	fmt.Println("✅ Wallet unlocked successfully!")
+	fmt.Printf("⏱️  Locks automatically after %s of inactivity\n", formatSessionTimeout(manager.SessionTimeout()))
+
+	printUnlockSummary(manager)
+
 	fmt.Println("💡 Use 'odyssey address [chain]' to see your addresses")
 	fmt.Println("💡 Use 'odyssey balance [chain]' to check your balances")
 
 	return nil
-}
\ No newline at end of file
+}
+
+// unlockAgent hands the keys to the running agent
+func unlockAgent(cmd *cobra.Command, manager *wallet.Manager) error {
+	status, err := manager.AgentStatus()
+	if err != nil {
+		if errors.Is(err, wallet.ErrAgentNotRunning) {
+			return fmt.Errorf("no agent is running, start it with 'odyssey agent start'")
+		}
+		return err
+	}
+	if status.Unlocked {
+		fmt.Println("✅ Wallet is already unlocked in the agent")
+		return nil
+	}
+
+	password, err := readWalletPassword(cmd, "Enter your wallet password: ")
+	if err != nil {
+		return err
+	}
+	if err := manager.AddToAgent(password); err != nil {
+		return fmt.Errorf("failed to unlock wallet: %w", err)
+	}
+
+	fmt.Println("✅ Wallet unlocked in the agent")
+	fmt.Printf("⏱️  Locks automatically after %s of inactivity\n", formatSessionTimeout(manager.SessionTimeout()))
+	printUnlockSummary(manager)
+	return nil
+}
+
+// formatSessionTimeout formats a timeout without trailing zero units, e.g. 10m instead of 10m0s
+func formatSessionTimeout(timeout time.Duration) string {
+	text := timeout.String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+// significantPriceMove is the price change in percent worth reporting on unlock
+const significantPriceMove = 5.0
+
+// printUnlockSummary shows the activity since the previous unlock, computed
+// from the local activity cache and balance snapshots only
+func printUnlockSummary(manager *wallet.Manager) {
+	network := manager.GetCurrentNetwork()
+	now := time.Now()
+
+	since, err := manager.MarkUnlocked(network, now)
+	if err != nil || since.IsZero() {
+		return
+	}
+	activity, err := manager.GetActivity()
+	if err != nil {
+		return
+	}
+
+	var lines []string
+
+	// Incoming transactions and our own sends
+	var incoming []wallet.ActivityTransaction
+	confirmed, unconfirmed := 0, 0
+	for _, tx := range activity.Transactions {
+		if tx.Network != network {
+			continue
+		}
+		if tx.Incoming && tx.Timestamp.After(since) {
+			incoming = append(incoming, tx)
+			continue
+		}
+		if tx.SentAt.IsZero() {
+			continue
+		}
+		if tx.Confirmed && tx.ConfirmedAt.After(since) {
+			confirmed++
+		} else if !tx.Confirmed && tx.SentAt.After(since) {
+			unconfirmed++
+		}
+	}
+
+	if len(incoming) > 0 {
+		lines = append(lines, fmt.Sprintf("📥 %d incoming transaction%s", len(incoming), plural(len(incoming))))
+		for i := len(incoming) - 1; i >= 0 && i >= len(incoming)-3; i-- {
+			tx := incoming[i]
+			lines = append(lines, fmt.Sprintf("   + %s on %s (%s)", tx.Amount, tx.Chain, tx.Timestamp.Local().Format("Jan 2 15:04")))
+		}
+	}
+	if confirmed > 0 {
+		lines = append(lines, fmt.Sprintf("📤 %d send%s confirmed", confirmed, plural(confirmed)))
+	}
+	if unconfirmed > 0 {
+		lines = append(lines, fmt.Sprintf("⏳ %d send%s not seen confirmed yet", unconfirmed, plural(unconfirmed)))
+	}
+
+	// Price moves of held assets between the snapshots around the last unlock
+	if snapshots, err := manager.GetBalanceSnapshots(network); err == nil && len(snapshots) > 1 {
+		latest := snapshots[len(snapshots)-1]
+		base := snapshots[0]
+		for _, snapshot := range snapshots {
+			if snapshot.Time.After(since) {
+				break
+			}
+			base = snapshot
+		}
+
+		if latest.Time.After(base.Time) {
+			for _, asset := range latest.Assets {
+				if !asset.Amount.IsPositive() || asset.PriceUSD <= 0 {
+					continue
+				}
+				for _, previous := range base.Assets {
+					if previous.Symbol != asset.Symbol || previous.Chain != asset.Chain || previous.PriceUSD <= 0 {
+						continue
+					}
+					change := (asset.PriceUSD/previous.PriceUSD - 1) * 100
+					if change >= significantPriceMove {
+						lines = append(lines, fmt.Sprintf("📈 %s %+.1f%% ($%.2f)", asset.Symbol, change, asset.PriceUSD))
+					} else if change <= -significantPriceMove {
+						lines = append(lines, fmt.Sprintf("📉 %s %+.1f%% ($%.2f)", asset.Symbol, change, asset.PriceUSD))
+					}
+				}
+			}
+		}
+	}
+
+	// Actions waiting for this wallet
+	approvals := 0
+	for _, approval := range activity.Approvals {
+		if approval.Network == network {
+			approvals++
+		}
+	}
+	if approvals > 0 {
+		lines = append(lines, fmt.Sprintf("✍️  %d Safe transaction%s awaiting your confirmation (odyssey safe pending)", approvals, plural(approvals)))
+	}
+	if pending, err := manager.GetPendingBroadcasts(); err == nil {
+		queued := 0
+		for _, entry := range pending {
+			if entry.Network == network && entry.Resumable() {
+				queued++
+			}
+		}
+		if queued > 0 {
+			lines = append(lines, fmt.Sprintf("🔁 %d broadcast%s queued (odyssey tx pending)", queued, plural(queued)))
+		}
+	}
+
+	fmt.Println()
+	if len(lines) == 0 {
+		fmt.Printf("📋 Nothing new since your last unlock %s ago\n", formatElapsed(now.Sub(since)))
+	} else {
+		fmt.Printf("📋 Since your last unlock %s ago:\n", formatElapsed(now.Sub(since)))
+		for _, line := range lines {
+			fmt.Printf("   %s\n", line)
+		}
+	}
+	fmt.Println()
+}
+
+// formatElapsed formats a duration in its largest sensible unit, e.g. 3d or 5h
+func formatElapsed(elapsed time.Duration) string {
+	switch {
+	case elapsed >= 48*time.Hour:
+		return fmt.Sprintf("%dd", int(elapsed.Hours()/24))
+	case elapsed >= time.Hour:
+		return fmt.Sprintf("%dh", int(elapsed.Hours()))
+	case elapsed >= time.Minute:
+		return fmt.Sprintf("%dm", int(elapsed.Minutes()))
+	default:
+		return "under a minute"
+	}
+}
+
+// plural returns the suffix for a count of things
+func plural(count int) string {
+	if count == 1 {
+		return ""
+	}
+	return "s"
+}
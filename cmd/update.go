@@ -97,7 +97,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		// Ask for confirmation
 		if !confirmUpdate(latestVer) {
 			fmt.Println("❌ Update cancelled")
-			return nil
+			return errCancelled
 		}
 
 		// Perform update by building from source
@@ -195,7 +195,7 @@ func formatReleaseDate(dateStr string) string {
 }
 
 func confirmUpdate(newVersion string) bool {
-	fmt.Printf("🔧 Build and install %s from source? This will replace your current installation (y/N): ", color.GreenString(newVersion))
+	fmt.Fprintf(promptOut(), "🔧 Build and install %s from source? This will replace your current installation (y/N): ", color.GreenString(newVersion))
 
 	var response string
 	fmt.Scanln(&response)
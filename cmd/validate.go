@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/chinmay1088/odyssey/chains/solana"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mr-tron/base58"
+	"github.com/spf13/cobra"
+)
+
+// errInvalidAddress is returned by validate for an address that can't be
+// paid, scripts tell it apart by the exit code
+var errInvalidAddress = errors.New("invalid address")
+
+var (
+	validateChainFlag  string
+	validateOutputFlag string
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [address]",
+	Short: "Check an address and detect its chain and network",
+	Long: `Check an address before paying it. The chain is detected from the format:
+
+  Ethereum  0x followed by 40 hex digits, with the EIP-55 checksum verified
+            when the address has mixed case
+  Bitcoin   base58check (1..., 3...) or bech32 and bech32m (bc1...)
+  Solana    32 bytes in base58
+
+Bitcoin addresses also tell their network, a warning is printed when it
+isn't the one odyssey is on. --chain makes an address of another chain
+invalid.
+
+The exit code is 0 for a valid address and 6 for an invalid one. With
+--output json the verdict is printed for scripts either way.
+
+Examples:
+  odyssey validate 0x742d35Cc6634C0532925A3B8D4C9dB96C4B4d8B6
+  odyssey validate bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh --chain btc
+  odyssey validate 7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runValidate,
+}
+
+func init() {
+	validateCmd.Flags().StringVar(&validateChainFlag, "chain", "", "Chain the address must belong to (eth, btc, sol or an EVM chain)")
+	validateCmd.Flags().StringVarP(&validateOutputFlag, "output", "o", "text", "Output format (text, json)")
+}
+
+// addressVerdict is the outcome of validating an address
+type addressVerdict struct {
+	Address    string   `json:"address"`
+	Valid      bool     `json:"valid"`
+	Chain      string   `json:"chain,omitempty"`      // ethereum, bitcoin or solana
+	Network    string   `json:"network,omitempty"`    // mainnet, testnet, regtest, or any
+	Type       string   `json:"type,omitempty"`       // Bitcoin script type, e.g. p2wpkh
+	Encoding   string   `json:"encoding,omitempty"`   // hex, base58check, bech32, bech32m or base58
+	Checksum   string   `json:"checksum,omitempty"`   // valid, or none if the format has none
+	Normalized string   `json:"normalized,omitempty"` // checksummed form
+	Warnings   []string `json:"warnings,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// invalid marks the verdict invalid for reason
+func (v *addressVerdict) invalid(reason string) *addressVerdict {
+	v.Valid = false
+	v.Error = reason
+	return v
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	output := strings.ToLower(validateOutputFlag)
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format: %s. Use 'text' or 'json'", validateOutputFlag)
+	}
+
+	wantChain := ""
+	if validateChainFlag != "" {
+		_, whitelistChain, err := parsePolicyChain(strings.ToLower(validateChainFlag))
+		if err != nil {
+			return err
+		}
+		wantChain = whitelistChain
+	}
+
+	manager := wallet.NewManager()
+	verdict := validateAddress(strings.TrimSpace(args[0]), manager.GetCurrentNetwork())
+	if verdict.Valid && wantChain != "" && verdict.Chain != wantChain {
+		verdict.invalid(fmt.Sprintf("a %s address, not %s", verdict.Chain, wantChain))
+	}
+
+	if output == "json" {
+		data, err := json.MarshalIndent(verdict, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal verdict: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printAddressVerdict(verdict)
+	}
+
+	if !verdict.Valid {
+		if verdict.Chain != "" && !strings.Contains(verdict.Error, verdict.Chain) {
+			return fmt.Errorf("%w: %s (detected chain: %s)", errInvalidAddress, verdict.Error, verdict.Chain)
+		}
+		return fmt.Errorf("%w: %s", errInvalidAddress, verdict.Error)
+	}
+	return nil
+}
+
+// printAddressVerdict prints a verdict as text, the reason an address is
+// invalid is the error of the command
+func printAddressVerdict(verdict *addressVerdict) {
+	if !verdict.Valid {
+		printResult("invalid", "")
+		return
+	}
+
+	printResult(verdict.Chain, "✅ Valid %s address\n", verdict.Chain)
+	fmt.Println()
+	fmt.Printf("   Address:  %s\n", verdict.Normalized)
+	fmt.Printf("   Network:  %s\n", verdict.Network)
+	if verdict.Type != "" {
+		fmt.Printf("   Type:     %s\n", verdict.Type)
+	}
+	fmt.Printf("   Encoding: %s\n", verdict.Encoding)
+	fmt.Printf("   Checksum: %s\n", verdict.Checksum)
+	for _, warning := range verdict.Warnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+}
+
+// validateAddress detects the chain of address and checks it. network is
+// the network odyssey is on, addresses of another one get a warning.
+func validateAddress(address, network string) *addressVerdict {
+	verdict := &addressVerdict{Address: address, Valid: true}
+	switch {
+	case address == "":
+		return verdict.invalid("the address is empty")
+	case strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X"):
+		return validateEthereumAddress(verdict)
+	case hasBech32Prefix(address):
+		return validateBitcoinAddress(verdict, network)
+	}
+
+	// Base58: Bitcoin addresses carry a checksum and are 25 bytes, Solana
+	// addresses are 32 bytes without one
+	if decoded, err := base58.Decode(address); err == nil && len(decoded) == 32 {
+		return validateSolanaAddress(verdict)
+	}
+	return validateBitcoinAddress(verdict, network)
+}
+
+// validateEthereumAddress checks the hex digits and the EIP-55 checksum
+func validateEthereumAddress(verdict *addressVerdict) *addressVerdict {
+	address := verdict.Address
+	verdict.Chain = "ethereum"
+	verdict.Network = "any"
+	verdict.Encoding = "hex"
+	if !common.IsHexAddress(address) {
+		return verdict.invalid("an Ethereum address is 0x followed by 40 hex digits")
+	}
+
+	checksummed := common.HexToAddress(address).Hex()
+	verdict.Normalized = checksummed
+	digits := address[2:]
+	switch {
+	case digits == strings.ToLower(digits) || digits == strings.ToUpper(digits):
+		verdict.Checksum = "none"
+		verdict.Warnings = append(verdict.Warnings, "No EIP-55 checksum (all one case), a typo can't be detected. Prefer the checksummed form above")
+	case "0x"+digits != checksummed:
+		return verdict.invalid("EIP-55 checksum mismatch, the address has a typo or was altered")
+	default:
+		verdict.Checksum = "valid"
+	}
+
+	if common.HexToAddress(address) == (common.Address{}) {
+		verdict.Warnings = append(verdict.Warnings, "This is the zero address, funds sent to it are burned")
+	}
+	verdict.Warnings = append(verdict.Warnings, "The same address is used on Ethereum and every EVM chain, on mainnet and testnet")
+	return verdict
+}
+
+// bitcoinNetworks are the networks a Bitcoin address is tried against.
+// Signet shares the addresses of testnet.
+var bitcoinNetworks = []struct {
+	name   string
+	params *chaincfg.Params
+}{
+	{"mainnet", &chaincfg.MainNetParams},
+	{"testnet", &chaincfg.TestNet3Params},
+	{"regtest", &chaincfg.RegressionNetParams},
+}
+
+// hasBech32Prefix returns true for the human readable parts of Bitcoin
+// segwit addresses
+func hasBech32Prefix(address string) bool {
+	lower := strings.ToLower(address)
+	for _, prefix := range []string{"bc1", "tb1", "bcrt1"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateBitcoinAddress decodes the address, which verifies its checksum,
+// and finds its network and script type
+func validateBitcoinAddress(verdict *addressVerdict, network string) *addressVerdict {
+	verdict.Chain = "bitcoin"
+
+	var decoded btcutil.Address
+	var lastErr error
+	for _, candidate := range bitcoinNetworks {
+		addr, err := btcutil.DecodeAddress(verdict.Address, candidate.params)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if addr.IsForNet(candidate.params) {
+			decoded = addr
+			verdict.Network = candidate.name
+			break
+		}
+	}
+	if decoded == nil {
+		if !hasBech32Prefix(verdict.Address) && !looksLikeBase58Bitcoin(verdict.Address) {
+			verdict.Chain = ""
+			return verdict.invalid("not an Ethereum, Bitcoin or Solana address")
+		}
+		return verdict.invalid(fmt.Sprintf("not a valid Bitcoin address: %v", lastErr))
+	}
+
+	verdict.Normalized = decoded.EncodeAddress()
+	verdict.Checksum = "valid"
+	switch decoded.(type) {
+	case *btcutil.AddressPubKeyHash:
+		verdict.Type, verdict.Encoding = "p2pkh", "base58check"
+	case *btcutil.AddressScriptHash:
+		verdict.Type, verdict.Encoding = "p2sh", "base58check"
+	case *btcutil.AddressWitnessPubKeyHash:
+		verdict.Type, verdict.Encoding = "p2wpkh", "bech32"
+	case *btcutil.AddressWitnessScriptHash:
+		verdict.Type, verdict.Encoding = "p2wsh", "bech32"
+	case *btcutil.AddressTaproot:
+		verdict.Type, verdict.Encoding = "p2tr", "bech32m"
+	default:
+		verdict.Encoding = "bech32m"
+		verdict.Warnings = append(verdict.Warnings, "Unknown witness version, wallets may not be able to spend from this address")
+	}
+
+	if verdict.Network != network && !(verdict.Network == "regtest" && network == "testnet") {
+		verdict.Warnings = append(verdict.Warnings, fmt.Sprintf("This is a %s address but odyssey is on %s, payments from this network can't reach it", verdict.Network, network))
+	}
+	return verdict
+}
+
+// looksLikeBase58Bitcoin returns true for strings starting like a legacy or
+// nested segwit address of any network
+func looksLikeBase58Bitcoin(address string) bool {
+	if len(address) < 26 || len(address) > 35 {
+		return false
+	}
+	return strings.ContainsRune("13mn2", rune(address[0]))
+}
+
+// validateSolanaAddress checks a base58 public key
+func validateSolanaAddress(verdict *addressVerdict) *addressVerdict {
+	verdict.Chain = "solana"
+	verdict.Network = "any"
+	verdict.Encoding = "base58"
+	verdict.Checksum = "none"
+
+	key, err := solana.ParseAddress(verdict.Address)
+	if err != nil {
+		return verdict.invalid(err.Error())
+	}
+	verdict.Normalized = key.String()
+	if !key.IsOnCurve() {
+		verdict.Warnings = append(verdict.Warnings, "Off the ed25519 curve: a program derived address, no private key can sign for it")
+	}
+	verdict.Warnings = append(verdict.Warnings, "Solana addresses have no checksum, compare every character", "The same address is used on mainnet and devnet")
+	return verdict
+}
@@ -0,0 +1,395 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chinmay1088/odyssey/crypto"
+	"github.com/chinmay1088/odyssey/wallet"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/mr-tron/base58"
+	"github.com/spf13/cobra"
+)
+
+var (
+	vanityPrefixFlag     string
+	vanitySuffixFlag     string
+	vanityThreadsFlag    int
+	vanityIgnoreCaseFlag bool
+	vanityImportFlag     bool
+	vanityLabelFlag      string
+)
+
+// vanityMaxAttempts is the most expected attempts a pattern may need, about
+// a month on a fast machine
+const vanityMaxAttempts = 1 << 42
+
+// base58Alphabet are the characters of Solana addresses
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var vanityCmd = &cobra.Command{
+	Use:   "vanity [eth|sol]",
+	Short: "Generate an address that starts or ends with chosen characters",
+	Long: `Generate random keys until the address starts with --prefix and ends with
+--suffix, using every CPU core. Progress is shown every second, Ctrl+C stops
+the search.
+
+Ethereum patterns are hex (0-9, a-f) and match in any case. Solana patterns
+are base58, without 0, O, I and l, and match case-sensitively unless
+--ignore-case is set. Every character makes the search 16 (Ethereum) or 58
+(Solana) times longer: 4 characters take seconds, 6 minutes to hours.
+
+The key isn't derived from your recovery phrase. With --import, or when
+asked, it is kept in the vault as an additional account shown by 'odyssey
+address' and 'odyssey balance --all-wallets'. Otherwise the private key is
+printed once and only you have it.
+
+Examples:
+  odyssey vanity eth --prefix dead
+  odyssey vanity sol --prefix Chin
+  odyssey vanity eth --prefix 00 --suffix 00 --import --label "Donations"
+  odyssey vanity sol --prefix chin --ignore-case --threads 4`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVanity,
+}
+
+func init() {
+	vanityCmd.Flags().StringVar(&vanityPrefixFlag, "prefix", "", "Characters the address starts with, after 0x on Ethereum")
+	vanityCmd.Flags().StringVar(&vanitySuffixFlag, "suffix", "", "Characters the address ends with")
+	vanityCmd.Flags().IntVar(&vanityThreadsFlag, "threads", runtime.NumCPU(), "Number of CPU cores to search with")
+	vanityCmd.Flags().BoolVar(&vanityIgnoreCaseFlag, "ignore-case", false, "Match Solana patterns in any case")
+	vanityCmd.Flags().BoolVar(&vanityImportFlag, "import", false, "Keep the key in the vault as an additional account without asking")
+	vanityCmd.Flags().StringVar(&vanityLabelFlag, "label", "", "Name of the imported account (default \"Vanity\" and the address)")
+}
+
+// vanityPattern is what a generated address must look like
+type vanityPattern struct {
+	chain      string // ethereum or solana
+	prefix     string
+	suffix     string
+	ignoreCase bool
+}
+
+// vanityKey is a generated key whose address matches the pattern
+type vanityKey struct {
+	address string
+	key     []byte // 32 byte secp256k1 scalar or 64 byte ed25519 key
+}
+
+func runVanity(cmd *cobra.Command, args []string) error {
+	chain, err := parseWatchChain(args[0])
+	if err != nil {
+		return err
+	}
+	pattern, err := newVanityPattern(chain, vanityPrefixFlag, vanitySuffixFlag, vanityIgnoreCaseFlag)
+	if err != nil {
+		return err
+	}
+	if vanityThreadsFlag < 1 {
+		return fmt.Errorf("threads must be at least 1")
+	}
+
+	manager := wallet.NewManager()
+	if vanityImportFlag && !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	expected := pattern.expectedAttempts()
+	fmt.Printf("🎯 Searching %s addresses %s\n", vanityChainName(chain), pattern)
+	fmt.Printf("🔢 About %s attempts on average, %d thread(s)\n", formatAttempts(expected), vanityThreadsFlag)
+	fmt.Println("   Press Ctrl+C to stop")
+	fmt.Println()
+
+	start := time.Now()
+	found, attempts, err := grindVanityKey(cmd.Context(), pattern, vanityThreadsFlag, expected)
+	if err != nil {
+		return err
+	}
+	defer crypto.ClearBytes(found.key)
+
+	elapsed := time.Since(start)
+	fmt.Printf("✅ Found after %s attempts in %s\n", formatAttempts(float64(attempts)), elapsed.Round(time.Second))
+	fmt.Println()
+	printResult(found.address, "   Address: %s\n", found.address)
+	fmt.Println()
+
+	importKey := vanityImportFlag
+	if !importKey && manager.IsUnlocked() {
+		importKey = confirmVanityImport()
+	}
+	if !importKey {
+		printVanityKey(chain, found)
+		return nil
+	}
+
+	label := vanityLabelFlag
+	if label == "" {
+		label = "Vanity " + truncateAddress(found.address)
+	}
+	if err := manager.ImportKey(chain, label, found.address, found.key); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Imported as %q\n", label)
+	fmt.Println("⚠️  Your recovery phrase doesn't restore this account, back up ~/.odyssey/wallet.vault")
+	return nil
+}
+
+// newVanityPattern checks that prefix and suffix can occur in an address of
+// chain and aren't too long to be found
+func newVanityPattern(chain, prefix, suffix string, ignoreCase bool) (*vanityPattern, error) {
+	pattern := &vanityPattern{chain: chain, prefix: prefix, suffix: suffix, ignoreCase: ignoreCase}
+	switch chain {
+	case "ethereum":
+		if ignoreCase {
+			return nil, fmt.Errorf("--ignore-case is only supported for Solana, Ethereum patterns always match in any case")
+		}
+		pattern.prefix = strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(prefix, "0x"), "0X"))
+		pattern.suffix = strings.ToLower(suffix)
+		for _, r := range pattern.prefix + pattern.suffix {
+			if !strings.ContainsRune("0123456789abcdef", r) {
+				return nil, fmt.Errorf("invalid character %q: Ethereum addresses only contain 0-9 and a-f", r)
+			}
+		}
+	case "solana":
+		for _, r := range prefix + suffix {
+			if pattern.caseVariants(r) == 0 {
+				return nil, fmt.Errorf("invalid character %q: Solana addresses are base58, without 0, O, I and l", r)
+			}
+		}
+		if ignoreCase {
+			pattern.prefix = strings.ToLower(prefix)
+			pattern.suffix = strings.ToLower(suffix)
+		}
+	default:
+		return nil, fmt.Errorf("vanity addresses are supported for Ethereum and Solana")
+	}
+
+	if pattern.prefix == "" && pattern.suffix == "" {
+		return nil, fmt.Errorf("set --prefix, --suffix or both")
+	}
+	if pattern.expectedAttempts() > vanityMaxAttempts {
+		return nil, fmt.Errorf("the pattern is too long to be found in reasonable time, use fewer characters")
+	}
+	return pattern, nil
+}
+
+// caseVariants returns the number of base58 characters that match r
+func (p *vanityPattern) caseVariants(r rune) int {
+	if !p.ignoreCase {
+		return strings.Count(base58Alphabet, string(r))
+	}
+	variants := 0
+	for _, c := range base58Alphabet {
+		if strings.EqualFold(string(c), string(r)) {
+			variants++
+		}
+	}
+	return variants
+}
+
+// expectedAttempts returns the average number of keys to generate until one
+// matches
+func (p *vanityPattern) expectedAttempts() float64 {
+	if p.chain == "ethereum" {
+		return math.Pow(16, float64(len(p.prefix)+len(p.suffix)))
+	}
+	attempts := 1.0
+	for _, r := range p.prefix + p.suffix {
+		attempts *= 58 / float64(p.caseVariants(r))
+	}
+	return attempts
+}
+
+// matches reports whether an address, without 0x on Ethereum, fits the
+// pattern
+func (p *vanityPattern) matches(address string) bool {
+	if p.ignoreCase {
+		address = strings.ToLower(address)
+	}
+	return strings.HasPrefix(address, p.prefix) && strings.HasSuffix(address, p.suffix)
+}
+
+// String describes the pattern, e.g. starting with dead
+func (p *vanityPattern) String() string {
+	var parts []string
+	if p.prefix != "" {
+		start := p.prefix
+		if p.chain == "ethereum" {
+			start = "0x" + start
+		}
+		parts = append(parts, "starting with "+start)
+	}
+	if p.suffix != "" {
+		parts = append(parts, "ending with "+p.suffix)
+	}
+	description := strings.Join(parts, " and ")
+	if p.ignoreCase {
+		description += " (any case)"
+	}
+	return description
+}
+
+// generate returns a random key and its address in the form matched by the
+// pattern
+func (p *vanityPattern) generate() (*vanityKey, string, error) {
+	if p.chain == "ethereum" {
+		key, err := ethcrypto.GenerateKey()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate key: %w", err)
+		}
+		address := ethcrypto.PubkeyToAddress(key.PublicKey)
+		candidate := hex.EncodeToString(address[:])
+		return &vanityKey{address: address.Hex(), key: ethcrypto.FromECDSA(key)}, candidate, nil
+	}
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	address := base58.Encode(public)
+	return &vanityKey{address: address, key: private}, address, nil
+}
+
+// grindVanityKey generates keys on threads goroutines until one matches the
+// pattern, printing progress every second. It returns the key and the
+// number of keys generated.
+func grindVanityKey(ctx context.Context, pattern *vanityPattern, threads int, expected float64) (*vanityKey, uint64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var attempts atomic.Uint64
+	found := make(chan *vanityKey, 1)
+	errs := make(chan error, threads)
+
+	var wg sync.WaitGroup
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				key, candidate, err := pattern.generate()
+				if err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+				attempts.Add(1)
+				if !pattern.matches(candidate) {
+					crypto.ClearBytes(key.key)
+					continue
+				}
+				select {
+				case found <- key:
+				default:
+					crypto.ClearBytes(key.key)
+				}
+				cancel()
+				return
+			}
+		}()
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	progress := false
+	for ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+			printVanityProgress(attempts.Load(), time.Since(start), expected)
+			progress = true
+		}
+	}
+	wg.Wait()
+	if progress && !plainMode {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+
+	select {
+	case key := <-found:
+		return key, attempts.Load(), nil
+	default:
+	}
+	select {
+	case err := <-errs:
+		return nil, 0, err
+	default:
+	}
+	return nil, 0, ctx.Err()
+}
+
+// printVanityProgress shows the keys generated so far, the rate and the
+// expected time left on stderr
+func printVanityProgress(attempts uint64, elapsed time.Duration, expected float64) {
+	rate := float64(attempts) / elapsed.Seconds()
+	remaining := "unknown"
+	if rate > 0 {
+		left := math.Max(expected-float64(attempts), 0) / rate
+		remaining = "~" + (time.Duration(left) * time.Second).Round(time.Second).String()
+	}
+	line := fmt.Sprintf("⏳ %s attempts, %s/s, %s left on average", formatAttempts(float64(attempts)), formatAttempts(rate), remaining)
+	if plainMode {
+		fmt.Fprintln(os.Stderr, line)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\r\033[K%s", line)
+}
+
+// formatAttempts shortens a count, e.g. 1.2M
+func formatAttempts(n float64) string {
+	switch {
+	case n >= 1e12:
+		return fmt.Sprintf("%.1fT", n/1e12)
+	case n >= 1e9:
+		return fmt.Sprintf("%.1fB", n/1e9)
+	case n >= 1e6:
+		return fmt.Sprintf("%.1fM", n/1e6)
+	case n >= 1e3:
+		return fmt.Sprintf("%.1fk", n/1e3)
+	}
+	return fmt.Sprintf("%.0f", n)
+}
+
+// vanityChainName names a chain for display
+func vanityChainName(chain string) string {
+	if chain == "ethereum" {
+		return "Ethereum"
+	}
+	return "Solana"
+}
+
+// confirmVanityImport asks whether to keep the generated key in the vault
+func confirmVanityImport() bool {
+	fmt.Fprint(promptOut(), "🔐 Keep the key in your vault as an additional account? (y/N): ")
+
+	var response string
+	fmt.Scanln(&response)
+
+	response = strings.ToLower(strings.TrimSpace(response))
+	fmt.Println()
+	return response == "y" || response == "yes"
+}
+
+// printVanityKey prints a private key that isn't kept in the vault, in the
+// format other wallets import
+func printVanityKey(chain string, found *vanityKey) {
+	privateKey := hex.EncodeToString(found.key)
+	if chain == "solana" {
+		privateKey = base58.Encode(found.key)
+	}
+	fmt.Println("🔑 Private key:")
+	fmt.Printf("   %s\n", privateKey)
+	fmt.Println()
+	fmt.Println("⚠️  This key is shown only once and isn't stored anywhere. Anyone with it controls the address")
+}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/crypto"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	walletCreateImportFlag bool
+	walletDeleteBackupFlag string
+)
+
+// walletDeletePhrase must be typed to confirm that the wallet is deleted
+const walletDeletePhrase = "delete my wallet"
+
+var walletCmd = &cobra.Command{
+	Use:   "wallet",
+	Short: "Manage the wallets on this machine",
+	Long: `Manage the wallets on this machine.
+
+Besides the default wallet in ~/.odyssey, named wallets live in
+~/.odyssey/wallets/<name>, each with its own vault, network, session and
+address book. Pick one per command with --wallet or ODYSSEY_WALLET, or for
+every command with 'odyssey wallet use'. Settings are shared by all wallets.
+
+Examples:
+  odyssey wallet create work
+  odyssey wallet create personal --import
+  odyssey wallet list
+  odyssey wallet use work
+  odyssey balance --wallet personal
+  odyssey wallet delete --wallet work`,
+}
+
+var walletCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a named wallet",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWalletCreate,
+}
+
+var walletListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the wallets on this machine",
+	Args:  cobra.NoArgs,
+	RunE:  runWalletList,
+}
+
+var walletUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Use a wallet when no --wallet is given",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWalletUse,
+}
+
+var walletDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Wipe the wallet from this machine",
+	Long: `Wipe the wallet from this machine, the active one or the one given with
+--wallet. It needs the wallet password and the phrase
+'` + walletDeletePhrase + `' typed to confirm.
+
+The vault, sessions, caches, address book, known contracts, multisig wallets,
+snapshots and every other file of the wallet's directory are overwritten
+with random data and removed. Settings, the audit log, which records the
+deletion, exported reports and other named wallets are kept.
+
+Without the recovery phrase the funds are lost for good. With --backup, an
+encrypted copy of the vault and the wallet files is written first, encrypted
+with the wallet password. 'odyssey migrate import' restores it.
+
+Examples:
+  odyssey wallet delete
+  odyssey wallet delete --backup final.bundle
+  odyssey wallet delete --wallet work`,
+	Args: cobra.NoArgs,
+	RunE: runWalletDelete,
+}
+
+func init() {
+	walletCreateCmd.Flags().BoolVar(&walletCreateImportFlag, "import", false, "Restore the wallet from a recovery phrase instead of creating a new one")
+	walletDeleteCmd.Flags().StringVar(&walletDeleteBackupFlag, "backup", "", "Write an encrypted backup to this file before deleting")
+
+	walletCmd.AddCommand(walletCreateCmd)
+	walletCmd.AddCommand(walletListCmd)
+	walletCmd.AddCommand(walletUseCmd)
+	walletCmd.AddCommand(walletDeleteCmd)
+}
+
+func runWalletCreate(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(args[0])
+	if err := config.ValidateWalletName(name); err != nil {
+		return err
+	}
+	if name == config.DefaultWallet {
+		return fmt.Errorf("the default wallet is created with 'odyssey init'")
+	}
+	dir, err := config.WalletDirOf(name)
+	if err != nil {
+		return err
+	}
+	if walletExists(name) {
+		return fmt.Errorf("wallet %q already exists", name)
+	}
+
+	if err := config.SelectWallet(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	fmt.Printf("👛 Wallet %q in %s\n", name, dir)
+	if walletCreateImportFlag {
+		err = importRecoveryPhrase(cmd.Context(), wallet.NewManager())
+	} else {
+		err = runInit(cmd, nil)
+	}
+	if err != nil {
+		// Leave no empty wallet behind
+		if !walletExists(name) {
+			os.RemoveAll(dir)
+		}
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("💡 Use it with --wallet %s, or for every command with 'odyssey wallet use %s'\n", name, name)
+	return nil
+}
+
+func runWalletList(cmd *cobra.Command, args []string) error {
+	names, err := config.WalletNames()
+	if err != nil {
+		return err
+	}
+	active := config.ActiveWallet()
+
+	fmt.Println("👛 Wallets")
+	fmt.Println()
+	for _, name := range names {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		status := config.WalletNetwork(name)
+		if !walletExists(name) {
+			status = "no vault"
+		}
+		printResult(name, " %s %-20s %s\n", marker, name, status)
+	}
+	fmt.Println()
+	fmt.Println("* active wallet, change it with 'odyssey wallet use [name]'")
+	return nil
+}
+
+func runWalletUse(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(args[0])
+	if err := config.ValidateWalletName(name); err != nil {
+		return err
+	}
+	if name != config.DefaultWallet && !walletExists(name) {
+		return fmt.Errorf("wallet %q doesn't exist. Create it with 'odyssey wallet create %s'", name, name)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if name == config.DefaultWallet {
+		err = cfg.Unset(config.KeyDefaultWallet)
+	} else {
+		err = cfg.Set(config.KeyDefaultWallet, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Using wallet %q\n", name)
+	if env := os.Getenv(config.WalletEnv); env != "" && env != name {
+		fmt.Printf("⚠️  %s=%s still selects another wallet in this shell\n", config.WalletEnv, env)
+	}
+	return nil
+}
+
+// walletExists returns true if the wallet name has a vault
+func walletExists(name string) bool {
+	dir, err := config.WalletDirOf(name)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(dir, "wallet.vault"))
+	return err == nil
+}
+
+func runWalletDelete(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+	if !manager.VaultExists() {
+		return fmt.Errorf("no wallet found")
+	}
+	if walletDeleteBackupFlag != "" {
+		if _, err := os.Stat(walletDeleteBackupFlag); err == nil {
+			return fmt.Errorf("%s already exists", walletDeleteBackupFlag)
+		}
+	}
+
+	name := config.ActiveWallet()
+	if name == config.DefaultWallet {
+		fmt.Println("🗑️  Delete Wallet")
+	} else {
+		fmt.Printf("🗑️  Delete Wallet %q\n", name)
+	}
+	fmt.Println()
+	fmt.Println("⚠️  This wipes the vault and every wallet file from this machine.")
+	fmt.Println("⚠️  Without your recovery phrase your funds are lost for good.")
+	if walletDeleteBackupFlag == "" {
+		fmt.Println("💡 Use --backup [file] to keep an encrypted copy first")
+	}
+	fmt.Println()
+
+	password, err := readWalletPassword(cmd, "Enter your password: ")
+	if err != nil {
+		return err
+	}
+	if err := manager.CheckDeletePassword(password); err != nil {
+		return err
+	}
+
+	if walletDeleteBackupFlag != "" {
+		if err := writeWalletBackup(manager, password, walletDeleteBackupFlag); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Backup written to %s\n", walletDeleteBackupFlag)
+		fmt.Println()
+	}
+
+	fmt.Fprintf(promptOut(), "Type '%s' to confirm: ", walletDeletePhrase)
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return errCancelled
+	}
+	if strings.TrimSpace(line) != walletDeletePhrase {
+		return errCancelled
+	}
+	fmt.Println()
+
+	removed, err := manager.DeleteWallet(password)
+	if err != nil {
+		if len(removed) > 0 {
+			fmt.Printf("⚠️  Removed %s before the error\n", strings.Join(removed, ", "))
+		}
+		return err
+	}
+
+	fmt.Printf("✅ Wallet deleted, wiped %s\n", strings.Join(removed, ", "))
+	if name == config.DefaultWallet {
+		fmt.Println("💡 Run 'odyssey init' or 'odyssey recovery-phrase import' to set up a wallet again")
+	} else {
+		fmt.Printf("💡 Run 'odyssey wallet create %s' to set it up again\n", name)
+	}
+	return nil
+}
+
+// writeWalletBackup writes the vault and the wallet files to path, encrypted
+// with the wallet password in the format of 'odyssey migrate'
+func writeWalletBackup(manager *wallet.Manager, password, path string) error {
+	bundle, err := manager.ExportBackup()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to encode backup: %w", err)
+	}
+	sealed, err := crypto.SealWithPassword(password, data, migratePurpose)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt backup: %w", err)
+	}
+	out, err := json.MarshalIndent(sealed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode backup: %w", err)
+	}
+
+	if err := os.WriteFile(path, out, 0600); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/api"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+const (
+	minWatchInterval = 5 * time.Second // keeps public endpoints from rate limiting us
+	watchPageSize    = 10
+)
+
+var (
+	watchIntervalFlag time.Duration
+	watchTimeoutFlag  time.Duration
+	watchUntilFlag    bool
+	watchBellFlag     bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [chain]",
+	Short: "Watch your addresses for new transactions",
+	Long: `Watch your addresses for new transactions and print them as they appear,
+useful while waiting for a deposit to land.
+
+The addresses are polled at an interval. A transaction is reported when it is
+first seen and again when it is confirmed, also as a desktop notification
+unless display.notifications is turned off.
+
+Supported chains: eth, btc, sol (all of them if none is given)
+
+Examples:
+  odyssey watch                       # Watch all chains until Ctrl+C
+  odyssey watch btc --until-received  # Stop once a Bitcoin deposit is confirmed
+  odyssey watch sol --interval 5s --bell
+  odyssey watch eth --timeout 30m`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchIntervalFlag, "interval", 15*time.Second, "Time between polls")
+	watchCmd.Flags().DurationVar(&watchTimeoutFlag, "timeout", 0, "Stop watching after this long (0 watches until Ctrl+C)")
+	watchCmd.Flags().BoolVar(&watchUntilFlag, "until-received", false, "Stop once an incoming transaction is confirmed")
+	watchCmd.Flags().BoolVar(&watchBellFlag, "bell", false, "Ring the terminal bell on new transactions")
+}
+
+// watchedAddress is an address polled for transactions
+type watchedAddress struct {
+	chain   string // ethereum, bitcoin or solana
+	emoji   string
+	address string
+	fetch   func(ctx context.Context, limit int) (*api.TransactionPage, error)
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if watchIntervalFlag < minWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+	}
+	if watchTimeoutFlag < 0 {
+		return fmt.Errorf("--timeout must not be negative")
+	}
+
+	manager := wallet.NewManager()
+	client := api.NewClient()
+
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	var chains []string
+	if len(args) == 0 {
+		if manager.IsTestnet() {
+			chains = []string{"ethereum", "solana"}
+		} else {
+			chains = []string{"ethereum", "bitcoin", "solana"}
+		}
+	} else {
+		switch strings.ToLower(args[0]) {
+		case "eth", "ethereum":
+			chains = []string{"ethereum"}
+		case "btc", "bitcoin":
+			if !manager.BitcoinSupported() {
+				return fmt.Errorf("bitcoin is not supported in testnet mode")
+			}
+			chains = []string{"bitcoin"}
+		case "sol", "solana":
+			chains = []string{"solana"}
+		default:
+			return fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol", args[0])
+		}
+	}
+
+	watched, err := watchedAddresses(manager, client, chains)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if watchTimeoutFlag > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, watchTimeoutFlag)
+		defer cancel()
+	}
+
+	noun := "addresses"
+	if len(watched) == 1 {
+		noun = "address"
+	}
+	fmt.Printf("👀 Watching %d %s every %s (Ctrl+C to stop)\n", len(watched), noun, watchIntervalFlag)
+	for _, w := range watched {
+		fmt.Printf("   %s %s\n", w.emoji, w.address)
+	}
+	fmt.Println()
+
+	// The transactions already there when watching starts aren't reported,
+	// except pending ones which are reported once they confirm
+	confirmed := make(map[string]bool)
+	for _, w := range watched {
+		page, err := w.fetch(ctx, watchPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s transactions: %w", w.chain, err)
+		}
+		for _, tx := range page.Transactions {
+			confirmed[w.chain+":"+tx.Hash] = tx.BlockNumber > 0
+		}
+	}
+
+	start := time.Now()
+	reported := 0
+	failing := make(map[string]bool) // chains whose last poll failed
+
+	ticker := time.NewTicker(watchIntervalFlag)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			fmt.Printf("🛑 Stopped watching after %s, %d transaction%s seen\n", formatElapsed(time.Since(start)), reported, plural(reported))
+			if watchUntilFlag {
+				return fmt.Errorf("no incoming transaction was confirmed")
+			}
+			return nil
+		case <-ticker.C:
+		}
+
+		for _, w := range watched {
+			page, err := w.fetch(ctx, watchPageSize)
+			if err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				// Keep watching through outages, reporting each one once
+				if !failing[w.address] {
+					fmt.Printf("⚠️  %s: %v, retrying\n", w.chain, err)
+					failing[w.address] = true
+				}
+				continue
+			}
+			failing[w.address] = false
+
+			var fresh []api.Transaction
+			// Oldest first, so they are reported in the order they happened
+			for i := len(page.Transactions) - 1; i >= 0; i-- {
+				tx := page.Transactions[i]
+				key := w.chain + ":" + tx.Hash
+				wasConfirmed, seen := confirmed[key]
+				isConfirmed := tx.BlockNumber > 0
+				if seen && (wasConfirmed || !isConfirmed) {
+					continue
+				}
+				confirmed[key] = isConfirmed
+				fresh = append(fresh, tx)
+
+				if !seen {
+					reported++
+				}
+				printWatchedTransaction(w, tx, seen)
+
+				if watchUntilFlag && tx.IsIncoming && isConfirmed {
+					recordActivity(manager, w.chain, fresh)
+					fmt.Println("✅ Incoming transaction confirmed, stopping")
+					return nil
+				}
+			}
+			if len(fresh) > 0 {
+				recordActivity(manager, w.chain, fresh)
+			}
+		}
+	}
+}
+
+// watchedAddresses returns the addresses of the wallet on the given chains
+func watchedAddresses(manager *wallet.Manager, client *api.Client, chains []string) ([]watchedAddress, error) {
+	var watched []watchedAddress
+	for _, chain := range chains {
+		switch chain {
+		case "ethereum":
+			address, err := manager.GetEthereumAddress()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Ethereum address: %w", err)
+			}
+			hex := address.Hex()
+			watched = append(watched, watchedAddress{chain: chain, emoji: "🔷", address: hex, fetch: func(ctx context.Context, limit int) (*api.TransactionPage, error) {
+				return client.GetEthereumTransactions(ctx, hex, limit, "")
+			}})
+		case "bitcoin":
+			// Deposits may arrive on any address type
+			addresses, err := bitcoinWalletAddresses(manager)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Bitcoin address: %w", err)
+			}
+			for _, address := range addresses {
+				address := address
+				watched = append(watched, watchedAddress{chain: chain, emoji: "🟠", address: address, fetch: func(ctx context.Context, limit int) (*api.TransactionPage, error) {
+					return client.GetBitcoinTransactions(ctx, address, limit, "")
+				}})
+			}
+		case "solana":
+			address, err := manager.GetSolanaAddress()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Solana address: %w", err)
+			}
+			base58 := address.String()
+			watched = append(watched, watchedAddress{chain: chain, emoji: "🟣", address: base58, fetch: func(ctx context.Context, limit int) (*api.TransactionPage, error) {
+				return client.GetSolanaTransactions(ctx, base58, limit, "")
+			}})
+		}
+	}
+	return watched, nil
+}
+
+// printWatchedTransaction reports a new transaction, or the confirmation of
+// one reported before
+func printWatchedTransaction(w watchedAddress, tx api.Transaction, confirmation bool) {
+	if watchBellFlag {
+		fmt.Print("\a")
+	}
+
+	now := time.Now().Format("15:04:05")
+	direction := "⬅️ IN"
+	counterparty := "from " + displayAddress(tx.From)
+	if !tx.IsIncoming {
+		direction = "➡️ OUT"
+		counterparty = "to " + displayAddress(tx.To)
+	}
+
+	title := "Odyssey: incoming transaction"
+	if !tx.IsIncoming {
+		title = "Odyssey: outgoing transaction"
+	}
+
+	switch {
+	case confirmation:
+		fmt.Printf("[%s] %s ✅ Confirmed %s %s in block %d\n", now, w.emoji, tx.Amount, counterparty, tx.BlockNumber)
+		notifyUser(title+" confirmed", fmt.Sprintf("%s %s", tx.Amount, counterparty))
+	case tx.BlockNumber > 0:
+		fmt.Printf("[%s] %s %s %s %s, confirmed in block %d\n", now, w.emoji, direction, tx.Amount, counterparty, tx.BlockNumber)
+		notifyUser(title, fmt.Sprintf("%s %s, confirmed", tx.Amount, counterparty))
+	default:
+		fmt.Printf("[%s] %s %s %s %s, pending\n", now, w.emoji, direction, tx.Amount, counterparty)
+		notifyUser(title, fmt.Sprintf("%s %s, pending", tx.Amount, counterparty))
+	}
+	fmt.Printf("           Hash: %s\n", tx.Hash)
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/chains/bitcoin"
+	"github.com/chinmay1088/odyssey/chains/ethereum"
+	"github.com/chinmay1088/odyssey/chains/solana"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+var watchlistCmd = &cobra.Command{
+	Use:   "watchlist",
+	Short: "Manage watch-only addresses",
+	Long: `Manage watch-only addresses that are tracked without their private keys.
+
+Addresses are grouped by label, so a label can hold one address per chain.
+Watch-only wallets are included in 'odyssey balance --all-wallets'.
+
+Examples:
+  odyssey watchlist                              # List watch-only addresses
+  odyssey watchlist add cold btc bc1qxy2kgd...   # Watch a Bitcoin address
+  odyssey watchlist add cold eth 0x742d35Cc...   # Add an Ethereum address to the same label
+  odyssey watchlist remove cold btc              # Stop watching one address
+  odyssey watchlist remove cold                  # Remove the whole label`,
+	Args: cobra.NoArgs,
+	RunE: runWatchlistList,
+}
+
+var watchlistAddCmd = &cobra.Command{
+	Use:   "add [label] [chain] [address]",
+	Short: "Add a watch-only address",
+	Args:  cobra.ExactArgs(3),
+	RunE:  runWatchlistAdd,
+}
+
+var watchlistRemoveCmd = &cobra.Command{
+	Use:   "remove [label] [chain]",
+	Short: "Remove a watch-only address or label",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE:  runWatchlistRemove,
+}
+
+func init() {
+	watchlistCmd.AddCommand(watchlistAddCmd)
+	watchlistCmd.AddCommand(watchlistRemoveCmd)
+}
+
+func runWatchlistList(cmd *cobra.Command, args []string) error {
+	manager := wallet.NewManager()
+
+	addresses, err := manager.GetWatchAddresses()
+	if err != nil {
+		return err
+	}
+
+	if len(addresses) == 0 {
+		fmt.Println("📭 No watch-only addresses")
+		fmt.Println("💡 Add one with: odyssey watchlist add [label] [chain] [address]")
+		return nil
+	}
+
+	fmt.Println("👀 Watch-only Addresses")
+	fmt.Println()
+
+	label := ""
+	for _, entry := range addresses {
+		if entry.Label != label {
+			if label != "" {
+				fmt.Println()
+			}
+			label = entry.Label
+			fmt.Printf("🏷️  %s\n", label)
+		}
+		fmt.Printf("   %-9s %s\n", entry.Chain, entry.Address)
+	}
+
+	return nil
+}
+
+func runWatchlistAdd(cmd *cobra.Command, args []string) error {
+	label := strings.TrimSpace(args[0])
+	if label == "" {
+		return fmt.Errorf("label cannot be empty")
+	}
+
+	chain, err := parseWatchChain(args[1])
+	if err != nil {
+		return err
+	}
+
+	address, err := normalizeWatchAddress(chain, strings.TrimSpace(args[2]))
+	if err != nil {
+		return err
+	}
+
+	manager := wallet.NewManager()
+	if err := manager.AddWatchAddress(wallet.WatchAddress{Label: label, Chain: chain, Address: address}); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Watching %s address for '%s'\n", chain, label)
+	fmt.Printf("   📍 Address: %s\n", address)
+	return nil
+}
+
+func runWatchlistRemove(cmd *cobra.Command, args []string) error {
+	label := args[0]
+
+	chain := ""
+	if len(args) == 2 {
+		var err error
+		if chain, err = parseWatchChain(args[1]); err != nil {
+			return err
+		}
+	}
+
+	manager := wallet.NewManager()
+	removed, err := manager.RemoveWatchAddress(label, chain)
+	if err != nil {
+		return err
+	}
+
+	if removed == 0 {
+		return fmt.Errorf("no watch-only address found for '%s'", label)
+	}
+
+	fmt.Printf("✅ Removed %d watch-only address(es) from '%s'\n", removed, label)
+	return nil
+}
+
+// parseWatchChain converts a chain argument to the name stored in the watchlist
+func parseWatchChain(chain string) (string, error) {
+	switch strings.ToLower(chain) {
+	case "eth", "ethereum":
+		return "ethereum", nil
+	case "btc", "bitcoin":
+		return "bitcoin", nil
+	case "sol", "solana":
+		return "solana", nil
+	default:
+		return "", fmt.Errorf("unsupported chain: %s. Supported chains: eth, btc, sol", chain)
+	}
+}
+
+// normalizeWatchAddress validates an address and returns its canonical form
+func normalizeWatchAddress(chain, address string) (string, error) {
+	switch chain {
+	case "ethereum":
+		parsed, err := ethereum.ParseAddress(address)
+		if err != nil {
+			return "", err
+		}
+		return parsed.Hex(), nil
+	case "bitcoin":
+		parsed, err := bitcoin.ParseAddress(address)
+		if err != nil {
+			return "", fmt.Errorf("invalid Bitcoin address: %w", err)
+		}
+		return parsed.String(), nil
+	case "solana":
+		parsed, err := solana.ParseAddress(address)
+		if err != nil {
+			return "", err
+		}
+		return parsed.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported chain: %s", chain)
+	}
+}
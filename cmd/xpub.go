@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/wallet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	xpubTypeFlag   string
+	xpubOutputFlag string
+)
+
+var exportXpubCmd = &cobra.Command{
+	Use:   "xpub [chain]",
+	Short: "Export the extended public key of an account",
+	Long: `Export the extended public key of your Bitcoin or Ethereum account, to
+follow it in another wallet as watch-only or share it with an accountant.
+It derives every address of the account and shows all their transactions,
+but can't spend anything.
+
+Bitcoin keys are exported as zpub for native SegWit, ypub for nested SegWit
+and xpub for Taproot and legacy, with output descriptors for wallets like
+Sparrow and Bitcoin Core. --type picks the address type, by default the one
+in use. Solana keys have no extended public key.
+
+Examples:
+  odyssey export xpub btc
+  odyssey export xpub btc --type p2tr
+  odyssey export xpub eth
+  odyssey export xpub btc -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExportXpub,
+}
+
+func init() {
+	exportXpubCmd.Flags().StringVar(&xpubTypeFlag, "type", "", "Bitcoin address type (p2wpkh, p2tr, p2sh-p2wpkh, p2pkh)")
+	exportXpubCmd.Flags().StringVarP(&xpubOutputFlag, "output", "o", "text", "Output format (text, json)")
+
+	exportCmd.AddCommand(exportXpubCmd)
+}
+
+// xpubExport is the JSON output of export xpub
+type xpubExport struct {
+	Chain             string `json:"chain"`
+	Type              string `json:"type,omitempty"`
+	Path              string `json:"path"`
+	Fingerprint       string `json:"fingerprint"`
+	Key               string `json:"key"`
+	ReceiveDescriptor string `json:"receive_descriptor,omitempty"`
+	ChangeDescriptor  string `json:"change_descriptor,omitempty"`
+}
+
+func runExportXpub(cmd *cobra.Command, args []string) error {
+	output := strings.ToLower(xpubOutputFlag)
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format: %s. Use 'text' or 'json'", xpubOutputFlag)
+	}
+	chain, err := parseWatchChain(args[0])
+	if err != nil {
+		return err
+	}
+
+	manager := wallet.NewManager()
+	if !manager.IsUnlocked() {
+		return errWalletLocked
+	}
+
+	addressType := ""
+	if chain == "bitcoin" {
+		addressType = manager.BitcoinAddressType()
+		if xpubTypeFlag != "" {
+			addressType = strings.ToLower(xpubTypeFlag)
+			if _, ok := bitcoinAddressNames[addressType]; !ok {
+				return fmt.Errorf("invalid address type: %s. Use p2wpkh, p2tr, p2sh-p2wpkh or p2pkh", xpubTypeFlag)
+			}
+		}
+	} else if xpubTypeFlag != "" {
+		return fmt.Errorf("--type is only supported for Bitcoin")
+	}
+
+	if err := requireTwoFactor(manager); err != nil {
+		return err
+	}
+	key, err := manager.GetAccountPublicKey(chain, addressType)
+	if err != nil {
+		return err
+	}
+	recordAudit(manager, wallet.AuditEntry{
+		Event:  wallet.AuditKeyExport,
+		Chain:  key.Chain,
+		Result: wallet.AuditOK,
+		Detail: "account public key " + key.Path,
+	})
+
+	export := xpubExport{
+		Chain:       key.Chain,
+		Type:        key.Type,
+		Path:        key.Path,
+		Fingerprint: fmt.Sprintf("%08x", key.Fingerprint),
+		Key:         key.Key,
+	}
+	if chain == "bitcoin" {
+		if export.ReceiveDescriptor, err = key.Descriptor(false); err != nil {
+			return err
+		}
+		if export.ChangeDescriptor, err = key.Descriptor(true); err != nil {
+			return err
+		}
+	}
+
+	if output == "json" {
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal account public key: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if chain == "bitcoin" {
+		fmt.Printf("🟠 Bitcoin %s account (%s)\n", bitcoinAddressNames[addressType], key.Path)
+	} else {
+		fmt.Printf("🔷 Ethereum account (%s)\n", key.Path)
+	}
+	fmt.Println()
+	printResult(key.Key, "   %s\n", key.Key)
+	fmt.Println()
+	fmt.Printf("   Fingerprint: %s\n", export.Fingerprint)
+	if chain == "bitcoin" {
+		fmt.Printf("   Receive:     %s\n", export.ReceiveDescriptor)
+		fmt.Printf("   Change:      %s\n", export.ChangeDescriptor)
+		if addressType == config.BitcoinAddressP2WPKH {
+			fmt.Println("💡 odyssey derives native SegWit on m/44' instead of m/84', the zpub finds the same addresses either way")
+		}
+	} else {
+		fmt.Printf("   Addresses:   %s/0/*\n", key.Path)
+	}
+	fmt.Println()
+	fmt.Println("⚠️  Anyone with this key can see every address and transaction of the account")
+	return nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BlocklistEntry is a token or address on the blocklist
+type BlocklistEntry struct {
+	Chain   string `json:"chain"` // e.g. ethereum, polygon or solana, addresses of EVM chains use ethereum
+	Address string `json:"address"`
+	Reason  string `json:"reason,omitempty"` // e.g. fake USDC airdrop or OFAC SDN list
+}
+
+// Blocklist lists scam tokens, hidden from token listings, and addresses on
+// sanction or fraud lists, which 'odyssey pay' warns about. It is a JSON file
+// kept by hand or imported from a list someone maintains.
+type Blocklist struct {
+	Tokens    []BlocklistEntry `json:"tokens"`
+	Addresses []BlocklistEntry `json:"addresses"`
+}
+
+// LoadBlocklist reads the blocklist of the screening.blocklist_file setting,
+// empty if the file doesn't exist
+func LoadBlocklist() (*Blocklist, error) {
+	path, err := BlocklistPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Blocklist{}, nil
+		}
+		return nil, fmt.Errorf("failed to read blocklist: %w", err)
+	}
+
+	var blocklist Blocklist
+	if err := json.Unmarshal(data, &blocklist); err != nil {
+		return nil, fmt.Errorf("failed to parse blocklist %s: %w", path, err)
+	}
+	return &blocklist, nil
+}
+
+// BlocklistPath returns where the blocklist is read from
+func BlocklistPath() (string, error) {
+	cfg, err := Load()
+	if err != nil {
+		return "", err
+	}
+	if path := cfg.Get(KeyBlocklistFile); path != "" {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".odyssey", "blocklist.json"), nil
+}
+
+// Token returns the entry of a token contract or mint on chain, nil if it
+// isn't listed or there is no blocklist
+func (b *Blocklist) Token(chain, address string) *BlocklistEntry {
+	if b == nil {
+		return nil
+	}
+	return findBlocklisted(b.Tokens, chain, address)
+}
+
+// Address returns the entry of an address on chain, nil if it isn't listed
+// or there is no blocklist. EVM chains share the ethereum entries.
+func (b *Blocklist) Address(chain, address string) *BlocklistEntry {
+	if b == nil {
+		return nil
+	}
+	return findBlocklisted(b.Addresses, chain, address)
+}
+
+// findBlocklisted returns the entry of address on chain in list, or nil. EVM
+// addresses match in any case, Bitcoin and Solana ones are case sensitive.
+func findBlocklisted(list []BlocklistEntry, chain, address string) *BlocklistEntry {
+	for i, entry := range list {
+		if entry.Chain != chain {
+			continue
+		}
+		if entry.Address == address || (strings.HasPrefix(address, "0x") && strings.EqualFold(entry.Address, address)) {
+			return &list[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,677 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Configuration keys
+const (
+	KeySessionTimeout       = "session.timeout"
+	KeyBroadcastRetryPeriod = "broadcast.retry_period"
+	KeyTestnetPrices        = "display.testnet_prices"
+	KeyEtherscanAPIKey      = "etherscan.api_key"
+	KeyBitcoinAddressType   = "bitcoin.address_type"
+	KeyHints                = "display.hints"
+	KeyZeroExAPIKey         = "swap.0x_api_key"
+	KeyThornodeURL          = "swap.thornode_url"
+	KeyAlchemyAPIKey        = "nft.alchemy_api_key"
+	KeyTenderlyAccount      = "simulate.tenderly_account"
+	KeyTenderlyProject      = "simulate.tenderly_project"
+	KeyTenderlyAccessKey    = "simulate.tenderly_access_key"
+	KeyNotifications        = "display.notifications"
+	KeyRetryAttempts        = "network.retry_attempts"
+	KeyRetryBackoff         = "network.retry_backoff"
+	KeyBitcoinRPCURL        = "bitcoin.rpc_url"
+	KeyBitcoinRPCUser       = "bitcoin.rpc_user"
+	KeyBitcoinRPCPassword   = "bitcoin.rpc_password"
+	KeyBitcoinRPCCookie     = "bitcoin.rpc_cookie"
+	KeyCostBasisMethod      = "tax.cost_basis"
+	KeySessionStorage       = "session.storage"
+	KeyDefaultWallet        = "wallet.default"
+	KeyElectrumServer       = "bitcoin.electrum_server"
+	KeyHeliusAPIKey         = "solana.helius_api_key"
+	KeyCoinSelection        = "bitcoin.coin_selection"
+	KeyMaxFeePercent        = "safety.max_fee_percent"
+	KeyMaxFeeUSD            = "safety.max_fee_usd"
+	KeyDuplicateWindow      = "safety.duplicate_window"
+	KeyGasTier              = "gas.tier"
+	KeyBlocklistFile        = "screening.blocklist_file"
+	KeyChainalysisAPIKey    = "screening.chainalysis_api_key"
+)
+
+// Bitcoin address types
+const (
+	BitcoinAddressP2WPKH = "p2wpkh" // native SegWit, m/44'/0'/0'/0/0
+	BitcoinAddressP2TR   = "p2tr"   // Taproot key path, m/86'/0'/0'/0/0
+
+	// Only scanned and spent from, for funds received by older wallets
+	BitcoinAddressP2PKH      = "p2pkh"       // legacy, m/44'/0'/0'/0/0
+	BitcoinAddressP2SHP2WPKH = "p2sh-p2wpkh" // nested SegWit, m/49'/0'/0'/0/0
+)
+
+// Cost basis methods, which acquisitions a disposal is matched against
+const (
+	CostBasisFIFO = "fifo" // oldest first
+	CostBasisLIFO = "lifo" // newest first
+	CostBasisHIFO = "hifo" // highest cost first
+)
+
+// Coin selection strategies, which UTXOs pay for a Bitcoin transaction
+const (
+	CoinSelectionFee     = "fee"     // lowest fee and waste, ideally without change
+	CoinSelectionInputs  = "inputs"  // fewest inputs, largest UTXOs first
+	CoinSelectionPrivacy = "privacy" // all UTXOs of as few addresses as possible
+	CoinSelectionAll     = "all"     // every UTXO, consolidating the wallet
+)
+
+// Gas price tiers of EVM payments, how soon they should be included
+const (
+	GasTierSlow   = "slow"   // within a few blocks, the lowest price recent blocks took
+	GasTierNormal = "normal" // within a block or two
+	GasTierFast   = "fast"   // in the next block
+)
+
+// Session storage, where an unlocked wallet keeps its keys between commands
+const (
+	SessionStorageFile  = "file"  // encrypted session file, bound to this machine
+	SessionStorageAgent = "agent" // memory of a running 'odyssey agent'
+	SessionStorageNone  = "none"  // nothing is written, every command asks for the password
+)
+
+// Default values
+const (
+	DefaultSessionTimeout       = 30 * time.Minute
+	DefaultBroadcastRetryPeriod = 2 * time.Minute
+	DefaultRetryAttempts        = 3
+	DefaultRetryBackoff         = 500 * time.Millisecond
+	DefaultMaxFeePercent        = 25
+	DefaultMaxFeeUSD            = 100
+	DefaultDuplicateWindow      = 10 * time.Minute
+)
+
+// Key describes a supported configuration setting
+type Key struct {
+	Name        string
+	Description string
+	Default     string
+	Validate    func(value string) error
+}
+
+// keys lists every supported setting
+var keys = map[string]Key{
+	KeySessionTimeout: {
+		Name:        KeySessionTimeout,
+		Description: "Idle time after which an unlocked wallet locks itself (e.g. 10m, 1h)",
+		Default:     DefaultSessionTimeout.String(),
+		Validate:    validateSessionTimeout,
+	},
+	KeyBroadcastRetryPeriod: {
+		Name:        KeyBroadcastRetryPeriod,
+		Description: "How long a failed broadcast is retried before it is left in the queue (0 disables)",
+		Default:     "2m",
+		Validate:    validateBroadcastRetryPeriod,
+	},
+	KeyTestnetPrices: {
+		Name:        KeyTestnetPrices,
+		Description: "Show mainnet USD values on testnet, marked as reference only (true or false)",
+		Default:     "false",
+		Validate:    validateBool,
+	},
+	KeyEtherscanAPIKey: {
+		Name:        KeyEtherscanAPIKey,
+		Description: "Etherscan API key used to import verified contract ABIs, and by the etherscan provider",
+	},
+	KeyBitcoinAddressType: {
+		Name:        KeyBitcoinAddressType,
+		Description: "Bitcoin address used to receive and send (p2wpkh or p2tr)",
+		Default:     BitcoinAddressP2WPKH,
+		Validate:    validateBitcoinAddressType,
+	},
+	KeyHints: {
+		Name:        KeyHints,
+		Description: "Suggest next steps based on the wallet state (true or false)",
+		Default:     "true",
+		Validate:    validateBool,
+	},
+	KeyZeroExAPIKey: {
+		Name:        KeyZeroExAPIKey,
+		Description: "0x API key used to quote Ethereum token swaps",
+	},
+	KeyThornodeURL: {
+		Name:        KeyThornodeURL,
+		Description: "THORNode API used for cross-chain swaps, e.g. your own node (default: Nine Realms)",
+		Validate:    validateURL,
+	},
+	KeyAlchemyAPIKey: {
+		Name:        KeyAlchemyAPIKey,
+		Description: "Alchemy API key used to list NFTs",
+	},
+	KeyTenderlyAccount: {
+		Name:        KeyTenderlyAccount,
+		Description: "Tenderly account used to simulate EVM transactions instead of the node",
+	},
+	KeyTenderlyProject: {
+		Name:        KeyTenderlyProject,
+		Description: "Tenderly project used to simulate EVM transactions",
+	},
+	KeyTenderlyAccessKey: {
+		Name:        KeyTenderlyAccessKey,
+		Description: "Tenderly access key used to simulate EVM transactions",
+	},
+	KeyNotifications: {
+		Name:        KeyNotifications,
+		Description: "Show desktop notifications when watched transactions arrive or long operations finish (true or false)",
+		Default:     "true",
+		Validate:    validateBool,
+	},
+	KeyRetryAttempts: {
+		Name:        KeyRetryAttempts,
+		Description: "How often a request is tried when a server fails or the connection drops (1 disables retries)",
+		Default:     strconv.Itoa(DefaultRetryAttempts),
+		Validate:    validateRetryAttempts,
+	},
+	KeyRetryBackoff: {
+		Name:        KeyRetryBackoff,
+		Description: "Wait before the first retry of a failed request, doubled on every further retry (e.g. 500ms, 2s)",
+		Default:     DefaultRetryBackoff.String(),
+		Validate:    validateRetryBackoff,
+	},
+	KeyBitcoinRPCURL: {
+		Name:        KeyBitcoinRPCURL,
+		Description: "Your own Bitcoin Core node used for balances, UTXOs, fee estimates and broadcasting, e.g. http://127.0.0.1:8332",
+		Validate:    validateURL,
+	},
+	KeyBitcoinRPCUser: {
+		Name:        KeyBitcoinRPCUser,
+		Description: "RPC user of the Bitcoin Core node (rpcuser)",
+	},
+	KeyBitcoinRPCPassword: {
+		Name:        KeyBitcoinRPCPassword,
+		Description: "RPC password of the Bitcoin Core node (rpcpassword)",
+	},
+	KeyBitcoinRPCCookie: {
+		Name:        KeyBitcoinRPCCookie,
+		Description: "Cookie file of the Bitcoin Core node, used when no RPC user is set (e.g. ~/.bitcoin/.cookie)",
+	},
+	KeyCostBasisMethod: {
+		Name:        KeyCostBasisMethod,
+		Description: "Lots sold first when computing gains and tax reports (fifo, lifo or hifo)",
+		Default:     CostBasisFIFO,
+		Validate:    ValidateCostBasisMethod,
+	},
+	KeySessionStorage: {
+		Name:        KeySessionStorage,
+		Description: "Where unlocked keys are kept between commands (file, agent, or none to ask for the password every time)",
+		Default:     SessionStorageFile,
+		Validate:    validateSessionStorage,
+	},
+	KeyDefaultWallet: {
+		Name:        KeyDefaultWallet,
+		Description: "Wallet used when no --wallet is given, see 'odyssey wallet list'",
+		Default:     DefaultWallet,
+		Validate:    ValidateWalletName,
+	},
+	KeyElectrumServer: {
+		Name:        KeyElectrumServer,
+		Description: "Electrum server used by the electrum provider, e.g. ssl://electrum.example.com:50002",
+		Validate:    validateElectrumServer,
+	},
+	KeyHeliusAPIKey: {
+		Name:        KeyHeliusAPIKey,
+		Description: "Helius API key used by the helius provider",
+	},
+	KeyCoinSelection: {
+		Name:        KeyCoinSelection,
+		Description: "UTXOs spent by Bitcoin payments (fee, inputs, privacy or all)",
+		Default:     CoinSelectionFee,
+		Validate:    ValidateCoinSelection,
+	},
+	KeyMaxFeePercent: {
+		Name:        KeyMaxFeePercent,
+		Description: "Payments whose fee is more than this percentage of the amount need an extra confirmation (0 disables the check)",
+		Default:     strconv.Itoa(DefaultMaxFeePercent),
+		Validate:    validateMaxFeePercent,
+	},
+	KeyMaxFeeUSD: {
+		Name:        KeyMaxFeeUSD,
+		Description: "Mainnet payments whose fee is worth more than this many dollars need an extra confirmation (0 disables the check)",
+		Default:     strconv.Itoa(DefaultMaxFeeUSD),
+		Validate:    validateMaxFeeUSD,
+	},
+	KeyDuplicateWindow: {
+		Name:        KeyDuplicateWindow,
+		Description: "A payment of the same amount to the same address within this time is refused as a duplicate (0 disables the check)",
+		Default:     DefaultDuplicateWindow.String(),
+		Validate:    validateDuplicateWindow,
+	},
+	KeyGasTier: {
+		Name:        KeyGasTier,
+		Description: "Gas price paid on Ethereum and other EVM chains (slow, normal or fast)",
+		Default:     GasTierNormal,
+		Validate:    ValidateGasTier,
+	},
+	KeyBlocklistFile: {
+		Name:        KeyBlocklistFile,
+		Description: "Blocklist of scam tokens and flagged addresses (default: ~/.odyssey/blocklist.json)",
+		Validate:    validateBlocklistFile,
+	},
+	KeyChainalysisAPIKey: {
+		Name:        KeyChainalysisAPIKey,
+		Description: "Chainalysis sanctions API key used to screen payment recipients",
+	},
+}
+
+// Config holds user settings stored in ~/.odyssey/config.json
+type Config struct {
+	path   string
+	values map[string]string
+}
+
+// Load reads the configuration file, a missing file yields the defaults
+func Load() (*Config, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	cfg := &Config{
+		path:   filepath.Join(homeDir, ".odyssey", "config.json"),
+		values: make(map[string]string),
+	}
+
+	data, err := os.ReadFile(cfg.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cfg.values); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Keys returns all supported settings sorted by name
+func Keys() []Key {
+	list := make([]Key, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, key)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// LookupKey returns the description of a setting
+func LookupKey(name string) (Key, error) {
+	key, ok := keys[name]
+	if !ok {
+		return Key{}, fmt.Errorf("unknown config key: %s. Run 'odyssey config' to list the available keys", name)
+	}
+	return key, nil
+}
+
+// Get returns the value of a setting, or its default when unset
+func (c *Config) Get(name string) string {
+	if value, ok := c.values[name]; ok {
+		return value
+	}
+	return keys[name].Default
+}
+
+// IsSet returns true if a setting has been set explicitly
+func (c *Config) IsSet(name string) bool {
+	_, ok := c.values[name]
+	return ok
+}
+
+// Set validates and stores a setting and saves the configuration
+func (c *Config) Set(name, value string) error {
+	key, err := LookupKey(name)
+	if err != nil {
+		return err
+	}
+
+	if key.Validate != nil {
+		if err := key.Validate(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", name, err)
+		}
+	}
+
+	c.values[name] = value
+	return c.save()
+}
+
+// Unset restores the default of a setting and saves the configuration
+func (c *Config) Unset(name string) error {
+	if _, err := LookupKey(name); err != nil {
+		return err
+	}
+
+	delete(c.values, name)
+	return c.save()
+}
+
+// Problems returns the stored settings that are unknown or invalid, e.g.
+// after a manual edit of the file. Such values are ignored for the defaults.
+func (c *Config) Problems() []error {
+	names := make([]string, 0, len(c.values))
+	for name := range c.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []error
+	for _, name := range names {
+		key, err := LookupKey(name)
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		if key.Validate != nil {
+			if err := key.Validate(c.values[name]); err != nil {
+				problems = append(problems, fmt.Errorf("invalid value for %s: %w", name, err))
+			}
+		}
+	}
+	return problems
+}
+
+// Path returns the location of the configuration file
+func (c *Config) Path() string {
+	return c.path
+}
+
+// SessionTimeout returns the idle time after which the wallet locks itself
+func (c *Config) SessionTimeout() time.Duration {
+	timeout, err := time.ParseDuration(c.Get(KeySessionTimeout))
+	if err != nil || timeout <= 0 {
+		return DefaultSessionTimeout
+	}
+	return timeout
+}
+
+// BroadcastRetryPeriod returns how long transient broadcast failures are retried
+func (c *Config) BroadcastRetryPeriod() time.Duration {
+	period, err := time.ParseDuration(c.Get(KeyBroadcastRetryPeriod))
+	if err != nil || period < 0 {
+		return DefaultBroadcastRetryPeriod
+	}
+	return period
+}
+
+// RetryAttempts returns how often a failing request is tried
+func (c *Config) RetryAttempts() int {
+	attempts, err := strconv.Atoi(c.Get(KeyRetryAttempts))
+	if err != nil || attempts < 1 {
+		return DefaultRetryAttempts
+	}
+	return attempts
+}
+
+// RetryBackoff returns the wait before the first retry of a failed request
+func (c *Config) RetryBackoff() time.Duration {
+	backoff, err := time.ParseDuration(c.Get(KeyRetryBackoff))
+	if err != nil || backoff <= 0 {
+		return DefaultRetryBackoff
+	}
+	return backoff
+}
+
+// TestnetPrices returns true if USD values should be shown on testnet
+func (c *Config) TestnetPrices() bool {
+	enabled, err := strconv.ParseBool(c.Get(KeyTestnetPrices))
+	return err == nil && enabled
+}
+
+// Hints returns true if next step suggestions should be shown
+func (c *Config) Hints() bool {
+	enabled, err := strconv.ParseBool(c.Get(KeyHints))
+	return err != nil || enabled
+}
+
+// Notifications returns true if desktop notifications should be shown
+func (c *Config) Notifications() bool {
+	enabled, err := strconv.ParseBool(c.Get(KeyNotifications))
+	return err != nil || enabled
+}
+
+// BitcoinAddressType returns the Bitcoin address type used by the wallet
+func (c *Config) BitcoinAddressType() string {
+	if value := c.Get(KeyBitcoinAddressType); value == BitcoinAddressP2TR {
+		return value
+	}
+	return BitcoinAddressP2WPKH
+}
+
+// CostBasisMethod returns the cost basis method used for gains
+func (c *Config) CostBasisMethod() string {
+	if value := c.Get(KeyCostBasisMethod); ValidateCostBasisMethod(value) == nil {
+		return value
+	}
+	return CostBasisFIFO
+}
+
+// CoinSelection returns the coin selection strategy of Bitcoin payments
+func (c *Config) CoinSelection() string {
+	if value := c.Get(KeyCoinSelection); ValidateCoinSelection(value) == nil {
+		return value
+	}
+	return CoinSelectionFee
+}
+
+// MaxFeePercent returns the largest fee a payment may pay as a percentage of
+// its amount, 0 if fees aren't checked
+func (c *Config) MaxFeePercent() float64 {
+	percent, err := strconv.ParseFloat(c.Get(KeyMaxFeePercent), 64)
+	if err != nil || percent < 0 {
+		return DefaultMaxFeePercent
+	}
+	return percent
+}
+
+// MaxFeeUSD returns the largest fee in dollars a mainnet payment may pay, 0
+// if fees aren't checked
+func (c *Config) MaxFeeUSD() float64 {
+	usd, err := strconv.ParseFloat(c.Get(KeyMaxFeeUSD), 64)
+	if err != nil || usd < 0 {
+		return DefaultMaxFeeUSD
+	}
+	return usd
+}
+
+// DuplicateWindow returns how long a payment is remembered to refuse sending
+// it again, 0 if duplicates aren't checked
+func (c *Config) DuplicateWindow() time.Duration {
+	window, err := time.ParseDuration(c.Get(KeyDuplicateWindow))
+	if err != nil || window < 0 {
+		return DefaultDuplicateWindow
+	}
+	return window
+}
+
+// GasTier returns the gas price tier of EVM payments
+func (c *Config) GasTier() string {
+	if value := c.Get(KeyGasTier); ValidateGasTier(value) == nil {
+		return value
+	}
+	return GasTierNormal
+}
+
+// SessionStorage returns where unlocked keys are kept between commands
+func (c *Config) SessionStorage() string {
+	if value := c.Get(KeySessionStorage); value == SessionStorageAgent || value == SessionStorageNone {
+		return value
+	}
+	return SessionStorageFile
+}
+
+// save writes the configuration to disk
+func (c *Config) save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c.values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+func validateBool(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("expected true or false")
+	}
+	return nil
+}
+
+func validateURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("expected a URL like https://thornode.example.com")
+	}
+	return nil
+}
+
+func validateBlocklistFile(value string) error {
+	if !filepath.IsAbs(value) {
+		return fmt.Errorf("expected an absolute path like /home/me/blocklist.json")
+	}
+	return nil
+}
+
+func validateBroadcastRetryPeriod(value string) error {
+	period, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("expected a duration like 30s or 5m")
+	}
+	if period < 0 || period > time.Hour {
+		return fmt.Errorf("retry period must be between 0 and 1h")
+	}
+	return nil
+}
+
+func validateDuplicateWindow(value string) error {
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("expected a duration like 10m or 1h")
+	}
+	if window < 0 || window > 24*time.Hour {
+		return fmt.Errorf("window must be between 0 and 24h")
+	}
+	return nil
+}
+
+func validateRetryAttempts(value string) error {
+	attempts, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("expected a number like 3")
+	}
+	if attempts < 1 || attempts > 10 {
+		return fmt.Errorf("attempts must be between 1 and 10")
+	}
+	return nil
+}
+
+func validateMaxFeePercent(value string) error {
+	percent, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("expected a percentage like 25 or 2.5")
+	}
+	if percent < 0 {
+		return fmt.Errorf("percentage must not be negative")
+	}
+	return nil
+}
+
+func validateMaxFeeUSD(value string) error {
+	usd, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("expected an amount in dollars like 100 or 12.50")
+	}
+	if usd < 0 {
+		return fmt.Errorf("amount must not be negative")
+	}
+	return nil
+}
+
+func validateRetryBackoff(value string) error {
+	backoff, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("expected a duration like 500ms or 2s")
+	}
+	if backoff < 50*time.Millisecond || backoff > 10*time.Second {
+		return fmt.Errorf("backoff must be between 50ms and 10s")
+	}
+	return nil
+}
+
+func validateSessionTimeout(value string) error {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("expected a duration like 10m or 1h")
+	}
+	if timeout < time.Minute {
+		return fmt.Errorf("timeout must be at least 1m")
+	}
+	if timeout > 24*time.Hour {
+		return fmt.Errorf("timeout must be at most 24h")
+	}
+	return nil
+}
+
+func validateBitcoinAddressType(value string) error {
+	if value != BitcoinAddressP2WPKH && value != BitcoinAddressP2TR {
+		return fmt.Errorf("expected %s or %s", BitcoinAddressP2WPKH, BitcoinAddressP2TR)
+	}
+	return nil
+}
+
+// ValidateCostBasisMethod checks a cost basis method, also used for --method
+func ValidateCostBasisMethod(value string) error {
+	if value != CostBasisFIFO && value != CostBasisLIFO && value != CostBasisHIFO {
+		return fmt.Errorf("expected %s, %s or %s", CostBasisFIFO, CostBasisLIFO, CostBasisHIFO)
+	}
+	return nil
+}
+
+// ValidateCoinSelection checks a coin selection strategy, also used for
+// --coin-selection
+func ValidateCoinSelection(value string) error {
+	switch value {
+	case CoinSelectionFee, CoinSelectionInputs, CoinSelectionPrivacy, CoinSelectionAll:
+		return nil
+	}
+	return fmt.Errorf("expected %s, %s, %s or %s", CoinSelectionFee, CoinSelectionInputs, CoinSelectionPrivacy, CoinSelectionAll)
+}
+
+// ValidateGasTier checks a gas price tier, also used for --gas-tier
+func ValidateGasTier(value string) error {
+	switch value {
+	case GasTierSlow, GasTierNormal, GasTierFast:
+		return nil
+	}
+	return fmt.Errorf("expected %s, %s or %s", GasTierSlow, GasTierNormal, GasTierFast)
+}
+
+func validateSessionStorage(value string) error {
+	if value != SessionStorageFile && value != SessionStorageAgent && value != SessionStorageNone {
+		return fmt.Errorf("expected %s, %s or %s", SessionStorageFile, SessionStorageAgent, SessionStorageNone)
+	}
+	return nil
+}
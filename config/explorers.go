@@ -0,0 +1,175 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ExplorerChains are the chains whose block explorer can be configured
+var ExplorerChains = append([]string{"bitcoin"}, RPCChains...)
+
+// explorerProvider is a block explorer that can be chosen by name
+type explorerProvider struct {
+	chain       string
+	mainnet     string           // base URL on mainnet
+	testnets    map[int64]string // base URL by Ethereum testnet chain ID
+	accountPath string           // path of address pages, address unless set
+	cluster     bool             // Solana explorers take the cluster as a query parameter
+}
+
+// explorerProviders lists the explorers that explorer.<chain> accepts by name
+var explorerProviders = map[string]explorerProvider{
+	"mempool":     {chain: "bitcoin", mainnet: "https://mempool.space"},
+	"blockstream": {chain: "bitcoin", mainnet: "https://blockstream.info"},
+	"etherscan": {chain: "ethereum", mainnet: "https://etherscan.io", testnets: map[int64]string{
+		11155111: "https://sepolia.etherscan.io",
+		17000:    "https://holesky.etherscan.io",
+	}},
+	"blockscout": {chain: "ethereum", mainnet: "https://eth.blockscout.com", testnets: map[int64]string{
+		11155111: "https://eth-sepolia.blockscout.com",
+		17000:    "https://eth-holesky.blockscout.com",
+	}},
+	"solscan":         {chain: "solana", mainnet: "https://solscan.io", accountPath: "account", cluster: true},
+	"solana-explorer": {chain: "solana", mainnet: "https://explorer.solana.com", cluster: true},
+}
+
+// defaultBitcoinExplorer is used on Bitcoin mainnet unless explorer.bitcoin is set
+const defaultBitcoinExplorer = "mempool"
+
+func init() {
+	for _, chain := range ExplorerChains {
+		chain := chain
+		for _, network := range []string{NetworkMainnet, NetworkTestnet} {
+			name := ExplorerKey(chain, network)
+			keys[name] = Key{
+				Name:        name,
+				Description: explorerDescription(chain, network),
+				Validate:    func(value string) error { return validateExplorer(chain, value) },
+			}
+		}
+	}
+}
+
+// ExplorerKey returns the setting holding the block explorer of a chain on a
+// network, e.g. explorer.bitcoin or explorer.ethereum.testnet
+func ExplorerKey(chain, network string) string {
+	if network == NetworkTestnet {
+		return "explorer." + chain + ".testnet"
+	}
+	return "explorer." + chain
+}
+
+// ExplorerURL links a transaction (kind tx) or an address (kind address) on
+// the block explorer of a chain on the active network. The explorer.<chain>
+// setting wins over the explorer of the network, an empty string means there
+// is no explorer to link
+func ExplorerURL(chain, kind, id string) string {
+	cfg, err := Load()
+	if err != nil {
+		cfg = &Config{}
+	}
+	return cfg.ExplorerURL(ActiveNetwork(), chain, kind, id)
+}
+
+// ExplorerURL links a transaction or an address on the block explorer of a
+// chain on a network
+func (c *Config) ExplorerURL(network *Network, chain, kind, id string) string {
+	base, accountPath, cluster := c.explorer(network, chain)
+	if base == "" {
+		return ""
+	}
+	if kind == "address" && accountPath != "" {
+		kind = accountPath
+	}
+
+	link := fmt.Sprintf("%s/%s/%s", strings.TrimRight(base, "/"), kind, id)
+	if cluster {
+		switch network.SolanaCluster {
+		case "":
+		case "custom":
+			link += "?cluster=custom&customUrl=" + url.QueryEscape(network.SolanaRPC)
+		default:
+			link += "?cluster=" + network.SolanaCluster
+		}
+	}
+	return link
+}
+
+// explorer resolves the base URL of the explorer of a chain on a network
+func (c *Config) explorer(network *Network, chain string) (base, accountPath string, cluster bool) {
+	value := c.Get(ExplorerKey(chain, network.Keys))
+	if provider, ok := explorerProviders[value]; ok {
+		if base := provider.base(network); base != "" {
+			return base, provider.accountPath, provider.cluster
+		}
+		// The provider doesn't index this network, fall back to its own explorer
+		value = ""
+	}
+	if value != "" {
+		// A URL, e.g. a self-hosted Blockscout or mempool instance
+		if chain == "solana" {
+			return value, "account", true
+		}
+		return value, "", false
+	}
+
+	switch chain {
+	case "bitcoin":
+		if !network.IsTestnet() {
+			return explorerProviders[defaultBitcoinExplorer].mainnet, "", false
+		}
+	case "ethereum":
+		return network.EthereumExplorer, "", false
+	case "solana":
+		return network.SolanaExplorer, "account", true
+	}
+	return "", "", false
+}
+
+// base returns the base URL of a provider on a network, empty if it doesn't index it
+func (p explorerProvider) base(network *Network) string {
+	switch {
+	case !network.IsTestnet():
+		return p.mainnet
+	case p.testnets != nil:
+		return p.testnets[network.EthereumChainID]
+	case p.cluster && network.SolanaCluster != "":
+		return p.mainnet
+	}
+	return ""
+}
+
+// ExplorerProviders returns the explorers a chain accepts by name
+func ExplorerProviders(chain string) []string {
+	var names []string
+	for name, provider := range explorerProviders {
+		if provider.chain == chain {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func explorerDescription(chain, network string) string {
+	choices := "a URL"
+	if names := ExplorerProviders(chain); len(names) > 0 {
+		choices = strings.Join(names, ", ") + " or a URL"
+	}
+	return fmt.Sprintf("Block explorer linked for %s %s transactions and addresses (%s)", chain, network, choices)
+}
+
+func validateExplorer(chain, value string) error {
+	if provider, ok := explorerProviders[value]; ok && provider.chain == chain {
+		return nil
+	}
+	if err := validateURL(value); err != nil {
+		if names := ExplorerProviders(chain); len(names) > 0 {
+			return fmt.Errorf("expected %s or a URL like https://explorer.example.com", strings.Join(names, ", "))
+		}
+		return fmt.Errorf("expected a URL like https://explorer.example.com")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Network keys, the built-in networks of the same names use them
+const (
+	NetworkMainnet = "mainnet"
+	NetworkTestnet = "testnet"
+)
+
+// networkPath returns the file holding the network of a wallet
+func networkPath(name string) (string, error) {
+	dir, err := WalletDirOf(name)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "network.txt"), nil
+}
+
+// CurrentNetwork returns the keys of the active network, mainnet or testnet.
+// See ActiveNetwork for its nodes and explorers.
+func CurrentNetwork() string {
+	return ActiveNetwork().Keys
+}
+
+// WalletNetwork returns the name of the network selected for a wallet. It
+// only reads local files and defaults to mainnet when the file is missing or
+// names no known network.
+func WalletNetwork(name string) string {
+	path, err := networkPath(name)
+	if err != nil {
+		return NetworkMainnet
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NetworkMainnet
+	}
+
+	network := strings.TrimSpace(string(data))
+	if _, err := LookupNetwork(network); err != nil {
+		return NetworkMainnet
+	}
+
+	return network
+}
+
+// SetNetwork stores the selected network of the active wallet
+func SetNetwork(network string) error {
+	if _, err := LookupNetwork(network); err != nil {
+		return err
+	}
+
+	path, err := networkPath(ActiveWallet())
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(network), 0600); err != nil {
+		return fmt.Errorf("failed to write network file: %w", err)
+	}
+
+	return nil
+}
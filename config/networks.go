@@ -0,0 +1,403 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// Network is a set of nodes and explorers odyssey works with. Its Keys
+// decide which keys and features apply: testnet networks derive the testnet
+// addresses, have no Bitcoin and no fiat purchases.
+type Network struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Keys        string `json:"keys"` // mainnet or testnet
+
+	EthereumName     string `json:"ethereum_name,omitempty"` // e.g. Sepolia, shown next to Ethereum
+	EthereumRPC      string `json:"ethereum_rpc,omitempty"`
+	EthereumChainID  int64  `json:"ethereum_chain_id,omitempty"` // 0 asks the node, see ResolveEthereumChainID
+	EthereumExplorer string `json:"ethereum_explorer,omitempty"` // Etherscan compatible, /tx/ and /address/ are appended
+
+	SolanaName     string `json:"solana_name,omitempty"` // e.g. Devnet, shown next to Solana
+	SolanaRPC      string `json:"solana_rpc,omitempty"`
+	SolanaExplorer string `json:"solana_explorer,omitempty"` // Solscan compatible
+	SolanaCluster  string `json:"solana_cluster,omitempty"`  // cluster of the explorer links, custom for SolanaRPC
+
+	// BitcoinRPC is a Bitcoin Core node used instead of the explorers. On
+	// networks with the testnet keys it runs regtest, without it they have no
+	// Bitcoin.
+	BitcoinRPC string `json:"bitcoin_rpc,omitempty"`
+
+	// NoFiat disables price lookups, for chains whose coins have no price
+	NoFiat bool `json:"no_fiat,omitempty"`
+
+	Builtin bool `json:"-"`
+}
+
+// builtinNetworks are the networks odyssey ships with. testnet was the only
+// test network before the registry and stays the default one.
+var builtinNetworks = []Network{
+	{
+		Name:             NetworkMainnet,
+		Description:      "Ethereum, Bitcoin and Solana mainnet",
+		Keys:             NetworkMainnet,
+		EthereumName:     "Mainnet",
+		EthereumRPC:      "https://ethereum-rpc.publicnode.com",
+		EthereumChainID:  1,
+		EthereumExplorer: "https://etherscan.io",
+		SolanaName:       "Mainnet",
+		SolanaRPC:        "https://api.mainnet-beta.solana.com",
+		SolanaExplorer:   "https://solscan.io",
+	},
+	{
+		Name:             NetworkTestnet,
+		Description:      "Ethereum Sepolia and Solana devnet",
+		Keys:             NetworkTestnet,
+		EthereumName:     "Sepolia",
+		EthereumRPC:      "https://ethereum-sepolia.publicnode.com",
+		EthereumChainID:  11155111,
+		EthereumExplorer: "https://sepolia.etherscan.io",
+		SolanaName:       "Devnet",
+		SolanaRPC:        "https://api.devnet.solana.com",
+		SolanaExplorer:   "https://solscan.io",
+		SolanaCluster:    "devnet",
+	},
+	{
+		Name:             "sepolia",
+		Description:      "Ethereum Sepolia and Solana devnet, like testnet",
+		Keys:             NetworkTestnet,
+		EthereumName:     "Sepolia",
+		EthereumRPC:      "https://ethereum-sepolia.publicnode.com",
+		EthereumChainID:  11155111,
+		EthereumExplorer: "https://sepolia.etherscan.io",
+		SolanaName:       "Devnet",
+		SolanaRPC:        "https://api.devnet.solana.com",
+		SolanaExplorer:   "https://solscan.io",
+		SolanaCluster:    "devnet",
+	},
+	{
+		Name:             "holesky",
+		Description:      "Ethereum Holesky and Solana devnet",
+		Keys:             NetworkTestnet,
+		EthereumName:     "Holesky",
+		EthereumRPC:      "https://ethereum-holesky.publicnode.com",
+		EthereumChainID:  17000,
+		EthereumExplorer: "https://holesky.etherscan.io",
+		SolanaName:       "Devnet",
+		SolanaRPC:        "https://api.devnet.solana.com",
+		SolanaExplorer:   "https://solscan.io",
+		SolanaCluster:    "devnet",
+	},
+	{
+		Name:             "devnet",
+		Description:      "Solana devnet and Ethereum Sepolia",
+		Keys:             NetworkTestnet,
+		EthereumName:     "Sepolia",
+		EthereumRPC:      "https://ethereum-sepolia.publicnode.com",
+		EthereumChainID:  11155111,
+		EthereumExplorer: "https://sepolia.etherscan.io",
+		SolanaName:       "Devnet",
+		SolanaRPC:        "https://api.devnet.solana.com",
+		SolanaExplorer:   "https://solscan.io",
+		SolanaCluster:    "devnet",
+	},
+	{
+		Name:         "localnet",
+		Description:  "Local Anvil or Hardhat, solana-test-validator and bitcoind -regtest",
+		Keys:         NetworkTestnet,
+		EthereumName: "Localnet",
+		EthereumRPC:  "http://127.0.0.1:8545",
+		SolanaName:   "Localnet",
+		SolanaRPC:    "http://127.0.0.1:8899",
+		BitcoinRPC:   "http://127.0.0.1:18443",
+		NoFiat:       true,
+	},
+}
+
+// networkNamePattern are the names a custom network can have
+var networkNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// reservedNetworkNames are subcommands of 'odyssey network'
+var reservedNetworkNames = map[string]bool{"list": true, "add": true, "remove": true}
+
+// selectedNetwork is the network chosen with --network for this process
+var selectedNetwork string
+
+var (
+	// chainIDDetector asks an Ethereum node for its chain ID, see
+	// SetChainIDDetector
+	chainIDDetector func(rpc string) (int64, error)
+
+	// detectedChainIDs are the chain IDs nodes reported, keyed by node
+	detectedChainIDs   = make(map[string]int64)
+	detectedChainIDsMu sync.Mutex
+)
+
+// SetChainIDDetector sets how the chain ID of an Ethereum node is asked for
+// when a network leaves it out
+func SetChainIDDetector(detect func(rpc string) (int64, error)) {
+	chainIDDetector = detect
+}
+
+// SelectNetwork makes name the network of this process instead of the one
+// stored with 'odyssey network'. An empty name clears the selection.
+func SelectNetwork(name string) error {
+	if name != "" {
+		if _, err := LookupNetwork(name); err != nil {
+			return err
+		}
+	}
+	selectedNetwork = name
+	return nil
+}
+
+// ActiveNetwork returns the network of this process: the one chosen with
+// --network, else the one stored for the active wallet
+func ActiveNetwork() *Network {
+	name := selectedNetwork
+	if name == "" {
+		name = WalletNetwork(ActiveWallet())
+	}
+	if network, err := LookupNetwork(name); err == nil {
+		return network
+	}
+	network, _ := LookupNetwork(NetworkMainnet)
+	return network
+}
+
+// Networks returns the built-in networks followed by the custom ones
+func Networks() ([]Network, error) {
+	custom, err := loadCustomNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	networks := make([]Network, 0, len(builtinNetworks)+len(custom))
+	for _, network := range builtinNetworks {
+		network.Builtin = true
+		networks = append(networks, network)
+	}
+	for _, network := range custom {
+		networks = append(networks, network.withDefaults())
+	}
+	return networks, nil
+}
+
+// LookupNetwork returns the network called name
+func LookupNetwork(name string) (*Network, error) {
+	networks, err := Networks()
+	if err != nil {
+		return nil, err
+	}
+	for i := range networks {
+		if networks[i].Name == name {
+			return &networks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown network: %s. Run 'odyssey network list' to see the available networks", name)
+}
+
+// AddNetwork stores a custom network. Nodes and explorers left empty are
+// those of the built-in network with the same keys.
+func AddNetwork(network Network) error {
+	if err := network.validate(); err != nil {
+		return err
+	}
+	for _, builtin := range builtinNetworks {
+		if builtin.Name == network.Name {
+			return fmt.Errorf("%s is a built-in network", network.Name)
+		}
+	}
+
+	custom, err := loadCustomNetworks()
+	if err != nil {
+		return err
+	}
+	for _, existing := range custom {
+		if existing.Name == network.Name {
+			return fmt.Errorf("network %s already exists, remove it first to change it", network.Name)
+		}
+	}
+	return saveCustomNetworks(append(custom, network))
+}
+
+// RemoveNetwork deletes a custom network
+func RemoveNetwork(name string) error {
+	custom, err := loadCustomNetworks()
+	if err != nil {
+		return err
+	}
+	for i, existing := range custom {
+		if existing.Name == name {
+			return saveCustomNetworks(append(custom[:i], custom[i+1:]...))
+		}
+	}
+	for _, builtin := range builtinNetworks {
+		if builtin.Name == name {
+			return fmt.Errorf("%s is a built-in network and can't be removed", name)
+		}
+	}
+	return fmt.Errorf("unknown network: %s", name)
+}
+
+// IsTestnet returns true if the network uses the testnet keys
+func (n *Network) IsTestnet() bool {
+	return n.Keys == NetworkTestnet
+}
+
+// HasBitcoin returns true if Bitcoin is available: always on mainnet, on
+// test networks only with a regtest node
+func (n *Network) HasBitcoin() bool {
+	return !n.IsTestnet() || n.BitcoinRPC != ""
+}
+
+// IsRegtest returns true if the network's Bitcoin is a regtest node
+func (n *Network) IsRegtest() bool {
+	return n.IsTestnet() && n.BitcoinRPC != ""
+}
+
+// BitcoinParams returns the Bitcoin chain parameters of the network:
+// mainnet, or regtest on test networks with a regtest node
+func (n *Network) BitcoinParams() *chaincfg.Params {
+	if n.IsRegtest() {
+		return &chaincfg.RegressionNetParams
+	}
+	return &chaincfg.MainNetParams
+}
+
+// ResolveEthereumChainID returns the chain ID of the network's Ethereum node.
+// Networks that leave it out, like localnet where Anvil and Hardhat pick
+// their own, ask the node once per process.
+func (n *Network) ResolveEthereumChainID() (int64, error) {
+	if n.EthereumChainID != 0 {
+		return n.EthereumChainID, nil
+	}
+
+	detectedChainIDsMu.Lock()
+	defer detectedChainIDsMu.Unlock()
+	if id, ok := detectedChainIDs[n.EthereumRPC]; ok {
+		return id, nil
+	}
+	if chainIDDetector == nil {
+		return 0, fmt.Errorf("the chain ID of %s is unknown", n.EthereumRPC)
+	}
+	id, err := chainIDDetector(n.EthereumRPC)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the chain ID of %s: %w", n.EthereumRPC, err)
+	}
+	detectedChainIDs[n.EthereumRPC] = id
+	return id, nil
+}
+
+// withDefaults fills the empty fields of a custom network from the built-in
+// network with the same keys
+func (n Network) withDefaults() Network {
+	base := builtinNetworks[0]
+	if n.Keys == NetworkTestnet {
+		base = builtinNetworks[1]
+	}
+	if n.EthereumRPC == "" {
+		n.EthereumRPC, n.EthereumChainID = base.EthereumRPC, base.EthereumChainID
+		if n.EthereumName == "" {
+			n.EthereumName = base.EthereumName
+		}
+	}
+	if n.EthereumName == "" {
+		n.EthereumName = n.Name
+	}
+	if n.EthereumExplorer == "" && n.EthereumChainID == base.EthereumChainID {
+		n.EthereumExplorer = base.EthereumExplorer
+	}
+	if n.SolanaRPC == "" {
+		n.SolanaRPC, n.SolanaCluster = base.SolanaRPC, base.SolanaCluster
+		if n.SolanaName == "" {
+			n.SolanaName = base.SolanaName
+		}
+		if n.SolanaExplorer == "" {
+			n.SolanaExplorer = base.SolanaExplorer
+		}
+	}
+	if n.SolanaName == "" {
+		n.SolanaName = n.Name
+	}
+	return n
+}
+
+// validate checks a custom network before it is stored
+func (n *Network) validate() error {
+	if !networkNamePattern.MatchString(n.Name) || reservedNetworkNames[n.Name] {
+		return fmt.Errorf("invalid network name: %q. Use up to 32 lowercase letters, digits, - and _", n.Name)
+	}
+	if n.Keys != NetworkMainnet && n.Keys != NetworkTestnet {
+		return fmt.Errorf("invalid keys: %q. Use %s or %s", n.Keys, NetworkMainnet, NetworkTestnet)
+	}
+	for _, value := range []string{n.EthereumRPC, n.EthereumExplorer, n.SolanaRPC, n.SolanaExplorer, n.BitcoinRPC} {
+		if value == "" {
+			continue
+		}
+		if err := validateURL(value); err != nil {
+			return fmt.Errorf("invalid URL: %s", value)
+		}
+	}
+	if n.EthereumChainID < 0 {
+		return fmt.Errorf("invalid chain ID: %d", n.EthereumChainID)
+	}
+	return nil
+}
+
+// customNetworksPath returns the file holding the custom networks, shared by
+// every wallet like the settings
+func customNetworksPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".odyssey", "networks.json"), nil
+}
+
+// loadCustomNetworks reads the custom networks, a missing file has none
+func loadCustomNetworks() ([]Network, error) {
+	path, err := customNetworksPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read networks: %w", err)
+	}
+
+	var networks []Network
+	if err := json.Unmarshal(data, &networks); err != nil {
+		return nil, fmt.Errorf("failed to parse networks: %w", err)
+	}
+	return networks, nil
+}
+
+// saveCustomNetworks writes the custom networks
+func saveCustomNetworks(networks []Network) error {
+	path, err := customNetworksPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(networks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal networks: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write networks: %w", err)
+	}
+	return nil
+}
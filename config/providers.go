@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Kinds of data a backend provider serves
+const (
+	ProviderBalance   = "balance"
+	ProviderHistory   = "history"
+	ProviderBroadcast = "broadcast"
+)
+
+// ProviderKinds are the kinds of data whose provider can be configured per chain
+var ProviderKinds = []string{ProviderBalance, ProviderHistory, ProviderBroadcast}
+
+// backendProviders lists the providers provider.<chain>.<kind> accepts by
+// name, the first is used unless another is set
+var backendProviders = map[string]map[string][]string{
+	"ethereum": {
+		ProviderBalance:   {"rpc", "etherscan"},
+		ProviderHistory:   {"rpc", "etherscan"},
+		ProviderBroadcast: {"rpc", "etherscan"},
+	},
+	"bitcoin": {
+		ProviderBalance:   {"blockchain.info", "blockstream", "mempool", "electrum"},
+		ProviderHistory:   {"blockchain.info", "blockstream", "mempool"},
+		ProviderBroadcast: {"mempool", "blockstream", "electrum"},
+	},
+	"solana": {
+		ProviderBalance:   {"rpc", "helius"},
+		ProviderHistory:   {"rpc", "helius"},
+		ProviderBroadcast: {"rpc", "helius"},
+	},
+}
+
+// ProviderChains are the chains whose backend providers can be configured
+var ProviderChains = []string{"ethereum", "bitcoin", "solana"}
+
+func init() {
+	for _, chain := range ProviderChains {
+		for _, kind := range ProviderKinds {
+			name := ProviderKey(chain, kind)
+			names := backendProviders[chain][kind]
+			keys[name] = Key{
+				Name:        name,
+				Description: fmt.Sprintf("Backend that serves %s %s (%s)", chain, providerData(kind), strings.Join(names, ", ")),
+				Default:     names[0],
+				Validate: func(value string) error {
+					for _, provider := range names {
+						if value == provider {
+							return nil
+						}
+					}
+					return fmt.Errorf("expected one of %s", strings.Join(names, ", "))
+				},
+			}
+		}
+	}
+}
+
+// ProviderKey returns the setting holding the provider of a kind of data for
+// a chain, e.g. provider.bitcoin.history
+func ProviderKey(chain, kind string) string {
+	return "provider." + chain + "." + kind
+}
+
+// Provider returns the name of the provider of a kind of data for a chain
+func (c *Config) Provider(chain, kind string) string {
+	return c.Get(ProviderKey(chain, kind))
+}
+
+// Providers returns the providers a chain accepts for a kind of data
+func Providers(chain, kind string) []string {
+	return backendProviders[chain][kind]
+}
+
+func providerData(kind string) string {
+	switch kind {
+	case ProviderBalance:
+		return "balances"
+	case ProviderHistory:
+		return "transaction history"
+	default:
+		return "broadcasts"
+	}
+}
+
+func validateElectrumServer(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "tcp" && parsed.Scheme != "ssl") || parsed.Host == "" {
+		return fmt.Errorf("expected a server like ssl://electrum.example.com:50002 or tcp://127.0.0.1:50001")
+	}
+	if _, port, err := net.SplitHostPort(parsed.Host); err != nil || port == "" {
+		return fmt.Errorf("the server needs a port, e.g. ssl://electrum.example.com:50002")
+	}
+	return nil
+}
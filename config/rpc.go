@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RPCChains are the chains whose JSON-RPC nodes can be configured
+var RPCChains = []string{"ethereum", "solana", "polygon", "arbitrum", "optimism", "base", "bsc"}
+
+func init() {
+	for _, chain := range RPCChains {
+		for _, network := range []string{NetworkMainnet, NetworkTestnet} {
+			name := RPCKey(chain, network)
+			keys[name] = Key{
+				Name:        name,
+				Description: fmt.Sprintf("Comma separated %s %s nodes, tried in order before the public node", chain, network),
+				Validate:    validateURLList,
+			}
+		}
+	}
+}
+
+// RPCKey returns the setting holding the nodes of a chain on a network,
+// e.g. rpc.ethereum or rpc.solana.testnet
+func RPCKey(chain, network string) string {
+	if network == NetworkTestnet {
+		return "rpc." + chain + ".testnet"
+	}
+	return "rpc." + chain
+}
+
+// RPCEndpoints returns the nodes configured for a chain on a network
+func (c *Config) RPCEndpoints(chain, network string) []string {
+	var endpoints []string
+	for _, endpoint := range strings.Split(c.Get(RPCKey(chain, network)), ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+func validateURLList(value string) error {
+	for _, endpoint := range strings.Split(value, ",") {
+		if err := validateURL(strings.TrimSpace(endpoint)); err != nil {
+			return fmt.Errorf("expected comma separated URLs like https://node1.example.com,https://node2.example.com")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Request timeouts. network.timeout applies to every request, chains and
+// operations with a slower or faster typical response have their own.
+const (
+	KeyNetworkTimeout = "network.timeout"
+
+	DefaultNetworkTimeout = 30 * time.Second
+	DefaultPriceTimeout   = 10 * time.Second
+	DefaultHistoryTimeout = 60 * time.Second
+)
+
+// Operations whose requests have their own timeout
+const (
+	OperationPrice   = "price"
+	OperationHistory = "history"
+)
+
+// TimeoutChains are the chains whose requests can have their own timeout
+var TimeoutChains = append([]string{"bitcoin"}, RPCChains...)
+
+func init() {
+	keys[KeyNetworkTimeout] = Key{
+		Name:        KeyNetworkTimeout,
+		Description: "Timeout of network requests without a more specific timeout (e.g. 30s, 1m)",
+		Default:     DefaultNetworkTimeout.String(),
+		Validate:    validateRequestTimeout,
+	}
+	for _, chain := range TimeoutChains {
+		name := TimeoutKey(chain)
+		keys[name] = Key{
+			Name:        name,
+			Description: fmt.Sprintf("Timeout of requests to %s nodes and APIs", chain),
+			Validate:    validateRequestTimeout,
+		}
+	}
+	keys[TimeoutKey(OperationPrice)] = Key{
+		Name:        TimeoutKey(OperationPrice),
+		Description: "Timeout of price lookups",
+		Default:     DefaultPriceTimeout.String(),
+		Validate:    validateRequestTimeout,
+	}
+	keys[TimeoutKey(OperationHistory)] = Key{
+		Name:        TimeoutKey(OperationHistory),
+		Description: "Timeout of transaction history requests",
+		Default:     DefaultHistoryTimeout.String(),
+		Validate:    validateRequestTimeout,
+	}
+}
+
+// TimeoutKey returns the setting holding the timeout of a chain or an
+// operation, e.g. network.timeout.solana or network.timeout.history
+func TimeoutKey(name string) string {
+	return KeyNetworkTimeout + "." + name
+}
+
+// RequestTimeout returns the timeout of a request to chain for operation,
+// either may be empty. The longest timeout that applies wins, so a slow chain
+// keeps its time for history requests and vice versa.
+func (c *Config) RequestTimeout(chain, operation string) time.Duration {
+	var timeout time.Duration
+	for _, name := range []string{chain, operation} {
+		if name == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(c.Get(TimeoutKey(name))); err == nil && d > timeout {
+			timeout = d
+		}
+	}
+	if timeout > 0 {
+		return timeout
+	}
+
+	timeout, err := time.ParseDuration(c.Get(KeyNetworkTimeout))
+	if err != nil || timeout <= 0 {
+		return DefaultNetworkTimeout
+	}
+	return timeout
+}
+
+func validateRequestTimeout(value string) error {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("expected a duration like 30s or 2m")
+	}
+	if timeout < time.Second || timeout > 10*time.Minute {
+		return fmt.Errorf("timeout must be between 1s and 10m")
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxTokenDecimals is the most decimals a custom token can have, tokens of
+// note use at most 18 and more is almost certainly a typo
+const MaxTokenDecimals = 36
+
+// tokenSymbolPattern are the symbols a custom token can have
+var tokenSymbolPattern = regexp.MustCompile(`^[A-Za-z0-9$._-]{1,16}$`)
+
+// CustomToken is a token added with 'odyssey token add', tracked next to the
+// built-in tokens of its chain on one network
+type CustomToken struct {
+	Chain       string `json:"chain"` // ethereum or solana
+	Network     string `json:"network"`
+	Address     string `json:"address"` // ERC-20 contract address or SPL mint
+	Symbol      string `json:"symbol"`
+	Name        string `json:"name,omitempty"`
+	Decimals    int32  `json:"decimals"`
+	CoingeckoID string `json:"coingecko_id,omitempty"` // empty if it has no price
+}
+
+// CustomTokens returns the custom tokens sorted by chain, network and symbol
+func CustomTokens() ([]CustomToken, error) {
+	path, err := customTokensPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read tokens: %w", err)
+	}
+
+	var tokens []CustomToken
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse tokens: %w", err)
+	}
+	sort.SliceStable(tokens, func(i, j int) bool {
+		if tokens[i].Chain != tokens[j].Chain {
+			return tokens[i].Chain < tokens[j].Chain
+		}
+		if tokens[i].Network != tokens[j].Network {
+			return tokens[i].Network < tokens[j].Network
+		}
+		return strings.ToLower(tokens[i].Symbol) < strings.ToLower(tokens[j].Symbol)
+	})
+	return tokens, nil
+}
+
+// CustomTokensOf returns the custom tokens of a chain on a network
+func CustomTokensOf(chain, network string) []CustomToken {
+	tokens, err := CustomTokens()
+	if err != nil {
+		return nil
+	}
+	var matching []CustomToken
+	for _, token := range tokens {
+		if token.Chain == chain && token.Network == network {
+			matching = append(matching, token)
+		}
+	}
+	return matching
+}
+
+// AddToken stores a custom token, replacing the one with the same address on
+// its chain and network
+func AddToken(token CustomToken) error {
+	if !tokenSymbolPattern.MatchString(token.Symbol) {
+		return fmt.Errorf("invalid symbol %q: use up to 16 letters, digits or $._-", token.Symbol)
+	}
+	if token.Decimals < 0 || token.Decimals > MaxTokenDecimals {
+		return fmt.Errorf("invalid decimals %d: expected 0 to %d", token.Decimals, MaxTokenDecimals)
+	}
+
+	tokens, err := CustomTokens()
+	if err != nil {
+		return err
+	}
+	for i, existing := range tokens {
+		if existing.Chain == token.Chain && existing.Network == token.Network && strings.EqualFold(existing.Address, token.Address) {
+			tokens[i] = token
+			return saveCustomTokens(tokens)
+		}
+	}
+	return saveCustomTokens(append(tokens, token))
+}
+
+// RemoveToken deletes the custom token of a chain and network with a symbol
+// or address, it returns the token removed
+func RemoveToken(chain, network, symbolOrAddress string) (*CustomToken, error) {
+	tokens, err := CustomTokens()
+	if err != nil {
+		return nil, err
+	}
+	for i, existing := range tokens {
+		if existing.Chain != chain || existing.Network != network {
+			continue
+		}
+		if strings.EqualFold(existing.Symbol, symbolOrAddress) || strings.EqualFold(existing.Address, symbolOrAddress) {
+			if err := saveCustomTokens(append(tokens[:i], tokens[i+1:]...)); err != nil {
+				return nil, err
+			}
+			return &existing, nil
+		}
+	}
+	return nil, fmt.Errorf("no custom %s token %s on %s. Run 'odyssey token' to list them", chain, symbolOrAddress, network)
+}
+
+// customTokensPath returns where custom tokens are stored, shared by all
+// wallets like the networks they belong to
+func customTokensPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".odyssey", "tokens.json"), nil
+}
+
+// saveCustomTokens writes the custom tokens
+func saveCustomTokens(tokens []CustomToken) error {
+	path, err := customTokensPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tokens: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write tokens: %w", err)
+	}
+	return nil
+}
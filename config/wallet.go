@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// DefaultWallet is the wallet kept directly in ~/.odyssey, the only one
+// before named wallets existed
+const DefaultWallet = "default"
+
+// WalletEnv selects a named wallet like --wallet
+const WalletEnv = "ODYSSEY_WALLET"
+
+// walletNamePattern are the names a wallet can have, they name a directory
+var walletNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// selectedWallet is the wallet chosen with --wallet for this process
+var selectedWallet string
+
+// SelectWallet makes name the wallet of this process, ahead of ODYSSEY_WALLET
+// and wallet.default. An empty name clears the selection.
+func SelectWallet(name string) error {
+	if name != "" {
+		if err := ValidateWalletName(name); err != nil {
+			return err
+		}
+	}
+	selectedWallet = name
+	return nil
+}
+
+// ActiveWallet returns the wallet commands work on: the one chosen with
+// --wallet, ODYSSEY_WALLET or wallet.default, in that order
+func ActiveWallet() string {
+	if selectedWallet != "" {
+		return selectedWallet
+	}
+	if name := os.Getenv(WalletEnv); name != "" && ValidateWalletName(name) == nil {
+		return name
+	}
+	if cfg, err := Load(); err == nil {
+		if name := cfg.Get(KeyDefaultWallet); ValidateWalletName(name) == nil {
+			return name
+		}
+	}
+	return DefaultWallet
+}
+
+// WalletDir returns the directory of the active wallet: ~/.odyssey for the
+// default wallet and ~/.odyssey/wallets/<name> for named ones
+func WalletDir() (string, error) {
+	return WalletDirOf(ActiveWallet())
+}
+
+// WalletDirOf returns the directory of the wallet name
+func WalletDirOf(name string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	if name == DefaultWallet {
+		return filepath.Join(homeDir, ".odyssey"), nil
+	}
+	return filepath.Join(homeDir, ".odyssey", "wallets", name), nil
+}
+
+// WalletNames returns the default wallet and every named wallet that has a
+// directory, sorted by name
+func WalletNames() ([]string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(homeDir, ".odyssey", "wallets"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read wallets: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateWalletName(entry.Name()) == nil && entry.Name() != DefaultWallet {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultWallet}, names...), nil
+}
+
+// ValidateWalletName checks the name of a wallet, also used for --wallet
+func ValidateWalletName(name string) error {
+	if !walletNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid wallet name: %q. Use up to 32 lowercase letters, digits, - and _", name)
+	}
+	return nil
+}
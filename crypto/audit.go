@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// auditKeyInfo separates the key sealing the audit key from the other keys
+// derived from the mnemonic
+const auditKeyInfo = "odyssey audit key"
+
+// auditKeySize is the size of the key authenticating the audit log
+const auditKeySize = 32
+
+// AuditHead is the last entry of the audit log, kept in the vault so entries
+// removed from the end of the log are noticed. MAC authenticates it with the
+// audit key.
+type AuditHead struct {
+	Seq  int    `json:"seq"`
+	Hash string `json:"hash"`
+	MAC  []byte `json:"mac"`
+}
+
+// NewAuditHead returns the head of a log ending with the entry seq of hash
+func NewAuditHead(auditKey []byte, seq int, hash string) *AuditHead {
+	return &AuditHead{Seq: seq, Hash: hash, MAC: auditHeadMAC(auditKey, seq, hash)}
+}
+
+// Verify returns true if the head was written with auditKey
+func (h *AuditHead) Verify(auditKey []byte) bool {
+	return hmac.Equal(h.MAC, auditHeadMAC(auditKey, h.Seq, h.Hash))
+}
+
+func auditHeadMAC(auditKey []byte, seq int, hash string) []byte {
+	mac := hmac.New(sha256.New, auditKey)
+	mac.Write([]byte(strconv.Itoa(seq) + ":" + hash))
+	return mac.Sum(nil)
+}
+
+// auditSealingKey derives the key sealing the audit key from the mnemonic
+func auditSealingKey(mnemonic *SecureBuffer) []byte {
+	mac := hmac.New(sha256.New, mnemonic.Bytes())
+	mac.Write([]byte(auditKeyInfo))
+	return mac.Sum(nil)
+}
+
+// EnsureAuditKey creates the audit key of a vault that has none, sealed with
+// the main wallet's mnemonic
+func (v *Vault) EnsureAuditKey(mnemonic *SecureBuffer) error {
+	if v.Audit != nil {
+		return nil
+	}
+	auditKey := make([]byte, auditKeySize)
+	if _, err := io.ReadFull(rand.Reader, auditKey); err != nil {
+		return fmt.Errorf("failed to generate audit key: %w", err)
+	}
+	defer clearBytes(auditKey)
+
+	sealed, err := sealAuditKey(mnemonic, auditKey)
+	if err != nil {
+		return err
+	}
+	v.Audit = sealed
+	return nil
+}
+
+func sealAuditKey(mnemonic *SecureBuffer, auditKey []byte) (*SealedSecret, error) {
+	key := auditSealingKey(mnemonic)
+	defer clearBytes(key)
+
+	nonce, data, err := Seal(key, auditKey, []byte(auditKeyInfo))
+	if err != nil {
+		return nil, err
+	}
+	return &SealedSecret{Nonce: nonce, Data: data}, nil
+}
+
+// OpenAuditKey decrypts the key authenticating the audit log with the
+// mnemonic of the main or the decoy wallet. Both share the log, so both hold
+// the same key.
+func (v *Vault) OpenAuditKey(mnemonic *SecureBuffer) ([]byte, error) {
+	key := auditSealingKey(mnemonic)
+	defer clearBytes(key)
+
+	if v.Audit != nil {
+		if auditKey, err := Open(key, v.Audit.Nonce, v.Audit.Data, []byte(auditKeyInfo)); err == nil {
+			return auditKey, nil
+		}
+	}
+	if v.Slot2 != nil && v.Slot2.Audit != nil {
+		if auditKey, err := Open(key, v.Slot2.Audit.Nonce, v.Slot2.Audit.Data, []byte(auditKeyInfo)); err == nil {
+			return auditKey, nil
+		}
+	}
+	return nil, fmt.Errorf("the vault has no audit key for this wallet")
+}
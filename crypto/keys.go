@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+)
+
+// importedKeyInfo separates the key sealing imported private keys from other
+// keys derived from the mnemonic
+const importedKeyInfo = "odyssey imported key"
+
+// ImportedKey is a private key kept in the vault besides the keys derived
+// from the mnemonic, e.g. of a vanity address
+type ImportedKey struct {
+	Chain   string `json:"chain"` // ethereum or solana
+	Label   string `json:"label"`
+	Address string `json:"address"`
+	Key     []byte `json:"key"`
+}
+
+// importedKeyKey derives the key sealing imported private keys from the
+// mnemonic, so an unlocked session can use them without the password
+func importedKeyKey(mnemonic *SecureBuffer) []byte {
+	mac := hmac.New(sha256.New, mnemonic.Bytes())
+	mac.Write([]byte(importedKeyInfo))
+	return mac.Sum(nil)
+}
+
+// SealImportedKey adds a private key to the vault, encrypted with a key
+// derived from the mnemonic. The address is sealed with it, so a decoy
+// wallet can't list the keys of the main one.
+func (v *Vault) SealImportedKey(mnemonic *SecureBuffer, imported *ImportedKey) error {
+	data, err := json.Marshal(imported)
+	if err != nil {
+		return fmt.Errorf("failed to serialize key: %w", err)
+	}
+	defer clearBytes(data)
+
+	key := importedKeyKey(mnemonic)
+	defer clearBytes(key)
+
+	nonce, sealed, err := Seal(key, data, []byte(importedKeyInfo))
+	if err != nil {
+		return err
+	}
+	v.Keys = append(v.Keys, &SealedSecret{Nonce: nonce, Data: sealed})
+	return nil
+}
+
+// OpenImportedKeys decrypts the imported private keys of the mnemonic, keys
+// sealed with another mnemonic are skipped
+func (v *Vault) OpenImportedKeys(mnemonic *SecureBuffer) ([]ImportedKey, error) {
+	key := importedKeyKey(mnemonic)
+	defer clearBytes(key)
+
+	var keys []ImportedKey
+	for _, sealed := range v.Keys {
+		data, err := Open(key, sealed.Nonce, sealed.Data, []byte(importedKeyInfo))
+		if err != nil {
+			continue
+		}
+		var imported ImportedKey
+		err = json.Unmarshal(data, &imported)
+		clearBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse imported key: %w", err)
+		}
+		keys = append(keys, imported)
+	}
+	return keys, nil
+}
@@ -0,0 +1,57 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// PasswordSealed is data encrypted with a key derived from a password,
+// for files that leave the machine such as migration bundles
+type PasswordSealed struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// SealWithPassword encrypts data with AES-256-GCM under a scrypt key. The
+// purpose is bound to the ciphertext so it can't be opened as something else.
+func SealWithPassword(password string, data []byte, purpose string) (*PasswordSealed, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := deriveKey(password, salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	defer clearBytes(key)
+
+	nonce, ciphertext, err := Seal(key, data, []byte(purpose))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordSealed{Version: 1, Salt: salt, Nonce: nonce, Data: ciphertext}, nil
+}
+
+// Open decrypts data sealed with SealWithPassword
+func (s *PasswordSealed) Open(password string, purpose string) ([]byte, error) {
+	if s.Version != 1 {
+		return nil, fmt.Errorf("unsupported version: %d", s.Version)
+	}
+
+	key, err := deriveKey(password, s.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	defer clearBytes(key)
+
+	data, err := Open(key, s.Nonce, s.Data, []byte(purpose))
+	if err != nil {
+		return nil, fmt.Errorf("wrong password or corrupted file")
+	}
+	return data, nil
+}
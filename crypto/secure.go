@@ -0,0 +1,77 @@
+package crypto
+
+import (
+	"crypto/sha512"
+	"runtime"
+	"sync"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// SecureBuffer holds a secret outside of memory managed by the garbage
+// collector, which copies and leaves behind whatever it moves. Its pages are
+// locked so they aren't swapped to disk and left out of core dumps where the
+// platform allows. Destroy overwrites and frees it.
+type SecureBuffer struct {
+	mu     sync.Mutex
+	memory []byte // whole pages
+	data   []byte // the secret, at the start of memory
+	secure bool   // memory came from allocSecure, false if that failed
+}
+
+// NewSecureBuffer copies data into a secure buffer. The caller should clear
+// data afterwards.
+func NewSecureBuffer(data []byte) *SecureBuffer {
+	memory := allocSecure(len(data))
+	secure := memory != nil
+	if !secure {
+		memory = make([]byte, len(data))
+	}
+	b := &SecureBuffer{memory: memory, data: memory[:len(data)], secure: secure}
+	copy(b.data, data)
+	runtime.SetFinalizer(b, (*SecureBuffer).Destroy)
+	return b
+}
+
+// NewSecureString copies a string into a secure buffer. The string itself
+// can't be cleared, so secrets should be kept in strings as briefly as
+// possible.
+func NewSecureString(s string) *SecureBuffer {
+	return NewSecureBuffer([]byte(s))
+}
+
+// Bytes returns the secret, valid until Destroy. It must not be kept.
+func (b *SecureBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.data
+}
+
+// Clone copies the secret into a buffer of its own
+func (b *SecureBuffer) Clone() *SecureBuffer {
+	return NewSecureBuffer(b.Bytes())
+}
+
+// Destroy overwrites the secret and frees its memory. Destroying a buffer
+// twice is harmless.
+func (b *SecureBuffer) Destroy() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.memory == nil {
+		return
+	}
+	clearBytes(b.memory)
+	if b.secure {
+		freeSecure(b.memory)
+	}
+	b.memory, b.data = nil, nil
+	runtime.SetFinalizer(b, nil)
+}
+
+// MnemonicSeed derives the BIP-39 seed of a mnemonic without a passphrase,
+// like bip39.NewSeed but without copying the mnemonic into a string
+func MnemonicSeed(mnemonic *SecureBuffer) *SecureBuffer {
+	seed := pbkdf2.Key(mnemonic.Bytes(), []byte("mnemonic"), 2048, 64, sha512.New)
+	defer clearBytes(seed)
+	return NewSecureBuffer(seed)
+}
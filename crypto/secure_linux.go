@@ -0,0 +1,8 @@
+package crypto
+
+import "golang.org/x/sys/unix"
+
+// excludeFromDump leaves memory out of core dumps
+func excludeFromDump(memory []byte) {
+	unix.Madvise(memory, unix.MADV_DONTDUMP)
+}
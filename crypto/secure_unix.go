@@ -0,0 +1,35 @@
+//go:build !windows
+
+package crypto
+
+import (
+	"log/slog"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// allocSecure maps whole pages for size bytes outside the Go heap and locks
+// them, or returns nil. Without the memory lock (e.g. a low RLIMIT_MEMLOCK)
+// the pages are still used, and may be swapped.
+func allocSecure(size int) []byte {
+	pageSize := os.Getpagesize()
+	length := (size/pageSize + 1) * pageSize
+
+	memory, err := unix.Mmap(-1, 0, length, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_ANON|unix.MAP_PRIVATE)
+	if err != nil {
+		slog.Debug("secure memory unavailable, using the heap", "error", err)
+		return nil
+	}
+	if err := unix.Mlock(memory); err != nil {
+		slog.Debug("failed to lock secure memory", "error", err)
+	}
+	excludeFromDump(memory)
+	return memory
+}
+
+// freeSecure unlocks and unmaps pages of allocSecure
+func freeSecure(memory []byte) {
+	unix.Munlock(memory)
+	unix.Munmap(memory)
+}
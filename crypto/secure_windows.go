@@ -0,0 +1,23 @@
+package crypto
+
+import (
+	"log/slog"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// allocSecure allocates size bytes and locks them into memory. The Go heap
+// doesn't move allocations, so the lock holds until freeSecure.
+func allocSecure(size int) []byte {
+	memory := make([]byte, size+1)
+	if err := windows.VirtualLock(uintptr(unsafe.Pointer(&memory[0])), uintptr(len(memory))); err != nil {
+		slog.Debug("failed to lock secure memory", "error", err)
+	}
+	return memory
+}
+
+// freeSecure unlocks memory of allocSecure
+func freeSecure(memory []byte) {
+	windows.VirtualUnlock(uintptr(unsafe.Pointer(&memory[0])), uintptr(len(memory)))
+}
@@ -0,0 +1,126 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// MaxShares is the largest number of shares a secret can be split into
+const MaxShares = 16
+
+// Share is one share of a secret split with SplitSecret
+type Share struct {
+	X byte   // evaluation point, never 0
+	Y []byte // one polynomial value per secret byte
+}
+
+// SplitSecret splits a secret into shares using Shamir's secret sharing over
+// GF(256). Any threshold shares recover the secret, fewer reveal nothing about it.
+func SplitSecret(secret []byte, shares, threshold int) ([]Share, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("secret cannot be empty")
+	}
+	if threshold < 1 || threshold > shares {
+		return nil, fmt.Errorf("threshold must be between 1 and the number of shares")
+	}
+	if shares > MaxShares {
+		return nil, fmt.Errorf("at most %d shares are supported", MaxShares)
+	}
+
+	result := make([]Share, shares)
+	for i := range result {
+		result[i] = Share{X: byte(i + 1), Y: make([]byte, len(secret))}
+	}
+
+	// One random polynomial per byte, with the secret byte as constant term
+	coefficients := make([]byte, threshold)
+	defer clearBytes(coefficients)
+	for b, value := range secret {
+		coefficients[0] = value
+		if _, err := io.ReadFull(rand.Reader, coefficients[1:]); err != nil {
+			return nil, fmt.Errorf("failed to generate coefficients: %w", err)
+		}
+
+		for i := range result {
+			result[i].Y[b] = evaluatePolynomial(coefficients, result[i].X)
+		}
+	}
+
+	return result, nil
+}
+
+// CombineShares recovers a secret from at least threshold distinct shares
+func CombineShares(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("no shares provided")
+	}
+
+	size := len(shares[0].Y)
+	seen := make(map[byte]bool)
+	for _, share := range shares {
+		if share.X == 0 {
+			return nil, fmt.Errorf("invalid share index 0")
+		}
+		if seen[share.X] {
+			return nil, fmt.Errorf("share %d was provided twice", share.X)
+		}
+		if len(share.Y) != size {
+			return nil, fmt.Errorf("shares have different lengths")
+		}
+		seen[share.X] = true
+	}
+
+	// Lagrange interpolation at x = 0
+	secret := make([]byte, size)
+	for i, share := range shares {
+		basis := byte(1)
+		for j, other := range shares {
+			if i == j {
+				continue
+			}
+			basis = gfMul(basis, gfMul(other.X, gfInv(other.X^share.X)))
+		}
+
+		for b := range secret {
+			secret[b] ^= gfMul(share.Y[b], basis)
+		}
+	}
+
+	return secret, nil
+}
+
+// evaluatePolynomial evaluates a polynomial at x using Horner's method
+func evaluatePolynomial(coefficients []byte, x byte) byte {
+	result := byte(0)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result = gfMul(result, x) ^ coefficients[i]
+	}
+	return result
+}
+
+// gfMul multiplies in GF(256) with the AES polynomial x^8 + x^4 + x^3 + x + 1
+func gfMul(a, b byte) byte {
+	var result byte
+	for b > 0 {
+		if b&1 == 1 {
+			result ^= a
+		}
+		carry := a & 0x80
+		a <<= 1
+		if carry != 0 {
+			a ^= 0x1b
+		}
+		b >>= 1
+	}
+	return result
+}
+
+// gfInv returns the multiplicative inverse in GF(256), a^254
+func gfInv(a byte) byte {
+	result := byte(1)
+	for i := 0; i < 254; i++ {
+		result = gfMul(result, a)
+	}
+	return result
+}
@@ -0,0 +1,195 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// TOTP parameters of RFC 6238 as authenticator apps use them
+const (
+	TOTPDigits     = 6
+	TOTPPeriod     = 30 * time.Second
+	totpSecretSize = 20
+	totpSkew       = 1 // steps accepted before and after the current one, for clock drift
+)
+
+// totpKeyInfo separates the key sealing the TOTP secret from other keys
+// derived from the mnemonic
+const totpKeyInfo = "odyssey totp secret"
+
+// SealedSecret is a secret encrypted with AES-256-GCM
+type SealedSecret struct {
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// NewTOTPSecret returns a random TOTP secret
+func NewTOTPSecret() ([]byte, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := io.ReadFull(rand.Reader, secret); err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return secret, nil
+}
+
+// EncodeTOTPSecret returns the base32 form of a secret that authenticator
+// apps accept when typed in
+func EncodeTOTPSecret(secret []byte) string {
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret)
+}
+
+// TOTPURI returns the otpauth URI of a secret, shown as a QR code to enroll
+// an authenticator app
+func TOTPURI(secret []byte, issuer, account string) string {
+	query := url.Values{}
+	query.Set("secret", EncodeTOTPSecret(secret))
+	query.Set("issuer", issuer)
+	query.Set("digits", fmt.Sprint(TOTPDigits))
+	query.Set("period", fmt.Sprint(int(TOTPPeriod.Seconds())))
+	return fmt.Sprintf("otpauth://totp/%s:%s?%s", url.PathEscape(issuer), url.PathEscape(account), query.Encode())
+}
+
+// TOTPCode returns the code of a secret at time t
+func TOTPCode(secret []byte, t time.Time) string {
+	return totpCode(secret, uint64(t.Unix())/uint64(TOTPPeriod.Seconds()))
+}
+
+// totpCode computes the HOTP code of RFC 4226 for a counter
+func totpCode(secret []byte, counter uint64) string {
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], counter)
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+// ValidateTOTP checks a code against the secret at time t, allowing one step
+// of clock drift either way
+func ValidateTOTP(secret []byte, code string, t time.Time) bool {
+	if len(code) != TOTPDigits {
+		return false
+	}
+	counter := uint64(t.Unix()) / uint64(TOTPPeriod.Seconds())
+	valid := false
+	for step := -totpSkew; step <= totpSkew; step++ {
+		expected := totpCode(secret, counter+uint64(step))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// totpKey derives the key sealing the TOTP secret from the mnemonic, so an
+// unlocked session can check codes without the password
+func totpKey(mnemonic *SecureBuffer) []byte {
+	mac := hmac.New(sha256.New, mnemonic.Bytes())
+	mac.Write([]byte(totpKeyInfo))
+	return mac.Sum(nil)
+}
+
+// SealTOTPSecret stores a TOTP secret in the vault, encrypted with a key
+// derived from the mnemonic
+func (v *Vault) SealTOTPSecret(mnemonic *SecureBuffer, secret []byte) error {
+	sealed, err := sealTOTPSecret(mnemonic, secret)
+	if err != nil {
+		return err
+	}
+	v.TOTP = sealed
+	return nil
+}
+
+func sealTOTPSecret(mnemonic *SecureBuffer, secret []byte) (*SealedSecret, error) {
+	key := totpKey(mnemonic)
+	defer clearBytes(key)
+
+	nonce, data, err := Seal(key, secret, []byte(totpKeyInfo))
+	if err != nil {
+		return nil, err
+	}
+	return &SealedSecret{Nonce: nonce, Data: data}, nil
+}
+
+// Wallets whose two-factor secret a mnemonic opens, see TOTPSlot
+const (
+	TOTPSlotNone  = iota // two-factor authentication isn't enabled
+	TOTPSlotMain         // the secret of the main wallet
+	TOTPSlotDecoy        // the secret of the decoy wallet in the second slot
+)
+
+// TOTPSlot returns whose two-factor secret mnemonic opens. A mnemonic opens
+// only the secret of its own wallet.
+func (v *Vault) TOTPSlot(mnemonic *SecureBuffer) int {
+	secret, slot := v.openTOTPSecret(mnemonic)
+	clearBytes(secret)
+	return slot
+}
+
+// OpenTOTPSecret decrypts the TOTP secret of the vault, or the one of the
+// decoy wallet if mnemonic is the decoy's
+func (v *Vault) OpenTOTPSecret(mnemonic *SecureBuffer) ([]byte, error) {
+	secret, slot := v.openTOTPSecret(mnemonic)
+	if slot == TOTPSlotNone {
+		return nil, fmt.Errorf("two-factor authentication is not enabled")
+	}
+	return secret, nil
+}
+
+func (v *Vault) openTOTPSecret(mnemonic *SecureBuffer) ([]byte, int) {
+	key := totpKey(mnemonic)
+	defer clearBytes(key)
+
+	if v.TOTP != nil {
+		if secret, err := Open(key, v.TOTP.Nonce, v.TOTP.Data, []byte(totpKeyInfo)); err == nil {
+			return secret, TOTPSlotMain
+		}
+	}
+	// The second slot always holds a secret or filler of the same size
+	if v.Slot2 != nil && v.Slot2.TOTP != nil {
+		if secret, err := Open(key, v.Slot2.TOTP.Nonce, v.Slot2.TOTP.Data, []byte(totpKeyInfo)); err == nil {
+			return secret, TOTPSlotDecoy
+		}
+	}
+	return nil, TOTPSlotNone
+}
+
+// SealDecoyTOTPSecret stores a TOTP secret of the decoy wallet in the second
+// slot, encrypted with a key derived from the decoy mnemonic
+func (v *Vault) SealDecoyTOTPSecret(mnemonic *SecureBuffer, secret []byte) error {
+	if v.Slot2 == nil {
+		return fmt.Errorf("the vault has no second slot")
+	}
+	sealed, err := sealTOTPSecret(mnemonic, secret)
+	if err != nil {
+		return err
+	}
+	v.Slot2.TOTP = sealed
+	return nil
+}
+
+// ClearDecoyTOTPSecret replaces the TOTP secret of the decoy wallet with
+// random filler, so the slot looks the same without one
+func (v *Vault) ClearDecoyTOTPSecret() error {
+	if v.Slot2 == nil {
+		return nil
+	}
+	filler, err := newFillerSlot()
+	if err != nil {
+		return err
+	}
+	v.Slot2.TOTP = filler.TOTP
+	return nil
+}
@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -18,11 +19,30 @@ const (
 	KeyLen  = 32 // AES-256 key length
 )
 
+// vaultSlotSize is the plaintext size of the second slot. A decoy is padded
+// to it so it can't be told apart from the random filler of an unused slot.
+const vaultSlotSize = 512
+
 type Vault struct {
-	Salt   []byte `json:"salt"`
-	Nonce  []byte `json:"nonce"`
-	Data   []byte `json:"data"`
-	MAC    []byte `json:"mac"`
+	Salt      []byte          `json:"salt"`
+	Nonce     []byte          `json:"nonce"`
+	Data      []byte          `json:"data"`
+	MAC       []byte          `json:"mac"`
+	TOTP      *SealedSecret   `json:"totp,omitempty"`       // two-factor secret, see SealTOTPSecret
+	Slot2     *VaultSlot      `json:"slot2,omitempty"`      // decoy wallet or random filler
+	Keys      []*SealedSecret `json:"keys,omitempty"`       // imported private keys, see SealImportedKey
+	Audit     *SealedSecret   `json:"audit,omitempty"`      // key of the audit log, see EnsureAuditKey
+	AuditHead *AuditHead      `json:"audit_head,omitempty"` // last entry of the audit log
+}
+
+// VaultSlot is vault data encrypted under a password of its own, used for a
+// decoy wallet unlocked with a duress password
+type VaultSlot struct {
+	Salt  []byte        `json:"salt"`
+	Nonce []byte        `json:"nonce"`
+	Data  []byte        `json:"data"`
+	TOTP  *SealedSecret `json:"totp"`  // two-factor secret of the decoy, or filler
+	Audit *SealedSecret `json:"audit"` // audit key sealed for the decoy, or filler
 }
 
 type VaultData struct {
@@ -68,24 +88,152 @@ func NewVault(mnemonic, password string) (*Vault, error) {
 		return nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
 
+	slot, err := newFillerSlot()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Vault{
 		Salt:  salt,
 		Nonce: nonce,
 		Data:  encryptedData,
 		MAC:   mac,
+		Slot2: slot,
 	}, nil
 }
 
-func (v *Vault) Decrypt(password string) (string, error) {
-	// Derive key from password
+// newFillerSlot returns a slot of random bytes, as large as a real one
+func newFillerSlot() (*VaultSlot, error) {
+	slot := &VaultSlot{
+		Salt:  make([]byte, 32),
+		Nonce: make([]byte, 12),
+		Data:  make([]byte, vaultSlotSize+16), // GCM adds a 16 byte tag
+		TOTP: &SealedSecret{
+			Nonce: make([]byte, 12),
+			Data:  make([]byte, totpSecretSize+16),
+		},
+		Audit: &SealedSecret{
+			Nonce: make([]byte, 12),
+			Data:  make([]byte, auditKeySize+16),
+		},
+	}
+	for _, b := range [][]byte{slot.Salt, slot.Nonce, slot.Data, slot.TOTP.Nonce, slot.TOTP.Data, slot.Audit.Nonce, slot.Audit.Data} {
+		if _, err := io.ReadFull(rand.Reader, b); err != nil {
+			return nil, fmt.Errorf("failed to generate slot: %w", err)
+		}
+	}
+	return slot, nil
+}
+
+// SetDecoy stores a decoy mnemonic in the second slot, unlocked with its own
+// password. The slot's TOTP secret is filler until the decoy enables
+// two-factor authentication. A non-nil auditKey is sealed for the decoy, as
+// both wallets append to the same audit log.
+func (v *Vault) SetDecoy(mnemonic, password string, auditKey []byte) error {
+	data, err := json.Marshal(VaultData{Mnemonic: mnemonic, Version: 1})
+	if err != nil {
+		return fmt.Errorf("failed to serialize vault data: %w", err)
+	}
+	defer clearBytes(data)
+	if len(data) > vaultSlotSize {
+		return fmt.Errorf("mnemonic is too long")
+	}
+	// JSON allows trailing whitespace
+	padded := make([]byte, vaultSlotSize)
+	copy(padded, data)
+	for i := len(data); i < len(padded); i++ {
+		padded[i] = ' '
+	}
+	defer clearBytes(padded)
+
+	slot, err := newFillerSlot()
+	if err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(rand.Reader, slot.Salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	if _, err := io.ReadFull(rand.Reader, slot.Nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	key, err := deriveKey(password, slot.Salt)
+	if err != nil {
+		return fmt.Errorf("failed to derive key: %w", err)
+	}
+	defer clearBytes(key)
+
+	if slot.Data, _, err = encrypt(key, slot.Nonce, padded); err != nil {
+		return fmt.Errorf("failed to encrypt data: %w", err)
+	}
+	if auditKey != nil {
+		decoy := NewSecureString(mnemonic)
+		defer decoy.Destroy()
+		if slot.Audit, err = sealAuditKey(decoy, auditKey); err != nil {
+			return err
+		}
+	}
+	v.Slot2 = slot
+	return nil
+}
+
+// ClearDecoy replaces the second slot with random filler
+func (v *Vault) ClearDecoy() error {
+	slot, err := newFillerSlot()
+	if err != nil {
+		return err
+	}
+	v.Slot2 = slot
+	return nil
+}
+
+// IsPrimaryPassword returns true if password opens the main wallet, not the
+// decoy
+func (v *Vault) IsPrimaryPassword(password string) bool {
 	key, err := deriveKey(password, v.Salt)
 	if err != nil {
-		return "", fmt.Errorf("failed to derive key: %w", err)
+		return false
 	}
 	defer clearBytes(key)
 
+	plaintext, err := decrypt(key, v.Nonce, v.Data, v.MAC)
+	if err != nil {
+		return false
+	}
+	clearBytes(plaintext)
+	return true
+}
+
+// open decrypts the slot password belongs to. The keys of both slots are
+// always derived, so the time taken doesn't tell which one opened.
+func (v *Vault) open(password string) ([]byte, error) {
+	key, err := deriveKey(password, v.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	defer clearBytes(key)
+
+	var slotKey []byte
+	if v.Slot2 != nil {
+		if slotKey, err = deriveKey(password, v.Slot2.Salt); err != nil {
+			return nil, fmt.Errorf("failed to derive key: %w", err)
+		}
+		defer clearBytes(slotKey)
+	}
+
+	plaintext, err := decrypt(key, v.Nonce, v.Data, v.MAC)
+	if err == nil || slotKey == nil {
+		return plaintext, err
+	}
+	if plaintext, slotErr := decrypt(slotKey, v.Slot2.Nonce, v.Slot2.Data, nil); slotErr == nil {
+		return plaintext, nil
+	}
+	return nil, err
+}
+
+func (v *Vault) Decrypt(password string) (string, error) {
 	// Decrypt data
-	decryptedData, err := decrypt(key, v.Nonce, v.Data, v.MAC)
+	decryptedData, err := v.open(password)
 	if err != nil {
 		return "", fmt.Errorf("failed to decrypt data: %w", err)
 	}
@@ -99,6 +247,30 @@ func (v *Vault) Decrypt(password string) (string, error) {
 	return vaultData.Mnemonic, nil
 }
 
+// DecryptSecure decrypts the mnemonic into a secure buffer, without keeping
+// a copy of it in ordinary memory
+func (v *Vault) DecryptSecure(password string) (*SecureBuffer, error) {
+	decryptedData, err := v.open(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data: %w", err)
+	}
+	defer clearBytes(decryptedData)
+
+	// The mnemonic stays a slice of the decrypted data, BIP-39 words never
+	// need escaping in JSON
+	var vaultData struct {
+		Mnemonic json.RawMessage `json:"mnemonic"`
+	}
+	if err := json.Unmarshal(decryptedData, &vaultData); err != nil {
+		return nil, fmt.Errorf("failed to deserialize vault data: %w", err)
+	}
+	mnemonic := vaultData.Mnemonic
+	if len(mnemonic) < 2 || mnemonic[0] != '"' || bytes.IndexByte(mnemonic, '\\') >= 0 {
+		return nil, fmt.Errorf("failed to deserialize vault data: unexpected mnemonic")
+	}
+	return NewSecureBuffer(mnemonic[1 : len(mnemonic)-1]), nil
+}
+
 func deriveKey(password string, salt []byte) ([]byte, error) {
 	key, err := scrypt.Key([]byte(password), salt, ScryptN, ScryptR, ScryptP, KeyLen)
 	if err != nil {
@@ -148,6 +320,63 @@ func clearBytes(b []byte) {
 }
 
 func (v *Vault) ValidatePassword(password string) bool {
-	_, err := v.Decrypt(password)
-	return err == nil
-}
\ No newline at end of file
+	mnemonic, err := v.DecryptSecure(password)
+	if err != nil {
+		return false
+	}
+	mnemonic.Destroy()
+	return true
+}
+
+// Seal encrypts data with AES-256-GCM under key, binding it to additionalData
+func Seal(key, data, additionalData []byte) (nonce, ciphertext []byte, err error) {
+	if len(key) != KeyLen {
+		return nil, nil, fmt.Errorf("invalid key length: %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aesGCM, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	nonce = make([]byte, aesGCM.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return nonce, aesGCM.Seal(nil, nonce, data, additionalData), nil
+}
+
+// Open decrypts data sealed with Seal
+func Open(key, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(key) != KeyLen {
+		return nil, fmt.Errorf("invalid key length: %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aesGCM, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	plaintext, err := aesGCM.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// ClearBytes overwrites sensitive data in memory
+func ClearBytes(b []byte) {
+	clearBytes(b)
+}
@@ -0,0 +1,120 @@
+// Package logging sets up the leveled diagnostic log of odyssey. Messages go
+// through log/slog to stderr, so they never mix with command output:
+//
+//	slog.Debug("session expired", "path", path)
+//
+// Warnings and errors are always shown, -v adds info and debug messages and
+// -vv also the raw requests and responses of every HTTP and RPC call.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// LevelTrace is below debug and holds raw network traffic
+const LevelTrace = slog.LevelDebug - 4
+
+var level = new(slog.LevelVar)
+
+func init() {
+	level.Set(slog.LevelWarn)
+	slog.SetDefault(slog.New(newHandler(os.Stderr)))
+}
+
+// SetVerbosity sets the level from the number of -v flags, quiet only keeps errors
+func SetVerbosity(verbosity int, quiet bool) {
+	switch {
+	case quiet:
+		level.Set(slog.LevelError)
+	case verbosity >= 2:
+		level.Set(LevelTrace)
+	case verbosity == 1:
+		level.Set(slog.LevelDebug)
+	default:
+		level.Set(slog.LevelWarn)
+	}
+}
+
+// Enabled returns true if messages of level l are logged, to skip building
+// expensive ones such as response dumps
+func Enabled(l slog.Level) bool {
+	return slog.Default().Enabled(context.Background(), l)
+}
+
+// Trace logs raw network traffic, shown with -vv
+func Trace(msg string, args ...any) {
+	slog.Log(context.Background(), LevelTrace, msg, args...)
+}
+
+func newHandler(w io.Writer) slog.Handler {
+	return slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			switch a.Key {
+			case slog.TimeKey:
+				return slog.String(slog.TimeKey, a.Value.Time().Format("15:04:05.000"))
+			case slog.LevelKey:
+				if a.Value.Any() == LevelTrace {
+					return slog.String(slog.LevelKey, "TRACE")
+				}
+				return a
+			}
+			if a.Value.Kind() == slog.KindString {
+				return slog.String(a.Key, Redact(a.Key, a.Value.String()))
+			}
+			return a
+		},
+	})
+}
+
+// Keys and parameters whose values are never logged
+var secretName = regexp.MustCompile(`(?i)(pass|secret|api[_-]?key|access[_-]?(key|token)|private|auth|cookie|mnemonic|seed)`)
+
+// Secrets inside JSON bodies and URL paths of providers that put the API key there
+var (
+	secretJSONField = regexp.MustCompile(`(?i)("[a-z_]*(?:password|secret|api_?key|access_?token|private_?key|mnemonic|seed)[a-z_]*"\s*:\s*)"[^"]*"`)
+	secretPath      = regexp.MustCompile(`(?i)(/v[0-9]+/)[0-9a-z_-]{20,}`)
+)
+
+// Redact hides secrets in a logged value: whole values of secret looking keys,
+// credentials and API keys in URLs and secret fields of JSON bodies
+func Redact(key, value string) string {
+	if value == "" {
+		return value
+	}
+	if secretName.MatchString(key) {
+		return "[REDACTED]"
+	}
+	if strings.Contains(value, "://") && !strings.ContainsAny(value, " \n") {
+		return RedactURL(value)
+	}
+	return secretJSONField.ReplaceAllString(value, `$1"[REDACTED]"`)
+}
+
+// RedactURL hides user info, secret looking query parameters and API keys in
+// the path of a URL
+func RedactURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	if parsed.User != nil {
+		parsed.User = url.User("REDACTED")
+	}
+	query := parsed.Query()
+	for name := range query {
+		if secretName.MatchString(name) {
+			query.Set(name, "REDACTED")
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	parsed.Path = secretPath.ReplaceAllString(parsed.Path, "${1}REDACTED")
+	parsed.RawPath = ""
+	return parsed.String()
+}
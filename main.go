@@ -8,8 +8,12 @@ import (
 )
 
 func main() {
-	if err := cmd.Execute(); err != nil {
+	err := cmd.Execute()
+	// A declined confirmation was already reported by the command
+	if err != nil && !cmd.IsCancelled(err) {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		cmd.PrintErrorHints()
 	}
+	cmd.FlushOutput()
+	os.Exit(cmd.ExitCode(err))
 }
@@ -0,0 +1,37 @@
+// Package notify shows desktop notifications with the native notifier of the
+// operating system, so long-running commands can alert the user when
+// something happens while the terminal is in the background.
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// ErrUnsupported is returned when no notifier is available on this system
+var ErrUnsupported = errors.New("desktop notifications are not supported on this system")
+
+// Send shows a desktop notification. It returns once the notifier accepted
+// it, not when it is dismissed.
+func Send(title, message string) error {
+	name, args, err := command(title, message)
+	if err != nil {
+		return err
+	}
+
+	if output, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to show notification: %w (%s)", err, output)
+	}
+	return nil
+}
+
+// Available returns true if a notifier is installed
+func Available() bool {
+	name, _, err := command("", "")
+	if err != nil {
+		return false
+	}
+	_, err = exec.LookPath(name)
+	return err == nil
+}
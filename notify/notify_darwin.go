@@ -0,0 +1,21 @@
+//go:build darwin
+
+package notify
+
+import (
+	"fmt"
+	"strings"
+)
+
+// command builds an AppleScript notification run by osascript
+func command(title, message string) (string, []string, error) {
+	script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+	return "osascript", []string{"-e", script}, nil
+}
+
+// appleScriptString quotes a string literal for AppleScript
+func appleScriptString(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
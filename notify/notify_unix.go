@@ -0,0 +1,13 @@
+//go:build !darwin && !windows
+
+package notify
+
+import "os/exec"
+
+// command uses notify-send of libnotify, which freedesktop desktops provide
+func command(title, message string) (string, []string, error) {
+	if _, err := exec.LookPath("notify-send"); err != nil {
+		return "", nil, ErrUnsupported
+	}
+	return "notify-send", []string{"--app-name=Odyssey", "--", title, message}, nil
+}
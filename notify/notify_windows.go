@@ -0,0 +1,29 @@
+//go:build windows
+
+package notify
+
+import "strings"
+
+// toastScript shows a toast through the Windows Runtime API, available from
+// Windows 10 without extra modules. The title and message are inserted as
+// single quoted literals.
+const toastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$texts = $template.GetElementsByTagName('text')
+$texts.Item(0).AppendChild($template.CreateTextNode('%TITLE%')) | Out-Null
+$texts.Item(1).AppendChild($template.CreateTextNode('%MESSAGE%')) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('Odyssey').Show($toast)
+`
+
+// command builds a PowerShell toast notification
+func command(title, message string) (string, []string, error) {
+	script := strings.NewReplacer("%TITLE%", powerShellString(title), "%MESSAGE%", powerShellString(message)).Replace(toastScript)
+	return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", script}, nil
+}
+
+// powerShellString escapes a value for a single quoted PowerShell literal
+func powerShellString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
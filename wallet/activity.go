@@ -0,0 +1,186 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// maxActivityTransactions limits how many transactions are cached
+const maxActivityTransactions = 500
+
+// ActivityTransaction is a transaction of the wallet seen by an earlier
+// command, either in the transaction history or when it was sent
+type ActivityTransaction struct {
+	Chain       string    `json:"chain"`
+	Network     string    `json:"network"`
+	Hash        string    `json:"hash"`
+	Incoming    bool      `json:"incoming"`
+	Amount      string    `json:"amount"`
+	Timestamp   time.Time `json:"timestamp,omitempty"`    // block time
+	Confirmed   bool      `json:"confirmed"`              // included in a block
+	ConfirmedAt time.Time `json:"confirmed_at,omitempty"` // when the confirmation was first seen
+	SentAt      time.Time `json:"sent_at,omitempty"`      // when this wallet broadcast it
+}
+
+// ActivityApproval is an action waiting for this wallet, e.g. a Safe
+// transaction that still needs our confirmation
+type ActivityApproval struct {
+	Kind        string    `json:"kind"`   // e.g. safe
+	Source      string    `json:"source"` // e.g. the Safe address
+	Network     string    `json:"network"`
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	SeenAt      time.Time `json:"seen_at"`
+}
+
+// ActivityCache is the wallet activity known locally, used for the summary
+// shown on unlock without network access
+type ActivityCache struct {
+	LastUnlock   map[string]time.Time  `json:"last_unlock"` // by network
+	Transactions []ActivityTransaction `json:"transactions"`
+	Approvals    []ActivityApproval    `json:"approvals"`
+}
+
+// activityPath returns the location of the activity cache
+func (m *Manager) activityPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "activity.json")
+}
+
+// GetActivity returns the cached wallet activity
+func (m *Manager) GetActivity() (*ActivityCache, error) {
+	cache := &ActivityCache{LastUnlock: make(map[string]time.Time)}
+
+	data, err := os.ReadFile(m.activityPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("failed to read activity cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, fmt.Errorf("failed to parse activity cache: %w", err)
+	}
+	if cache.LastUnlock == nil {
+		cache.LastUnlock = make(map[string]time.Time)
+	}
+
+	return cache, nil
+}
+
+// RecordTransactions merges transactions into the cache. Entries already
+// known keep the time they were sent, and confirmations are timestamped
+// when first seen.
+func (m *Manager) RecordTransactions(transactions []ActivityTransaction) error {
+	cache, err := m.GetActivity()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, tx := range transactions {
+		if tx.Confirmed && tx.ConfirmedAt.IsZero() {
+			tx.ConfirmedAt = now
+		}
+
+		index := -1
+		for i, known := range cache.Transactions {
+			if known.Chain == tx.Chain && known.Network == tx.Network && known.Hash == tx.Hash {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			cache.Transactions = append(cache.Transactions, tx)
+			continue
+		}
+
+		known := &cache.Transactions[index]
+		if tx.Confirmed && !known.Confirmed {
+			known.Confirmed = true
+			known.ConfirmedAt = tx.ConfirmedAt
+		}
+		if !tx.Timestamp.IsZero() {
+			known.Timestamp = tx.Timestamp
+		}
+		if known.SentAt.IsZero() {
+			known.SentAt = tx.SentAt
+		}
+		if tx.Amount != "" {
+			known.Amount = tx.Amount
+		}
+	}
+
+	// Keep the most recent transactions
+	sort.SliceStable(cache.Transactions, func(i, j int) bool {
+		return activityTime(cache.Transactions[i]).Before(activityTime(cache.Transactions[j]))
+	})
+	if len(cache.Transactions) > maxActivityTransactions {
+		cache.Transactions = cache.Transactions[len(cache.Transactions)-maxActivityTransactions:]
+	}
+
+	return m.saveActivity(cache)
+}
+
+// SetApprovals replaces the cached approvals of one source
+func (m *Manager) SetApprovals(kind, source, network string, approvals []ActivityApproval) error {
+	cache, err := m.GetActivity()
+	if err != nil {
+		return err
+	}
+
+	var kept []ActivityApproval
+	for _, approval := range cache.Approvals {
+		if approval.Kind != kind || approval.Source != source || approval.Network != network {
+			kept = append(kept, approval)
+		}
+	}
+	cache.Approvals = append(kept, approvals...)
+
+	return m.saveActivity(cache)
+}
+
+// MarkUnlocked stores the time of an unlock and returns the previous one,
+// which is zero on the first unlock of a network
+func (m *Manager) MarkUnlocked(network string, at time.Time) (time.Time, error) {
+	cache, err := m.GetActivity()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	previous := cache.LastUnlock[network]
+	cache.LastUnlock[network] = at
+
+	return previous, m.saveActivity(cache)
+}
+
+// activityTime is the best known time of a transaction
+func activityTime(tx ActivityTransaction) time.Time {
+	if !tx.Timestamp.IsZero() {
+		return tx.Timestamp
+	}
+	return tx.SentAt
+}
+
+// saveActivity writes the activity cache to disk
+func (m *Manager) saveActivity(cache *ActivityCache) error {
+	path := m.activityPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal activity cache: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write activity cache: %w", err)
+	}
+
+	return nil
+}
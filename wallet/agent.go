@@ -0,0 +1,314 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/chinmay1088/odyssey/crypto"
+)
+
+// agentIOTimeout bounds a whole request to the agent, it only answers from
+// memory
+const agentIOTimeout = 5 * time.Second
+
+// Agent operations
+const (
+	agentOpGet    = "get"    // the mnemonic, renews the idle timeout
+	agentOpAdd    = "add"    // hold a mnemonic
+	agentOpLock   = "lock"   // forget the mnemonic, keep running
+	agentOpStatus = "status" // whether a mnemonic is held
+	agentOpStop   = "stop"   // forget the mnemonic and exit
+)
+
+// ErrAgentNotRunning is returned when no agent listens on the socket
+var ErrAgentNotRunning = errors.New("no agent is running")
+
+// errAgentUnsupported is returned where the agent can't check who connects
+var errAgentUnsupported = errors.New("the agent is only supported on Linux and macOS")
+
+// agentRequest is one request to the agent, sent as a JSON line
+type agentRequest struct {
+	Op       string `json:"op"`
+	Mnemonic []byte `json:"mnemonic,omitempty"`
+}
+
+// agentResponse answers an agentRequest. The mnemonic travels as bytes, so
+// both ends can wipe it after use.
+type agentResponse struct {
+	Error    string    `json:"error,omitempty"`
+	Mnemonic []byte    `json:"mnemonic,omitempty"`
+	PID      int       `json:"pid"`
+	Unlocked bool      `json:"unlocked"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// AgentStatus describes a running agent
+type AgentStatus struct {
+	PID      int
+	Socket   string
+	Unlocked bool      // the agent holds the mnemonic
+	LastUsed time.Time // last time a command got the mnemonic
+}
+
+// AgentSocketPath returns the unix socket the agent listens on
+func (m *Manager) AgentSocketPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "agent.sock")
+}
+
+// callAgent sends one request to the agent and returns its answer
+func (m *Manager) callAgent(request agentRequest) (*agentResponse, error) {
+	conn, err := net.DialTimeout("unix", m.AgentSocketPath(), time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAgentNotRunning, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(agentIOTimeout))
+
+	if err := json.NewEncoder(conn).Encode(request); err != nil {
+		return nil, fmt.Errorf("failed to send request to agent: %w", err)
+	}
+	var response agentResponse
+	if err := json.NewDecoder(conn).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to read agent response: %w", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("agent: %s", response.Error)
+	}
+	return &response, nil
+}
+
+// AgentStatus returns the status of the running agent
+func (m *Manager) AgentStatus() (*AgentStatus, error) {
+	response, err := m.callAgent(agentRequest{Op: agentOpStatus})
+	if err != nil {
+		return nil, err
+	}
+	return &AgentStatus{
+		PID:      response.PID,
+		Socket:   m.AgentSocketPath(),
+		Unlocked: response.Unlocked,
+		LastUsed: response.LastUsed,
+	}, nil
+}
+
+// AddToAgent decrypts the vault and hands the mnemonic to the running agent
+func (m *Manager) AddToAgent(password string) error {
+	mnemonic, err := m.decryptVault(password)
+	if err != nil {
+		return err
+	}
+	defer mnemonic.Destroy()
+	_, err = m.callAgent(agentRequest{Op: agentOpAdd, Mnemonic: mnemonic.Bytes()})
+	return err
+}
+
+// StopAgent makes the running agent forget the mnemonic and exit
+func (m *Manager) StopAgent() error {
+	_, err := m.callAgent(agentRequest{Op: agentOpStop})
+	return err
+}
+
+// loadAgentSession unlocks the wallet with the mnemonic held by the agent
+func (m *Manager) loadAgentSession() bool {
+	response, err := m.callAgent(agentRequest{Op: agentOpGet})
+	if err != nil {
+		slog.Debug("agent unavailable", "error", err)
+		return false
+	}
+	if len(response.Mnemonic) == 0 {
+		return false
+	}
+	m.setMnemonic(crypto.NewSecureBuffer(response.Mnemonic))
+	crypto.ClearBytes(response.Mnemonic)
+	return true
+}
+
+// agentServer holds the mnemonic for the commands of this user
+type agentServer struct {
+	mu       sync.Mutex
+	mnemonic *crypto.SecureBuffer
+	lastUsed time.Time
+	timeout  time.Duration
+	idle     *time.Timer
+	stop     context.CancelFunc
+}
+
+// ServeAgent runs the agent until ctx is done or it is stopped. With a
+// password it starts out holding the decrypted mnemonic. Only processes of
+// the same user may connect, and the mnemonic is forgotten when no command
+// used it for the session timeout.
+func (m *Manager) ServeAgent(ctx context.Context, password string) error {
+	if !agentSupported {
+		return errAgentUnsupported
+	}
+	var mnemonic *crypto.SecureBuffer
+	if password != "" {
+		var err error
+		if mnemonic, err = m.decryptVault(password); err != nil {
+			return err
+		}
+		defer mnemonic.Destroy()
+	}
+	if err := protectAgentProcess(); err != nil {
+		slog.Warn("agent memory may be readable by other processes", "error", err)
+	}
+
+	path := m.AgentSocketPath()
+	if _, err := m.callAgent(agentRequest{Op: agentOpStatus}); err == nil {
+		return fmt.Errorf("an agent is already running on %s", path)
+	}
+	// A socket left by an agent that didn't exit cleanly
+	os.Remove(path)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	defer os.Remove(path)
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to protect %s: %w", path, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	server := &agentServer{timeout: m.sessionTimeout, stop: cancel}
+	if mnemonic != nil {
+		server.add(mnemonic.Clone())
+	}
+	defer server.forget()
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	slog.Debug("agent listening", "socket", path, "pid", os.Getpid())
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("agent stopped: %w", err)
+		}
+		go server.handle(conn.(*net.UnixConn))
+	}
+}
+
+// handle answers one request, from processes of this user only
+func (s *agentServer) handle(conn *net.UnixConn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(agentIOTimeout))
+
+	encoder := json.NewEncoder(conn)
+	uid, err := peerUID(conn)
+	if err != nil || uid != os.Getuid() {
+		slog.Warn("agent rejected a connection", "uid", uid, "error", err)
+		encoder.Encode(agentResponse{Error: "permission denied"})
+		return
+	}
+
+	var request agentRequest
+	if err := json.NewDecoder(conn).Decode(&request); err != nil {
+		encoder.Encode(agentResponse{Error: "invalid request"})
+		return
+	}
+	defer crypto.ClearBytes(request.Mnemonic)
+
+	response := agentResponse{PID: os.Getpid()}
+	switch request.Op {
+	case agentOpGet:
+		if mnemonic := s.get(); mnemonic != nil {
+			defer mnemonic.Destroy()
+			response.Mnemonic = mnemonic.Bytes()
+		}
+	case agentOpAdd:
+		if len(request.Mnemonic) == 0 {
+			response.Error = "no mnemonic"
+			break
+		}
+		s.add(crypto.NewSecureBuffer(request.Mnemonic))
+	case agentOpLock:
+		s.forget()
+	case agentOpStatus:
+	case agentOpStop:
+		s.forget()
+		defer s.stop()
+	default:
+		response.Error = fmt.Sprintf("unknown operation %q", request.Op)
+	}
+
+	s.mu.Lock()
+	response.Unlocked = s.mnemonic != nil
+	response.LastUsed = s.lastUsed
+	s.mu.Unlock()
+	encoder.Encode(response)
+}
+
+// add holds a mnemonic, replacing the previous one
+func (s *agentServer) add(mnemonic *crypto.SecureBuffer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clear()
+	s.mnemonic = mnemonic
+	s.touch()
+}
+
+// get returns a copy of the mnemonic, nil if none is held, and renews the
+// idle timeout. The caller destroys the copy.
+func (s *agentServer) get() *crypto.SecureBuffer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mnemonic == nil {
+		return nil
+	}
+	s.touch()
+	return s.mnemonic.Clone()
+}
+
+// forget clears the mnemonic
+func (s *agentServer) forget() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clear()
+}
+
+// touch records a use and restarts the idle timer, s.mu is held
+func (s *agentServer) touch() {
+	s.lastUsed = time.Now()
+	if s.idle != nil {
+		s.idle.Stop()
+	}
+	s.idle = time.AfterFunc(s.timeout, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.mnemonic != nil && time.Since(s.lastUsed) >= s.timeout {
+			slog.Debug("agent idle, forgetting the mnemonic")
+			s.clear()
+		}
+	})
+}
+
+// clear overwrites and drops the mnemonic, s.mu is held
+func (s *agentServer) clear() {
+	if s.idle != nil {
+		s.idle.Stop()
+		s.idle = nil
+	}
+	if s.mnemonic != nil {
+		s.mnemonic.Destroy()
+	}
+	s.mnemonic = nil
+}
@@ -0,0 +1,36 @@
+package wallet
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// agentSupported is true where the agent can check who connects
+const agentSupported = true
+
+// peerUID returns the user ID of the process on the other end of conn
+func peerUID(conn *net.UnixConn) (int, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return -1, err
+	}
+	var cred *unix.Xucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptXucred(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERCRED)
+	})
+	if err != nil {
+		return -1, err
+	}
+	if credErr != nil {
+		return -1, credErr
+	}
+	return int(cred.Uid), nil
+}
+
+// protectAgentProcess does nothing, macOS doesn't let other processes of
+// the user attach without debugging entitlements
+func protectAgentProcess() error {
+	return nil
+}
@@ -0,0 +1,36 @@
+package wallet
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// agentSupported is true where the agent can check who connects
+const agentSupported = true
+
+// peerUID returns the user ID of the process on the other end of conn
+func peerUID(conn *net.UnixConn) (int, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return -1, err
+	}
+	var cred *unix.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err != nil {
+		return -1, err
+	}
+	if credErr != nil {
+		return -1, credErr
+	}
+	return int(cred.Uid), nil
+}
+
+// protectAgentProcess keeps the agent out of core dumps and keeps other
+// processes of the user from reading its memory with ptrace
+func protectAgentProcess() error {
+	return unix.Prctl(unix.PR_SET_DUMPABLE, 0, 0, 0, 0)
+}
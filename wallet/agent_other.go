@@ -0,0 +1,16 @@
+//go:build !linux && !darwin
+
+package wallet
+
+import "net"
+
+// agentSupported is false, peer credentials can't be read on this platform
+const agentSupported = false
+
+func peerUID(conn *net.UnixConn) (int, error) {
+	return -1, errAgentUnsupported
+}
+
+func protectAgentProcess() error {
+	return errAgentUnsupported
+}
@@ -0,0 +1,258 @@
+package wallet
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of archived records
+const (
+	ArchiveTransaction = "transaction" // seen in the transaction history
+	ArchiveReceipt     = "receipt"     // broadcast by this wallet
+)
+
+// archiveGenesis is the previous hash of the first entry
+var archiveGenesis = strings.Repeat("0", 64)
+
+// ArchiveRecord is a transaction or receipt as it was known when archived
+type ArchiveRecord struct {
+	Kind      string    `json:"kind"`
+	Chain     string    `json:"chain"`
+	Network   string    `json:"network"`
+	Hash      string    `json:"hash"`
+	Incoming  bool      `json:"incoming"`
+	Amount    string    `json:"amount"`
+	Timestamp time.Time `json:"timestamp"`
+	Confirmed bool      `json:"confirmed"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+// ArchiveEntry is one line of the archive. The record is addressed by the
+// hash of its content and every entry commits to the one before it, so
+// changing or removing an entry breaks all hashes after it.
+type ArchiveEntry struct {
+	Seq         int             `json:"seq"`
+	Prev        string          `json:"prev"`
+	ArchivedAt  time.Time       `json:"archived_at"`
+	ContentHash string          `json:"content_hash"`
+	Hash        string          `json:"hash"`
+	Record      json.RawMessage `json:"record"`
+}
+
+// ArchiveRecords returns the records of all known transactions, oldest first
+func (m *Manager) ArchiveRecords() ([]ArchiveRecord, error) {
+	activity, err := m.GetActivity()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]ArchiveRecord, 0, len(activity.Transactions))
+	for _, tx := range activity.Transactions {
+		kind := ArchiveTransaction
+		if !tx.SentAt.IsZero() {
+			kind = ArchiveReceipt
+		}
+		records = append(records, ArchiveRecord{
+			Kind:      kind,
+			Chain:     tx.Chain,
+			Network:   tx.Network,
+			Hash:      tx.Hash,
+			Incoming:  tx.Incoming,
+			Amount:    tx.Amount,
+			Timestamp: tx.Timestamp.UTC(),
+			Confirmed: tx.Confirmed,
+			SentAt:    tx.SentAt.UTC(),
+		})
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return archiveTime(records[i]).Before(archiveTime(records[j]))
+	})
+	return records, nil
+}
+
+// archiveTime is the best known time of a record
+func archiveTime(record ArchiveRecord) time.Time {
+	if !record.Timestamp.IsZero() && record.Timestamp.Unix() > 0 {
+		return record.Timestamp
+	}
+	return record.SentAt
+}
+
+// ReadArchive reads the entries of an archive file. A missing file is an
+// empty archive.
+func ReadArchive(path string) ([]ArchiveEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	var entries []ArchiveEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry ArchiveEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse archive line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	return entries, nil
+}
+
+// VerifyArchive checks the content hashes and the chain of entry hashes
+func VerifyArchive(entries []ArchiveEntry) error {
+	prev := archiveGenesis
+	for i, entry := range entries {
+		if entry.Seq != i {
+			return fmt.Errorf("entry %d has sequence number %d, entries are missing or reordered", i, entry.Seq)
+		}
+		if entry.Prev != prev {
+			return fmt.Errorf("entry %d does not link to the entry before it", i)
+		}
+		if contentHash(entry.Record) != entry.ContentHash {
+			return fmt.Errorf("entry %d: record does not match its content hash", i)
+		}
+		if entryHash(entry) != entry.Hash {
+			return fmt.Errorf("entry %d: entry hash does not match", i)
+		}
+		prev = entry.Hash
+	}
+	return nil
+}
+
+// AppendArchive adds the records not archived yet to the end of the archive
+// and returns the new entries. Existing entries are never rewritten; a record
+// whose content changed, e.g. because it confirmed, is added again.
+func AppendArchive(path string, entries []ArchiveEntry, records []ArchiveRecord) ([]ArchiveEntry, error) {
+	known := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		known[entry.ContentHash] = true
+	}
+
+	prev := archiveGenesis
+	if len(entries) > 0 {
+		prev = entries[len(entries)-1].Hash
+	}
+
+	now := time.Now().UTC()
+	var added []ArchiveEntry
+	var lines bytes.Buffer
+	for _, record := range records {
+		content, err := json.Marshal(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal archive record: %w", err)
+		}
+		hash := contentHash(content)
+		if known[hash] {
+			continue
+		}
+		known[hash] = true
+
+		entry := ArchiveEntry{
+			Seq:         len(entries) + len(added),
+			Prev:        prev,
+			ArchivedAt:  now,
+			ContentHash: hash,
+			Record:      content,
+		}
+		entry.Hash = entryHash(entry)
+
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal archive entry: %w", err)
+		}
+		lines.Write(line)
+		lines.WriteByte('\n')
+
+		added = append(added, entry)
+		prev = entry.Hash
+	}
+
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	if _, err := file.Write(lines.Bytes()); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return added, nil
+}
+
+// ArchiveMerkleRoot returns the merkle root over the entry hashes. An odd
+// node at the end of a level is paired with itself.
+func ArchiveMerkleRoot(entries []ArchiveEntry) (string, error) {
+	if len(entries) == 0 {
+		return "", fmt.Errorf("archive is empty")
+	}
+
+	level := make([][]byte, len(entries))
+	for i, entry := range entries {
+		hash, err := hex.DecodeString(entry.Hash)
+		if err != nil {
+			return "", fmt.Errorf("entry %d has an invalid hash: %w", i, err)
+		}
+		level[i] = hash
+	}
+
+	for len(level) > 1 {
+		var next [][]byte
+		for i := 0; i < len(level); i += 2 {
+			right := level[i]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			sum := sha256.Sum256(append(append([]byte{}, level[i]...), right...))
+			next = append(next, sum[:])
+		}
+		level = next
+	}
+
+	return hex.EncodeToString(level[0]), nil
+}
+
+// contentHash is the address of a record
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// entryHash commits to the position, the previous entry, the archive time
+// and the record of an entry
+func entryHash(entry ArchiveEntry) string {
+	data := fmt.Sprintf("%d|%s|%s|%s", entry.Seq, entry.Prev, entry.ArchivedAt.UTC().Format(time.RFC3339Nano), entry.ContentHash)
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,334 @@
+package wallet
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/chinmay1088/odyssey/crypto"
+)
+
+// Audited events
+const (
+	AuditUnlock             = "unlock"          // the vault was decrypted with the password
+	AuditRecoveryPhraseShow = "recovery-phrase" // the recovery phrase was displayed
+	AuditKeyExport          = "key-export"      // key material left the wallet, e.g. as shares
+	AuditSend               = "send"            // a transaction was broadcast
+	AuditTwoFactor          = "2fa"             // a two-factor code was rejected
+	AuditWalletDelete       = "wallet-delete"   // the vault and wallet files were wiped
+)
+
+// Audit results
+const (
+	AuditOK     = "ok"
+	AuditFailed = "failed"
+	AuditQueued = "queued" // a send waiting in the retry queue
+)
+
+// auditGenesis is the previous hash of the first entry
+var auditGenesis = hex.EncodeToString(make([]byte, sha256.Size))
+
+// auditMu serializes appends of this process, so entries chain in order
+var auditMu sync.Mutex
+
+// AuditEntry is one line of the audit log. Every entry includes the hash of
+// the one before, so changing or removing an entry breaks the chain. Entries
+// written while the wallet is unlocked are keyed: their hash is an HMAC with
+// the audit key sealed in the vault, so the chain can't be rebuilt without
+// the wallet. Entries written while it is locked, e.g. failed unlocks, are
+// chained with a plain hash and covered by the next keyed entry.
+type AuditEntry struct {
+	Seq       int       `json:"seq"`
+	Time      time.Time `json:"time"`
+	Event     string    `json:"event"`
+	Network   string    `json:"network,omitempty"`
+	Chain     string    `json:"chain,omitempty"`
+	Amount    string    `json:"amount,omitempty"`
+	Recipient string    `json:"recipient,omitempty"`
+	Result    string    `json:"result"`
+	Detail    string    `json:"detail,omitempty"` // e.g. the transaction hash or the error
+	Keyed     bool      `json:"keyed,omitempty"`
+	PrevHash  string    `json:"prev_hash"`
+	Hash      string    `json:"hash"`
+}
+
+// AuditError reports where the audit log stops verifying
+type AuditError struct {
+	Line   int
+	Reason string
+}
+
+func (e *AuditError) Error() string {
+	return fmt.Sprintf("audit log is broken at line %d: %s", e.Line, e.Reason)
+}
+
+// AuditLogPath returns the location of the audit log
+func (m *Manager) AuditLogPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "audit.log")
+}
+
+// computeHash returns the hash of the entry chained to its previous hash,
+// an HMAC with auditKey for keyed entries
+func (e AuditEntry) computeHash(auditKey []byte) (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	data = append([]byte(e.PrevHash), data...)
+	if !e.Keyed {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:]), nil
+	}
+	if auditKey == nil {
+		return "", fmt.Errorf("the wallet holds no audit key")
+	}
+	mac := hmac.New(sha256.New, auditKey)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// RecordAudit appends an event to the audit log, chained to the last entry.
+// The entry is keyed if the wallet is unlocked.
+func (m *Manager) RecordAudit(entry AuditEntry) error {
+	if err := m.rlockUnlocked(); err != nil {
+		return m.appendAudit(entry, nil)
+	}
+	defer m.mu.RUnlock()
+	return m.appendAudit(entry, m.mnemonic)
+}
+
+// appendAudit appends an entry, keyed with the audit key that mnemonic opens.
+// A keyed entry becomes the head kept in the vault. The caller holds m.mu, or
+// passes a nil mnemonic.
+func (m *Manager) appendAudit(entry AuditEntry, mnemonic *crypto.SecureBuffer) error {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	var vault *crypto.Vault
+	var auditKey []byte
+	if mnemonic != nil {
+		var err error
+		if vault, err = m.loadVault(); err != nil {
+			return err
+		}
+		// A decoy set before the log was keyed holds no key, its entries
+		// stay unkeyed
+		if auditKey, err = vault.OpenAuditKey(mnemonic); err == nil {
+			defer crypto.ClearBytes(auditKey)
+			entry.Keyed = true
+		}
+	}
+
+	path := m.AuditLogPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	last, err := lastAuditEntry(path)
+	if err != nil {
+		return err
+	}
+	entry.Seq = 1
+	entry.PrevHash = auditGenesis
+	if last != nil {
+		entry.Seq = last.Seq + 1
+		entry.PrevHash = last.Hash
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	entry.Time = entry.Time.UTC()
+	if entry.Network == "" {
+		entry.Network = m.GetCurrentNetwork()
+	}
+	if entry.Hash, err = entry.computeHash(auditKey); err != nil {
+		return fmt.Errorf("failed to hash audit entry: %w", err)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	if !entry.Keyed {
+		return nil
+	}
+	vault.AuditHead = crypto.NewAuditHead(auditKey, entry.Seq, entry.Hash)
+	return m.saveVault(vault)
+}
+
+// recordAudit records an event, keyed if mnemonic is the decrypted wallet. A
+// log that can't be written doesn't stop the operation. The caller holds m.mu.
+func (m *Manager) recordAudit(entry AuditEntry, mnemonic *crypto.SecureBuffer) {
+	if err := m.appendAudit(entry, mnemonic); err != nil {
+		slog.Warn("failed to write audit log", "event", entry.Event, "error", err)
+	}
+}
+
+// ensureAuditKey creates the audit key of a vault written before the log was
+// keyed. mnemonic is the main wallet, unlocked with its password.
+func (m *Manager) ensureAuditKey(mnemonic *crypto.SecureBuffer) error {
+	vault, err := m.loadVault()
+	if err != nil {
+		return err
+	}
+	if vault.Audit != nil {
+		return nil
+	}
+	if err := vault.EnsureAuditKey(mnemonic); err != nil {
+		return err
+	}
+	return m.saveVault(vault)
+}
+
+// lastAuditEntry returns the last entry of the log, nil if it is empty
+func lastAuditEntry(path string) (*AuditEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	data = bytes.TrimRight(data, "\n")
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+		data = data[i+1:]
+	}
+
+	var entry AuditEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse the last audit entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// GetAuditLog returns the entries of the audit log, oldest first, without
+// verifying them
+func (m *Manager) GetAuditLog() ([]AuditEntry, error) {
+	file, err := os.Open(m.AuditLogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse audit log line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// VerifyAuditLog checks that every entry is intact and chained to the one
+// before, that keyed entries were written with the audit key and that the log
+// still reaches the head kept in the vault. It needs the unlocked wallet and
+// returns the number of entries. A broken log returns an *AuditError for the
+// first entry that doesn't verify.
+func (m *Manager) VerifyAuditLog() (int, error) {
+	if err := m.rlockUnlocked(); err != nil {
+		return 0, err
+	}
+	defer m.mu.RUnlock()
+
+	vault, err := m.loadVault()
+	if err != nil {
+		return 0, err
+	}
+	var auditKey []byte
+	if vault.Audit != nil {
+		if auditKey, err = vault.OpenAuditKey(m.mnemonic); err != nil {
+			return 0, err
+		}
+		defer crypto.ClearBytes(auditKey)
+	}
+	head := vault.AuditHead
+	if head != nil && (auditKey == nil || !head.Verify(auditKey)) {
+		return 0, fmt.Errorf("the audit head in the vault was not written by this wallet")
+	}
+
+	file, err := os.Open(m.AuditLogPath())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return 0, fmt.Errorf("failed to read audit log: %w", err)
+		}
+		if head != nil {
+			return 0, &AuditError{Line: 1, Reason: fmt.Sprintf("the log is missing, the vault expects %d entries", head.Seq)}
+		}
+		return 0, nil
+	}
+	defer file.Close()
+
+	count := 0
+	prevHash := auditGenesis
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return count, &AuditError{Line: line, Reason: "not a valid entry"}
+		}
+		if entry.Seq != count+1 {
+			return count, &AuditError{Line: line, Reason: fmt.Sprintf("entry %d follows entry %d", entry.Seq, count)}
+		}
+		if entry.PrevHash != prevHash {
+			return count, &AuditError{Line: line, Reason: "not chained to the previous entry"}
+		}
+		hash, err := entry.computeHash(auditKey)
+		if err != nil || hash != entry.Hash {
+			return count, &AuditError{Line: line, Reason: "entry was modified"}
+		}
+		if head != nil && entry.Seq == head.Seq && entry.Hash != head.Hash {
+			return count, &AuditError{Line: line, Reason: "entry differs from the head kept in the vault"}
+		}
+		prevHash = entry.Hash
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return count, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	if head != nil && count < head.Seq {
+		return count, &AuditError{Line: count + 1, Reason: fmt.Sprintf("entries were removed from the end, the vault expects %d", head.Seq)}
+	}
+	return count, nil
+}
@@ -0,0 +1,129 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BitcoinChangeAddress is an address on the internal chain of an address
+// type that received the change of a payment
+type BitcoinChangeAddress struct {
+	Type      string    `json:"type"`
+	Path      string    `json:"path"`
+	Address   string    `json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// changeAddressesPath returns the location of the Bitcoin change addresses
+func (m *Manager) changeAddressesPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "change.json")
+}
+
+// bitcoinChangeChain returns the derivation path of the internal chain of a
+// Bitcoin address type without the address index, e.g. m/86'/0'/0'/1/
+func bitcoinChangeChain(addressType string) string {
+	return strings.TrimSuffix(bitcoinAccountPath(addressType), "0/0") + "1/"
+}
+
+// GetBitcoinChangeAddresses returns the change addresses of payments in the
+// order they were used
+func (m *Manager) GetBitcoinChangeAddresses() ([]BitcoinChangeAddress, error) {
+	data, err := os.ReadFile(m.changeAddressesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read change addresses: %w", err)
+	}
+
+	var addresses []BitcoinChangeAddress
+	if err := json.Unmarshal(data, &addresses); err != nil {
+		return nil, fmt.Errorf("failed to parse change addresses: %w", err)
+	}
+	return addresses, nil
+}
+
+// NextBitcoinChangeAccount derives the first address of the internal chain of
+// the address type in use after every address the wallet knows on it. Each
+// payment gets a fresh change address, so its change can't be linked to the
+// receiving address.
+func (m *Manager) NextBitcoinChangeAccount() (*BitcoinAccount, error) {
+	accounts, err := m.GetBitcoinAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	// Legacy and native SegWit share the keys of m/44', the next index
+	// follows the change addresses of both
+	chain := bitcoinChangeChain(m.btcAddressType)
+	next := uint64(0)
+	for _, account := range accounts {
+		if !strings.HasPrefix(account.Path, chain) {
+			continue
+		}
+		index, err := strconv.ParseUint(strings.TrimPrefix(account.Path, chain), 10, 31)
+		if err == nil && index >= next {
+			next = index + 1
+		}
+	}
+
+	return m.deriveBitcoinAccount(m.btcAddressType, chain+strconv.FormatUint(next, 10))
+}
+
+// RecordBitcoinChangeAccount keeps a change address, so its funds are
+// included in balances and payments. It is recorded before the payment is
+// broadcast, a payment retried later still sends change to it.
+func (m *Manager) RecordBitcoinChangeAccount(account *BitcoinAccount) error {
+	addresses, err := m.GetBitcoinChangeAddresses()
+	if err != nil {
+		return err
+	}
+	for _, existing := range addresses {
+		if existing.Path == account.Path && existing.Type == account.Type {
+			return nil
+		}
+	}
+
+	addresses = append(addresses, BitcoinChangeAddress{
+		Type:      account.Type,
+		Path:      account.Path,
+		Address:   account.Address.String(),
+		CreatedAt: time.Now(),
+	})
+
+	data, err := json.MarshalIndent(addresses, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal change addresses: %w", err)
+	}
+	if err := os.WriteFile(m.changeAddressesPath(), data, 0600); err != nil {
+		return fmt.Errorf("failed to write change addresses: %w", err)
+	}
+	return nil
+}
+
+// bitcoinChangeAccounts derives the keys of the change addresses. Addresses
+// of another recovery phrase, e.g. the main wallet while the decoy is
+// unlocked, are skipped.
+func (m *Manager) bitcoinChangeAccounts() ([]BitcoinAccount, error) {
+	addresses, err := m.GetBitcoinChangeAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []BitcoinAccount
+	for _, change := range addresses {
+		account, err := m.deriveBitcoinAccount(change.Type, change.Path)
+		if err != nil {
+			return nil, err
+		}
+		if account.Address.String() == change.Address {
+			accounts = append(accounts, *account)
+		}
+	}
+	return accounts, nil
+}
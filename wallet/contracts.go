@@ -0,0 +1,130 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Contract is a smart contract the user interacts with, stored with its ABI
+type Contract struct {
+	Name    string `json:"name"`
+	Chain   string `json:"chain"` // ethereum
+	Network string `json:"network"`
+	Address string `json:"address"`
+	ABI     string `json:"abi"`
+}
+
+// contractsPath returns the location of the known contracts registry
+func (m *Manager) contractsPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "contracts.json")
+}
+
+// GetContracts returns all known contracts sorted by network and name
+func (m *Manager) GetContracts() ([]Contract, error) {
+	data, err := os.ReadFile(m.contractsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read contracts: %w", err)
+	}
+
+	var contracts []Contract
+	if err := json.Unmarshal(data, &contracts); err != nil {
+		return nil, fmt.Errorf("failed to parse contracts: %w", err)
+	}
+
+	sort.SliceStable(contracts, func(i, j int) bool {
+		if contracts[i].Network != contracts[j].Network {
+			return contracts[i].Network < contracts[j].Network
+		}
+		return contracts[i].Name < contracts[j].Name
+	})
+
+	return contracts, nil
+}
+
+// FindContract looks up a known contract on a network by name or address
+func (m *Manager) FindContract(nameOrAddress, network string) (*Contract, error) {
+	contracts, err := m.GetContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, contract := range contracts {
+		if contract.Network != network {
+			continue
+		}
+		if strings.EqualFold(contract.Name, nameOrAddress) || strings.EqualFold(contract.Address, nameOrAddress) {
+			return &contracts[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no known contract named '%s' on %s. Add it with 'odyssey contract add'", nameOrAddress, network)
+}
+
+// SaveContract adds a contract to the registry or replaces the one with the same name
+func (m *Manager) SaveContract(entry Contract) error {
+	contracts, err := m.GetContracts()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, existing := range contracts {
+		if existing.Network == entry.Network && strings.EqualFold(existing.Name, entry.Name) {
+			contracts[i] = entry
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		contracts = append(contracts, entry)
+	}
+
+	return m.saveContracts(contracts)
+}
+
+// RemoveContract removes a contract from the registry
+func (m *Manager) RemoveContract(name, network string) (bool, error) {
+	contracts, err := m.GetContracts()
+	if err != nil {
+		return false, err
+	}
+
+	kept := contracts[:0]
+	for _, existing := range contracts {
+		if existing.Network == network && strings.EqualFold(existing.Name, name) {
+			continue
+		}
+		kept = append(kept, existing)
+	}
+
+	if len(kept) == len(contracts) {
+		return false, nil
+	}
+	return true, m.saveContracts(kept)
+}
+
+// saveContracts writes the known contracts registry to disk
+func (m *Manager) saveContracts(contracts []Contract) error {
+	path := m.contractsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(contracts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal contracts: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write contracts: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// deleteKeptFiles are left in the wallet directory when the wallet is
+// deleted: settings, the audit log that records the deletion, exported
+// reports and the named wallets below the default one
+var deleteKeptFiles = map[string]bool{
+	"config.json": true,
+	"network.txt": true,
+	"audit.log":   true,
+	"exports":     true,
+	"agent.sock":  true,
+	"wallets":     true,
+}
+
+// CheckDeletePassword returns an error unless password opens the vault
+func (m *Manager) CheckDeletePassword(password string) error {
+	vault, err := m.loadVault()
+	if err != nil {
+		return err
+	}
+	// The decoy password is accepted as well, refusing it would reveal that
+	// it isn't the main one
+	if !vault.ValidatePassword(password) {
+		m.recordAudit(AuditEntry{Event: AuditWalletDelete, Result: AuditFailed, Detail: "invalid password"}, nil)
+		return fmt.Errorf("invalid password")
+	}
+	return nil
+}
+
+// ExportBackup collects the vault, still encrypted with the wallet password,
+// the addresses found by discovery and change and the metadata files into a
+// bundle that 'odyssey migrate import' restores
+func (m *Manager) ExportBackup() (*MigrationBundle, error) {
+	return m.exportFiles(BackupFiles)
+}
+
+// DeleteWallet locks the wallet and wipes the vault, sessions, caches and
+// every file of the wallet directory except settings, the audit log and
+// exports. Files are overwritten with random data before they are removed.
+// It returns the names of the removed files and directories.
+func (m *Manager) DeleteWallet(password string) ([]string, error) {
+	if err := m.CheckDeletePassword(password); err != nil {
+		return nil, err
+	}
+
+	m.Lock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.recordAudit(AuditEntry{Event: AuditWalletDelete, Result: AuditOK}, nil)
+
+	dir := filepath.Dir(m.vaultPath)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallet directory: %w", err)
+	}
+
+	// The vault goes first, a failure later leaves no keys behind
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name() == filepath.Base(m.vaultPath)
+	})
+
+	var removed []string
+	for _, entry := range entries {
+		if deleteKeptFiles[entry.Name()] {
+			continue
+		}
+		if err := wipePath(filepath.Join(dir, entry.Name())); err != nil {
+			return removed, err
+		}
+		removed = append(removed, entry.Name())
+	}
+	m.vault = nil
+	return removed, nil
+}
+
+// wipePath overwrites a file, or every file in a directory, with random
+// data and removes it. On SSDs and copy-on-write file systems the old blocks
+// may survive, the vault stays encrypted with the password there.
+func wipePath(path string) error {
+	err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		return wipeFile(file)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wipe %s: %w", filepath.Base(path), err)
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// wipeFile overwrites the content of a file with random data
+func wipeFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(f, rand.Reader, info.Size()); err != nil {
+		return err
+	}
+	return f.Sync()
+}
@@ -0,0 +1,245 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/crypto"
+	"github.com/ethereum/go-ethereum/accounts"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// DiscoveryGapLimit is the number of unused addresses in a row after which
+// discovery stops scanning a chain, as recommended by BIP-44
+const DiscoveryGapLimit = 20
+
+// DiscoveryChain is a sequence of addresses scanned by discovery, e.g. the
+// external chain of the native SegWit account
+type DiscoveryChain struct {
+	Chain    string // ethereum, bitcoin or solana
+	Type     string // Bitcoin address type
+	Internal bool   // change addresses
+	pattern  string // derivation path with %d for the index
+}
+
+// Path returns the derivation path of the address at index
+func (c DiscoveryChain) Path(index uint32) string {
+	return fmt.Sprintf(c.pattern, index)
+}
+
+// Name returns the derivation path of the chain, e.g. m/84'/0'/0'/1/*
+func (c DiscoveryChain) Name() string {
+	return strings.Replace(c.pattern, "%d", "*", 1)
+}
+
+// DiscoveredAccount is an address of the wallet beyond the first one of its
+// chain that discovery found to have history
+type DiscoveredAccount struct {
+	Network string `json:"network"`
+	Chain   string `json:"chain"`          // ethereum, bitcoin or solana
+	Type    string `json:"type,omitempty"` // Bitcoin address type
+	Path    string `json:"path"`
+	Address string `json:"address"`
+}
+
+// bitcoinDiscoveryChains are the Bitcoin accounts scanned by discovery.
+// Native SegWit is scanned on m/44', used by odyssey, and on the standard
+// m/84' of other wallets.
+var bitcoinDiscoveryChains = []struct {
+	addressType string
+	purpose     int
+}{
+	{config.BitcoinAddressP2WPKH, 44},
+	{config.BitcoinAddressP2WPKH, 84},
+	{config.BitcoinAddressP2TR, 86},
+	{config.BitcoinAddressP2SHP2WPKH, 49},
+	{config.BitcoinAddressP2PKH, 44},
+}
+
+// DiscoveryChains returns the chains scanned by discovery on the current
+// network. Ethereum uses one chain of address indexes like most wallets,
+// Solana one account per index.
+func (m *Manager) DiscoveryChains() []DiscoveryChain {
+	ethereumPattern, solanaPattern := "m/44'/60'/0'/0/%d", "m/44'/501'/%d'/0'"
+	if m.network == NetworkTestnet {
+		ethereumPattern, solanaPattern = "m/44'/1'/0'/0/%d", "m/44'/501'/%d'/1'"
+	}
+
+	chains := []DiscoveryChain{{Chain: "ethereum", pattern: ethereumPattern}}
+	if m.BitcoinSupported() {
+		for _, btc := range bitcoinDiscoveryChains {
+			for _, internal := range []bool{false, true} {
+				change := 0
+				if internal {
+					change = 1
+				}
+				chains = append(chains, DiscoveryChain{
+					Chain:    "bitcoin",
+					Type:     btc.addressType,
+					Internal: internal,
+					pattern:  fmt.Sprintf("m/%d'/0'/0'/%d/%%d", btc.purpose, change),
+				})
+			}
+		}
+	}
+	return append(chains, DiscoveryChain{Chain: "solana", pattern: solanaPattern})
+}
+
+// DeriveDiscoveryAddresses derives count addresses of a discovery chain from
+// index start on
+func (m *Manager) DeriveDiscoveryAddresses(chain DiscoveryChain, start, count uint32) ([]DiscoveredAccount, error) {
+	if err := m.rlockUnlocked(); err != nil {
+		return nil, err
+	}
+	defer m.mu.RUnlock()
+
+	seedBuffer := crypto.MnemonicSeed(m.mnemonic)
+	defer seedBuffer.Destroy()
+	seed := seedBuffer.Bytes()
+
+	addresses := make([]DiscoveredAccount, 0, count)
+	for index := start; index < start+count; index++ {
+		path := chain.Path(index)
+		address, err := discoveryAddressFromSeed(seed, chain, path)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, DiscoveredAccount{
+			Network: m.network,
+			Chain:   chain.Chain,
+			Type:    chain.Type,
+			Path:    path,
+			Address: address,
+		})
+	}
+	return addresses, nil
+}
+
+// discoveryAddressFromSeed derives the address of a discovery chain at path
+func discoveryAddressFromSeed(seed []byte, chain DiscoveryChain, path string) (string, error) {
+	switch chain.Chain {
+	case "ethereum":
+		derivationPath, err := accounts.ParseDerivationPath(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse derivation path: %w", err)
+		}
+		key, err := deriveEthereumKey(seed, derivationPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to derive Ethereum key: %w", err)
+		}
+		return ethcrypto.PubkeyToAddress(*key.Public().(*ecdsa.PublicKey)).Hex(), nil
+	case "bitcoin":
+		account, err := bitcoinAccountFromSeed(seed, chain.Type, path)
+		if err != nil {
+			return "", err
+		}
+		return account.Address.String(), nil
+	case "solana":
+		key, err := deriveSolanaKey(seed, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to derive Solana key: %w", err)
+		}
+		return key.PublicKey().String(), nil
+	}
+	return "", fmt.Errorf("unsupported chain: %s", chain.Chain)
+}
+
+// discoveredPath returns the location of the accounts found by discovery
+func (m *Manager) discoveredPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "discovered.json")
+}
+
+// GetDiscoveredAccounts returns the accounts found by discovery on the
+// current network in the order they were found
+func (m *Manager) GetDiscoveredAccounts() ([]DiscoveredAccount, error) {
+	all, err := m.loadDiscoveredAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var found []DiscoveredAccount
+	for _, account := range all {
+		if account.Network == m.network {
+			found = append(found, account)
+		}
+	}
+	return found, nil
+}
+
+// loadDiscoveredAccounts returns the accounts found on every network
+func (m *Manager) loadDiscoveredAccounts() ([]DiscoveredAccount, error) {
+	data, err := os.ReadFile(m.discoveredPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read discovered accounts: %w", err)
+	}
+
+	var found []DiscoveredAccount
+	if err := json.Unmarshal(data, &found); err != nil {
+		return nil, fmt.Errorf("failed to parse discovered accounts: %w", err)
+	}
+	return found, nil
+}
+
+// AddDiscoveredAccounts registers accounts found by discovery, accounts
+// already registered are kept once
+func (m *Manager) AddDiscoveredAccounts(found []DiscoveredAccount) error {
+	all, err := m.loadDiscoveredAccounts()
+	if err != nil {
+		return err
+	}
+
+	for _, account := range found {
+		known := false
+		for _, existing := range all {
+			if existing == account {
+				known = true
+				break
+			}
+		}
+		if !known {
+			all = append(all, account)
+		}
+	}
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal discovered accounts: %w", err)
+	}
+	if err := os.WriteFile(m.discoveredPath(), data, 0600); err != nil {
+		return fmt.Errorf("failed to write discovered accounts: %w", err)
+	}
+	return nil
+}
+
+// discoveredBitcoinAccounts derives the keys of the Bitcoin addresses found
+// by discovery. Addresses of another recovery phrase, e.g. the main wallet
+// while the decoy is unlocked, are skipped.
+func (m *Manager) discoveredBitcoinAccounts() ([]BitcoinAccount, error) {
+	found, err := m.GetDiscoveredAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var bitcoinAccounts []BitcoinAccount
+	for _, discovered := range found {
+		if discovered.Chain != "bitcoin" {
+			continue
+		}
+		account, err := m.deriveBitcoinAccount(discovered.Type, discovered.Path)
+		if err != nil {
+			return nil, err
+		}
+		if account.Address.String() == discovered.Address {
+			bitcoinAccounts = append(bitcoinAccounts, *account)
+		}
+	}
+	return bitcoinAccounts, nil
+}
@@ -0,0 +1,95 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/chinmay1088/odyssey/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// SetDecoyWallet stores a decoy wallet in the vault, unlocked with
+// decoyPassword instead of the main password. An empty decoyMnemonic creates
+// a new one. It returns the decoy mnemonic.
+func (m *Manager) SetDecoyWallet(mainPassword, decoyMnemonic, decoyPassword string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	vault, err := m.loadVault()
+	if err != nil {
+		return "", fmt.Errorf("failed to load vault: %w", err)
+	}
+	if !vault.IsPrimaryPassword(mainPassword) {
+		return "", fmt.Errorf("invalid password")
+	}
+	if mainPassword == decoyPassword {
+		return "", fmt.Errorf("the duress password must differ from the main password")
+	}
+
+	mnemonic, err := vault.DecryptSecure(mainPassword)
+	if err != nil {
+		return "", fmt.Errorf("invalid password")
+	}
+	defer mnemonic.Destroy()
+
+	if decoyMnemonic == "" {
+		entropy, err := bip39.NewEntropy(256) // 24 words
+		if err != nil {
+			return "", fmt.Errorf("failed to generate entropy: %w", err)
+		}
+		if decoyMnemonic, err = bip39.NewMnemonic(entropy); err != nil {
+			return "", fmt.Errorf("failed to generate mnemonic: %w", err)
+		}
+	}
+	if !bip39.IsMnemonicValid(decoyMnemonic) {
+		return "", fmt.Errorf("invalid mnemonic")
+	}
+	if bytes.Equal([]byte(decoyMnemonic), mnemonic.Bytes()) {
+		return "", fmt.Errorf("the decoy recovery phrase must differ from the main one")
+	}
+
+	// Both wallets append to the audit log, so the decoy gets the audit key
+	if err := vault.EnsureAuditKey(mnemonic); err != nil {
+		return "", err
+	}
+	auditKey, err := vault.OpenAuditKey(mnemonic)
+	if err != nil {
+		return "", err
+	}
+	defer crypto.ClearBytes(auditKey)
+
+	// The decoy starts without two-factor authentication, it gets its own
+	// secret once '2fa enable' is run with it unlocked
+	if err := vault.SetDecoy(decoyMnemonic, decoyPassword, auditKey); err != nil {
+		return "", fmt.Errorf("failed to store decoy wallet: %w", err)
+	}
+	if err := m.saveVault(vault); err != nil {
+		return "", err
+	}
+	m.vault = vault
+	return decoyMnemonic, nil
+}
+
+// RemoveDecoyWallet replaces the decoy wallet with random filler, so the
+// duress password no longer unlocks anything
+func (m *Manager) RemoveDecoyWallet(mainPassword string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	vault, err := m.loadVault()
+	if err != nil {
+		return fmt.Errorf("failed to load vault: %w", err)
+	}
+	if !vault.IsPrimaryPassword(mainPassword) {
+		return fmt.Errorf("invalid password")
+	}
+
+	if err := vault.ClearDecoy(); err != nil {
+		return err
+	}
+	if err := m.saveVault(vault); err != nil {
+		return err
+	}
+	m.vault = vault
+	return nil
+}
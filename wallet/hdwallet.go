@@ -11,6 +11,9 @@ import (
 	"strings"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gagliardetto/solana-go"
@@ -82,6 +85,41 @@ func deriveBitcoinKey(seed []byte, path string) (*btcec.PrivateKey, error) {
 	return privateKey, nil
 }
 
+// deriveAccountKey derives the extended private key of an account, e.g.
+// m/84'/0'/0', with the fingerprint of the master key. Its public key
+// derives the addresses of the account without any private key.
+func deriveAccountKey(seed []byte, path string) (*hdkeychain.ExtendedKey, uint32, error) {
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create master key: %w", err)
+	}
+
+	masterPub, err := master.ECPubKey()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get master public key: %w", err)
+	}
+	hash := btcutil.Hash160(masterPub.SerializeCompressed())
+	fingerprint := binary.BigEndian.Uint32(hash[:4])
+
+	pathParts := strings.Split(path, "/")
+	if len(pathParts) < 2 || pathParts[0] != "m" {
+		return nil, 0, fmt.Errorf("invalid derivation path")
+	}
+
+	account := master
+	for _, part := range pathParts[1:] {
+		childNum, err := parseChildNum(part)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to parse child number: %w", err)
+		}
+		if account, err = account.Derive(childNum); err != nil {
+			return nil, 0, fmt.Errorf("failed to derive account key: %w", err)
+		}
+	}
+
+	return account, fingerprint, nil
+}
+
 // deriveSolanaKey derives a Solana private key from seed and path
 func deriveSolanaKey(seed []byte, path string) (solana.PrivateKey, error) {
 	// For Solana, which uses Ed25519, we need to take a different approach
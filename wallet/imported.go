@@ -0,0 +1,75 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/chinmay1088/odyssey/crypto"
+)
+
+// ImportedAccount is an account of a private key imported into the vault,
+// e.g. a vanity address, besides the accounts of the recovery phrase
+type ImportedAccount struct {
+	Chain   string // ethereum or solana
+	Label   string
+	Address string
+}
+
+// ImportKey seals the private key of an Ethereum or Solana address into the
+// vault. The recovery phrase doesn't derive it, the vault is its only backup.
+func (m *Manager) ImportKey(chain, label, address string, key []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.unlocked && !m.loadSession() {
+		return ErrLocked
+	}
+
+	vault, err := m.loadVault()
+	if err != nil {
+		return err
+	}
+	existing, err := vault.OpenImportedKeys(m.mnemonic)
+	if err != nil {
+		return err
+	}
+	for _, imported := range existing {
+		if imported.Address == address {
+			return fmt.Errorf("%s is already imported as %q", address, imported.Label)
+		}
+	}
+
+	imported := &crypto.ImportedKey{Chain: chain, Label: label, Address: address, Key: key}
+	if err := vault.SealImportedKey(m.mnemonic, imported); err != nil {
+		return fmt.Errorf("failed to seal the private key: %w", err)
+	}
+	if err := m.saveVault(vault); err != nil {
+		return err
+	}
+	m.vault = vault
+	return nil
+}
+
+// GetImportedAccounts returns the accounts of the imported private keys of
+// the unlocked wallet in the order they were imported
+func (m *Manager) GetImportedAccounts() ([]ImportedAccount, error) {
+	if err := m.rlockUnlocked(); err != nil {
+		return nil, err
+	}
+	defer m.mu.RUnlock()
+
+	vault, err := m.loadVault()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := vault.OpenImportedKeys(m.mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]ImportedAccount, len(keys))
+	for i, key := range keys {
+		accounts[i] = ImportedAccount{Chain: key.Chain, Label: key.Label, Address: key.Address}
+		crypto.ClearBytes(key.Key)
+	}
+	return accounts, nil
+}
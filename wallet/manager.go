@@ -3,9 +3,12 @@ package wallet
 import (
 	"crypto/ecdsa"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,8 +16,10 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
-	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/chinmay1088/odyssey/config"
 	"github.com/chinmay1088/odyssey/crypto"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
@@ -29,68 +34,127 @@ const (
 	NetworkTestnet = "testnet"
 
 	// Derivation paths for different chains (mainnet)
-	EthDerivationPath = "m/44'/60'/0'/0/0"
-	BtcDerivationPath = "m/44'/0'/0'/0/0"
-	SolDerivationPath = "m/44'/501'/0'/0'"
+	EthDerivationPath   = "m/44'/60'/0'/0/0"
+	BtcDerivationPath   = "m/44'/0'/0'/0/0"
+	BtcTaprootPath      = "m/86'/0'/0'/0/0"
+	BtcNestedSegwitPath = "m/49'/0'/0'/0/0"
+	SolDerivationPath   = "m/44'/501'/0'/0'"
 
 	// Derivation paths for testnet
 	EthTestnetDerivationPath = "m/44'/1'/0'/0/0"  // Use coin type 1 for testnet
 	SolTestnetDerivationPath = "m/44'/501'/0'/1'" // Use different account index for testnet
 
-	// Session duration in minutes
-	SessionDuration = 30
+	// Session scopes
+	SessionScopeGlobal   = "global"   // one session shared by every terminal
+	SessionScopeTerminal = "terminal" // session only valid in the terminal that unlocked
+
+	// SessionScopeEnv selects the default session scope
+	SessionScopeEnv = "ODYSSEY_SESSION_SCOPE"
 )
 
-// SessionData holds the wallet session information
+// SessionData holds the wallet session information.
+// The mnemonic is encrypted with the machine bound session key, Mnemonic is
+// only read from sessions written by older versions and never written.
 type SessionData struct {
 	Token      string    `json:"token"`
-	Mnemonic   string    `json:"mnemonic"`
+	Mnemonic   string    `json:"mnemonic,omitempty"`
+	Nonce      []byte    `json:"nonce"`
+	Ciphertext []byte    `json:"ciphertext"`
 	Expiration time.Time `json:"expiration"`
-	Network    string    `json:"network"` // Store network with session
+	LastUsed   time.Time `json:"last_used"` // renewed on every use, the session expires when idle
+	Network    string    `json:"network"`   // Store network with session
 }
 
+// ErrLocked is returned when keys are requested while the wallet is locked
+var ErrLocked = errors.New("wallet is locked")
+
 // Manager handles wallet operations and key derivation
 type Manager struct {
-	vaultPath   string
-	sessionPath string
-	vault       *crypto.Vault
-	mnemonic    string
-	password    string
-	mu          sync.RWMutex
-	unlocked    bool
-	network     string // Current network (mainnet or testnet)
+	vaultPath           string
+	sessionPath         string
+	terminalSessionPath string // empty if the terminal can't be identified
+	sessionScope        string
+	sessionTimeout      time.Duration // idle time before the session expires
+	sessionStorage      string        // file, agent or none, see config.SessionStorageFile
+	vault               *crypto.Vault
+	mnemonic            *crypto.SecureBuffer // nil while locked
+	mu                  sync.RWMutex
+	unlocked            bool
+	network             string // Current network (mainnet or testnet)
+	btcAddressType      string // p2wpkh or p2tr
 }
 
 // NewManager creates a new wallet manager
 func NewManager() *Manager {
-	homeDir, err := os.UserHomeDir()
+	// Every wallet keeps its vault, session and metadata in a directory of
+	// its own
+	dir, err := config.WalletDir()
 	if err != nil {
-		panic(fmt.Sprintf("failed to get home directory: %v", err))
+		panic(err.Error())
 	}
 
 	// Determine the current network
-	network := NetworkMainnet // Default to mainnet
-	networkPath := filepath.Join(homeDir, ".odyssey", "network.txt")
-
-	// Read network file if it exists
-	if _, err := os.Stat(networkPath); err == nil {
-		data, err := os.ReadFile(networkPath)
-		if err == nil {
-			network = strings.TrimSpace(string(data))
-			// Validate network
-			if network != NetworkMainnet && network != NetworkTestnet {
-				network = NetworkMainnet // Default to mainnet if invalid
-			}
-		}
+	network := config.CurrentNetwork()
+
+	// Terminal scoped sessions live next to the global one, keyed by terminal
+	terminalSessionPath := ""
+	if key, ok := terminalSessionKey(); ok {
+		hash := sha256.Sum256([]byte(key))
+		terminalSessionPath = filepath.Join(dir, "sessions", hex.EncodeToString(hash[:16])+".json")
+	}
+
+	sessionScope := SessionScopeGlobal
+	if strings.ToLower(os.Getenv(SessionScopeEnv)) == SessionScopeTerminal && terminalSessionPath != "" {
+		sessionScope = SessionScopeTerminal
+	}
+
+	// Session timeout is configurable, fall back to the default on a broken config
+	sessionTimeout := config.DefaultSessionTimeout
+	sessionStorage := config.SessionStorageFile
+	btcAddressType := config.BitcoinAddressP2WPKH
+	if cfg, err := config.Load(); err == nil {
+		sessionTimeout = cfg.SessionTimeout()
+		sessionStorage = cfg.SessionStorage()
+		btcAddressType = cfg.BitcoinAddressType()
+	}
+	if noSession && sessionStorage == config.SessionStorageFile {
+		sessionStorage = config.SessionStorageNone
 	}
 
 	return &Manager{
-		vaultPath:   filepath.Join(homeDir, ".odyssey", "wallet.vault"),
-		sessionPath: filepath.Join(homeDir, ".odyssey", "session.json"),
-		network:     network,
+		vaultPath:           filepath.Join(dir, "wallet.vault"),
+		sessionPath:         filepath.Join(dir, "session.json"),
+		terminalSessionPath: terminalSessionPath,
+		sessionScope:        sessionScope,
+		sessionTimeout:      sessionTimeout,
+		sessionStorage:      sessionStorage,
+		network:             network,
+		btcAddressType:      btcAddressType,
 	}
 }
 
+// SetSessionScope selects whether new sessions are shared globally or bound to
+// the current terminal
+func (m *Manager) SetSessionScope(scope string) error {
+	switch scope {
+	case SessionScopeGlobal:
+	case SessionScopeTerminal:
+		if m.terminalSessionPath == "" {
+			return fmt.Errorf("unable to identify the current terminal")
+		}
+	default:
+		return fmt.Errorf("invalid session scope: %s. Use '%s' or '%s'", scope, SessionScopeGlobal, SessionScopeTerminal)
+	}
+
+	m.sessionScope = scope
+	return nil
+}
+
+// SessionTimeout returns the idle time after which the session expires
+func (m *Manager) SessionTimeout() time.Duration {
+	return m.sessionTimeout
+}
+
 // generateSessionToken creates a random session token
 func generateSessionToken() (string, error) {
 	tokenBytes := make([]byte, 32)
@@ -101,35 +165,98 @@ func generateSessionToken() (string, error) {
 	return hex.EncodeToString(tokenBytes), nil
 }
 
-// createSession creates and saves a new session
+// createSession creates and saves a new session, or keeps the mnemonic in
+// memory when sessions aren't stored
 func (m *Manager) createSession() error {
+	if !m.SessionStored() {
+		m.rememberMemorySession(m.mnemonic)
+		if m.sessionStorage == config.SessionStorageAgent {
+			if _, err := m.callAgent(agentRequest{Op: agentOpAdd, Mnemonic: m.mnemonic.Bytes()}); err != nil {
+				slog.Warn("the agent doesn't hold the keys, they are kept for this command only", "error", err)
+			}
+		}
+		return nil
+	}
+
 	token, err := generateSessionToken()
 	if err != nil {
 		return fmt.Errorf("failed to generate session token: %w", err)
 	}
 
+	now := time.Now()
 	session := SessionData{
 		Token:      token,
-		Mnemonic:   m.mnemonic,
-		Expiration: time.Now().Add(SessionDuration * time.Minute),
+		Expiration: now.Add(m.sessionTimeout),
+		LastUsed:   now,
 		Network:    m.network, // Save current network with session
 	}
 
+	sessionPath := m.sessionPath
+	if m.sessionScope == SessionScopeTerminal {
+		sessionPath = m.terminalSessionPath
+		if err := os.MkdirAll(filepath.Dir(sessionPath), 0700); err != nil {
+			return fmt.Errorf("failed to create sessions directory: %w", err)
+		}
+	}
+
+	return m.writeSessionFile(sessionPath, &session, m.mnemonic.Bytes())
+}
+
+// writeSessionFile encrypts the mnemonic into the session and saves it to disk
+func (m *Manager) writeSessionFile(sessionPath string, session *SessionData, mnemonic []byte) error {
+	key, err := m.sessionKey()
+	if err != nil {
+		return err
+	}
+	defer crypto.ClearBytes(key)
+
+	// The token binds the ciphertext to this session
+	nonce, ciphertext, err := crypto.Seal(key, mnemonic, []byte(session.Token))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt session: %w", err)
+	}
+
+	session.Mnemonic = ""
+	session.Nonce = nonce
+	session.Ciphertext = ciphertext
+
 	data, err := json.Marshal(session)
 	if err != nil {
 		return fmt.Errorf("failed to marshal session: %w", err)
 	}
 
-	if err := os.WriteFile(m.sessionPath, data, 0600); err != nil {
+	if err := os.WriteFile(sessionPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write session file: %w", err)
 	}
 
 	return nil
 }
 
-// loadSession loads the session if it exists and is valid
+// loadSession loads the session if it exists and is valid.
+// A session bound to the current terminal takes precedence over the global one,
+// which is ignored entirely when the scope is terminal.
 func (m *Manager) loadSession() bool {
-	data, err := os.ReadFile(m.sessionPath)
+	if m.sessionStorage == config.SessionStorageAgent && m.loadAgentSession() {
+		return true
+	}
+	if !m.SessionStored() {
+		return m.loadMemorySession()
+	}
+
+	if m.terminalSessionPath != "" && m.loadSessionFile(m.terminalSessionPath) {
+		return true
+	}
+
+	if m.sessionScope == SessionScopeTerminal {
+		return false
+	}
+
+	return m.loadSessionFile(m.sessionPath)
+}
+
+// loadSessionFile loads a single session file if it exists and is valid
+func (m *Manager) loadSessionFile(sessionPath string) bool {
+	data, err := os.ReadFile(sessionPath)
 	if err != nil {
 		return false
 	}
@@ -137,33 +264,120 @@ func (m *Manager) loadSession() bool {
 	var session SessionData
 	if err := json.Unmarshal(data, &session); err != nil {
 		// Session file is corrupted, delete it
-		os.Remove(m.sessionPath)
+		slog.Debug("removing corrupted session", "path", sessionPath, "error", err)
+		os.Remove(sessionPath)
 		return false
 	}
 
-	// Check if session has expired
-	if time.Now().After(session.Expiration) {
+	// Check if session has expired. Sessions expire after being idle for the
+	// configured timeout, older sessions without a last use only have an expiration
+	now := time.Now()
+	expired := now.After(session.Expiration)
+	if !session.LastUsed.IsZero() {
+		expired = now.Sub(session.LastUsed) > m.sessionTimeout
+	}
+	if expired {
 		// Session expired, delete it
-		os.Remove(m.sessionPath)
+		slog.Debug("session expired", "path", sessionPath, "last_used", session.LastUsed.Format(time.RFC3339))
+		os.Remove(sessionPath)
 		return false
 	}
 
 	// Check if network matches current network
 	if session.Network != m.network {
 		// Network mismatch, session not valid for current network
+		slog.Debug("ignoring session of another network", "path", sessionPath, "session_network", session.Network, "network", m.network)
 		return false
 	}
 
-	// Session is valid, load the mnemonic
-	m.mnemonic = session.Mnemonic
-	m.unlocked = true
+	// Decrypt the mnemonic, plaintext sessions of older versions are migrated
+	// by the renewal below
+	var mnemonic *crypto.SecureBuffer
+	if session.Mnemonic != "" {
+		mnemonic = crypto.NewSecureString(session.Mnemonic)
+	} else {
+		key, err := m.sessionKey()
+		if err != nil {
+			slog.Debug("session key unavailable", "error", err)
+			return false
+		}
+		plaintext, err := crypto.Open(key, session.Nonce, session.Ciphertext, []byte(session.Token))
+		crypto.ClearBytes(key)
+		if err != nil {
+			// Written on another machine or with another key, unusable
+			slog.Debug("removing session that can't be decrypted", "path", sessionPath)
+			os.Remove(sessionPath)
+			return false
+		}
+		mnemonic = crypto.NewSecureBuffer(plaintext)
+		crypto.ClearBytes(plaintext)
+	}
+
+	// Session is valid, renew it and load the mnemonic
+	legacy := session.Mnemonic != ""
+	session.LastUsed = now
+	session.Expiration = now.Add(m.sessionTimeout)
+	if err := m.writeSessionFile(sessionPath, &session, mnemonic.Bytes()); err != nil {
+		slog.Warn("failed to renew session", "path", sessionPath, "error", err)
+		if legacy {
+			// Never keep using a plaintext session that couldn't be migrated
+			os.Remove(sessionPath)
+			mnemonic.Destroy()
+			return false
+		}
+	}
+
+	m.setMnemonic(mnemonic)
 
 	return true
 }
 
+// ActiveSessionNetwork returns the network of a session that hasn't expired,
+// or an empty string. It doesn't decrypt or renew the session, so the wallet
+// stays locked when the session belongs to another network.
+func (m *Manager) ActiveSessionNetwork() string {
+	if !m.SessionStored() {
+		return ""
+	}
+
+	paths := []string{m.terminalSessionPath}
+	if m.sessionScope != SessionScopeTerminal {
+		paths = append(paths, m.sessionPath)
+	}
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var session SessionData
+		if err := json.Unmarshal(data, &session); err != nil {
+			continue
+		}
+		expired := time.Now().After(session.Expiration)
+		if !session.LastUsed.IsZero() {
+			expired = time.Since(session.LastUsed) > m.sessionTimeout
+		}
+		if !expired {
+			return session.Network
+		}
+	}
+	return ""
+}
+
 // clearSession removes the current session
 func (m *Manager) clearSession() {
+	clearMemorySession()
+	if m.sessionStorage == config.SessionStorageAgent {
+		m.callAgent(agentRequest{Op: agentOpLock})
+	}
 	os.Remove(m.sessionPath)
+	if m.terminalSessionPath != "" {
+		os.Remove(m.terminalSessionPath)
+	}
 }
 
 // Initialize creates a new wallet with a fresh mnemonic
@@ -187,6 +401,11 @@ func (m *Manager) Initialize(password string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create vault: %w", err)
 	}
+	secure := crypto.NewSecureString(mnemonic)
+	if err := vault.EnsureAuditKey(secure); err != nil {
+		secure.Destroy()
+		return err
+	}
 
 	// Ensure directory exists
 	dir := filepath.Dir(m.vaultPath)
@@ -196,13 +415,12 @@ func (m *Manager) Initialize(password string) error {
 
 	// Save vault
 	if err := m.saveVault(vault); err != nil {
+		secure.Destroy()
 		return fmt.Errorf("failed to save vault: %w", err)
 	}
 
 	m.vault = vault
-	m.mnemonic = mnemonic
-	m.password = password
-	m.unlocked = true
+	m.setMnemonic(secure)
 
 	// Create session
 	if err := m.createSession(); err != nil {
@@ -227,6 +445,11 @@ func (m *Manager) ImportFromMnemonic(mnemonic, password string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create vault: %w", err)
 	}
+	secure := crypto.NewSecureString(mnemonic)
+	if err := vault.EnsureAuditKey(secure); err != nil {
+		secure.Destroy()
+		return err
+	}
 
 	// Ensure directory exists
 	dir := filepath.Dir(m.vaultPath)
@@ -236,13 +459,12 @@ func (m *Manager) ImportFromMnemonic(mnemonic, password string) error {
 
 	// Save vault
 	if err := m.saveVault(vault); err != nil {
+		secure.Destroy()
 		return fmt.Errorf("failed to save vault: %w", err)
 	}
 
 	m.vault = vault
-	m.mnemonic = mnemonic
-	m.password = password
-	m.unlocked = true
+	m.setMnemonic(secure)
 
 	// Create session
 	if err := m.createSession(); err != nil {
@@ -257,8 +479,9 @@ func (m *Manager) Unlock(password string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// First try to load existing session
-	if m.loadSession() {
+	// First try to load existing session, without a stored one the password
+	// is checked right away
+	if m.SessionStored() && m.loadSession() {
 		return nil
 	}
 
@@ -271,20 +494,20 @@ func (m *Manager) Unlock(password string) error {
 		m.vault = vault
 	}
 
-	// Validate password
-	if !m.vault.ValidatePassword(password) {
+	// Decrypting fails with a wrong password
+	mnemonic, err := m.vault.DecryptSecure(password)
+	if err != nil {
+		m.recordAudit(AuditEntry{Event: AuditUnlock, Result: AuditFailed, Detail: "invalid password"}, nil)
 		return fmt.Errorf("invalid password")
 	}
-
-	// Decrypt mnemonic
-	mnemonic, err := m.vault.Decrypt(password)
-	if err != nil {
-		return fmt.Errorf("failed to decrypt vault: %w", err)
+	if m.vault.IsPrimaryPassword(password) {
+		if err := m.ensureAuditKey(mnemonic); err != nil {
+			slog.Warn("failed to create audit key", "error", err)
+		}
 	}
+	m.recordAudit(AuditEntry{Event: AuditUnlock, Result: AuditOK}, mnemonic)
 
-	m.mnemonic = mnemonic
-	m.password = password
-	m.unlocked = true
+	m.setMnemonic(mnemonic)
 
 	// Create session
 	if err := m.createSession(); err != nil {
@@ -299,9 +522,7 @@ func (m *Manager) Lock() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.unlocked = false
-	m.mnemonic = ""
-	m.password = ""
+	m.setMnemonic(nil)
 
 	// Clear session
 	m.clearSession()
@@ -313,7 +534,7 @@ func (m *Manager) IsUnlocked() bool {
 	defer m.mu.Unlock()
 
 	// If already unlocked in memory, return true
-	if m.unlocked && m.mnemonic != "" {
+	if m.unlocked && m.mnemonic != nil {
 		return true
 	}
 
@@ -321,39 +542,69 @@ func (m *Manager) IsUnlocked() bool {
 	return m.loadSession()
 }
 
-// GetMnemonic returns the current mnemonic (only if unlocked)
-func (m *Manager) GetMnemonic() (string, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+// WithMnemonic calls use with the mnemonic of the unlocked wallet, for
+// showing or exporting it. The bytes are a copy in secure memory that is
+// wiped when use returns, so they must not be kept.
+func (m *Manager) WithMnemonic(use func(mnemonic []byte) error) error {
+	if err := m.rlockUnlocked(); err != nil {
+		return err
+	}
+	mnemonic := m.mnemonic.Clone()
+	m.mu.RUnlock()
+	defer mnemonic.Destroy()
+
+	return use(mnemonic.Bytes())
+}
+
+// setMnemonic replaces the mnemonic, destroying the previous one, and
+// unlocks the wallet, or locks it for nil
+func (m *Manager) setMnemonic(mnemonic *crypto.SecureBuffer) {
+	if m.mnemonic != nil {
+		m.mnemonic.Destroy()
+	}
+	m.mnemonic = mnemonic
+	m.unlocked = mnemonic != nil
+}
 
-	// Check if already unlocked
-	if m.unlocked && m.mnemonic != "" {
-		return m.mnemonic, nil
+// rlockUnlocked takes the read lock of an unlocked wallet. A stored session
+// is loaded under the write lock first, as loading it replaces the mnemonic
+// that readers use. The caller releases the read lock unless ErrLocked is
+// returned.
+func (m *Manager) rlockUnlocked() error {
+	m.mu.RLock()
+	if m.unlocked {
+		return nil
 	}
+	m.mu.RUnlock()
 
-	// Try to load session
-	if !m.loadSession() {
-		return "", fmt.Errorf("wallet is locked")
+	m.mu.Lock()
+	// Another goroutine may have loaded the session meanwhile
+	if !m.unlocked && !m.loadSession() {
+		m.mu.Unlock()
+		return ErrLocked
 	}
+	m.mu.Unlock()
 
-	return m.mnemonic, nil
+	// The wallet may have been locked again before the read lock is taken
+	m.mu.RLock()
+	if !m.unlocked {
+		m.mu.RUnlock()
+		return ErrLocked
+	}
+	return nil
 }
 
 // GetEthereumKey returns the Ethereum private key
 func (m *Manager) GetEthereumKey() (*ecdsa.PrivateKey, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	// Check if already unlocked
-	if !m.unlocked {
-		// Try to load session
-		if !m.loadSession() {
-			return nil, fmt.Errorf("wallet is locked")
-		}
+	if err := m.rlockUnlocked(); err != nil {
+		return nil, err
 	}
+	defer m.mu.RUnlock()
 
 	// Derive seed from mnemonic
-	seed := bip39.NewSeed(m.mnemonic, "")
+	seedBuffer := crypto.MnemonicSeed(m.mnemonic)
+	defer seedBuffer.Destroy()
+	seed := seedBuffer.Bytes()
 
 	// Choose derivation path based on network
 	derivationPath := EthDerivationPath
@@ -388,75 +639,183 @@ func (m *Manager) GetEthereumAddress() (common.Address, error) {
 	return address, nil
 }
 
-// GetBitcoinKey returns the Bitcoin private key
+// BitcoinAddressType returns the Bitcoin address type in use (p2wpkh or p2tr)
+func (m *Manager) BitcoinAddressType() string {
+	return m.btcAddressType
+}
+
+// SetBitcoinAddressType overrides the configured Bitcoin address type
+func (m *Manager) SetBitcoinAddressType(addressType string) error {
+	if addressType != config.BitcoinAddressP2WPKH && addressType != config.BitcoinAddressP2TR {
+		return fmt.Errorf("invalid address type: %s. Use '%s' or '%s'", addressType, config.BitcoinAddressP2WPKH, config.BitcoinAddressP2TR)
+	}
+	m.btcAddressType = addressType
+	return nil
+}
+
+// BitcoinAccount is one Bitcoin address of the wallet and its signing key
+type BitcoinAccount struct {
+	Type    string // one of the config.BitcoinAddress* types
+	Path    string
+	Address btcutil.Address
+	Key     *btcec.PrivateKey // for Taproot the untweaked internal key
+}
+
+// bitcoinAccountTypes are all address types derived from the seed. Older
+// wallets may have received funds on legacy and nested SegWit addresses.
+var bitcoinAccountTypes = []string{
+	config.BitcoinAddressP2WPKH,
+	config.BitcoinAddressP2TR,
+	config.BitcoinAddressP2SHP2WPKH,
+	config.BitcoinAddressP2PKH,
+}
+
+// GetBitcoinKey returns the Bitcoin private key of the address type in use.
+// For Taproot this is the internal key, which is tweaked when signing.
 func (m *Manager) GetBitcoinKey() (*btcec.PrivateKey, error) {
-	// Bitcoin is only supported in mainnet
-	if m.network == NetworkTestnet {
-		return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
+	account, err := m.getBitcoinAccount(m.btcAddressType)
+	if err != nil {
+		return nil, err
 	}
+	return account.Key, nil
+}
 
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+// GetBitcoinAddress returns the Bitcoin address
+func (m *Manager) GetBitcoinAddress() (btcutil.Address, error) {
+	account, err := m.getBitcoinAccount(m.btcAddressType)
+	if err != nil {
+		return nil, err
+	}
+	return account.Address, nil
+}
 
-	// Check if already unlocked
-	if !m.unlocked {
-		// Try to load session
-		if !m.loadSession() {
-			return nil, fmt.Errorf("wallet is locked")
+// GetBitcoinAccounts returns the address of every Bitcoin address type, the
+// type in use first, followed by the change addresses and the addresses found
+// by discovery. Funds on any of them belong to the wallet.
+func (m *Manager) GetBitcoinAccounts() ([]BitcoinAccount, error) {
+	types := []string{m.btcAddressType}
+	for _, addressType := range bitcoinAccountTypes {
+		if addressType != m.btcAddressType {
+			types = append(types, addressType)
 		}
 	}
 
-	// Derive seed from mnemonic
-	seed := bip39.NewSeed(m.mnemonic, "")
+	accounts := make([]BitcoinAccount, 0, len(types))
+	for _, addressType := range types {
+		account, err := m.getBitcoinAccount(addressType)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *account)
+	}
 
-	// Derive Bitcoin key
-	key, err := deriveBitcoinKey(seed, BtcDerivationPath)
+	change, err := m.bitcoinChangeAccounts()
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive Bitcoin key: %w", err)
+		return nil, err
 	}
+	accounts = append(accounts, change...)
 
-	return key, nil
+	discovered, err := m.discoveredBitcoinAccounts()
+	if err != nil {
+		return nil, err
+	}
+	return append(accounts, discovered...), nil
 }
 
-// GetBitcoinAddress returns the Bitcoin address
-func (m *Manager) GetBitcoinAddress() (btcutil.Address, error) {
-	// Bitcoin is only supported in mainnet
-	if m.network == NetworkTestnet {
+// getBitcoinAccount derives the key and address of a Bitcoin address type
+func (m *Manager) getBitcoinAccount(addressType string) (*BitcoinAccount, error) {
+	return m.deriveBitcoinAccount(addressType, bitcoinAccountPath(addressType))
+}
+
+// bitcoinAccountPath returns the derivation path of the first address of a
+// Bitcoin address type
+func bitcoinAccountPath(addressType string) string {
+	// Legacy and native SegWit share the m/44' key for compatibility with
+	// addresses created by earlier versions
+	switch addressType {
+	case config.BitcoinAddressP2TR:
+		return BtcTaprootPath
+	case config.BitcoinAddressP2SHP2WPKH:
+		return BtcNestedSegwitPath
+	}
+	return BtcDerivationPath
+}
+
+// deriveBitcoinAccount derives the key and address of a Bitcoin address type
+// at a derivation path
+func (m *Manager) deriveBitcoinAccount(addressType, path string) (*BitcoinAccount, error) {
+	// Bitcoin is only supported in mainnet and regtest
+	if !m.BitcoinSupported() {
 		return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
 	}
 
-	key, err := m.GetBitcoinKey()
+	if err := m.rlockUnlocked(); err != nil {
+		return nil, err
+	}
+	defer m.mu.RUnlock()
+
+	// Derive seed from mnemonic
+	seedBuffer := crypto.MnemonicSeed(m.mnemonic)
+	defer seedBuffer.Destroy()
+	return bitcoinAccountFromSeed(seedBuffer.Bytes(), addressType, path)
+}
+
+// bitcoinAccountFromSeed derives the key and address of a Bitcoin address
+// type at a derivation path from seed
+func bitcoinAccountFromSeed(seed []byte, addressType, path string) (*BitcoinAccount, error) {
+	key, err := deriveBitcoinKey(seed, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive Bitcoin key: %w", err)
+	}
+
+	address, err := bitcoinAddress(addressType, key.PubKey())
 	if err != nil {
 		return nil, err
 	}
 
-	publicKey := key.PubKey()
+	return &BitcoinAccount{Type: addressType, Path: path, Address: address, Key: key}, nil
+}
 
-	// Use native SegWit (bech32) address format for better compatibility with modern APIs
-	witnessProg := btcutil.Hash160(publicKey.SerializeCompressed())
-	address, err := btcutil.NewAddressWitnessPubKeyHash(witnessProg, &chaincfg.MainNetParams)
+// bitcoinAddress returns the mainnet address of a public key for a Bitcoin
+// address type
+func bitcoinAddress(addressType string, publicKey *btcec.PublicKey) (btcutil.Address, error) {
+	pubKeyHash := btcutil.Hash160(publicKey.SerializeCompressed())
+
+	params := config.ActiveNetwork().BitcoinParams()
+	var address btcutil.Address
+	var err error
+	switch addressType {
+	case config.BitcoinAddressP2TR:
+		// Taproot key path address (bech32m)
+		outputKey := txscript.ComputeTaprootKeyNoScript(publicKey)
+		address, err = btcutil.NewAddressTaproot(schnorr.SerializePubKey(outputKey), params)
+	case config.BitcoinAddressP2SHP2WPKH:
+		// SegWit wrapped in P2SH, the redeem script is the witness program
+		redeemScript := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, pubKeyHash...)
+		address, err = btcutil.NewAddressScriptHash(redeemScript, params)
+	case config.BitcoinAddressP2PKH:
+		address, err = btcutil.NewAddressPubKeyHash(pubKeyHash, params)
+	default:
+		// Use native SegWit (bech32) address format for better compatibility with modern APIs
+		address, err = btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, params)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Bitcoin address: %w", err)
 	}
-
 	return address, nil
 }
 
 // GetSolanaKey returns the Solana private key
 func (m *Manager) GetSolanaKey() (solana.PrivateKey, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	// Check if already unlocked
-	if !m.unlocked {
-		// Try to load session
-		if !m.loadSession() {
-			return nil, fmt.Errorf("wallet is locked")
-		}
+	if err := m.rlockUnlocked(); err != nil {
+		return nil, err
 	}
+	defer m.mu.RUnlock()
 
 	// Derive seed from mnemonic
-	seed := bip39.NewSeed(m.mnemonic, "")
+	seedBuffer := crypto.MnemonicSeed(m.mnemonic)
+	defer seedBuffer.Destroy()
+	seed := seedBuffer.Bytes()
 
 	// Choose derivation path based on network
 	derivationPath := SolDerivationPath
@@ -483,14 +842,31 @@ func (m *Manager) GetSolanaAddress() (solana.PublicKey, error) {
 	return key.PublicKey(), nil
 }
 
-// saveVault saves the vault to disk
+// saveVault saves the vault to disk. It is written to a temporary file that
+// replaces the vault, so an interrupted write never leaves a truncated one.
 func (m *Manager) saveVault(vault *crypto.Vault) error {
 	data, err := json.Marshal(vault)
 	if err != nil {
 		return fmt.Errorf("failed to marshal vault: %w", err)
 	}
 
-	if err := os.WriteFile(m.vaultPath, data, 0600); err != nil {
+	file, err := os.CreateTemp(filepath.Dir(m.vaultPath), ".vault-*")
+	if err != nil {
+		return fmt.Errorf("failed to write vault file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write vault file: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write vault file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write vault file: %w", err)
+	}
+	if err := os.Rename(file.Name(), m.vaultPath); err != nil {
 		return fmt.Errorf("failed to write vault file: %w", err)
 	}
 
@@ -512,17 +888,62 @@ func (m *Manager) loadVault() (*crypto.Vault, error) {
 	return &vault, nil
 }
 
+// decryptVault reads the vault and decrypts the mnemonic with password
+func (m *Manager) decryptVault(password string) (*crypto.SecureBuffer, error) {
+	vault, err := m.loadVault()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vault: %w", err)
+	}
+	mnemonic, err := vault.DecryptSecure(password)
+	if err != nil {
+		m.recordAudit(AuditEntry{Event: AuditUnlock, Result: AuditFailed, Detail: "invalid password"}, nil)
+		return nil, fmt.Errorf("invalid password")
+	}
+	if vault.IsPrimaryPassword(password) {
+		if err := m.ensureAuditKey(mnemonic); err != nil {
+			slog.Warn("failed to create audit key", "error", err)
+		}
+	}
+	m.recordAudit(AuditEntry{Event: AuditUnlock, Result: AuditOK}, mnemonic)
+	return mnemonic, nil
+}
+
+// CheckPassword returns an error unless password opens the vault. The decoy
+// password is accepted as well, refusing it would reveal that it isn't the
+// main one.
+func (m *Manager) CheckPassword(password string) error {
+	vault, err := m.loadVault()
+	if err != nil {
+		return fmt.Errorf("failed to load vault: %w", err)
+	}
+	if !vault.ValidatePassword(password) {
+		return fmt.Errorf("invalid password")
+	}
+	return nil
+}
+
 // VaultExists checks if a vault file exists
 func (m *Manager) VaultExists() bool {
 	_, err := os.Stat(m.vaultPath)
 	return err == nil
 }
 
+// VaultPath returns the location of the vault file
+func (m *Manager) VaultPath() string {
+	return m.vaultPath
+}
+
 // IsTestnet returns true if the wallet is in testnet mode
 func (m *Manager) IsTestnet() bool {
 	return m.network == NetworkTestnet
 }
 
+// BitcoinSupported returns true if the current network has Bitcoin, mainnet
+// or a regtest node
+func (m *Manager) BitcoinSupported() bool {
+	return config.ActiveNetwork().HasBitcoin()
+}
+
 // GetCurrentNetwork returns the current network (mainnet or testnet)
 func (m *Manager) GetCurrentNetwork() string {
 	return m.network
@@ -0,0 +1,114 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MigrationFiles are the metadata files moved by 'odyssey migrate'. Keys
+// are never part of a migration, the vault is restored from the mnemonic.
+var MigrationFiles = []string{
+	"config.json",
+	"network.txt",
+	"watchlist.json",
+	"contracts.json",
+	"multisig.json",
+	"snapshots.json",
+}
+
+// BackupFiles are the files of the backup written before a wallet is
+// deleted: the vault, still encrypted with the wallet password, the
+// addresses the wallet found and the metadata files
+var BackupFiles = append([]string{
+	"wallet.vault",
+	"discovered.json",
+	"change.json",
+}, MigrationFiles...)
+
+// MigrationBundle holds the metadata files of a wallet directory
+type MigrationBundle struct {
+	Version   int               `json:"version"`
+	CreatedAt time.Time         `json:"created_at"`
+	Files     map[string][]byte `json:"files"`
+}
+
+// ExportMetadata collects the metadata files that exist into a bundle
+func (m *Manager) ExportMetadata() (*MigrationBundle, error) {
+	return m.exportFiles(MigrationFiles)
+}
+
+// exportFiles collects the files of names that exist into a bundle
+func (m *Manager) exportFiles(names []string) (*MigrationBundle, error) {
+	bundle := &MigrationBundle{Version: 1, CreatedAt: time.Now().UTC(), Files: make(map[string][]byte)}
+
+	dir := filepath.Dir(m.vaultPath)
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		bundle.Files[name] = data
+	}
+
+	return bundle, nil
+}
+
+// ImportMetadata writes the files of a bundle to the wallet directory.
+// Existing files are kept unless overwrite is set, an existing vault is
+// always kept. It returns the names of the imported and skipped files.
+func (m *Manager) ImportMetadata(bundle *MigrationBundle, overwrite bool) ([]string, []string, error) {
+	if bundle.Version != 1 {
+		return nil, nil, fmt.Errorf("unsupported bundle version: %d", bundle.Version)
+	}
+
+	dir := filepath.Dir(m.vaultPath)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Check everything before writing anything
+	for name, data := range bundle.Files {
+		if !isMigrationFile(name) {
+			return nil, nil, fmt.Errorf("bundle contains unexpected file: %s", name)
+		}
+		if (filepath.Ext(name) == ".json" || name == "wallet.vault") && !json.Valid(data) {
+			return nil, nil, fmt.Errorf("bundle contains an invalid %s", name)
+		}
+	}
+
+	var imported, skipped []string
+	for _, name := range BackupFiles {
+		data, ok := bundle.Files[name]
+		if !ok {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil && (!overwrite || name == "wallet.vault") {
+			skipped = append(skipped, name)
+			continue
+		}
+
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return imported, skipped, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		imported = append(imported, name)
+	}
+
+	return imported, skipped, nil
+}
+
+func isMigrationFile(name string) bool {
+	for _, file := range BackupFiles {
+		if file == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/chinmay1088/odyssey/crypto"
+)
+
+// MultisigCosigner is one key of a multisig wallet, as a BIP-48 account xpub
+type MultisigCosigner struct {
+	Fingerprint string `json:"fingerprint"` // master key fingerprint in hex
+	XPub        string `json:"xpub"`
+}
+
+// MultisigWallet is an m-of-n P2WSH Bitcoin wallet shared with cosigners
+type MultisigWallet struct {
+	Name        string             `json:"name"`
+	Threshold   int                `json:"threshold"`
+	Cosigners   []MultisigCosigner `json:"cosigners"`
+	NextReceive uint32             `json:"next_receive"`
+	NextChange  uint32             `json:"next_change"`
+}
+
+// multisigPath returns the location of the multisig wallet list
+func (m *Manager) multisigPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "multisig.json")
+}
+
+// GetMultisigAccount returns this wallet's BIP-48 multisig account key and
+// the fingerprint of its master key
+func (m *Manager) GetMultisigAccount() (*hdkeychain.ExtendedKey, uint32, error) {
+	// Bitcoin is only supported in mainnet and regtest
+	if !m.BitcoinSupported() {
+		return nil, 0, fmt.Errorf("bitcoin is not supported in testnet mode")
+	}
+
+	if err := m.rlockUnlocked(); err != nil {
+		return nil, 0, err
+	}
+	defer m.mu.RUnlock()
+
+	seedBuffer := crypto.MnemonicSeed(m.mnemonic)
+	defer seedBuffer.Destroy()
+	account, fingerprint, err := deriveAccountKey(seedBuffer.Bytes(), "m/48'/0'/0'/2'")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to derive multisig account: %w", err)
+	}
+
+	return account, fingerprint, nil
+}
+
+// GetMultisigWallets returns all multisig wallets sorted by name
+func (m *Manager) GetMultisigWallets() ([]MultisigWallet, error) {
+	data, err := os.ReadFile(m.multisigPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read multisig wallets: %w", err)
+	}
+
+	var wallets []MultisigWallet
+	if err := json.Unmarshal(data, &wallets); err != nil {
+		return nil, fmt.Errorf("failed to parse multisig wallets: %w", err)
+	}
+
+	sort.SliceStable(wallets, func(i, j int) bool {
+		return wallets[i].Name < wallets[j].Name
+	})
+
+	return wallets, nil
+}
+
+// FindMultisigWallet looks up a multisig wallet by name
+func (m *Manager) FindMultisigWallet(name string) (*MultisigWallet, error) {
+	wallets, err := m.GetMultisigWallets()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, w := range wallets {
+		if strings.EqualFold(w.Name, name) {
+			return &wallets[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no multisig wallet named '%s'. Create one with 'odyssey multisig create'", name)
+}
+
+// SaveMultisigWallet adds a multisig wallet or replaces the one with the same name
+func (m *Manager) SaveMultisigWallet(entry MultisigWallet) error {
+	wallets, err := m.GetMultisigWallets()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, existing := range wallets {
+		if strings.EqualFold(existing.Name, entry.Name) {
+			wallets[i] = entry
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		wallets = append(wallets, entry)
+	}
+
+	return m.saveMultisigWallets(wallets)
+}
+
+// RemoveMultisigWallet removes a multisig wallet
+func (m *Manager) RemoveMultisigWallet(name string) (bool, error) {
+	wallets, err := m.GetMultisigWallets()
+	if err != nil {
+		return false, err
+	}
+
+	kept := wallets[:0]
+	for _, existing := range wallets {
+		if strings.EqualFold(existing.Name, name) {
+			continue
+		}
+		kept = append(kept, existing)
+	}
+
+	if len(kept) == len(wallets) {
+		return false, nil
+	}
+	return true, m.saveMultisigWallets(kept)
+}
+
+// saveMultisigWallets writes the multisig wallet list to disk
+func (m *Manager) saveMultisigWallets(wallets []MultisigWallet) error {
+	path := m.multisigPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(wallets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal multisig wallets: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write multisig wallets: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// nonceTrackingWindow is how long a locally used nonce is trusted over the
+// node. A transaction that hasn't reached the node's pending pool by then was
+// most likely dropped, and its nonce is handed out again.
+const nonceTrackingWindow = time.Hour
+
+// NonceRecord is the next nonce of an address as known from transactions
+// this wallet sent
+type NonceRecord struct {
+	Chain     string    `json:"chain"` // ethereum or an EVM chain name
+	Network   string    `json:"network"`
+	Address   string    `json:"address"`
+	Next      uint64    `json:"next"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// noncesPath returns the location of the nonce tracker
+func (m *Manager) noncesPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "nonces.json")
+}
+
+// getNonces returns the tracked nonces
+func (m *Manager) getNonces() ([]NonceRecord, error) {
+	data, err := os.ReadFile(m.noncesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read nonces: %w", err)
+	}
+
+	var records []NonceRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse nonces: %w", err)
+	}
+	return records, nil
+}
+
+// NextNonce returns the nonce for a new transaction from address. pending is
+// the node's transaction count including its pending pool; transactions sent
+// or queued locally that the node doesn't know yet move it further.
+func (m *Manager) NextNonce(chain, network, address string, pending uint64) (uint64, error) {
+	next := pending
+
+	records, err := m.getNonces()
+	if err != nil {
+		return 0, err
+	}
+	for _, record := range records {
+		if record.Chain == chain && record.Network == network && strings.EqualFold(record.Address, address) &&
+			time.Since(record.UpdatedAt) < nonceTrackingWindow && record.Next > next {
+			next = record.Next
+		}
+	}
+
+	// Broadcasts interrupted or waiting in the retry queue hold their nonce
+	queued, err := m.GetPendingBroadcasts()
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range queued {
+		if entry.Chain == chain && entry.Network == network && strings.EqualFold(entry.From, address) &&
+			entry.Resumable() && entry.Nonce >= next {
+			next = entry.Nonce + 1
+		}
+	}
+
+	return next, nil
+}
+
+// RecordNonce remembers that nonce was used by a transaction from address
+func (m *Manager) RecordNonce(chain, network, address string, nonce uint64) error {
+	records, err := m.getNonces()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	found := false
+	for i := range records {
+		record := &records[i]
+		if record.Chain == chain && record.Network == network && strings.EqualFold(record.Address, address) {
+			if nonce+1 > record.Next || now.Sub(record.UpdatedAt) >= nonceTrackingWindow {
+				record.Next = nonce + 1
+			}
+			record.UpdatedAt = now
+			found = true
+			break
+		}
+	}
+	if !found {
+		records = append(records, NonceRecord{Chain: chain, Network: network, Address: address, Next: nonce + 1, UpdatedAt: now})
+	}
+
+	path := m.noncesPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal nonces: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write nonces: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Pending broadcast states. A transaction is saved as signed before it is
+// first sent, then moves to sent and confirmed, or to queued while a failed
+// broadcast is retried.
+const (
+	PendingSigned    = "signed"    // signed, the broadcast was interrupted before it succeeded
+	PendingQueued    = "queued"    // waiting to be retried
+	PendingSent      = "sent"      // accepted by a node, waiting to be confirmed
+	PendingConfirmed = "confirmed" // included in a block
+	PendingFailed    = "failed"    // rejected by the network
+	PendingExpired   = "expired"   // can no longer be broadcast safely
+)
+
+// confirmedRetention is how long confirmed transactions stay in the queue
+const confirmedRetention = 24 * time.Hour
+
+// PendingBroadcast is a signed transaction tracked from signing until it is
+// confirmed, so an interrupted send can be resumed
+type PendingBroadcast struct {
+	ID           string          `json:"id"`    // transaction hash, txid or signature
+	Chain        string          `json:"chain"` // ethereum, bitcoin or solana
+	Network      string          `json:"network"`
+	SignedTx     string          `json:"signed_tx"`
+	From         string          `json:"from"`
+	To           string          `json:"to"`
+	Amount       string          `json:"amount"`
+	Value        string          `json:"value,omitempty"`         // payments only, amount in the smallest unit
+	Nonce        uint64          `json:"nonce,omitempty"`         // Ethereum only
+	Blockhash    string          `json:"blockhash,omitempty"`     // Solana only
+	NonceAccount string          `json:"nonce_account,omitempty"` // Solana durable nonce only
+	SpendChain   string          `json:"spend_chain,omitempty"`   // chain of the spending limits the payment counts towards
+	SpendAmount  decimal.Decimal `json:"spend_amount"`            // native asset counted once a node accepts it
+	CreatedAt    time.Time       `json:"created_at"`
+	LastAttempt  time.Time       `json:"last_attempt"`
+	Attempts     int             `json:"attempts"`
+	LastError    string          `json:"last_error,omitempty"`
+	Status       string          `json:"status"`
+	ConfirmedAt  time.Time       `json:"confirmed_at,omitempty"`
+}
+
+// Resumable returns true if the transaction hasn't reached the network and
+// can still be broadcast
+func (p *PendingBroadcast) Resumable() bool {
+	return p.Status == PendingSigned || p.Status == PendingQueued
+}
+
+// pendingPath returns the location of the broadcast retry queue
+func (m *Manager) pendingPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "pending.json")
+}
+
+// GetPendingBroadcasts returns all queued broadcasts, oldest first
+func (m *Manager) GetPendingBroadcasts() ([]PendingBroadcast, error) {
+	data, err := os.ReadFile(m.pendingPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read pending broadcasts: %w", err)
+	}
+
+	var pending []PendingBroadcast
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return nil, fmt.Errorf("failed to parse pending broadcasts: %w", err)
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+
+	return pending, nil
+}
+
+// FindPendingBroadcast looks up a queued broadcast by its ID or a unique prefix of it
+func (m *Manager) FindPendingBroadcast(id string) (*PendingBroadcast, error) {
+	pending, err := m.GetPendingBroadcasts()
+	if err != nil {
+		return nil, err
+	}
+
+	var match *PendingBroadcast
+	for i := range pending {
+		if pending[i].ID == id {
+			return &pending[i], nil
+		}
+		if strings.HasPrefix(pending[i].ID, id) {
+			if match != nil {
+				return nil, fmt.Errorf("'%s' matches more than one pending broadcast", id)
+			}
+			match = &pending[i]
+		}
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("no pending broadcast found for '%s'", id)
+	}
+	return match, nil
+}
+
+// SavePendingBroadcast adds a broadcast to the queue or updates it.
+// Transactions confirmed more than a day ago are removed.
+func (m *Manager) SavePendingBroadcast(entry PendingBroadcast) error {
+	pending, err := m.GetPendingBroadcasts()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, existing := range pending {
+		if existing.ID == entry.ID {
+			pending[i] = entry
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		pending = append(pending, entry)
+	}
+
+	cutoff := time.Now().Add(-confirmedRetention)
+	kept := pending[:0]
+	for _, existing := range pending {
+		if existing.Status != PendingConfirmed || existing.ConfirmedAt.After(cutoff) {
+			kept = append(kept, existing)
+		}
+	}
+
+	return m.savePendingBroadcasts(kept)
+}
+
+// RemovePendingBroadcast removes a broadcast from the queue
+func (m *Manager) RemovePendingBroadcast(id string) error {
+	pending, err := m.GetPendingBroadcasts()
+	if err != nil {
+		return err
+	}
+
+	kept := pending[:0]
+	for _, existing := range pending {
+		if existing.ID != id {
+			kept = append(kept, existing)
+		}
+	}
+
+	if len(kept) == len(pending) {
+		return nil
+	}
+	return m.savePendingBroadcasts(kept)
+}
+
+// savePendingBroadcasts writes the broadcast retry queue to disk
+func (m *Manager) savePendingBroadcasts(pending []PendingBroadcast) error {
+	path := m.pendingPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(pending, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending broadcasts: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write pending broadcasts: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// policySpendWindow is the period the daily limit applies to
+const policySpendWindow = 24 * time.Hour
+
+// ErrPolicyViolation is returned for payments the spending policy forbids
+var ErrPolicyViolation = errors.New("blocked by the spending policy")
+
+// ChainLimits are the spending limits of one chain in its native asset, zero
+// is no limit
+type ChainLimits struct {
+	MaxPerTx     decimal.Decimal `json:"max_per_tx"`
+	Daily        decimal.Decimal `json:"daily"`         // sum of the last 24 hours
+	ConfirmAbove decimal.Decimal `json:"confirm_above"` // payments above need an extra confirmation
+}
+
+// IsZero returns true if no limit is set
+func (l ChainLimits) IsZero() bool {
+	return l.MaxPerTx.IsZero() && l.Daily.IsZero() && l.ConfirmAbove.IsZero()
+}
+
+// WhitelistedRecipient is an address on the whitelist (the allow-list) or
+// the deny-list
+type WhitelistedRecipient struct {
+	Chain   string `json:"chain"` // ethereum, bitcoin or solana, EVM chains use ethereum
+	Address string `json:"address"`
+	Label   string `json:"label,omitempty"`
+}
+
+// PolicySpend is a payment counted towards the daily limit
+type PolicySpend struct {
+	Chain   string          `json:"chain"`
+	Network string          `json:"network"`
+	Amount  decimal.Decimal `json:"amount"`
+	SentAt  time.Time       `json:"sent_at"`
+}
+
+// SpendingPolicy limits what the pay command may send
+type SpendingPolicy struct {
+	Limits          map[string]ChainLimits `json:"limits"` // by chain, e.g. ethereum or polygon
+	WhitelistOnly   bool                   `json:"whitelist_only"`
+	ConfirmUnlisted bool                   `json:"confirm_unlisted"` // recipients off the whitelist need the address typed
+	Whitelist       []WhitelistedRecipient `json:"whitelist"`
+	Denylist        []WhitelistedRecipient `json:"denylist"` // never paid, even if whitelisted
+	Spends          []PolicySpend          `json:"spends"`   // payments of the last 24 hours
+}
+
+// PolicyCheck is the outcome of checking a payment against the policy
+type PolicyCheck struct {
+	NeedsConfirmation bool            // the amount is above the confirmation threshold
+	ConfirmAbove      decimal.Decimal // the threshold
+	Unlisted          bool            // the recipient isn't whitelisted and must be typed
+}
+
+// policyPath returns the location of the spending policy
+func (m *Manager) policyPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "policy.json")
+}
+
+// GetSpendingPolicy returns the spending policy, empty if none was set
+func (m *Manager) GetSpendingPolicy() (*SpendingPolicy, error) {
+	policy := &SpendingPolicy{Limits: make(map[string]ChainLimits)}
+
+	data, err := os.ReadFile(m.policyPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return policy, nil
+		}
+		return nil, fmt.Errorf("failed to read spending policy: %w", err)
+	}
+
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse spending policy: %w", err)
+	}
+	if policy.Limits == nil {
+		policy.Limits = make(map[string]ChainLimits)
+	}
+
+	return policy, nil
+}
+
+// SaveSpendingPolicy writes the spending policy to disk
+func (m *Manager) SaveSpendingPolicy(policy *SpendingPolicy) error {
+	for chain, limits := range policy.Limits {
+		if limits.IsZero() {
+			delete(policy.Limits, chain)
+		}
+	}
+	sortRecipients(policy.Whitelist)
+	sortRecipients(policy.Denylist)
+
+	path := m.policyPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal spending policy: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write spending policy: %w", err)
+	}
+
+	return nil
+}
+
+// sortRecipients orders a recipient list by chain and address
+func sortRecipients(list []WhitelistedRecipient) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Chain != list[j].Chain {
+			return list[i].Chain < list[j].Chain
+		}
+		return list[i].Address < list[j].Address
+	})
+}
+
+// IsWhitelisted returns true if payments to address on chain are allowed in
+// whitelist-only mode. Bitcoin and Solana addresses are case sensitive.
+func (p *SpendingPolicy) IsWhitelisted(chain, address string) bool {
+	return findRecipient(p.Whitelist, chain, address) >= 0
+}
+
+// IsDenied returns true if address on chain is on the deny-list
+func (p *SpendingPolicy) IsDenied(chain, address string) bool {
+	return findRecipient(p.Denylist, chain, address) >= 0
+}
+
+// findRecipient returns the index of address on chain in list, or -1
+func findRecipient(list []WhitelistedRecipient, chain, address string) int {
+	for i, entry := range list {
+		if entry.Chain != chain {
+			continue
+		}
+		if entry.Address == address || (chain == "ethereum" && strings.EqualFold(entry.Address, address)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// SpentToday returns the sum of the payments on chain and network in the
+// last 24 hours
+func (p *SpendingPolicy) SpentToday(chain, network string, now time.Time) decimal.Decimal {
+	total := decimal.Zero
+	for _, spend := range p.Spends {
+		if spend.Chain == chain && spend.Network == network && now.Sub(spend.SentAt) < policySpendWindow {
+			total = total.Add(spend.Amount)
+		}
+	}
+	return total
+}
+
+// CheckPayment checks a payment of amount in the native asset of chain to
+// recipient, whitelisted under whitelistChain. It returns an error if the
+// policy forbids it. Without a recipient, e.g. for a swap through a program
+// that pays back to the wallet, only the limits are checked.
+func (p *SpendingPolicy) CheckPayment(chain, whitelistChain, network string, amount decimal.Decimal, recipient string) (*PolicyCheck, error) {
+	if p.IsDenied(whitelistChain, recipient) {
+		return nil, fmt.Errorf("%w: %s is on the deny-list", ErrPolicyViolation, recipient)
+	}
+	whitelisted := recipient == "" || p.IsWhitelisted(whitelistChain, recipient)
+	if p.WhitelistOnly && !whitelisted {
+		return nil, fmt.Errorf("%w: %s is not on the whitelist", ErrPolicyViolation, recipient)
+	}
+
+	limits := p.Limits[chain]
+	if !limits.MaxPerTx.IsZero() && amount.GreaterThan(limits.MaxPerTx) {
+		return nil, fmt.Errorf("%w: %s is above the limit of %s per transaction on %s", ErrPolicyViolation, amount, limits.MaxPerTx, chain)
+	}
+	if !limits.Daily.IsZero() {
+		spent := p.SpentToday(chain, network, time.Now())
+		if spent.Add(amount).GreaterThan(limits.Daily) {
+			return nil, fmt.Errorf("%w: %s would exceed the daily limit of %s on %s, %s was sent in the last 24 hours", ErrPolicyViolation, amount, limits.Daily, chain, spent)
+		}
+	}
+
+	check := &PolicyCheck{ConfirmAbove: limits.ConfirmAbove}
+	check.NeedsConfirmation = !limits.ConfirmAbove.IsZero() && amount.GreaterThan(limits.ConfirmAbove)
+	check.Unlisted = p.ConfirmUnlisted && !whitelisted
+	return check, nil
+}
+
+// RecordPolicySpend counts a payment towards the daily limit of its chain,
+// if it has one, and drops payments older than a day
+func (m *Manager) RecordPolicySpend(chain, network string, amount decimal.Decimal) error {
+	policy, err := m.GetSpendingPolicy()
+	if err != nil {
+		return err
+	}
+	if policy.Limits[chain].Daily.IsZero() {
+		return nil
+	}
+
+	now := time.Now()
+	var kept []PolicySpend
+	for _, spend := range policy.Spends {
+		if now.Sub(spend.SentAt) < policySpendWindow {
+			kept = append(kept, spend)
+		}
+	}
+	policy.Spends = append(kept, PolicySpend{Chain: chain, Network: network, Amount: amount, SentAt: now})
+
+	return m.SaveSpendingPolicy(policy)
+}
@@ -0,0 +1,104 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// maxRecentSendAge is how long sends are remembered, the longest duplicate
+// window allowed
+const maxRecentSendAge = 24 * time.Hour
+
+// RecentSend is the fingerprint of a payment this wallet broadcast or queued,
+// used to catch the same payment sent twice
+type RecentSend struct {
+	ID      string    `json:"id"` // transaction hash, txid or signature
+	Chain   string    `json:"chain"`
+	Network string    `json:"network"`
+	To      string    `json:"to"`
+	Amount  string    `json:"amount"` // as shown to the user
+	Value   string    `json:"value"`  // in the smallest unit of the asset, e.g. wei
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// recentSendsPath returns the location of the recent sends
+func (m *Manager) recentSendsPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "sends.json")
+}
+
+// getRecentSends returns the remembered sends, oldest first
+func (m *Manager) getRecentSends() ([]RecentSend, error) {
+	data, err := os.ReadFile(m.recentSendsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read recent sends: %w", err)
+	}
+
+	var sends []RecentSend
+	if err := json.Unmarshal(data, &sends); err != nil {
+		return nil, fmt.Errorf("failed to parse recent sends: %w", err)
+	}
+	return sends, nil
+}
+
+// RecordRecentSend remembers a send, a send already known by its ID keeps
+// its time. Sends older than a day are forgotten.
+func (m *Manager) RecordRecentSend(send RecentSend) error {
+	sends, err := m.getRecentSends()
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-maxRecentSendAge)
+	kept := sends[:0]
+	for _, known := range sends {
+		if known.ID == send.ID {
+			send.SentAt = known.SentAt
+			continue
+		}
+		if known.SentAt.After(cutoff) {
+			kept = append(kept, known)
+		}
+	}
+	if send.SentAt.IsZero() {
+		send.SentAt = time.Now()
+	}
+	sends = append(kept, send)
+
+	path := m.recentSendsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	data, err := json.MarshalIndent(sends, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal recent sends: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write recent sends: %w", err)
+	}
+	return nil
+}
+
+// FindRecentSend returns the latest send of exactly value, in the smallest
+// unit of the asset, to the same address on the same chain and network within
+// window, nil if there is none
+func (m *Manager) FindRecentSend(chain, network, to, value string, window time.Duration) (*RecentSend, error) {
+	sends, err := m.getRecentSends()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-window)
+	for i := len(sends) - 1; i >= 0; i-- {
+		send := sends[i]
+		if send.Chain == chain && send.Network == network && send.To == to && send.Value == value && send.SentAt.After(cutoff) {
+			return &send, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/crypto"
+)
+
+// machineIDPaths are checked in order for a stable per-machine identifier
+var machineIDPaths = []string{
+	"/etc/machine-id",
+	"/var/lib/dbus/machine-id",
+}
+
+// sessionKey returns the key used to encrypt session files. It combines a
+// random secret stored next to the vault with an identifier of this machine,
+// so a session file copied elsewhere, even along with the secret, can't be
+// decrypted.
+func (m *Manager) sessionKey() ([]byte, error) {
+	secret, err := m.loadSessionSecret()
+	if err != nil {
+		return nil, err
+	}
+	defer crypto.ClearBytes(secret)
+
+	hash := sha256.New()
+	hash.Write([]byte("odyssey-session-v1"))
+	hash.Write(secret)
+	hash.Write([]byte(machineID()))
+	return hash.Sum(nil), nil
+}
+
+// loadSessionSecret reads the session secret, creating it on first use
+func (m *Manager) loadSessionSecret() ([]byte, error) {
+	path := filepath.Join(filepath.Dir(m.vaultPath), "session.key")
+
+	secret, err := os.ReadFile(path)
+	if err == nil && len(secret) == crypto.KeyLen {
+		return secret, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read session key: %w", err)
+	}
+
+	// Missing or damaged, existing sessions become unreadable and expire
+	secret = make([]byte, crypto.KeyLen)
+	if _, err := io.ReadFull(rand.Reader, secret); err != nil {
+		return nil, fmt.Errorf("failed to generate session key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, secret, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write session key: %w", err)
+	}
+
+	return secret, nil
+}
+
+// machineID returns an identifier of this machine, or the hostname when the
+// platform has no machine ID
+func machineID() string {
+	for _, path := range machineIDPaths {
+		if data, err := os.ReadFile(path); err == nil {
+			if id := strings.TrimSpace(string(data)); id != "" {
+				return id
+			}
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	return hostname
+}
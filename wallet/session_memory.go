@@ -0,0 +1,103 @@
+package wallet
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/crypto"
+)
+
+// noSession is set by --no-session, see SetNoSession
+var noSession bool
+
+// SetNoSession keeps the keys of managers created afterwards in memory only,
+// as if session.storage was none
+func SetNoSession(enabled bool) {
+	noSession = enabled
+}
+
+// passwordPrompt asks for the wallet password when keys are needed and no
+// session is stored, nil if the process can't ask
+var passwordPrompt func() (string, error)
+
+// SetPasswordPrompt sets how the wallet password is asked for when sessions
+// aren't stored
+func SetPasswordPrompt(prompt func() (string, error)) {
+	passwordPrompt = prompt
+}
+
+// memorySession holds the mnemonic this process unlocked when sessions aren't
+// stored, shared by every manager so the password is asked for once
+var memorySession struct {
+	sync.Mutex
+	mnemonic *crypto.SecureBuffer
+	asked    bool // the password was asked for, whether or not it was right
+}
+
+// SessionStored returns false if unlocked keys are never written to disk,
+// they are kept by the agent or asked for by every command
+func (m *Manager) SessionStored() bool {
+	return m.sessionStorage == config.SessionStorageFile
+}
+
+// SessionStorage returns where unlocked keys are kept between commands
+func (m *Manager) SessionStorage() string {
+	return m.sessionStorage
+}
+
+// rememberMemorySession keeps the mnemonic for the rest of the process and
+// removes session files left from before sessions stopped being stored
+func (m *Manager) rememberMemorySession(mnemonic *crypto.SecureBuffer) {
+	os.Remove(m.sessionPath)
+	if m.terminalSessionPath != "" {
+		os.Remove(m.terminalSessionPath)
+	}
+
+	memorySession.Lock()
+	defer memorySession.Unlock()
+	if memorySession.mnemonic != nil {
+		memorySession.mnemonic.Destroy()
+	}
+	memorySession.mnemonic = mnemonic.Clone()
+}
+
+// loadMemorySession unlocks the wallet with the mnemonic of this process,
+// asking for the password the first time
+func (m *Manager) loadMemorySession() bool {
+	memorySession.Lock()
+	defer memorySession.Unlock()
+
+	if memorySession.mnemonic == nil {
+		if passwordPrompt == nil || memorySession.asked {
+			return false
+		}
+		memorySession.asked = true
+
+		password, err := passwordPrompt()
+		if err != nil {
+			slog.Warn("wallet stays locked", "error", err)
+			return false
+		}
+		mnemonic, err := m.decryptVault(password)
+		if err != nil {
+			slog.Warn("wallet stays locked", "error", err)
+			return false
+		}
+		memorySession.mnemonic = mnemonic
+	}
+
+	m.setMnemonic(memorySession.mnemonic.Clone())
+	return true
+}
+
+// clearMemorySession forgets the mnemonic of this process
+func clearMemorySession() {
+	memorySession.Lock()
+	defer memorySession.Unlock()
+	if memorySession.mnemonic != nil {
+		memorySession.mnemonic.Destroy()
+		memorySession.mnemonic = nil
+	}
+}
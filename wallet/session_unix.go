@@ -0,0 +1,29 @@
+//go:build !windows
+
+package wallet
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalSessionKey identifies the terminal this process was started from.
+// Every process launched from the same terminal shares the shell's session ID,
+// and the controlling TTY device guards against reused session IDs.
+func terminalSessionKey() (string, bool) {
+	sid, err := unix.Getsid(0)
+	if err != nil {
+		return "", false
+	}
+
+	key := fmt.Sprintf("sid-%d", sid)
+
+	var stat unix.Stat_t
+	if err := unix.Fstat(int(os.Stdin.Fd()), &stat); err == nil && stat.Mode&unix.S_IFMT == unix.S_IFCHR {
+		key += fmt.Sprintf("-tty-%d", stat.Rdev)
+	}
+
+	return key, true
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package wallet
+
+import (
+	"fmt"
+	"os"
+)
+
+// terminalSessionKey identifies the terminal this process was started from.
+// Windows consoles have no session ID, so the parent shell's PID is used instead.
+func terminalSessionKey() (string, bool) {
+	return fmt.Sprintf("ppid-%d", os.Getppid()), true
+}
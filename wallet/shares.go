@@ -0,0 +1,221 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Share layout: identifier (2) | threshold (1) | index (1) | length (1) | value | checksum (4)
+const (
+	shareHeaderSize   = 5
+	shareChecksumSize = 4
+	shareWordBits     = 11
+)
+
+// RecoveryShare is one share of a recovery phrase split with SplitRecoveryPhrase
+type RecoveryShare struct {
+	ID        uint16 // random identifier shared by all shares of one split
+	Threshold int
+	Index     int
+	Value     []byte
+}
+
+// SplitRecoveryPhrase splits a recovery phrase into share phrases. Any
+// threshold of them recover the phrase with CombineRecoveryShares.
+func SplitRecoveryPhrase(mnemonic []byte, shares, threshold int) ([]string, error) {
+	entropy, err := mnemonicEntropy(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recovery phrase: %w", err)
+	}
+	defer crypto.ClearBytes(entropy)
+
+	if threshold < 2 {
+		return nil, fmt.Errorf("threshold must be at least 2")
+	}
+
+	parts, err := crypto.SplitSecret(entropy, shares, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate share identifier: %w", err)
+	}
+
+	phrases := make([]string, len(parts))
+	for i, part := range parts {
+		phrases[i] = encodeRecoveryShare(RecoveryShare{
+			ID:        binary.BigEndian.Uint16(id[:]),
+			Threshold: threshold,
+			Index:     int(part.X),
+			Value:     part.Y,
+		})
+	}
+
+	return phrases, nil
+}
+
+// mnemonicEntropy returns the entropy of a mnemonic like
+// bip39.EntropyFromMnemonic, without copying the mnemonic into a string
+func mnemonicEntropy(mnemonic []byte) ([]byte, error) {
+	words := bytes.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, bip39.ErrInvalidMnemonic
+	}
+
+	// The English word list is sorted
+	wordList := bip39.GetWordList()
+	bits := make([]byte, (len(words)*shareWordBits+7)/8)
+	defer crypto.ClearBytes(bits)
+	for i, word := range words {
+		index := sort.Search(len(wordList), func(j int) bool { return wordList[j] >= string(word) })
+		if index == len(wordList) || wordList[index] != string(word) {
+			return nil, bip39.ErrInvalidMnemonic
+		}
+		for bit := 0; bit < shareWordBits; bit++ {
+			if index&(1<<(shareWordBits-1-bit)) != 0 {
+				position := i*shareWordBits + bit
+				bits[position/8] |= 0x80 >> (position % 8)
+			}
+		}
+	}
+
+	// 32 bits of entropy per 3 words, followed by a checksum of 1 bit per 3
+	size, checksumBits := len(words)*4/3, len(words)/3
+	entropy := make([]byte, size)
+	copy(entropy, bits)
+	hash := sha256.Sum256(entropy)
+	if hash[0]>>(8-checksumBits) != bits[size]>>(8-checksumBits) {
+		crypto.ClearBytes(entropy)
+		return nil, bip39.ErrChecksumIncorrect
+	}
+	return entropy, nil
+}
+
+// ParseRecoveryShare decodes and verifies a share phrase
+func ParseRecoveryShare(phrase string) (*RecoveryShare, error) {
+	words := strings.Fields(strings.ToLower(phrase))
+	if len(words) == 0 {
+		return nil, fmt.Errorf("share is empty")
+	}
+
+	// Unpack 11 bits per word
+	data := make([]byte, len(words)*shareWordBits/8)
+	bit := 0
+	for _, word := range words {
+		index, ok := bip39.GetWordIndex(word)
+		if !ok {
+			return nil, fmt.Errorf("unknown word in share: %s", word)
+		}
+		for i := shareWordBits - 1; i >= 0; i-- {
+			if index>>i&1 == 1 && bit/8 < len(data) {
+				data[bit/8] |= 1 << (7 - bit%8)
+			}
+			bit++
+		}
+	}
+
+	if len(data) < shareHeaderSize {
+		return nil, fmt.Errorf("share is too short")
+	}
+
+	length := int(data[4])
+	total := shareHeaderSize + length + shareChecksumSize
+	if len(words) != shareWordCount(total) {
+		return nil, fmt.Errorf("share has %d words, expected %d", len(words), shareWordCount(total))
+	}
+
+	checksum := sha256.Sum256(data[:total-shareChecksumSize])
+	if string(checksum[:shareChecksumSize]) != string(data[total-shareChecksumSize:total]) {
+		return nil, fmt.Errorf("share checksum mismatch, check the words for typos")
+	}
+
+	share := &RecoveryShare{
+		ID:        binary.BigEndian.Uint16(data[0:2]),
+		Threshold: int(data[2]),
+		Index:     int(data[3]),
+		Value:     append([]byte(nil), data[shareHeaderSize:shareHeaderSize+length]...),
+	}
+	if share.Threshold < 2 || share.Index == 0 {
+		return nil, fmt.Errorf("share header is invalid")
+	}
+
+	return share, nil
+}
+
+// CombineRecoveryShares recovers the recovery phrase from share phrases
+func CombineRecoveryShares(shares []*RecoveryShare) (string, error) {
+	if len(shares) == 0 {
+		return "", fmt.Errorf("no shares provided")
+	}
+
+	first := shares[0]
+	parts := make([]crypto.Share, 0, len(shares))
+	for _, share := range shares {
+		if share.ID != first.ID {
+			return "", fmt.Errorf("shares belong to different splits")
+		}
+		if share.Threshold != first.Threshold {
+			return "", fmt.Errorf("shares have different thresholds")
+		}
+		parts = append(parts, crypto.Share{X: byte(share.Index), Y: share.Value})
+	}
+
+	if len(shares) < first.Threshold {
+		return "", fmt.Errorf("%d shares are needed, only %d provided", first.Threshold, len(shares))
+	}
+
+	entropy, err := crypto.CombineShares(parts)
+	if err != nil {
+		return "", err
+	}
+	defer crypto.ClearBytes(entropy)
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("failed to rebuild recovery phrase: %w", err)
+	}
+
+	return mnemonic, nil
+}
+
+// encodeRecoveryShare encodes a share as words from the BIP-39 word list
+func encodeRecoveryShare(share RecoveryShare) string {
+	data := []byte{
+		byte(share.ID >> 8), byte(share.ID),
+		byte(share.Threshold), byte(share.Index), byte(len(share.Value)),
+	}
+	data = append(data, share.Value...)
+	checksum := sha256.Sum256(data)
+	data = append(data, checksum[:shareChecksumSize]...)
+
+	wordList := bip39.GetWordList()
+	words := make([]string, shareWordCount(len(data)))
+	for w := range words {
+		index := 0
+		for i := 0; i < shareWordBits; i++ {
+			bit := w*shareWordBits + i
+			index <<= 1
+			if bit/8 < len(data) && data[bit/8]>>(7-bit%8)&1 == 1 {
+				index |= 1
+			}
+		}
+		words[w] = wordList[index]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// shareWordCount returns the number of words needed to encode size bytes
+func shareWordCount(size int) int {
+	return (size*8 + shareWordBits - 1) / shareWordBits
+}
@@ -0,0 +1,124 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxBalanceSnapshots limits how much history is kept on disk
+const maxBalanceSnapshots = 2000
+
+// snapshotInterval is the minimum time between two stored snapshots, a newer
+// snapshot within it replaces the previous one
+const snapshotInterval = time.Hour
+
+// SnapshotAsset is the balance and price of one asset at snapshot time
+type SnapshotAsset struct {
+	Symbol   string          `json:"symbol"`
+	Chain    string          `json:"chain"`
+	Amount   decimal.Decimal `json:"amount"`
+	PriceUSD float64         `json:"price_usd"`
+}
+
+// BalanceSnapshot records the portfolio at a point in time
+type BalanceSnapshot struct {
+	Time    time.Time       `json:"time"`
+	Network string          `json:"network"`
+	Assets  []SnapshotAsset `json:"assets"`
+}
+
+// snapshotsPath returns the location of the balance snapshot history
+func (m *Manager) snapshotsPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "snapshots.json")
+}
+
+// GetBalanceSnapshots returns the snapshots of a network, oldest first
+func (m *Manager) GetBalanceSnapshots(network string) ([]BalanceSnapshot, error) {
+	all, err := m.loadBalanceSnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []BalanceSnapshot
+	for _, snapshot := range all {
+		if snapshot.Network == network {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// SaveBalanceSnapshot stores a snapshot, replacing the latest one of the
+// same network if it was taken less than an hour earlier
+func (m *Manager) SaveBalanceSnapshot(entry BalanceSnapshot) error {
+	snapshots, err := m.loadBalanceSnapshots()
+	if err != nil {
+		return err
+	}
+
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].Network != entry.Network {
+			continue
+		}
+		if entry.Time.Sub(snapshots[i].Time) < snapshotInterval {
+			snapshots = append(snapshots[:i], snapshots[i+1:]...)
+		}
+		break
+	}
+	snapshots = append(snapshots, entry)
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+	if len(snapshots) > maxBalanceSnapshots {
+		snapshots = snapshots[len(snapshots)-maxBalanceSnapshots:]
+	}
+
+	return m.saveBalanceSnapshots(snapshots)
+}
+
+func (m *Manager) loadBalanceSnapshots() ([]BalanceSnapshot, error) {
+	data, err := os.ReadFile(m.snapshotsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read balance snapshots: %w", err)
+	}
+
+	var snapshots []BalanceSnapshot
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to parse balance snapshots: %w", err)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+
+	return snapshots, nil
+}
+
+// saveBalanceSnapshots writes the snapshot history to disk
+func (m *Manager) saveBalanceSnapshots(snapshots []BalanceSnapshot) error {
+	path := m.snapshotsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal balance snapshots: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write balance snapshots: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chinmay1088/odyssey/crypto"
+)
+
+// ErrInvalidTwoFactorCode is returned for a wrong or expired 2FA code
+var ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+
+// TwoFactorEnabled returns true if sends and key exports need a TOTP code.
+// Once unlocked it tells for the wallet unlocked, the main or the decoy one.
+func (m *Manager) TwoFactorEnabled() bool {
+	vault, err := m.loadVault()
+	if err != nil {
+		return false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Without the mnemonic only the main wallet's secret can be seen
+	if !m.unlocked {
+		return vault.TOTP != nil
+	}
+	return vault.TOTPSlot(m.mnemonic) != crypto.TOTPSlotNone
+}
+
+// EnableTwoFactor stores a TOTP secret in the vault after checking code
+// against it, so the authenticator app is known to be set up. The password
+// tells whether the unlocked wallet is the main or the decoy one, the secret
+// is stored in its slot only.
+func (m *Manager) EnableTwoFactor(password string, secret []byte, code string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.unlocked && !m.loadSession() {
+		return ErrLocked
+	}
+	if !crypto.ValidateTOTP(secret, strings.TrimSpace(code), time.Now()) {
+		return ErrInvalidTwoFactorCode
+	}
+
+	vault, err := m.loadVault()
+	if err != nil {
+		return err
+	}
+	if vault.TOTPSlot(m.mnemonic) != crypto.TOTPSlotNone {
+		return fmt.Errorf("two-factor authentication is already enabled")
+	}
+
+	mnemonic, err := vault.DecryptSecure(password)
+	if err != nil {
+		return fmt.Errorf("invalid password")
+	}
+	defer mnemonic.Destroy()
+	if !bytes.Equal(mnemonic.Bytes(), m.mnemonic.Bytes()) {
+		return fmt.Errorf("invalid password")
+	}
+
+	if vault.IsPrimaryPassword(password) {
+		err = vault.SealTOTPSecret(m.mnemonic, secret)
+	} else {
+		err = vault.SealDecoyTOTPSecret(m.mnemonic, secret)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to seal the two-factor secret: %w", err)
+	}
+	if err := m.saveVault(vault); err != nil {
+		return err
+	}
+	m.vault = vault
+	return nil
+}
+
+// DisableTwoFactor removes the TOTP secret of the unlocked wallet from the
+// vault, code must be valid. A decoy's secret is replaced with filler, the
+// main wallet's is kept.
+func (m *Manager) DisableTwoFactor(code string) error {
+	if err := m.VerifyTwoFactor(code); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.unlocked {
+		return ErrLocked
+	}
+	vault, err := m.loadVault()
+	if err != nil {
+		return err
+	}
+	switch vault.TOTPSlot(m.mnemonic) {
+	case crypto.TOTPSlotMain:
+		vault.TOTP = nil
+	case crypto.TOTPSlotDecoy:
+		if err := vault.ClearDecoyTOTPSecret(); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	if err := m.saveVault(vault); err != nil {
+		return err
+	}
+	m.vault = vault
+	return nil
+}
+
+// VerifyTwoFactor checks a TOTP code with the secret of the unlocked wallet.
+// It succeeds without a code if two-factor authentication isn't enabled.
+func (m *Manager) VerifyTwoFactor(code string) error {
+	vault, err := m.loadVault()
+	if err != nil {
+		return err
+	}
+
+	if err := m.rlockUnlocked(); err != nil {
+		// Without a secret in the vault, no wallet needs a code
+		if vault.TOTP == nil && vault.Slot2 == nil {
+			return nil
+		}
+		return err
+	}
+	defer m.mu.RUnlock()
+
+	if vault.TOTPSlot(m.mnemonic) == crypto.TOTPSlotNone {
+		return nil
+	}
+	secret, err := vault.OpenTOTPSecret(m.mnemonic)
+	if err != nil {
+		return err
+	}
+	defer crypto.ClearBytes(secret)
+
+	if !crypto.ValidateTOTP(secret, strings.TrimSpace(code), time.Now()) {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// WatchAddress is a watch-only address tracked without its private key
+type WatchAddress struct {
+	Label   string `json:"label"`
+	Chain   string `json:"chain"` // ethereum, bitcoin or solana
+	Address string `json:"address"`
+}
+
+// watchlistPath returns the location of the watch-only address list
+func (m *Manager) watchlistPath() string {
+	return filepath.Join(filepath.Dir(m.vaultPath), "watchlist.json")
+}
+
+// GetWatchAddresses returns all watch-only addresses sorted by label and chain
+func (m *Manager) GetWatchAddresses() ([]WatchAddress, error) {
+	data, err := os.ReadFile(m.watchlistPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read watchlist: %w", err)
+	}
+
+	var addresses []WatchAddress
+	if err := json.Unmarshal(data, &addresses); err != nil {
+		return nil, fmt.Errorf("failed to parse watchlist: %w", err)
+	}
+
+	sort.SliceStable(addresses, func(i, j int) bool {
+		if addresses[i].Label != addresses[j].Label {
+			return addresses[i].Label < addresses[j].Label
+		}
+		return addresses[i].Chain < addresses[j].Chain
+	})
+
+	return addresses, nil
+}
+
+// AddWatchAddress adds or replaces the address for a label on a chain
+func (m *Manager) AddWatchAddress(entry WatchAddress) error {
+	addresses, err := m.GetWatchAddresses()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, existing := range addresses {
+		if existing.Label == entry.Label && existing.Chain == entry.Chain {
+			addresses[i] = entry
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		addresses = append(addresses, entry)
+	}
+
+	return m.saveWatchAddresses(addresses)
+}
+
+// RemoveWatchAddress removes a label, or only its address on one chain when
+// chain is not empty. It returns the number of removed entries.
+func (m *Manager) RemoveWatchAddress(label, chain string) (int, error) {
+	addresses, err := m.GetWatchAddresses()
+	if err != nil {
+		return 0, err
+	}
+
+	kept := addresses[:0]
+	for _, existing := range addresses {
+		if existing.Label == label && (chain == "" || existing.Chain == chain) {
+			continue
+		}
+		kept = append(kept, existing)
+	}
+
+	removed := len(addresses) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	return removed, m.saveWatchAddresses(kept)
+}
+
+// saveWatchAddresses writes the watch-only address list to disk
+func (m *Manager) saveWatchAddresses(addresses []WatchAddress) error {
+	path := m.watchlistPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(addresses, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal watchlist: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write watchlist: %w", err)
+	}
+
+	return nil
+}
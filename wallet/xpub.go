@@ -0,0 +1,203 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chinmay1088/odyssey/config"
+	"github.com/chinmay1088/odyssey/crypto"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// SLIP-132 versions of extended public keys that tell other wallets the
+// address type. Taproot and legacy accounts use the plain xpub version.
+var (
+	ypubVersion = []byte{0x04, 0x9d, 0x7c, 0xb2} // nested SegWit
+	zpubVersion = []byte{0x04, 0xb2, 0x47, 0x46} // native SegWit
+)
+
+// AccountPublicKey is the extended public key of an account. It derives every
+// address of the account, but none of its private keys.
+type AccountPublicKey struct {
+	Chain       string // bitcoin or ethereum
+	Type        string // Bitcoin address type
+	Path        string // account derivation path, e.g. m/84'/0'/0'
+	Fingerprint uint32 // of the master key
+	Key         string // xpub, or ypub and zpub for SegWit Bitcoin accounts
+	XPub        string // the same key with the xpub version
+}
+
+// GetAccountPublicKey returns the extended public key of the Ethereum account
+// or the account of a Bitcoin address type. The first address it derives is
+// checked against the wallet's, so other wallets find the same addresses.
+func (m *Manager) GetAccountPublicKey(chain, addressType string) (*AccountPublicKey, error) {
+	var path string
+	switch chain {
+	case "ethereum":
+		path = strings.TrimSuffix(EthDerivationPath, "/0/0")
+		if m.network == NetworkTestnet {
+			path = strings.TrimSuffix(EthTestnetDerivationPath, "/0/0")
+		}
+	case "bitcoin":
+		// Bitcoin is only supported in mainnet and regtest
+		if !m.BitcoinSupported() {
+			return nil, fmt.Errorf("bitcoin is not supported in testnet mode")
+		}
+		path = strings.TrimSuffix(bitcoinAccountPath(addressType), "/0/0")
+	case "solana":
+		return nil, fmt.Errorf("solana keys are derived with hardened steps only, they have no extended public key")
+	default:
+		return nil, fmt.Errorf("unsupported chain: %s", chain)
+	}
+
+	if err := m.rlockUnlocked(); err != nil {
+		return nil, err
+	}
+	defer m.mu.RUnlock()
+
+	seedBuffer := crypto.MnemonicSeed(m.mnemonic)
+	defer seedBuffer.Destroy()
+	seed := seedBuffer.Bytes()
+
+	account, fingerprint, err := deriveAccountKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	xpub, err := account.Neuter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account public key: %w", err)
+	}
+
+	// The first receive address from the public key must be the wallet's
+	first, err := xpub.Derive(0)
+	if err == nil {
+		first, err = first.Derive(0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive from account public key: %w", err)
+	}
+	publicKey, err := first.ECPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive from account public key: %w", err)
+	}
+
+	key := xpub
+	var derived, expected string
+	if chain == "ethereum" {
+		derived = ethcrypto.PubkeyToAddress(*publicKey.ToECDSA()).Hex()
+		if expected, err = discoveryAddressFromSeed(seed, DiscoveryChain{Chain: chain}, path+"/0/0"); err != nil {
+			return nil, err
+		}
+	} else {
+		address, err := bitcoinAddress(addressType, publicKey)
+		if err != nil {
+			return nil, err
+		}
+		own, err := bitcoinAccountFromSeed(seed, addressType, path+"/0/0")
+		if err != nil {
+			return nil, err
+		}
+		derived, expected = address.String(), own.Address.String()
+
+		switch addressType {
+		case config.BitcoinAddressP2WPKH:
+			key, err = xpub.CloneWithVersion(zpubVersion)
+		case config.BitcoinAddressP2SHP2WPKH:
+			key, err = xpub.CloneWithVersion(ypubVersion)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode account public key: %w", err)
+		}
+	}
+	if derived != expected {
+		return nil, fmt.Errorf("the account public key derives %s instead of the wallet's address %s, other wallets would not find your funds", derived, expected)
+	}
+
+	return &AccountPublicKey{
+		Chain:       chain,
+		Type:        addressType,
+		Path:        path,
+		Fingerprint: fingerprint,
+		Key:         key.String(),
+		XPub:        xpub.String(),
+	}, nil
+}
+
+// Descriptor returns the output descriptor of the receive or change addresses
+// of a Bitcoin account, with its checksum
+func (k *AccountPublicKey) Descriptor(change bool) (string, error) {
+	branch := 0
+	if change {
+		branch = 1
+	}
+	origin := fmt.Sprintf("[%08x%s]", k.Fingerprint, strings.ReplaceAll(strings.TrimPrefix(k.Path, "m"), "'", "h"))
+	key := fmt.Sprintf("%s%s/%d/*", origin, k.XPub, branch)
+
+	var descriptor string
+	switch k.Type {
+	case config.BitcoinAddressP2TR:
+		descriptor = "tr(" + key + ")"
+	case config.BitcoinAddressP2SHP2WPKH:
+		descriptor = "sh(wpkh(" + key + "))"
+	case config.BitcoinAddressP2PKH:
+		descriptor = "pkh(" + key + ")"
+	default:
+		descriptor = "wpkh(" + key + ")"
+	}
+
+	checksum, err := descriptorChecksum(descriptor)
+	if err != nil {
+		return "", err
+	}
+	return descriptor + "#" + checksum, nil
+}
+
+// Characters of output descriptors and of their checksums, see BIP-380
+const (
+	descriptorInputCharset    = "0123456789()[],'/*abcdefgh@:$%{}IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// descriptorChecksum computes the 8 character checksum of an output
+// descriptor
+func descriptorChecksum(descriptor string) (string, error) {
+	c := uint64(1)
+	class, classCount := 0, 0
+	for _, r := range descriptor {
+		position := strings.IndexRune(descriptorInputCharset, r)
+		if position < 0 {
+			return "", fmt.Errorf("invalid character %q in descriptor", r)
+		}
+		c = descriptorPolymod(c, position&31)
+		class = class*3 + position>>5
+		if classCount++; classCount == 3 {
+			c = descriptorPolymod(c, class)
+			class, classCount = 0, 0
+		}
+	}
+	if classCount > 0 {
+		c = descriptorPolymod(c, class)
+	}
+	for i := 0; i < 8; i++ {
+		c = descriptorPolymod(c, 0)
+	}
+	c ^= 1
+
+	checksum := make([]byte, 8)
+	for i := range checksum {
+		checksum[i] = descriptorChecksumCharset[(c>>(5*(7-i)))&31]
+	}
+	return string(checksum), nil
+}
+
+// descriptorPolymod feeds a value into the descriptor checksum
+func descriptorPolymod(c uint64, value int) uint64 {
+	c0 := c >> 35
+	c = (c&0x7ffffffff)<<5 ^ uint64(value)
+	for i, generator := range []uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd} {
+		if c0>>i&1 != 0 {
+			c ^= generator
+		}
+	}
+	return c
+}